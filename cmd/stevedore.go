@@ -4,7 +4,10 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/credentialhelper"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/stevedore"
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
@@ -31,6 +34,11 @@ func main() {
 	}
 
 	stevedore := stevedore.NewCommand(context.Background(), fs, compatibility, compatibility, cons, log, conf)
+	// stevedore runs as a Docker credentials helper when it is invoked through a 'docker-credential-stevedore' link
+	if strings.HasPrefix(filepath.Base(os.Args[0]), credentialhelper.HelperBinaryPrefix) {
+		stevedore.Command.SetArgs(append([]string{credentialhelper.CommandName}, os.Args[1:]...))
+	}
+
 	err = stevedore.Execute()
 	if err != nil {
 		os.Exit(1)
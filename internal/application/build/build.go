@@ -8,9 +8,11 @@ import (
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
 	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler"
@@ -30,6 +32,10 @@ type Application struct {
 	dispatch       Dispatcher
 	semver         Semverser
 	credentials    repository.AuthFactorier
+	digestResolver DigestLookuper
+	immutableTags  ImmutableTagser
+	referenceNamer repository.ImageReferenceNamer
+	expiration     CredentialsExpirationWarner
 }
 
 // NewApplication creates a Service to build docker images
@@ -89,6 +95,34 @@ func WithCredentials(credentials repository.AuthFactorier) OptionsFunc {
 	}
 }
 
+// WithDigestResolver sets the component which looks up the images digest on the registry
+func WithDigestResolver(r DigestLookuper) OptionsFunc {
+	return func(a *Application) {
+		a.digestResolver = r
+	}
+}
+
+// WithImmutableTags sets the rules that define which tags can not be overwritten on the registry
+func WithImmutableTags(t ImmutableTagser) OptionsFunc {
+	return func(a *Application) {
+		a.immutableTags = t
+	}
+}
+
+// WithReferenceNamer sets the image reference namer
+func WithReferenceNamer(ref repository.ImageReferenceNamer) OptionsFunc {
+	return func(a *Application) {
+		a.referenceNamer = ref
+	}
+}
+
+// WithCredentialsExpirationWarner sets the component which warns about the registry credentials required by the build plan that are about to expire
+func WithCredentialsExpirationWarner(w CredentialsExpirationWarner) OptionsFunc {
+	return func(a *Application) {
+		a.expiration = w
+	}
+}
+
 // Options configure the service
 func (a *Application) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
@@ -122,6 +156,10 @@ func (a *Application) Build(ctx context.Context, buildPlan Planner, name string,
 	// configure service options before start build
 	a.Options(optionsFunc...)
 
+	if a.expiration != nil {
+		a.expiration.WarnExpiring(registryCredentialsIDs(steps, options)...)
+	}
+
 	// future promise which triggers the image build
 	buildWorkerFunc := func(ctx context.Context, step PlanSteper, options *Options) func() error {
 		var err error
@@ -307,37 +345,21 @@ func (a *Application) build(ctx context.Context, i *image.Image, options *Option
 	}
 
 	if i.Parent != nil && i.Parent.RegistryHost != "" && i.Parent.RegistryHost != image.UndefinedStringValue {
-		auth, err := a.getCredentials(i.Parent.RegistryHost)
+		pullAuth, err := a.getRegistryAuth(i.Parent.Repository())
 		if err != nil {
 			return errors.New(errContext, "", err)
 		}
 
-		if auth != nil {
-			pullAuth, isBasicAuth := auth.(*authmethodbasic.BasicAuthMethod)
-			if !isBasicAuth {
-				return errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic auth method", i.Parent.RegistryHost, auth.Name()))
-			}
-
-			buildOptions.PullAuthUsername = pullAuth.Username
-			buildOptions.PullAuthPassword = pullAuth.Password
-		}
+		setPullAuth(buildOptions, pullAuth)
 	}
 
 	if i.RegistryHost != image.UndefinedStringValue {
-		auth, err := a.getCredentials(i.RegistryHost)
+		pushAuth, err := a.getRegistryAuth(i.Repository())
 		if err != nil {
 			return errors.New(errContext, "", err)
 		}
 
-		if auth != nil {
-			pushAuth, isBasicAuth := auth.(*authmethodbasic.BasicAuthMethod)
-			if !isBasicAuth {
-				return errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic auth method", i.RegistryHost, auth.Name()))
-			}
-
-			buildOptions.PushAuthUsername = pushAuth.Username
-			buildOptions.PushAuthPassword = pushAuth.Password
-		}
+		setPushAuth(buildOptions, pushAuth)
 	}
 
 	imageBuilder, err := a.getBuilder(i)
@@ -374,6 +396,13 @@ func (a *Application) build(ctx context.Context, i *image.Image, options *Option
 		return errors.New(errContext, "", err)
 	}
 
+	if options.PushImageAfterBuild && !options.Force {
+		err = a.checkImmutableTags(ctx, i, buildOptions)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
 	cmd, err := a.command(driver, i, buildOptions)
 	if err != nil {
 		return errors.New(errContext, "", err)
@@ -395,6 +424,56 @@ func (a *Application) build(ctx context.Context, i *image.Image, options *Option
 	return nil
 }
 
+// checkImmutableTags fails when any of the image tags to push is immutable and it already exists on the registry. The digest of an image to be built is unknown, then any existing immutable tag is considered to be overwritten
+func (a *Application) checkImmutableTags(ctx context.Context, i *image.Image, options *image.BuildDriverOptions) error {
+
+	errContext := "(application::build::checkImmutableTags)"
+	existing := []string{}
+
+	if a.immutableTags == nil {
+		return nil
+	}
+
+	if a.referenceNamer == nil {
+		return errors.New(errContext, "To check the immutable tags, is required an image reference namer")
+	}
+
+	for _, tag := range append([]string{i.Version}, i.Tags...) {
+		taggedImage, err := image.NewImage(i.Name, tag, i.RegistryHost, i.RegistryNamespace)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		name, err := a.referenceNamer.GenerateName(taggedImage)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		if !a.immutableTags.IsImmutable(name) {
+			continue
+		}
+
+		if a.digestResolver == nil {
+			return errors.New(errContext, "To check the immutable tags, is required a digest resolver")
+		}
+
+		_, found, err := a.digestResolver.Lookup(ctx, name, options.PushAuthUsername, options.PushAuthPassword, options.PushAuthIdentityToken, options.PushAuthRegistryToken)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		if found {
+			existing = append(existing, name)
+		}
+	}
+
+	if len(existing) > 0 {
+		return errors.New(errContext, fmt.Sprintf("Immutable tag '%s' already exists and it would be overwritten by the build. Use force to overwrite it", strings.Join(existing, "', '")))
+	}
+
+	return nil
+}
+
 func (a *Application) job(ctx context.Context, cmd job.Commander) (scheduler.Jobber, error) {
 	errContext := "(application::build::job)"
 
@@ -435,11 +514,121 @@ func (a *Application) getCredentials(registry string) (repository.AuthMethodRead
 		return nil, errors.New(errContext, "To get credentials, is required a credentials store")
 	}
 
-	auth, _ := a.credentials.Get(registry)
+	auth, err := a.credentials.Get(registry)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials for '%s' could not be achieved", registry), err)
+	}
 
 	return auth, nil
 }
 
+// registryCredentialsIDs returns the repositories used to achieve the registry credentials to pull the parent images and push the images on the build plan. The options that overwrite the images attributes are applied as the build does, without modifying the images
+func registryCredentialsIDs(steps []*plan.Step, options *Options) []string {
+	ids := []string{}
+
+	overwrite := func(value, override string) string {
+		if override != image.UndefinedStringValue {
+			return override
+		}
+		return value
+	}
+
+	for _, step := range steps {
+		i := step.Image()
+		if i == nil {
+			continue
+		}
+
+		var parent *image.Image
+		if i.Parent != nil {
+			parent = &image.Image{
+				Name:              i.Parent.Name,
+				RegistryHost:      i.Parent.RegistryHost,
+				RegistryNamespace: i.Parent.RegistryNamespace,
+			}
+		}
+
+		if options.ImageFromName != image.UndefinedStringValue ||
+			options.ImageFromRegistryHost != image.UndefinedStringValue ||
+			options.ImageFromRegistryNamespace != image.UndefinedStringValue {
+			if parent == nil {
+				parent = &image.Image{}
+			}
+			parent.Name = overwrite(parent.Name, options.ImageFromName)
+			parent.RegistryHost = overwrite(parent.RegistryHost, options.ImageFromRegistryHost)
+			parent.RegistryNamespace = overwrite(parent.RegistryNamespace, options.ImageFromRegistryNamespace)
+		}
+
+		if parent != nil && parent.RegistryHost != "" && parent.RegistryHost != image.UndefinedStringValue {
+			ids = append(ids, parent.Repository())
+		}
+
+		target := &image.Image{
+			Name:              overwrite(i.Name, options.ImageName),
+			RegistryHost:      overwrite(i.RegistryHost, options.ImageRegistryHost),
+			RegistryNamespace: overwrite(i.RegistryNamespace, options.ImageRegistryNamespace),
+		}
+
+		if target.RegistryHost != image.UndefinedStringValue {
+			ids = append(ids, target.Repository())
+		}
+	}
+
+	return ids
+}
+
+// getRegistryAuth returns the auth method to authenticate to the registry, either a basic or a token auth method, or nil when there is no credential for it. Keyfile and SSH agent credentials are not used to authenticate to a registry but to forward SSH into the build, then they are ignored
+func (a *Application) getRegistryAuth(registry string) (repository.AuthMethodReader, error) {
+	errContext := "(application::build::getRegistryAuth)"
+
+	auth, err := a.getCredentials(registry)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if auth == nil {
+		return nil, nil
+	}
+
+	switch auth.Name() {
+	case credentials.KeyFileAuthMethod, credentials.SSHAgentAuthMethod:
+		return nil, nil
+	}
+
+	switch auth.(type) {
+	case *authmethodbasic.BasicAuthMethod, *authmethodtoken.TokenAuthMethod:
+		return auth, nil
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic or token auth method", registry, auth.Name()))
+	}
+}
+
+// setPullAuth sets the auth method to pull the parent image on the build options
+func setPullAuth(options *image.BuildDriverOptions, auth repository.AuthMethodReader) {
+	switch method := auth.(type) {
+	case *authmethodbasic.BasicAuthMethod:
+		options.PullAuthUsername = method.Username
+		options.PullAuthPassword = method.Password
+	case *authmethodtoken.TokenAuthMethod:
+		options.PullAuthUsername = method.Username
+		options.PullAuthIdentityToken = method.RefreshToken
+		options.PullAuthRegistryToken = method.Token
+	}
+}
+
+// setPushAuth sets the auth method to push the image on the build options
+func setPushAuth(options *image.BuildDriverOptions, auth repository.AuthMethodReader) {
+	switch method := auth.(type) {
+	case *authmethodbasic.BasicAuthMethod:
+		options.PushAuthUsername = method.Username
+		options.PushAuthPassword = method.Password
+	case *authmethodtoken.TokenAuthMethod:
+		options.PushAuthUsername = method.Username
+		options.PushAuthIdentityToken = method.RefreshToken
+		options.PushAuthRegistryToken = method.Token
+	}
+}
+
 func (a *Application) getDriver(builder *builder.Builder, options *Options) (repository.BuildDriverer, error) {
 	errContext := "(application::build::getDriver)"
 
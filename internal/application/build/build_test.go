@@ -9,13 +9,18 @@ import (
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/domain/varsmap"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/expiration"
 	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
 	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
 	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	authmethodsshagent "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/sshagent"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/mock"
 	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
+	"github.com/gostevedore/stevedore/internal/infrastructure/promote/registry"
+	defaultreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/default"
 	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/command"
 	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/dispatch"
 	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/job"
@@ -68,6 +73,7 @@ func TestBuild(t *testing.T) {
 				WithDispatch(dispatch.NewMockDispatch()),
 				WithSemver(semver.NewSemVerGenerator()),
 				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithCredentialsExpirationWarner(expiration.NewMockExpirationWarner()),
 			),
 			buildPlan: plan.NewMockPlan(),
 			name:      "parent",
@@ -93,6 +99,7 @@ func TestBuild(t *testing.T) {
 			err: &errors.Error{},
 			assertFunc: func(service *Application) bool {
 				return service.credentials.(*authfactory.MockAuthFactory).AssertExpectations(t) &&
+					service.expiration.(*expiration.MockExpirationWarner).AssertExpectations(t) &&
 					service.commandFactory.(*command.MockBuildCommandFactory).AssertExpectations(t) &&
 					service.dispatch.(*dispatch.MockDispatch).AssertExpectations(t) &&
 					service.jobFactory.(*job.MockJobFactory).AssertExpectations(t)
@@ -134,7 +141,12 @@ func TestBuild(t *testing.T) {
 					stepChild,
 				}, nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry").Return(&authmethodbasic.BasicAuthMethod{
+				service.expiration.(*expiration.MockExpirationWarner).On("WarnExpiring", []string{"registry/namespace/parent", "registry/namespace/child"})
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry/namespace/parent").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "username",
+					Password: "password",
+				}, nil)
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry/namespace/child").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
@@ -197,6 +209,87 @@ func TestBuild(t *testing.T) {
 	}
 }
 
+func TestRegistryCredentialsIDs(t *testing.T) {
+
+	undefinedOptions := func() *Options {
+		return &Options{
+			ImageFromName:              image.UndefinedStringValue,
+			ImageFromRegistryHost:      image.UndefinedStringValue,
+			ImageFromRegistryNamespace: image.UndefinedStringValue,
+			ImageFromVersion:           image.UndefinedStringValue,
+			ImageName:                  image.UndefinedStringValue,
+			ImageRegistryHost:          image.UndefinedStringValue,
+			ImageRegistryNamespace:     image.UndefinedStringValue,
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		steps   []*plan.Step
+		options *Options
+		res     []string
+	}{
+		{
+			desc: "Testing registry credentials ids of the images and their parents",
+			steps: []*plan.Step{
+				plan.NewStep(&image.Image{
+					Name:              "image",
+					RegistryHost:      "registry",
+					RegistryNamespace: "namespace",
+					Parent: &image.Image{
+						Name:              "parent",
+						RegistryHost:      "parent-registry",
+						RegistryNamespace: "library",
+					},
+				}, "image", nil),
+			},
+			options: undefinedOptions(),
+			res:     []string{"parent-registry/library/parent", "registry/namespace/image"},
+		},
+		{
+			desc: "Testing registry credentials ids skipping the images without registry host",
+			steps: []*plan.Step{
+				plan.NewStep(&image.Image{
+					Name:         "image",
+					RegistryHost: image.UndefinedStringValue,
+					Parent: &image.Image{
+						Name: "parent",
+					},
+				}, "image", nil),
+			},
+			options: undefinedOptions(),
+			res:     []string{},
+		},
+		{
+			desc: "Testing registry credentials ids applying the options that overwrite the images attributes",
+			steps: []*plan.Step{
+				plan.NewStep(&image.Image{
+					Name:              "image",
+					RegistryHost:      "registry",
+					RegistryNamespace: "namespace",
+				}, "image", nil),
+			},
+			options: func() *Options {
+				options := undefinedOptions()
+				options.ImageRegistryHost = "other-registry"
+				options.ImageFromName = "parent"
+				options.ImageFromRegistryHost = "parent-registry"
+				return options
+			}(),
+			res: []string{"parent-registry/parent", "other-registry/namespace/image"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := registryCredentialsIDs(test.steps, test.options)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
 func TestBuildWorker(t *testing.T) {
 
 	errContext := "(application::build::worker)"
@@ -332,12 +425,12 @@ func TestBuildWorker(t *testing.T) {
 				mockJob := job.NewMockJob()
 				mockJob.On("Wait").Return(nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry").Return(&authmethodbasic.BasicAuthMethod{
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "parent_registry").Return(&authmethodbasic.BasicAuthMethod{
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "parent_registry/parent_namespace/parent").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_parent",
 					Password: "password_parent",
 				}, nil)
@@ -390,7 +483,7 @@ func TestBuildWorker(t *testing.T) {
 			},
 		},
 		{
-			desc: "Testing error build when image credentials are invalid",
+			desc: "Testing build ignoring keyfile image credentials",
 			service: NewApplication(
 				WithBuilders(builders.NewMockStore()),
 				WithCommandFactory(command.NewMockBuildCommandFactory()),
@@ -448,22 +541,43 @@ func TestBuildWorker(t *testing.T) {
 					Labels:            map[string]string{"parentlabel": "value"},
 				},
 			},
-			err: errors.New(errContext, "Invalid credentials method for 'registry'. Found 'keyfile' when is expected basic auth method"),
+			err: &errors.Error{},
+			assertFunc: func(service *Application) bool {
+				return service.credentials.(*authfactory.MockAuthFactory).AssertExpectations(t) &&
+					service.commandFactory.(*command.MockBuildCommandFactory).AssertExpectations(t) &&
+					service.dispatch.(*dispatch.MockDispatch).AssertExpectations(t) &&
+					service.jobFactory.(*job.MockJobFactory).AssertExpectations(t)
+			},
 			prepareAssertFunc: func(service *Application, i *image.Image) {
 
 				mockJob := job.NewMockJob()
 				mockJob.On("Wait").Return(nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "parent_registry").Return(&authmethodbasic.BasicAuthMethod{
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "parent_registry/parent_namespace/parent").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry/namespace/image").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				service.commandFactory.(*command.MockBuildCommandFactory).On("New",
+					testmock.Anything,
+					testmock.Anything,
+					&image.BuildDriverOptions{
+						AnsibleIntermediateContainerName: "builder_mock_namespace_image_0.0.0",
+						BuilderOptions:                   &builder.BuilderOptions{},
+						BuilderVarMappings:               varsmap.New(),
+						PullAuthPassword:                 "password",
+						PullAuthUsername:                 "username",
+						PullParentImage:                  true,
+						PushImageAfterBuild:              true,
+						RemoveImageAfterBuild:            true,
+					}).Return(command.NewMockBuildCommand(), nil)
+				service.jobFactory.(*job.MockJobFactory).On("New", command.NewMockBuildCommand()).Return(mockJob, nil)
+				service.dispatch.(*dispatch.MockDispatch).On("Enqueue", mockJob)
 			},
 		},
 		{
-			desc: "Testing error build when parent credentials are invalid",
+			desc: "Testing build ignoring keyfile parent credentials",
 			service: NewApplication(
 				WithBuilders(builders.NewMockStore()),
 				WithCommandFactory(command.NewMockBuildCommandFactory()),
@@ -521,18 +635,39 @@ func TestBuildWorker(t *testing.T) {
 					Labels:            map[string]string{"parentlabel": "value"},
 				},
 			},
-			err: errors.New(errContext, "Invalid credentials method for 'parent_registry'. Found 'keyfile' when is expected basic auth method"),
+			err: &errors.Error{},
+			assertFunc: func(service *Application) bool {
+				return service.credentials.(*authfactory.MockAuthFactory).AssertExpectations(t) &&
+					service.commandFactory.(*command.MockBuildCommandFactory).AssertExpectations(t) &&
+					service.dispatch.(*dispatch.MockDispatch).AssertExpectations(t) &&
+					service.jobFactory.(*job.MockJobFactory).AssertExpectations(t)
+			},
 			prepareAssertFunc: func(service *Application, i *image.Image) {
 
 				mockJob := job.NewMockJob()
 				mockJob.On("Wait").Return(nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry").Return(&authmethodbasic.BasicAuthMethod{
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
 
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "parent_registry").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "parent_registry/parent_namespace/parent").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				service.commandFactory.(*command.MockBuildCommandFactory).On("New",
+					testmock.Anything,
+					testmock.Anything,
+					&image.BuildDriverOptions{
+						AnsibleIntermediateContainerName: "builder_mock_namespace_image_0.0.0",
+						BuilderOptions:                   &builder.BuilderOptions{},
+						BuilderVarMappings:               varsmap.New(),
+						PushAuthPassword:                 "password",
+						PushAuthUsername:                 "username",
+						PullParentImage:                  true,
+						PushImageAfterBuild:              true,
+						RemoveImageAfterBuild:            true,
+					}).Return(command.NewMockBuildCommand(), nil)
+				service.jobFactory.(*job.MockJobFactory).On("New", command.NewMockBuildCommand()).Return(mockJob, nil)
+				service.dispatch.(*dispatch.MockDispatch).On("Enqueue", mockJob)
 			},
 		},
 	}
@@ -892,9 +1027,23 @@ func TestGetCredentials(t *testing.T) {
 			registry: "registry.test",
 			res:      nil,
 			prepareAssertFunc: func(service *Application) {
-				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(nil, errors.New(errContext, "Credentials not found"))
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(nil, nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error getting credentials",
+			service: NewApplication(
+				WithCredentials(
+					authfactory.NewMockAuthFactory(),
+				),
+			),
+			registry: "registry.test",
+			prepareAssertFunc: func(service *Application) {
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(nil, errors.New(errContext, "AWS ECR authorization token could not be achieved"))
 			},
-			err: errors.New(errContext, "Credentials not found"),
+			err: errors.New(errContext, "Credentials for 'registry.test' could not be achieved",
+				errors.New(errContext, "AWS ECR authorization token could not be achieved")),
 		},
 	}
 
@@ -916,6 +1065,195 @@ func TestGetCredentials(t *testing.T) {
 	}
 }
 
+func TestGetRegistryAuth(t *testing.T) {
+
+	errContext := "(application::build::getRegistryAuth)"
+
+	tests := []struct {
+		desc              string
+		service           *Application
+		registry          string
+		res               repository.AuthMethodReader
+		err               error
+		prepareAssertFunc func(*Application)
+	}{
+		{
+			desc:    "Testing error getting registry auth when credentials store is nil",
+			service: NewApplication(),
+			err:     errors.New(errContext, "", errors.New("(application::build::getCredentials)", "To get credentials, is required a credentials store")),
+		},
+		{
+			desc: "Testing get registry basic auth",
+			service: NewApplication(
+				WithCredentials(
+					authfactory.NewMockAuthFactory(),
+				),
+			),
+			registry: "registry.test",
+			res: &authmethodbasic.BasicAuthMethod{
+				Username: "username",
+				Password: "password",
+			},
+			prepareAssertFunc: func(service *Application) {
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "username",
+					Password: "password",
+				}, nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get registry token auth",
+			service: NewApplication(
+				WithCredentials(
+					authfactory.NewMockAuthFactory(),
+				),
+			),
+			registry: "registry.test",
+			res: &authmethodtoken.TokenAuthMethod{
+				Username: "robot$project",
+				Token:    "token",
+			},
+			prepareAssertFunc: func(service *Application) {
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodtoken.TokenAuthMethod{
+					Username: "robot$project",
+					Token:    "token",
+				}, nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get registry auth ignoring keyfile credentials",
+			service: NewApplication(
+				WithCredentials(
+					authfactory.NewMockAuthFactory(),
+				),
+			),
+			registry: "registry.test",
+			res:      nil,
+			prepareAssertFunc: func(service *Application) {
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodkeyfile.KeyFileAuthMethod{
+					PrivateKeyFile: "id_rsa",
+				}, nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get registry auth ignoring ssh agent credentials",
+			service: NewApplication(
+				WithCredentials(
+					authfactory.NewMockAuthFactory(),
+				),
+			),
+			registry: "registry.test",
+			res:      nil,
+			prepareAssertFunc: func(service *Application) {
+				service.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodsshagent.SSHAgentAuthMethod{}, nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.service)
+			}
+
+			auth, err := test.service.getRegistryAuth(test.registry)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, auth)
+			}
+		})
+	}
+}
+
+func TestCheckImmutableTags(t *testing.T) {
+
+	errContext := "(application::build::checkImmutableTags)"
+
+	immutableTags, _ := image.NewImmutableTags([]string{"stable/*"}, []string{"^latest$"})
+
+	i := &image.Image{
+		Name:              "image",
+		Version:           "1.2.3",
+		RegistryHost:      "registry.test",
+		RegistryNamespace: "stable",
+		Tags:              []string{"latest"},
+	}
+
+	options := &image.BuildDriverOptions{
+		PushAuthUsername: "username",
+		PushAuthPassword: "password",
+	}
+
+	tests := []struct {
+		desc              string
+		service           *Application
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc:    "Testing check immutable tags when no immutable tags are defined",
+			service: NewApplication(),
+			err:     &errors.Error{},
+		},
+		{
+			desc:    "Testing error checking immutable tags without a reference namer",
+			service: NewApplication(WithImmutableTags(immutableTags)),
+			err:     errors.New(errContext, "To check the immutable tags, is required an image reference namer"),
+		},
+		{
+			desc: "Testing check immutable tags when the immutable tag does not exist",
+			service: NewApplication(
+				WithImmutableTags(immutableTags),
+				WithReferenceNamer(defaultreferencename.NewDefaultReferenceName()),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Lookup", context.TODO(), "registry.test/stable/image:1.2.3", "username", "password", "", "").Return("", false, nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error checking immutable tags when the immutable tag already exists",
+			service: NewApplication(
+				WithImmutableTags(immutableTags),
+				WithReferenceNamer(defaultreferencename.NewDefaultReferenceName()),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Lookup", context.TODO(), "registry.test/stable/image:1.2.3", "username", "password", "", "").Return("sha256:digest", true, nil)
+			},
+			err: errors.New(errContext, "Immutable tag 'registry.test/stable/image:1.2.3' already exists and it would be overwritten by the build. Use force to overwrite it"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.service)
+			}
+
+			err := test.service.checkImmutableTags(context.TODO(), i, options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				if test.service.digestResolver != nil {
+					test.service.digestResolver.(*registry.MockDigestResolver).AssertExpectations(t)
+				}
+			}
+		})
+	}
+}
+
 func TestGetDriver(t *testing.T) {
 	errContext := "(application::build::getDriver)"
 
@@ -1,6 +1,8 @@
 package build
 
 import (
+	"context"
+
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	driverfactory "github.com/gostevedore/stevedore/internal/infrastructure/driver/factory"
@@ -48,6 +50,21 @@ type Semverser interface {
 	GenerateSemverList(version []string, tmpls []string) ([]string, error)
 }
 
+// DigestLookuper interface defines the component which looks up the manifest digest of an image stored on a registry
+type DigestLookuper interface {
+	Lookup(ctx context.Context, name, username, password, identityToken, registryToken string) (string, bool, error)
+}
+
+// CredentialsExpirationWarner interface defines the component which warns about the registry credentials that are about to expire
+type CredentialsExpirationWarner interface {
+	WarnExpiring(ids ...string)
+}
+
+// ImmutableTagser interface defines the rules to decide whether an image tag can not be overwritten
+type ImmutableTagser interface {
+	IsImmutable(name string) bool
+}
+
 // // CredentialsStorer
 // type CredentialsStorer interface {
 // 	Get(id string) (*credentials.UserPasswordAuth, error)
@@ -12,6 +12,8 @@ type Options struct {
 	AnsibleLimit string
 	// EnableSemanticVersionTags is a flag to enable semantic version tags
 	EnableSemanticVersionTags bool
+	// Force flag overwrites the image tags on the registry even when they are immutable
+	Force bool
 	// ImageFromName is the parent's image name
 	ImageFromName string `yaml:"image_from_name"`
 	// ImageFromRegistryHost is the parent's image registry host
@@ -66,6 +68,7 @@ func (o *Options) Copy() *Options {
 	copy.ImageFromRegistryHost = o.ImageFromRegistryHost
 	copy.ImageFromVersion = o.ImageFromVersion
 
+	copy.Force = o.Force
 	copy.PushImageAfterBuild = o.PushImageAfterBuild
 	copy.RemoveImagesAfterPush = o.RemoveImagesAfterPush
 	copy.AnsibleConnectionLocal = o.AnsibleConnectionLocal
@@ -0,0 +1,187 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to check the credentials against the registries
+type Application struct {
+	credentials repository.AuthFactorier
+	store       repository.CredentialsLister
+	checker     repository.RegistryChecker
+	output      repository.CredentialsCheckPrinter
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentials provides a function to configure the auth factory that resolves the credentials
+func WithCredentials(credentials repository.AuthFactorier) OptionsFunc {
+	return func(a *Application) {
+		a.credentials = credentials
+	}
+}
+
+// WithStore provides a function to configure the credentials store used to list the credentials to check when no id is provided
+func WithStore(store repository.CredentialsLister) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// WithChecker provides a function to configure the registry checker
+func WithChecker(checker repository.RegistryChecker) OptionsFunc {
+	return func(a *Application) {
+		a.checker = checker
+	}
+}
+
+// WithOutput provides a function to configure the output where the results are printed
+func WithOutput(output repository.CredentialsCheckPrinter) OptionsFunc {
+	return func(a *Application) {
+		a.output = output
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run checks the access to the registry of each credentials id, or of all the stored credentials when no id is provided, and prints the results. It returns an error when any check fails
+func (a *Application) Run(ctx context.Context, ids []string, options *Options, optionsFunc ...OptionsFunc) error {
+
+	errContext := "(application::check::credentials::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.credentials == nil {
+		return errors.New(errContext, "To run the check credentials application, an auth factory must be provided")
+	}
+
+	if a.checker == nil {
+		return errors.New(errContext, "To run the check credentials application, a registry checker must be provided")
+	}
+
+	if a.output == nil {
+		return errors.New(errContext, "To run the check credentials application, an output must be provided")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "To run the check credentials application, options must be provided")
+	}
+
+	if len(ids) == 0 {
+		if a.store == nil {
+			return errors.New(errContext, "To check all the credentials, a credentials store that lists its credentials must be provided")
+		}
+
+		all, err := a.store.All()
+		if err != nil {
+			return errors.New(errContext, "Error listing the credentials to check", err)
+		}
+
+		for _, credential := range all {
+			if credential != nil {
+				ids = append(ids, credential.ID)
+			}
+		}
+	}
+
+	results := make([]*credentials.CheckResult, 0, len(ids))
+	failed := []string{}
+
+	for _, id := range ids {
+		result, err := a.check(ctx, id, options)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		results = append(results, result)
+		if result.Failed() {
+			failed = append(failed, id)
+		}
+	}
+
+	err := a.output.Print(results)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if len(failed) > 0 {
+		return errors.New(errContext, fmt.Sprintf("Credentials check failed for: %s", strings.Join(failed, ", ")))
+	}
+
+	return nil
+}
+
+// check returns the result of checking the access to the registry of a credentials id
+func (a *Application) check(ctx context.Context, id string, options *Options) (*credentials.CheckResult, error) {
+
+	errContext := "(application::check::credentials::check)"
+
+	registry := credentials.ServerHost(id)
+	result := &credentials.CheckResult{
+		ID:       id,
+		Registry: registry,
+	}
+
+	if credentials.IsIDPattern(id) {
+		result.Status = credentials.CheckStatusSkipped
+		result.Message = "Credentials defined by a pattern can not be checked. Check them using a registry that matches the pattern"
+		return result, nil
+	}
+
+	auth, err := a.credentials.Get(id)
+	if err != nil {
+		result.Status = credentials.CheckStatusError
+		result.Message = fmt.Sprintf("Credentials could not be achieved. %s", err.Error())
+		return result, nil
+	}
+
+	if auth == nil {
+		result.Status = credentials.CheckStatusError
+		result.Message = "Credentials not found"
+		return result, nil
+	}
+
+	if auth.Name() == credentials.TokenAuthMethod {
+		result.Status = credentials.CheckStatusSkipped
+		result.Message = fmt.Sprintf("Checking credentials using the '%s' auth method is not supported", auth.Name())
+		return result, nil
+	}
+
+	basicAuth, isBasicAuth := auth.(*authmethodbasic.BasicAuthMethod)
+	if !isBasicAuth {
+		result.Status = credentials.CheckStatusSkipped
+		result.Message = fmt.Sprintf("Credentials using the '%s' auth method are not used to authenticate to registries", auth.Name())
+		return result, nil
+	}
+
+	checked, err := a.checker.Check(ctx, registry, basicAuth.Username, basicAuth.Password, options.Repository, options.Scope)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error checking the credentials '%s'", id), err)
+	}
+
+	checked.ID = id
+
+	return checked, nil
+}
@@ -0,0 +1,197 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	registrychecker "github.com/gostevedore/stevedore/internal/infrastructure/check/registry"
+	output "github.com/gostevedore/stevedore/internal/infrastructure/output/credentialscheck"
+	mockstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	errContext := "(application::check::credentials::Run)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		ids               []string
+		options           *Options
+		prepareAssertFunc func(*Application)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc: "Testing error running check credentials application without auth factory",
+			app: NewApplication(
+				WithChecker(registrychecker.NewMockRegistryChecker()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: &Options{},
+			err:     errors.New(errContext, "To run the check credentials application, an auth factory must be provided"),
+		},
+		{
+			desc: "Testing error checking all the credentials without a credentials store",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithChecker(registrychecker.NewMockRegistryChecker()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: &Options{},
+			err:     errors.New(errContext, "To check all the credentials, a credentials store that lists its credentials must be provided"),
+		},
+		{
+			desc: "Testing check credentials",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithChecker(registrychecker.NewMockRegistryChecker()),
+				WithOutput(output.NewMockOutput()),
+			),
+			ids: []string{"registry.test/team"},
+			options: &Options{
+				Repository: "team/app",
+				Scope:      credentials.CheckScopePush,
+			},
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/team").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "user",
+					Password: "pass",
+				}, nil)
+				a.checker.(*registrychecker.MockRegistryChecker).On("Check", context.TODO(), "registry.test", "user", "pass", "team/app", credentials.CheckScopePush).Return(&credentials.CheckResult{
+					Registry:   "registry.test",
+					Repository: "team/app",
+					Scope:      credentials.CheckScopePush,
+					Status:     credentials.CheckStatusOK,
+				}, nil)
+				a.output.(*output.MockOutput).On("Print", []*credentials.CheckResult{
+					{
+						ID:         "registry.test/team",
+						Registry:   "registry.test",
+						Repository: "team/app",
+						Scope:      credentials.CheckScopePush,
+						Status:     credentials.CheckStatusOK,
+					},
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.checker.(*registrychecker.MockRegistryChecker).AssertExpectations(t)
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+		},
+		{
+			desc: "Testing check all the stored credentials",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithStore(mockstore.NewMockStore()),
+				WithChecker(registrychecker.NewMockRegistryChecker()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: &Options{},
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mockstore.MockStore).On("All").Return([]*credentials.Credential{
+					{ID: "registry.test"},
+					{ID: "*.registry.test"},
+					{ID: "git.test"},
+				}, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "user",
+					Password: "pass",
+				}, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "git.test").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				a.checker.(*registrychecker.MockRegistryChecker).On("Check", context.TODO(), "registry.test", "user", "pass", "", "").Return(&credentials.CheckResult{
+					Registry: "registry.test",
+					Status:   credentials.CheckStatusOK,
+				}, nil)
+				a.output.(*output.MockOutput).On("Print", []*credentials.CheckResult{
+					{
+						ID:       "registry.test",
+						Registry: "registry.test",
+						Status:   credentials.CheckStatusOK,
+					},
+					{
+						ID:       "*.registry.test",
+						Registry: "*.registry.test",
+						Status:   credentials.CheckStatusSkipped,
+						Message:  "Credentials defined by a pattern can not be checked. Check them using a registry that matches the pattern",
+					},
+					{
+						ID:       "git.test",
+						Registry: "git.test",
+						Status:   credentials.CheckStatusSkipped,
+						Message:  "Credentials using the 'keyfile' auth method are not used to authenticate to registries",
+					},
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+		},
+		{
+			desc: "Testing error when a credentials check fails",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithChecker(registrychecker.NewMockRegistryChecker()),
+				WithOutput(output.NewMockOutput()),
+			),
+			ids:     []string{"registry.test", "unknown.test"},
+			options: &Options{},
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "user",
+					Password: "expired",
+				}, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "unknown.test").Return(nil, nil)
+				a.checker.(*registrychecker.MockRegistryChecker).On("Check", context.TODO(), "registry.test", "user", "expired", "", "").Return(&credentials.CheckResult{
+					Registry: "registry.test",
+					Status:   credentials.CheckStatusExpired,
+					Message:  "Registry 'registry.test' rejected the credentials. The credentials are expired",
+				}, nil)
+				a.output.(*output.MockOutput).On("Print", []*credentials.CheckResult{
+					{
+						ID:       "registry.test",
+						Registry: "registry.test",
+						Status:   credentials.CheckStatusExpired,
+						Message:  "Registry 'registry.test' rejected the credentials. The credentials are expired",
+					},
+					{
+						ID:       "unknown.test",
+						Registry: "unknown.test",
+						Status:   credentials.CheckStatusError,
+						Message:  "Credentials not found",
+					},
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+			err: errors.New(errContext, "Credentials check failed for: registry.test, unknown.test"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Run(context.TODO(), test.ids, test.options)
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+
+			if test.assertFunc != nil {
+				test.assertFunc(t, test.app)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of check credentials application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of check credentials application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, ids, options, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, ids []string, options *Options, optionsFunc ...OptionsFunc) error {
+	args := m.Called(ctx, ids, options, optionsFunc)
+	return args.Error(0)
+}
@@ -0,0 +1,225 @@
+package credentialhelper
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
+)
+
+const (
+	// CredentialsNotFoundMessage is the message that the Docker credentials helpers protocol expects when there are no credentials for a server
+	CredentialsNotFoundMessage = "credentials not found in native keychain"
+	// IdentityTokenUsername is the username used on the Docker credentials helpers protocol to state that the secret is an identity token
+	IdentityTokenUsername = "<token>"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service that serves the credentials managed by stevedore through the Docker credentials helpers protocol
+type Application struct {
+	credentials repository.AuthFactorier
+	store       repository.CredentialsStorer
+	output      CredentialsHelperPrinter
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentials sets the auth factory used to resolve the credentials
+func WithCredentials(credentials repository.AuthFactorier) OptionsFunc {
+	return func(a *Application) {
+		a.credentials = credentials
+	}
+}
+
+// WithStore sets the credentials store
+func WithStore(store repository.CredentialsStorer) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// WithOutput sets the output to print the responses
+func WithOutput(output CredentialsHelperPrinter) OptionsFunc {
+	return func(a *Application) {
+		a.output = output
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Get prints the username and secret for the server. The credentials are resolved through the auth factory, so the AWS ECR credentials are exchanged for a registry token. Refresh tokens are served as identity tokens
+func (a *Application) Get(ctx context.Context, serverURL string) error {
+
+	errContext := "(application::credentialhelper::Get)"
+
+	if a.credentials == nil {
+		return errors.New(errContext, "To get credentials, an auth factory must be provided")
+	}
+
+	if a.output == nil {
+		return errors.New(errContext, "To get credentials, an output must be provided")
+	}
+
+	if serverURL == "" {
+		return errors.New(errContext, "To get credentials, a server URL must be provided")
+	}
+
+	host := credentials.ServerHost(serverURL)
+
+	auth, err := a.credentials.Get(host)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if auth == nil {
+		return errors.New(errContext, CredentialsNotFoundMessage)
+	}
+
+	var username, secret string
+
+	switch method := auth.(type) {
+	case *authmethodbasic.BasicAuthMethod:
+		username = method.Username
+		secret = method.Password
+	case *authmethodtoken.TokenAuthMethod:
+		// the Docker credentials helpers protocol can only serve the refresh tokens, as identity tokens
+		if method.RefreshToken == "" {
+			return errors.New(errContext, fmt.Sprintf("Credentials for '%s' define a registry token, which can not be served through the Docker credentials helpers protocol", host))
+		}
+		username = IdentityTokenUsername
+		secret = method.RefreshToken
+	default:
+		return errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic or token auth method", host, auth.Name()))
+	}
+
+	err = a.output.PrintCredentials(serverURL, username, secret)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// Store saves the username and secret for the server. When the username is '<token>', the secret is an identity token and it is saved as a refresh token
+func (a *Application) Store(ctx context.Context, serverURL, username, secret string) error {
+
+	errContext := "(application::credentialhelper::Store)"
+
+	if a.store == nil {
+		return errors.New(errContext, "To store credentials, a credentials store must be provided")
+	}
+
+	if serverURL == "" {
+		return errors.New(errContext, "To store credentials, a server URL must be provided")
+	}
+
+	host := credentials.ServerHost(serverURL)
+	credential := &credentials.Credential{
+		ID:       host,
+		Username: username,
+		Password: secret,
+	}
+
+	if username == IdentityTokenUsername {
+		credential = &credentials.Credential{
+			ID:           host,
+			RefreshToken: secret,
+		}
+	}
+
+	_, err := credential.IsValid()
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = a.store.Store(host, credential)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error storing '%s' credentials", host), err)
+	}
+
+	return nil
+}
+
+// Erase removes the credentials for the server. It requires a credentials store that can remove credentials
+func (a *Application) Erase(ctx context.Context, serverURL string) error {
+
+	errContext := "(application::credentialhelper::Erase)"
+
+	if a.store == nil {
+		return errors.New(errContext, "To erase credentials, a credentials store must be provided")
+	}
+
+	if serverURL == "" {
+		return errors.New(errContext, "To erase credentials, a server URL must be provided")
+	}
+
+	eraser, isEraser := a.store.(CredentialsEraser)
+	if !isEraser {
+		return errors.New(errContext, "Credentials store does not support to erase credentials")
+	}
+
+	host := credentials.ServerHost(serverURL)
+	err := eraser.Delete(host)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error erasing '%s' credentials", host), err)
+	}
+
+	return nil
+}
+
+// List prints the server and username of all the credentials. The credentials without username, such as the AWS ECR ones, are listed with an empty username
+func (a *Application) List(ctx context.Context) error {
+
+	errContext := "(application::credentialhelper::List)"
+
+	if a.store == nil {
+		return errors.New(errContext, "To list credentials, a credentials store must be provided")
+	}
+
+	if a.output == nil {
+		return errors.New(errContext, "To list credentials, an output must be provided")
+	}
+
+	list := map[string]string{}
+
+	lister, isLister := a.store.(CredentialsLister)
+	if isLister {
+		all, err := lister.All()
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		for _, credential := range all {
+			if credential == nil || credential.ID == "" {
+				continue
+			}
+
+			list[credential.ID] = credential.Username
+		}
+	}
+
+	err := a.output.PrintList(list)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,344 @@
+package credentialhelper
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
+	output "github.com/gostevedore/stevedore/internal/infrastructure/output/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	errContext := "(application::credentialhelper::Get)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		serverURL         string
+		prepareAssertFunc func(*Application)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc: "Testing error getting credentials without auth factory",
+			app: NewApplication(
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "registry.example.com",
+			err:       errors.New(errContext, "To get credentials, an auth factory must be provided"),
+		},
+		{
+			desc: "Testing error getting credentials without server URL",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			err: errors.New(errContext, "To get credentials, a server URL must be provided"),
+		},
+		{
+			desc: "Testing get credentials for a server URL",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "https://registry.example.com/v2/",
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.example.com").Return(
+					&authmethodbasic.BasicAuthMethod{
+						Username: "username",
+						Password: "password",
+					}, nil)
+				a.output.(*output.MockOutput).On("PrintCredentials", "https://registry.example.com/v2/", "username", "password").Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).AssertExpectations(t)
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+		},
+		{
+			desc: "Testing get refresh token credentials as an identity token",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.example.com").Return(
+					&authmethodtoken.TokenAuthMethod{
+						RefreshToken: "refresh-token",
+					}, nil)
+				a.output.(*output.MockOutput).On("PrintCredentials", "registry.example.com", IdentityTokenUsername, "refresh-token").Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+		},
+		{
+			desc: "Testing error getting registry token credentials",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.example.com").Return(
+					&authmethodtoken.TokenAuthMethod{
+						Token: "token",
+					}, nil)
+			},
+			err: errors.New(errContext, "Credentials for 'registry.example.com' define a registry token, which can not be served through the Docker credentials helpers protocol"),
+		},
+		{
+			desc: "Testing error getting credentials not found",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.example.com").Return(nil, nil)
+			},
+			err: errors.New(errContext, CredentialsNotFoundMessage),
+		},
+		{
+			desc: "Testing error getting credentials when the credentials store fails",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.example.com").Return(nil, errors.New("", "credentials store can not be read"))
+			},
+			err: errors.New(errContext, "",
+				errors.New("", "credentials store can not be read")),
+		},
+		{
+			desc: "Testing error getting credentials that are not a basic auth method",
+			app: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithOutput(output.NewMockOutput()),
+			),
+			serverURL: "registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.example.com").Return(authmethodkeyfile.NewKeyFileAuthMethod(), nil)
+			},
+			err: errors.New(errContext, "Invalid credentials method for 'registry.example.com'. Found 'keyfile' when is expected basic or token auth method"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Get(context.TODO(), test.serverURL)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.app)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	errContext := "(application::credentialhelper::Store)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		serverURL         string
+		username          string
+		secret            string
+		prepareAssertFunc func(*Application)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc:      "Testing error storing credentials without store",
+			app:       NewApplication(),
+			serverURL: "registry.example.com",
+			err:       errors.New(errContext, "To store credentials, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing store credentials for a server URL",
+			app: NewApplication(
+				WithStore(mock.NewMockStore()),
+			),
+			serverURL: "https://index.docker.io/v1/",
+			username:  "username",
+			secret:    "secret",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Store", "docker.io", &credentials.Credential{
+					ID:       "docker.io",
+					Username: "username",
+					Password: "secret",
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.store.(*mock.MockStore).AssertExpectations(t)
+			},
+		},
+		{
+			desc: "Testing store an identity token as a refresh token",
+			app: NewApplication(
+				WithStore(mock.NewMockStore()),
+			),
+			serverURL: "registry.example.com",
+			username:  IdentityTokenUsername,
+			secret:    "refresh-token",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Store", "registry.example.com", &credentials.Credential{
+					ID:           "registry.example.com",
+					RefreshToken: "refresh-token",
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.store.(*mock.MockStore).AssertExpectations(t)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Store(context.TODO(), test.serverURL, test.username, test.secret)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.app)
+			}
+		})
+	}
+}
+
+func TestErase(t *testing.T) {
+	errContext := "(application::credentialhelper::Erase)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		serverURL         string
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc:      "Testing error erasing credentials without store",
+			app:       NewApplication(),
+			serverURL: "registry.example.com",
+			err:       errors.New(errContext, "To erase credentials, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing erase credentials",
+			app: NewApplication(
+				WithStore(mock.NewMockStore()),
+			),
+			serverURL: "https://registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Delete", "registry.example.com").Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error erasing credentials when store fails to remove them",
+			app: NewApplication(
+				WithStore(mock.NewMockStore()),
+			),
+			serverURL: "registry.example.com",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Delete", "registry.example.com").Return(errors.New("", "error deleting"))
+			},
+			err: errors.New(errContext, "Error erasing 'registry.example.com' credentials", errors.New("", "error deleting")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Erase(context.TODO(), test.serverURL)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.app.store.(*mock.MockStore).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		desc              string
+		app               *Application
+		prepareAssertFunc func(*Application)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc: "Testing list credentials",
+			app: NewApplication(
+				WithStore(mock.NewMockStore()),
+				WithOutput(output.NewMockOutput()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("All").Return([]*credentials.Credential{
+					{
+						ID:       "registry.example.com",
+						Username: "username",
+						Password: "password",
+					},
+					{
+						ID:                 "*.dkr.ecr.eu-west-1.amazonaws.com",
+						AWSAccessKeyID:     "key",
+						AWSSecretAccessKey: "secret",
+					},
+				}, nil)
+				a.output.(*output.MockOutput).On("PrintList", map[string]string{
+					"registry.example.com":              "username",
+					"*.dkr.ecr.eu-west-1.amazonaws.com": "",
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.store.(*mock.MockStore).AssertExpectations(t)
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.List(context.TODO())
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.app)
+			}
+		})
+	}
+}
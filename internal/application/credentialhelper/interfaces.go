@@ -0,0 +1,19 @@
+package credentialhelper
+
+import "github.com/gostevedore/stevedore/internal/core/domain/credentials"
+
+// CredentialsLister is the interface of the credentials stores that can list all their credentials
+type CredentialsLister interface {
+	All() ([]*credentials.Credential, error)
+}
+
+// CredentialsEraser is the interface of the credentials stores that can remove credentials
+type CredentialsEraser interface {
+	Delete(id string) error
+}
+
+// CredentialsHelperPrinter is the interface to print the credentials helper responses
+type CredentialsHelperPrinter interface {
+	PrintCredentials(serverURL, username, secret string) error
+	PrintList(list map[string]string) error
+}
@@ -0,0 +1,41 @@
+package credentialhelper
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of credential helper application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of credential helper application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Get provides a mock function with given fields: ctx, serverURL
+func (m *MockApplication) Get(ctx context.Context, serverURL string) error {
+	args := m.Called(ctx, serverURL)
+	return args.Error(0)
+}
+
+// Store provides a mock function with given fields: ctx, serverURL, username, secret
+func (m *MockApplication) Store(ctx context.Context, serverURL, username, secret string) error {
+	args := m.Called(ctx, serverURL, username, secret)
+	return args.Error(0)
+}
+
+// Erase provides a mock function with given fields: ctx, serverURL
+func (m *MockApplication) Erase(ctx context.Context, serverURL string) error {
+	args := m.Called(ctx, serverURL)
+	return args.Error(0)
+}
+
+// List provides a mock function with given fields: ctx
+func (m *MockApplication) List(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to delete credentials
+type Application struct {
+	store repository.CredentialsDeleter
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentialsStore provides a function to configure the credentials store
+func WithCredentialsStore(store repository.CredentialsDeleter) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run method carries out the application tasks
+func (a *Application) Run(ctx context.Context, id string, optionsFunc ...OptionsFunc) error {
+	var err error
+
+	errContext := "(application::delete::credentials::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.store == nil {
+		return errors.New(errContext, "To run the delete credentials application, a credentials store must be provided")
+	}
+
+	if id == "" {
+		return errors.New(errContext, "To run the delete credentials application, a id for credentials must be provided")
+	}
+
+	err = a.store.Delete(id)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error deleting '%s' credentials", id), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	errContext := "(application::delete::credentials::Run)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		id                string
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc: "Testing error running delete credentials application without store",
+			app:  NewApplication(),
+			id:   "id",
+			err:  errors.New(errContext, "To run the delete credentials application, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing error running delete credentials application without credential id",
+			app:  NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			err:  errors.New(errContext, "To run the delete credentials application, a id for credentials must be provided"),
+		},
+		{
+			desc: "Testing error running delete credentials application when store fails",
+			app:  NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:   "id",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Delete", "id").Return(errors.New("", "Credentials 'id' does not exist"))
+			},
+			err: errors.New(errContext, "Error deleting 'id' credentials", errors.New("", "Credentials 'id' does not exist")),
+		},
+		{
+			desc: "Testing run delete credentials application",
+			app:  NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:   "id",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Delete", "id").Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Run(context.TODO(), test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.app.store.(*mock.MockStore).AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of delete credentials application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of delete credentials application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, id, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, id string, optionsFunc ...OptionsFunc) error {
+	args := m.Called(ctx, id, optionsFunc)
+	return args.Error(0)
+}
@@ -0,0 +1,86 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to export credentials
+type Application struct {
+	store  repository.CredentialsLister
+	bundle repository.CredentialsBundleWriter
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentialsStore provides a function to configure the credentials store to export the credentials from
+func WithCredentialsStore(store repository.CredentialsLister) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// WithCredentialsBundle provides a function to configure the credentials bundle to export the credentials to
+func WithCredentialsBundle(bundle repository.CredentialsBundleWriter) OptionsFunc {
+	return func(a *Application) {
+		a.bundle = bundle
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run method carries out the application tasks. It returns the number of exported credentials
+func (a *Application) Run(ctx context.Context, optionsFunc ...OptionsFunc) (int, error) {
+
+	errContext := "(application::export::credentials::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.store == nil {
+		return 0, errors.New(errContext, "To run the export credentials application, a credentials store must be provided")
+	}
+
+	if a.bundle == nil {
+		return 0, errors.New(errContext, "To run the export credentials application, a credentials bundle must be provided")
+	}
+
+	all, err := a.store.All()
+	if err != nil {
+		return 0, errors.New(errContext, "Error achieving the credentials to export", err)
+	}
+
+	if len(all) == 0 {
+		return 0, errors.New(errContext, "There are no credentials to export")
+	}
+
+	// credentials that can not be read are never exported as empty ones
+	for _, credential := range all {
+		if credential == nil {
+			return 0, errors.New(errContext, "Credentials store contains credentials that can not be read")
+		}
+	}
+
+	err = a.bundle.Write(all)
+	if err != nil {
+		return 0, errors.New(errContext, "Error writing the credentials bundle", err)
+	}
+
+	return len(all), nil
+}
@@ -0,0 +1,105 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	errContext := "(application::export::credentials::Run)"
+
+	all := []*credentials.Credential{
+		{ID: "registry.example.com", Username: "username", Password: "password"},
+	}
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		prepareAssertFunc func(*Application)
+		res               int
+		err               error
+	}{
+		{
+			desc: "Testing error running export credentials application without store",
+			app:  NewApplication(),
+			err:  errors.New(errContext, "To run the export credentials application, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing error running export credentials application without bundle",
+			app:  NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			err:  errors.New(errContext, "To run the export credentials application, a credentials bundle must be provided"),
+		},
+		{
+			desc: "Testing error running export credentials application without credentials",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithCredentialsBundle(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("All").Return([]*credentials.Credential{}, nil)
+			},
+			err: errors.New(errContext, "There are no credentials to export"),
+		},
+		{
+			desc: "Testing error running export credentials application when the store contains credentials that can not be read",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithCredentialsBundle(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("All").Return(append([]*credentials.Credential{nil}, all...), nil)
+			},
+			err: errors.New(errContext, "Credentials store contains credentials that can not be read"),
+		},
+		{
+			desc: "Testing error running export credentials application when bundle fails",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithCredentialsBundle(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("All").Return(all, nil)
+				a.bundle.(*mock.MockStore).On("Write", all).Return(errors.New("", "error"))
+			},
+			err: errors.New(errContext, "Error writing the credentials bundle", errors.New("", "error")),
+		},
+		{
+			desc: "Testing run export credentials application",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithCredentialsBundle(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("All").Return(all, nil)
+				a.bundle.(*mock.MockStore).On("Write", all).Return(nil)
+			},
+			res: 1,
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			res, err := test.app.Run(context.TODO())
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+				test.app.store.(*mock.MockStore).AssertExpectations(t)
+				test.app.bundle.(*mock.MockStore).AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of export credentials application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of export credentials application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, optionsFunc ...OptionsFunc) (int, error) {
+	args := m.Called(ctx, optionsFunc)
+	return args.Int(0), args.Error(1)
+}
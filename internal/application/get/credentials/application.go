@@ -2,8 +2,10 @@ package credentials
 
 import (
 	"context"
+	"time"
 
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 )
 
@@ -14,12 +16,17 @@ type OptionsFunc func(*Application)
 type Application struct {
 	credentials repository.CredentialsFilterer
 	output      repository.CredentialsPrinter
+	// expiringWithin is the window used to print only the credentials about to expire. No filter is applied when it is not set
+	expiringWithin *time.Duration
+	now            func() time.Time
 }
 
 // NewApplication creats a new application service
 func NewApplication(options ...OptionsFunc) *Application {
 
-	service := &Application{}
+	service := &Application{
+		now: time.Now,
+	}
 	service.Options(options...)
 
 	return service
@@ -37,6 +44,20 @@ func WithOutput(output repository.CredentialsPrinter) OptionsFunc {
 	}
 }
 
+// WithExpiringWithin sets the window used to print only the credentials that expire within it. Expired credentials are printed as well
+func WithExpiringWithin(window time.Duration) OptionsFunc {
+	return func(a *Application) {
+		a.expiringWithin = &window
+	}
+}
+
+// WithNow sets the function used to get the current time
+func WithNow(now func() time.Time) OptionsFunc {
+	return func(a *Application) {
+		a.now = now
+	}
+}
+
 // Options configure the service
 func (a *Application) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
@@ -56,6 +77,13 @@ func (a *Application) Run(ctx context.Context, optionsFunc ...OptionsFunc) error
 		return errors.New(errContext, "", err)
 	}
 
+	if a.expiringWithin != nil {
+		credentialsList, err = a.filterExpiring(credentialsList)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
 	err = a.output.Print(credentialsList)
 	if err != nil {
 		return errors.New(errContext, "", err)
@@ -63,3 +91,28 @@ func (a *Application) Run(ctx context.Context, optionsFunc ...OptionsFunc) error
 
 	return nil
 }
+
+// filterExpiring returns the credentials that expire within the application window
+func (a *Application) filterExpiring(list []*credentials.Credential) ([]*credentials.Credential, error) {
+
+	errContext := "(application::get::credentials::filterExpiring)"
+
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+
+	expiring := []*credentials.Credential{}
+	for _, credential := range list {
+		within, err := credential.ExpiresWithin(now(), *a.expiringWithin)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		if within {
+			expiring = append(expiring, credential)
+		}
+	}
+
+	return expiring, nil
+}
@@ -3,6 +3,7 @@ package credentials
 import (
 	"context"
 	"testing"
+	"time"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
@@ -47,6 +48,63 @@ func TestRun(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing get credentials application filtering the credentials about to expire",
+			app: NewApplication(
+				WithCredentials(
+					mockstore.NewMockStore(),
+				),
+				WithOutput(
+					mockoutput.NewMockOutput(),
+				),
+				WithExpiringWithin(7*24*time.Hour),
+				WithNow(func() time.Time {
+					return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+				}),
+			),
+			prepareAssertFunc: func(app *Application) {
+				app.credentials.(*mockstore.MockStore).On("All").Return([]*credentials.Credential{
+					{
+						ID:       "no-expiration",
+						Username: "username",
+						Password: "password",
+					},
+					{
+						ID:        "expiring",
+						Username:  "username",
+						Password:  "password",
+						ExpiresAt: "2024-06-03T00:00:00Z",
+					},
+					{
+						ID:        "expired",
+						Username:  "username",
+						Password:  "password",
+						ExpiresAt: "2024-05-01T00:00:00Z",
+					},
+					{
+						ID:        "not-expiring",
+						Username:  "username",
+						Password:  "password",
+						ExpiresAt: "2025-06-01T00:00:00Z",
+					},
+				}, nil)
+				app.output.(*mockoutput.MockOutput).On("Print", []*credentials.Credential{
+					{
+						ID:        "expiring",
+						Username:  "username",
+						Password:  "password",
+						ExpiresAt: "2024-06-03T00:00:00Z",
+					},
+					{
+						ID:        "expired",
+						Username:  "username",
+						Password:  "password",
+						ExpiresAt: "2024-05-01T00:00:00Z",
+					},
+				}).Return(nil)
+			},
+			err: &errors.Error{},
+		},
 	}
 
 	for _, test := range tests {
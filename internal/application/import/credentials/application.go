@@ -0,0 +1,94 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to import credentials
+type Application struct {
+	source repository.CredentialsLister
+	store  CredentialsStorer
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentialsSource provides a function to configure where the credentials are imported from, such as a credentials bundle or the Docker configuration file
+func WithCredentialsSource(source repository.CredentialsLister) OptionsFunc {
+	return func(a *Application) {
+		a.source = source
+	}
+}
+
+// WithCredentialsStore provides a function to configure the credentials store to import the credentials to
+func WithCredentialsStore(store CredentialsStorer) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run method carries out the application tasks. It returns the number of imported credentials
+func (a *Application) Run(ctx context.Context, optionsFunc ...OptionsFunc) (int, error) {
+
+	errContext := "(application::import::credentials::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.source == nil {
+		return 0, errors.New(errContext, "To run the import credentials application, a credentials source must be provided")
+	}
+
+	if a.store == nil {
+		return 0, errors.New(errContext, "To run the import credentials application, a credentials store must be provided")
+	}
+
+	all, err := a.source.All()
+	if err != nil {
+		return 0, errors.New(errContext, "Error achieving the credentials to import", err)
+	}
+
+	if len(all) == 0 {
+		return 0, errors.New(errContext, "There are no credentials to import")
+	}
+
+	// all the credentials are validated before storing any of them
+	for _, credential := range all {
+		if credential.ID == "" {
+			return 0, errors.New(errContext, "Credentials to import requires an id")
+		}
+
+		_, err = credential.IsValid()
+		if err != nil {
+			return 0, errors.New(errContext, fmt.Sprintf("Invalid '%s' credentials", credential.ID), err)
+		}
+	}
+
+	for _, credential := range all {
+		err = a.store.Store(credential.ID, credential)
+		if err != nil {
+			return 0, errors.New(errContext, fmt.Sprintf("Error importing '%s' credentials", credential.ID), err)
+		}
+	}
+
+	return len(all), nil
+}
@@ -0,0 +1,107 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	errContext := "(application::import::credentials::Run)"
+
+	credential := &credentials.Credential{ID: "registry.example.com", Username: "username", Password: "password"}
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		prepareAssertFunc func(*Application)
+		res               int
+		err               error
+	}{
+		{
+			desc: "Testing error running import credentials application without source",
+			app:  NewApplication(),
+			err:  errors.New(errContext, "To run the import credentials application, a credentials source must be provided"),
+		},
+		{
+			desc: "Testing error running import credentials application without store",
+			app:  NewApplication(WithCredentialsSource(mock.NewMockStore())),
+			err:  errors.New(errContext, "To run the import credentials application, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing error running import credentials application without credentials",
+			app: NewApplication(
+				WithCredentialsSource(mock.NewMockStore()),
+				WithCredentialsStore(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.source.(*mock.MockStore).On("All").Return([]*credentials.Credential{}, nil)
+			},
+			err: errors.New(errContext, "There are no credentials to import"),
+		},
+		{
+			desc: "Testing error running import credentials application with invalid credentials",
+			app: NewApplication(
+				WithCredentialsSource(mock.NewMockStore()),
+				WithCredentialsStore(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.source.(*mock.MockStore).On("All").Return([]*credentials.Credential{
+					credential,
+					{ID: "invalid", Username: "username"},
+				}, nil)
+			},
+			err: errors.New(errContext, "Invalid 'invalid' credentials",
+				errors.New("(core::domain::credentials::IsValid)", "Invalid credential. Missing password")),
+		},
+		{
+			desc: "Testing error running import credentials application when store fails",
+			app: NewApplication(
+				WithCredentialsSource(mock.NewMockStore()),
+				WithCredentialsStore(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.source.(*mock.MockStore).On("All").Return([]*credentials.Credential{credential}, nil)
+				a.store.(*mock.MockStore).On("Store", "registry.example.com", credential).Return(errors.New("", "error"))
+			},
+			err: errors.New(errContext, "Error importing 'registry.example.com' credentials", errors.New("", "error")),
+		},
+		{
+			desc: "Testing run import credentials application",
+			app: NewApplication(
+				WithCredentialsSource(mock.NewMockStore()),
+				WithCredentialsStore(mock.NewMockStore()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.source.(*mock.MockStore).On("All").Return([]*credentials.Credential{credential}, nil)
+				a.store.(*mock.MockStore).On("Store", "registry.example.com", credential).Return(nil)
+			},
+			res: 1,
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			res, err := test.app.Run(context.TODO())
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+				test.app.source.(*mock.MockStore).AssertExpectations(t)
+				test.app.store.(*mock.MockStore).AssertExpectations(t)
+			}
+		})
+	}
+}
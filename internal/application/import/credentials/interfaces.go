@@ -0,0 +1,8 @@
+package credentials
+
+import "github.com/gostevedore/stevedore/internal/core/domain/credentials"
+
+// CredentialsStorer interface defines the storage of the imported credentials
+type CredentialsStorer interface {
+	Store(id string, credential *credentials.Credential) error
+}
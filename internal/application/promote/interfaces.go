@@ -1,7 +1,15 @@
 package promote
 
 import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	operationfilter "github.com/gostevedore/stevedore/internal/infrastructure/filters/operation"
+	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/job"
 )
 
 // Outputter
@@ -9,6 +17,33 @@ type Outputter interface {
 	Info(msg ...interface{})
 	Warn(msg ...interface{})
 	Error(msg ...interface{})
+	PrintTable(content [][]string) error
+}
+
+// DigestResolver interface defines the component which resolves the manifest digest of an image stored on a registry. The tokens take precedence over the username and password
+type DigestResolver interface {
+	Digest(ctx context.Context, name, username, password, identityToken, registryToken string) (string, error)
+	Lookup(ctx context.Context, name, username, password, identityToken, registryToken string) (string, bool, error)
+}
+
+// LabelsResolver interface defines the component which resolves the labels of an image. The tokens take precedence over the username and password
+type LabelsResolver interface {
+	Labels(ctx context.Context, name, username, password, identityToken, registryToken string) (map[string]string, error)
+}
+
+// PromotionPolicier interface defines the promotion policy which governs the routes that images are allowed to follow when they are promoted
+type PromotionPolicier interface {
+	Routes(source, target string) ([]*image.PromotionRule, error)
+}
+
+// CredentialsExpirationWarner interface defines the component which warns about the registry credentials that are about to expire
+type CredentialsExpirationWarner interface {
+	WarnExpiring(ids ...string)
+}
+
+// ImmutableTagser interface defines the rules to decide whether an image tag can not be overwritten
+type ImmutableTagser interface {
+	IsImmutable(name string) bool
 }
 
 // PromoteFactorier
@@ -21,3 +56,28 @@ type PromoteFactorier interface {
 type Semverser interface {
 	GenerateSemverList(version []string, tmpls []string) ([]string, error)
 }
+
+// Planner interfaces defines the plan to resolve the images to promote
+type Planner interface {
+	Plan(name string, versions []string) ([]*plan.Step, error)
+}
+
+// PromoteCommandFactorier interface defines the factory of promote commands
+type PromoteCommandFactorier interface {
+	New(repository.Promoter, *image.PromoteOptions) command.PromoteCommander
+}
+
+// JobFactorier interface defines the factory of promote jobs
+type JobFactorier interface {
+	New(job.Commander) scheduler.Jobber
+}
+
+// Dispatcher is a dispatcher to promote docker images
+type Dispatcher interface {
+	Enqueue(scheduler.Jobber)
+}
+
+// FilterFactorier interface defines the factory of filter operations
+type FilterFactorier interface {
+	FilterOperation() *operationfilter.FilterOperation
+}
@@ -18,3 +18,8 @@ func (p *MockApplication) Promote(ctx context.Context, options *Options) error {
 	args := p.Mock.Called(ctx, options)
 	return args.Error(0)
 }
+
+func (p *MockApplication) PromoteDefinition(ctx context.Context, promotePlan Planner, name string, versions []string, options *Options) error {
+	args := p.Mock.Called(ctx, promotePlan, name, versions, options)
+	return args.Error(0)
+}
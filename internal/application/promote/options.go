@@ -5,6 +5,12 @@ type Options struct {
 	DryRun bool
 	// EnableSemanticVersionTags flag generate semantic versioning tags when is true
 	EnableSemanticVersionTags bool
+	// Force flag overwrites the target image tags even when they are immutable
+	Force bool
+	// Filter is a list of filters to select the images to promote when they are resolved from the images definition
+	Filter []string
+	// VerifyDigest flag verifies that the target images digest matches the source image digest once they are promoted
+	VerifyDigest bool
 	// TargetImageName is the target image name
 	TargetImageName string
 	// TargetImageRegistryNamespace is the target namespace name
@@ -13,12 +19,16 @@ type Options struct {
 	TargetImageRegistryHost string
 	// TargetImageTags list of extra tags for the target image
 	TargetImageTags []string
+	// Promoter is the name of the promoter used to promote the images. When it is not defined, the docker promoter is used
+	Promoter string
 	// PromoteSourceImageTag push source image to registry
 	PromoteSourceImageTag bool
 	// RemoveTargetImageTags flag removes all images from local host once the image is promoted
 	RemoveTargetImageTags bool
 	// RemoteSourceImage flag indicates to use an image from remote source
 	RemoteSourceImage bool
+	// SourceImageDigest is the digest that the source image must have to be promoted
+	SourceImageDigest string
 	// SourceImageName is the source image name
 	SourceImageName string
 	// SemanticVersionTagsTemplates is a list of templates to use to generate semantic version tags
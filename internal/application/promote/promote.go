@@ -4,22 +4,45 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
+	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
 	"github.com/gostevedore/stevedore/internal/infrastructure/types/list"
 )
 
+const (
+	// DigestVerifiedStatus is the status reported when the target image digest matches the source image digest
+	DigestVerifiedStatus = "verified"
+	// DigestMismatchStatus is the status reported when the target image digest does not match the source image digest
+	DigestMismatchStatus = "mismatch"
+)
+
 // OptionsFunc is a function used to configure the application
 type OptionsFunc func(*Application)
 
 // Application is the application used to promote images
 type Application struct {
+	commandFactory PromoteCommandFactorier
 	credentials    repository.AuthFactorier
+	digestResolver DigestResolver
+	dispatch       Dispatcher
+	expiration     CredentialsExpirationWarner
 	factory        PromoteFactorier
+	filterFactory  FilterFactorier
+	immutableTags  ImmutableTagser
+	jobFactory     JobFactorier
+	labelsResolver LabelsResolver
+	output         Outputter
+	policy         PromotionPolicier
 	referenceNamer repository.ImageReferenceNamer
+	selectors      map[string]repository.ImagesSelector
 	semver         Semverser
 }
 
@@ -59,6 +82,83 @@ func WithReferenceNamer(ref repository.ImageReferenceNamer) OptionsFunc {
 	}
 }
 
+// WithCommandFactory sets the factory used to create the promote commands
+func WithCommandFactory(f PromoteCommandFactorier) OptionsFunc {
+	return func(a *Application) {
+		a.commandFactory = f
+	}
+}
+
+// WithJobFactory sets the factory used to create the promote jobs
+func WithJobFactory(f JobFactorier) OptionsFunc {
+	return func(a *Application) {
+		a.jobFactory = f
+	}
+}
+
+// WithDispatch sets the dispatcher which executes the promote jobs
+func WithDispatch(d Dispatcher) OptionsFunc {
+	return func(a *Application) {
+		a.dispatch = d
+	}
+}
+
+// WithSelector sets the images selectors used to filter the images to promote
+func WithSelector(selectors map[string]repository.ImagesSelector) OptionsFunc {
+	return func(a *Application) {
+		a.selectors = selectors
+	}
+}
+
+// WithFilterFactory sets the filter operations factory
+func WithFilterFactory(f FilterFactorier) OptionsFunc {
+	return func(a *Application) {
+		a.filterFactory = f
+	}
+}
+
+// WithImmutableTags sets the rules that define which tags can not be overwritten on the registry
+func WithImmutableTags(t ImmutableTagser) OptionsFunc {
+	return func(a *Application) {
+		a.immutableTags = t
+	}
+}
+
+// WithPolicy sets the promotion policy which governs the promotions
+func WithPolicy(p PromotionPolicier) OptionsFunc {
+	return func(a *Application) {
+		a.policy = p
+	}
+}
+
+// WithLabelsResolver sets the component which resolves the source images labels required by the promotion policy
+func WithLabelsResolver(r LabelsResolver) OptionsFunc {
+	return func(a *Application) {
+		a.labelsResolver = r
+	}
+}
+
+// WithDigestResolver sets the component which resolves the images digest
+func WithDigestResolver(r DigestResolver) OptionsFunc {
+	return func(a *Application) {
+		a.digestResolver = r
+	}
+}
+
+// WithOutput sets the output where the promotion results are reported
+func WithOutput(o Outputter) OptionsFunc {
+	return func(a *Application) {
+		a.output = o
+	}
+}
+
+// WithCredentialsExpirationWarner sets the component which warns about the registry credentials required by the promotion that are about to expire
+func WithCredentialsExpirationWarner(w CredentialsExpirationWarner) OptionsFunc {
+	return func(a *Application) {
+		a.expiration = w
+	}
+}
+
 // Options configure the application
 func (a *Application) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
@@ -72,6 +172,7 @@ func (a *Application) Promote(ctx context.Context, options *Options) error {
 	var err error
 	var sourceImage, targetImage *image.Image
 	var referenceName string
+	var sourceDigest string
 
 	promoteOptions := &image.PromoteOptions{}
 	errContext := "(application::promote::Promote)"
@@ -110,20 +211,12 @@ func (a *Application) Promote(ctx context.Context, options *Options) error {
 		return errors.New(errContext, "", err)
 	}
 
-	auth, err := a.getCredentials(sourceImage.RegistryHost)
+	pullAuth, err := a.getRegistryAuth(sourceImage.Repository())
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
 
-	if auth != nil {
-		pullAuth, isBasicAuth := auth.(*authmethodbasic.BasicAuthMethod)
-		if !isBasicAuth {
-			return errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic auth method", sourceImage.RegistryHost, auth.Name()))
-		}
-
-		promoteOptions.PullAuthUsername = pullAuth.Username
-		promoteOptions.PullAuthPassword = pullAuth.Password
-	}
+	setPullAuth(promoteOptions, pullAuth)
 
 	if options.TargetImageRegistryHost != image.UndefinedStringValue {
 		targetImage.RegistryHost = options.TargetImageRegistryHost
@@ -187,31 +280,523 @@ func (a *Application) Promote(ctx context.Context, options *Options) error {
 
 	// Registry host must be defined explicitly to achive the host credentials
 	if targetImage.RegistryHost != "" {
-		auth, err = a.getCredentials(targetImage.RegistryHost)
+		pushAuth, err := a.getRegistryAuth(targetImage.Repository())
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		setPushAuth(promoteOptions, pushAuth)
+	}
+
+	promoteOptions.RemoteSourceImage = options.RemoteSourceImage
+	promoteOptions.RemoveTargetImageTags = options.RemoveTargetImageTags
+
+	err = a.checkPolicy(ctx, promoteOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if options.SourceImageDigest != "" {
+		sourceDigest, err = a.checkSourceDigest(ctx, promoteOptions, options.SourceImageDigest)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		// the source image is promoted by its digest, so the promoted image is the verified one even when the source tag moves afterwards
+		promoteOptions.SourceImageName = fmt.Sprintf("%s@%s", sourceImage.Repository(), sourceDigest)
+	}
+
+	if !options.Force && !options.DryRun {
+		err = a.checkImmutableTags(ctx, promoteOptions, sourceDigest)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
+	promoter, err := a.getPromoter(options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = promoter.Promote(ctx, promoteOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	// promoting a pinned source digest is always verified
+	if (options.VerifyDigest || options.SourceImageDigest != "") && !options.DryRun {
+		digests, verifyErr := a.verifyDigest(ctx, promoteOptions, sourceDigest)
+
+		err = a.printDigests(digests)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		if verifyErr != nil {
+			return errors.New(errContext, "", verifyErr)
+		}
+	}
+
+	return nil
+}
+
+// PromoteDefinition promotes all the images achieved from an images definition. Each image is promoted along with its tags, and the semantic version tags when they are enabled. Promotions are executed in parallel through the scheduler
+func (a *Application) PromoteDefinition(ctx context.Context, promotePlan Planner, name string, versions []string, options *Options) error {
+
+	var err error
+	var steps []*plan.Step
+	var promoter repository.Promoter
+	var wg sync.WaitGroup
+
+	images := []*image.Image{}
+	promoteOptionsList := []*image.PromoteOptions{}
+	promoteWorkerErrs := []func() error{}
+	promotedReferences := map[string]struct{}{}
+
+	errContext := "(application::promote::PromoteDefinition)"
+
+	if a.factory == nil {
+		return errors.New(errContext, "Promote application requires promote factory")
+	}
+
+	if a.semver == nil {
+		return errors.New(errContext, "Promote application requires semver")
+	}
+
+	if a.referenceNamer == nil {
+		return errors.New(errContext, "Promote application requires a image reference namer")
+	}
+
+	if a.credentials == nil {
+		return errors.New(errContext, "Promote application requires credentials factory")
+	}
+
+	if a.commandFactory == nil {
+		return errors.New(errContext, "Promote application requires a command factory to promote images definition")
+	}
+
+	if a.jobFactory == nil {
+		return errors.New(errContext, "Promote application requires a job factory to promote images definition")
+	}
+
+	if a.dispatch == nil {
+		return errors.New(errContext, "Promote application requires a dispatcher to promote images definition")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Promote application requires options")
+	}
+
+	if promotePlan == nil {
+		return errors.New(errContext, "Promote application requires a plan to promote images definition")
+	}
+
+	if options.SourceImageDigest != "" {
+		return errors.New(errContext, "Source image digest is not supported when promoting an images definition")
+	}
+
+	steps, err = promotePlan.Plan(name, versions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	for _, step := range steps {
+		images = append(images, step.Image())
+	}
+
+	images, err = a.selectImages(images, options.Filter)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if len(images) < 1 {
+		return errors.New(errContext, fmt.Sprintf("There is no image to promote from '%s' definition", name))
+	}
+
+	// all the promote options are generated before start promoting to not promote partially the images definition when any of them is invalid
+	for _, i := range images {
+		promoteOptions, err := a.definitionPromoteOptions(i, options)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		_, promoted := promotedReferences[promoteOptions.SourceImageName]
+		if promoted {
+			continue
+		}
+		promotedReferences[promoteOptions.SourceImageName] = struct{}{}
+
+		err = a.checkPolicy(ctx, promoteOptions)
 		if err != nil {
 			return errors.New(errContext, "", err)
 		}
 
-		if auth != nil {
-			pushAuth, isBasicAuth := auth.(*authmethodbasic.BasicAuthMethod)
-			if !isBasicAuth {
-				return errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic auth method", targetImage.RegistryHost, auth.Name()))
+		if !options.Force && !options.DryRun {
+			err = a.checkImmutableTags(ctx, promoteOptions, "")
+			if err != nil {
+				return errors.New(errContext, "", err)
 			}
+		}
+
+		promoteOptionsList = append(promoteOptionsList, promoteOptions)
+	}
+
+	promoter, err = a.getPromoter(options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	// digests verified on each promotion, indexed as the promote options list
+	digests := make([][][]string, len(promoteOptionsList))
+
+	// future promise which triggers the image promotion
+	promoteWorkerFunc := func(ctx context.Context, idx int, promoteOptions *image.PromoteOptions) func() error {
+		var err error
+
+		c := make(chan struct{}, 1)
+		go func() {
+			defer close(c)
+			defer wg.Done()
+
+			err = a.dispatchPromotion(ctx, promoter, promoteOptions)
+			if err != nil {
+				return
+			}
+
+			if options.VerifyDigest && !options.DryRun {
+				digests[idx], err = a.verifyDigest(ctx, promoteOptions, "")
+			}
+		}()
+
+		return func() error {
+			<-c
+			return err
+		}
+	}
+
+	for idx, promoteOptions := range promoteOptionsList {
+		wg.Add(1)
+		promoteWorkerErrs = append(promoteWorkerErrs, promoteWorkerFunc(ctx, idx, promoteOptions))
+	}
+
+	wg.Wait()
+
+	if options.VerifyDigest && !options.DryRun {
+		verifiedDigests := [][]string{}
+		for _, d := range digests {
+			verifiedDigests = append(verifiedDigests, d...)
+		}
+
+		err = a.printDigests(verifiedDigests)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
+	errMsg := ""
+	for _, promoteWorkerErr := range promoteWorkerErrs {
+		err = promoteWorkerErr()
+		if err != nil {
+			errMsg = fmt.Sprintf("%s%s\n", errMsg, err.Error())
+		}
+	}
+	if errMsg != "" {
+		return errors.New(errContext, errMsg)
+	}
+
+	return nil
+}
+
+// definitionPromoteOptions returns the promote options to promote an image achieved from an images definition
+func (a *Application) definitionPromoteOptions(i *image.Image, options *Options) (*image.PromoteOptions, error) {
+
+	var err error
+	var sourceImage, targetImage *image.Image
+	var referenceName string
+
+	errContext := "(application::promote::definitionPromoteOptions)"
+	promoteOptions := &image.PromoteOptions{}
+	auxTargetImageTagsMap := map[string]struct{}{}
+
+	if i == nil {
+		return nil, errors.New(errContext, "To generate the promote options, an image is required")
+	}
+
+	sourceImage, err = i.Copy()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	// images are sanetized before being built, then the source reference must be sanetized too
+	err = sourceImage.Sanetize()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
 
-			promoteOptions.PushAuthUsername = pushAuth.Username
-			promoteOptions.PushAuthPassword = pushAuth.Password
+	referenceName, err = a.referenceNamer.GenerateName(sourceImage)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error generating source image reference name for '%s:%s'", i.Name, i.Version), err)
+	}
+	promoteOptions.SourceImageName = referenceName
+
+	targetImage, err = sourceImage.Copy()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if options.TargetImageRegistryHost != image.UndefinedStringValue {
+		targetImage.RegistryHost = options.TargetImageRegistryHost
+	}
+
+	if options.TargetImageRegistryNamespace != image.UndefinedStringValue {
+		targetImage.RegistryNamespace = options.TargetImageRegistryNamespace
+	}
+
+	for _, tag := range sourceImage.Tags {
+		auxTargetImageTagsMap[tag] = struct{}{}
+	}
+
+	if options.EnableSemanticVersionTags {
+		semVerTags, _ := a.semver.GenerateSemverList(append([]string{sourceImage.Version}, sourceImage.Tags...), options.SemanticVersionTagsTemplates)
+		for _, tag := range semVerTags {
+			auxTargetImageTagsMap[tag] = struct{}{}
 		}
 	}
 
+	referenceName, err = a.referenceNamer.GenerateName(targetImage)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error generating target image reference name for '%s'", promoteOptions.SourceImageName), err)
+	}
+	promoteOptions.TargetImageName = referenceName
+
+	auxTargetImageTagsList := []string{}
+	for tag := range auxTargetImageTagsMap {
+		auxTargetImageTagsList = append(auxTargetImageTagsList, tag)
+	}
+	promoteOptions.TargetImageTags, err = a.generateReferenceNameList(targetImage, auxTargetImageTagsList)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+	sort.Sort(list.SortedStringList(promoteOptions.TargetImageTags))
+
+	if sourceImage.RegistryHost != "" {
+		pullAuth, err := a.getRegistryAuth(sourceImage.Repository())
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		setPullAuth(promoteOptions, pullAuth)
+	}
+
+	if targetImage.RegistryHost != "" {
+		pushAuth, err := a.getRegistryAuth(targetImage.Repository())
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		setPushAuth(promoteOptions, pushAuth)
+	}
+
 	promoteOptions.RemoteSourceImage = options.RemoteSourceImage
 	promoteOptions.RemoveTargetImageTags = options.RemoveTargetImageTags
 
-	promoter, err := a.getPromoter(options)
+	return promoteOptions, nil
+}
+
+// checkSourceDigest returns the source image digest, and fails when it does not match the expected one
+func (a *Application) checkSourceDigest(ctx context.Context, options *image.PromoteOptions, expected string) (string, error) {
+
+	errContext := "(application::promote::checkSourceDigest)"
+
+	if a.digestResolver == nil {
+		return "", errors.New(errContext, "To check the source image digest, is required a digest resolver")
+	}
+
+	digest, err := a.digestResolver.Digest(ctx, options.SourceImageName, options.PullAuthUsername, options.PullAuthPassword, options.PullAuthIdentityToken, options.PullAuthRegistryToken)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	if digest != expected {
+		return "", errors.New(errContext, fmt.Sprintf("Image '%s' is not promoted because its digest '%s' does not match the expected digest '%s'. The source image has changed since its digest was taken", options.SourceImageName, digest, expected))
+	}
+
+	return digest, nil
+}
+
+// checkPolicy fails when the promotion policy does not allow to promote the source image to any of the target images. A target image is allowed when any of the rules that route the source image to the target is fulfilled
+func (a *Application) checkPolicy(ctx context.Context, options *image.PromoteOptions) error {
+
+	var err error
+	var sourceImage *image.Image
+	var labels map[string]string
+
+	errContext := "(application::promote::checkPolicy)"
+	denials := []string{}
+
+	if a.policy == nil {
+		return nil
+	}
+
+	sourceImage, err = image.Parse(options.SourceImageName)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
 
-	err = promoter.Promote(ctx, promoteOptions)
+	for _, target := range append([]string{options.TargetImageName}, options.TargetImageTags...) {
+		routes, err := a.policy.Routes(options.SourceImageName, target)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		if len(routes) == 0 {
+			denials = append(denials, fmt.Sprintf("there is no rule that allows promoting to '%s'", target))
+			continue
+		}
+
+		allowed := false
+		reasons := []string{}
+		for _, rule := range routes {
+			if rule.RequiresLabels() && labels == nil {
+				if a.labelsResolver == nil {
+					return errors.New(errContext, "To check the promotion policy required labels, is required a labels resolver")
+				}
+
+				labels, err = a.labelsResolver.Labels(ctx, options.SourceImageName, options.PullAuthUsername, options.PullAuthPassword, options.PullAuthIdentityToken, options.PullAuthRegistryToken)
+				if err != nil {
+					return errors.New(errContext, "", err)
+				}
+			}
+
+			err = rule.Check(sourceImage.Version, labels)
+			if err == nil {
+				allowed = true
+				break
+			}
+			reasons = append(reasons, err.Error())
+		}
+
+		if !allowed {
+			denials = append(denials, fmt.Sprintf("promoting to '%s' is denied. %s", target, strings.Join(reasons, ". ")))
+		}
+	}
+
+	if len(denials) > 0 {
+		return errors.New(errContext, fmt.Sprintf("Promotion policy denies promoting '%s': %s", options.SourceImageName, strings.Join(denials, "; ")))
+	}
+
+	return nil
+}
+
+// checkImmutableTags fails when any of the target images tags is immutable and it already exists on the registry with a digest that differs from the source image digest
+func (a *Application) checkImmutableTags(ctx context.Context, options *image.PromoteOptions, sourceDigest string) error {
+
+	var err error
+	var targetDigest string
+	var found bool
+
+	errContext := "(application::promote::checkImmutableTags)"
+	overwritten := []string{}
+
+	if a.immutableTags == nil {
+		return nil
+	}
+
+	for _, target := range append([]string{options.TargetImageName}, options.TargetImageTags...) {
+		if !a.immutableTags.IsImmutable(target) {
+			continue
+		}
+
+		if a.digestResolver == nil {
+			return errors.New(errContext, "To check the immutable tags, is required a digest resolver")
+		}
+
+		targetDigest, found, err = a.digestResolver.Lookup(ctx, target, options.PushAuthUsername, options.PushAuthPassword, options.PushAuthIdentityToken, options.PushAuthRegistryToken)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		if !found {
+			continue
+		}
+
+		if sourceDigest == "" {
+			sourceDigest, err = a.digestResolver.Digest(ctx, options.SourceImageName, options.PullAuthUsername, options.PullAuthPassword, options.PullAuthIdentityToken, options.PullAuthRegistryToken)
+			if err != nil {
+				return errors.New(errContext, fmt.Sprintf("Immutable tag '%s' already exists and it can not be compared to the source image '%s'. Use force to overwrite it", target, options.SourceImageName), err)
+			}
+		}
+
+		if targetDigest != sourceDigest {
+			overwritten = append(overwritten, target)
+		}
+	}
+
+	if len(overwritten) > 0 {
+		return errors.New(errContext, fmt.Sprintf("Immutable tag '%s' already exists with a digest that differs from the source image '%s' digest '%s'. Use force to overwrite it", strings.Join(overwritten, "', '"), options.SourceImageName, sourceDigest))
+	}
+
+	return nil
+}
+
+// verifyDigest compares the digest of each promoted target image with the source image digest. It returns the verified digests, one row per target image, and an error when any of them does not match
+func (a *Application) verifyDigest(ctx context.Context, options *image.PromoteOptions, sourceDigest string) ([][]string, error) {
+
+	var err error
+	var targetDigest string
+
+	errContext := "(application::promote::verifyDigest)"
+	digests := [][]string{}
+	mismatches := []string{}
+
+	if a.digestResolver == nil {
+		return nil, errors.New(errContext, "To verify the promoted images digest, is required a digest resolver")
+	}
+
+	if sourceDigest == "" {
+		sourceDigest, err = a.digestResolver.Digest(ctx, options.SourceImageName, options.PullAuthUsername, options.PullAuthPassword, options.PullAuthIdentityToken, options.PullAuthRegistryToken)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+	}
+
+	for _, target := range append([]string{options.TargetImageName}, options.TargetImageTags...) {
+		targetDigest, err = a.digestResolver.Digest(ctx, target, options.PushAuthUsername, options.PushAuthPassword, options.PushAuthIdentityToken, options.PushAuthRegistryToken)
+		if err != nil {
+			return digests, errors.New(errContext, "", err)
+		}
+
+		status := DigestVerifiedStatus
+		if targetDigest != sourceDigest {
+			status = DigestMismatchStatus
+			mismatches = append(mismatches, target)
+		}
+
+		digests = append(digests, []string{options.SourceImageName, sourceDigest, target, targetDigest, status})
+	}
+
+	if len(mismatches) > 0 {
+		return digests, errors.New(errContext, fmt.Sprintf("Digest of '%s' does not match the source image '%s' digest '%s'", strings.Join(mismatches, "', '"), options.SourceImageName, sourceDigest))
+	}
+
+	return digests, nil
+}
+
+// printDigests prints the verified digests as a table
+func (a *Application) printDigests(digests [][]string) error {
+
+	errContext := "(application::promote::printDigests)"
+
+	if a.output == nil || len(digests) < 1 {
+		return nil
+	}
+
+	content := [][]string{
+		{"SOURCE", "SOURCE DIGEST", "TARGET", "TARGET DIGEST", "STATUS"},
+	}
+	content = append(content, digests...)
+
+	err := a.output.PrintTable(content)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
@@ -219,6 +804,77 @@ func (a *Application) Promote(ctx context.Context, options *Options) error {
 	return nil
 }
 
+// dispatchPromotion enqueues a promote job to the dispatcher and waits until it finishes
+func (a *Application) dispatchPromotion(ctx context.Context, promoter repository.Promoter, options *image.PromoteOptions) error {
+
+	errContext := "(application::promote::dispatchPromotion)"
+
+	if a.commandFactory == nil {
+		return errors.New(errContext, "To dispatch a promotion, is required a command factory")
+	}
+
+	if a.jobFactory == nil {
+		return errors.New(errContext, "To dispatch a promotion, is required a job factory")
+	}
+
+	if a.dispatch == nil {
+		return errors.New(errContext, "To dispatch a promotion, is required a dispatcher")
+	}
+
+	cmd := a.commandFactory.New(promoter, options)
+	job := a.jobFactory.New(cmd)
+
+	a.dispatch.Enqueue(job)
+
+	err := job.Wait()
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
+	}
+
+	return nil
+}
+
+// selectImages returns the images that fulfill all the filters
+func (a *Application) selectImages(images []*image.Image, filters []string) ([]*image.Image, error) {
+
+	var err error
+
+	errContext := "(application::promote::selectImages)"
+
+	if len(filters) < 1 {
+		return images, nil
+	}
+
+	if a.filterFactory == nil {
+		return nil, errors.New(errContext, "To filter the images to promote, is required a filter factory")
+	}
+
+	for _, filter := range filters {
+		operation := a.filterFactory.FilterOperation()
+		err = operation.ParseFilterOpration(filter)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		// unlike on get images, an unknown filter is not ignored because it could promote more images than expected
+		if !operation.IsDefined() {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid filter '%s'", filter))
+		}
+
+		selector, valid := a.selectors[operation.Attribute()]
+		if !valid {
+			return nil, errors.New(errContext, fmt.Sprintf("Filter attribute '%s' is not supported", operation.Attribute()))
+		}
+
+		images, err = selector.Select(images, operation.Operation(), operation.Item().(string))
+		if err != nil {
+			return nil, errors.New(errContext, "Images selection does not finish properly", err)
+		}
+	}
+
+	return images, nil
+}
+
 // generateReferenceNameList return a list of reference names
 func (a *Application) generateReferenceNameList(i *image.Image, tags []string) ([]string, error) {
 
@@ -251,11 +907,71 @@ func (a *Application) getCredentials(registry string) (repository.AuthMethodRead
 		return nil, errors.New(errContext, "Credentials has not been initialized")
 	}
 
-	auth, _ := a.credentials.Get(registry)
+	auth, err := a.credentials.Get(registry)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials for '%s' could not be achieved", registry), err)
+	}
 
 	return auth, nil
 }
 
+// getRegistryAuth returns the auth method for the registry, either a basic or a token auth method, or nil when there is no credential for it. Keyfile and SSH agent credentials can not authenticate to a registry, then they are ignored
+func (a *Application) getRegistryAuth(registry string) (repository.AuthMethodReader, error) {
+	errContext := "(application::promote::getRegistryAuth)"
+
+	// registry auth is achieved before promoting the images, then it is the moment to warn about the credentials that are about to expire. Each credential is warned once
+	if a.expiration != nil {
+		a.expiration.WarnExpiring(registry)
+	}
+
+	auth, err := a.getCredentials(registry)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if auth == nil {
+		return nil, nil
+	}
+
+	switch auth.Name() {
+	case credentials.KeyFileAuthMethod, credentials.SSHAgentAuthMethod:
+		return nil, nil
+	}
+
+	switch auth.(type) {
+	case *authmethodbasic.BasicAuthMethod, *authmethodtoken.TokenAuthMethod:
+		return auth, nil
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid credentials method for '%s'. Found '%s' when is expected basic or token auth method", registry, auth.Name()))
+	}
+}
+
+// setPullAuth sets the auth method to pull the source image on the promote options
+func setPullAuth(options *image.PromoteOptions, auth repository.AuthMethodReader) {
+	switch method := auth.(type) {
+	case *authmethodbasic.BasicAuthMethod:
+		options.PullAuthUsername = method.Username
+		options.PullAuthPassword = method.Password
+	case *authmethodtoken.TokenAuthMethod:
+		options.PullAuthUsername = method.Username
+		options.PullAuthIdentityToken = method.RefreshToken
+		options.PullAuthRegistryToken = method.Token
+	}
+}
+
+// setPushAuth sets the auth method to push the target images on the promote options
+func setPushAuth(options *image.PromoteOptions, auth repository.AuthMethodReader) {
+	switch method := auth.(type) {
+	case *authmethodbasic.BasicAuthMethod:
+		options.PushAuthUsername = method.Username
+		options.PushAuthPassword = method.Password
+	case *authmethodtoken.TokenAuthMethod:
+		options.PushAuthUsername = method.Username
+		options.PushAuthIdentityToken = method.RefreshToken
+		options.PushAuthRegistryToken = method.Token
+	}
+}
+
 func (a *Application) getPromoter(options *Options) (repository.Promoter, error) {
 
 	errContext := "(Handler::getPromoter)"
@@ -264,9 +980,13 @@ func (a *Application) getPromoter(options *Options) (repository.Promoter, error)
 		return nil, errors.New(errContext, "Promote factory has not been initialized")
 	}
 
-	promoteDriver := "docker"
+	promoteDriver := image.DockerPromoterName
+	if options.Promoter != "" {
+		promoteDriver = options.Promoter
+	}
+
 	if options.DryRun {
-		promoteDriver = "dry-run"
+		promoteDriver = image.DryRunPromoterName
 	}
 	promoter, err := a.factory.Get(promoteDriver)
 	if err != nil {
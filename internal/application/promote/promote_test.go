@@ -7,14 +7,24 @@ import (
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/expiration"
 	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
 	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
 	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	filter "github.com/gostevedore/stevedore/internal/infrastructure/filters/images"
+	"github.com/gostevedore/stevedore/internal/infrastructure/filters/operation"
+	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/dryrun"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/mock"
+	"github.com/gostevedore/stevedore/internal/infrastructure/promote/registry"
 	reference "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/default"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/dispatch"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/job"
 	"github.com/gostevedore/stevedore/internal/infrastructure/semver"
 	"github.com/stretchr/testify/assert"
 )
@@ -65,7 +75,7 @@ func TestPromote(t *testing.T) {
 					TargetImageTags:       []string{},
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
@@ -117,7 +127,7 @@ func TestPromote(t *testing.T) {
 				factory.Register(image.DockerPromoterName, mock)
 				p.factory = factory
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
@@ -163,7 +173,7 @@ func TestPromote(t *testing.T) {
 				factory.Register(image.DockerPromoterName, mock)
 				p.factory = factory
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
@@ -209,7 +219,7 @@ func TestPromote(t *testing.T) {
 				factory.Register(image.DockerPromoterName, mock)
 				p.factory = factory
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username",
 					Password: "password",
 				}, nil)
@@ -255,12 +265,12 @@ func TestPromote(t *testing.T) {
 					},
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_pull",
 					Password: "password_pull",
 				}, nil)
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetnamespace/targetimage").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_push",
 					Password: "password_push",
 				}, nil)
@@ -313,8 +323,8 @@ func TestPromote(t *testing.T) {
 					PushAuthPassword:      "",
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{}, nil)
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test").Return(&authmethodbasic.BasicAuthMethod{}, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{}, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetnamespace/targetimage").Return(&authmethodbasic.BasicAuthMethod{}, nil)
 
 				mock := mock.NewMockPromote()
 				mock.On("Promote", context.TODO(), options).Return(nil)
@@ -364,7 +374,7 @@ func TestPromote(t *testing.T) {
 					PushAuthPassword:      "password_pullpush",
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_pullpush",
 					Password: "password_pullpush",
 				}, nil)
@@ -417,12 +427,12 @@ func TestPromote(t *testing.T) {
 					PushAuthPassword:      "password_push",
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_pull",
 					Password: "password_pull",
 				}, nil)
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetnamespace/targetimage").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_push",
 					Password: "password_push",
 				}, nil)
@@ -473,12 +483,12 @@ func TestPromote(t *testing.T) {
 					PushAuthPassword:      "password_push",
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_pull",
 					Password: "password_pull",
 				}, nil)
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetnamespace/targetimage").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_push",
 					Password: "password_push",
 				}, nil)
@@ -493,7 +503,7 @@ func TestPromote(t *testing.T) {
 			err: &errors.Error{},
 		},
 		{
-			desc: "Testing error on promote application when push credentials are invalid",
+			desc: "Testing promote application ignoring keyfile push credentials",
 			service: &Application{
 				credentials:    authfactory.NewMockAuthFactory(),
 				semver:         semver.NewSemVerGenerator(),
@@ -516,26 +526,68 @@ func TestPromote(t *testing.T) {
 				options := &image.PromoteOptions{
 					TargetImageName: "targetregistry.test/targetnamespace/targetimage:1.2.3",
 					TargetImageTags: []string{
+						"targetregistry.test/targetnamespace/targetimage:1",
 						"targetregistry.test/targetnamespace/targetimage:tag",
 						"targetregistry.test/targetnamespace/targetimage:tag1",
 						"targetregistry.test/targetnamespace/targetimage:tag2",
-						"targetregistry.test/targetnamespace/targetimage:1",
 					},
 					RemoveTargetImageTags: true,
 					RemoteSourceImage:     true,
 					SourceImageName:       "registry.test/namespace/image:tag",
 					PullAuthUsername:      "username_pull",
 					PullAuthPassword:      "password_pull",
-					PushAuthUsername:      "username_push",
-					PushAuthPassword:      "password_push",
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_pull",
 					Password: "password_pull",
 				}, nil)
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetnamespace/targetimage").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+
+				mock := mock.NewMockPromote()
+				mock.On("Promote", context.TODO(), options).Return(nil)
+
+				factory := factory.NewPromoteFactory()
+				factory.Register(image.DockerPromoterName, mock)
+				p.factory = factory
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing promote application using token credentials",
+			service: &Application{
+				credentials:    authfactory.NewMockAuthFactory(),
+				semver:         semver.NewSemVerGenerator(),
+				referenceNamer: reference.NewDefaultReferenceName(),
+			},
+			context: context.TODO(),
+			options: &Options{
+				SourceImageName:         "registry.test/namespace/image:tag",
+				TargetImageName:         "targetimage",
+				TargetImageRegistryHost: "targetregistry.test",
+				TargetImageTags:         []string{"tag"},
+				RemoteSourceImage:       true,
+			},
+			prepareMockFunc: func(p *Application) {
+				options := &image.PromoteOptions{
+					TargetImageName:       "targetregistry.test/targetimage:tag",
+					TargetImageTags:       []string{},
+					RemoteSourceImage:     true,
+					SourceImageName:       "registry.test/namespace/image:tag",
+					PullAuthUsername:      "robot$pull",
+					PullAuthRegistryToken: "pull_token",
+					PushAuthIdentityToken: "push_refresh_token",
+				}
+
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodtoken.TokenAuthMethod{
+					Username: "robot$pull",
+					Token:    "pull_token",
+				}, nil)
+
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetimage").Return(&authmethodtoken.TokenAuthMethod{
+					RefreshToken: "push_refresh_token",
+				}, nil)
 
 				mock := mock.NewMockPromote()
 				mock.On("Promote", context.TODO(), options).Return(nil)
@@ -544,10 +596,10 @@ func TestPromote(t *testing.T) {
 				factory.Register(image.DockerPromoterName, mock)
 				p.factory = factory
 			},
-			err: errors.New(errContext, "Invalid credentials method for 'targetregistry.test'. Found 'keyfile' when is expected basic auth method"),
+			err: &errors.Error{},
 		},
 		{
-			desc: "Testing error on promote application when pull credentials are invalid",
+			desc: "Testing promote application ignoring keyfile pull credentials",
 			service: &Application{
 				credentials:    authfactory.NewMockAuthFactory(),
 				semver:         semver.NewSemVerGenerator(),
@@ -578,18 +630,16 @@ func TestPromote(t *testing.T) {
 					RemoveTargetImageTags: true,
 					RemoteSourceImage:     true,
 					SourceImageName:       "registry.test/namespace/image:tag",
-					PullAuthUsername:      "username_pull",
-					PullAuthPassword:      "password_pull",
 					PushAuthUsername:      "username_push",
 					PushAuthPassword:      "password_push",
 				}
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test").Return(&authmethodbasic.BasicAuthMethod{
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "targetregistry.test/targetnamespace/targetimage").Return(&authmethodbasic.BasicAuthMethod{
 					Username: "username_push",
 					Password: "password_push",
 				}, nil)
 
-				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(&authmethodkeyfile.KeyFileAuthMethod{}, nil)
 
 				mock := mock.NewMockPromote()
 				mock.On("Promote", context.TODO(), options).Return(nil)
@@ -598,7 +648,127 @@ func TestPromote(t *testing.T) {
 				factory.Register(image.DockerPromoterName, mock)
 				p.factory = factory
 			},
-			err: errors.New(errContext, "Invalid credentials method for 'registry.test'. Found 'keyfile' when is expected basic auth method"),
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing the promote application verifying the promoted image digest",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+				WithOutput(console.NewMockConsole()),
+			),
+			context: context.TODO(),
+			options: &Options{
+				RemoteSourceImage:            true,
+				SourceImageDigest:            "sha256:source",
+				SourceImageName:              "registry.test/namespace/image:tag",
+				TargetImageName:              image.UndefinedStringValue,
+				TargetImageRegistryHost:      image.UndefinedStringValue,
+				TargetImageRegistryNamespace: "stable",
+				TargetImageTags:              []string{"tag", "latest"},
+				VerifyDigest:                 true,
+			},
+			prepareMockFunc: func(p *Application) {
+				options := &image.PromoteOptions{
+					RemoteSourceImage: true,
+					SourceImageName:   "registry.test/namespace/image@sha256:source",
+					TargetImageName:   "registry.test/stable/image:tag",
+					TargetImageTags:   []string{"registry.test/stable/image:latest"},
+				}
+
+				mock := mock.NewMockPromote()
+				mock.On("Promote", context.TODO(), options).Return(nil)
+				p.factory.Register(image.DockerPromoterName, mock)
+
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(nil, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/stable/image").Return(nil, nil)
+
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/namespace/image:tag", "", "", "", "").Return("sha256:source", nil).Once()
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:tag", "", "", "", "").Return("sha256:source", nil)
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:latest", "", "", "", "").Return("sha256:source", nil)
+
+				p.output.(*console.MockConsole).On("PrintTable", [][]string{
+					{"SOURCE", "SOURCE DIGEST", "TARGET", "TARGET DIGEST", "STATUS"},
+					{"registry.test/namespace/image@sha256:source", "sha256:source", "registry.test/stable/image:tag", "sha256:source", DigestVerifiedStatus},
+					{"registry.test/namespace/image@sha256:source", "sha256:source", "registry.test/stable/image:latest", "sha256:source", DigestVerifiedStatus},
+				}).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing the promote application pinning the source image digest, which is always verified",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+				WithOutput(console.NewMockConsole()),
+			),
+			context: context.TODO(),
+			options: &Options{
+				RemoteSourceImage:            true,
+				SourceImageDigest:            "sha256:source",
+				SourceImageName:              "registry.test/namespace/image:tag",
+				TargetImageName:              image.UndefinedStringValue,
+				TargetImageRegistryHost:      image.UndefinedStringValue,
+				TargetImageRegistryNamespace: "stable",
+				TargetImageTags:              []string{"tag", "latest"},
+			},
+			prepareMockFunc: func(p *Application) {
+				options := &image.PromoteOptions{
+					RemoteSourceImage: true,
+					SourceImageName:   "registry.test/namespace/image@sha256:source",
+					TargetImageName:   "registry.test/stable/image:tag",
+					TargetImageTags:   []string{"registry.test/stable/image:latest"},
+				}
+
+				mock := mock.NewMockPromote()
+				mock.On("Promote", context.TODO(), options).Return(nil)
+				p.factory.Register(image.DockerPromoterName, mock)
+
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(nil, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/stable/image").Return(nil, nil)
+
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/namespace/image:tag", "", "", "", "").Return("sha256:source", nil).Once()
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:tag", "", "", "", "").Return("sha256:source", nil)
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:latest", "", "", "", "").Return("sha256:source", nil)
+
+				p.output.(*console.MockConsole).On("PrintTable", [][]string{
+					{"SOURCE", "SOURCE DIGEST", "TARGET", "TARGET DIGEST", "STATUS"},
+					{"registry.test/namespace/image@sha256:source", "sha256:source", "registry.test/stable/image:tag", "sha256:source", DigestVerifiedStatus},
+					{"registry.test/namespace/image@sha256:source", "sha256:source", "registry.test/stable/image:latest", "sha256:source", DigestVerifiedStatus},
+				}).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error on the promote application when the source image digest does not match the expected one",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+			),
+			context: context.TODO(),
+			options: &Options{
+				SourceImageDigest:            "sha256:tested",
+				SourceImageName:              "registry.test/namespace/image:tag",
+				TargetImageName:              image.UndefinedStringValue,
+				TargetImageRegistryHost:      image.UndefinedStringValue,
+				TargetImageRegistryNamespace: "stable",
+			},
+			prepareMockFunc: func(p *Application) {
+				p.factory.Register(image.DockerPromoterName, mock.NewMockPromote())
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/image").Return(nil, nil)
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/stable/image").Return(nil, nil)
+				p.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/namespace/image:tag", "", "", "", "").Return("sha256:moved", nil)
+			},
+			err: errors.New(errContext, "Image 'registry.test/namespace/image:tag' is not promoted because its digest 'sha256:moved' does not match the expected digest 'sha256:tested'. The source image has changed since its digest was taken"),
 		},
 	}
 
@@ -621,6 +791,652 @@ func TestPromote(t *testing.T) {
 	}
 }
 
+func TestPromoteDefinition(t *testing.T) {
+	errContext := "(application::promote::PromoteDefinition)"
+
+	parentImage := &image.Image{
+		Name:              "parent",
+		Version:           "1.2.3",
+		RegistryHost:      "registry.test",
+		RegistryNamespace: "namespace",
+		Tags:              []string{"latest"},
+	}
+	childImage := &image.Image{
+		Name:              "child",
+		Version:           "0.1.0",
+		RegistryHost:      "registry.test",
+		RegistryNamespace: "namespace",
+	}
+
+	tests := []struct {
+		desc              string
+		service           *Application
+		plan              Planner
+		name              string
+		versions          []string
+		options           *Options
+		prepareAssertFunc func(*Application, Planner)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc: "Testing error promoting an images definition when the plan is not provided",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithCommandFactory(command.NewMockPromoteCommandFactory()),
+				WithJobFactory(job.NewMockJobFactory()),
+				WithDispatch(dispatch.NewMockDispatch()),
+			),
+			options: &Options{},
+			err:     errors.New(errContext, "Promote application requires a plan to promote images definition"),
+		},
+		{
+			desc: "Testing error promoting an images definition when the dispatcher is not provided",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithCommandFactory(command.NewMockPromoteCommandFactory()),
+				WithJobFactory(job.NewMockJobFactory()),
+			),
+			plan:    plan.NewMockPlan(),
+			options: &Options{},
+			err:     errors.New(errContext, "Promote application requires a dispatcher to promote images definition"),
+		},
+		{
+			desc: "Testing promote an images definition with semantic version tags to a target registry and namespace",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithCommandFactory(command.NewMockPromoteCommandFactory()),
+				WithJobFactory(job.NewMockJobFactory()),
+				WithDispatch(dispatch.NewMockDispatch()),
+			),
+			plan:     plan.NewMockPlan(),
+			name:     "parent",
+			versions: []string{"1.2.3"},
+			options: &Options{
+				EnableSemanticVersionTags:    true,
+				SemanticVersionTagsTemplates: []string{"{{ .Major }}"},
+				RemoteSourceImage:            true,
+				TargetImageName:              image.UndefinedStringValue,
+				TargetImageRegistryHost:      "prod.test",
+				TargetImageRegistryNamespace: "stable",
+			},
+			prepareAssertFunc: func(a *Application, p Planner) {
+				mockJob := job.NewMockJob()
+				mockJob.On("Wait").Return(nil)
+				mockCommand := command.NewMockPromoteCommand()
+				mockPromoter := mock.NewMockPromote()
+
+				a.factory.Register(image.DockerPromoterName, mockPromoter)
+
+				parentStep := plan.NewStep(parentImage, "parent", nil)
+				childSync := make(chan struct{})
+				childStep := plan.NewStep(childImage, "child", childSync)
+				parentStep.Subscribe(childSync)
+
+				p.(*plan.MockPlan).On("Plan", "parent", []string{"1.2.3"}).Return([]*plan.Step{parentStep, childStep}, nil)
+
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/parent").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "pull_username",
+					Password: "pull_password",
+				}, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/child").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "pull_username",
+					Password: "pull_password",
+				}, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "prod.test/stable/parent").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "push_username",
+					Password: "push_password",
+				}, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "prod.test/stable/child").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "push_username",
+					Password: "push_password",
+				}, nil)
+
+				a.commandFactory.(*command.MockPromoteCommandFactory).On("New", mockPromoter, &image.PromoteOptions{
+					SourceImageName:   "registry.test/namespace/parent:1.2.3",
+					TargetImageName:   "prod.test/stable/parent:1.2.3",
+					TargetImageTags:   []string{"prod.test/stable/parent:1", "prod.test/stable/parent:latest"},
+					PullAuthUsername:  "pull_username",
+					PullAuthPassword:  "pull_password",
+					PushAuthUsername:  "push_username",
+					PushAuthPassword:  "push_password",
+					RemoteSourceImage: true,
+				}).Return(mockCommand)
+				a.commandFactory.(*command.MockPromoteCommandFactory).On("New", mockPromoter, &image.PromoteOptions{
+					SourceImageName:   "registry.test/namespace/child:0.1.0",
+					TargetImageName:   "prod.test/stable/child:0.1.0",
+					TargetImageTags:   []string{"prod.test/stable/child:0"},
+					PullAuthUsername:  "pull_username",
+					PullAuthPassword:  "pull_password",
+					PushAuthUsername:  "push_username",
+					PushAuthPassword:  "push_password",
+					RemoteSourceImage: true,
+				}).Return(mockCommand)
+
+				a.jobFactory.(*job.MockJobFactory).On("New", mockCommand).Return(mockJob)
+				a.dispatch.(*dispatch.MockDispatch).On("Enqueue", mockJob)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.commandFactory.(*command.MockPromoteCommandFactory).AssertExpectations(t)
+				a.jobFactory.(*job.MockJobFactory).AssertNumberOfCalls(t, "New", 2)
+				a.dispatch.(*dispatch.MockDispatch).AssertNumberOfCalls(t, "Enqueue", 2)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing promote an images definition selecting images by filter",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithCommandFactory(command.NewMockPromoteCommandFactory()),
+				WithJobFactory(job.NewMockJobFactory()),
+				WithDispatch(dispatch.NewMockDispatch()),
+				WithFilterFactory(operation.NewFilterOperationFactory()),
+				WithSelector(map[string]repository.ImagesSelector{
+					image.NameFilterAttribute: filter.NewImageNameFilter(),
+				}),
+			),
+			plan: plan.NewMockPlan(),
+			name: "parent",
+			options: &Options{
+				DryRun:                       true,
+				Filter:                       []string{"name=child"},
+				TargetImageName:              image.UndefinedStringValue,
+				TargetImageRegistryHost:      image.UndefinedStringValue,
+				TargetImageRegistryNamespace: "stable",
+			},
+			prepareAssertFunc: func(a *Application, p Planner) {
+				mockJob := job.NewMockJob()
+				mockJob.On("Wait").Return(nil)
+				mockCommand := command.NewMockPromoteCommand()
+				mockPromoter := mock.NewMockPromote()
+
+				a.factory.Register(image.DryRunPromoterName, mockPromoter)
+
+				p.(*plan.MockPlan).On("Plan", "parent", []string(nil)).Return([]*plan.Step{
+					plan.NewStep(parentImage, "parent", nil),
+					plan.NewStep(childImage, "child", nil),
+				}, nil)
+
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/child").Return(nil, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/stable/child").Return(nil, nil)
+
+				a.commandFactory.(*command.MockPromoteCommandFactory).On("New", mockPromoter, &image.PromoteOptions{
+					SourceImageName: "registry.test/namespace/child:0.1.0",
+					TargetImageName: "registry.test/stable/child:0.1.0",
+					TargetImageTags: []string{},
+				}).Return(mockCommand)
+
+				a.jobFactory.(*job.MockJobFactory).On("New", mockCommand).Return(mockJob)
+				a.dispatch.(*dispatch.MockDispatch).On("Enqueue", mockJob)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.commandFactory.(*command.MockPromoteCommandFactory).AssertExpectations(t)
+				a.dispatch.(*dispatch.MockDispatch).AssertNumberOfCalls(t, "Enqueue", 1)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error promoting an images definition when the filters discard all the images",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithCommandFactory(command.NewMockPromoteCommandFactory()),
+				WithJobFactory(job.NewMockJobFactory()),
+				WithDispatch(dispatch.NewMockDispatch()),
+				WithFilterFactory(operation.NewFilterOperationFactory()),
+				WithSelector(map[string]repository.ImagesSelector{
+					image.NameFilterAttribute: filter.NewImageNameFilter(),
+				}),
+			),
+			plan: plan.NewMockPlan(),
+			name: "parent",
+			options: &Options{
+				Filter: []string{"name=unknown"},
+			},
+			prepareAssertFunc: func(a *Application, p Planner) {
+				p.(*plan.MockPlan).On("Plan", "parent", []string(nil)).Return([]*plan.Step{
+					plan.NewStep(parentImage, "parent", nil),
+				}, nil)
+			},
+			err: errors.New(errContext, "There is no image to promote from 'parent' definition"),
+		},
+		{
+			desc: "Testing error promoting an images definition when a job fails",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithSemver(semver.NewSemVerGenerator()),
+				WithPromoteFactory(factory.NewPromoteFactory()),
+				WithReferenceNamer(reference.NewDefaultReferenceName()),
+				WithCommandFactory(command.NewMockPromoteCommandFactory()),
+				WithJobFactory(job.NewMockJobFactory()),
+				WithDispatch(dispatch.NewMockDispatch()),
+			),
+			plan: plan.NewMockPlan(),
+			name: "child",
+			options: &Options{
+				TargetImageRegistryHost:      image.UndefinedStringValue,
+				TargetImageRegistryNamespace: "stable",
+			},
+			prepareAssertFunc: func(a *Application, p Planner) {
+				mockJob := job.NewMockJob()
+				mockJob.On("Wait").Return(errors.New("", "job error"))
+				mockCommand := command.NewMockPromoteCommand()
+				mockPromoter := mock.NewMockPromote()
+
+				a.factory.Register(image.DockerPromoterName, mockPromoter)
+
+				p.(*plan.MockPlan).On("Plan", "child", []string(nil)).Return([]*plan.Step{
+					plan.NewStep(childImage, "child", nil),
+				}, nil)
+
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/namespace/child").Return(nil, nil)
+				a.credentials.(*authfactory.MockAuthFactory).On("Get", "registry.test/stable/child").Return(nil, nil)
+				a.commandFactory.(*command.MockPromoteCommandFactory).On("New", mockPromoter, &image.PromoteOptions{
+					SourceImageName: "registry.test/namespace/child:0.1.0",
+					TargetImageName: "registry.test/stable/child:0.1.0",
+					TargetImageTags: []string{},
+				}).Return(mockCommand)
+				a.jobFactory.(*job.MockJobFactory).On("New", mockCommand).Return(mockJob)
+				a.dispatch.(*dispatch.MockDispatch).On("Enqueue", mockJob)
+			},
+			err: errors.New(errContext, "Image 'registry.test/namespace/child:0.1.0' could not be promoted\n job error\n"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.service, test.plan)
+			}
+
+			err := test.service.PromoteDefinition(context.TODO(), test.plan, test.name, test.versions, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.service)
+			}
+		})
+	}
+}
+
+func TestVerifyDigest(t *testing.T) {
+	errContext := "(application::promote::verifyDigest)"
+
+	options := &image.PromoteOptions{
+		SourceImageName:  "registry.test/namespace/image:tag",
+		TargetImageName:  "registry.test/stable/image:tag",
+		TargetImageTags:  []string{"registry.test/stable/image:latest"},
+		PullAuthUsername: "pull_username",
+		PullAuthPassword: "pull_password",
+		PushAuthUsername: "push_username",
+		PushAuthPassword: "push_password",
+	}
+
+	tests := []struct {
+		desc              string
+		service           *Application
+		sourceDigest      string
+		prepareAssertFunc func(*Application)
+		res               [][]string
+		err               error
+	}{
+		{
+			desc:    "Testing error verifying digests without a digest resolver",
+			service: NewApplication(),
+			err:     errors.New(errContext, "To verify the promoted images digest, is required a digest resolver"),
+		},
+		{
+			desc:         "Testing verify digests when the source digest is already known",
+			service:      NewApplication(WithDigestResolver(registry.NewMockDigestResolver())),
+			sourceDigest: "sha256:source",
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:tag", "push_username", "push_password", "", "").Return("sha256:source", nil)
+				a.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:latest", "push_username", "push_password", "", "").Return("sha256:source", nil)
+			},
+			res: [][]string{
+				{"registry.test/namespace/image:tag", "sha256:source", "registry.test/stable/image:tag", "sha256:source", DigestVerifiedStatus},
+				{"registry.test/namespace/image:tag", "sha256:source", "registry.test/stable/image:latest", "sha256:source", DigestVerifiedStatus},
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing error verifying digests when a target digest does not match",
+			service: NewApplication(WithDigestResolver(registry.NewMockDigestResolver())),
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/namespace/image:tag", "pull_username", "pull_password", "", "").Return("sha256:source", nil)
+				a.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:tag", "push_username", "push_password", "", "").Return("sha256:source", nil)
+				a.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/stable/image:latest", "push_username", "push_password", "", "").Return("sha256:other", nil)
+			},
+			err: errors.New(errContext, "Digest of 'registry.test/stable/image:latest' does not match the source image 'registry.test/namespace/image:tag' digest 'sha256:source'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.service)
+			}
+
+			res, err := test.service.verifyDigest(context.TODO(), options, test.sourceDigest)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestVerifyDigestUsingTokens(t *testing.T) {
+	t.Log("Testing verify digests resolving them with the registry tokens")
+
+	options := &image.PromoteOptions{
+		SourceImageName:       "registry.test/namespace/image:tag",
+		TargetImageName:       "registry.test/stable/image:tag",
+		PullAuthUsername:      "pull_username",
+		PullAuthIdentityToken: "pull_identity_token",
+		PushAuthRegistryToken: "push_registry_token",
+	}
+
+	digestResolver := registry.NewMockDigestResolver()
+	digestResolver.On("Digest", context.TODO(), "registry.test/namespace/image:tag", "pull_username", "", "pull_identity_token", "").Return("sha256:source", nil)
+	digestResolver.On("Digest", context.TODO(), "registry.test/stable/image:tag", "", "", "", "push_registry_token").Return("sha256:source", nil)
+
+	res, err := NewApplication(WithDigestResolver(digestResolver)).verifyDigest(context.TODO(), options, "")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{
+		{"registry.test/namespace/image:tag", "sha256:source", "registry.test/stable/image:tag", "sha256:source", DigestVerifiedStatus},
+	}, res)
+	digestResolver.AssertExpectations(t)
+}
+
+func TestCheckPolicy(t *testing.T) {
+	errContext := "(application::promote::checkPolicy)"
+
+	policy := &image.PromotionPolicy{
+		Rules: []*image.PromotionRule{
+			{
+				Name:           "staging-to-prod",
+				Sources:        []string{"registry.staging.test/*/*"},
+				Targets:        []string{"registry.prod.test/stable/*"},
+				RequiredLabels: map[string]string{"qa.approved": "true"},
+				VersionPattern: `^\d+\.\d+\.\d+$`,
+			},
+		},
+	}
+
+	tests := []struct {
+		desc              string
+		service           *Application
+		options           *image.PromoteOptions
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc:    "Testing check policy when no policy is defined",
+			service: NewApplication(),
+			options: &image.PromoteOptions{
+				SourceImageName: "registry.staging.test/namespace/image:1.2.3",
+				TargetImageName: "registry.prod.test/unstable/image:1.2.3",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing check policy when the promotion is allowed",
+			service: NewApplication(
+				WithPolicy(policy),
+				WithLabelsResolver(registry.NewMockLabelsResolver()),
+			),
+			options: &image.PromoteOptions{
+				SourceImageName:  "registry.staging.test/namespace/image:1.2.3",
+				TargetImageName:  "registry.prod.test/stable/image:1.2.3",
+				TargetImageTags:  []string{"registry.prod.test/stable/image:1.2"},
+				PullAuthUsername: "pull_username",
+				PullAuthPassword: "pull_password",
+			},
+			prepareAssertFunc: func(a *Application) {
+				a.labelsResolver.(*registry.MockLabelsResolver).On("Labels", context.TODO(), "registry.staging.test/namespace/image:1.2.3", "pull_username", "pull_password", "", "").Return(map[string]string{"qa.approved": "true"}, nil).Once()
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing error checking policy when there is no route to the target",
+			service: NewApplication(WithPolicy(policy)),
+			options: &image.PromoteOptions{
+				SourceImageName: "registry.staging.test/namespace/image:1.2.3",
+				TargetImageName: "registry.prod.test/unstable/image:1.2.3",
+			},
+			err: errors.New(errContext, "Promotion policy denies promoting 'registry.staging.test/namespace/image:1.2.3': there is no rule that allows promoting to 'registry.prod.test/unstable/image:1.2.3'"),
+		},
+		{
+			desc: "Testing error checking policy when the rule requirements are not fulfilled",
+			service: NewApplication(
+				WithPolicy(policy),
+				WithLabelsResolver(registry.NewMockLabelsResolver()),
+			),
+			options: &image.PromoteOptions{
+				SourceImageName: "registry.staging.test/namespace/image:1.2.3-rc.1",
+				TargetImageName: "registry.prod.test/stable/image:1.2.3-rc.1",
+			},
+			prepareAssertFunc: func(a *Application) {
+				a.labelsResolver.(*registry.MockLabelsResolver).On("Labels", context.TODO(), "registry.staging.test/namespace/image:1.2.3-rc.1", "", "", "", "").Return(map[string]string{}, nil)
+			},
+			err: errors.New(errContext, "Promotion policy denies promoting 'registry.staging.test/namespace/image:1.2.3-rc.1': promoting to 'registry.prod.test/stable/image:1.2.3-rc.1' is denied. Rule 'staging-to-prod' is not fulfilled: version '1.2.3-rc.1' does not match '^\\d+\\.\\d+\\.\\d+$', label 'qa.approved=true' is missing"),
+		},
+		{
+			desc:    "Testing error checking policy without a labels resolver",
+			service: NewApplication(WithPolicy(policy)),
+			options: &image.PromoteOptions{
+				SourceImageName: "registry.staging.test/namespace/image:1.2.3",
+				TargetImageName: "registry.prod.test/stable/image:1.2.3",
+			},
+			err: errors.New(errContext, "To check the promotion policy required labels, is required a labels resolver"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.service)
+			}
+
+			err := test.service.checkPolicy(context.TODO(), test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				if test.service.labelsResolver != nil {
+					test.service.labelsResolver.(*registry.MockLabelsResolver).AssertExpectations(t)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckImmutableTags(t *testing.T) {
+	errContext := "(application::promote::checkImmutableTags)"
+
+	immutableTags, _ := image.NewImmutableTags([]string{"stable/*"}, []string{"^latest$"})
+
+	options := &image.PromoteOptions{
+		SourceImageName:  "registry.test/namespace/image:tag",
+		TargetImageName:  "registry.test/stable/image:tag",
+		TargetImageTags:  []string{"registry.test/stable/image:latest"},
+		PullAuthUsername: "pull_username",
+		PullAuthPassword: "pull_password",
+		PushAuthUsername: "push_username",
+		PushAuthPassword: "push_password",
+	}
+
+	tests := []struct {
+		desc              string
+		service           *Application
+		sourceDigest      string
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc:    "Testing check immutable tags when no immutable tags are defined",
+			service: NewApplication(),
+			err:     &errors.Error{},
+		},
+		{
+			desc:    "Testing error checking immutable tags without a digest resolver",
+			service: NewApplication(WithImmutableTags(immutableTags)),
+			err:     errors.New(errContext, "To check the immutable tags, is required a digest resolver"),
+		},
+		{
+			desc: "Testing check immutable tags when the immutable tag does not exist",
+			service: NewApplication(
+				WithImmutableTags(immutableTags),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Lookup", context.TODO(), "registry.test/stable/image:tag", "push_username", "push_password", "", "").Return("", false, nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing check immutable tags when the immutable tag exists with the source image digest",
+			service: NewApplication(
+				WithImmutableTags(immutableTags),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+			),
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Lookup", context.TODO(), "registry.test/stable/image:tag", "push_username", "push_password", "", "").Return("sha256:source", true, nil)
+				a.digestResolver.(*registry.MockDigestResolver).On("Digest", context.TODO(), "registry.test/namespace/image:tag", "pull_username", "pull_password", "", "").Return("sha256:source", nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error checking immutable tags when the immutable tag exists with a different digest",
+			service: NewApplication(
+				WithImmutableTags(immutableTags),
+				WithDigestResolver(registry.NewMockDigestResolver()),
+			),
+			sourceDigest: "sha256:source",
+			prepareAssertFunc: func(a *Application) {
+				a.digestResolver.(*registry.MockDigestResolver).On("Lookup", context.TODO(), "registry.test/stable/image:tag", "push_username", "push_password", "", "").Return("sha256:other", true, nil)
+			},
+			err: errors.New(errContext, "Immutable tag 'registry.test/stable/image:tag' already exists with a digest that differs from the source image 'registry.test/namespace/image:tag' digest 'sha256:source'. Use force to overwrite it"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.service)
+			}
+
+			err := test.service.checkImmutableTags(context.TODO(), options, test.sourceDigest)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				if test.service.digestResolver != nil {
+					test.service.digestResolver.(*registry.MockDigestResolver).AssertExpectations(t)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectImages(t *testing.T) {
+	errContext := "(application::promote::selectImages)"
+
+	images := []*image.Image{
+		{Name: "image1", Version: "1.0.0", RegistryNamespace: "namespace"},
+		{Name: "image2", Version: "1.0.0", RegistryNamespace: "namespace"},
+		{Name: "image2", Version: "2.0.0", RegistryNamespace: "other"},
+	}
+
+	tests := []struct {
+		desc    string
+		service *Application
+		filters []string
+		res     []*image.Image
+		err     error
+	}{
+		{
+			desc:    "Testing select images without filters",
+			service: NewApplication(),
+			res:     images,
+			err:     &errors.Error{},
+		},
+		{
+			desc:    "Testing error selecting images without filter factory",
+			service: NewApplication(),
+			filters: []string{"name=image1"},
+			err:     errors.New(errContext, "To filter the images to promote, is required a filter factory"),
+		},
+		{
+			desc: "Testing select images by name and namespace",
+			service: NewApplication(
+				WithFilterFactory(operation.NewFilterOperationFactory()),
+				WithSelector(map[string]repository.ImagesSelector{
+					image.NameFilterAttribute:              filter.NewImageNameFilter(),
+					image.RegistryNamespaceFilterAttribute: filter.NewImageNamespaceFilter(),
+				}),
+			),
+			filters: []string{"name=image2", "namespace=other"},
+			res:     []*image.Image{images[2]},
+			err:     &errors.Error{},
+		},
+		{
+			desc: "Testing error selecting images with an invalid filter",
+			service: NewApplication(
+				WithFilterFactory(operation.NewFilterOperationFactory()),
+			),
+			filters: []string{"image1"},
+			err:     errors.New(errContext, "Invalid filter 'image1'"),
+		},
+		{
+			desc: "Testing error selecting images with an unsupported filter attribute",
+			service: NewApplication(
+				WithFilterFactory(operation.NewFilterOperationFactory()),
+				WithSelector(map[string]repository.ImagesSelector{}),
+			),
+			filters: []string{"builder=docker"},
+			err:     errors.New(errContext, "Filter attribute 'builder' is not supported"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.service.selectImages(images, test.filters)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
 func TestGetCredentials(t *testing.T) {
 	errContext := "(Service::getCredentials)"
 
@@ -655,6 +1471,18 @@ func TestGetCredentials(t *testing.T) {
 				Password: "password",
 			},
 		},
+		{
+			desc: "Testing error getting credentials",
+			service: &Application{
+				credentials: authfactory.NewMockAuthFactory(),
+			},
+			registry: "myregistry",
+			prepareMockFunc: func(p *Application) {
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "myregistry").Return(nil, errors.New(errContext, "AWS ECR authorization token could not be achieved"))
+			},
+			err: errors.New(errContext, "Credentials for 'myregistry' could not be achieved",
+				errors.New(errContext, "AWS ECR authorization token could not be achieved")),
+		},
 	}
 
 	for _, test := range tests {
@@ -675,6 +1503,56 @@ func TestGetCredentials(t *testing.T) {
 	}
 }
 
+func TestGetRegistryAuth(t *testing.T) {
+
+	tests := []struct {
+		desc            string
+		service         *Application
+		registry        string
+		prepareMockFunc func(*Application)
+		res             repository.AuthMethodReader
+		err             error
+	}{
+		{
+			desc: "Testing get registry auth warning about the credentials about to expire",
+			service: NewApplication(
+				WithCredentials(authfactory.NewMockAuthFactory()),
+				WithCredentialsExpirationWarner(expiration.NewMockExpirationWarner()),
+			),
+			registry: "myregistry/namespace/image",
+			prepareMockFunc: func(p *Application) {
+				p.expiration.(*expiration.MockExpirationWarner).On("WarnExpiring", []string{"myregistry/namespace/image"})
+				p.credentials.(*authfactory.MockAuthFactory).On("Get", "myregistry/namespace/image").Return(&authmethodbasic.BasicAuthMethod{
+					Username: "username",
+					Password: "password",
+				}, nil)
+			},
+			res: &authmethodbasic.BasicAuthMethod{
+				Username: "username",
+				Password: "password",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareMockFunc != nil {
+				test.prepareMockFunc(test.service)
+			}
+
+			res, err := test.service.getRegistryAuth(test.registry)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+				test.service.expiration.(*expiration.MockExpirationWarner).AssertExpectations(t)
+			}
+		})
+	}
+}
+
 func TestGetPromoter(t *testing.T) {
 
 	errContext := "(Handler::getPromoter)"
@@ -719,6 +1597,47 @@ func TestGetPromoter(t *testing.T) {
 			res: &dryrun.DryRunPromote{},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing get registry promoter",
+			service: &Application{
+				factory: factory.NewPromoteFactory(),
+			},
+			options: &Options{
+				Promoter: image.RegistryPromoterName,
+			},
+			prepareAssertFunc: func(p *Application) {
+				p.factory.Register(image.DockerPromoterName, &docker.DockerPromete{})
+				p.factory.Register(image.RegistryPromoterName, &registry.RegistryPromote{})
+			},
+			res: &registry.RegistryPromote{},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get promoter with dry-run when registry promoter is requested",
+			service: &Application{
+				factory: factory.NewPromoteFactory(),
+			},
+			options: &Options{
+				DryRun:   true,
+				Promoter: image.RegistryPromoterName,
+			},
+			prepareAssertFunc: func(p *Application) {
+				p.factory.Register(image.RegistryPromoterName, &registry.RegistryPromote{})
+				p.factory.Register(image.DryRunPromoterName, &dryrun.DryRunPromote{})
+			},
+			res: &dryrun.DryRunPromote{},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error getting an unregistered promoter",
+			service: &Application{
+				factory: factory.NewPromoteFactory(),
+			},
+			options: &Options{
+				Promoter: "unknown",
+			},
+			err: errors.New(errContext, "Promoter 'unknown' has not been registered"),
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,72 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to rename credentials
+type Application struct {
+	store repository.CredentialsRenamer
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentialsStore provides a function to configure the credentials store
+func WithCredentialsStore(store repository.CredentialsRenamer) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run method carries out the application tasks
+func (a *Application) Run(ctx context.Context, id, newID string, optionsFunc ...OptionsFunc) error {
+	var err error
+
+	errContext := "(application::rename::credentials::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.store == nil {
+		return errors.New(errContext, "To run the rename credentials application, a credentials store must be provided")
+	}
+
+	if id == "" {
+		return errors.New(errContext, "To run the rename credentials application, a id for credentials must be provided")
+	}
+
+	if newID == "" {
+		return errors.New(errContext, "To run the rename credentials application, a new id for credentials must be provided")
+	}
+
+	if id == newID {
+		return errors.New(errContext, fmt.Sprintf("To run the rename credentials application, the new id must differ from '%s'", id))
+	}
+
+	err = a.store.Rename(id, newID)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error renaming '%s' credentials to '%s'", id, newID), err)
+	}
+
+	return nil
+}
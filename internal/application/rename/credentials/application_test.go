@@ -0,0 +1,72 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	errContext := "(application::rename::credentials::Run)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		id                string
+		newID             string
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc:  "Testing error running rename credentials application without store",
+			app:   NewApplication(),
+			id:    "id",
+			newID: "new-id",
+			err:   errors.New(errContext, "To run the rename credentials application, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing error running rename credentials application without new credential id",
+			app:  NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:   "id",
+			err:  errors.New(errContext, "To run the rename credentials application, a new id for credentials must be provided"),
+		},
+		{
+			desc:  "Testing error running rename credentials application when the new id is the same",
+			app:   NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:    "id",
+			newID: "id",
+			err:   errors.New(errContext, "To run the rename credentials application, the new id must differ from 'id'"),
+		},
+		{
+			desc:  "Testing run rename credentials application",
+			app:   NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:    "id",
+			newID: "new-id",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Rename", "id", "new-id").Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Run(context.TODO(), test.id, test.newID)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.app.store.(*mock.MockStore).AssertExpectations(t)
+			}
+		})
+	}
+}
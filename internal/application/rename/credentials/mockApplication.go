@@ -0,0 +1,23 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of rename credentials application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of rename credentials application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, id, newID, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, id, newID string, optionsFunc ...OptionsFunc) error {
+	args := m.Called(ctx, id, newID, optionsFunc)
+	return args.Error(0)
+}
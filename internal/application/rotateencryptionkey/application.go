@@ -0,0 +1,91 @@
+package rotateencryptionkey
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to rotate the credentials encryption key
+type Application struct {
+	store         repository.CredentialsRekeyer
+	encryption    repository.CredentialsEncrypter
+	configuration EncryptionKeyWriter
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentialsStore provides a function to configure the credentials store
+func WithCredentialsStore(store repository.CredentialsRekeyer) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// WithEncryption provides a function to configure the encryption based on the new encryption key
+func WithEncryption(encryption repository.CredentialsEncrypter) OptionsFunc {
+	return func(a *Application) {
+		a.encryption = encryption
+	}
+}
+
+// WithConfiguration provides a function to configure where the new encryption key is persisted
+func WithConfiguration(configuration EncryptionKeyWriter) OptionsFunc {
+	return func(a *Application) {
+		a.configuration = configuration
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run method re-encrypts all the credentials with the new encryption and persists the new encryption key on the configuration, when a configuration writer is provided
+func (a *Application) Run(ctx context.Context, key string, optionsFunc ...OptionsFunc) error {
+	var err error
+
+	errContext := "(application::rotateencryptionkey::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.store == nil {
+		return errors.New(errContext, "To run the rotate encryption key application, a credentials store must be provided")
+	}
+
+	if a.encryption == nil {
+		return errors.New(errContext, "To run the rotate encryption key application, the new encryption must be provided")
+	}
+
+	if key == "" {
+		return errors.New(errContext, "To run the rotate encryption key application, the new encryption key must be provided")
+	}
+
+	err = a.store.Rekey(a.encryption)
+	if err != nil {
+		return errors.New(errContext, "Error re-encrypting the credentials with the new encryption key", err)
+	}
+
+	if a.configuration != nil {
+		err = a.configuration.WriteEncryptionKey(key)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Credentials have been re-encrypted but the new encryption key could not be written on the configuration. You must set '%s' as the credentials encryption key", key), err)
+		}
+	}
+
+	return nil
+}
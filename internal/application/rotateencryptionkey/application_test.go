@@ -0,0 +1,128 @@
+package rotateencryptionkey
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	output "github.com/gostevedore/stevedore/internal/infrastructure/configuration/output/mock"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	errContext := "(application::rotateencryptionkey::Run)"
+
+	newEncryption := encryption.NewMockEncryption()
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		key               string
+		prepareAssertFunc func(*Application)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc: "Testing error running rotate encryption key application without store",
+			app:  NewApplication(),
+			key:  "new-key",
+			err:  errors.New(errContext, "To run the rotate encryption key application, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing error running rotate encryption key application without encryption",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+			),
+			key: "new-key",
+			err: errors.New(errContext, "To run the rotate encryption key application, the new encryption must be provided"),
+		},
+		{
+			desc: "Testing error running rotate encryption key application without key",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithEncryption(newEncryption),
+			),
+			err: errors.New(errContext, "To run the rotate encryption key application, the new encryption key must be provided"),
+		},
+		{
+			desc: "Testing error running rotate encryption key application when credentials can not be re-encrypted",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithEncryption(newEncryption),
+				WithConfiguration(output.NewConfigurationMockOutput()),
+			),
+			key: "new-key",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Rekey", newEncryption).Return(errors.New("", "error rekeying"))
+			},
+			err: errors.New(errContext, "Error re-encrypting the credentials with the new encryption key", errors.New("", "error rekeying")),
+		},
+		{
+			desc: "Testing error running rotate encryption key application when the key can not be written on the configuration",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithEncryption(newEncryption),
+				WithConfiguration(output.NewConfigurationMockOutput()),
+			),
+			key: "new-key",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Rekey", newEncryption).Return(nil)
+				a.configuration.(*output.ConfigurationMockOutput).On("WriteEncryptionKey", "new-key").Return(errors.New("", "error writing"))
+			},
+			err: errors.New(errContext, "Credentials have been re-encrypted but the new encryption key could not be written on the configuration. You must set 'new-key' as the credentials encryption key", errors.New("", "error writing")),
+		},
+		{
+			desc: "Testing run rotate encryption key application",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithEncryption(newEncryption),
+				WithConfiguration(output.NewConfigurationMockOutput()),
+			),
+			key: "new-key",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Rekey", newEncryption).Return(nil)
+				a.configuration.(*output.ConfigurationMockOutput).On("WriteEncryptionKey", "new-key").Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.store.(*mock.MockStore).AssertExpectations(t)
+				a.configuration.(*output.ConfigurationMockOutput).AssertExpectations(t)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing run rotate encryption key application without configuration writer",
+			app: NewApplication(
+				WithCredentialsStore(mock.NewMockStore()),
+				WithEncryption(newEncryption),
+			),
+			key: "new-key",
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Rekey", newEncryption).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.store.(*mock.MockStore).AssertExpectations(t)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Run(context.TODO(), test.key)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.app)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package rotateencryptionkey
+
+// EncryptionKeyWriter is the interface to persist the encryption key on the configuration
+type EncryptionKeyWriter interface {
+	WriteEncryptionKey(key string) error
+}
@@ -0,0 +1,23 @@
+package rotateencryptionkey
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of rotate encryption key application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of rotate encryption key application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, key, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, key string, optionsFunc ...OptionsFunc) error {
+	args := m.Called(ctx, key, optionsFunc)
+	return args.Error(0)
+}
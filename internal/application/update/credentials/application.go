@@ -0,0 +1,149 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to update credentials
+type Application struct {
+	store repository.CredentialsStorer
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithCredentialsStore provides a function to configure the credentials store
+func WithCredentialsStore(store repository.CredentialsStorer) OptionsFunc {
+	return func(a *Application) {
+		a.store = store
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run method carries out the application tasks. It updates the existing credential with the attributes set on the update credential, keeping the rest of them
+func (a *Application) Run(ctx context.Context, id string, update *credentials.Credential, optionsFunc ...OptionsFunc) error {
+	var err error
+	var credential *credentials.Credential
+
+	errContext := "(application::update::credentials::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.store == nil {
+		return errors.New(errContext, "To run the update credentials application, a credentials store must be provided")
+	}
+
+	if id == "" {
+		return errors.New(errContext, "To run the update credentials application, a id for credentials must be provided")
+	}
+
+	if update == nil {
+		return errors.New(errContext, "To run the update credentials application, a credential must be provided")
+	}
+
+	credential, err = a.store.Get(id)
+	if err != nil && !credentials.IsNotFoundError(err) {
+		return errors.New(errContext, fmt.Sprintf("Error achieving '%s' credentials", id), err)
+	}
+
+	if credential == nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' does not exist", id))
+	}
+
+	mergeCredential(credential, update)
+	credential.ID = id
+
+	_, err = credential.IsValid()
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = a.store.Store(id, credential)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error storing '%s' credentials", id), err)
+	}
+
+	return nil
+}
+
+// mergeCredential sets on the credential those attributes defined on the update credential
+func mergeCredential(credential, update *credentials.Credential) {
+
+	if update.AllowUseSSHAgent {
+		credential.AllowUseSSHAgent = update.AllowUseSSHAgent
+	}
+	if update.AWSAccessKeyID != "" {
+		credential.AWSAccessKeyID = update.AWSAccessKeyID
+	}
+	if update.AWSProfile != "" {
+		credential.AWSProfile = update.AWSProfile
+	}
+	if update.AWSRegion != "" {
+		credential.AWSRegion = update.AWSRegion
+	}
+	if update.AWSRoleARN != "" {
+		credential.AWSRoleARN = update.AWSRoleARN
+	}
+	if update.AWSSecretAccessKey != "" {
+		credential.AWSSecretAccessKey = update.AWSSecretAccessKey
+	}
+	if len(update.AWSSharedConfigFiles) > 0 {
+		credential.AWSSharedConfigFiles = append([]string{}, update.AWSSharedConfigFiles...)
+	}
+	if len(update.AWSSharedCredentialsFiles) > 0 {
+		credential.AWSSharedCredentialsFiles = append([]string{}, update.AWSSharedCredentialsFiles...)
+	}
+	if update.AWSUseDefaultCredentialsChain {
+		credential.AWSUseDefaultCredentialsChain = update.AWSUseDefaultCredentialsChain
+	}
+	if update.CredentialProcess != "" {
+		credential.CredentialProcess = update.CredentialProcess
+	}
+	if update.Description != "" {
+		credential.Description = update.Description
+	}
+	if update.ExpiresAt != "" {
+		credential.ExpiresAt = update.ExpiresAt
+	}
+	if update.GitSSHUser != "" {
+		credential.GitSSHUser = update.GitSSHUser
+	}
+	if update.Password != "" {
+		credential.Password = update.Password
+	}
+	if update.PrivateKeyFile != "" {
+		credential.PrivateKeyFile = update.PrivateKeyFile
+	}
+	if update.PrivateKeyPassword != "" {
+		credential.PrivateKeyPassword = update.PrivateKeyPassword
+	}
+	if update.RefreshToken != "" {
+		credential.RefreshToken = update.RefreshToken
+	}
+	if update.Token != "" {
+		credential.Token = update.Token
+	}
+	if update.Username != "" {
+		credential.Username = update.Username
+	}
+}
@@ -0,0 +1,142 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+
+	errContext := "(application::update::credentials::Run)"
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		id                string
+		update            *credentials.Credential
+		prepareAssertFunc func(*Application)
+		err               error
+	}{
+		{
+			desc:   "Testing error running update credentials application without store",
+			app:    NewApplication(),
+			id:     "id",
+			update: &credentials.Credential{},
+			err:    errors.New(errContext, "To run the update credentials application, a credentials store must be provided"),
+		},
+		{
+			desc: "Testing error running update credentials application without credential",
+			app:  NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:   "id",
+			err:  errors.New(errContext, "To run the update credentials application, a credential must be provided"),
+		},
+		{
+			desc:   "Testing error running update credentials application when credentials does not exist",
+			app:    NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:     "id",
+			update: &credentials.Credential{Username: "new-username"},
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Get", "id").Return(nil, credentials.NewNotFoundError("id"))
+			},
+			err: errors.New(errContext, "Credentials 'id' does not exist"),
+		},
+		{
+			desc:   "Testing error running update credentials application when the updated credential is not valid",
+			app:    NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:     "id",
+			update: &credentials.Credential{Username: "username"},
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Get", "id").Return(&credentials.Credential{
+					ID:         "id",
+					GitSSHUser: "git",
+				}, nil)
+			},
+			err: errors.New(errContext, "", errors.New("(core::domain::credentials::IsValid)", "Invalid credential. Missing password")),
+		},
+		{
+			desc:   "Testing run update credentials application keeping the existing password",
+			app:    NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:     "id",
+			update: &credentials.Credential{Username: "new-username"},
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Get", "id").Return(&credentials.Credential{
+					ID:       "id",
+					Username: "username",
+					Password: "password",
+				}, nil)
+				a.store.(*mock.MockStore).On("Store", "id", &credentials.Credential{
+					ID:       "id",
+					Username: "new-username",
+					Password: "password",
+				}).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing run update credentials application updating the token",
+			app:    NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:     "id",
+			update: &credentials.Credential{Token: "new-token"},
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Get", "id").Return(&credentials.Credential{
+					ID:           "id",
+					Username:     "robot",
+					Token:        "token",
+					RefreshToken: "refresh-token",
+				}, nil)
+				a.store.(*mock.MockStore).On("Store", "id", &credentials.Credential{
+					ID:           "id",
+					Username:     "robot",
+					Token:        "new-token",
+					RefreshToken: "refresh-token",
+				}).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing run update credentials application updating the expiration and description",
+			app:    NewApplication(WithCredentialsStore(mock.NewMockStore())),
+			id:     "id",
+			update: &credentials.Credential{ExpiresAt: "2025-01-01T00:00:00Z", Description: "rotated"},
+			prepareAssertFunc: func(a *Application) {
+				a.store.(*mock.MockStore).On("Get", "id").Return(&credentials.Credential{
+					ID:          "id",
+					Username:    "username",
+					Password:    "password",
+					ExpiresAt:   "2024-01-01T00:00:00Z",
+					Description: "registry account",
+				}, nil)
+				a.store.(*mock.MockStore).On("Store", "id", &credentials.Credential{
+					ID:          "id",
+					Username:    "username",
+					Password:    "password",
+					ExpiresAt:   "2025-01-01T00:00:00Z",
+					Description: "rotated",
+				}).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Run(context.TODO(), test.id, test.update)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.app.store.(*mock.MockStore).AssertExpectations(t)
+			}
+		})
+	}
+}
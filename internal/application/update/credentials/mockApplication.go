@@ -0,0 +1,24 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of update credentials application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of update credentials application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, id, update, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, id string, update *credentials.Credential, optionsFunc ...OptionsFunc) error {
+	args := m.Called(ctx, id, update, optionsFunc)
+	return args.Error(0)
+}
@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// OptionsFunc is a function used to configure the service
+type OptionsFunc func(*Application)
+
+// Application is an application service to validate the configuration, builders and images files
+type Application struct {
+	validator repository.Validator
+	output    repository.ValidationPrinter
+}
+
+// NewApplication creates a new application service
+func NewApplication(options ...OptionsFunc) *Application {
+
+	service := &Application{}
+	service.Options(options...)
+
+	return service
+}
+
+// WithValidator provides a function to configure the files validator
+func WithValidator(validator repository.Validator) OptionsFunc {
+	return func(a *Application) {
+		a.validator = validator
+	}
+}
+
+// WithOutput provides a function to configure the output where the issues are printed
+func WithOutput(output repository.ValidationPrinter) OptionsFunc {
+	return func(a *Application) {
+		a.output = output
+	}
+}
+
+// Options configure the service
+func (a *Application) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(a)
+	}
+}
+
+// Run validates the configuration, builders and images files and prints the issues found, sorted by location. It returns an error when any issue has error severity
+func (a *Application) Run(ctx context.Context, options *Options, optionsFunc ...OptionsFunc) error {
+
+	errContext := "(application::validate::Run)"
+
+	a.Options(optionsFunc...)
+
+	if a.validator == nil {
+		return errors.New(errContext, "To run the validate application, a validator must be provided")
+	}
+
+	if a.output == nil {
+		return errors.New(errContext, "To run the validate application, an output must be provided")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "To run the validate application, options must be provided")
+	}
+
+	sources := []*validation.Source{}
+	for _, configurationFile := range options.ConfigurationFiles {
+		sources = append(sources, &validation.Source{Path: configurationFile, Kind: validation.ConfigurationKind})
+	}
+	for _, buildersPath := range options.BuildersPaths {
+		sources = append(sources, &validation.Source{Path: buildersPath, Kind: validation.BuildersKind})
+	}
+	for _, imagesPath := range options.ImagesPaths {
+		sources = append(sources, &validation.Source{Path: imagesPath, Kind: validation.ImagesKind})
+	}
+
+	issues, err := a.validator.Validate(sources)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+
+		return issues[i].Column < issues[j].Column
+	})
+
+	err = a.output.Print(issues)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	failed := 0
+	for _, issue := range issues {
+		if issue.IsError() {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.New(errContext, fmt.Sprintf("Validation failed, errors found: %d", failed))
+	}
+
+	return nil
+}
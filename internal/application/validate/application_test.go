@@ -0,0 +1,140 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	output "github.com/gostevedore/stevedore/internal/infrastructure/output/validation"
+	"github.com/gostevedore/stevedore/internal/infrastructure/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	errContext := "(application::validate::Run)"
+
+	sources := []*validation.Source{
+		{Path: "stevedore.yaml", Kind: validation.ConfigurationKind},
+		{Path: "builders", Kind: validation.BuildersKind},
+		{Path: "images", Kind: validation.ImagesKind},
+	}
+
+	options := &Options{
+		ConfigurationFiles: []string{"stevedore.yaml"},
+		BuildersPaths:      []string{"builders"},
+		ImagesPaths:        []string{"images"},
+	}
+
+	tests := []struct {
+		desc              string
+		app               *Application
+		options           *Options
+		prepareAssertFunc func(*Application)
+		assertFunc        func(*testing.T, *Application)
+		err               error
+	}{
+		{
+			desc: "Testing error running validate application without validator",
+			app: NewApplication(
+				WithOutput(output.NewMockOutput()),
+			),
+			options: options,
+			err:     errors.New(errContext, "To run the validate application, a validator must be provided"),
+		},
+		{
+			desc: "Testing error running validate application without output",
+			app: NewApplication(
+				WithValidator(validator.NewMockValidator()),
+			),
+			options: options,
+			err:     errors.New(errContext, "To run the validate application, an output must be provided"),
+		},
+		{
+			desc: "Testing error running validate application without options",
+			app: NewApplication(
+				WithValidator(validator.NewMockValidator()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: nil,
+			err:     errors.New(errContext, "To run the validate application, options must be provided"),
+		},
+		{
+			desc: "Testing run validate application with warnings",
+			app: NewApplication(
+				WithValidator(validator.NewMockValidator()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: options,
+			prepareAssertFunc: func(a *Application) {
+				issues := []*validation.Issue{
+					{File: "stevedore.yaml", Line: 2, Column: 1, Path: "tree_path", Severity: validation.SeverityWarning, Message: "'tree_path' is deprecated, use 'images_path' instead"},
+				}
+
+				a.validator.(*validator.MockValidator).On("Validate", sources).Return(issues, nil)
+				a.output.(*output.MockOutput).On("Print", issues).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.validator.(*validator.MockValidator).AssertExpectations(t)
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+		},
+		{
+			desc: "Testing run validate application with errors sorted by location",
+			app: NewApplication(
+				WithValidator(validator.NewMockValidator()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: options,
+			prepareAssertFunc: func(a *Application) {
+				a.validator.(*validator.MockValidator).On("Validate", sources).Return([]*validation.Issue{
+					{File: "images/base.yaml", Line: 9, Column: 3, Severity: validation.SeverityError, Message: "second"},
+					{File: "builders/builders.yaml", Line: 4, Column: 1, Severity: validation.SeverityWarning, Message: "first"},
+					{File: "images/base.yaml", Line: 2, Column: 5, Severity: validation.SeverityError, Message: "third"},
+				}, nil)
+				a.output.(*output.MockOutput).On("Print", []*validation.Issue{
+					{File: "builders/builders.yaml", Line: 4, Column: 1, Severity: validation.SeverityWarning, Message: "first"},
+					{File: "images/base.yaml", Line: 2, Column: 5, Severity: validation.SeverityError, Message: "third"},
+					{File: "images/base.yaml", Line: 9, Column: 3, Severity: validation.SeverityError, Message: "second"},
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, a *Application) {
+				a.output.(*output.MockOutput).AssertExpectations(t)
+			},
+			err: errors.New(errContext, "Validation failed, errors found: 2"),
+		},
+		{
+			desc: "Testing error running validate application when validation fails",
+			app: NewApplication(
+				WithValidator(validator.NewMockValidator()),
+				WithOutput(output.NewMockOutput()),
+			),
+			options: options,
+			prepareAssertFunc: func(a *Application) {
+				a.validator.(*validator.MockValidator).On("Validate", sources).Return(nil, errors.New("validator", "error"))
+			},
+			err: errors.New(errContext, "", errors.New("validator", "error")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.app)
+			}
+
+			err := test.app.Run(context.TODO(), test.options)
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+
+			if test.assertFunc != nil {
+				test.assertFunc(t, test.app)
+			}
+		})
+	}
+}
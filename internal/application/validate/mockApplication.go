@@ -0,0 +1,23 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApplication is a mock of validate application
+type MockApplication struct {
+	mock.Mock
+}
+
+// NewMockApplication return a mock of validate application
+func NewMockApplication() *MockApplication {
+	return &MockApplication{}
+}
+
+// Run provides a mock function with given fields: ctx, options, optionsFunc
+func (m *MockApplication) Run(ctx context.Context, options *Options, optionsFunc ...OptionsFunc) error {
+	args := m.Called(ctx, options, optionsFunc)
+	return args.Error(0)
+}
@@ -0,0 +1,11 @@
+package validate
+
+// Options are the options to validate the files
+type Options struct {
+	// ConfigurationFiles are the stevedore configuration files
+	ConfigurationFiles []string
+	// BuildersPaths are the files or folders where the builders are defined
+	BuildersPaths []string
+	// ImagesPaths are the files or folders where the images are defined
+	ImagesPaths []string
+}
@@ -16,6 +16,8 @@ type BuilderOptions struct {
 	Dockerfile string `yaml:"dockerfile"`
 	// Context    []*DockerDriverContextOptions `yaml:"context"`
 	Context interface{} `yaml:"context"`
+	// SSH are the SSH agent sockets or keys forwarded to the build, which are available to 'RUN --mount=type=ssh' instructions
+	SSH []*DockerDriverSSHOptions `yaml:"ssh"`
 }
 
 func (o *BuilderOptions) GetContext() ([]*DockerDriverContextOptions, error) {
@@ -90,6 +92,18 @@ type DockerDriverGitContextAuthOptions struct {
 	PrivateKeyFile string `yaml:"private_key_file"`
 	// PrivateKeyPassword is the password to use for the private key
 	PrivateKeyPassword string `yaml:"private_key_password"`
-	// CredentialsID is the id of the credentials on credentials store to use to authenticate to the git repository
+	// CredentialsID is the id of the credentials on credentials store to use to authenticate to the git repository. When no auth is defined, the credentials stored for the repository host are used
+	CredentialsID string `yaml:"credentials_id"`
+}
+
+// DockerDriverSSHOptions defines an SSH agent socket or key forwarded to a docker build
+type DockerDriverSSHOptions struct {
+	// ID is the identifier used on 'RUN --mount=type=ssh,id=<id>' instructions. By default is used 'default'
+	ID string `yaml:"id"`
+	// PrivateKeyFile is the path to the private key forwarded to the build
+	PrivateKeyFile string `yaml:"private_key_file"`
+	// PrivateKeyPassword is the password for the private key
+	PrivateKeyPassword string `yaml:"private_key_password"`
+	// CredentialsID is the id of the credentials on credentials store to forward to the build. It must be a keyfile or ssh-agent credential
 	CredentialsID string `yaml:"credentials_id"`
 }
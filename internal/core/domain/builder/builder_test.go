@@ -223,6 +223,43 @@ options:
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing create builder from byte array with Docker driver SSH data",
+			data: []byte(`
+driver: docker
+options:
+  context:
+    path: path
+  ssh:
+    - id: default
+      credentials_id: git-credentials
+    - id: deploy
+      private_key_file: id_rsa
+      private_key_password: password
+`),
+			res: &Builder{
+				Name:   "",
+				Driver: "docker",
+				Options: &BuilderOptions{
+					Context: map[string]interface{}{
+						"path": "path",
+					},
+					SSH: []*DockerDriverSSHOptions{
+						{
+							ID:            "default",
+							CredentialsID: "git-credentials",
+						},
+						{
+							ID:                 "deploy",
+							PrivateKeyFile:     "id_rsa",
+							PrivateKeyPassword: "password",
+						},
+					},
+				},
+				VarMapping: varsmap.New(),
+			},
+			err: &errors.Error{},
+		},
 	}
 
 	for _, test := range tests {
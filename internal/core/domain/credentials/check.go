@@ -0,0 +1,46 @@
+package credentials
+
+const (
+	// CheckStatusOK is the status of the credentials that grant access to the registry
+	CheckStatusOK = "ok"
+	// CheckStatusUnauthorized is the status of the credentials rejected by the registry
+	CheckStatusUnauthorized = "unauthorized"
+	// CheckStatusExpired is the status of the credentials that are expired
+	CheckStatusExpired = "expired"
+	// CheckStatusUnreachable is the status of the credentials whose registry could not be reached
+	CheckStatusUnreachable = "unreachable"
+	// CheckStatusError is the status of the credentials that could not be checked
+	CheckStatusError = "error"
+	// CheckStatusSkipped is the status of the credentials that are not used to authenticate to registries, such as the patterns or the git credentials
+	CheckStatusSkipped = "skipped"
+
+	// CheckScopePull checks the access to pull from the repository
+	CheckScopePull = "pull"
+	// CheckScopePush checks the access to pull from and push to the repository
+	CheckScopePush = "push"
+)
+
+// CheckResult is the result of checking the access to a registry using a credential
+type CheckResult struct {
+	// ID is the credentials id
+	ID string `json:"id" yaml:"id"`
+	// Registry is the registry host checked
+	Registry string `json:"registry" yaml:"registry"`
+	// Repository is the repository whose scope is checked
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+	// Scope is the access checked on the repository
+	Scope string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	// Status is the check status
+	Status string `json:"status" yaml:"status"`
+	// Message describes the check status
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Failed returns whether the check failed. Skipped checks are not failed
+func (r *CheckResult) Failed() bool {
+	if r == nil {
+		return false
+	}
+
+	return r.Status != CheckStatusOK && r.Status != CheckStatusSkipped
+}
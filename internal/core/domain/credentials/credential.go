@@ -24,6 +24,8 @@ type Credential struct {
 	AWSSharedConfigFiles []string `json:"aws_shared_config_files" yaml:"aws_shared_config_files" mapstructure:"aws_shared_config_files"`
 	// AWSUseDefaultCredentialsChain must be set to true when you want to use the sdk default's credentials chain described at https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/#specifying-credentials
 	AWSUseDefaultCredentialsChain bool `json:"aws_use_default_credentials_chain" yaml:"aws_use_default_credentials_chain" mapstructure:"aws_use_default_credentials_chain"`
+	// CredentialProcess is the command executed to achieve the username and password. The command must print a JSON object with the 'username', 'password' and, optionally, 'expires_at' attributes
+	CredentialProcess string `json:"credential_process" yaml:"credential_process" mapstructure:"credential_process"`
 	// DEPRECATEDPassword password for basic auth method
 	DEPRECATEDPassword string `json:"docker_login_password" yaml:"docker_login_password" mapstructure:"docker_login_password"`
 	// DEPRECATEDUsername username for basic auth method
@@ -32,6 +34,10 @@ type Credential struct {
 	Password string `json:"password" yaml:"password" mapstructure:"password"`
 	// Username for basic auth method. It could be used to authenticate to either docker registry or git server
 	Username string `json:"username" yaml:"username" mapstructure:"username"`
+	// Token is a bearer token sent to the registry, such as a registry access token or a robot account token. It could be used along with a username
+	Token string `json:"token" yaml:"token" mapstructure:"token"`
+	// RefreshToken is an OAuth2 refresh token, also known as identity token, exchanged by the registry authorization service for an access token. It could be used along with a username
+	RefreshToken string `json:"refresh_token" yaml:"refresh_token" mapstructure:"refresh_token"`
 	// PrivateKeyFile is the path to the private key file. It could be used to authenticate to git server
 	PrivateKeyFile string `json:"private_key_file" yaml:"private_key_file" mapstructure:"private_key_file"`
 	// PrivateKeyPassword is the password for the private key file. It could be used to authenticate to git server
@@ -40,6 +46,10 @@ type Credential struct {
 	GitSSHUser string `json:"git_ssh_user" yaml:"git_ssh_user" mapstructure:"git_ssh_user"`
 	// AllowUseSSHAgent must be set to true when you allow to use the ssh-agent to authenticate to the git server
 	AllowUseSSHAgent bool `json:"use_ssh_agent" yaml:"use_ssh_agent" mapstructure:"use_ssh_agent"`
+	// Description is a free text that describes the credential, such as its owner or how to rotate it
+	Description string `json:"description" yaml:"description" mapstructure:"description"`
+	// ExpiresAt is the date, in RFC3339 format, when the credential expires. It is used to warn about the credentials that must be rotated
+	ExpiresAt string `json:"expires_at" yaml:"expires_at" mapstructure:"expires_at"`
 }
 
 // IsValid return whether a credential is valid, otherwise returns an error with the invalid reason
@@ -60,6 +70,10 @@ func (credential *Credential) IsValid() (bool, error) {
 		return true, nil
 	}
 
+	if credential.Token != "" || credential.RefreshToken != "" {
+		return true, nil
+	}
+
 	if credential.AWSUseDefaultCredentialsChain {
 		return true, nil
 	}
@@ -72,6 +86,10 @@ func (credential *Credential) IsValid() (bool, error) {
 		return true, nil
 	}
 
+	if credential.CredentialProcess != "" {
+		return true, nil
+	}
+
 	// invalid credentials
 	if credential.Username != "" && credential.Password == "" {
 		return false, errors.New(errContext, "Invalid credential. Missing password")
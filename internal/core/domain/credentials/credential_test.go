@@ -58,6 +58,31 @@ func TestIsValid(t *testing.T) {
 			valid: true,
 			err:   &errors.Error{},
 		},
+		{
+			desc: "Testing a valid credential with token",
+			credential: &Credential{
+				Username: "robot$project",
+				Token:    "token",
+			},
+			valid: true,
+			err:   &errors.Error{},
+		},
+		{
+			desc: "Testing a valid credential with refresh token",
+			credential: &Credential{
+				RefreshToken: "refresh-token",
+			},
+			valid: true,
+			err:   &errors.Error{},
+		},
+		{
+			desc: "Testing a valid credential with credential process",
+			credential: &Credential{
+				CredentialProcess: "credential-broker --registry registry.example.com",
+			},
+			valid: true,
+			err:   &errors.Error{},
+		},
 		{
 			desc:       "Testing an invalid credential",
 			credential: &Credential{},
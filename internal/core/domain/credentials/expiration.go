@@ -0,0 +1,112 @@
+package credentials
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// ExpirationLayout is the layout used to store the credentials expiration date
+	ExpirationLayout = time.RFC3339
+	// ExpirationDateLayout is the layout accepted to define the credentials expiration date without time. The credentials expire at the beginning of that day, in UTC
+	ExpirationDateLayout = "2006-01-02"
+	// DefaultExpirationWarningWindow is the window used to warn about the credentials that are about to expire
+	DefaultExpirationWarningWindow = 7 * 24 * time.Hour
+
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// Expiration returns the time when the credential expires and whether the credential defines an expiration
+func (credential *Credential) Expiration() (time.Time, bool, error) {
+
+	errContext := "(core::domain::credentials::Expiration)"
+
+	if credential == nil || credential.ExpiresAt == "" {
+		return time.Time{}, false, nil
+	}
+
+	expiresAt, err := time.Parse(ExpirationLayout, credential.ExpiresAt)
+	if err != nil {
+		return time.Time{}, true, errors.New(errContext, fmt.Sprintf("Invalid expiration date '%s'. The expected format is '%s'", credential.ExpiresAt, ExpirationLayout), err)
+	}
+
+	return expiresAt, true, nil
+}
+
+// ExpiresWithin returns whether the credential expires before the window elapses from now. Expired credentials are also considered to expire within the window
+func (credential *Credential) ExpiresWithin(now time.Time, window time.Duration) (bool, error) {
+
+	errContext := "(core::domain::credentials::ExpiresWithin)"
+
+	expiresAt, defined, err := credential.Expiration()
+	if err != nil {
+		return false, errors.New(errContext, "", err)
+	}
+
+	if !defined {
+		return false, nil
+	}
+
+	return expiresAt.Before(now.Add(window)), nil
+}
+
+// ParseExpiration returns the expiration time from a value that could be an RFC3339 date, such as '2024-12-31T00:00:00Z', a date, such as '2024-12-31', or a duration from now, such as '90d' or '12h'
+func ParseExpiration(value string, now time.Time) (time.Time, error) {
+
+	errContext := "(core::domain::credentials::ParseExpiration)"
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New(errContext, "Expiration must be provided")
+	}
+
+	expiresAt, err := time.Parse(ExpirationLayout, value)
+	if err == nil {
+		return expiresAt, nil
+	}
+
+	expiresAt, err = time.Parse(ExpirationDateLayout, value)
+	if err == nil {
+		return expiresAt, nil
+	}
+
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return time.Time{}, errors.New(errContext, fmt.Sprintf("Invalid expiration '%s'. It must be a date, such as '2024-12-31' or '2024-12-31T00:00:00Z', or a duration, such as '90d'", value))
+	}
+
+	return now.Add(duration), nil
+}
+
+// ParseDuration parses a duration as time.ParseDuration does, but it also accepts days, such as '7d', and weeks, such as '2w'
+func ParseDuration(value string) (time.Duration, error) {
+
+	errContext := "(core::domain::credentials::ParseDuration)"
+
+	value = strings.TrimSpace(value)
+
+	for suffix, unit := range map[string]time.Duration{"d": day, "w": week} {
+		if !strings.HasSuffix(value, suffix) {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSuffix(value, suffix), 64)
+		if err != nil || amount < 0 {
+			return 0, errors.New(errContext, fmt.Sprintf("Invalid duration '%s'", value))
+		}
+
+		return time.Duration(amount * float64(unit)), nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, errors.New(errContext, fmt.Sprintf("Invalid duration '%s'", value))
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,194 @@
+package credentials
+
+import (
+	"testing"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiresWithin(t *testing.T) {
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc       string
+		credential *Credential
+		window     time.Duration
+		res        bool
+		err        error
+	}{
+		{
+			desc:       "Testing credential without expiration does not expire within the window",
+			credential: &Credential{},
+			window:     DefaultExpirationWarningWindow,
+			res:        false,
+			err:        &errors.Error{},
+		},
+		{
+			desc: "Testing credential that expires within the window",
+			credential: &Credential{
+				ExpiresAt: "2024-06-05T00:00:00Z",
+			},
+			window: DefaultExpirationWarningWindow,
+			res:    true,
+			err:    &errors.Error{},
+		},
+		{
+			desc: "Testing credential that expires after the window",
+			credential: &Credential{
+				ExpiresAt: "2024-07-01T00:00:00Z",
+			},
+			window: DefaultExpirationWarningWindow,
+			res:    false,
+			err:    &errors.Error{},
+		},
+		{
+			desc: "Testing expired credential expires within the window",
+			credential: &Credential{
+				ExpiresAt: "2024-05-01T00:00:00Z",
+			},
+			window: 0,
+			res:    true,
+			err:    &errors.Error{},
+		},
+		{
+			desc: "Testing error when the credential expiration is invalid",
+			credential: &Credential{
+				ExpiresAt: "tomorrow",
+			},
+			window: DefaultExpirationWarningWindow,
+			err: errors.New("(core::domain::credentials::ExpiresWithin)", "",
+				errors.New("(core::domain::credentials::Expiration)", "Invalid expiration date 'tomorrow'. The expected format is '2006-01-02T15:04:05Z07:00'")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.credential.ExpiresWithin(now, test.window)
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestParseExpiration(t *testing.T) {
+
+	errContext := "(core::domain::credentials::ParseExpiration)"
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc  string
+		value string
+		res   time.Time
+		err   error
+	}{
+		{
+			desc:  "Testing parse an RFC3339 expiration",
+			value: "2024-12-31T10:00:00Z",
+			res:   time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC),
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing parse a date expiration",
+			value: "2024-12-31",
+			res:   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing parse a duration expiration",
+			value: "90d",
+			res:   now.Add(90 * 24 * time.Hour),
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing error parsing an empty expiration",
+			value: "",
+			err:   errors.New(errContext, "Expiration must be provided"),
+		},
+		{
+			desc:  "Testing error parsing an invalid expiration",
+			value: "next-year",
+			err:   errors.New(errContext, "Invalid expiration 'next-year'. It must be a date, such as '2024-12-31' or '2024-12-31T00:00:00Z', or a duration, such as '90d'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseExpiration(test.value, now)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.True(t, test.res.Equal(res), "expected %s, got %s", test.res, res)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+
+	errContext := "(core::domain::credentials::ParseDuration)"
+
+	tests := []struct {
+		desc  string
+		value string
+		res   time.Duration
+		err   error
+	}{
+		{
+			desc:  "Testing parse a duration in days",
+			value: "7d",
+			res:   7 * 24 * time.Hour,
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing parse a duration in weeks",
+			value: "2w",
+			res:   14 * 24 * time.Hour,
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing parse a duration in hours",
+			value: "36h",
+			res:   36 * time.Hour,
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing parse a zero duration",
+			value: "0",
+			res:   0,
+			err:   &errors.Error{},
+		},
+		{
+			desc:  "Testing error parsing an invalid duration",
+			value: "xd",
+			err:   errors.New(errContext, "Invalid duration 'xd'"),
+		},
+		{
+			desc:  "Testing error parsing a negative duration",
+			value: "-1h",
+			err:   errors.New(errContext, "Invalid duration '-1h'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseDuration(test.value)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package credentials
+
+import (
+	"path"
+	"strings"
+)
+
+const (
+	// idPathSeparator is the separator between the registry host and the path segments of a credentials id
+	idPathSeparator = "/"
+	// idWildcards are the characters that make an id segment a pattern
+	idWildcards = "*?[]\\"
+)
+
+// MatchID returns whether the credentials id matches the reference and how specific the match is. An id could be a registry host, such as 'registry.example.com', a host pattern, such as '*.dkr.ecr.eu-west-1.amazonaws.com', or a path-scoped id, such as 'registry.example.com/team-a'. Each id segment is matched using shell file name patterns against the reference segment in the same position, and the id matches when all its segments match the reference leading segments.
+// The specificity is higher the more segments are matched, and for the same number of segments, the more literal characters the id has. It is used to resolve the longest match when several ids match the same reference
+func MatchID(id, reference string) (int, bool) {
+
+	if id == "" || reference == "" {
+		return 0, false
+	}
+
+	idSegments := strings.Split(strings.Trim(id, idPathSeparator), idPathSeparator)
+	referenceSegments := strings.Split(strings.Trim(reference, idPathSeparator), idPathSeparator)
+
+	if len(idSegments) > len(referenceSegments) {
+		return 0, false
+	}
+
+	literals := 0
+	for i, segment := range idSegments {
+		match, err := path.Match(segment, referenceSegments[i])
+		if err != nil || !match {
+			return 0, false
+		}
+
+		for _, c := range segment {
+			if !strings.ContainsRune(idWildcards, c) {
+				literals++
+			}
+		}
+	}
+
+	return len(idSegments)<<16 + literals, true
+}
+
+// IsIDPattern returns whether the credentials id is a pattern, such as '*.dkr.ecr.eu-west-1.amazonaws.com'
+func IsIDPattern(id string) bool {
+	return strings.ContainsAny(id, idWildcards)
+}
@@ -0,0 +1,123 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchID(t *testing.T) {
+
+	tests := []struct {
+		desc      string
+		id        string
+		reference string
+		match     bool
+	}{
+		{
+			desc:      "Testing match a registry host id",
+			id:        "registry.example.com",
+			reference: "registry.example.com/team-a/image",
+			match:     true,
+		},
+		{
+			desc:      "Testing match a registry host pattern id",
+			id:        "*.dkr.ecr.eu-west-1.amazonaws.com",
+			reference: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/team-a/image",
+			match:     true,
+		},
+		{
+			desc:      "Testing match a path-scoped id",
+			id:        "registry.example.com/team-a",
+			reference: "registry.example.com/team-a/image",
+			match:     true,
+		},
+		{
+			desc:      "Testing not match a path-scoped id from another namespace",
+			id:        "registry.example.com/team-a",
+			reference: "registry.example.com/team-b/image",
+			match:     false,
+		},
+		{
+			desc:      "Testing not match a path-scoped id that is a segment prefix",
+			id:        "registry.example.com/team",
+			reference: "registry.example.com/team-a/image",
+			match:     false,
+		},
+		{
+			desc:      "Testing not match a path-scoped id longer than the reference",
+			id:        "registry.example.com/team-a",
+			reference: "registry.example.com",
+			match:     false,
+		},
+		{
+			desc:      "Testing not match a host pattern from another region",
+			id:        "*.dkr.ecr.eu-west-1.amazonaws.com",
+			reference: "123456789012.dkr.ecr.us-east-1.amazonaws.com/image",
+			match:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			_, match := MatchID(test.id, test.reference)
+			assert.Equal(t, test.match, match)
+		})
+	}
+}
+
+func TestMatchIDSpecificity(t *testing.T) {
+
+	reference := "registry.example.com/team-a/image"
+
+	ids := []string{
+		"*.example.com",
+		"registry.example.com",
+		"*.example.com/team-a",
+		"registry.example.com/team-a",
+		"registry.example.com/team-a/image",
+	}
+
+	previous := -1
+	for _, id := range ids {
+		specificity, match := MatchID(id, reference)
+		assert.True(t, match, id)
+		assert.Greater(t, specificity, previous, id)
+		previous = specificity
+	}
+}
+
+func TestIsIDPattern(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		id   string
+		res  bool
+	}{
+		{
+			desc: "Testing a registry host id is not a pattern",
+			id:   "registry.example.com",
+			res:  false,
+		},
+		{
+			desc: "Testing a path-scoped id is not a pattern",
+			id:   "registry.example.com/team-a",
+			res:  false,
+		},
+		{
+			desc: "Testing a registry host pattern id is a pattern",
+			id:   "*.dkr.ecr.eu-west-1.amazonaws.com",
+			res:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, IsIDPattern(test.id))
+		})
+	}
+}
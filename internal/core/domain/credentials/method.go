@@ -3,6 +3,8 @@ package credentials
 const (
 	// BasicAuthMethod credentials used for basic authentication
 	BasicAuthMethod = "basic"
+	// TokenAuthMethod credentials used to authenticate through a bearer token or an OAuth2 refresh token
+	TokenAuthMethod = "token"
 	// KeyFileAuthMethod data used to authenticate through private key file on git
 	KeyFileAuthMethod = "keyfile"
 	// SSHAgentAuthMethod data used to authenticate through ssh-agent
@@ -0,0 +1,28 @@
+package credentials
+
+import "fmt"
+
+// NotFoundError is the error returned by the credentials stores when there are no credentials for an id
+type NotFoundError struct {
+	// ID is the id of the credentials that do not exist
+	ID string
+}
+
+// NewNotFoundError returns an error for the credentials id that does not exist
+func NewNotFoundError(id string) *NotFoundError {
+	return &NotFoundError{
+		ID: id,
+	}
+}
+
+// Error returns the error message
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("Credentials '%s' does not exist", e.ID)
+}
+
+// IsNotFoundError returns true when the error is returned because there are no credentials for an id
+func IsNotFoundError(err error) bool {
+	_, isNotFound := err.(*NotFoundError)
+
+	return isNotFound
+}
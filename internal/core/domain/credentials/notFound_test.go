@@ -0,0 +1,45 @@
+package credentials
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		res  bool
+	}{
+		{
+			desc: "Testing a not found error",
+			err:  NewNotFoundError("registry.example.com"),
+			res:  true,
+		},
+		{
+			desc: "Testing a wrapped not found error",
+			err:  errors.New("(context)", "", NewNotFoundError("registry.example.com")),
+			res:  false,
+		},
+		{
+			desc: "Testing another error",
+			err:  errors.New("(context)", "Error reading credentials"),
+			res:  false,
+		},
+		{
+			desc: "Testing a nil error",
+			err:  nil,
+			res:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, IsNotFoundError(test.err))
+		})
+	}
+}
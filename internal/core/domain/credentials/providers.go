@@ -5,6 +5,8 @@ const (
 	StoreAuthProvider = "store"
 	// AWSECRSAuthProvider provider which uses aws ecr get-login-password to get user/password auth
 	AWSECRSAuthProvider = "aws-ecr"
+	// CredentialProcessAuthProvider provider which executes a command to get user/password auth
+	CredentialProcessAuthProvider = "credential-process"
 	// MockAuthProvider is a mocked auth provider
 	MockAuthProvider = "mock"
 )
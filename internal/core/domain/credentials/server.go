@@ -0,0 +1,23 @@
+package credentials
+
+import "strings"
+
+const (
+	// DockerHubHost is the host used to identify Docker Hub credentials
+	DockerHubHost = "docker.io"
+)
+
+// ServerHost returns the registry host from a registry server address, such as 'https://index.docker.io/v1/' or 'registry.example.com'. The Docker Hub addresses are all identified by the 'docker.io' host
+func ServerHost(server string) string {
+
+	host := strings.TrimPrefix(server, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host, _, _ = strings.Cut(host, "/")
+
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", DockerHubHost:
+		return DockerHubHost
+	}
+
+	return host
+}
@@ -0,0 +1,45 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerHost(t *testing.T) {
+	tests := []struct {
+		desc   string
+		server string
+		res    string
+	}{
+		{
+			desc:   "Testing server host from a registry host",
+			server: "registry.example.com",
+			res:    "registry.example.com",
+		},
+		{
+			desc:   "Testing server host from a registry URL",
+			server: "https://registry.example.com:5000/v2/",
+			res:    "registry.example.com:5000",
+		},
+		{
+			desc:   "Testing server host from a Docker Hub URL",
+			server: "https://index.docker.io/v1/",
+			res:    DockerHubHost,
+		},
+		{
+			desc:   "Testing server host from the Docker Hub registry host",
+			server: "registry-1.docker.io",
+			res:    DockerHubHost,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := ServerHost(test.server)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
@@ -1,10 +1,23 @@
 package credentials
 
 const (
+	// DockerConfigStore is a read-only store backend that uses the Docker configuration file and credentials helpers
+	DockerConfigStore = "docker-config"
 	// EnvvarsStore is a store backend that uses environment variables to store credentials
 	EnvvarsStore = "envvars"
 	// LocalStore is a store backend which stores credentials in local file system
 	LocalStore = "local"
 	// MockStore is a mocked backend store
 	MockStore = "mock"
+	// VaultStore is a store backend that uses a HashiCorp Vault KV version 2 secrets engine to store credentials
+	VaultStore = "vault"
+)
+
+const (
+	// VaultTokenAuth is the Vault auth method that uses a Vault token
+	VaultTokenAuth = "token"
+	// VaultAppRoleAuth is the Vault auth method that logs in using an AppRole role id and secret id
+	VaultAppRoleAuth = "approle"
+	// VaultJWTAuth is the Vault auth method that logs in using a JWT, such as the ones issued by CI platforms
+	VaultJWTAuth = "jwt"
 )
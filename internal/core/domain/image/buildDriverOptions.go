@@ -25,12 +25,20 @@ type BuildDriverOptions struct {
 	PullAuthUsername string `yaml:"pull_auth_username"`
 	// PullAuthPassword is the password to use for pulling the image
 	PullAuthPassword string `yaml:"-"`
+	// PullAuthIdentityToken is the OAuth2 refresh token to use for pulling the image
+	PullAuthIdentityToken string `yaml:"-"`
+	// PullAuthRegistryToken is the bearer token to use for pulling the image
+	PullAuthRegistryToken string `yaml:"-"`
 	// PullParentImage indicates whether to pull the parent image
 	PullParentImage bool `yaml:"pull_parent_image"`
 	// PushAuthUsername is the username to use for pushing the image
 	PushAuthUsername string `yaml:"push_auth_username"`
 	// PushAuthPassword is the password to use for pushing the image
 	PushAuthPassword string `yaml:"-"`
+	// PushAuthIdentityToken is the OAuth2 refresh token to use for pushing the image
+	PushAuthIdentityToken string `yaml:"-"`
+	// PushAuthRegistryToken is the bearer token to use for pushing the image
+	PushAuthRegistryToken string `yaml:"-"`
 	// PushImageAfterBuild flag indicate whether to push the image to the registry once it has been built
 	PushImageAfterBuild bool `yaml:"push_image_after_build"`
 	// RemoveImageAfterBuild flag indicate whether to remove the image after build
@@ -330,6 +330,19 @@ func (i *Image) Copy() (*Image, error) {
 	return copiedImage, nil
 }
 
+// Repository returns the image repository path, composed by the registry host, the registry namespace and the image name. Undefined elements are omitted
+func (i *Image) Repository() string {
+	path := []string{}
+
+	for _, element := range []string{i.RegistryHost, i.RegistryNamespace, i.Name} {
+		if element != "" && element != UndefinedStringValue {
+			path = append(path, element)
+		}
+	}
+
+	return strings.Join(path, "/")
+}
+
 // IsWildcardImage returns true if the image is a wildcard image
 func (i *Image) IsWildcardImage() bool {
 	return i.Version == ImageWildcardVersionSymbol
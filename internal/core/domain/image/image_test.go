@@ -354,6 +354,45 @@ func TestIsWildcardImage(t *testing.T) {
 	}
 }
 
+func TestRepository(t *testing.T) {
+	tests := []struct {
+		desc  string
+		image *Image
+		res   string
+	}{
+		{
+			desc: "Testing repository of an image with registry host and namespace",
+			image: &Image{
+				Name:              "image",
+				Version:           "version",
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+			},
+			res: "registry.test/namespace/image",
+		},
+		{
+			desc: "Testing repository of an image with undefined registry host",
+			image: &Image{
+				Name:              "image",
+				Version:           "version",
+				RegistryHost:      UndefinedStringValue,
+				RegistryNamespace: "namespace",
+			},
+			res: "namespace/image",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := test.image.Repository()
+
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
 func TestParse(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -0,0 +1,88 @@
+package image
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// ImmutableTags defines which image tags can not be overwritten once they exist on a registry
+type ImmutableTags struct {
+	// images is a list of patterns that defines the images whose tags are immutable
+	images []string
+	// floatingTags is a list of regular expressions that defines the tags that remain mutable, such as 'latest'
+	floatingTags []*regexp.Regexp
+}
+
+// NewImmutableTags returns a new ImmutableTags. Images patterns are matched using shell file name patterns against the image name, either with or without the registry host, such as 'registry.example.com/stable/*' or 'stable/*'. Floating tags are regular expressions matched against the image tag
+func NewImmutableTags(images []string, floatingTags []string) (*ImmutableTags, error) {
+
+	errContext := "(core::domain::image::NewImmutableTags)"
+
+	immutable := &ImmutableTags{
+		images:       []string{},
+		floatingTags: []*regexp.Regexp{},
+	}
+
+	for _, pattern := range images {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Immutable images pattern '%s' is not valid", pattern), err)
+		}
+		immutable.images = append(immutable.images, pattern)
+	}
+
+	for _, expr := range floatingTags {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Floating tag expression '%s' is not valid", expr), err)
+		}
+		immutable.floatingTags = append(immutable.floatingTags, re)
+	}
+
+	return immutable, nil
+}
+
+// IsImmutable returns true when the image reference name matches any of the immutable images patterns and its tag is not a floating tag
+func (t *ImmutableTags) IsImmutable(name string) bool {
+
+	if t == nil || len(t.images) == 0 {
+		return false
+	}
+
+	i, err := Parse(name)
+	if err != nil {
+		return false
+	}
+
+	for _, re := range t.floatingTags {
+		if re.MatchString(i.Version) {
+			return false
+		}
+	}
+
+	return matchRepository(t.images, i)
+}
+
+// matchRepository returns true when the image repository matches any of the patterns. Patterns are matched against the repository either with or without the registry host
+func matchRepository(patterns []string, i *Image) bool {
+
+	repository := strings.Join([]string{i.RegistryNamespace, i.Name}, "/")
+	if i.RegistryNamespace == "" {
+		repository = i.Name
+	}
+	fullRepository := strings.Join([]string{i.RegistryHost, repository}, "/")
+
+	for _, pattern := range patterns {
+		matchRepository, _ := path.Match(pattern, repository)
+		matchFullRepository, _ := path.Match(pattern, fullRepository)
+		if matchRepository || matchFullRepository {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+package image
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImmutableTags(t *testing.T) {
+
+	errContext := "(core::domain::image::NewImmutableTags)"
+
+	tests := []struct {
+		desc         string
+		images       []string
+		floatingTags []string
+		err          error
+	}{
+		{
+			desc:         "Testing create immutable tags",
+			images:       []string{"stable/*"},
+			floatingTags: []string{"^latest$"},
+		},
+		{
+			desc:   "Testing error creating immutable tags with an invalid image pattern",
+			images: []string{"stable/["},
+			err:    errors.New(errContext, "Immutable images pattern 'stable/[' is not valid"),
+		},
+		{
+			desc:         "Testing error creating immutable tags with an invalid floating tag expression",
+			images:       []string{"stable/*"},
+			floatingTags: []string{"^(latest$"},
+			err:          errors.New(errContext, "Floating tag expression '^(latest$' is not valid"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := NewImmutableTags(test.images, test.floatingTags)
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err.Error())
+			} else {
+				assert.NotNil(t, res)
+				assert.Equal(t, test.images, res.images)
+				assert.Equal(t, len(test.floatingTags), len(res.floatingTags))
+			}
+		})
+	}
+}
+
+func TestIsImmutable(t *testing.T) {
+
+	floatingTags := []string{"^latest$", `^\d+$`, `^\d+\.\d+$`}
+
+	tests := []struct {
+		desc   string
+		images []string
+		name   string
+		res    bool
+	}{
+		{
+			desc:   "Testing tag is immutable when image matches a namespace pattern",
+			images: []string{"stable/*"},
+			name:   "registry.test/stable/image:1.2.3",
+			res:    true,
+		},
+		{
+			desc:   "Testing tag is immutable when image matches a registry pattern",
+			images: []string{"registry.test/*/*"},
+			name:   "registry.test/stable/image:1.2.3",
+			res:    true,
+		},
+		{
+			desc:   "Testing tag is mutable when image does not match any pattern",
+			images: []string{"stable/*"},
+			name:   "registry.test/unstable/image:1.2.3",
+			res:    false,
+		},
+		{
+			desc:   "Testing tag is mutable when it is a 'latest' floating tag",
+			images: []string{"stable/*"},
+			name:   "registry.test/stable/image:latest",
+			res:    false,
+		},
+		{
+			desc:   "Testing tag is mutable when it is a major floating tag",
+			images: []string{"stable/*"},
+			name:   "registry.test/stable/image:1",
+			res:    false,
+		},
+		{
+			desc:   "Testing tag is mutable when it is a minor floating tag",
+			images: []string{"stable/*"},
+			name:   "registry.test/stable/image:1.2",
+			res:    false,
+		},
+		{
+			desc: "Testing tag is mutable when no images patterns are defined",
+			name: "registry.test/stable/image:1.2.3",
+			res:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			immutable, err := NewImmutableTags(test.images, floatingTags)
+			assert.Nil(t, err)
+			assert.Equal(t, test.res, immutable.IsImmutable(test.name))
+		})
+	}
+}
@@ -7,4 +7,6 @@ const (
 	DryRunPromoterName = "dry-run"
 	// MockPromoterName is the name for the mock promoter
 	MockPromoterName = "mock"
+	// RegistryPromoterName is the name for the promoter that copies images between registries without a Docker daemon
+	RegistryPromoterName = "registry"
 )
@@ -20,10 +20,18 @@ type PromoteOptions struct {
 	PullAuthUsername string `yaml:"pull_auth_username"`
 	// PullAuthPassword
 	PullAuthPassword string `yaml:"-"`
+	// PullAuthIdentityToken
+	PullAuthIdentityToken string `yaml:"-"`
+	// PullAuthRegistryToken
+	PullAuthRegistryToken string `yaml:"-"`
 	// PushAuthUsername
 	PushAuthUsername string `yaml:"push_auth_username"`
 	// PushAuthPassword
 	PushAuthPassword string `yaml:"-"`
+	// PushAuthIdentityToken
+	PushAuthIdentityToken string `yaml:"-"`
+	// PushAuthRegistryToken
+	PushAuthRegistryToken string `yaml:"-"`
 }
 
 // String TODO
@@ -0,0 +1,147 @@
+package image
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// PromotionPolicy defines the routes that images are allowed to follow when they are promoted. An image can only be promoted to a target when there is a rule that allows the route from its source to the target
+type PromotionPolicy struct {
+	// Rules is the list of promotion rules
+	Rules []*PromotionRule `yaml:"rules"`
+}
+
+// PromotionRule defines a route from a set of sources to a set of targets, and the requirements that the source image must fulfill to follow it
+type PromotionRule struct {
+	// Name is the rule name, used to identify the rule on the denial messages
+	Name string `yaml:"name"`
+	// Sources is a list of patterns that defines the source images allowed by the rule, such as 'registry.staging.example.com/*/*' or 'qa/*'
+	Sources []string `yaml:"sources"`
+	// Targets is a list of patterns that defines the target images allowed by the rule, such as 'registry.example.com/stable/*'
+	Targets []string `yaml:"targets"`
+	// RequiredLabels are the labels, and their values, that the source image must have to be promoted
+	RequiredLabels map[string]string `yaml:"required_labels"`
+	// VersionPattern is a regular expression that the source image tag must match to be promoted
+	VersionPattern string `yaml:"version_pattern"`
+}
+
+// Validate checks that the promotion policy is well defined
+func (p *PromotionPolicy) Validate() error {
+
+	errContext := "(core::domain::image::PromotionPolicy::Validate)"
+
+	if p == nil {
+		return errors.New(errContext, "Promotion policy is not defined")
+	}
+
+	for idx, rule := range p.Rules {
+		if rule == nil {
+			return errors.New(errContext, fmt.Sprintf("Promotion policy rule %d is not defined", idx))
+		}
+
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", idx)
+		}
+
+		if len(rule.Sources) == 0 {
+			return errors.New(errContext, fmt.Sprintf("Promotion policy rule '%s' requires at least one source", rule.Name))
+		}
+
+		if len(rule.Targets) == 0 {
+			return errors.New(errContext, fmt.Sprintf("Promotion policy rule '%s' requires at least one target", rule.Name))
+		}
+
+		for _, pattern := range append(append([]string{}, rule.Sources...), rule.Targets...) {
+			_, err := path.Match(pattern, "")
+			if err != nil {
+				return errors.New(errContext, fmt.Sprintf("Promotion policy rule '%s' pattern '%s' is not valid", rule.Name, pattern), err)
+			}
+		}
+
+		if rule.VersionPattern != "" {
+			_, err := regexp.Compile(rule.VersionPattern)
+			if err != nil {
+				return errors.New(errContext, fmt.Sprintf("Promotion policy rule '%s' version pattern '%s' is not valid", rule.Name, rule.VersionPattern), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Routes returns the rules that allow promoting the source image to the target image
+func (p *PromotionPolicy) Routes(source, target string) ([]*PromotionRule, error) {
+
+	errContext := "(core::domain::image::PromotionPolicy::Routes)"
+	routes := []*PromotionRule{}
+
+	sourceImage, err := Parse(source)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	targetImage, err := Parse(target)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	for _, rule := range p.Rules {
+		if matchRepository(rule.Sources, sourceImage) && matchRepository(rule.Targets, targetImage) {
+			routes = append(routes, rule)
+		}
+	}
+
+	return routes, nil
+}
+
+// RequiresLabels returns true when the rule requires the source image to have any label
+func (r *PromotionRule) RequiresLabels() bool {
+	return len(r.RequiredLabels) > 0
+}
+
+// Check validates that the source image version and labels fulfill the rule requirements. It returns an error that describes every unfulfilled requirement
+func (r *PromotionRule) Check(version string, labels map[string]string) error {
+
+	errContext := "(core::domain::image::PromotionRule::Check)"
+	unfulfilled := []string{}
+
+	if r.VersionPattern != "" {
+		match, err := regexp.MatchString(r.VersionPattern, version)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Rule '%s' version pattern '%s' is not valid", r.Name, r.VersionPattern), err)
+		}
+
+		if !match {
+			unfulfilled = append(unfulfilled, fmt.Sprintf("version '%s' does not match '%s'", version, r.VersionPattern))
+		}
+	}
+
+	requiredLabels := []string{}
+	for label := range r.RequiredLabels {
+		requiredLabels = append(requiredLabels, label)
+	}
+	sort.Strings(requiredLabels)
+
+	for _, label := range requiredLabels {
+		value, exists := labels[label]
+		if !exists {
+			unfulfilled = append(unfulfilled, fmt.Sprintf("label '%s=%s' is missing", label, r.RequiredLabels[label]))
+			continue
+		}
+
+		if value != r.RequiredLabels[label] {
+			unfulfilled = append(unfulfilled, fmt.Sprintf("label '%s' is '%s' when '%s' is required", label, value, r.RequiredLabels[label]))
+		}
+	}
+
+	if len(unfulfilled) > 0 {
+		return errors.New(errContext, fmt.Sprintf("Rule '%s' is not fulfilled: %s", r.Name, strings.Join(unfulfilled, ", ")))
+	}
+
+	return nil
+}
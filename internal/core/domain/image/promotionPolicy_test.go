@@ -0,0 +1,193 @@
+package image
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotionPolicyValidate(t *testing.T) {
+
+	errContext := "(core::domain::image::PromotionPolicy::Validate)"
+
+	tests := []struct {
+		desc   string
+		policy *PromotionPolicy
+		err    error
+	}{
+		{
+			desc: "Testing validate a promotion policy",
+			policy: &PromotionPolicy{
+				Rules: []*PromotionRule{
+					{
+						Name:           "prod",
+						Sources:        []string{"registry.staging.test/*/*"},
+						Targets:        []string{"registry.prod.test/stable/*"},
+						VersionPattern: `^\d+\.\d+\.\d+$`,
+					},
+				},
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error validating a promotion policy rule without sources",
+			policy: &PromotionPolicy{
+				Rules: []*PromotionRule{
+					{
+						Name:    "prod",
+						Targets: []string{"registry.prod.test/stable/*"},
+					},
+				},
+			},
+			err: errors.New(errContext, "Promotion policy rule 'prod' requires at least one source"),
+		},
+		{
+			desc: "Testing error validating an unnamed promotion policy rule without targets",
+			policy: &PromotionPolicy{
+				Rules: []*PromotionRule{
+					{
+						Sources: []string{"registry.staging.test/*/*"},
+					},
+				},
+			},
+			err: errors.New(errContext, "Promotion policy rule 'rule-0' requires at least one target"),
+		},
+		{
+			desc: "Testing error validating a promotion policy rule with an invalid version pattern",
+			policy: &PromotionPolicy{
+				Rules: []*PromotionRule{
+					{
+						Name:           "prod",
+						Sources:        []string{"registry.staging.test/*/*"},
+						Targets:        []string{"registry.prod.test/stable/*"},
+						VersionPattern: `^(\d+$`,
+					},
+				},
+			},
+			err: errors.New(errContext, "Promotion policy rule 'prod' version pattern '^(\\d+$' is not valid\n error parsing regexp: missing closing ): `^(\\d+$`"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.policy.Validate()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPromotionPolicyRoutes(t *testing.T) {
+
+	policy := &PromotionPolicy{
+		Rules: []*PromotionRule{
+			{
+				Name:    "staging-to-prod",
+				Sources: []string{"registry.staging.test/*/*"},
+				Targets: []string{"registry.prod.test/stable/*"},
+			},
+			{
+				Name:    "qa-to-staging",
+				Sources: []string{"qa/*"},
+				Targets: []string{"registry.staging.test/*/*"},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc   string
+		source string
+		target string
+		res    []string
+	}{
+		{
+			desc:   "Testing routes allowed from a source registry to a target namespace",
+			source: "registry.staging.test/qa/image:1.2.3",
+			target: "registry.prod.test/stable/image:1.2.3",
+			res:    []string{"staging-to-prod"},
+		},
+		{
+			desc:   "Testing routes allowed from a source namespace on any registry",
+			source: "registry.staging.test/qa/image:1.2.3",
+			target: "registry.staging.test/stable/image:1.2.3",
+			res:    []string{"qa-to-staging"},
+		},
+		{
+			desc:   "Testing no routes are allowed to an ungoverned target",
+			source: "registry.staging.test/qa/image:1.2.3",
+			target: "registry.prod.test/unstable/image:1.2.3",
+			res:    []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			routes, err := policy.Routes(test.source, test.target)
+			assert.Nil(t, err)
+
+			res := []string{}
+			for _, route := range routes {
+				res = append(res, route.Name)
+			}
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestPromotionRuleCheck(t *testing.T) {
+
+	errContext := "(core::domain::image::PromotionRule::Check)"
+
+	rule := &PromotionRule{
+		Name:           "prod",
+		RequiredLabels: map[string]string{"qa.approved": "true", "team": "core"},
+		VersionPattern: `^\d+\.\d+\.\d+$`,
+	}
+
+	tests := []struct {
+		desc    string
+		version string
+		labels  map[string]string
+		err     error
+	}{
+		{
+			desc:    "Testing check a rule when all requirements are fulfilled",
+			version: "1.2.3",
+			labels:  map[string]string{"qa.approved": "true", "team": "core"},
+			err:     &errors.Error{},
+		},
+		{
+			desc:    "Testing error checking a rule when the version does not match",
+			version: "1.2.3-rc.1",
+			labels:  map[string]string{"qa.approved": "true", "team": "core"},
+			err:     errors.New(errContext, "Rule 'prod' is not fulfilled: version '1.2.3-rc.1' does not match '^\\d+\\.\\d+\\.\\d+$'"),
+		},
+		{
+			desc:    "Testing error checking a rule when labels are missing or differ",
+			version: "1.2.3",
+			labels:  map[string]string{"qa.approved": "false"},
+			err:     errors.New(errContext, "Rule 'prod' is not fulfilled: label 'qa.approved' is 'false' when 'true' is required, label 'team=core' is missing"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := rule.Check(test.version, test.labels)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
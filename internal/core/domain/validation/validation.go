@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"fmt"
+)
+
+const (
+	// SeverityError is the severity of the issues that prevent stevedore from loading a file as expected
+	SeverityError = "error"
+	// SeverityWarning is the severity of the issues that do not prevent stevedore from loading a file, such as the deprecated keys
+	SeverityWarning = "warning"
+
+	// ConfigurationKind is the kind of the stevedore configuration file
+	ConfigurationKind = "configuration"
+	// BuildersKind is the kind of the files that define builders
+	BuildersKind = "builders"
+	// ImagesKind is the kind of the files that define images
+	ImagesKind = "images"
+)
+
+// Source is a file or a folder to validate, along with the kind of definitions it contains
+type Source struct {
+	// Path is the file or folder location. All the '*.yaml' and '*.yml' files are validated when it is a folder
+	Path string
+	// Kind is the kind of definitions that the source contains: configuration, builders or images
+	Kind string
+}
+
+// Issue is a problem found validating a file
+type Issue struct {
+	// File is the file where the issue is found
+	File string `json:"file" yaml:"file"`
+	// Line is the line where the issue is found. It is zero when the issue is not related to any line
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+	// Column is the column where the issue is found. It is zero when the issue is not related to any column
+	Column int `json:"column,omitempty" yaml:"column,omitempty"`
+	// Path is the keys path to the element where the issue is found, such as 'images.ubuntu.22.04.persistent_vars'
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Severity is the issue severity, either error or warning
+	Severity string `json:"severity" yaml:"severity"`
+	// Message describes the issue
+	Message string `json:"message" yaml:"message"`
+}
+
+// Location returns the issue location as 'file:line:column'. Line and column are only included when they are known
+func (i *Issue) Location() string {
+	if i == nil {
+		return ""
+	}
+
+	if i.Line <= 0 {
+		return i.File
+	}
+
+	if i.Column <= 0 {
+		return fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", i.File, i.Line, i.Column)
+}
+
+// IsError returns whether the issue has error severity
+func (i *Issue) IsError() bool {
+	if i == nil {
+		return false
+	}
+
+	return i.Severity == SeverityError
+}
@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		desc  string
+		issue *Issue
+		res   string
+	}{
+		{
+			desc:  "Testing location of a nil issue",
+			issue: nil,
+			res:   "",
+		},
+		{
+			desc:  "Testing location of an issue without line",
+			issue: &Issue{File: "stevedore.yaml"},
+			res:   "stevedore.yaml",
+		},
+		{
+			desc:  "Testing location of an issue without column",
+			issue: &Issue{File: "stevedore.yaml", Line: 3},
+			res:   "stevedore.yaml:3",
+		},
+		{
+			desc:  "Testing location of an issue with line and column",
+			issue: &Issue{File: "stevedore.yaml", Line: 3, Column: 5},
+			res:   "stevedore.yaml:3:5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.issue.Location())
+		})
+	}
+}
+
+func TestIsError(t *testing.T) {
+	tests := []struct {
+		desc  string
+		issue *Issue
+		res   bool
+	}{
+		{
+			desc:  "Testing a nil issue is not an error",
+			issue: nil,
+			res:   false,
+		},
+		{
+			desc:  "Testing an issue with error severity is an error",
+			issue: &Issue{Severity: SeverityError},
+			res:   true,
+		},
+		{
+			desc:  "Testing an issue with warning severity is not an error",
+			issue: &Issue{Severity: SeverityWarning},
+			res:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.issue.IsError())
+		})
+	}
+}
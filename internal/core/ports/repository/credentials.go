@@ -1,18 +1,53 @@
 package repository
 
-import "github.com/gostevedore/stevedore/internal/core/domain/credentials"
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
 
 // AuthFactorier
 type AuthFactorier interface {
 	Get(id string) (AuthMethodReader, error)
 }
 
-// CredentialsStorer is a repository for credentials
+// CredentialsStorer is a repository for credentials. Get returns a credentials.NotFoundError when there are no credentials for the id
 type CredentialsStorer interface {
 	Get(id string) (*credentials.Credential, error)
 	Store(id string, credential *credentials.Credential) error
 }
 
+// CredentialsDeleter is a repository that deletes credentials
+type CredentialsDeleter interface {
+	Delete(id string) error
+}
+
+// CredentialsRenamer is a repository that renames credentials
+type CredentialsRenamer interface {
+	Rename(id, newID string) error
+}
+
+// CredentialsLister is a repository that lists all its credentials
+type CredentialsLister interface {
+	All() ([]*credentials.Credential, error)
+}
+
+// CredentialsBundleWriter is a repository that persists a set of credentials at once
+type CredentialsBundleWriter interface {
+	Write(credentials []*credentials.Credential) error
+}
+
+// CredentialsEncrypter encrypts and decrypts the credentials persisted by a repository
+type CredentialsEncrypter interface {
+	Encrypt(text string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// CredentialsRekeyer is a repository that re-encrypts all its credentials with a new encryption
+type CredentialsRekeyer interface {
+	Rekey(encryption CredentialsEncrypter) error
+}
+
 // AuthProviderer interface that provides authentication
 type AuthProviderer interface {
 	Get(credential *credentials.Credential) (AuthMethodReader, error)
@@ -54,3 +89,13 @@ type CredentialsFilterer interface {
 type CredentialsPrinter interface {
 	Print(credentials []*credentials.Credential) error
 }
+
+// RegistryChecker checks the access to a registry using the username and password. When a repository is provided, it also checks the access to the repository for the given scope
+type RegistryChecker interface {
+	Check(ctx context.Context, registry, username, password, repository, scope string) (*credentials.CheckResult, error)
+}
+
+// CredentialsCheckPrinter is an interface for printing the credentials check results
+type CredentialsCheckPrinter interface {
+	Print(results []*credentials.CheckResult) error
+}
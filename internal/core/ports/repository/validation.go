@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/gostevedore/stevedore/internal/core/domain/validation"
+
+// Validator is an interface for validating the configuration, builders and images files
+type Validator interface {
+	Validate(sources []*validation.Source) ([]*validation.Issue, error)
+}
+
+// ValidationPrinter is an interface for printing the issues found validating the files
+type ValidationPrinter interface {
+	Print(issues []*validation.Issue) error
+}
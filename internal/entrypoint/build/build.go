@@ -3,11 +3,10 @@ package build
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	errors "github.com/apenella/go-common-utils/error"
 	godockerbuild "github.com/apenella/go-docker-builder/pkg/build"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	dockerclient "github.com/docker/docker/client"
 	application "github.com/gostevedore/stevedore/internal/application/build"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
@@ -15,19 +14,12 @@ import (
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	buildhandler "github.com/gostevedore/stevedore/internal/handler/build"
 	handler "github.com/gostevedore/stevedore/internal/handler/build"
-	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
-	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
-	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
-	authmethodsshagent "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/sshagent"
-	authproviderawsecr "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr"
-	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token"
-	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token/awscredprovider"
-	authproviderstore "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/store"
-	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
+	authexpiration "github.com/gostevedore/stevedore/internal/infrastructure/auth/expiration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	buildersconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/builders"
 	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
 	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/ansible"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/ansible/goansible"
 	driverdefault "github.com/gostevedore/stevedore/internal/infrastructure/driver/default"
@@ -35,12 +27,13 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder"
 	dockercontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context"
 	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
+	"github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/sshforward"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/dryrun"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/factory"
-	credentialsformatfactory "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/graph"
 	"github.com/gostevedore/stevedore/internal/infrastructure/now"
 	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
+	"github.com/gostevedore/stevedore/internal/infrastructure/promote/registry"
 	defaultreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/default"
 	dockerreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/render"
@@ -50,10 +43,7 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/worker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/semver"
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/builders"
-	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
-	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
-	credentialsenvvarsstorebackend "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars/backend"
-	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/images"
 	"github.com/spf13/afero"
 )
@@ -122,6 +112,7 @@ func (e *Entrypoint) Execute(
 	var buildService *application.Application
 	var commandFactory *command.BuildCommandFactory
 	var credentialsFactory repository.AuthFactorier
+	var definitionsSources *definitionssources.DefinitionsSources
 	var dispatcher *dispatch.Dispatch
 	var entrypointOptions *Options
 	var err error
@@ -157,7 +148,12 @@ func (e *Entrypoint) Execute(
 		return errors.New(errContext, "", err)
 	}
 
-	buildersStore, err = e.createBuildersStore(conf)
+	definitionsSources, err = e.createDefinitionsSources(conf, credentialsFactory)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	buildersStore, err = e.createBuildersStore(ctx, conf, definitionsSources)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
@@ -192,7 +188,7 @@ func (e *Entrypoint) Execute(
 		return errors.New(errContext, "", err)
 	}
 
-	buildService = application.NewApplication(
+	applicationOptions := []application.OptionsFunc{
 		application.WithBuilders(buildersStore),
 		application.WithCommandFactory(commandFactory),
 		application.WithDriverFactory(buildDriverFactory),
@@ -200,7 +196,26 @@ func (e *Entrypoint) Execute(
 		application.WithDispatch(dispatcher),
 		application.WithSemver(semVerFactory),
 		application.WithCredentials(credentialsFactory),
-	)
+	}
+
+	expirationWarner, err := e.createCredentialsExpirationWarner(conf.Credentials, credentialsFactory)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	if expirationWarner != nil {
+		applicationOptions = append(applicationOptions, application.WithCredentialsExpirationWarner(expirationWarner))
+	}
+
+	// immutable tags are not checked on dry-run because images are not pushed
+	if !entrypointOptions.DryRun {
+		immutableTagsOptions, err := e.prepareImmutableTags(conf, entrypointOptions)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+		applicationOptions = append(applicationOptions, immutableTagsOptions...)
+	}
+
+	buildService = application.NewApplication(applicationOptions...)
 
 	imageRender, err = e.createImageRender(now.NewNow())
 	if err != nil {
@@ -217,7 +232,7 @@ func (e *Entrypoint) Execute(
 		return errors.New(errContext, "", err)
 	}
 
-	imagesStore, err = e.createImagesStore(conf, imageRender, imagesGraphTemplatesStore)
+	imagesStore, err = e.createImagesStore(ctx, conf, imageRender, imagesGraphTemplatesStore, definitionsSources)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
@@ -302,6 +317,7 @@ func (e *Entrypoint) prepareHandlerOptions(conf *configuration.Configuration, in
 	options.BuildOnCascade = inputHandlerOptions.BuildOnCascade
 	options.CascadeDepth = inputHandlerOptions.CascadeDepth
 	options.EnableSemanticVersionTags = conf.EnableSemanticVersionTags || inputHandlerOptions.EnableSemanticVersionTags
+	options.Force = inputHandlerOptions.Force
 	options.ImageFromName = inputHandlerOptions.ImageFromName
 	options.ImageFromRegistryHost = inputHandlerOptions.ImageFromRegistryHost
 	options.ImageFromRegistryNamespace = inputHandlerOptions.ImageFromRegistryNamespace
@@ -327,65 +343,49 @@ func (e *Entrypoint) prepareHandlerOptions(conf *configuration.Configuration, in
 	return options, nil
 }
 
-func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
+// prepareImmutableTags returns the application options required to protect the immutable tags defined on the configuration
+func (e *Entrypoint) prepareImmutableTags(conf *configuration.Configuration, options *Options) ([]application.OptionsFunc, error) {
 
-	var store repository.CredentialsStorer
+	errContext := "(entrypoint::build::prepareImmutableTags)"
 
-	errContext := "(entrypoint::build::createCredentialsStore)"
-
-	if conf == nil {
-		return nil, errors.New(errContext, "To create credentials store in build entrypoint, credentials configuration is required")
+	if conf.ImmutableTags == nil || len(conf.ImmutableTags.Images) == 0 {
+		return nil, nil
 	}
 
-	if conf.Format == "" {
-		return nil, errors.New(errContext, "To create credentials store in build entrypoint, credentials format must be specified")
+	immutableTags, err := image.NewImmutableTags(conf.ImmutableTags.Images, conf.ImmutableTags.FloatingTags)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
 	}
 
-	encryption := credentialsstoreencryption.NewEncryption(
-		credentialsstoreencryption.WithKey(conf.EncryptionKey),
-	)
-
-	credentialsFormatFactory := credentialsformatfactory.NewFormatFactory()
-	credentialsFormat, err := credentialsFormatFactory.Get(conf.Format)
+	referenceName, err := e.createReferenceName(options)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
 
-	switch conf.StorageType {
-	case credentials.LocalStore:
-		if e.compatibility == nil {
-			return nil, errors.New(errContext, "To create credentials store in build entrypoint, compatibility is required")
-		}
-
-		if conf.LocalStoragePath == "" {
-			return nil, errors.New(errContext, "To create credentials store in build entrypoint, local storage path is required")
-		}
-
-		credentialsCompatibility := credentialscompatibility.NewCredentialsCompatibility(e.compatibility)
+	return []application.OptionsFunc{
+		application.WithImmutableTags(immutableTags),
+		application.WithDigestResolver(registry.NewDigestResolver(&http.Client{}, registry.DefaultScheme)),
+		application.WithReferenceNamer(referenceName),
+	}, nil
+}
 
-		localStoreOpts := []credentialslocalstore.OptionsFunc{
-			credentialslocalstore.WithFilesystem(e.fs),
-			credentialslocalstore.WithCompatibility(credentialsCompatibility),
-			credentialslocalstore.WithPath(conf.LocalStoragePath),
-			credentialslocalstore.WithFormater(credentialsFormat),
-		}
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
 
-		if conf.EncryptionKey != "" {
-			localStoreOpts = append(localStoreOpts, credentialslocalstore.WithEncryption(encryption))
-		}
+	errContext := "(entrypoint::build::createCredentialsStore)"
 
-		store = credentialslocalstore.NewLocalStore(localStoreOpts...)
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in build entrypoint, credentials configuration is required")
+	}
 
-	case credentials.EnvvarsStore:
-		store = credentialsenvvarsstore.NewEnvvarsStore(
-			credentialsenvvarsstore.WithConsole(e.writer),
-			credentialsenvvarsstore.WithBackend(credentialsenvvarsstorebackend.NewOSEnvvarsBackend()),
-			credentialsenvvarsstore.WithFormater(credentialsFormat),
-			credentialsenvvarsstore.WithEncryption(encryption),
-		)
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.writer),
+	)
 
-	default:
-		return nil, errors.New(errContext, fmt.Sprintf("Unsupported credentials storage type '%s'", conf.StorageType))
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
 	}
 
 	return store, nil
@@ -411,37 +411,70 @@ func (e *Entrypoint) createAuthFactory(conf *configuration.Configuration) (repos
 		return nil, errors.New(errContext, "", err)
 	}
 
-	// create authorization methods
-	basic := authmethodbasic.NewBasicAuthMethod()
-	keyfile := authmethodkeyfile.NewKeyFileAuthMethod()
-	sshagent := authmethodsshagent.NewSSHAgentAuthMethod()
-
-	// create authorization badge provider
-	badge := authproviderstore.NewStoreAuthProvider(basic, keyfile, sshagent)
-
-	// create authorization aws ecr provider
-	tokenProvider := token.NewAWSECRToken(
-		token.WithStaticCredentialsProvider(awscredprovider.NewStaticCredentialsProvider()),
-		token.WithAssumeRoleARNProvider(awscredprovider.NewAssumerRoleARNProvider()),
-		token.WithECRClientFactory(
-			token.NewECRClientFactory(
-				func(cfg aws.Config) token.ECRClienter {
-					c := ecr.NewFromConfig(cfg)
-					return c
-				},
-			),
-		),
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
 	)
 
-	awsecr := authproviderawsecr.NewAWSECRAuthProvider(tokenProvider)
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
 
-	// create credentials factory
-	factory := authfactory.NewAuthFactory(store, badge, awsecr)
+	return authFactory, nil
+}
 
-	return factory, nil
+// createCredentialsExpirationWarner returns the component which warns about the credentials that are about to expire, or nil when the warnings are disabled
+func (e *Entrypoint) createCredentialsExpirationWarner(conf *configuration.CredentialsConfiguration, credentialsFactory repository.AuthFactorier) (*authexpiration.ExpirationWarner, error) {
+	var err error
+
+	errContext := "(entrypoint::build::createCredentialsExpirationWarner)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the credentials expiration warner in build entrypoint, credentials configuration is required")
+	}
+
+	// credentials can only be inspected when the auth factory resolves them
+	resolver, isResolver := credentialsFactory.(authexpiration.CredentialsResolver)
+	if !isResolver || e.writer == nil {
+		return nil, nil
+	}
+
+	window := credentials.DefaultExpirationWarningWindow
+	if conf.ExpirationWarningWindow != "" {
+		window, err = credentials.ParseDuration(conf.ExpirationWarningWindow)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid credentials '%s' value", configuration.CredentialsExpirationWarningWindowKey), err)
+		}
+	}
+
+	if window == 0 {
+		return nil, nil
+	}
+
+	return authexpiration.NewExpirationWarner(resolver, e.writer, authexpiration.WithWindow(window)), nil
 }
 
-func (e *Entrypoint) createBuildersStore(conf *configuration.Configuration) (*builders.Store, error) {
+// createDefinitionsSources returns the component which resolves the local paths where the images and builders are defined, fetching the git sources into the cache folder
+func (e *Entrypoint) createDefinitionsSources(conf *configuration.Configuration, credentialsFactory repository.AuthFactorier) (*definitionssources.DefinitionsSources, error) {
+
+	errContext := "(entrypoint::build::createDefinitionsSources)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the definitions sources in build entrypoint, configuration is required")
+	}
+
+	options := []definitionssources.OptionsFunc{
+		definitionssources.WithCachePath(conf.GitSourcesCachePath),
+	}
+
+	if credentialsFactory != nil {
+		options = append(options, definitionssources.WithAuthFactory(gitauth.NewGitAuthFactory(credentialsFactory)))
+	}
+
+	return definitionssources.NewDefinitionsSources(options...), nil
+}
+
+func (e *Entrypoint) createBuildersStore(ctx context.Context, conf *configuration.Configuration, sources *definitionssources.DefinitionsSources) (*builders.Store, error) {
 
 	errContext := "(entrypoint::build::createBuildersStore)"
 
@@ -453,17 +486,28 @@ func (e *Entrypoint) createBuildersStore(conf *configuration.Configuration) (*bu
 		return nil, errors.New(errContext, "To create a builders store in build entrypoint, configuration is required")
 	}
 
-	if conf.BuildersPath == "" {
+	if conf.BuildersPath == "" && len(conf.BuildersSources) == 0 {
 		return nil, errors.New(errContext, "To create a builders store in build entrypoint, builders path must be provided in configuration")
 	}
 
-	buildersStore := builders.NewStore()
-	buildersConfiguration := buildersconfiguration.NewBuilders(e.fs, buildersStore)
-	err := buildersConfiguration.LoadBuilders(conf.BuildersPath)
+	if sources == nil {
+		return nil, errors.New(errContext, "To create a builders store in build entrypoint, definitions sources are required")
+	}
+
+	paths, err := sources.Paths(ctx, conf.BuildersDefinitionsSources())
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
 
+	buildersStore := builders.NewStore()
+	buildersConfiguration := buildersconfiguration.NewBuilders(e.fs, buildersStore)
+	for _, path := range paths {
+		err = buildersConfiguration.LoadBuilders(path)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+	}
+
 	return buildersStore, nil
 }
 
@@ -489,7 +533,7 @@ func (e *Entrypoint) createImageRender(now render.Nower) (*render.ImageRender, e
 	return render.NewImageRender(now), nil
 }
 
-func (e *Entrypoint) createImagesStore(conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer) (*images.Store, error) {
+func (e *Entrypoint) createImagesStore(ctx context.Context, conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer, sources *definitionssources.DefinitionsSources) (*images.Store, error) {
 
 	errContext := "(entrypoint::build::createImagesStore)"
 
@@ -513,13 +557,22 @@ func (e *Entrypoint) createImagesStore(conf *configuration.Configuration, render
 		return nil, errors.New(errContext, "To create an images store in build entrypoint, compatibility is required")
 	}
 
-	if conf.ImagesPath == "" {
+	if conf.ImagesPath == "" && len(conf.ImagesSources) == 0 {
 		return nil, errors.New(errContext, "To create an images store in build entrypoint, images path must be provided in configuration")
 	}
 
+	if sources == nil {
+		return nil, errors.New(errContext, "To create an images store in build entrypoint, definitions sources are required")
+	}
+
+	paths, err := sources.Paths(ctx, conf.ImagesDefinitionsSources())
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
 	store := images.NewStore(render)
 	imagesConfiguration := imagesconfiguration.NewImagesConfiguration(e.fs, graph, store, render, e.compatibility)
-	err := imagesConfiguration.LoadImagesToStore(conf.ImagesPath)
+	err = imagesConfiguration.LoadImagesToStore(paths...)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
@@ -710,6 +763,7 @@ func (e *Entrypoint) createDockerDriver(credentialsFactory repository.AuthFactor
 		gitAuth = gitauth.NewGitAuthFactory(credentialsFactory)
 		dockerDriverBuldContext = dockercontext.NewDockerBuildContextFactory(gitAuth)
 		goDockerBuildDriver = godockerbuilder.NewGoDockerBuildDriver(goDockerBuild, dockerDriverBuldContext)
+		goDockerBuildDriver.WithSSHForward(sshforward.NewSSHForwardFactory(credentialsFactory), dockerClient)
 		dockerDriver, err = docker.NewDockerDriver(goDockerBuildDriver, referenceName, e.writer)
 		if err != nil {
 			return nil, errors.New(errContext, "", err)
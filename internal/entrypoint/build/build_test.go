@@ -1,6 +1,7 @@
 package build
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -8,11 +9,13 @@ import (
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/build"
+	authexpiration "github.com/gostevedore/stevedore/internal/infrastructure/auth/expiration"
 	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
 	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
 	"github.com/gostevedore/stevedore/internal/infrastructure/console"
 	"github.com/gostevedore/stevedore/internal/infrastructure/driver/ansible"
 	defaultdriver "github.com/gostevedore/stevedore/internal/infrastructure/driver/default"
@@ -31,6 +34,7 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/builders"
 	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
 	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/images"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -347,31 +351,35 @@ func TestCreateCredentialsStore(t *testing.T) {
 			conf: &configuration.CredentialsConfiguration{
 				StorageType: credentials.LocalStore,
 			},
-			err: errors.New(errContext, "To create credentials store in build entrypoint, credentials format must be specified"),
+			err: errors.New(errContext, "To create the credentials store, credentials format must be defined"),
 		},
 		{
-			desc:       "Testing error when creating credentials local store in build entrypoint with undefined compatibilitier",
-			entrypoint: NewEntrypoint(),
+			desc: "Testing error when creating credentials local store in build entrypoint with undefined compatibilitier",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
 			conf: &configuration.CredentialsConfiguration{
 				StorageType: credentials.LocalStore,
 				Format:      credentials.JSONFormat,
 			},
-			err: errors.New(errContext, "To create credentials store in build entrypoint, compatibility is required"),
+			err: errors.New(errContext, "To create the credentials local store, compatibility is required"),
 		},
 		{
 			desc: "Testing error when creating credentials local store in build entrypoint with undefined storage path",
 			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
 			conf: &configuration.CredentialsConfiguration{
 				StorageType: credentials.LocalStore,
 				Format:      credentials.JSONFormat,
 			},
-			err: errors.New(errContext, "To create credentials store in build entrypoint, local storage path is required"),
+			err: errors.New(errContext, "To create the credentials local store, local storage path is required"),
 		},
 		{
 			desc: "Testing error when creating credentials local store in build entrypoint with unsupported storage type",
 			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
 			conf: &configuration.CredentialsConfiguration{
@@ -383,6 +391,7 @@ func TestCreateCredentialsStore(t *testing.T) {
 		{
 			desc: "Testing create credentials local store in build entrypoint",
 			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
 			conf: &configuration.CredentialsConfiguration{
@@ -396,6 +405,7 @@ func TestCreateCredentialsStore(t *testing.T) {
 		{
 			desc: "Testing create credentials envvars store in build entrypoint",
 			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
 			conf: &configuration.CredentialsConfiguration{
@@ -405,6 +415,23 @@ func TestCreateCredentialsStore(t *testing.T) {
 			res: &credentialsenvvarsstore.EnvvarsStore{},
 			err: &errors.Error{},
 		},
+		{
+			desc:       "Testing create credentials Vault store in build entrypoint",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+			err: &errors.Error{},
+		},
 	}
 
 	for _, test := range tests {
@@ -499,6 +526,121 @@ func TestCreateAuthFactory(t *testing.T) {
 	}
 }
 
+func TestCreateCredentialsExpirationWarner(t *testing.T) {
+	errContext := "(entrypoint::build::createCredentialsExpirationWarner)"
+
+	tests := []struct {
+		desc               string
+		entrypoint         *Entrypoint
+		conf               *configuration.CredentialsConfiguration
+		credentialsFactory repository.AuthFactorier
+		res                *authexpiration.ExpirationWarner
+		err                error
+	}{
+		{
+			desc:               "Testing error creating credentials expiration warner in build entrypoint when credentials configuration is not defined",
+			entrypoint:         NewEntrypoint(WithWriter(console.NewMockConsole())),
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			err:                errors.New(errContext, "To create the credentials expiration warner in build entrypoint, credentials configuration is required"),
+		},
+		{
+			desc:               "Testing create credentials expiration warner in build entrypoint using the default window",
+			entrypoint:         NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf:               &configuration.CredentialsConfiguration{},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			res:                &authexpiration.ExpirationWarner{},
+		},
+		{
+			desc:       "Testing create credentials expiration warner in build entrypoint using the configured window",
+			entrypoint: NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf: &configuration.CredentialsConfiguration{
+				ExpirationWarningWindow: "14d",
+			},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			res:                &authexpiration.ExpirationWarner{},
+		},
+		{
+			desc:       "Testing create no credentials expiration warner in build entrypoint when the warnings are disabled",
+			entrypoint: NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf: &configuration.CredentialsConfiguration{
+				ExpirationWarningWindow: "0",
+			},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			res:                nil,
+		},
+		{
+			desc:       "Testing error creating credentials expiration warner in build entrypoint when the window is not valid",
+			entrypoint: NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf: &configuration.CredentialsConfiguration{
+				ExpirationWarningWindow: "soon",
+			},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			err: errors.New(errContext, "Invalid credentials 'expiration_warning_window' value",
+				errors.New("(core::domain::credentials::ParseDuration)", "Invalid duration 'soon'")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			warner, err := test.entrypoint.createCredentialsExpirationWarner(test.conf, test.credentialsFactory)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				if test.res == nil {
+					assert.Nil(t, warner)
+				} else {
+					assert.IsType(t, test.res, warner)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateDefinitionsSources(t *testing.T) {
+	errContext := "(entrypoint::build::createDefinitionsSources)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.Configuration
+		res        *definitionssources.DefinitionsSources
+		err        error
+	}{
+		{
+			desc:       "Testing error creating definitions sources in build entrypoint when configuration is not defined",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To create the definitions sources in build entrypoint, configuration is required"),
+		},
+		{
+			desc:       "Testing create definitions sources in build entrypoint",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+			},
+			res: definitionssources.NewDefinitionsSources(
+				definitionssources.WithCachePath("/cache/git"),
+			),
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			sources, err := test.entrypoint.createDefinitionsSources(test.conf, nil)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, sources)
+			}
+		})
+	}
+}
+
 func TestCreateBuildersStore(t *testing.T) {
 	errContext := "(entrypoint::build::createBuildersStore)"
 
@@ -510,6 +652,7 @@ func TestCreateBuildersStore(t *testing.T) {
 		desc       string
 		entrypoint *Entrypoint
 		conf       *configuration.Configuration
+		sources    *definitionssources.DefinitionsSources
 		res        *builders.Store
 		err        error
 	}{
@@ -533,6 +676,16 @@ func TestCreateBuildersStore(t *testing.T) {
 			conf: &configuration.Configuration{},
 			err:  errors.New(errContext, "To create a builders store in build entrypoint, builders path must be provided in configuration"),
 		},
+		{
+			desc: "Testing error creating builder store in build entrypoint when definitions sources are not defined",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(testFs),
+			),
+			conf: &configuration.Configuration{
+				BuildersPath: baseDir,
+			},
+			err: errors.New(errContext, "To create a builders store in build entrypoint, definitions sources are required"),
+		},
 		{
 			desc: "Testing create builders store in build entrypoint",
 			entrypoint: NewEntrypoint(
@@ -541,8 +694,24 @@ func TestCreateBuildersStore(t *testing.T) {
 			conf: &configuration.Configuration{
 				BuildersPath: baseDir,
 			},
-			res: &builders.Store{},
-			err: &errors.Error{},
+			sources: definitionssources.NewDefinitionsSources(),
+			res:     &builders.Store{},
+			err:     &errors.Error{},
+		},
+		{
+			desc: "Testing create builders store in build entrypoint from several sources",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(testFs),
+			),
+			conf: &configuration.Configuration{
+				BuildersSources: []*configuration.DefinitionsSource{
+					{Path: baseDir},
+					{Path: baseDir},
+				},
+			},
+			sources: definitionssources.NewDefinitionsSources(),
+			res:     &builders.Store{},
+			err:     &errors.Error{},
 		},
 	}
 
@@ -550,7 +719,7 @@ func TestCreateBuildersStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			store, err := test.entrypoint.createBuildersStore(test.conf)
+			store, err := test.entrypoint.createBuildersStore(context.TODO(), test.conf, test.sources)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
@@ -700,6 +869,7 @@ func TestCreateImagesStore(t *testing.T) {
 		render        repository.Renderer
 		graph         imagesconfiguration.ImagesGraphTemplatesStorer
 		compatibility Compatibilitier
+		sources       *definitionssources.DefinitionsSources
 		res           *images.Store
 		err           error
 	}{
@@ -754,6 +924,20 @@ func TestCreateImagesStore(t *testing.T) {
 			compatibility: &compatibility.Compatibility{},
 			err:           errors.New(errContext, "To create an images store in build entrypoint, images path must be provided in configuration"),
 		},
+		{
+			desc: "Testing error creating images store in build entrypoint when definitions sources are not defined",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(testFs),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				ImagesPath: baseDir,
+			},
+			render:        &render.ImageRender{},
+			graph:         &imagesgraphtemplate.ImagesGraphTemplate{},
+			compatibility: &compatibility.Compatibility{},
+			err:           errors.New(errContext, "To create an images store in build entrypoint, definitions sources are required"),
+		},
 		{
 			desc: "Testing create images store in build entrypoint",
 			entrypoint: NewEntrypoint(
@@ -768,6 +952,7 @@ func TestCreateImagesStore(t *testing.T) {
 				graph.NewGraphTemplateFactory(false),
 			),
 			compatibility: &compatibility.Compatibility{},
+			sources:       definitionssources.NewDefinitionsSources(),
 			res:           &images.Store{},
 			err:           &errors.Error{},
 		},
@@ -777,7 +962,7 @@ func TestCreateImagesStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			store, err := test.entrypoint.createImagesStore(test.conf, test.render, test.graph)
+			store, err := test.entrypoint.createImagesStore(context.TODO(), test.conf, test.render, test.graph, test.sources)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
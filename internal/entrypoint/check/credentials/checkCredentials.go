@@ -0,0 +1,190 @@
+package credentials
+
+import (
+	"context"
+	"net/http"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/check/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/check/credentials"
+	registrychecker "github.com/gostevedore/stevedore/internal/infrastructure/check/registry"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	outputcredentialscheck "github.com/gostevedore/stevedore/internal/infrastructure/output/credentialscheck"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the check credentials command
+type Entrypoint struct {
+	writer        ConsoleWriter
+	compatibility Compatibilitier
+	fs            afero.Fs
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithWriter sets the writer for the entrypoint
+func WithWriter(w ConsoleWriter) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.writer = w
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+	var store repository.CredentialsStorer
+	var authFactory repository.AuthFactorier
+
+	errContext := "(check::credentials::entrypoint::Execute)"
+
+	if e.writer == nil {
+		return errors.New(errContext, "To execute the check credentials entrypoint, a writer is required")
+	}
+
+	conf, err = e.prepareConfiguration(conf, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	authFactory, err = e.createAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	output := outputcredentialscheck.NewOutput(
+		console.NewConsole(e.writer, nil),
+		outputcredentialscheck.WithFormat(options.Output),
+	)
+
+	appOptions := []application.OptionsFunc{
+		application.WithCredentials(authFactory),
+		application.WithChecker(registrychecker.NewRegistryChecker(&http.Client{})),
+		application.WithOutput(output),
+	}
+
+	// the credentials are listed to check all of them when no id is provided
+	lister, isLister := store.(repository.CredentialsLister)
+	if isLister {
+		appOptions = append(appOptions, application.WithStore(lister))
+	}
+
+	app := application.NewApplication(appOptions...)
+
+	h := handler.NewHandler(
+		handler.WithApplication(app),
+	)
+
+	err = h.Handler(ctx, args, &handler.Options{
+		Repository: options.Repository,
+		Scope:      options.Scope,
+	})
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+func (e *Entrypoint) prepareConfiguration(conf *configuration.Configuration, options *Options) (*configuration.Configuration, error) {
+
+	errContext := "(check::credentials::entrypoint::prepareConfiguration)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to prepare configuration")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "Configuration must be provided to prepare configuration")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "Configuration credentials must be provided to prepare configuration")
+	}
+
+	if conf.Credentials.StorageType == credentials.LocalStore && options.LocalStoragePath != "" {
+		conf.Credentials.LocalStoragePath = options.LocalStoragePath
+	}
+
+	return conf, nil
+}
+
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
+	errContext := "(check::credentials::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the check credentials entrypoint, credentials configuration is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.writer),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
+
+// createAuthFactory creates the auth factory on top of the credentials store. It includes the AWS ECR auth provider, which exchanges the AWS credentials for a registry token
+func (e *Entrypoint) createAuthFactory(conf *configuration.CredentialsConfiguration, store repository.CredentialsStorer) (repository.AuthFactorier, error) {
+
+	errContext := "(check::credentials::entrypoint::createAuthFactory)"
+
+	if store == nil {
+		return nil, errors.New(errContext, "To create the auth factory in the check credentials entrypoint, a credentials store is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+	)
+
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return authFactory, nil
+}
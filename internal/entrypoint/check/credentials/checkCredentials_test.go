@@ -0,0 +1,171 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(check::credentials::entrypoint::Execute)"
+
+	hashedID, _ := encryption.HashID("git.example.com")
+	output := &bytes.Buffer{}
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		args       []string
+		conf       *configuration.Configuration
+		options    *Options
+		assertFunc func(*testing.T, *Entrypoint)
+		err        error
+	}{
+		{
+			desc:       "Testing error executing check credentials entrypoint without writer",
+			entrypoint: NewEntrypoint(),
+			args:       []string{},
+			err:        errors.New(errContext, "To execute the check credentials entrypoint, a writer is required"),
+		},
+		{
+			desc: "Testing error executing check credentials entrypoint without credentials configuration",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+			),
+			args:    []string{},
+			conf:    &configuration.Configuration{},
+			options: &Options{},
+			err: errors.New(errContext, "",
+				errors.New("(check::credentials::entrypoint::prepareConfiguration)", "Configuration credentials must be provided to prepare configuration")),
+		},
+		{
+			desc: "Testing execute check credentials entrypoint on local store",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+				WithFileSystem(testLocalStoreFs(hashedID)),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			args: []string{"git.example.com"},
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+				},
+			},
+			options: &Options{
+				Output: "json",
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				assert.Contains(t, output.String(), `"status": "skipped"`)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			output.Reset()
+
+			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				test.assertFunc(t, test.entrypoint)
+			}
+		})
+	}
+}
+
+func TestCreateCredentialsStore(t *testing.T) {
+
+	errContext := "(check::credentials::entrypoint::createCredentialsStore)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.CredentialsConfiguration
+		res        interface{}
+		err        error
+	}{
+		{
+			desc: "Testing create local credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+			res: &credentialslocalstore.LocalStore{},
+		},
+		{
+			desc:       "Testing create envvars credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.EnvvarsStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsenvvarsstore.EnvvarsStore{},
+		},
+		{
+			desc: "Testing create Docker config credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.DockerConfigStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsdockerconfigstore.DockerConfigStore{},
+		},
+		{
+			desc:       "Testing error creating a credentials store with an unsupported storage type",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: "unknown",
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "Unsupported credentials storage type 'unknown'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			store, err := test.entrypoint.createCredentialsStore(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, store)
+			}
+		})
+	}
+}
+
+// testLocalStoreFs returns a file system with a local store that contains the credentials for the hashed id
+func testLocalStoreFs(hashedID string) afero.Fs {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, filepath.Join("/credentials", hashedID), []byte(`{"id":"git.example.com","private_key_file":"/keys/id_rsa"}`), 0600)
+
+	return fs
+}
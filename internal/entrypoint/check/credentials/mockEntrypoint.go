@@ -0,0 +1,24 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEntrypoint is a mock of the check credentials entrypoint
+type MockEntrypoint struct {
+	mock.Mock
+}
+
+// NewMockEntrypoint provides a mock of the check credentials entrypoint
+func NewMockEntrypoint() *MockEntrypoint {
+	return &MockEntrypoint{}
+}
+
+// Execute provides a mock function
+func (e *MockEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	res := e.Called(ctx, args, conf, options)
+	return res.Error(0)
+}
@@ -0,0 +1,13 @@
+package credentials
+
+// Options is the options for the check credentials command entrypoint
+type Options struct {
+	// LocalStoragePath is the location of local storage
+	LocalStoragePath string
+	// Repository is the repository, on each registry, whose access is checked
+	Repository string
+	// Scope is the access checked on the repository, either pull or push
+	Scope string
+	// Output is the format used to print the check results: table, json or yaml
+	Output string
+}
@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -80,6 +81,11 @@ func (e *CreateConfigurationEntrypoint) Execute(ctx context.Context, options *Op
 		return errors.New(errContext, "", err)
 	}
 
+	err = e.checkEncryptionKeyFile(options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
 	createConfigurationApplication = application.NewCreateConfigurationApplication(
 		application.WithWrite(writer),
 	)
@@ -92,6 +98,11 @@ func (e *CreateConfigurationEntrypoint) Execute(ctx context.Context, options *Op
 		return errors.New(errContext, "", err)
 	}
 
+	err = e.writeEncryptionKeyFile(options, handlerOptions.CredentialsEncryptionKey)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
 	if e.console != nil {
 		e.console.Info("Stevedore configuration successfully created")
 	}
@@ -120,6 +131,9 @@ func (e *CreateConfigurationEntrypoint) prepareHandlerOptions(options *Options)
 	handlerOptions.CredentialsLocalStoragePath = options.CredentialsLocalStoragePath
 	handlerOptions.CredentialsStorageType = options.CredentialsStorageType
 	handlerOptions.CredentialsEncryptionKey = key
+	handlerOptions.CredentialsEncryptionKeyFile = options.CredentialsEncryptionKeyFile
+	handlerOptions.CredentialsEncryptionKeyEnv = options.CredentialsEncryptionKeyEnv
+	handlerOptions.CredentialsEncryptionKeyCommand = options.CredentialsEncryptionKeyCommand
 	handlerOptions.EnableSemanticVersionTags = options.EnableSemanticVersionTags
 	handlerOptions.ImagesPath = options.ImagesPath
 	handlerOptions.LogPathFile = options.LogPathFile
@@ -194,6 +208,21 @@ func (e *CreateConfigurationEntrypoint) getEncryptionKey(options *Options) (stri
 		return "", errors.New(errContext, "Providing an encryption key is not compatible with the generate encryption key option")
 	}
 
+	sources := 0
+	for _, source := range []string{options.CredentialsEncryptionKeyFile, options.CredentialsEncryptionKeyEnv, options.CredentialsEncryptionKeyCommand} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		return "", errors.New(errContext, "Only one of the encryption key file, environment variable or command options can be provided")
+	}
+
+	if (options.GenerateCredentialsEncryptionKey || options.CredentialsEncryptionKey != "") && (options.CredentialsEncryptionKeyEnv != "" || options.CredentialsEncryptionKeyCommand != "") {
+		return "", errors.New(errContext, "Providing or generating an encryption key is only compatible with the encryption key file option")
+	}
+
 	if options.GenerateCredentialsEncryptionKey {
 
 		generator := encryption.NewEncryption()
@@ -208,3 +237,54 @@ func (e *CreateConfigurationEntrypoint) getEncryptionKey(options *Options) (stri
 
 	return options.CredentialsEncryptionKey, nil
 }
+
+// checkEncryptionKeyFile returns an error when the encryption key must be written on a file that already exists, unless force is enabled
+func (e *CreateConfigurationEntrypoint) checkEncryptionKeyFile(options *Options) error {
+
+	errContext := "(entrypoint::create::configuration::checkEncryptionKeyFile)"
+
+	if options.CredentialsEncryptionKeyFile == "" || options.Force {
+		return nil
+	}
+
+	if !options.GenerateCredentialsEncryptionKey && options.CredentialsEncryptionKey == "" {
+		return nil
+	}
+
+	exists, err := afero.Exists(e.fs, options.CredentialsEncryptionKeyFile)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if exists {
+		return errors.New(errContext, fmt.Sprintf("Encryption key file '%s' already exists. Use the force option to overwrite it", options.CredentialsEncryptionKeyFile))
+	}
+
+	return nil
+}
+
+// writeEncryptionKeyFile writes the encryption key on the encryption key file, which is only readable by its owner. Nothing is written when either the encryption key or the file are not provided
+func (e *CreateConfigurationEntrypoint) writeEncryptionKeyFile(options *Options, key string) error {
+
+	errContext := "(entrypoint::create::configuration::writeEncryptionKeyFile)"
+
+	if options.CredentialsEncryptionKeyFile == "" || key == "" {
+		return nil
+	}
+
+	err := e.fs.MkdirAll(filepath.Dir(options.CredentialsEncryptionKeyFile), 0700)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Folder for the encryption key file '%s' could not be created", options.CredentialsEncryptionKeyFile), err)
+	}
+
+	err = afero.WriteFile(e.fs, options.CredentialsEncryptionKeyFile, []byte(key+"\n"), 0600)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Encryption key could not be written on file '%s'", options.CredentialsEncryptionKeyFile), err)
+	}
+
+	if e.console != nil {
+		e.console.Info(fmt.Sprintf("Credentials encryption key written on file '%s'", options.CredentialsEncryptionKeyFile))
+	}
+
+	return nil
+}
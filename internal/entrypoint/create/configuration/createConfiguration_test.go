@@ -112,6 +112,30 @@ func TestPrepareHnadlerOptions(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc:       "Testing prepare handler options into create configuration entrypoint with encryption key command",
+			entrypoint: NewCreateConfigurationEntrypoint(),
+			options: &Options{
+				BuildersPath:                    "builderspath",
+				Concurrency:                     5,
+				CredentialsFormat:               "credentialsformat",
+				CredentialsLocalStoragePath:     "credentialslocalstoragepath",
+				CredentialsStorageType:          "credentialsstoragetype",
+				CredentialsEncryptionKeyCommand: "pass show stevedore",
+				ImagesPath:                      "imagespath",
+			},
+			res: &handler.Options{
+				BuildersPath:                    "builderspath",
+				Concurrency:                     5,
+				CredentialsFormat:               "credentialsformat",
+				CredentialsLocalStoragePath:     "credentialslocalstoragepath",
+				CredentialsStorageType:          "credentialsstoragetype",
+				CredentialsEncryptionKeyCommand: "pass show stevedore",
+				ImagesPath:                      "imagespath",
+				SemanticVersionTagsTemplates:    []string{},
+			},
+			err: &errors.Error{},
+		},
 	}
 
 	for _, test := range tests {
@@ -263,6 +287,33 @@ func TestGetEncryptionKey(t *testing.T) {
 			},
 			resLenth: 32,
 		},
+		{
+			desc:       "Testing create configuration entrypoint error getting encryption key when more than one encryption key source is set",
+			entrypoint: NewCreateConfigurationEntrypoint(),
+			options: &Options{
+				CredentialsEncryptionKeyFile: "/secrets/encryption.key",
+				CredentialsEncryptionKeyEnv:  "ENCRYPTION_KEY",
+			},
+			err: errors.New(errContext, "Only one of the encryption key file, environment variable or command options can be provided"),
+		},
+		{
+			desc:       "Testing create configuration entrypoint error getting encryption key when generate encryption key is enabled along with encryption key command",
+			entrypoint: NewCreateConfigurationEntrypoint(),
+			options: &Options{
+				CredentialsEncryptionKeyCommand:  "pass show stevedore",
+				GenerateCredentialsEncryptionKey: true,
+			},
+			err: errors.New(errContext, "Providing or generating an encryption key is only compatible with the encryption key file option"),
+		},
+		{
+			desc:       "Testing create configuration entrypoint get encryption key when generate encryption key is enabled along with encryption key file",
+			entrypoint: NewCreateConfigurationEntrypoint(),
+			options: &Options{
+				CredentialsEncryptionKeyFile:     "/secrets/encryption.key",
+				GenerateCredentialsEncryptionKey: true,
+			},
+			resLenth: 32,
+		},
 		{
 			desc:       "Testing create configuration entrypoint get encryption key",
 			entrypoint: NewCreateConfigurationEntrypoint(),
@@ -290,3 +341,134 @@ func TestGetEncryptionKey(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckEncryptionKeyFile(t *testing.T) {
+	errContext := "(entrypoint::create::configuration::checkEncryptionKeyFile)"
+
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "/secrets/encryption.key", []byte("key\n"), 0600)
+
+	tests := []struct {
+		desc       string
+		entrypoint *CreateConfigurationEntrypoint
+		options    *Options
+		err        error
+	}{
+		{
+			desc: "Testing create configuration entrypoint check encryption key file when no encryption key file is set",
+			entrypoint: NewCreateConfigurationEntrypoint(
+				WithFileSystem(testFs),
+			),
+			options: &Options{
+				GenerateCredentialsEncryptionKey: true,
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create configuration entrypoint check encryption key file when the existing file is only referenced",
+			entrypoint: NewCreateConfigurationEntrypoint(
+				WithFileSystem(testFs),
+			),
+			options: &Options{
+				CredentialsEncryptionKeyFile: "/secrets/encryption.key",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create configuration entrypoint check encryption key file when the existing file is overwritten by force",
+			entrypoint: NewCreateConfigurationEntrypoint(
+				WithFileSystem(testFs),
+			),
+			options: &Options{
+				CredentialsEncryptionKeyFile:     "/secrets/encryption.key",
+				GenerateCredentialsEncryptionKey: true,
+				Force:                            true,
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create configuration entrypoint error checking encryption key file when the file already exists",
+			entrypoint: NewCreateConfigurationEntrypoint(
+				WithFileSystem(testFs),
+			),
+			options: &Options{
+				CredentialsEncryptionKeyFile:     "/secrets/encryption.key",
+				GenerateCredentialsEncryptionKey: true,
+			},
+			err: errors.New(errContext, "Encryption key file '/secrets/encryption.key' already exists. Use the force option to overwrite it"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.entrypoint.checkEncryptionKeyFile(test.options)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.err, &errors.Error{})
+			}
+		})
+	}
+}
+
+func TestWriteEncryptionKeyFile(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *Options
+		key     string
+		res     string
+		err     error
+	}{
+		{
+			desc: "Testing create configuration entrypoint write encryption key file",
+			options: &Options{
+				CredentialsEncryptionKeyFile: "/secrets/encryption.key",
+			},
+			key: "0123456789abcdef",
+			res: "0123456789abcdef\n",
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create configuration entrypoint does not write encryption key file when there is no encryption key",
+			options: &Options{
+				CredentialsEncryptionKeyFile: "/secrets/encryption.key",
+			},
+			key: "",
+			res: "",
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			testFs := afero.NewMemMapFs()
+			entrypoint := NewCreateConfigurationEntrypoint(
+				WithFileSystem(testFs),
+			)
+
+			err := entrypoint.writeEncryptionKeyFile(test.options, test.key)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			if test.res == "" {
+				exists, _ := afero.Exists(testFs, test.options.CredentialsEncryptionKeyFile)
+				assert.False(t, exists)
+				return
+			}
+
+			content, err := afero.ReadFile(testFs, test.options.CredentialsEncryptionKeyFile)
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, string(content))
+
+			info, err := testFs.Stat(test.options.CredentialsEncryptionKeyFile)
+			assert.NoError(t, err)
+			assert.Equal(t, "-rw-------", info.Mode().Perm().String())
+		})
+	}
+}
@@ -5,6 +5,9 @@ type Options struct {
 	Concurrency                      int
 	ConfigurationFilePath            string
 	CredentialsEncryptionKey         string
+	CredentialsEncryptionKeyCommand  string
+	CredentialsEncryptionKeyEnv      string
+	CredentialsEncryptionKeyFile     string
 	CredentialsFormat                string
 	CredentialsLocalStoragePath      string
 	CredentialsStorageType           string
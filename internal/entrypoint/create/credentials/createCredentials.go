@@ -3,20 +3,14 @@ package credentials
 import (
 	"context"
 	"fmt"
+	"time"
 
 	errors "github.com/apenella/go-common-utils/error"
 	application "github.com/gostevedore/stevedore/internal/application/create/credentials"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
-	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/create/credentials"
-	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
-	credentialsformatfactory "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/factory"
-	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
-	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
-	credentialsenvvarsstorebackend "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars/backend"
-	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
-	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
 	"github.com/spf13/afero"
 )
 
@@ -259,12 +253,25 @@ func (e *CreateCredentialsEntrypoint) prepareHandlerOptions(inputEntrypointOptio
 		options.AWSSharedCredentialsFiles = append([]string{}, inputHandlerOptions.AWSSharedCredentialsFiles...)
 	}
 	options.AWSUseDefaultCredentialsChain = inputHandlerOptions.AWSUseDefaultCredentialsChain
+	options.CredentialProcess = inputHandlerOptions.CredentialProcess
+	options.Description = inputHandlerOptions.Description
 	options.GitSSHUser = inputHandlerOptions.GitSSHUser
 	options.PrivateKeyFile = inputHandlerOptions.PrivateKeyFile
 	options.PrivateKeyPassword = inputHandlerOptions.PrivateKeyPassword
+	options.RefreshToken = inputHandlerOptions.RefreshToken
+	options.Token = inputHandlerOptions.Token
 	options.Username = inputHandlerOptions.Username
 
-	if inputHandlerOptions.Username != "" {
+	if inputHandlerOptions.ExpiresAt != "" {
+		expiresAt, err := credentials.ParseExpiration(inputHandlerOptions.ExpiresAt, time.Now())
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+		options.ExpiresAt = expiresAt.UTC().Format(credentials.ExpirationLayout)
+	}
+
+	// the username could be used along with a token, then the password is not required
+	if inputHandlerOptions.Username != "" && inputHandlerOptions.Token == "" && inputHandlerOptions.RefreshToken == "" {
 		password, err = e.getPassword()
 		if err != nil {
 			return nil, errors.New(errContext, "", err)
@@ -291,98 +298,10 @@ func (e *CreateCredentialsEntrypoint) prepareHandlerOptions(inputEntrypointOptio
 	return options, nil
 }
 
-func (e *CreateCredentialsEntrypoint) createCredentialsFormater(conf *configuration.CredentialsConfiguration) (repository.Formater, error) {
-	errContext := "(create::credentials::entrypoint::createCredentialsFormater)"
-
-	if conf.Format == "" {
-		return nil, errors.New(errContext, "To create credentials store in the create credentials entrypoint, credentials format must be specified")
-	}
-
-	credentialsFormatFactory := credentialsformatfactory.NewFormatFactory()
-	credentialsFormat, err := credentialsFormatFactory.Get(conf.Format)
-	if err != nil {
-		return nil, errors.New(errContext, "", err)
-	}
-
-	return credentialsFormat, nil
-}
-
-func (e *CreateCredentialsEntrypoint) createCredentialsEnvvarsStore(conf *configuration.CredentialsConfiguration) (*credentialsenvvarsstore.EnvvarsStore, error) {
-	errContext := "(create::credentials::entrypoint::createCredentialsEnvvarsStore)"
-	var err error
-	var format repository.Formater
-
-	format, err = e.createCredentialsFormater(conf)
-	if err != nil {
-		return nil, errors.New(errContext, "", err)
-	}
-
-	encryption := credentialsstoreencryption.NewEncryption(
-		credentialsstoreencryption.WithKey(conf.EncryptionKey),
-	)
-
-	store := credentialsenvvarsstore.NewEnvvarsStore(
-		credentialsenvvarsstore.WithBackend(credentialsenvvarsstorebackend.NewOSEnvvarsBackend()),
-		credentialsenvvarsstore.WithConsole(e.console),
-		credentialsenvvarsstore.WithEncryption(encryption),
-		credentialsenvvarsstore.WithFormater(format),
-	)
-
-	return store, nil
-}
-
-func (e *CreateCredentialsEntrypoint) createCredentialsLocalStore(comp credentialslocalstore.CredentialsCompatibilier, conf *configuration.CredentialsConfiguration) (*local.LocalStore, error) {
-	var err error
-	var format repository.Formater
-
-	errContext := "(create::credentials::entrypoint:::createCredentialsLocalStore)"
-
-	if comp == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, credentials compatibilitier is required")
-	}
-
-	if conf == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, credentials configuration is required")
-	}
-
-	if conf.LocalStoragePath == "" {
-		return nil, errors.New(errContext, "To create the credentials local store, local storage path is required")
-	}
-
-	if e.fs == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, filesystem is required")
-	}
-
-	format, err = e.createCredentialsFormater(conf)
-	if err != nil {
-		return nil, errors.New(errContext, "", err)
-	}
-
-	localStoreOpts := []credentialslocalstore.OptionsFunc{
-		credentialslocalstore.WithCompatibility(comp),
-		credentialslocalstore.WithFilesystem(e.fs),
-		credentialslocalstore.WithFormater(format),
-		credentialslocalstore.WithPath(conf.LocalStoragePath),
-	}
-
-	if conf.EncryptionKey != "" {
-		encryption := credentialsstoreencryption.NewEncryption(
-			credentialsstoreencryption.WithKey(conf.EncryptionKey),
-		)
-
-		localStoreOpts = append(localStoreOpts, credentialslocalstore.WithEncryption(encryption))
-	}
-
-	store := credentialslocalstore.NewLocalStore(localStoreOpts...)
-
-	return store, nil
-}
-
 func (e *CreateCredentialsEntrypoint) createCredentialsStore(conf *configuration.Configuration, options *Options) (application.CredentialsStorer, error) {
 
 	var store application.CredentialsStorer
 	var err error
-	var credentialsFormat repository.Formater
 	errContext := "(create::credentials::entrypoint:::createCredentialsLocalStore)"
 
 	if conf == nil {
@@ -401,89 +320,24 @@ func (e *CreateCredentialsEntrypoint) createCredentialsStore(conf *configuration
 		return nil, errors.New(errContext, "To create the credentials store, options are required")
 	}
 
-	switch conf.Credentials.StorageType {
-	case credentials.LocalStore:
-
-		if e.compatibility == nil {
-			return nil, errors.New(errContext, "To create the credentials store, compatibilitier is required")
-		}
-
-		if conf.Credentials.Format == "" {
-			return nil, errors.New(errContext, "To create the credentials store, credentials format must be defined")
-		}
-
-		credentialsCompatibility := credentialscompatibility.NewCredentialsCompatibility(e.compatibility)
-		credentialsFormatFactory := credentialsformatfactory.NewFormatFactory()
-		credentialsFormat, err = credentialsFormatFactory.Get(conf.Credentials.Format)
-		if err != nil {
-			return nil, errors.New(errContext, "", err)
-		}
-
-		if options.ForceCreate {
-			store, err = e.createCredentialsLocalStore(credentialsCompatibility, conf.Credentials)
-			if err != nil {
-				return nil, errors.New(errContext, "", err)
-			}
-		} else {
-			store, err = e.createCredentialsLocalStoreWithSafeStore(credentialsCompatibility, conf.Credentials, credentialsFormat)
-			if err != nil {
-				return nil, errors.New(errContext, "", err)
-			}
-		}
-
-	case credentials.EnvvarsStore:
-		store, err = e.createCredentialsEnvvarsStore(conf.Credentials)
-		if err != nil {
-			return nil, errors.New(errContext, "", err)
-		}
-
-	default:
-		return nil, errors.New(errContext, fmt.Sprintf("Unsupported credentials storage type '%s'", conf.Credentials.StorageType))
-	}
-
-	return store, nil
-}
-
-func (e *CreateCredentialsEntrypoint) createCredentialsLocalStoreWithSafeStore(comp credentialslocalstore.CredentialsCompatibilier, conf *configuration.CredentialsConfiguration, format repository.Formater) (*local.LocalStoreWithSafeStore, error) {
-
-	errContext := "(create::credentials::entrypoint:::createCredentialsLocalStore)"
-
-	if comp == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, credentials compatibilitier is required")
-	}
-
-	if conf == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, credentials configuration is required")
+	if conf.Credentials.StorageType == credentials.DockerConfigStore {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to create credentials", conf.Credentials.StorageType))
 	}
 
-	if conf.LocalStoragePath == "" {
-		return nil, errors.New(errContext, "To create the credentials local store, local storage path is required")
-	}
-
-	if format == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, formater is required")
-	}
-
-	if e.fs == nil {
-		return nil, errors.New(errContext, "To create the credentials local store, filesystem is required")
-	}
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.console),
+	)
 
-	localStoreOpts := []credentialslocalstore.OptionsFunc{
-		credentialslocalstore.WithFilesystem(e.fs),
-		credentialslocalstore.WithCompatibility(comp),
-		credentialslocalstore.WithPath(conf.LocalStoragePath),
-		credentialslocalstore.WithFormater(format),
+	if options.ForceCreate {
+		store, err = credentialsFactory.CreateStore(conf.Credentials)
+	} else {
+		store, err = credentialsFactory.CreateSafeStore(conf.Credentials)
 	}
-
-	if conf.EncryptionKey != "" {
-		encryption := credentialsstoreencryption.NewEncryption(
-			credentialsstoreencryption.WithKey(conf.EncryptionKey),
-		)
-
-		localStoreOpts = append(localStoreOpts, credentialslocalstore.WithEncryption(encryption))
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
 	}
 
-	store := credentialslocalstore.NewLocalStoreWithSafeStore(localStoreOpts...)
-
 	return store, nil
 }
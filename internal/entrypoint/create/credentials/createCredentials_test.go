@@ -7,7 +7,6 @@ import (
 	errors "github.com/apenella/go-common-utils/error"
 	application "github.com/gostevedore/stevedore/internal/application/create/credentials"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
-	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/create/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	credentialscompatibilitiy "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
@@ -16,7 +15,7 @@ import (
 	credentialsformat "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/mock"
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
-	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -265,6 +264,56 @@ func TestPrepareHandlerOptions(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing create credentials entrypoint prepare handler options method without asking for password when a token is provided",
+			entrypoint: NewCreateCredentialsEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				Username: "robot",
+				Token:    "token",
+			},
+			res: &handler.Options{
+				Username: "robot",
+				Token:    "token",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create credentials entrypoint prepare handler options method with expiration and description",
+			entrypoint: NewCreateCredentialsEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				Username:    "robot",
+				Token:       "token",
+				Description: "CI robot account",
+				ExpiresAt:   "2024-12-31",
+			},
+			res: &handler.Options{
+				Username:    "robot",
+				Token:       "token",
+				Description: "CI robot account",
+				ExpiresAt:   "2024-12-31T00:00:00Z",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error on create credentials entrypoint prepare handler options method with an invalid expiration",
+			entrypoint: NewCreateCredentialsEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				Username:  "robot",
+				Token:     "token",
+				ExpiresAt: "someday",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(core::domain::credentials::ParseExpiration)", "Invalid expiration 'someday'. It must be a date, such as '2024-12-31' or '2024-12-31T00:00:00Z', or a duration, such as '90d'")),
+		},
 	}
 
 	for _, test := range tests {
@@ -411,15 +460,22 @@ func TestCreateCredentialsStore(t *testing.T) {
 			err: errors.New(errContext, "To create the credentials store, options are required"),
 		},
 		{
-			desc:       "Testing error creating local credentials store on create credentials entrypoint when compatibilitier is not provided",
-			entrypoint: NewCreateCredentialsEntrypoint(),
+			desc: "Testing error creating local credentials store on create credentials entrypoint when compatibilitier is not provided",
+			entrypoint: NewCreateCredentialsEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
 			conf: &configuration.Configuration{
 				Credentials: &configuration.CredentialsConfiguration{
-					StorageType: credentials.LocalStore,
+					Format:           credentials.JSONFormat,
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "path",
 				},
 			},
 			options: &Options{},
-			err:     errors.New(errContext, "To create the credentials store, compatibilitier is required"),
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::factory::CreateSafeStore)", "",
+					errors.New("(store::credentials::factory::createStore)", "",
+						errors.New("(store::credentials::factory::createLocalStore)", "To create the credentials local store, compatibility is required")))),
 		},
 		{
 			desc: "Testing error creating local credentials store on create credentials entrypoint when credentials format is not provided",
@@ -432,7 +488,10 @@ func TestCreateCredentialsStore(t *testing.T) {
 				},
 			},
 			options: &Options{},
-			err:     errors.New(errContext, "To create the credentials store, credentials format must be defined"),
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::factory::CreateSafeStore)", "",
+					errors.New("(store::credentials::factory::createStore)", "",
+						errors.New("(store::credentials::factory::createFormater)", "To create the credentials store, credentials format must be defined")))),
 		},
 		{
 			desc: "Testing create a local credentials store on create credentials entrypoint",
@@ -473,86 +532,56 @@ func TestCreateCredentialsStore(t *testing.T) {
 			err:     &errors.Error{},
 			res:     envvars.NewEnvvarsStore(),
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			t.Log(test.desc)
-
-			res, err := test.entrypoint.createCredentialsStore(test.conf, test.options)
-			if err != nil {
-				assert.Equal(t, test.err, err)
-			} else {
-				assert.IsType(t, test.res, res)
-			}
-		})
-	}
-
-}
-
-func TestCreateCredentialsLocalStore(t *testing.T) {
-	errContext := "(create::credentials::entrypoint:::createCredentialsLocalStore)"
-
-	tests := []struct {
-		desc            string
-		entrypoint      *CreateCredentialsEntrypoint
-		compatibilitier credentialslocalstore.CredentialsCompatibilier
-		conf            *configuration.CredentialsConfiguration
-		format          repository.Formater
-		res             *local.LocalStore
-		err             error
-	}{
 		{
-			desc:       "Testing error creating credentials local store on create credentials entrypoint when compatibilitier is not provided",
+			desc:       "Testing create a Vault credentials store on create credentials entrypoint",
 			entrypoint: NewCreateCredentialsEntrypoint(),
-			err:        errors.New(errContext, "To create the credentials local store, credentials compatibilitier is required"),
-		},
-		{
-			desc:            "Testing error creating credentials local store on create credentials entrypoint when credentials configuration is not provided",
-			entrypoint:      NewCreateCredentialsEntrypoint(),
-			compatibilitier: credentialscompatibilitiy.NewCredentialsCompatibility(compatibility.NewMockCompatibility()),
-			err:             errors.New(errContext, "To create the credentials local store, credentials configuration is required"),
-		},
-		{
-			desc:            "Testing error creating credentials local store on create credentials entrypoint when local storage path is not provided",
-			entrypoint:      NewCreateCredentialsEntrypoint(),
-			conf:            &configuration.CredentialsConfiguration{},
-			compatibilitier: credentialscompatibilitiy.NewCredentialsCompatibility(compatibility.NewMockCompatibility()),
-			err:             errors.New(errContext, "To create the credentials local store, local storage path is required"),
-		},
-		{
-			desc:            "Testing error creating credentials local store on create credentials entrypoint when filesystem is not provided",
-			entrypoint:      NewCreateCredentialsEntrypoint(),
-			compatibilitier: credentialscompatibilitiy.NewCredentialsCompatibility(compatibility.NewMockCompatibility()),
-			conf: &configuration.CredentialsConfiguration{
-				LocalStoragePath: "path",
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.VaultStore,
+					Format:      credentials.JSONFormat,
+					Vault: &configuration.VaultConfiguration{
+						Address:    "http://127.0.0.1:8200",
+						Mount:      "secret",
+						Path:       "stevedore",
+						AuthMethod: credentials.VaultTokenAuth,
+						Token:      "token",
+					},
+				},
 			},
-			format: credentialsformat.NewMockFormater(),
-			err:    errors.New(errContext, "To create the credentials local store, filesystem is required"),
+			options: &Options{},
+			err:     &errors.Error{},
+			res:     &credentialsvaultstore.VaultStoreWithSafeStore{},
 		},
 		{
-			desc: "Testing error creating credentials local store on create credentials entrypoint when credentials formater is not provided",
-			entrypoint: NewCreateCredentialsEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
-			),
-			compatibilitier: credentialscompatibilitiy.NewCredentialsCompatibility(compatibility.NewMockCompatibility()),
-			conf: &configuration.CredentialsConfiguration{
-				LocalStoragePath: "path",
+			desc:       "Testing create a Vault credentials store that overwrites credentials on create credentials entrypoint",
+			entrypoint: NewCreateCredentialsEntrypoint(),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.VaultStore,
+					Format:      credentials.JSONFormat,
+					Vault: &configuration.VaultConfiguration{
+						Address:    "http://127.0.0.1:8200",
+						Mount:      "secret",
+						Path:       "stevedore",
+						AuthMethod: credentials.VaultTokenAuth,
+						Token:      "token",
+					},
+				},
 			},
-			err: errors.New(errContext, "To create credentials store in the create credentials entrypoint, credentials format must be specified"),
+			options: &Options{ForceCreate: true},
+			err:     &errors.Error{},
+			res:     &credentialsvaultstore.VaultStore{},
 		},
 		{
-			desc: "Testing create credentials local store on create credentials entrypoint",
-			entrypoint: NewCreateCredentialsEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
-			),
-			compatibilitier: credentialscompatibilitiy.NewCredentialsCompatibility(compatibility.NewMockCompatibility()),
-			conf: &configuration.CredentialsConfiguration{
-				LocalStoragePath: "path",
-				Format:           credentials.JSONFormat,
+			desc:       "Testing error creating a Docker config credentials store on create credentials entrypoint",
+			entrypoint: NewCreateCredentialsEntrypoint(),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.DockerConfigStore,
+				},
 			},
-			format: credentialsformat.NewMockFormater(),
-			err:    errors.New(errContext, "To create the credentials local store, filesystem is required"),
+			options: &Options{},
+			err:     errors.New(errContext, "Credentials storage type 'docker-config' does not support to create credentials"),
 		},
 	}
 
@@ -560,12 +589,13 @@ func TestCreateCredentialsLocalStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			res, err := test.entrypoint.createCredentialsLocalStore(test.compatibilitier, test.conf)
+			res, err := test.entrypoint.createCredentialsStore(test.conf, test.options)
 			if err != nil {
-				assert.Equal(t, test.err.Error(), err.Error())
+				assert.Equal(t, test.err, err)
 			} else {
 				assert.IsType(t, test.res, res)
 			}
 		})
 	}
+
 }
@@ -0,0 +1,213 @@
+package credentialhelper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	output "github.com/gostevedore/stevedore/internal/infrastructure/output/credentialhelper"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+// storeRequest is the Docker credentials helpers protocol request for the store action
+type storeRequest struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the credential helper command
+type Entrypoint struct {
+	fs            afero.Fs
+	writer        ConsoleWriter
+	reader        io.Reader
+	compatibility Compatibilitier
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// WithWriter sets the writer for the entrypoint
+func WithWriter(w ConsoleWriter) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.writer = w
+	}
+}
+
+// WithReader sets the reader where the entrypoint receives the credentials helper requests
+func WithReader(r io.Reader) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.reader = r
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility checker for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+	var store repository.CredentialsStorer
+	var handlerOptions *handler.Options
+
+	errContext := "(credentialhelper::entrypoint::Execute)"
+
+	if e.writer == nil {
+		return errors.New(errContext, "To execute the credential helper entrypoint, a writer is required")
+	}
+
+	if conf == nil {
+		return errors.New(errContext, "To execute the credential helper entrypoint, configuration is required")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "To execute the credential helper entrypoint, options are required")
+	}
+
+	handlerOptions, err = e.prepareHandlerOptions(options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	authFactory, err := e.createAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	credentialHelperApplication := application.NewApplication(
+		application.WithCredentials(authFactory),
+		application.WithStore(store),
+		application.WithOutput(output.NewOutput(e.writer)),
+	)
+
+	credentialHelperHandler := handler.NewHandler(
+		handler.WithApplication(credentialHelperApplication),
+	)
+
+	err = credentialHelperHandler.Handler(ctx, handlerOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// prepareHandlerOptions reads the credentials helper request for the action. The get and erase actions receive the server URL, and the store action receives the credentials encoded as JSON
+func (e *Entrypoint) prepareHandlerOptions(options *Options) (*handler.Options, error) {
+
+	errContext := "(credentialhelper::entrypoint::prepareHandlerOptions)"
+
+	handlerOptions := &handler.Options{
+		Action: options.Action,
+	}
+
+	switch options.Action {
+	case handler.GetAction, handler.EraseAction, handler.StoreAction:
+		if e.reader == nil {
+			return nil, errors.New(errContext, "To read the credential helper request, a reader is required")
+		}
+
+		request, err := io.ReadAll(e.reader)
+		if err != nil {
+			return nil, errors.New(errContext, "Error reading the credential helper request", err)
+		}
+
+		if options.Action != handler.StoreAction {
+			handlerOptions.ServerURL = strings.TrimSpace(string(request))
+			break
+		}
+
+		storeReq := &storeRequest{}
+		err = json.Unmarshal(request, storeReq)
+		if err != nil {
+			return nil, errors.New(errContext, "Error decoding the credential helper store request", err)
+		}
+
+		handlerOptions.ServerURL = storeReq.ServerURL
+		handlerOptions.Username = storeReq.Username
+		handlerOptions.Secret = storeReq.Secret
+
+	case handler.ListAction:
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Unknown credentials helper action '%s'", options.Action))
+	}
+
+	return handlerOptions, nil
+}
+
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
+	errContext := "(credentialhelper::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the credential helper entrypoint, credentials configuration is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.writer),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
+
+// createAuthFactory creates the auth factory on top of the credentials store. It includes the AWS ECR auth provider, which exchanges the AWS credentials for a registry token
+func (e *Entrypoint) createAuthFactory(conf *configuration.CredentialsConfiguration, store repository.CredentialsStorer) (repository.AuthFactorier, error) {
+
+	errContext := "(credentialhelper::entrypoint::createAuthFactory)"
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+	)
+
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return authFactory, nil
+}
@@ -0,0 +1,291 @@
+package credentialhelper
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(credentialhelper::entrypoint::Execute)"
+
+	output := &bytes.Buffer{}
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.Configuration
+		options    *Options
+		res        string
+		err        error
+	}{
+		{
+			desc:       "Testing error executing credential helper entrypoint without writer",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To execute the credential helper entrypoint, a writer is required"),
+		},
+		{
+			desc: "Testing error executing credential helper entrypoint without configuration",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewMockConsole()),
+			),
+			options: &Options{},
+			err:     errors.New(errContext, "To execute the credential helper entrypoint, configuration is required"),
+		},
+		{
+			desc: "Testing error executing credential helper entrypoint without options",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewMockConsole()),
+			),
+			conf: &configuration.Configuration{},
+			err:  errors.New(errContext, "To execute the credential helper entrypoint, options are required"),
+		},
+		{
+			desc: "Testing execute credential helper entrypoint to get credentials from the local store",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+				WithReader(strings.NewReader("https://registry.example.com/v2/\n")),
+				WithFileSystem(testLocalStoreFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+				},
+			},
+			options: &Options{
+				Action: handler.GetAction,
+			},
+			res: "{\"ServerURL\":\"https://registry.example.com/v2/\",\"Username\":\"username\",\"Secret\":\"password\"}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			output.Reset()
+
+			err := test.entrypoint.Execute(context.TODO(), nil, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, output.String())
+			}
+		})
+	}
+}
+
+func TestPrepareHandlerOptions(t *testing.T) {
+
+	errContext := "(credentialhelper::entrypoint::prepareHandlerOptions)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		options    *Options
+		res        *handler.Options
+		err        error
+	}{
+		{
+			desc:       "Testing error preparing handler options for an unknown action",
+			entrypoint: NewEntrypoint(),
+			options: &Options{
+				Action: "unknown",
+			},
+			err: errors.New(errContext, "Unknown credentials helper action 'unknown'"),
+		},
+		{
+			desc:       "Testing error preparing handler options without reader",
+			entrypoint: NewEntrypoint(),
+			options: &Options{
+				Action: handler.GetAction,
+			},
+			err: errors.New(errContext, "To read the credential helper request, a reader is required"),
+		},
+		{
+			desc: "Testing prepare handler options for get action",
+			entrypoint: NewEntrypoint(
+				WithReader(strings.NewReader("registry.example.com\n")),
+			),
+			options: &Options{
+				Action: handler.GetAction,
+			},
+			res: &handler.Options{
+				Action:    handler.GetAction,
+				ServerURL: "registry.example.com",
+			},
+		},
+		{
+			desc: "Testing prepare handler options for store action",
+			entrypoint: NewEntrypoint(
+				WithReader(strings.NewReader(`{"ServerURL":"registry.example.com","Username":"username","Secret":"secret"}`)),
+			),
+			options: &Options{
+				Action: handler.StoreAction,
+			},
+			res: &handler.Options{
+				Action:    handler.StoreAction,
+				ServerURL: "registry.example.com",
+				Username:  "username",
+				Secret:    "secret",
+			},
+		},
+		{
+			desc: "Testing error preparing handler options for store action with an invalid request",
+			entrypoint: NewEntrypoint(
+				WithReader(strings.NewReader("registry.example.com")),
+			),
+			options: &Options{
+				Action: handler.StoreAction,
+			},
+			err: errors.New(errContext, "Error decoding the credential helper store request",
+				errors.New("", "invalid character 'r' looking for beginning of value")),
+		},
+		{
+			desc:       "Testing prepare handler options for list action",
+			entrypoint: NewEntrypoint(),
+			options: &Options{
+				Action: handler.ListAction,
+			},
+			res: &handler.Options{
+				Action: handler.ListAction,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.entrypoint.prepareHandlerOptions(test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestCreateCredentialsStore(t *testing.T) {
+
+	errContext := "(credentialhelper::entrypoint::createCredentialsStore)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.CredentialsConfiguration
+		res        repository.CredentialsStorer
+		err        error
+	}{
+		{
+			desc:       "Testing error creating credentials store when configuration is not defined",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To create credentials store in the credential helper entrypoint, credentials configuration is required"),
+		},
+		{
+			desc: "Testing error creating credentials store when storage type is not supported",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: "unsupported",
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "Unsupported credentials storage type 'unsupported'"),
+		},
+		{
+			desc: "Testing create local credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "local-storage-path",
+				Format:           credentials.JSONFormat,
+			},
+			res: &credentialslocalstore.LocalStore{},
+		},
+		{
+			desc:       "Testing create envvars credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.EnvvarsStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsenvvarsstore.EnvvarsStore{},
+		},
+		{
+			desc: "Testing create docker-config credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.DockerConfigStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsdockerconfigstore.DockerConfigStore{},
+		},
+		{
+			desc:       "Testing create Vault credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			store, err := test.entrypoint.createCredentialsStore(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, store)
+			}
+		})
+	}
+}
+
+// testLocalStoreFs returns a file system with a local store that contains the 'registry.example.com' credentials
+func testLocalStoreFs() afero.Fs {
+	fs := afero.NewMemMapFs()
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	_ = afero.WriteFile(fs, filepath.Join("/credentials", hashedID), []byte(`{"id":"registry.example.com","username":"username","password":"password"}`), 0600)
+
+	return fs
+}
@@ -0,0 +1,17 @@
+package credentialhelper
+
+// Compatibilitier is the interface for the compatibility checker
+type Compatibilitier interface {
+	AddDeprecated(deprecated ...string)
+	AddRemoved(removed ...string)
+	AddChanged(changed ...string)
+}
+
+// ConsoleWriter is the interface to write the credentials helper responses
+type ConsoleWriter interface {
+	Debug(msg ...interface{})
+	Error(msg ...interface{})
+	Info(msg ...interface{})
+	Warn(msg ...interface{})
+	Write(data []byte) (int, error)
+}
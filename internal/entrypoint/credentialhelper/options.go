@@ -0,0 +1,7 @@
+package credentialhelper
+
+// Options is the options for the credential helper command entrypoint
+type Options struct {
+	// Action is the credentials helper action to carry out
+	Action string
+}
@@ -0,0 +1,156 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/delete/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/delete/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the delete credentials command
+type Entrypoint struct {
+	console       ConsoleWriter
+	compatibility Compatibilitier
+	fs            afero.Fs
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithConsole sets the console for the entrypoint
+func WithConsole(console ConsoleWriter) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.console = console
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+	var store repository.CredentialsDeleter
+
+	errContext := "(delete::credentials::entrypoint::Execute)"
+
+	if len(args) < 1 {
+		return errors.New(errContext, "To execute the delete credentials entrypoint, an argument with credential id is required")
+	}
+
+	id := args[0]
+	if len(args) > 1 && e.console != nil {
+		e.console.Warn(fmt.Sprintf("Ignoring extra arguments: %v", args[1:]))
+	}
+
+	conf, err = e.prepareConfiguration(conf, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	app := application.NewApplication(
+		application.WithCredentialsStore(store),
+	)
+
+	h := handler.NewHandler(
+		handler.WithApplication(app),
+	)
+
+	err = h.Handler(ctx, id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if e.console != nil {
+		e.console.Info(fmt.Sprintf("Credentials '%s' successfully deleted", id))
+	}
+
+	return nil
+}
+
+func (e *Entrypoint) prepareConfiguration(conf *configuration.Configuration, options *Options) (*configuration.Configuration, error) {
+
+	errContext := "(delete::credentials::entrypoint::prepareConfiguration)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to prepare configuration")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "Configuration must be provided to prepare configuration")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "Configuration credentials must be provided to prepare configuration")
+	}
+
+	if conf.Credentials.StorageType == credentials.LocalStore && options.LocalStoragePath != "" {
+		conf.Credentials.LocalStoragePath = options.LocalStoragePath
+	}
+
+	return conf, nil
+}
+
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsDeleter, error) {
+	errContext := "(delete::credentials::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the delete credentials entrypoint, credentials configuration is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.console),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	deleter, isDeleter := store.(repository.CredentialsDeleter)
+	if !isDeleter {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to delete credentials", conf.StorageType))
+	}
+
+	return deleter, nil
+}
@@ -0,0 +1,162 @@
+package credentials
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(delete::credentials::entrypoint::Execute)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		args       []string
+		conf       *configuration.Configuration
+		options    *Options
+		assertFunc func(*testing.T, *Entrypoint)
+		err        error
+	}{
+		{
+			desc:       "Testing error executing delete credentials entrypoint without credential id",
+			entrypoint: NewEntrypoint(),
+			args:       []string{},
+			err:        errors.New(errContext, "To execute the delete credentials entrypoint, an argument with credential id is required"),
+		},
+		{
+			desc: "Testing execute delete credentials entrypoint on local store",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(testLocalStoreFs(hashedID)),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			args: []string{"registry.example.com"},
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+				},
+			},
+			options: &Options{},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				exists, _ := afero.Exists(e.fs, filepath.Join("/credentials", hashedID))
+				assert.False(t, exists)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.entrypoint)
+			}
+		})
+	}
+}
+
+func TestCreateCredentialsStore(t *testing.T) {
+
+	errContext := "(delete::credentials::entrypoint::createCredentialsStore)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.CredentialsConfiguration
+		res        interface{}
+		err        error
+	}{
+		{
+			desc: "Testing create local credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+			res: &credentialslocalstore.LocalStore{},
+		},
+		{
+			desc:       "Testing create envvars credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.EnvvarsStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsenvvarsstore.EnvvarsStore{},
+		},
+		{
+			desc:       "Testing create Vault credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+		},
+		{
+			desc: "Testing error creating a credentials store that does not support to delete credentials",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.DockerConfigStore,
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "Credentials storage type 'docker-config' does not support to delete credentials"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			store, err := test.entrypoint.createCredentialsStore(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, store)
+			}
+		})
+	}
+}
+
+// testLocalStoreFs returns a file system with a local store that contains the credentials for the hashed id
+func testLocalStoreFs(hashedID string) afero.Fs {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, filepath.Join("/credentials", hashedID), []byte(`{"id":"registry.example.com","username":"username","password":"password"}`), 0600)
+
+	return fs
+}
@@ -0,0 +1,16 @@
+package credentials
+
+// ConsoleWriter is the interface to write messages to the console
+type ConsoleWriter interface {
+	Info(msg ...interface{})
+	Warn(msg ...interface{})
+	Error(msg ...interface{})
+	Debug(msg ...interface{})
+}
+
+// Compatibilitier is the interface for the compatibility checker
+type Compatibilitier interface {
+	AddDeprecated(deprecated ...string)
+	AddRemoved(removed ...string)
+	AddChanged(changed ...string)
+}
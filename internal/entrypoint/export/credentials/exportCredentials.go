@@ -0,0 +1,224 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/export/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/export/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsbundlestore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/bundle"
+	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+const (
+	getPassphraseInputMessage = "Passphrase: "
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the export credentials command
+type Entrypoint struct {
+	console       Consoler
+	compatibility Compatibilitier
+	fs            afero.Fs
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithConsole sets the console for the entrypoint
+func WithConsole(console Consoler) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.console = console
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+	var count int
+	var passphrase string
+	var generated bool
+	var store repository.CredentialsLister
+
+	errContext := "(export::credentials::entrypoint::Execute)"
+
+	if e.console == nil {
+		return errors.New(errContext, "To execute the export credentials entrypoint, a console is required")
+	}
+
+	if e.fs == nil {
+		return errors.New(errContext, "To execute the export credentials entrypoint, a file system is required")
+	}
+
+	conf, err = e.prepareConfiguration(conf, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if options.To == "" {
+		return errors.New(errContext, "To execute the export credentials entrypoint, the credentials bundle file path is required")
+	}
+
+	passphrase, generated, err = e.preparePassphrase(options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	bundle := credentialsbundlestore.NewBundleStore(
+		credentialsbundlestore.WithFilesystem(e.fs),
+		credentialsbundlestore.WithPath(options.To),
+		credentialsbundlestore.WithEncryption(
+			credentialsstoreencryption.NewEncryption(
+				credentialsstoreencryption.WithKey(passphrase),
+			),
+		),
+	)
+
+	h := handler.NewHandler(
+		handler.WithApplication(
+			application.NewApplication(
+				application.WithCredentialsStore(store),
+				application.WithCredentialsBundle(bundle),
+			),
+		),
+	)
+
+	count, err = h.Handler(ctx)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	e.console.Info(fmt.Sprintf("%d credentials successfully exported to '%s'", count, options.To))
+
+	if generated {
+		e.console.Warn("Keep the following one-time passphrase. It is required to import the credentials:")
+		e.console.Warn(fmt.Sprintf(" %s", passphrase))
+	}
+
+	return nil
+}
+
+func (e *Entrypoint) prepareConfiguration(conf *configuration.Configuration, options *Options) (*configuration.Configuration, error) {
+
+	errContext := "(export::credentials::entrypoint::prepareConfiguration)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to prepare configuration")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "Configuration must be provided to prepare configuration")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "Configuration credentials must be provided to prepare configuration")
+	}
+
+	if conf.Credentials.StorageType == credentials.LocalStore && options.LocalStoragePath != "" {
+		conf.Credentials.LocalStoragePath = options.LocalStoragePath
+	}
+
+	return conf, nil
+}
+
+// preparePassphrase returns the passphrase to encrypt the credentials bundle and whether it has been generated. It is asked on the console when it is required, otherwise a one-time passphrase is generated when it is not provided
+func (e *Entrypoint) preparePassphrase(options *Options) (string, bool, error) {
+	var err error
+	var passphrase string
+
+	errContext := "(export::credentials::entrypoint::preparePassphrase)"
+
+	passphrase = options.Passphrase
+
+	if options.AskPassphrase {
+		passphrase, err = e.console.ReadPassword(getPassphraseInputMessage)
+		if err != nil {
+			return "", false, errors.New(errContext, "Error reading the passphrase", err)
+		}
+		fmt.Fprintln(e.console)
+
+		if passphrase == "" {
+			return "", false, errors.New(errContext, "The passphrase to encrypt the credentials bundle must not be empty")
+		}
+	}
+
+	if passphrase != "" {
+		return passphrase, false, nil
+	}
+
+	passphrase, err = credentialsstoreencryption.NewEncryption().GenerateEncryptionKey()
+	if err != nil {
+		return "", false, errors.New(errContext, "", err)
+	}
+
+	return passphrase, true, nil
+}
+
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsLister, error) {
+	errContext := "(export::credentials::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the export credentials entrypoint, credentials configuration is required")
+	}
+
+	if conf.StorageType == credentials.DockerConfigStore {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to export credentials", conf.StorageType))
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.console),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	lister, isLister := store.(repository.CredentialsLister)
+	if !isLister {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to export credentials", conf.StorageType))
+	}
+
+	return lister, nil
+}
@@ -0,0 +1,302 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	credentialsformatjson "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/json"
+	credentialsbundlestore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/bundle"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(export::credentials::entrypoint::Execute)"
+
+	credential := &credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	}
+
+	localStoreConfiguration := func() *configuration.Configuration {
+		return &configuration.Configuration{
+			Credentials: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+		}
+	}
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		conf              *configuration.Configuration
+		options           *Options
+		prepareAssertFunc func(*Entrypoint)
+		assertFunc        func(*testing.T, *Entrypoint)
+		err               error
+	}{
+		{
+			desc:       "Testing error executing export credentials entrypoint without console",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To execute the export credentials entrypoint, a console is required"),
+		},
+		{
+			desc: "Testing error executing export credentials entrypoint without bundle file path",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf:    localStoreConfiguration(),
+			options: &Options{},
+			err:     errors.New(errContext, "To execute the export credentials entrypoint, the credentials bundle file path is required"),
+		},
+		{
+			desc: "Testing execute export credentials entrypoint from local store",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: localStoreConfiguration(),
+			options: &Options{
+				Passphrase: "passphrase",
+				To:         "/credentials.bundle",
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				store := credentialslocalstore.NewLocalStore(
+					credentialslocalstore.WithFilesystem(e.fs),
+					credentialslocalstore.WithPath("/credentials"),
+					credentialslocalstore.WithCompatibility(credentialscompatibility.NewCredentialsCompatibility(compatibility.NewMockCompatibility())),
+					credentialslocalstore.WithFormater(credentialsformatjson.NewJSONFormater()),
+				)
+				_ = store.Store(credential.ID, credential)
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				bundle := credentialsbundlestore.NewBundleStore(
+					credentialsbundlestore.WithFilesystem(e.fs),
+					credentialsbundlestore.WithPath("/credentials.bundle"),
+					credentialsbundlestore.WithEncryption(encryption.NewEncryption(encryption.WithKey("passphrase"))),
+				)
+
+				all, err := bundle.All()
+				assert.NoError(t, err)
+				assert.Equal(t, []*credentials.Credential{credential}, all)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			err := test.entrypoint.Execute(context.TODO(), []string{}, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.entrypoint)
+			}
+		})
+	}
+}
+
+func TestExecuteWithGeneratedPassphrase(t *testing.T) {
+	var buff bytes.Buffer
+
+	fs := afero.NewMemMapFs()
+	credential := &credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	}
+
+	_ = credentialslocalstore.NewLocalStore(
+		credentialslocalstore.WithFilesystem(fs),
+		credentialslocalstore.WithPath("/credentials"),
+		credentialslocalstore.WithCompatibility(credentialscompatibility.NewCredentialsCompatibility(compatibility.NewMockCompatibility())),
+		credentialslocalstore.WithFormater(credentialsformatjson.NewJSONFormater()),
+	).Store(credential.ID, credential)
+
+	e := NewEntrypoint(
+		WithConsole(console.NewConsole(&buff, nil)),
+		WithFileSystem(fs),
+		WithCompatibility(compatibility.NewMockCompatibility()),
+	)
+
+	err := e.Execute(context.TODO(), []string{}, &configuration.Configuration{
+		Credentials: &configuration.CredentialsConfiguration{
+			StorageType:      credentials.LocalStore,
+			LocalStoragePath: "/credentials",
+			Format:           credentials.JSONFormat,
+		},
+	}, &Options{
+		To: "/credentials.bundle",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, buff.String(), "one-time passphrase")
+}
+
+func TestPreparePassphrase(t *testing.T) {
+
+	errContext := "(export::credentials::entrypoint::preparePassphrase)"
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		options           *Options
+		prepareAssertFunc func(*Entrypoint)
+		assertFunc        func(*testing.T, string, bool)
+		err               error
+	}{
+		{
+			desc:       "Testing prepare the passphrase provided by options",
+			entrypoint: NewEntrypoint(),
+			options:    &Options{Passphrase: "passphrase"},
+			assertFunc: func(t *testing.T, passphrase string, generated bool) {
+				assert.Equal(t, "passphrase", passphrase)
+				assert.False(t, generated)
+			},
+		},
+		{
+			desc:       "Testing prepare a generated passphrase",
+			entrypoint: NewEntrypoint(),
+			options:    &Options{},
+			assertFunc: func(t *testing.T, passphrase string, generated bool) {
+				assert.Len(t, passphrase, 32)
+				assert.True(t, generated)
+			},
+		},
+		{
+			desc: "Testing prepare the passphrase asked on the console",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			options: &Options{AskPassphrase: true},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getPassphraseInputMessage).Return("asked-passphrase", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			assertFunc: func(t *testing.T, passphrase string, generated bool) {
+				assert.Equal(t, "asked-passphrase", passphrase)
+				assert.False(t, generated)
+			},
+		},
+		{
+			desc: "Testing error preparing an empty passphrase asked on the console",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			options: &Options{AskPassphrase: true},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getPassphraseInputMessage).Return("", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			err: errors.New(errContext, "The passphrase to encrypt the credentials bundle must not be empty"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			passphrase, generated, err := test.entrypoint.preparePassphrase(test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, passphrase, generated)
+			}
+		})
+	}
+}
+
+func TestCreateCredentialsStore(t *testing.T) {
+
+	errContext := "(export::credentials::entrypoint::createCredentialsStore)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.CredentialsConfiguration
+		assertFunc func(*testing.T, interface{})
+		err        error
+	}{
+		{
+			desc:       "Testing error creating credentials store without configuration",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To create credentials store in the export credentials entrypoint, credentials configuration is required"),
+		},
+		{
+			desc: "Testing create local credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+			assertFunc: func(t *testing.T, store interface{}) {
+				assert.IsType(t, &credentialslocalstore.LocalStore{}, store)
+			},
+		},
+		{
+			desc:       "Testing create envvars credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:   credentials.EnvvarsStore,
+				Format:        credentials.JSONFormat,
+				EncryptionKey: "key",
+			},
+			assertFunc: func(t *testing.T, store interface{}) {
+				assert.IsType(t, &credentialsenvvarsstore.EnvvarsStore{}, store)
+			},
+		},
+		{
+			desc:       "Testing error creating credentials store with an unsupported storage type",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.DockerConfigStore,
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "Credentials storage type 'docker-config' does not support to export credentials"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			store, err := test.entrypoint.createCredentialsStore(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, store)
+			}
+		})
+	}
+}
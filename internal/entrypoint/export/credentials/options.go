@@ -0,0 +1,13 @@
+package credentials
+
+// Options is the options for the export credentials command entrypoint
+type Options struct {
+	// AskPassphrase is true if the passphrase to encrypt the credentials bundle should be asked
+	AskPassphrase bool
+	// LocalStoragePath is the location of local storage
+	LocalStoragePath string
+	// Passphrase is the passphrase to encrypt the credentials bundle. A one-time passphrase is generated when it is not provided
+	Passphrase string
+	// To is the credentials bundle file path
+	To string
+}
@@ -13,7 +13,9 @@ import (
 	buildersconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/builders"
 	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
 	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
 	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
 	filter "github.com/gostevedore/stevedore/internal/infrastructure/filters/builders"
 	"github.com/gostevedore/stevedore/internal/infrastructure/filters/operation"
 	"github.com/gostevedore/stevedore/internal/infrastructure/graph"
@@ -21,6 +23,7 @@ import (
 	output "github.com/gostevedore/stevedore/internal/infrastructure/output/builders"
 	"github.com/gostevedore/stevedore/internal/infrastructure/render"
 	buildersstore "github.com/gostevedore/stevedore/internal/infrastructure/store/builders"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
 	imagesstore "github.com/gostevedore/stevedore/internal/infrastructure/store/images"
 	"github.com/spf13/afero"
 )
@@ -80,6 +83,7 @@ func (e *GetBuildersEntrypoint) Execute(ctx context.Context, args []string, conf
 	var imageRender *render.ImageRender
 	var graphTemplateFactory *graph.GraphTemplateFactory
 	var imagesGraphTemplatesStore *imagesgraphtemplate.ImagesGraphTemplate
+	var definitionsSources *definitionssources.DefinitionsSources
 	var imagesStore *imagesstore.Store
 	var buildersStore *buildersstore.Store
 	var writer repository.BuildersOutputter
@@ -102,12 +106,17 @@ func (e *GetBuildersEntrypoint) Execute(ctx context.Context, args []string, conf
 		return errors.New(errContext, "", err)
 	}
 
-	imagesStore, err = e.createImagesStore(conf, imageRender, imagesGraphTemplatesStore)
+	definitionsSources, err = e.createDefinitionsSources(conf)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
 
-	buildersStore, err = e.createBuildersStore(conf)
+	imagesStore, err = e.createImagesStore(ctx, conf, imageRender, imagesGraphTemplatesStore, definitionsSources)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	buildersStore, err = e.createBuildersStore(ctx, conf, definitionsSources)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
@@ -151,7 +160,63 @@ func (e *GetBuildersEntrypoint) createImageRender(now render.Nower) (*render.Ima
 	return render.NewImageRender(now), nil
 }
 
-func (e *GetBuildersEntrypoint) createImagesStore(conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer) (*imagesstore.Store, error) {
+// createDefinitionsSources returns the component which resolves the local paths where the images and builders are defined, fetching the git sources into the cache folder. The git sources are authenticated through the credentials store, which is only created when any git source is defined
+func (e *GetBuildersEntrypoint) createDefinitionsSources(conf *configuration.Configuration) (*definitionssources.DefinitionsSources, error) {
+
+	errContext := "(entrypoint::get::builders::createDefinitionsSources)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the definitions sources in get builders entrypoint, configuration is required")
+	}
+
+	options := []definitionssources.OptionsFunc{
+		definitionssources.WithCachePath(conf.GitSourcesCachePath),
+	}
+
+	if conf.HasGitDefinitionsSources() {
+		authFactory, err := e.createAuthFactory(conf)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		options = append(options, definitionssources.WithAuthFactory(gitauth.NewGitAuthFactory(authFactory)))
+	}
+
+	return definitionssources.NewDefinitionsSources(options...), nil
+}
+
+// createAuthFactory returns the auth factory which achieves the credentials to access the git definitions sources
+func (e *GetBuildersEntrypoint) createAuthFactory(conf *configuration.Configuration) (repository.AuthFactorier, error) {
+
+	errContext := "(entrypoint::get::builders::createAuthFactory)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the auth factory in get builders entrypoint, configuration is required")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "To create the auth factory in get builders entrypoint, credentials configuration is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf.Credentials)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return authFactory, nil
+}
+
+func (e *GetBuildersEntrypoint) createImagesStore(ctx context.Context, conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer, sources *definitionssources.DefinitionsSources) (*imagesstore.Store, error) {
 
 	errContext := "(entrypoint::get::builders::createImagesStore)"
 
@@ -175,13 +240,22 @@ func (e *GetBuildersEntrypoint) createImagesStore(conf *configuration.Configurat
 		return nil, errors.New(errContext, "To create an images store in get builders entrypoint, compatibility is required")
 	}
 
-	if conf.ImagesPath == "" {
+	if conf.ImagesPath == "" && len(conf.ImagesSources) == 0 {
 		return nil, errors.New(errContext, "To create an images store in get builders entrypoint, images path must be provided in configuration")
 	}
 
+	if sources == nil {
+		return nil, errors.New(errContext, "To create an images store in get builders entrypoint, definitions sources are required")
+	}
+
+	paths, err := sources.Paths(ctx, conf.ImagesDefinitionsSources())
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
 	store := imagesstore.NewStore(render)
 	imagesConfiguration := imagesconfiguration.NewImagesConfiguration(e.fs, graph, store, render, e.compatibility)
-	err := imagesConfiguration.LoadImagesToStore(conf.ImagesPath)
+	err = imagesConfiguration.LoadImagesToStore(paths...)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
@@ -205,7 +279,7 @@ func (e *GetBuildersEntrypoint) createGraphTemplateFactory() (*graph.GraphTempla
 	return graph.NewGraphTemplateFactory(false), nil
 }
 
-func (e *GetBuildersEntrypoint) createBuildersStore(conf *configuration.Configuration) (*buildersstore.Store, error) {
+func (e *GetBuildersEntrypoint) createBuildersStore(ctx context.Context, conf *configuration.Configuration, sources *definitionssources.DefinitionsSources) (*buildersstore.Store, error) {
 
 	errContext := "(entrypoint::get::builders::createBuildersStore)"
 
@@ -217,17 +291,28 @@ func (e *GetBuildersEntrypoint) createBuildersStore(conf *configuration.Configur
 		return nil, errors.New(errContext, "To create a builders store in build entrypoint, configuration is required")
 	}
 
-	if conf.BuildersPath == "" {
+	if conf.BuildersPath == "" && len(conf.BuildersSources) == 0 {
 		return nil, errors.New(errContext, "To create a builders store in build entrypoint, builders path must be provided in configuration")
 	}
 
-	buildersStore := buildersstore.NewStore()
-	buildersConfiguration := buildersconfiguration.NewBuilders(e.fs, buildersStore)
-	err := buildersConfiguration.LoadBuilders(conf.BuildersPath)
+	if sources == nil {
+		return nil, errors.New(errContext, "To create a builders store in build entrypoint, definitions sources are required")
+	}
+
+	paths, err := sources.Paths(ctx, conf.BuildersDefinitionsSources())
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
 
+	buildersStore := buildersstore.NewStore()
+	buildersConfiguration := buildersconfiguration.NewBuilders(e.fs, buildersStore)
+	for _, path := range paths {
+		err = buildersConfiguration.LoadBuilders(path)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+	}
+
 	return buildersStore, nil
 }
 
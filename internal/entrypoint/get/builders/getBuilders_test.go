@@ -6,12 +6,14 @@ import (
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/get/builders"
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
 	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
 	"github.com/gostevedore/stevedore/internal/infrastructure/graph"
 	"github.com/gostevedore/stevedore/internal/infrastructure/now"
 	output "github.com/gostevedore/stevedore/internal/infrastructure/output/builders"
@@ -97,6 +99,7 @@ func TestCreateImagesStore(t *testing.T) {
 		render        repository.Renderer
 		graph         imagesconfiguration.ImagesGraphTemplatesStorer
 		compatibility Compatibilitier
+		sources       *definitionssources.DefinitionsSources
 		res           *images.Store
 		err           error
 	}{
@@ -151,6 +154,20 @@ func TestCreateImagesStore(t *testing.T) {
 			compatibility: &compatibility.Compatibility{},
 			err:           errors.New(errContext, "To create an images store in get builders entrypoint, images path must be provided in configuration"),
 		},
+		{
+			desc: "Testing error creating images store in get builders entrypoint when definitions sources are not defined",
+			entrypoint: NewGetBuildersEntrypoint(
+				WithFileSystem(testFs),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				ImagesPath: baseDir,
+			},
+			render:        &render.ImageRender{},
+			graph:         &imagesgraphtemplate.ImagesGraphTemplate{},
+			compatibility: &compatibility.Compatibility{},
+			err:           errors.New(errContext, "To create an images store in get builders entrypoint, definitions sources are required"),
+		},
 		{
 			desc: "Testing create images store in get builders entrypoint",
 			entrypoint: NewGetBuildersEntrypoint(
@@ -165,6 +182,7 @@ func TestCreateImagesStore(t *testing.T) {
 				graph.NewGraphTemplateFactory(false),
 			),
 			compatibility: &compatibility.Compatibility{},
+			sources:       definitionssources.NewDefinitionsSources(),
 			res:           &images.Store{},
 			err:           &errors.Error{},
 		},
@@ -174,7 +192,7 @@ func TestCreateImagesStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			store, err := test.entrypoint.createImagesStore(test.conf, test.render, test.graph)
+			store, err := test.entrypoint.createImagesStore(context.TODO(), test.conf, test.render, test.graph, test.sources)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
@@ -266,6 +284,7 @@ func TestCreateBuildersStore(t *testing.T) {
 		desc       string
 		entrypoint *GetBuildersEntrypoint
 		conf       *configuration.Configuration
+		sources    *definitionssources.DefinitionsSources
 		res        *buildersstore.Store
 		err        error
 	}{
@@ -297,8 +316,9 @@ func TestCreateBuildersStore(t *testing.T) {
 			conf: &configuration.Configuration{
 				BuildersPath: baseDir,
 			},
-			res: &buildersstore.Store{},
-			err: &errors.Error{},
+			sources: definitionssources.NewDefinitionsSources(),
+			res:     &buildersstore.Store{},
+			err:     &errors.Error{},
 		},
 	}
 
@@ -306,7 +326,7 @@ func TestCreateBuildersStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			store, err := test.entrypoint.createBuildersStore(test.conf)
+			store, err := test.entrypoint.createBuildersStore(context.TODO(), test.conf, test.sources)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
@@ -346,3 +366,79 @@ func TestCreateOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDefinitionsSources(t *testing.T) {
+	errContext := "(entrypoint::get::builders::createDefinitionsSources)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *GetBuildersEntrypoint
+		conf       *configuration.Configuration
+		res        *definitionssources.DefinitionsSources
+		err        error
+	}{
+		{
+			desc:       "Testing error creating definitions sources in get builders entrypoint when configuration is not defined",
+			entrypoint: NewGetBuildersEntrypoint(),
+			err:        errors.New(errContext, "To create the definitions sources in get builders entrypoint, configuration is required"),
+		},
+		{
+			desc:       "Testing create definitions sources in get builders entrypoint",
+			entrypoint: NewGetBuildersEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesPath:          "images",
+			},
+			res: definitionssources.NewDefinitionsSources(
+				definitionssources.WithCachePath("/cache/git"),
+			),
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing error creating definitions sources in get builders entrypoint when git sources are defined and credentials configuration is not defined",
+			entrypoint: NewGetBuildersEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Git: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git", CredentialsID: "github"}},
+				},
+			},
+			err: errors.New(errContext, "",
+				errors.New("(entrypoint::get::builders::createAuthFactory)", "To create the auth factory in get builders entrypoint, credentials configuration is required")),
+		},
+		{
+			desc: "Testing create definitions sources in get builders entrypoint authenticating the git sources through the credentials store",
+			entrypoint: NewGetBuildersEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Git: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git", CredentialsID: "github"}},
+				},
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.EnvvarsStore,
+					Format:      credentials.JSONFormat,
+				},
+			},
+			res: &definitionssources.DefinitionsSources{},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			sources, err := test.entrypoint.createDefinitionsSources(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else if test.conf.HasGitDefinitionsSources() {
+				assert.IsType(t, test.res, sources)
+			} else {
+				assert.Equal(t, test.res, sources)
+			}
+		})
+	}
+}
@@ -42,7 +42,7 @@ func (e *GetConfigurationEntrypoint) Options(opts ...OptionsFunc) {
 }
 
 // Execute is a pseudo-main method for the command
-func (e *GetConfigurationEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration) error {
+func (e *GetConfigurationEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, inputEntrypointOptions *Options) error {
 
 	var err error
 	var getConfigurationApplication *application.GetConfigurationApplication
@@ -50,7 +50,13 @@ func (e *GetConfigurationEntrypoint) Execute(ctx context.Context, args []string,
 
 	errContext := "(get::configuration::entrypoint::Execute)"
 
-	console := console.NewConfigurationConsoleOutput(e.writer)
+	if inputEntrypointOptions == nil {
+		inputEntrypointOptions = &Options{}
+	}
+
+	console := console.NewConfigurationConsoleOutput(e.writer,
+		console.WithShowOrigin(inputEntrypointOptions.ShowOrigin),
+	)
 	getConfigurationApplication = application.NewGetConfigurationApplication(
 		application.WithWrite(console),
 	)
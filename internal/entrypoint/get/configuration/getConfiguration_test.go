@@ -14,6 +14,7 @@ func TestExecute(t *testing.T) {
 		entrypoint      *GetConfigurationEntrypoint
 		args            []string
 		conf            *configuration.Configuration
+		options         *Options
 		prepareMockFunc func()
 		err             error
 	}{}
@@ -22,7 +23,7 @@ func TestExecute(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf)
+			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.options)
 			if err != nil {
 				assert.Equal(t, test.err, err)
 			}
@@ -18,7 +18,7 @@ func NewMockGetConfigurationEntrypoint() *MockGetConfigurationEntrypoint {
 }
 
 // Execute provides a mock function
-func (e *MockGetConfigurationEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration) error {
-	res := e.Called(ctx, args, conf)
+func (e *MockGetConfigurationEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	res := e.Called(ctx, args, conf, options)
 	return res.Error(0)
 }
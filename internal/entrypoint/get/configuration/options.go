@@ -0,0 +1,7 @@
+package configuration
+
+// Options is the options for the get configuration command entrypoint
+type Options struct {
+	// ShowOrigin shows where each configuration value comes from
+	ShowOrigin bool
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/get/credentials"
-	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/console"
 	credentialsformatfactory "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/factory"
@@ -18,12 +17,11 @@ import (
 	awsrolearn "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/AWSRoleARN"
 	awsstaticcredentials "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/AWSStaticCredentials"
 	sshagent "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/SSHAgent"
+	credentialprocess "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/credentialProcess"
 	privatekeyfile "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/privateKeyFile"
+	tokenoutput "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/token"
 	usernamepassword "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/usernamePassword"
-	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
-	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
-	credentialsenvvarsstorebackend "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars/backend"
-	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
 	"github.com/spf13/afero"
 )
 
@@ -85,6 +83,8 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 	var awsdefaultchainoutput outputcredentials.Outputter
 	var privatekeyfileoutput outputcredentials.Outputter
 	var sshagentoutput outputcredentials.Outputter
+	var credentialprocessoutput outputcredentials.Outputter
+	var tokenoutputter outputcredentials.Outputter
 
 	if e.writer == nil {
 		return errors.New(errContext, "To execute the entrypoint, a writer is required")
@@ -103,6 +103,8 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 	awsdefaultchainoutput = awsdefaultchain.NewAWSDefaultCredentialsChainOutput()
 	privatekeyfileoutput = privatekeyfile.NewPrivateKeyFileOutput()
 	sshagentoutput = sshagent.NewSSHAgentOutput()
+	credentialprocessoutput = credentialprocess.NewCredentialProcessOutput()
+	tokenoutputter = tokenoutput.NewTokenOutput()
 
 	if inputEntrypointOptions.ShowSecrets {
 		usernamepasswordoutput = usernamepassword.NewUsernamePasswordWithSecretsOutput(usernamepasswordoutput.(*usernamepassword.UsernamePasswordOutput))
@@ -112,6 +114,8 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 		awsrolearnoutput = awsrolearn.NewAWSRoleARNWithSecretsOutput(awsrolearnoutput.(*awsrolearn.AWSRoleARNOutput))
 
 		privatekeyfileoutput = privatekeyfile.NewPrivateKeyFileWithSecretsOutput(privatekeyfileoutput.(*privatekeyfile.PrivateKeyFileOutput))
+
+		tokenoutputter = tokenoutput.NewTokenWithSecretsOutput(tokenoutputter.(*tokenoutput.TokenOutput))
 	}
 
 	output := outputcredentials.NewOutput(writer,
@@ -121,12 +125,29 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 		awsdefaultchainoutput,
 		privatekeyfileoutput,
 		sshagentoutput,
+		credentialprocessoutput,
+		tokenoutputter,
+	)
+	output.Options(
+		outputcredentials.WithFormat(inputEntrypointOptions.Output),
+		outputcredentials.WithTypes(inputEntrypointOptions.Types...),
 	)
 
-	getCredentialsApplication := application.NewApplication(
+	applicationOptions := []application.OptionsFunc{
 		application.WithCredentials(credentialsStore),
 		application.WithOutput(output),
-	)
+	}
+
+	if inputEntrypointOptions.Expiring != "" {
+		expiringWithin, err := credentials.ParseDuration(inputEntrypointOptions.Expiring)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		applicationOptions = append(applicationOptions, application.WithExpiringWithin(expiringWithin))
+	}
+
+	getCredentialsApplication := application.NewApplication(applicationOptions...)
 
 	getCredentialsHandler := handler.NewHandler(
 		handler.WithApplication(getCredentialsApplication),
@@ -155,80 +176,8 @@ func (e Entrypoint) createCredentialsFormater(conf *configuration.CredentialsCon
 	return credentialsFormat, nil
 }
 
-func (e *Entrypoint) createCredentialsLocalStore(conf *configuration.CredentialsConfiguration) (*credentialslocalstore.LocalStore, error) {
-	var err error
-	var format repository.Formater
-
-	errContext := "(get::credentials::entrypoint::createCredentialsLocalStore)"
-
-	if e.fs == nil {
-		return nil, errors.New(errContext, "To create credentials local store in the entrypoint, a file system is required")
-	}
-
-	if conf == nil {
-		return nil, errors.New(errContext, "To create credentials local store in the entrypoint, credentials configuration is required")
-	}
-
-	if e.compatibility == nil {
-		return nil, errors.New(errContext, "To create credentials local store in the entrypoint, compatibilitier is required")
-	}
-
-	format, err = e.createCredentialsFormater(conf)
-	if err != nil {
-		return nil, errors.New(errContext, "", err)
-	}
-
-	credentialsCompatibility := credentialscompatibility.NewCredentialsCompatibility(e.compatibility)
-
-	localStoreOpts := []credentialslocalstore.OptionsFunc{
-		credentialslocalstore.WithFilesystem(e.fs),
-		credentialslocalstore.WithCompatibility(credentialsCompatibility),
-		credentialslocalstore.WithPath(conf.LocalStoragePath),
-		credentialslocalstore.WithFormater(format),
-	}
-
-	if conf.EncryptionKey != "" {
-		encryption := credentialsstoreencryption.NewEncryption(
-			credentialsstoreencryption.WithKey(conf.EncryptionKey),
-		)
-
-		localStoreOpts = append(localStoreOpts, credentialslocalstore.WithEncryption(encryption))
-	}
-
-	store := credentialslocalstore.NewLocalStore(localStoreOpts...)
-
-	return store, nil
-}
-
-func (e *Entrypoint) createCredentialsEnvvarsStore(conf *configuration.CredentialsConfiguration) (*credentialsenvvarsstore.EnvvarsStore, error) {
-
-	var err error
-	var format repository.Formater
-
-	errContext := "(get::credentials::entrypoint::createCredentialsEnvvarsStore)"
-
-	format, err = e.createCredentialsFormater(conf)
-	if err != nil {
-		return nil, errors.New(errContext, "", err)
-	}
-
-	encryption := credentialsstoreencryption.NewEncryption(
-		credentialsstoreencryption.WithKey(conf.EncryptionKey),
-	)
-
-	store := credentialsenvvarsstore.NewEnvvarsStore(
-		credentialsenvvarsstore.WithBackend(credentialsenvvarsstorebackend.NewOSEnvvarsBackend()),
-		credentialsenvvarsstore.WithEncryption(encryption),
-		credentialsenvvarsstore.WithFormater(format),
-	)
-
-	return store, nil
-}
-
 func (e *Entrypoint) createCredentialsFilter(conf *configuration.Configuration) (repository.CredentialsFilterer, error) {
 	errContext := "(get::credentials::entrypoint::createCredentialsFilter)"
-	var store repository.CredentialsFilterer
-	var err error
 
 	if conf == nil {
 		return nil, errors.New(errContext, "To create the credentials filter in the entrypoint, configuration is required")
@@ -238,30 +187,20 @@ func (e *Entrypoint) createCredentialsFilter(conf *configuration.Configuration)
 		return nil, errors.New(errContext, "To create the credentials filter in the entrypoint, credentials configuration is required")
 	}
 
-	if conf.Credentials.Format == "" {
-		return nil, errors.New(errContext, "To create credentials local store in the entrypoint, credentials format must be specified")
-	}
-
-	switch conf.Credentials.StorageType {
-	case credentials.LocalStore:
-		if conf.Credentials.LocalStoragePath == "" {
-			return nil, errors.New(errContext, "To create credentials local store in the entrypoint, local storage path is required")
-		}
-
-		// create credentials store
-		store, err = e.createCredentialsLocalStore(conf.Credentials)
-		if err != nil {
-			return nil, errors.New(errContext, "", err)
-		}
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+	)
 
-	case credentials.EnvvarsStore:
-		store, err = e.createCredentialsEnvvarsStore(conf.Credentials)
-		if err != nil {
-			return nil, errors.New(errContext, "", err)
-		}
+	store, err := credentialsFactory.CreateStore(conf.Credentials)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
 
-	default:
-		return nil, errors.New(errContext, fmt.Sprintf("Unsupported credentials storage type '%s'", conf.Credentials.StorageType))
+	filter, isFilterer := store.(repository.CredentialsFilterer)
+	if !isFilterer {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to get credentials", conf.Credentials.StorageType))
 	}
-	return store, nil
+
+	return filter, nil
 }
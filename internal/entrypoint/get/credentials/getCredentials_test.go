@@ -11,8 +11,10 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
 	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
 	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -55,106 +57,80 @@ func TestExecute(t *testing.T) {
 			err: &errors.Error{},
 		},
 		{
-			desc: "Testing error executing get credentials entrypoint when credentials configuration file is not defined",
+			desc: "Testing execute get credentials entrypoint with json output filtered by type",
 			entrypoint: NewEntrypoint(
 				WithWriter(console.NewConsole(io.Discard, nil)),
 				WithFileSystem(filesystem),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
-			options: &Options{},
+			options: &Options{
+				Output: "json",
+				Types:  []string{"username-password"},
+			},
 			conf: &configuration.Configuration{
 				Credentials: &configuration.CredentialsConfiguration{
 					StorageType:      credentials.LocalStore,
-					LocalStoragePath: "/unknown",
+					LocalStoragePath: "/credentials",
 					Format:           "json",
 				},
 			},
-			err: errors.New(errContext, "Error reading credentials file '/unknown'\n open /unknown: file does not exist"),
-		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			t.Log(test.desc)
-
-			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.options)
-			if err != nil {
-				assert.Equal(t, test.err.Error(), err.Error())
-			}
-		})
-	}
-}
-
-func TestCreateCredentialsLocalStore(t *testing.T) {
-
-	errContext := "(get::credentials::entrypoint::createCredentialsLocalStore)"
-
-	tests := []struct {
-		desc       string
-		entrypoint *Entrypoint
-		conf       *configuration.CredentialsConfiguration
-		format     repository.Formater
-		res        *credentialslocalstore.LocalStore
-		err        error
-	}{
-		{
-			desc:       "Testing error creating credentials local storage on get credentials entrypoint when configuration is not defined",
-			entrypoint: NewEntrypoint(),
-			err:        errors.New(errContext, "To create credentials local store in the entrypoint, a file system is required"),
-		},
-		{
-			desc: "Testing error creating credentials local storage on get credentials entrypoint when configuration is not defined",
-			entrypoint: NewEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
-			),
-			err: errors.New(errContext, "To create credentials local store in the entrypoint, credentials configuration is required"),
-		},
-		{
-			desc: "Testing error creating credentials local storage on get credentials entrypoint when compatibilitier format is not defined",
-			entrypoint: NewEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
-			),
-			conf: &configuration.CredentialsConfiguration{
-				Format: "json",
-			},
-			err: errors.New(errContext, "To create credentials local store in the entrypoint, compatibilitier is required"),
+			err: &errors.Error{},
 		},
 		{
-			desc: "Testing error creating credentials local storage on get credentials entrypoint when local storage path is not defined",
+			desc: "Testing execute get credentials entrypoint showing the credentials about to expire",
 			entrypoint: NewEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
+				WithWriter(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(filesystem),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
-			conf: &configuration.CredentialsConfiguration{
-				StorageType: credentials.LocalStore,
-				Format:      "json",
+			options: &Options{
+				Expiring: "7d",
 			},
-			err: errors.New(errContext, "To create credentials local store in the entrypoint, local storage path is required"),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           "json",
+				},
+			},
+			err: &errors.Error{},
 		},
 		{
-			desc: "Testing error creating credentials filter on get credentials entrypoint when storage type in not defined",
+			desc: "Testing error executing get credentials entrypoint with an invalid expiring duration",
 			entrypoint: NewEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
+				WithWriter(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(filesystem),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
-			conf: &configuration.CredentialsConfiguration{
-				Format:      "json",
-				StorageType: "unknown",
+			options: &Options{
+				Expiring: "soon",
+			},
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           "json",
+				},
 			},
-			err: errors.New(errContext, "Unsupported credentials storage type 'unknown'"),
+			err: errors.New(errContext, "",
+				errors.New("(core::domain::credentials::ParseDuration)", "Invalid duration 'soon'")),
 		},
 		{
-			desc: "Testing create credentials local storage on get credentials",
+			desc: "Testing error executing get credentials entrypoint when credentials configuration file is not defined",
 			entrypoint: NewEntrypoint(
-				WithFileSystem(afero.NewMemMapFs()),
+				WithWriter(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(filesystem),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
-			conf: &configuration.CredentialsConfiguration{
-				StorageType:      credentials.LocalStore,
-				LocalStoragePath: "./test/credentials",
-				Format:           "json",
+			options: &Options{},
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/unknown",
+					Format:           "json",
+				},
 			},
-			res: &credentialslocalstore.LocalStore{},
+			err: errors.New(errContext, "Error reading credentials file '/unknown'\n open /unknown: file does not exist"),
 		},
 	}
 
@@ -162,13 +138,10 @@ func TestCreateCredentialsLocalStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			store, err := test.entrypoint.createCredentialsLocalStore(test.conf)
+			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.options)
 			if err != nil {
-				assert.Equal(t, test.err, err)
-			} else {
-				assert.IsType(t, test.res, store)
+				assert.Equal(t, test.err.Error(), err.Error())
 			}
-
 		})
 	}
 }
@@ -195,6 +168,19 @@ func TestCredentialsFilter(t *testing.T) {
 			conf:       &configuration.Configuration{},
 			err:        errors.New(errContext, "To create the credentials filter in the entrypoint, credentials configuration is required"),
 		},
+		{
+			desc:       "Testing error creating credentials filter on get credentials when storage type is not supported",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: "unknown",
+					Format:      credentials.JSONFormat,
+				},
+			},
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::factory::CreateStore)", "",
+					errors.New("(store::credentials::factory::createStore)", "Unsupported credentials storage type 'unknown'"))),
+		},
 		{
 			desc: "Testing create credentials filter on get credentials",
 			entrypoint: NewEntrypoint(
@@ -221,6 +207,37 @@ func TestCredentialsFilter(t *testing.T) {
 			},
 			res: &credentialsenvvarsstore.EnvvarsStore{},
 		},
+		{
+			desc: "Testing create Docker config credentials filter on get credentials",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.DockerConfigStore,
+					Format:      credentials.JSONFormat,
+				},
+			},
+			res: &credentialsdockerconfigstore.DockerConfigStore{},
+		},
+		{
+			desc:       "Testing create Vault credentials filter on get credentials",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.VaultStore,
+					Format:      credentials.JSONFormat,
+					Vault: &configuration.VaultConfiguration{
+						Address:    "http://127.0.0.1:8200",
+						Mount:      "secret",
+						Path:       "stevedore",
+						AuthMethod: credentials.VaultTokenAuth,
+						Token:      "token",
+					},
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+		},
 	}
 
 	for _, test := range tests {
@@ -19,6 +19,6 @@ func NewMockEntrypoint() *MockEntrypoint {
 
 // Execute provides a mock function
 func (e *MockEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, inputEntrypointOptions *Options) error {
-	res := e.Called(ctx, args, conf)
+	res := e.Called(ctx, args, conf, inputEntrypointOptions)
 	return res.Error(0)
 }
@@ -4,4 +4,10 @@ package credentials
 type Options struct {
 	// ShowSecrets
 	ShowSecrets bool
+	// Output is the format used to print the credentials: table, json or yaml
+	Output string
+	// Types are the credentials types to print
+	Types []string
+	// Expiring is the duration used to show only the credentials that expire within it
+	Expiring string
 }
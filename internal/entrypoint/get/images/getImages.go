@@ -13,7 +13,9 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
 	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
 	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
 	filter "github.com/gostevedore/stevedore/internal/infrastructure/filters/images"
 	"github.com/gostevedore/stevedore/internal/infrastructure/graph"
 	"github.com/gostevedore/stevedore/internal/infrastructure/now"
@@ -22,6 +24,7 @@ import (
 	defaultreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/default"
 	dockerreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/render"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
 	store "github.com/gostevedore/stevedore/internal/infrastructure/store/images"
 	"github.com/spf13/afero"
 )
@@ -81,6 +84,7 @@ func (e *GetImagesEntrypoint) Execute(ctx context.Context, args []string, conf *
 	var imageRender *render.ImageRender
 	var graphTemplateFactory *graph.GraphTemplateFactory
 	var imagesGraphTemplatesStore *imagesgraphtemplate.ImagesGraphTemplate
+	var definitionsSources *definitionssources.DefinitionsSources
 	var imagesStore *store.Store
 	var writer repository.ImagesOutputter
 
@@ -101,7 +105,12 @@ func (e *GetImagesEntrypoint) Execute(ctx context.Context, args []string, conf *
 		return errors.New(errContext, "", err)
 	}
 
-	imagesStore, err = e.createImagesStore(conf, imageRender, imagesGraphTemplatesStore)
+	definitionsSources, err = e.createDefinitionsSources(conf)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	imagesStore, err = e.createImagesStore(ctx, conf, imageRender, imagesGraphTemplatesStore, definitionsSources)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
@@ -174,7 +183,63 @@ func (e *GetImagesEntrypoint) createImageRender(now render.Nower) (*render.Image
 	return render.NewImageRender(now), nil
 }
 
-func (e *GetImagesEntrypoint) createImagesStore(conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer) (*store.Store, error) {
+// createDefinitionsSources returns the component which resolves the local paths where the images and builders are defined, fetching the git sources into the cache folder. The git sources are authenticated through the credentials store, which is only created when any git source is defined
+func (e *GetImagesEntrypoint) createDefinitionsSources(conf *configuration.Configuration) (*definitionssources.DefinitionsSources, error) {
+
+	errContext := "(get::images::entrypoint::createDefinitionsSources)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the definitions sources in get images entrypoint, configuration is required")
+	}
+
+	options := []definitionssources.OptionsFunc{
+		definitionssources.WithCachePath(conf.GitSourcesCachePath),
+	}
+
+	if conf.HasGitDefinitionsSources() {
+		authFactory, err := e.createAuthFactory(conf)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		options = append(options, definitionssources.WithAuthFactory(gitauth.NewGitAuthFactory(authFactory)))
+	}
+
+	return definitionssources.NewDefinitionsSources(options...), nil
+}
+
+// createAuthFactory returns the auth factory which achieves the credentials to access the git definitions sources
+func (e *GetImagesEntrypoint) createAuthFactory(conf *configuration.Configuration) (repository.AuthFactorier, error) {
+
+	errContext := "(get::images::entrypoint::createAuthFactory)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the auth factory in get images entrypoint, configuration is required")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "To create the auth factory in get images entrypoint, credentials configuration is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf.Credentials)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return authFactory, nil
+}
+
+func (e *GetImagesEntrypoint) createImagesStore(ctx context.Context, conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer, sources *definitionssources.DefinitionsSources) (*store.Store, error) {
 
 	errContext := "(get::images::entrypoint::createImagesStore)"
 
@@ -198,13 +263,22 @@ func (e *GetImagesEntrypoint) createImagesStore(conf *configuration.Configuratio
 		return nil, errors.New(errContext, "To create an images store in get images entrypoint, compatibility is required")
 	}
 
-	if conf.ImagesPath == "" {
+	if conf.ImagesPath == "" && len(conf.ImagesSources) == 0 {
 		return nil, errors.New(errContext, "To create an images store in get images entrypoint, images path must be provided in configuration")
 	}
 
+	if sources == nil {
+		return nil, errors.New(errContext, "To create an images store in get images entrypoint, definitions sources are required")
+	}
+
+	paths, err := sources.Paths(ctx, conf.ImagesDefinitionsSources())
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
 	store := store.NewStore(render)
 	imagesConfiguration := imagesconfiguration.NewImagesConfiguration(e.fs, graph, store, render, e.compatibility)
-	err := imagesConfiguration.LoadImagesToStore(conf.ImagesPath)
+	err = imagesConfiguration.LoadImagesToStore(paths...)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
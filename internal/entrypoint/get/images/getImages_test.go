@@ -1,15 +1,18 @@
 package images
 
 import (
+	"context"
 	"io"
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
 	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
 	"github.com/gostevedore/stevedore/internal/infrastructure/graph"
 	"github.com/gostevedore/stevedore/internal/infrastructure/now"
 	plainoutput "github.com/gostevedore/stevedore/internal/infrastructure/output/images/plain"
@@ -122,6 +125,7 @@ func TestCreateImagesStore(t *testing.T) {
 		render        repository.Renderer
 		graph         imagesconfiguration.ImagesGraphTemplatesStorer
 		compatibility Compatibilitier
+		sources       *definitionssources.DefinitionsSources
 		res           *images.Store
 		err           error
 	}{
@@ -176,6 +180,20 @@ func TestCreateImagesStore(t *testing.T) {
 			compatibility: &compatibility.Compatibility{},
 			err:           errors.New(errContext, "To create an images store in get images entrypoint, images path must be provided in configuration"),
 		},
+		{
+			desc: "Testing error creating images store in get images entrypoint when definitions sources are not defined",
+			entrypoint: NewGetImagesEntrypoint(
+				WithFileSystem(testFs),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				ImagesPath: baseDir,
+			},
+			render:        &render.ImageRender{},
+			graph:         &imagesgraphtemplate.ImagesGraphTemplate{},
+			compatibility: &compatibility.Compatibility{},
+			err:           errors.New(errContext, "To create an images store in get images entrypoint, definitions sources are required"),
+		},
 		{
 			desc: "Testing create images store",
 			entrypoint: NewGetImagesEntrypoint(
@@ -190,6 +208,7 @@ func TestCreateImagesStore(t *testing.T) {
 				graph.NewGraphTemplateFactory(false),
 			),
 			compatibility: &compatibility.Compatibility{},
+			sources:       definitionssources.NewDefinitionsSources(),
 			res:           &images.Store{},
 			err:           &errors.Error{},
 		},
@@ -199,7 +218,7 @@ func TestCreateImagesStore(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			store, err := test.entrypoint.createImagesStore(test.conf, test.render, test.graph)
+			store, err := test.entrypoint.createImagesStore(context.TODO(), test.conf, test.render, test.graph, test.sources)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
@@ -384,3 +403,79 @@ func TestCreateReferenceName(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDefinitionsSources(t *testing.T) {
+	errContext := "(get::images::entrypoint::createDefinitionsSources)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *GetImagesEntrypoint
+		conf       *configuration.Configuration
+		res        *definitionssources.DefinitionsSources
+		err        error
+	}{
+		{
+			desc:       "Testing error creating definitions sources in get images entrypoint when configuration is not defined",
+			entrypoint: NewGetImagesEntrypoint(),
+			err:        errors.New(errContext, "To create the definitions sources in get images entrypoint, configuration is required"),
+		},
+		{
+			desc:       "Testing create definitions sources in get images entrypoint",
+			entrypoint: NewGetImagesEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesPath:          "images",
+			},
+			res: definitionssources.NewDefinitionsSources(
+				definitionssources.WithCachePath("/cache/git"),
+			),
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing error creating definitions sources in get images entrypoint when git sources are defined and credentials configuration is not defined",
+			entrypoint: NewGetImagesEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Git: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git", CredentialsID: "github"}},
+				},
+			},
+			err: errors.New(errContext, "",
+				errors.New("(get::images::entrypoint::createAuthFactory)", "To create the auth factory in get images entrypoint, credentials configuration is required")),
+		},
+		{
+			desc: "Testing create definitions sources in get images entrypoint authenticating the git sources through the credentials store",
+			entrypoint: NewGetImagesEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Git: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git", CredentialsID: "github"}},
+				},
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.EnvvarsStore,
+					Format:      credentials.JSONFormat,
+				},
+			},
+			res: &definitionssources.DefinitionsSources{},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			sources, err := test.entrypoint.createDefinitionsSources(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else if test.conf.HasGitDefinitionsSources() {
+				assert.IsType(t, test.res, sources)
+			} else {
+				assert.Equal(t, test.res, sources)
+			}
+		})
+	}
+}
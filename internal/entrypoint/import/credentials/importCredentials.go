@@ -0,0 +1,252 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/import/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/import/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsbundlestore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/bundle"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
+	credentialsdockerconfighelper "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig/helper"
+	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+const (
+	getPassphraseInputMessage = "Passphrase: "
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the import credentials command
+type Entrypoint struct {
+	console       Consoler
+	compatibility Compatibilitier
+	fs            afero.Fs
+	helper        credentialsdockerconfigstore.CredentialsHelperer
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithConsole sets the console for the entrypoint
+func WithConsole(console Consoler) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.console = console
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// WithCredentialsHelper sets the Docker credentials helper used to import the credentials from the Docker configuration file
+func WithCredentialsHelper(helper credentialsdockerconfigstore.CredentialsHelperer) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.helper = helper
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+	var count int
+	var source repository.CredentialsLister
+	var store application.CredentialsStorer
+
+	errContext := "(import::credentials::entrypoint::Execute)"
+
+	if e.console == nil {
+		return errors.New(errContext, "To execute the import credentials entrypoint, a console is required")
+	}
+
+	if e.fs == nil {
+		return errors.New(errContext, "To execute the import credentials entrypoint, a file system is required")
+	}
+
+	conf, err = e.prepareConfiguration(conf, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	source, err = e.createCredentialsSource(options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	h := handler.NewHandler(
+		handler.WithApplication(
+			application.NewApplication(
+				application.WithCredentialsSource(source),
+				application.WithCredentialsStore(store),
+			),
+		),
+	)
+
+	count, err = h.Handler(ctx)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	e.console.Info(fmt.Sprintf("%d credentials successfully imported", count))
+
+	return nil
+}
+
+func (e *Entrypoint) prepareConfiguration(conf *configuration.Configuration, options *Options) (*configuration.Configuration, error) {
+
+	errContext := "(import::credentials::entrypoint::prepareConfiguration)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to prepare configuration")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "Configuration must be provided to prepare configuration")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "Configuration credentials must be provided to prepare configuration")
+	}
+
+	if conf.Credentials.StorageType == credentials.LocalStore && options.LocalStoragePath != "" {
+		conf.Credentials.LocalStoragePath = options.LocalStoragePath
+	}
+
+	return conf, nil
+}
+
+// preparePassphrase returns the passphrase to decrypt the credentials bundle. It is asked on the console when it is not provided
+func (e *Entrypoint) preparePassphrase(options *Options) (string, error) {
+	var err error
+	var passphrase string
+
+	errContext := "(import::credentials::entrypoint::preparePassphrase)"
+
+	passphrase = options.Passphrase
+
+	if options.AskPassphrase || passphrase == "" {
+		passphrase, err = e.console.ReadPassword(getPassphraseInputMessage)
+		if err != nil {
+			return "", errors.New(errContext, "Error reading the passphrase", err)
+		}
+		fmt.Fprintln(e.console)
+	}
+
+	if passphrase == "" {
+		return "", errors.New(errContext, "The passphrase to decrypt the credentials bundle must be provided")
+	}
+
+	return passphrase, nil
+}
+
+// createCredentialsSource returns where the credentials are imported from, either a credentials bundle or the Docker configuration file
+func (e *Entrypoint) createCredentialsSource(options *Options) (repository.CredentialsLister, error) {
+	var err error
+	var passphrase string
+
+	errContext := "(import::credentials::entrypoint::createCredentialsSource)"
+
+	if options.From != "" && options.FromDockerConfig {
+		return nil, errors.New(errContext, "Credentials can be imported either from a credentials bundle or from the Docker configuration file, but not from both")
+	}
+
+	if options.FromDockerConfig {
+		helper := e.helper
+		if helper == nil {
+			helper = credentialsdockerconfighelper.NewDockerCredentialsHelper()
+		}
+
+		return credentialsdockerconfigstore.NewDockerConfigStore(
+			credentialsdockerconfigstore.WithFilesystem(e.fs),
+			credentialsdockerconfigstore.WithPath(credentialsdockerconfigstore.DefaultConfigPath()),
+			credentialsdockerconfigstore.WithCredentialsHelper(helper),
+		), nil
+	}
+
+	if options.From == "" {
+		return nil, errors.New(errContext, "To import credentials, either a credentials bundle or the Docker configuration file is required")
+	}
+
+	passphrase, err = e.preparePassphrase(options)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return credentialsbundlestore.NewBundleStore(
+		credentialsbundlestore.WithFilesystem(e.fs),
+		credentialsbundlestore.WithPath(options.From),
+		credentialsbundlestore.WithEncryption(
+			credentialsstoreencryption.NewEncryption(
+				credentialsstoreencryption.WithKey(passphrase),
+			),
+		),
+	), nil
+}
+
+// createCredentialsStore returns the store to import the credentials to. The local store refuses to overwrite the existing credentials, unless the import is forced
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration, options *Options) (application.CredentialsStorer, error) {
+	var store application.CredentialsStorer
+	var err error
+
+	errContext := "(import::credentials::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the import credentials entrypoint, credentials configuration is required")
+	}
+
+	if conf.StorageType == credentials.DockerConfigStore {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to import credentials", conf.StorageType))
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.console),
+	)
+
+	if options != nil && options.ForceImport {
+		store, err = credentialsFactory.CreateStore(conf)
+	} else {
+		store, err = credentialsFactory.CreateSafeStore(conf)
+	}
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
@@ -0,0 +1,274 @@
+package credentials
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	credentialsformatjson "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/json"
+	credentialsbundlestore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/bundle"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(import::credentials::entrypoint::Execute)"
+
+	t.Setenv(credentialsdockerconfigstore.DockerConfigEnvvar, "/docker")
+
+	credential := &credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	}
+
+	localStoreConfiguration := func() *configuration.Configuration {
+		return &configuration.Configuration{
+			Credentials: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+		}
+	}
+
+	localStore := func(fs afero.Fs) *credentialslocalstore.LocalStore {
+		return credentialslocalstore.NewLocalStore(
+			credentialslocalstore.WithFilesystem(fs),
+			credentialslocalstore.WithPath("/credentials"),
+			credentialslocalstore.WithFormater(credentialsformatjson.NewJSONFormater()),
+			credentialslocalstore.WithCompatibility(credentialscompatibility.NewCredentialsCompatibility(compatibility.NewMockCompatibility())),
+		)
+	}
+
+	writeBundle := func(fs afero.Fs) {
+		_ = credentialsbundlestore.NewBundleStore(
+			credentialsbundlestore.WithFilesystem(fs),
+			credentialsbundlestore.WithPath("/credentials.bundle"),
+			credentialsbundlestore.WithEncryption(encryption.NewEncryption(encryption.WithKey("passphrase"))),
+		).Write([]*credentials.Credential{credential})
+	}
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		conf              *configuration.Configuration
+		options           *Options
+		prepareAssertFunc func(*Entrypoint)
+		assertFunc        func(*testing.T, *Entrypoint)
+		err               error
+	}{
+		{
+			desc:       "Testing error executing import credentials entrypoint without console",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To execute the import credentials entrypoint, a console is required"),
+		},
+		{
+			desc: "Testing error executing import credentials entrypoint without source",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf:    localStoreConfiguration(),
+			options: &Options{},
+			err: errors.New(errContext, "",
+				errors.New("(import::credentials::entrypoint::createCredentialsSource)", "To import credentials, either a credentials bundle or the Docker configuration file is required")),
+		},
+		{
+			desc: "Testing error executing import credentials entrypoint with both sources",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: localStoreConfiguration(),
+			options: &Options{
+				From:             "/credentials.bundle",
+				FromDockerConfig: true,
+			},
+			err: errors.New(errContext, "",
+				errors.New("(import::credentials::entrypoint::createCredentialsSource)", "Credentials can be imported either from a credentials bundle or from the Docker configuration file, but not from both")),
+		},
+		{
+			desc: "Testing execute import credentials entrypoint from a credentials bundle to local store",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: localStoreConfiguration(),
+			options: &Options{
+				From:       "/credentials.bundle",
+				Passphrase: "passphrase",
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				writeBundle(e.fs)
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				res, err := localStore(e.fs).Get("registry.example.com")
+				assert.NoError(t, err)
+				assert.Equal(t, credential, res)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error executing import credentials entrypoint when credentials already exist",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: localStoreConfiguration(),
+			options: &Options{
+				From:       "/credentials.bundle",
+				Passphrase: "passphrase",
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				writeBundle(e.fs)
+				_ = localStore(e.fs).Store("registry.example.com", &credentials.Credential{Username: "other", Password: "other"})
+			},
+			err: errors.New(errContext, "",
+				errors.New("(import::credentials::Handler)", "",
+					errors.New("(application::import::credentials::Run)", "Error importing 'registry.example.com' credentials",
+						errors.New("(store::credentials::local::LocalStoreWithSafeStore)", "",
+							errors.New("(store::credentials::local::SafeStore)", "Credentials 'registry.example.com' already exist"))))),
+		},
+		{
+			desc: "Testing execute import credentials entrypoint forcing to overwrite the existing credentials",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: localStoreConfiguration(),
+			options: &Options{
+				From:        "/credentials.bundle",
+				Passphrase:  "passphrase",
+				ForceImport: true,
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				writeBundle(e.fs)
+				_ = localStore(e.fs).Store("registry.example.com", &credentials.Credential{Username: "other", Password: "other"})
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				res, err := localStore(e.fs).Get("registry.example.com")
+				assert.NoError(t, err)
+				assert.Equal(t, credential, res)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing execute import credentials entrypoint from the Docker configuration file",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: localStoreConfiguration(),
+			options: &Options{
+				FromDockerConfig: true,
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				// auth is the base64 encoded 'username:password'
+				_ = afero.WriteFile(e.fs, "/docker/config.json", []byte(`{"auths":{"registry.example.com":{"auth":"dXNlcm5hbWU6cGFzc3dvcmQ="}}}`), 0600)
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				res, err := localStore(e.fs).Get("registry.example.com")
+				assert.NoError(t, err)
+				assert.Equal(t, "username", res.Username)
+				assert.Equal(t, "password", res.Password)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			err := test.entrypoint.Execute(context.TODO(), []string{}, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.entrypoint)
+			}
+		})
+	}
+}
+
+func TestPreparePassphrase(t *testing.T) {
+
+	errContext := "(import::credentials::entrypoint::preparePassphrase)"
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		options           *Options
+		prepareAssertFunc func(*Entrypoint)
+		res               string
+		err               error
+	}{
+		{
+			desc:       "Testing prepare the passphrase provided by options",
+			entrypoint: NewEntrypoint(),
+			options:    &Options{Passphrase: "passphrase"},
+			res:        "passphrase",
+		},
+		{
+			desc: "Testing prepare the passphrase asked on the console when it is not provided",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			options: &Options{},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getPassphraseInputMessage).Return("asked-passphrase", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			res: "asked-passphrase",
+		},
+		{
+			desc: "Testing error preparing an empty passphrase",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			options: &Options{},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getPassphraseInputMessage).Return("", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			err: errors.New(errContext, "The passphrase to decrypt the credentials bundle must be provided"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			res, err := test.entrypoint.preparePassphrase(test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
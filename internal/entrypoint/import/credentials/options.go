@@ -0,0 +1,17 @@
+package credentials
+
+// Options is the options for the import credentials command entrypoint
+type Options struct {
+	// AskPassphrase is true if the passphrase to decrypt the credentials bundle should be asked. It is asked anyway when it is not provided
+	AskPassphrase bool
+	// ForceImport forces to import the credentials, overwriting the existing ones
+	ForceImport bool
+	// From is the credentials bundle file path
+	From string
+	// FromDockerConfig is true to import the credentials from the Docker configuration file
+	FromDockerConfig bool
+	// LocalStoragePath is the location of local storage
+	LocalStoragePath string
+	// Passphrase is the passphrase to decrypt the credentials bundle
+	Passphrase string
+}
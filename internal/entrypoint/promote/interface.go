@@ -12,5 +12,6 @@ type ConsoleWriter interface {
 	Error(msg ...interface{})
 	Info(msg ...interface{})
 	Warn(msg ...interface{})
+	PrintTable(content [][]string) error
 	Write(data []byte) (int, error)
 }
@@ -2,6 +2,12 @@ package promote
 
 // Options represents the options for the entrypoint
 type Options struct {
+	// Concurrency is the number of images promotions that can be executed at the same time
+	Concurrency int
+	// DryRun is true if the promote should be a dry run
+	DryRun bool
+	// PromotionPolicyPath is the path to the promotion policy file. It overrides the promotion policy path defined on the configuration
+	PromotionPolicyPath string
 	// UserDockerNormalizedName when is true are used Docker normalized name references
 	UseDockerNormalizedName bool
 }
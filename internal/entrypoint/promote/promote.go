@@ -3,43 +3,51 @@ package promote
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/apenella/go-docker-builder/pkg/copy"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	dockerclient "github.com/docker/docker/client"
 	application "github.com/gostevedore/stevedore/internal/application/promote"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/promote"
-	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
-	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
-	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
-	authmethodsshagent "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/sshagent"
-	authproviderawsecr "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr"
-	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token"
-	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token/awscredprovider"
-	authproviderstore "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/store"
-	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
+	authexpiration "github.com/gostevedore/stevedore/internal/infrastructure/auth/expiration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
-	credentialsformatfactory "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/factory"
+	imagesconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
+	imagesgraphtemplate "github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/graph"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration/policy"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
+	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
+	filter "github.com/gostevedore/stevedore/internal/infrastructure/filters/images"
+	"github.com/gostevedore/stevedore/internal/infrastructure/filters/operation"
+	"github.com/gostevedore/stevedore/internal/infrastructure/graph"
+	"github.com/gostevedore/stevedore/internal/infrastructure/now"
+	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/docker/godockerbuilder"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/dryrun"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/promote/registry"
 	defaultreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/default"
 	dockerreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/docker"
+	"github.com/gostevedore/stevedore/internal/infrastructure/render"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/dispatch"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/job"
+	"github.com/gostevedore/stevedore/internal/infrastructure/scheduler/worker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/semver"
-	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
-	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
-	credentialsenvvarsstorebackend "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars/backend"
-	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/images"
 	"github.com/spf13/afero"
 )
 
+const (
+	dryRunConcurreny = 1
+)
+
 // OptionsFunc defines the signature for an option function to set entrypoint attributes
 type OptionsFunc func(opts *Entrypoint)
 
@@ -94,6 +102,7 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 	var semverGenerator *semver.SemVerGenerator
 	var options *handler.Options
 	var referenceName repository.ImageReferenceNamer
+	var planFactory handler.PlanFactorier
 
 	errContext := "(promote::entrypoint::Execute)"
 
@@ -122,14 +131,63 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 		return errors.New(errContext, "", err)
 	}
 
-	promoteService := application.NewApplication(
+	applicationOptions := []application.OptionsFunc{
 		application.WithPromoteFactory(promoteRepoFactory),
 		application.WithCredentials(credentialsFactory),
 		application.WithSemver(semverGenerator),
 		application.WithReferenceNamer(referenceName),
-	)
+		application.WithDigestResolver(registry.NewDigestResolver(&http.Client{}, registry.DefaultScheme)),
+		application.WithOutput(e.writer),
+	}
+
+	expirationWarner, err := e.createCredentialsExpirationWarner(conf.Credentials, credentialsFactory)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	if expirationWarner != nil {
+		applicationOptions = append(applicationOptions, application.WithCredentialsExpirationWarner(expirationWarner))
+	}
+
+	immutableTags, err := e.createImmutableTags(conf)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	if immutableTags != nil {
+		applicationOptions = append(applicationOptions, application.WithImmutableTags(immutableTags))
+	}
+
+	promotionPolicy, err := e.createPromotionPolicy(conf, entrypointOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	if promotionPolicy != nil {
+		var labelsResolver application.LabelsResolver
+
+		labelsResolver, err = e.createLabelsResolver(options)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		applicationOptions = append(applicationOptions,
+			application.WithPolicy(promotionPolicy),
+			application.WithLabelsResolver(labelsResolver),
+		)
+	}
+
+	// plan factory is only required to promote images from an images definition
+	if options.ImageDefinitionName != "" {
+		var definitionOptions []application.OptionsFunc
+
+		planFactory, definitionOptions, err = e.prepareDefinitionPromotion(ctx, conf, entrypointOptions, credentialsFactory)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+		applicationOptions = append(applicationOptions, definitionOptions...)
+	}
 
-	promoteHandler := handler.NewHandler(promoteService)
+	promoteService := application.NewApplication(applicationOptions...)
+
+	promoteHandler := handler.NewHandler(planFactory, promoteService)
 	err = promoteHandler.Handler(ctx, options)
 	if err != nil {
 		return errors.New(errContext, "", err)
@@ -138,10 +196,159 @@ func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configura
 	return nil
 }
 
+// prepareDefinitionPromotion creates the components required to promote the images from an images definition
+func (e *Entrypoint) prepareDefinitionPromotion(ctx context.Context, conf *configuration.Configuration, inputEntrypointOptions *Options, credentialsFactory repository.AuthFactorier) (handler.PlanFactorier, []application.OptionsFunc, error) {
+	var definitionsSources *definitionssources.DefinitionsSources
+	var dispatcher *dispatch.Dispatch
+	var entrypointOptions *Options
+	var err error
+	var imageRender *render.ImageRender
+	var imagesGraphTemplatesStore *imagesgraphtemplate.ImagesGraphTemplate
+	var imagesStore *images.Store
+
+	errContext := "(promote::entrypoint::prepareDefinitionPromotion)"
+
+	entrypointOptions, err = e.prepareEntrypointOptions(conf, inputEntrypointOptions)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	imageRender = render.NewImageRender(now.NewNow())
+	imagesGraphTemplatesStore = imagesgraphtemplate.NewImagesGraphTemplate(graph.NewGraphTemplateFactory(false))
+
+	definitionsSources, err = e.createDefinitionsSources(conf, credentialsFactory)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	imagesStore, err = e.createImagesStore(ctx, conf, imageRender, imagesGraphTemplatesStore, definitionsSources)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	dispatcher = dispatch.NewDispatch(worker.NewWorkerFactory(), dispatch.WithNumWorkers(entrypointOptions.Concurrency))
+	err = dispatcher.Start(ctx)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	options := []application.OptionsFunc{
+		application.WithCommandFactory(command.NewPromoteCommandFactory()),
+		application.WithJobFactory(job.NewJobFactory()),
+		application.WithDispatch(dispatcher),
+		application.WithFilterFactory(operation.NewFilterOperationFactory()),
+		application.WithSelector(map[string]repository.ImagesSelector{
+			image.NameFilterAttribute:              filter.NewImageNameFilter(),
+			image.VersionFilterAttribute:           filter.NewImageVersionFilter(),
+			image.RegistryHostFilterAttribute:      filter.NewImageRegistryFilter(),
+			image.RegistryNamespaceFilterAttribute: filter.NewImageNamespaceFilter(),
+		}),
+	}
+
+	return plan.NewPlanFactory(imagesStore), options, nil
+}
+
+func (e *Entrypoint) prepareEntrypointOptions(conf *configuration.Configuration, inputEntrypointOptions *Options) (*Options, error) {
+
+	errContext := "(promote::entrypoint::prepareEntrypointOptions)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To prepare promote entrypoint options, configuration is required")
+	}
+
+	if inputEntrypointOptions == nil {
+		return nil, errors.New(errContext, "To prepare promote entrypoint options, entrypoint options are required")
+	}
+
+	options := &Options{}
+	options.DryRun = inputEntrypointOptions.DryRun
+	options.UseDockerNormalizedName = inputEntrypointOptions.UseDockerNormalizedName
+
+	options.Concurrency = inputEntrypointOptions.Concurrency
+	if conf.Concurrency > 0 && options.Concurrency < 1 {
+		options.Concurrency = conf.Concurrency
+	}
+
+	if options.DryRun {
+		options.Concurrency = dryRunConcurreny
+	}
+
+	return options, nil
+}
+
+// createDefinitionsSources returns the component which resolves the local paths where the images are defined, fetching the git sources into the cache folder
+func (e *Entrypoint) createDefinitionsSources(conf *configuration.Configuration, credentialsFactory repository.AuthFactorier) (*definitionssources.DefinitionsSources, error) {
+
+	errContext := "(promote::entrypoint::createDefinitionsSources)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the definitions sources in promote entrypoint, configuration is required")
+	}
+
+	options := []definitionssources.OptionsFunc{
+		definitionssources.WithCachePath(conf.GitSourcesCachePath),
+	}
+
+	if credentialsFactory != nil {
+		options = append(options, definitionssources.WithAuthFactory(gitauth.NewGitAuthFactory(credentialsFactory)))
+	}
+
+	return definitionssources.NewDefinitionsSources(options...), nil
+}
+
+func (e *Entrypoint) createImagesStore(ctx context.Context, conf *configuration.Configuration, render repository.Renderer, graph imagesconfiguration.ImagesGraphTemplatesStorer, sources *definitionssources.DefinitionsSources) (*images.Store, error) {
+
+	errContext := "(promote::entrypoint::createImagesStore)"
+
+	if e.fs == nil {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, a filesystem is required")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, configuration is required")
+	}
+
+	if render == nil {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, image render is required")
+	}
+
+	if graph == nil {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, images graph templates storer is required")
+	}
+
+	if e.compatibility == nil {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, compatibility is required")
+	}
+
+	if conf.ImagesPath == "" && len(conf.ImagesSources) == 0 {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, images path must be provided in configuration")
+	}
+
+	if sources == nil {
+		return nil, errors.New(errContext, "To create an images store in promote entrypoint, definitions sources are required")
+	}
+
+	paths, err := sources.Paths(ctx, conf.ImagesDefinitionsSources())
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	store := images.NewStore(render)
+	imagesConfiguration := imagesconfiguration.NewImagesConfiguration(e.fs, graph, store, render, e.compatibility)
+	err = imagesConfiguration.LoadImagesToStore(paths...)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
+
 func (e *Entrypoint) prepareHandlerOptions(args []string, conf *configuration.Configuration, inputOptions *handler.Options) (*handler.Options, error) {
 	errContext := "(promote::entrypoint::prepareHandlerOptions)"
 
-	if len(args) < 1 || args == nil {
+	promoteDefinition := inputOptions != nil && inputOptions.ImageDefinitionName != ""
+
+	if !promoteDefinition && (len(args) < 1 || args == nil) {
 		return nil, errors.New(errContext, "To execute the promote entrypoint, promote image argument is required")
 	}
 
@@ -153,7 +360,11 @@ func (e *Entrypoint) prepareHandlerOptions(args []string, conf *configuration.Co
 		return nil, errors.New(errContext, "To execute the promote entrypoint, configuration is required")
 	}
 
-	if len(args) > 1 {
+	if promoteDefinition && len(args) > 0 {
+		e.writer.Warn(fmt.Sprintf("Ignoring extra arguments: %v\n", args))
+	}
+
+	if !promoteDefinition && len(args) > 1 {
 		e.writer.Warn(fmt.Sprintf("Ignoring extra arguments: %v\n", args[1:]))
 	}
 
@@ -161,6 +372,7 @@ func (e *Entrypoint) prepareHandlerOptions(args []string, conf *configuration.Co
 
 	options.DryRun = inputOptions.DryRun
 	options.EnableSemanticVersionTags = conf.EnableSemanticVersionTags || inputOptions.EnableSemanticVersionTags
+	options.Force = inputOptions.Force
 	options.TargetImageName = inputOptions.TargetImageName
 	options.TargetImageRegistryNamespace = inputOptions.TargetImageRegistryNamespace
 	options.TargetImageRegistryHost = inputOptions.TargetImageRegistryHost
@@ -170,87 +382,42 @@ func (e *Entrypoint) prepareHandlerOptions(args []string, conf *configuration.Co
 	if inputOptions.EnableSemanticVersionTags && len(conf.SemanticVersionTagsTemplates) > 0 && len(inputOptions.SemanticVersionTagsTemplates) == 0 {
 		options.SemanticVersionTagsTemplates = append([]string{}, conf.SemanticVersionTagsTemplates...)
 	}
-	options.SourceImageName = args[0]
+	if promoteDefinition {
+		options.ImageDefinitionName = inputOptions.ImageDefinitionName
+		options.ImageDefinitionVersions = append([]string{}, inputOptions.ImageDefinitionVersions...)
+		options.Filter = append([]string{}, inputOptions.Filter...)
+		options.PromoteOnCascade = inputOptions.PromoteOnCascade
+		options.CascadeDepth = inputOptions.CascadeDepth
+	} else {
+		options.SourceImageName = args[0]
+	}
 	options.PromoteSourceImageTag = inputOptions.PromoteSourceImageTag
+	options.Promoter = inputOptions.Promoter
+	options.SourceImageDigest = inputOptions.SourceImageDigest
+	options.VerifyDigest = inputOptions.VerifyDigest
 	options.RemoteSourceImage = inputOptions.RemoteSourceImage
 
 	return options, nil
 }
 
-func (e *Entrypoint) createCredentialsFormater(conf *configuration.CredentialsConfiguration) (repository.Formater, error) {
-	errContext := "(promote::entrypoint::createCredentialsFormater)"
-
-	if conf.Format == "" {
-		return nil, errors.New(errContext, "To create credentials store in the entrypoint, credentials format must be specified")
-	}
-
-	credentialsFormatFactory := credentialsformatfactory.NewFormatFactory()
-	credentialsFormat, err := credentialsFormatFactory.Get(conf.Format)
-	if err != nil {
-		return nil, errors.New(errContext, "", err)
-	}
-
-	return credentialsFormat, nil
-}
-
 func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
-
-	var store repository.CredentialsStorer
-	var err error
-	var format repository.Formater
-
 	errContext := "(promote::entrypoint::createCredentialsStore)"
 
 	if conf == nil {
 		return nil, errors.New(errContext, "To create credentials store in the promote entrypoint, credentials configuration is required")
 	}
 
-	format, err = e.createCredentialsFormater(conf)
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.writer),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
 
-	encryption := credentialsstoreencryption.NewEncryption(
-		credentialsstoreencryption.WithKey(conf.EncryptionKey),
-	)
-
-	switch conf.StorageType {
-	case credentials.LocalStore:
-
-		if e.compatibility == nil {
-			return nil, errors.New(errContext, "To create credentials store in the promote entrypoint, compatibility is required")
-		}
-
-		if conf.LocalStoragePath == "" {
-			return nil, errors.New(errContext, "To create credentials store in the promote entrypoint, local storage path is required")
-		}
-
-		credentialsCompatibility := credentialscompatibility.NewCredentialsCompatibility(e.compatibility)
-
-		localStoreOpts := []credentialslocalstore.OptionsFunc{
-			credentialslocalstore.WithFilesystem(e.fs),
-			credentialslocalstore.WithCompatibility(credentialsCompatibility),
-			credentialslocalstore.WithPath(conf.LocalStoragePath),
-			credentialslocalstore.WithFormater(format),
-		}
-
-		if conf.EncryptionKey != "" {
-			localStoreOpts = append(localStoreOpts, credentialslocalstore.WithEncryption(encryption))
-		}
-
-		store = credentialslocalstore.NewLocalStore(localStoreOpts...)
-
-	case credentials.EnvvarsStore:
-		store = credentialsenvvarsstore.NewEnvvarsStore(
-			credentialsenvvarsstore.WithConsole(e.writer),
-			credentialsenvvarsstore.WithBackend(credentialsenvvarsstorebackend.NewOSEnvvarsBackend()),
-			credentialsenvvarsstore.WithFormater(format),
-			credentialsenvvarsstore.WithEncryption(encryption),
-		)
-
-	default:
-		return nil, errors.New(errContext, fmt.Sprintf("Unsupported credentials storage type '%s'", conf.StorageType))
-	}
 	return store, nil
 }
 
@@ -275,34 +442,47 @@ func (e *Entrypoint) createAuthFactory(conf *configuration.Configuration) (repos
 		return nil, errors.New(errContext, "", err)
 	}
 
-	// create auth methods
-	basic := authmethodbasic.NewBasicAuthMethod()
-	keyfile := authmethodkeyfile.NewKeyFileAuthMethod()
-	sshagent := authmethodsshagent.NewSSHAgentAuthMethod()
-
-	// create auth providers
-	badge := authproviderstore.NewStoreAuthProvider(basic, keyfile, sshagent)
-
-	// create authorization aws ecr provider
-	tokenProvider := token.NewAWSECRToken(
-		token.WithAssumeRoleARNProvider(awscredprovider.NewAssumerRoleARNProvider()),
-		token.WithStaticCredentialsProvider(awscredprovider.NewStaticCredentialsProvider()),
-		token.WithECRClientFactory(
-			token.NewECRClientFactory(
-				func(cfg aws.Config) token.ECRClienter {
-					c := ecr.NewFromConfig(cfg)
-					return c
-				},
-			),
-		),
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
 	)
 
-	awsecr := authproviderawsecr.NewAWSECRAuthProvider(tokenProvider)
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return authFactory, nil
+}
+
+// createCredentialsExpirationWarner returns the component which warns about the credentials that are about to expire, or nil when the warnings are disabled
+func (e *Entrypoint) createCredentialsExpirationWarner(conf *configuration.CredentialsConfiguration, credentialsFactory repository.AuthFactorier) (*authexpiration.ExpirationWarner, error) {
+	var err error
+
+	errContext := "(entrypoint::promote::createCredentialsExpirationWarner)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the credentials expiration warner in promote entrypoint, credentials configuration is required")
+	}
 
-	// create credentials factory
-	factory := authfactory.NewAuthFactory(store, badge, awsecr)
+	// credentials can only be inspected when the auth factory resolves them
+	resolver, isResolver := credentialsFactory.(authexpiration.CredentialsResolver)
+	if !isResolver || e.writer == nil {
+		return nil, nil
+	}
 
-	return factory, nil
+	window := credentials.DefaultExpirationWarningWindow
+	if conf.ExpirationWarningWindow != "" {
+		window, err = credentials.ParseDuration(conf.ExpirationWarningWindow)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid credentials '%s' value", configuration.CredentialsExpirationWarningWindowKey), err)
+		}
+	}
+
+	if window == 0 {
+		return nil, nil
+	}
+
+	return authexpiration.NewExpirationWarner(resolver, e.writer, authexpiration.WithWindow(window)), nil
 }
 
 func (e *Entrypoint) createPromoteFactory() (factory.PromoteFactory, error) {
@@ -314,15 +494,21 @@ func (e *Entrypoint) createPromoteFactory() (factory.PromoteFactory, error) {
 		return nil, errors.New(errContext, "", err)
 	}
 
-	copyCmd := copy.NewDockerImageCopyCmd(dockerClient)
-	copyCmdFacade := godockerbuilder.NewDockerCopy(copyCmd)
-	promoteRepoDocker := docker.NewDockerPromote(copyCmdFacade, os.Stdout)
+	// each promotion uses its own copy command because images could be promoted concurrently
+	promoteRepoDocker := docker.NewDockerPromoteFromFactory(func() docker.DockerCopier {
+		return godockerbuilder.NewDockerCopy(copy.NewDockerImageCopyCmd(dockerClient))
+	}, os.Stdout)
+	promoteRepoRegistry := registry.NewRegistryPromote(&http.Client{}, os.Stdout)
 	promoteRepoDryRun := dryrun.NewDryRunPromote(os.Stdout)
 	promoteRepoFactory := factory.NewPromoteFactory()
 	err = promoteRepoFactory.Register(image.DockerPromoterName, promoteRepoDocker)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
+	err = promoteRepoFactory.Register(image.RegistryPromoterName, promoteRepoRegistry)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
 	err = promoteRepoFactory.Register(image.DryRunPromoterName, promoteRepoDryRun)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
@@ -331,6 +517,74 @@ func (e *Entrypoint) createPromoteFactory() (factory.PromoteFactory, error) {
 	return promoteRepoFactory, nil
 }
 
+// createImmutableTags returns the immutable tags defined on the configuration. It returns nil when there are no immutable images defined
+func (e *Entrypoint) createImmutableTags(conf *configuration.Configuration) (*image.ImmutableTags, error) {
+
+	errContext := "(promote::entrypoint::createImmutableTags)"
+
+	if conf.ImmutableTags == nil || len(conf.ImmutableTags.Images) == 0 {
+		return nil, nil
+	}
+
+	immutableTags, err := image.NewImmutableTags(conf.ImmutableTags.Images, conf.ImmutableTags.FloatingTags)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return immutableTags, nil
+}
+
+// createPromotionPolicy returns the promotion policy to govern the promotions. The policy path provided on the entrypoint options takes precedence over the one defined on the configuration. It returns nil when there is no policy path defined
+func (e *Entrypoint) createPromotionPolicy(conf *configuration.Configuration, options *Options) (*image.PromotionPolicy, error) {
+
+	errContext := "(promote::entrypoint::createPromotionPolicy)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the promotion policy in the promote entrypoint, configuration is required")
+	}
+
+	if options == nil {
+		return nil, errors.New(errContext, "To create the promotion policy in the promote entrypoint, entrypoint options are required")
+	}
+
+	policyPath := conf.PromotionPolicyPath
+	if options.PromotionPolicyPath != "" {
+		policyPath = options.PromotionPolicyPath
+	}
+
+	if policyPath == "" {
+		return nil, nil
+	}
+
+	if e.fs == nil {
+		return nil, errors.New(errContext, "To create the promotion policy in the promote entrypoint, a file system is required")
+	}
+
+	promotionPolicy, err := policy.LoadPromotionPolicy(e.fs, policyPath)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return promotionPolicy, nil
+}
+
+// createLabelsResolver returns the labels resolver used to check the promotion policy required labels. Source images labels are read from the registry when the source image is remote or when the images are promoted between registries, otherwise they are read from the Docker daemon
+func (e *Entrypoint) createLabelsResolver(options *handler.Options) (application.LabelsResolver, error) {
+
+	errContext := "(promote::entrypoint::createLabelsResolver)"
+
+	if options.RemoteSourceImage || options.Promoter == image.RegistryPromoterName {
+		return registry.NewLabelsResolver(&http.Client{}, registry.DefaultScheme), nil
+	}
+
+	dockerClient, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return docker.NewDockerLabelsResolver(dockerClient), nil
+}
+
 func (e *Entrypoint) createSemanticVersionFactory() (*semver.SemVerGenerator, error) {
 	return semver.NewSemVerGenerator(), nil
 }
@@ -9,17 +9,21 @@ import (
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	handler "github.com/gostevedore/stevedore/internal/handler/promote"
+	authexpiration "github.com/gostevedore/stevedore/internal/infrastructure/auth/expiration"
+	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
 	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/console"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/dryrun"
 	"github.com/gostevedore/stevedore/internal/infrastructure/promote/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/promote/registry"
 	defaultreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/default"
 	dockerreferencename "github.com/gostevedore/stevedore/internal/infrastructure/reference/image/docker"
 	"github.com/gostevedore/stevedore/internal/infrastructure/semver"
 	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
 	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -135,6 +139,39 @@ func TestPrepareHandlerOptions(t *testing.T) {
 				RemoteSourceImage:            true,
 			},
 		},
+		{
+			desc:       "Testing prepare handler options to promote an images definition",
+			entrypoint: &Entrypoint{},
+			args:       []string{},
+			err:        &errors.Error{},
+			configuration: &configuration.Configuration{
+				EnableSemanticVersionTags:    true,
+				SemanticVersionTagsTemplates: []string{"template"},
+			},
+			handlerOptions: &handler.Options{
+				CascadeDepth:                 2,
+				Filter:                       []string{"namespace=stable"},
+				ImageDefinitionName:          "image",
+				ImageDefinitionVersions:      []string{"1.2.3"},
+				PromoteOnCascade:             true,
+				TargetImageName:              "-",
+				TargetImageRegistryNamespace: "target_image_regsitry_namespace",
+				TargetImageRegistryHost:      "target_image_registry_host",
+			},
+			res: &handler.Options{
+				CascadeDepth:                 2,
+				EnableSemanticVersionTags:    true,
+				Filter:                       []string{"namespace=stable"},
+				ImageDefinitionName:          "image",
+				ImageDefinitionVersions:      []string{"1.2.3"},
+				PromoteOnCascade:             true,
+				TargetImageName:              "-",
+				TargetImageRegistryNamespace: "target_image_regsitry_namespace",
+				TargetImageRegistryHost:      "target_image_registry_host",
+				TargetImageTags:              []string{},
+				SemanticVersionTagsTemplates: []string{},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -151,6 +188,76 @@ func TestPrepareHandlerOptions(t *testing.T) {
 	}
 }
 
+func TestPrepareEntrypointOptions(t *testing.T) {
+	errContext := "(promote::entrypoint::prepareEntrypointOptions)"
+
+	tests := []struct {
+		desc          string
+		entrypoint    *Entrypoint
+		configuration *configuration.Configuration
+		options       *Options
+		res           *Options
+		err           error
+	}{
+		{
+			desc:       "Testing error preparing entrypoint options when configuration is not provided",
+			entrypoint: NewEntrypoint(),
+			options:    &Options{},
+			err:        errors.New(errContext, "To prepare promote entrypoint options, configuration is required"),
+		},
+		{
+			desc:          "Testing error preparing entrypoint options when options are not provided",
+			entrypoint:    NewEntrypoint(),
+			configuration: &configuration.Configuration{},
+			err:           errors.New(errContext, "To prepare promote entrypoint options, entrypoint options are required"),
+		},
+		{
+			desc:       "Testing prepare entrypoint options using concurrency from configuration",
+			entrypoint: NewEntrypoint(),
+			configuration: &configuration.Configuration{
+				Concurrency: 4,
+			},
+			options: &Options{
+				UseDockerNormalizedName: true,
+			},
+			res: &Options{
+				Concurrency:             4,
+				UseDockerNormalizedName: true,
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing prepare entrypoint options on dry run",
+			entrypoint: NewEntrypoint(),
+			configuration: &configuration.Configuration{
+				Concurrency: 4,
+			},
+			options: &Options{
+				Concurrency: 8,
+				DryRun:      true,
+			},
+			res: &Options{
+				Concurrency: dryRunConcurreny,
+				DryRun:      true,
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.entrypoint.prepareEntrypointOptions(test.configuration, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, options)
+			}
+		})
+	}
+}
+
 func TestCreateCredentialsStore(t *testing.T) {
 
 	errContext := "(promote::entrypoint::createCredentialsStore)"
@@ -168,32 +275,37 @@ func TestCreateCredentialsStore(t *testing.T) {
 			err:        errors.New(errContext, "To create credentials store in the promote entrypoint, credentials configuration is required"),
 		},
 		{
-			desc:       "Testing error creating local credentials store in the promote entrypoint when format is undefined in credentials local store",
-			entrypoint: NewEntrypoint(),
+			desc: "Testing error creating local credentials store in the promote entrypoint when format is undefined in credentials local store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
 			conf: &configuration.CredentialsConfiguration{
 				StorageType: credentials.LocalStore,
 			},
-			err: errors.New(errContext, "To create credentials store in the entrypoint, credentials format must be specified"),
+			err: errors.New(errContext, "To create the credentials store, credentials format must be defined"),
 		},
 		{
-			desc:       "Testing error creating local credentials store in the promote entrypoint when compatibilitier is undefined in the entrypoint",
-			entrypoint: NewEntrypoint(),
+			desc: "Testing error creating local credentials store in the promote entrypoint when compatibilitier is undefined in the entrypoint",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+			),
 			conf: &configuration.CredentialsConfiguration{
 				StorageType: credentials.LocalStore,
 				Format:      credentials.JSONFormat,
 			},
-			err: errors.New(errContext, "To create credentials store in the promote entrypoint, compatibility is required"),
+			err: errors.New(errContext, "To create the credentials local store, compatibility is required"),
 		},
 		{
 			desc: "Testing error creating local credentials store in the promote entrypoint when local storage is used and local storage path is undefined",
 			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
 			conf: &configuration.CredentialsConfiguration{
 				StorageType: credentials.LocalStore,
 				Format:      credentials.JSONFormat,
 			},
-			err: errors.New(errContext, "To create credentials store in the promote entrypoint, local storage path is required"),
+			err: errors.New(errContext, "To create the credentials local store, local storage path is required"),
 		},
 		{
 			desc: "Testing error creating local credentials store in the promote entrypoint when storage type is not supported in credentials local store",
@@ -209,6 +321,7 @@ func TestCreateCredentialsStore(t *testing.T) {
 		{
 			desc: "Testing create local credentials store in the promote entrypoint",
 			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
 				WithCompatibility(compatibility.NewMockCompatibility()),
 			),
 			conf: &configuration.CredentialsConfiguration{
@@ -229,6 +342,23 @@ func TestCreateCredentialsStore(t *testing.T) {
 			res: &credentialsenvvarsstore.EnvvarsStore{},
 			err: &errors.Error{},
 		},
+		{
+			desc:       "Testing create Vault credentials store in the promote entrypoint",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+			err: &errors.Error{},
+		},
 	}
 
 	for _, test := range tests {
@@ -326,6 +456,79 @@ func TestCreateAuthFactory(t *testing.T) {
 	}
 }
 
+func TestCreateCredentialsExpirationWarner(t *testing.T) {
+	errContext := "(entrypoint::promote::createCredentialsExpirationWarner)"
+
+	tests := []struct {
+		desc               string
+		entrypoint         *Entrypoint
+		conf               *configuration.CredentialsConfiguration
+		credentialsFactory repository.AuthFactorier
+		res                *authexpiration.ExpirationWarner
+		err                error
+	}{
+		{
+			desc:               "Testing error creating credentials expiration warner in promote entrypoint when credentials configuration is not defined",
+			entrypoint:         NewEntrypoint(WithWriter(console.NewMockConsole())),
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			err:                errors.New(errContext, "To create the credentials expiration warner in promote entrypoint, credentials configuration is required"),
+		},
+		{
+			desc:               "Testing create credentials expiration warner in promote entrypoint using the default window",
+			entrypoint:         NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf:               &configuration.CredentialsConfiguration{},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			res:                &authexpiration.ExpirationWarner{},
+		},
+		{
+			desc:       "Testing create credentials expiration warner in promote entrypoint using the configured window",
+			entrypoint: NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf: &configuration.CredentialsConfiguration{
+				ExpirationWarningWindow: "14d",
+			},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			res:                &authexpiration.ExpirationWarner{},
+		},
+		{
+			desc:       "Testing create no credentials expiration warner in promote entrypoint when the warnings are disabled",
+			entrypoint: NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf: &configuration.CredentialsConfiguration{
+				ExpirationWarningWindow: "0",
+			},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			res:                nil,
+		},
+		{
+			desc:       "Testing error creating credentials expiration warner in promote entrypoint when the window is not valid",
+			entrypoint: NewEntrypoint(WithWriter(console.NewMockConsole())),
+			conf: &configuration.CredentialsConfiguration{
+				ExpirationWarningWindow: "soon",
+			},
+			credentialsFactory: authfactory.NewMockAuthFactory(),
+			err: errors.New(errContext, "Invalid credentials 'expiration_warning_window' value",
+				errors.New("(core::domain::credentials::ParseDuration)", "Invalid duration 'soon'")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			warner, err := test.entrypoint.createCredentialsExpirationWarner(test.conf, test.credentialsFactory)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				if test.res == nil {
+					assert.Nil(t, warner)
+				} else {
+					assert.IsType(t, test.res, warner)
+				}
+			}
+		})
+	}
+}
+
 func TestCreatePromoteFactory(t *testing.T) {
 
 	var err error
@@ -351,6 +554,12 @@ func TestCreatePromoteFactory(t *testing.T) {
 		assert.Nil(t, err)
 		assert.IsType(t, &dryrun.DryRunPromote{}, promoteRepoDryRun)
 	})
+
+	t.Run("Testing create promote factory and registry promote repository is returned in the promote entrypoint", func(t *testing.T) {
+		promoteRepoRegistry, err := promoteRepoFactory.Get(image.RegistryPromoterName)
+		assert.Nil(t, err)
+		assert.IsType(t, &registry.RegistryPromote{}, promoteRepoRegistry)
+	})
 }
 
 func TestCreateSemanticVersionFactory(t *testing.T) {
@@ -403,3 +612,87 @@ func TestCreateReferenceName(t *testing.T) {
 		})
 	}
 }
+
+func TestCreatePromotionPolicy(t *testing.T) {
+	errContext := "(promote::entrypoint::createPromotionPolicy)"
+
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "configured-policy.yaml", []byte(`
+rules:
+  - name: configured
+    sources: ["staging/*"]
+    targets: ["stable/*"]
+`), 0644)
+	_ = afero.WriteFile(fs, "override-policy.yaml", []byte(`
+rules:
+  - name: override
+    sources: ["qa/*"]
+    targets: ["staging/*"]
+`), 0644)
+
+	tests := []struct {
+		desc          string
+		entrypoint    *Entrypoint
+		configuration *configuration.Configuration
+		options       *Options
+		res           []string
+		err           error
+	}{
+		{
+			desc:          "Testing create no promotion policy when no policy path is defined",
+			entrypoint:    NewEntrypoint(WithFileSystem(fs)),
+			configuration: &configuration.Configuration{},
+			options:       &Options{},
+			err:           &errors.Error{},
+		},
+		{
+			desc:       "Testing create promotion policy from configuration",
+			entrypoint: NewEntrypoint(WithFileSystem(fs)),
+			configuration: &configuration.Configuration{
+				PromotionPolicyPath: "configured-policy.yaml",
+			},
+			options: &Options{},
+			res:     []string{"configured"},
+			err:     &errors.Error{},
+		},
+		{
+			desc:       "Testing create promotion policy overriding the configuration policy path",
+			entrypoint: NewEntrypoint(WithFileSystem(fs)),
+			configuration: &configuration.Configuration{
+				PromotionPolicyPath: "configured-policy.yaml",
+			},
+			options: &Options{
+				PromotionPolicyPath: "override-policy.yaml",
+			},
+			res: []string{"override"},
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing error creating promotion policy when configuration is not provided",
+			entrypoint: NewEntrypoint(WithFileSystem(fs)),
+			options:    &Options{},
+			err:        errors.New(errContext, "To create the promotion policy in the promote entrypoint, configuration is required"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			policy, err := test.entrypoint.createPromotionPolicy(test.configuration, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				if test.res == nil {
+					assert.Nil(t, policy)
+				} else {
+					res := []string{}
+					for _, rule := range policy.Rules {
+						res = append(res, rule.Name)
+					}
+					assert.Equal(t, test.res, res)
+				}
+			}
+		})
+	}
+}
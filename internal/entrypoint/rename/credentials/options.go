@@ -0,0 +1,7 @@
+package credentials
+
+// Options is the options for the rename credentials command entrypoint
+type Options struct {
+	// LocalStoragePath is the location of local storage
+	LocalStoragePath string
+}
@@ -0,0 +1,32 @@
+package rotateencryptionkey
+
+import (
+	"io"
+)
+
+// Consoler is the interface to write messages and read secrets from the console
+type Consoler interface {
+	io.Writer
+	ConsoleWriter
+	PasswordReader
+}
+
+// ConsoleWriter is the interface to write messages to the console
+type ConsoleWriter interface {
+	Info(msg ...interface{})
+	Warn(msg ...interface{})
+	Error(msg ...interface{})
+	Debug(msg ...interface{})
+}
+
+// PasswordReader is the interface to read secrets from the console
+type PasswordReader interface {
+	ReadPassword(prompt string) (string, error)
+}
+
+// Compatibilitier is the interface for the compatibility checker
+type Compatibilitier interface {
+	AddDeprecated(deprecated ...string)
+	AddRemoved(removed ...string)
+	AddChanged(changed ...string)
+}
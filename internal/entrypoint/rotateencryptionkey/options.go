@@ -0,0 +1,15 @@
+package rotateencryptionkey
+
+// Options is the options for the rotate encryption key command entrypoint
+type Options struct {
+	// AskEncryptionKey is true if the new encryption key should be asked
+	AskEncryptionKey bool
+	// EncryptionKey is the new encryption key. A new key is generated when it is not provided
+	EncryptionKey string
+	// KeepEncryptionKey is true to re-encrypt the credentials using the current encryption key, migrating them to the current encryption format
+	KeepEncryptionKey bool
+	// KDF is the key derivation function used to encrypt the credentials
+	KDF string
+	// LocalStoragePath is the location of local storage
+	LocalStoragePath string
+}
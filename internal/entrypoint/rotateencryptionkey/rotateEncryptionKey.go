@@ -0,0 +1,305 @@
+package rotateencryptionkey
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/rotateencryptionkey"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/rotateencryptionkey"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	configurationfile "github.com/gostevedore/stevedore/internal/infrastructure/configuration/output/file"
+	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+const (
+	getEncryptionKeyInputMessage = "New Encryption Key: "
+	// encryptionKeyEnvvar is the environment variable that overrides the encryption key defined on the configuration file
+	encryptionKeyEnvvar = "STEVEDORE_CREDENTIALS_ENCRYPTION_KEY"
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the rotate encryption key command
+type Entrypoint struct {
+	console       Consoler
+	compatibility Compatibilitier
+	fs            afero.Fs
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithConsole sets the console for the entrypoint
+func WithConsole(console Consoler) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.console = console
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+	var key string
+	var store repository.CredentialsRekeyer
+	var configurationWriter application.EncryptionKeyWriter
+
+	errContext := "(rotateencryptionkey::entrypoint::Execute)"
+
+	if e.console == nil {
+		return errors.New(errContext, "To execute the rotate encryption key entrypoint, a console is required")
+	}
+
+	conf, err = e.prepareConfiguration(conf, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	key, err = e.prepareEncryptionKey(conf.Credentials, options)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	encryption := credentialsstoreencryption.NewEncryption(
+		credentialsstoreencryption.WithKey(key),
+		credentialsstoreencryption.WithKDF(options.KDF),
+	)
+
+	appOptions := []application.OptionsFunc{
+		application.WithCredentialsStore(store),
+		application.WithEncryption(encryption),
+	}
+
+	if !options.KeepEncryptionKey {
+		configurationWriter, err = e.createConfigurationWriter(conf)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+	if configurationWriter != nil {
+		appOptions = append(appOptions, application.WithConfiguration(configurationWriter))
+	}
+
+	h := handler.NewHandler(
+		handler.WithApplication(application.NewApplication(appOptions...)),
+	)
+
+	err = h.Handler(ctx, &handler.Options{
+		EncryptionKey: key,
+	})
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if options.KeepEncryptionKey {
+		e.console.Info("Credentials successfully migrated to the current encryption format")
+		return nil
+	}
+
+	switch {
+	case configurationWriter == nil:
+		e.console.Warn("The new encryption key could not be written on the configuration file. You must set it as the credentials encryption key:")
+		e.console.Warn(fmt.Sprintf(" %s", key))
+	case conf.Credentials.EncryptionKeyFile != "":
+		e.console.Info(fmt.Sprintf("Credentials encryption key updated on the encryption key file '%s'", conf.Credentials.EncryptionKeyFile))
+	default:
+		file, profile := encryptionKeyConfigurationFile(conf)
+		if profile != "" {
+			e.console.Info(fmt.Sprintf("Credentials encryption key updated on the profile '%s' of the configuration file '%s'", profile, file))
+		} else {
+			e.console.Info(fmt.Sprintf("Credentials encryption key updated on the configuration file '%s'", file))
+		}
+	}
+
+	e.console.Info("Credentials encryption key successfully rotated")
+
+	return nil
+}
+
+func (e *Entrypoint) prepareConfiguration(conf *configuration.Configuration, options *Options) (*configuration.Configuration, error) {
+
+	errContext := "(rotateencryptionkey::entrypoint::prepareConfiguration)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to prepare configuration")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "Configuration must be provided to prepare configuration")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "Configuration credentials must be provided to prepare configuration")
+	}
+
+	if conf.Credentials.StorageType == credentials.LocalStore && options.LocalStoragePath != "" {
+		conf.Credentials.LocalStoragePath = options.LocalStoragePath
+	}
+
+	_, err := conf.Credentials.ResolveEncryptionKey(e.fs)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return conf, nil
+}
+
+// prepareEncryptionKey returns the new encryption key. It is asked on the console when it is required, otherwise a new key is generated when it is not provided. When the encryption key is kept, the current one is returned
+func (e *Entrypoint) prepareEncryptionKey(conf *configuration.CredentialsConfiguration, options *Options) (string, error) {
+	var err error
+	var key string
+
+	errContext := "(rotateencryptionkey::entrypoint::prepareEncryptionKey)"
+
+	if options.KeepEncryptionKey {
+		if conf.EncryptionKey == "" {
+			return "", errors.New(errContext, "To migrate the credentials encryption format, the current encryption key is required")
+		}
+
+		return conf.EncryptionKey, nil
+	}
+
+	key = options.EncryptionKey
+
+	if options.AskEncryptionKey {
+		key, err = e.console.ReadPassword(getEncryptionKeyInputMessage)
+		if err != nil {
+			return "", errors.New(errContext, "Error reading the new encryption key", err)
+		}
+		fmt.Fprintln(e.console)
+	}
+
+	if key == "" {
+		key, err = credentialsstoreencryption.NewEncryption().GenerateEncryptionKey()
+		if err != nil {
+			return "", errors.New(errContext, "", err)
+		}
+	}
+
+	if key == conf.EncryptionKey {
+		return "", errors.New(errContext, "The new encryption key must be different from the current one")
+	}
+
+	return key, nil
+}
+
+// createConfigurationWriter returns the writer to persist the new encryption key where the current one is defined, either on the encryption key file or on the configuration file and profile that define it. It returns nil when there is no place to persist it, because the encryption key is defined by an environment variable or a command, or because there is no configuration file. It fails when the configuration file can not be written, so the credentials are not encrypted with a key that would be lost
+func (e *Entrypoint) createConfigurationWriter(conf *configuration.Configuration) (application.EncryptionKeyWriter, error) {
+
+	errContext := "(rotateencryptionkey::entrypoint::createConfigurationWriter)"
+
+	if e.fs == nil {
+		return nil, nil
+	}
+
+	if os.Getenv(encryptionKeyEnvvar) != "" {
+		return nil, nil
+	}
+
+	if conf.Credentials.EncryptionKeyFile != "" {
+		return configurationfile.NewEncryptionKeyFileSourcePersist(
+			configurationfile.WithFileSystem(e.fs),
+			configurationfile.WithFilePath(conf.Credentials.EncryptionKeyFile),
+		), nil
+	}
+
+	if conf.Credentials.EncryptionKeyEnv != "" || conf.Credentials.EncryptionKeyCommand != "" {
+		return nil, nil
+	}
+
+	file, profile := encryptionKeyConfigurationFile(conf)
+	if file == "" {
+		return nil, nil
+	}
+
+	writer := configurationfile.NewEncryptionKeyFilePersist(
+		configurationfile.WithFileSystem(e.fs),
+		configurationfile.WithFilePath(file),
+		configurationfile.WithProfile(profile),
+	)
+
+	err := writer.Check()
+	if err != nil {
+		return nil, errors.New(errContext, "The encryption key can not be rotated because the new one can not be written where the current one is defined", err)
+	}
+
+	return writer, nil
+}
+
+// encryptionKeyConfigurationFile returns the configuration file and the profile that define the encryption key. When the encryption key is not defined on any configuration file, it returns the configuration file with the highest precedence
+func encryptionKeyConfigurationFile(conf *configuration.Configuration) (string, string) {
+
+	origin := conf.ValueOrigin(configuration.CredentialsKey, configuration.CredentialsEncryptionKeyKey)
+	if origin != nil && origin.File != "" {
+		return origin.File, origin.Profile
+	}
+
+	return conf.ConfigFileUsed(), ""
+}
+
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsRekeyer, error) {
+	errContext := "(rotateencryptionkey::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the rotate encryption key entrypoint, credentials configuration is required")
+	}
+
+	if conf.StorageType == credentials.EnvvarsStore && conf.EncryptionKey == "" {
+		return nil, errors.New(errContext, "To create credentials store in the rotate encryption key entrypoint, the current encryption key is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.console),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	rekeyer, isRekeyer := store.(repository.CredentialsRekeyer)
+	if !isRekeyer {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to rotate the encryption key", conf.StorageType))
+	}
+
+	return rekeyer, nil
+}
@@ -0,0 +1,498 @@
+package rotateencryptionkey
+
+import (
+	"context"
+	"io"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration/loader"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(rotateencryptionkey::entrypoint::Execute)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	credential := `{"id":"registry.example.com","username":"username","password":"password"}`
+	currentEncryption := encryption.NewEncryption(encryption.WithKey("current-key"))
+	newEncryption := encryption.NewEncryption(encryption.WithKey("new-key"))
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		conf              *configuration.Configuration
+		options           *Options
+		prepareAssertFunc func(*Entrypoint)
+		assertFunc        func(*testing.T, *Entrypoint)
+		err               error
+	}{
+		{
+			desc:       "Testing error executing rotate encryption key entrypoint without console",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To execute the rotate encryption key entrypoint, a console is required"),
+		},
+		{
+			desc: "Testing error executing rotate encryption key entrypoint with the current key",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+					EncryptionKey:    "current-key",
+				},
+			},
+			options: &Options{
+				EncryptionKey: "current-key",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(rotateencryptionkey::entrypoint::prepareEncryptionKey)", "The new encryption key must be different from the current one")),
+		},
+		{
+			desc: "Testing execute rotate encryption key entrypoint on local store",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+					EncryptionKey:    "current-key",
+				},
+			},
+			options: &Options{
+				EncryptionKey: "new-key",
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				content, _ := currentEncryption.Encrypt(credential)
+				_ = afero.WriteFile(e.fs, filepath.Join("/credentials", hashedID), []byte(content), 0600)
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				data, _ := afero.ReadFile(e.fs, filepath.Join("/credentials", hashedID))
+				content, err := newEncryption.Decrypt(string(data))
+				assert.NoError(t, err)
+				assert.Equal(t, credential, content)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing execute rotate encryption key entrypoint writing the new key on the encryption key file",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:       credentials.LocalStore,
+					LocalStoragePath:  "/credentials",
+					Format:            credentials.JSONFormat,
+					EncryptionKey:     "current-key",
+					EncryptionKeyFile: "/secrets/encryption.key",
+				},
+			},
+			options: &Options{
+				EncryptionKey: "new-key",
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				content, _ := currentEncryption.Encrypt(credential)
+				_ = afero.WriteFile(e.fs, filepath.Join("/credentials", hashedID), []byte(content), 0600)
+				_ = afero.WriteFile(e.fs, "/secrets/encryption.key", []byte("current-key\n"), 0600)
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				key, _ := afero.ReadFile(e.fs, "/secrets/encryption.key")
+				assert.Equal(t, "new-key\n", string(key))
+
+				data, _ := afero.ReadFile(e.fs, filepath.Join("/credentials", hashedID))
+				content, err := newEncryption.Decrypt(string(data))
+				assert.NoError(t, err)
+				assert.Equal(t, credential, content)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing execute migrate encryption format on local store",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+					EncryptionKey:    "key",
+				},
+			},
+			options: &Options{
+				KeepEncryptionKey: true,
+				KDF:               encryption.Argon2idKDF,
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				// legacy format content encrypted using the key 'key'
+				_ = afero.WriteFile(e.fs, filepath.Join("/credentials", hashedID), []byte("e50990a67be331277dc50b5dcfdd630eef07be89b070d1b5e2ee3091454ab26153811c2ad5"), 0600)
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				data, _ := afero.ReadFile(e.fs, filepath.Join("/credentials", hashedID))
+				assert.False(t, encryption.IsLegacy(string(data)))
+				assert.Contains(t, string(data), ":argon2id:")
+
+				content, err := encryption.NewEncryption(encryption.WithKey("key")).Decrypt(string(data))
+				assert.NoError(t, err)
+				assert.Equal(t, "plaintext", content)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			err := test.entrypoint.Execute(context.TODO(), []string{}, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.entrypoint)
+			}
+		})
+	}
+}
+
+func TestPrepareEncryptionKey(t *testing.T) {
+
+	errContext := "(rotateencryptionkey::entrypoint::prepareEncryptionKey)"
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		conf              *configuration.CredentialsConfiguration
+		options           *Options
+		prepareAssertFunc func(*Entrypoint)
+		assertFunc        func(*testing.T, string)
+		err               error
+	}{
+		{
+			desc:       "Testing prepare the encryption key provided by options",
+			entrypoint: NewEntrypoint(),
+			conf:       &configuration.CredentialsConfiguration{EncryptionKey: "current-key"},
+			options:    &Options{EncryptionKey: "new-key"},
+			assertFunc: func(t *testing.T, key string) {
+				assert.Equal(t, "new-key", key)
+			},
+		},
+		{
+			desc:       "Testing prepare a generated encryption key",
+			entrypoint: NewEntrypoint(),
+			conf:       &configuration.CredentialsConfiguration{EncryptionKey: "current-key"},
+			options:    &Options{},
+			assertFunc: func(t *testing.T, key string) {
+				assert.Len(t, key, 32)
+			},
+		},
+		{
+			desc: "Testing prepare the encryption key asked on the console",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			conf:    &configuration.CredentialsConfiguration{EncryptionKey: "current-key"},
+			options: &Options{AskEncryptionKey: true},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getEncryptionKeyInputMessage).Return("asked-key", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			assertFunc: func(t *testing.T, key string) {
+				assert.Equal(t, "asked-key", key)
+			},
+		},
+		{
+			desc:       "Testing error preparing the current encryption key",
+			entrypoint: NewEntrypoint(),
+			conf:       &configuration.CredentialsConfiguration{EncryptionKey: "current-key"},
+			options:    &Options{EncryptionKey: "current-key"},
+			err:        errors.New(errContext, "The new encryption key must be different from the current one"),
+		},
+		{
+			desc:       "Testing prepare the current encryption key when it is kept",
+			entrypoint: NewEntrypoint(),
+			conf:       &configuration.CredentialsConfiguration{EncryptionKey: "current-key"},
+			options:    &Options{KeepEncryptionKey: true},
+			assertFunc: func(t *testing.T, key string) {
+				assert.Equal(t, "current-key", key)
+			},
+		},
+		{
+			desc:       "Testing error preparing the kept encryption key when there is no current one",
+			entrypoint: NewEntrypoint(),
+			conf:       &configuration.CredentialsConfiguration{},
+			options:    &Options{KeepEncryptionKey: true},
+			err:        errors.New(errContext, "To migrate the credentials encryption format, the current encryption key is required"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			key, err := test.entrypoint.prepareEncryptionKey(test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, key)
+			}
+		})
+	}
+}
+
+func TestCreateCredentialsStore(t *testing.T) {
+
+	errContext := "(rotateencryptionkey::entrypoint::createCredentialsStore)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.CredentialsConfiguration
+		res        interface{}
+		err        error
+	}{
+		{
+			desc: "Testing create local credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+			res: &credentialslocalstore.LocalStore{},
+		},
+		{
+			desc:       "Testing create envvars credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:   credentials.EnvvarsStore,
+				Format:        credentials.JSONFormat,
+				EncryptionKey: "current-key",
+			},
+			res: &credentialsenvvarsstore.EnvvarsStore{},
+		},
+		{
+			desc:       "Testing error creating envvars credentials store without the current encryption key",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.EnvvarsStore,
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "To create credentials store in the rotate encryption key entrypoint, the current encryption key is required"),
+		},
+		{
+			desc:       "Testing error creating a credentials store that does not support to rotate the encryption key",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			err: errors.New(errContext, "Credentials storage type 'vault' does not support to rotate the encryption key"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			store, err := test.entrypoint.createCredentialsStore(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, store)
+			}
+		})
+	}
+}
+
+func TestExecuteWithProfile(t *testing.T) {
+
+	errContext := "(rotateencryptionkey::entrypoint::Execute)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	credential := `{"id":"registry.example.com","username":"username","password":"password"}`
+	configFile := "/project/stevedore.yaml"
+	content := `credentials:
+  storage_type: local
+  local_storage_path: /credentials
+  format: json
+  encryption_key: top-level-key
+profiles:
+  ci:
+    credentials:
+      encryption_key: current-key
+`
+
+	tests := []struct {
+		desc              string
+		prepareAssertFunc func(afero.Fs)
+		assertFunc        func(*testing.T, afero.Fs)
+		err               error
+	}{
+		{
+			desc: "Testing execute rotate encryption key entrypoint writing the new key on the profile that defines the current one",
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				conf, err := configuration.LoadFromFile(fs, loader.NewConfigurationLoader(viper.New()), configFile, compatibility.NewMockCompatibility())
+				assert.NoError(t, err)
+				assert.Equal(t, "new-key", conf.Credentials.EncryptionKey)
+
+				data, _ := afero.ReadFile(fs, filepath.Join("/credentials", hashedID))
+				decrypted, err := encryption.NewEncryption(encryption.WithKey("new-key")).Decrypt(string(data))
+				assert.NoError(t, err)
+				assert.Equal(t, credential, decrypted)
+
+				file, _ := afero.ReadFile(fs, configFile)
+				assert.Contains(t, string(file), "encryption_key: top-level-key")
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error executing rotate encryption key entrypoint when the profile that defines the current key can not be written",
+			prepareAssertFunc: func(fs afero.Fs) {
+				_ = fs.Remove(configFile)
+			},
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				data, _ := afero.ReadFile(fs, filepath.Join("/credentials", hashedID))
+				decrypted, err := encryption.NewEncryption(encryption.WithKey("current-key")).Decrypt(string(data))
+				assert.NoError(t, err)
+				assert.Equal(t, credential, decrypted)
+			},
+			err: errors.New(errContext, "",
+				errors.New("(rotateencryptionkey::entrypoint::createConfigurationWriter)", "The encryption key can not be rotated because the new one can not be written where the current one is defined",
+					errors.New("(configuration::output::EncryptionKeyFilePersist::Check)", "",
+						errors.New("(configuration::output::EncryptionKeyFilePersist::load)", "Configuration file '/project/stevedore.yaml' could not be found",
+							errors.New("", "open /project/stevedore.yaml: file does not exist"))))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			t.Setenv(configuration.ProfileEnv, "ci")
+
+			fs := afero.NewMemMapFs()
+			_ = afero.WriteFile(fs, configFile, []byte(content), 0600)
+			encrypted, _ := encryption.NewEncryption(encryption.WithKey("current-key")).Encrypt(credential)
+			_ = afero.WriteFile(fs, filepath.Join("/credentials", hashedID), []byte(encrypted), 0600)
+
+			conf, err := configuration.LoadFromFile(fs, loader.NewConfigurationLoader(viper.New()), configFile, compatibility.NewMockCompatibility())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(fs)
+			}
+
+			entrypoint := NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(fs),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			)
+
+			err = entrypoint.Execute(context.TODO(), []string{}, conf, &Options{EncryptionKey: "new-key"})
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.err, &errors.Error{})
+			}
+			test.assertFunc(t, fs)
+		})
+	}
+}
+
+func TestExecuteWithMergedConfigurationFiles(t *testing.T) {
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	credential := `{"id":"registry.example.com","username":"username","password":"password"}`
+
+	user, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	userConfigFile := filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml")
+	projectConfigFile := "stevedore.yaml"
+
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, userConfigFile, []byte(`credentials:
+  storage_type: local
+  local_storage_path: /credentials
+  format: json
+  encryption_key: current-key
+`), 0600)
+	_ = afero.WriteFile(fs, projectConfigFile, []byte(`images_path: images
+`), 0600)
+	encrypted, _ := encryption.NewEncryption(encryption.WithKey("current-key")).Encrypt(credential)
+	_ = afero.WriteFile(fs, filepath.Join("/credentials", hashedID), []byte(encrypted), 0600)
+
+	conf, err := configuration.New(fs, loader.NewConfigurationLoader(viper.New()), compatibility.NewMockCompatibility())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, projectConfigFile, conf.ConfigFileUsed())
+
+	entrypoint := NewEntrypoint(
+		WithConsole(console.NewConsole(io.Discard, nil)),
+		WithFileSystem(fs),
+		WithCompatibility(compatibility.NewMockCompatibility()),
+	)
+
+	err = entrypoint.Execute(context.TODO(), []string{}, conf, &Options{EncryptionKey: "new-key"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	userConfig, _ := afero.ReadFile(fs, userConfigFile)
+	assert.Contains(t, string(userConfig), "encryption_key: new-key")
+	projectConfig, _ := afero.ReadFile(fs, projectConfigFile)
+	assert.Equal(t, "images_path: images\n", string(projectConfig))
+
+	data, _ := afero.ReadFile(fs, filepath.Join("/credentials", hashedID))
+	decrypted, err := encryption.NewEncryption(encryption.WithKey("new-key")).Decrypt(string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, credential, decrypted)
+}
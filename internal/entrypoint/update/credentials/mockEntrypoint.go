@@ -0,0 +1,25 @@
+package credentials
+
+import (
+	"context"
+
+	handler "github.com/gostevedore/stevedore/internal/handler/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEntrypoint is a mock of the update credentials entrypoint
+type MockEntrypoint struct {
+	mock.Mock
+}
+
+// NewMockEntrypoint provides a mock of the update credentials entrypoint
+func NewMockEntrypoint() *MockEntrypoint {
+	return &MockEntrypoint{}
+}
+
+// Execute provides a mock function
+func (e *MockEntrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, inputEntrypointOptions *Options, inputHandlerOptions *handler.Options) error {
+	res := e.Called(ctx, args, conf, inputEntrypointOptions, inputHandlerOptions)
+	return res.Error(0)
+}
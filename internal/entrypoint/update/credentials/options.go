@@ -0,0 +1,13 @@
+package credentials
+
+// Options is the options for the update credentials command entrypoint
+type Options struct {
+	// AskPassword is true if the password should be asked
+	AskPassword bool
+	// AskAWSSecretAccessKey is true if the AWS secret access key should be asked
+	AskAWSSecretAccessKey bool
+	// AskPrivateKeyPassword is true if a private key password should be asked
+	AskPrivateKeyPassword bool
+	// LocalStoragePath is the location of local storage
+	LocalStoragePath string
+}
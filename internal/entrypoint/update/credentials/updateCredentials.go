@@ -0,0 +1,235 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/update/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/spf13/afero"
+)
+
+const (
+	getPasswordInputMessage           = "Password: "
+	getAWSSecretAccessKeyInputMessage = "AWS Secret Access Key: "
+	getPrivateKeyPasswordInputMessage = "Private Key Password: "
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the update credentials command
+type Entrypoint struct {
+	console       Consoler
+	compatibility Compatibilitier
+	fs            afero.Fs
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithConsole sets the console for the entrypoint
+func WithConsole(console Consoler) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.console = console
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, inputEntrypointOptions *Options, inputHandlerOptions *handler.Options) error {
+	var err error
+	var handlerOptions *handler.Options
+	var store repository.CredentialsStorer
+
+	errContext := "(update::credentials::entrypoint::Execute)"
+
+	if len(args) < 1 {
+		return errors.New(errContext, "To execute the update credentials entrypoint, an argument with credential id is required")
+	}
+
+	id := args[0]
+	if len(args) > 1 && e.console != nil {
+		e.console.Warn(fmt.Sprintf("Ignoring extra arguments: %v", args[1:]))
+	}
+
+	handlerOptions, err = e.prepareHandlerOptions(inputEntrypointOptions, inputHandlerOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	conf, err = e.prepareConfiguration(conf, inputEntrypointOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	store, err = e.createCredentialsStore(conf.Credentials)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	app := application.NewApplication(
+		application.WithCredentialsStore(store),
+	)
+
+	h := handler.NewHandler(
+		handler.WithApplication(app),
+	)
+
+	err = h.Handler(ctx, id, handlerOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if e.console != nil {
+		e.console.Info(fmt.Sprintf("Credentials '%s' successfully updated", id))
+	}
+
+	return nil
+}
+
+// prepareHandlerOptions set handler options before execute the handler. The secrets are only requested when they are explicitly asked or required by the updated attributes
+func (e *Entrypoint) prepareHandlerOptions(inputEntrypointOptions *Options, inputHandlerOptions *handler.Options) (*handler.Options, error) {
+	var err error
+
+	errContext := "(update::credentials::entrypoint::prepareHandlerOptions)"
+
+	if inputHandlerOptions == nil {
+		return nil, errors.New(errContext, "Handler options must be provided to execute update credentials entrypoint")
+	}
+
+	if inputEntrypointOptions == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to execute update credentials entrypoint")
+	}
+
+	options := &handler.Options{}
+	*options = *inputHandlerOptions
+
+	if inputHandlerOptions.ExpiresAt != "" {
+		expiresAt, err := credentials.ParseExpiration(inputHandlerOptions.ExpiresAt, time.Now())
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+		options.ExpiresAt = expiresAt.UTC().Format(credentials.ExpirationLayout)
+	}
+
+	if inputEntrypointOptions.AskPassword {
+		options.Password, err = e.readSecret(getPasswordInputMessage)
+		if err != nil {
+			return nil, errors.New(errContext, "Error reading password", err)
+		}
+	}
+
+	if inputEntrypointOptions.AskAWSSecretAccessKey || inputHandlerOptions.AWSAccessKeyID != "" {
+		options.AWSSecretAccessKey, err = e.readSecret(getAWSSecretAccessKeyInputMessage)
+		if err != nil {
+			return nil, errors.New(errContext, "Error reading AWS secret access key", err)
+		}
+	}
+
+	if inputEntrypointOptions.AskPrivateKeyPassword {
+		options.PrivateKeyPassword, err = e.readSecret(getPrivateKeyPasswordInputMessage)
+		if err != nil {
+			return nil, errors.New(errContext, "Error reading private key password", err)
+		}
+	}
+
+	return options, nil
+}
+
+// readSecret asks for a secret on the console
+func (e *Entrypoint) readSecret(prompt string) (string, error) {
+
+	errContext := "(update::credentials::entrypoint::readSecret)"
+
+	if e.console == nil {
+		return "", errors.New(errContext, "Console must be provided to read secrets on the update credentials entrypoint")
+	}
+
+	secret, err := e.console.ReadPassword(prompt)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+	fmt.Fprintln(e.console)
+
+	return secret, nil
+}
+
+func (e *Entrypoint) prepareConfiguration(conf *configuration.Configuration, options *Options) (*configuration.Configuration, error) {
+
+	errContext := "(update::credentials::entrypoint::prepareConfiguration)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "Entrypoint options must be provided to prepare configuration")
+	}
+
+	if conf == nil {
+		return nil, errors.New(errContext, "Configuration must be provided to prepare configuration")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "Configuration credentials must be provided to prepare configuration")
+	}
+
+	if conf.Credentials.StorageType == credentials.LocalStore && options.LocalStoragePath != "" {
+		conf.Credentials.LocalStoragePath = options.LocalStoragePath
+	}
+
+	return conf, nil
+}
+
+func (e *Entrypoint) createCredentialsStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
+	errContext := "(update::credentials::entrypoint::createCredentialsStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create credentials store in the update credentials entrypoint, credentials configuration is required")
+	}
+
+	if conf.StorageType == credentials.DockerConfigStore {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials storage type '%s' does not support to update credentials", conf.StorageType))
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+		credentialsstorefactory.WithConsole(e.console),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
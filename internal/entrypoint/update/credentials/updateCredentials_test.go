@@ -0,0 +1,270 @@
+package credentials
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	handler "github.com/gostevedore/stevedore/internal/handler/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(update::credentials::entrypoint::Execute)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		args              []string
+		conf              *configuration.Configuration
+		entrypointOptions *Options
+		handlerOptions    *handler.Options
+		res               string
+		err               error
+	}{
+		{
+			desc:       "Testing error executing update credentials entrypoint without credential id",
+			entrypoint: NewEntrypoint(),
+			args:       []string{},
+			err:        errors.New(errContext, "To execute the update credentials entrypoint, an argument with credential id is required"),
+		},
+		{
+			desc: "Testing execute update credentials entrypoint on local store keeping the password",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewConsole(io.Discard, nil)),
+				WithFileSystem(testLocalStoreFs(hashedID)),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			args: []string{"registry.example.com"},
+			conf: &configuration.Configuration{
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType:      credentials.LocalStore,
+					LocalStoragePath: "/credentials",
+					Format:           credentials.JSONFormat,
+				},
+			},
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				Username: "new-username",
+			},
+			res: `{"ID":"registry.example.com","aws_access_key_id":"","aws_region":"","aws_role_arn":"","aws_secret_access_key":"","aws_profile":"","aws_shared_credentials_files":null,"aws_shared_config_files":null,"aws_use_default_credentials_chain":false,"credential_process":"","docker_login_password":"","docker_login_username":"","password":"password","username":"new-username","token":"","refresh_token":"","private_key_file":"","private_key_password":"","git_ssh_user":"","use_ssh_agent":false,"description":"","expires_at":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.entrypointOptions, test.handlerOptions)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				content, err := afero.ReadFile(test.entrypoint.fs, filepath.Join("/credentials", hashedID))
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.res, string(content))
+			}
+		})
+	}
+}
+
+func TestPrepareHandlerOptions(t *testing.T) {
+
+	errContext := "(update::credentials::entrypoint::prepareHandlerOptions)"
+
+	tests := []struct {
+		desc              string
+		entrypoint        *Entrypoint
+		entrypointOptions *Options
+		handlerOptions    *handler.Options
+		prepareAssertFunc func(*Entrypoint)
+		res               *handler.Options
+		err               error
+	}{
+		{
+			desc:              "Testing error preparing update credentials handler options without handler options",
+			entrypoint:        NewEntrypoint(),
+			entrypointOptions: &Options{},
+			err:               errors.New(errContext, "Handler options must be provided to execute update credentials entrypoint"),
+		},
+		{
+			desc:              "Testing prepare update credentials handler options without asking for secrets",
+			entrypoint:        NewEntrypoint(),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				Username: "username",
+			},
+			res: &handler.Options{
+				Username: "username",
+			},
+		},
+		{
+			desc: "Testing prepare update credentials handler options asking for the password",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			entrypointOptions: &Options{
+				AskPassword: true,
+			},
+			handlerOptions: &handler.Options{},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getPasswordInputMessage).Return("password", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			res: &handler.Options{
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing prepare update credentials handler options asking for the AWS secret access key when AWS access key id is updated",
+			entrypoint: NewEntrypoint(
+				WithConsole(console.NewMockConsole()),
+			),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				AWSAccessKeyID: "aws-access-key-id",
+			},
+			prepareAssertFunc: func(e *Entrypoint) {
+				e.console.(*console.MockConsole).On("ReadPassword", getAWSSecretAccessKeyInputMessage).Return("aws-secret-access-key", nil)
+				e.console.(*console.MockConsole).On("Write", []byte("\n")).Return(1, nil)
+			},
+			res: &handler.Options{
+				AWSAccessKeyID:     "aws-access-key-id",
+				AWSSecretAccessKey: "aws-secret-access-key",
+			},
+		},
+		{
+			desc:              "Testing prepare update credentials handler options normalizing the expiration",
+			entrypoint:        NewEntrypoint(),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				ExpiresAt: "2024-12-31",
+			},
+			res: &handler.Options{
+				ExpiresAt: "2024-12-31T00:00:00Z",
+			},
+		},
+		{
+			desc:              "Testing error preparing update credentials handler options with an invalid expiration",
+			entrypoint:        NewEntrypoint(),
+			entrypointOptions: &Options{},
+			handlerOptions: &handler.Options{
+				ExpiresAt: "someday",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(core::domain::credentials::ParseExpiration)", "Invalid expiration 'someday'. It must be a date, such as '2024-12-31' or '2024-12-31T00:00:00Z', or a duration, such as '90d'")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.entrypoint)
+			}
+
+			res, err := test.entrypoint.prepareHandlerOptions(test.entrypointOptions, test.handlerOptions)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestCreateCredentialsStore(t *testing.T) {
+
+	errContext := "(update::credentials::entrypoint::createCredentialsStore)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.CredentialsConfiguration
+		res        interface{}
+		err        error
+	}{
+		{
+			desc: "Testing create local credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				LocalStoragePath: "/credentials",
+				Format:           credentials.JSONFormat,
+			},
+			res: &credentialslocalstore.LocalStore{},
+		},
+		{
+			desc:       "Testing create envvars credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.EnvvarsStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsenvvarsstore.EnvvarsStore{},
+		},
+		{
+			desc:       "Testing create Vault credentials store",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+		},
+		{
+			desc:       "Testing error creating a credentials store that does not support to update credentials",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.DockerConfigStore,
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "Credentials storage type 'docker-config' does not support to update credentials"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			store, err := test.entrypoint.createCredentialsStore(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, store)
+			}
+		})
+	}
+}
+
+// testLocalStoreFs returns a file system with a local store that contains the credentials for the hashed id
+func testLocalStoreFs(hashedID string) afero.Fs {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, filepath.Join("/credentials", hashedID), []byte(`{"id":"registry.example.com","username":"username","password":"password"}`), 0600)
+
+	return fs
+}
@@ -0,0 +1,7 @@
+package validate
+
+// Options is the options for the validate command entrypoint
+type Options struct {
+	// Output is the format used to print the issues: table, json or yaml
+	Output string
+}
@@ -0,0 +1,183 @@
+package validate
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/validate"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	handler "github.com/gostevedore/stevedore/internal/handler/validate"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
+	outputvalidation "github.com/gostevedore/stevedore/internal/infrastructure/output/validation"
+	credentialsstorefactory "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/validator"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc defines the signature for an option function to set entrypoint attributes
+type OptionsFunc func(opts *Entrypoint)
+
+// Entrypoint defines the entrypoint for the validate command
+type Entrypoint struct {
+	writer        ConsoleWriter
+	fs            afero.Fs
+	compatibility Compatibilitier
+}
+
+// NewEntrypoint returns a new entrypoint
+func NewEntrypoint(opts ...OptionsFunc) *Entrypoint {
+	e := &Entrypoint{}
+	e.Options(opts...)
+
+	return e
+}
+
+// Options provides the options for the entrypoint
+func (e *Entrypoint) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(e)
+	}
+}
+
+// WithWriter sets the writer for the entrypoint
+func WithWriter(w ConsoleWriter) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.writer = w
+	}
+}
+
+// WithFileSystem sets the file system for the entrypoint
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility for the entrypoint
+func WithCompatibility(c Compatibilitier) OptionsFunc {
+	return func(e *Entrypoint) {
+		e.compatibility = c
+	}
+}
+
+// Execute is a pseudo-main method for the command
+func (e *Entrypoint) Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *Options) error {
+	var err error
+
+	errContext := "(validate::entrypoint::Execute)"
+
+	if e.writer == nil {
+		return errors.New(errContext, "To execute the validate entrypoint, a writer is required")
+	}
+
+	if e.fs == nil {
+		return errors.New(errContext, "To execute the validate entrypoint, a file system is required")
+	}
+
+	if conf == nil {
+		return errors.New(errContext, "To execute the validate entrypoint, configuration is required")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "To execute the validate entrypoint, options are required")
+	}
+
+	output := outputvalidation.NewOutput(
+		console.NewConsole(e.writer, nil),
+		outputvalidation.WithFormat(options.Output),
+	)
+
+	app := application.NewApplication(
+		application.WithValidator(validator.NewValidator(validator.WithFileSystem(e.fs))),
+		application.WithOutput(output),
+	)
+
+	h := handler.NewHandler(
+		handler.WithApplication(app),
+	)
+
+	definitionsSources, err := e.createDefinitionsSources(conf)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	buildersPaths, err := definitionsSources.Paths(ctx, conf.BuildersDefinitionsSources())
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	imagesPaths, err := definitionsSources.Paths(ctx, conf.ImagesDefinitionsSources())
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = h.Handler(ctx, &handler.Options{
+		ConfigurationFiles: conf.ConfigFilesUsed(),
+		BuildersPaths:      buildersPaths,
+		ImagesPaths:        imagesPaths,
+	})
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// createDefinitionsSources returns the component which resolves the local paths where the images and builders are defined, fetching the git sources into the cache folder. The git sources are authenticated through the credentials store, which is only created when any git source is defined
+func (e *Entrypoint) createDefinitionsSources(conf *configuration.Configuration) (*definitionssources.DefinitionsSources, error) {
+
+	errContext := "(validate::entrypoint::createDefinitionsSources)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the definitions sources in validate entrypoint, configuration is required")
+	}
+
+	options := []definitionssources.OptionsFunc{
+		definitionssources.WithCachePath(conf.GitSourcesCachePath),
+	}
+
+	if conf.HasGitDefinitionsSources() {
+		authFactory, err := e.createAuthFactory(conf)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		options = append(options, definitionssources.WithAuthFactory(gitauth.NewGitAuthFactory(authFactory)))
+	}
+
+	return definitionssources.NewDefinitionsSources(options...), nil
+}
+
+// createAuthFactory returns the auth factory which achieves the credentials to access the git definitions sources
+func (e *Entrypoint) createAuthFactory(conf *configuration.Configuration) (repository.AuthFactorier, error) {
+
+	errContext := "(validate::entrypoint::createAuthFactory)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the auth factory in validate entrypoint, configuration is required")
+	}
+
+	if conf.Credentials == nil {
+		return nil, errors.New(errContext, "To create the auth factory in validate entrypoint, credentials configuration is required")
+	}
+
+	credentialsFactory := credentialsstorefactory.NewCredentialsFactory(
+		credentialsstorefactory.WithFilesystem(e.fs),
+		credentialsstorefactory.WithCompatibility(e.compatibility),
+	)
+
+	store, err := credentialsFactory.CreateStore(conf.Credentials)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	authFactory, err := credentialsFactory.CreateAuthFactory(conf.Credentials, store)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return authFactory, nil
+}
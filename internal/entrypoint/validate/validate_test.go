@@ -0,0 +1,227 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	definitionssources "github.com/gostevedore/stevedore/internal/infrastructure/configuration/sources"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+
+	errContext := "(validate::entrypoint::Execute)"
+
+	output := &bytes.Buffer{}
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/builders.yaml", []byte(`
+builders:
+  infrastructure:
+    driver: docker
+`), 0644)
+	afero.WriteFile(fs, "/images.yaml", []byte(`
+images:
+  ubuntu:
+    "22.04":
+      builder: infrastructure
+`), 0644)
+	afero.WriteFile(fs, "/invalid/images.yaml", []byte(`
+images:
+  ubuntu:
+    "22.04":
+      persistant_vars:
+        key: value
+`), 0644)
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		args       []string
+		conf       *configuration.Configuration
+		options    *Options
+		assertFunc func(*testing.T, *Entrypoint)
+		err        error
+	}{
+		{
+			desc:       "Testing error executing validate entrypoint without writer",
+			entrypoint: NewEntrypoint(),
+			args:       []string{},
+			err:        errors.New(errContext, "To execute the validate entrypoint, a writer is required"),
+		},
+		{
+			desc: "Testing error executing validate entrypoint without file system",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+			),
+			args: []string{},
+			err:  errors.New(errContext, "To execute the validate entrypoint, a file system is required"),
+		},
+		{
+			desc: "Testing error executing validate entrypoint without configuration",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+				WithFileSystem(fs),
+			),
+			args: []string{},
+			err:  errors.New(errContext, "To execute the validate entrypoint, configuration is required"),
+		},
+		{
+			desc: "Testing execute validate entrypoint",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+				WithFileSystem(fs),
+			),
+			args: []string{},
+			conf: &configuration.Configuration{
+				BuildersPath: "/builders.yaml",
+				ImagesPath:   "/images.yaml",
+			},
+			options: &Options{
+				Output: "json",
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				assert.Equal(t, "[]\n", output.String())
+			},
+		},
+		{
+			desc: "Testing execute validate entrypoint with definitions sources",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+				WithFileSystem(fs),
+			),
+			args: []string{},
+			conf: &configuration.Configuration{
+				BuildersSources: []*configuration.DefinitionsSource{
+					{Path: "/builders.yaml"},
+				},
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Path: "/images.yaml"},
+				},
+			},
+			options: &Options{
+				Output: "json",
+			},
+			assertFunc: func(t *testing.T, e *Entrypoint) {
+				assert.Equal(t, "[]\n", output.String())
+			},
+		},
+		{
+			desc: "Testing error executing validate entrypoint with issues",
+			entrypoint: NewEntrypoint(
+				WithWriter(console.NewConsole(output, nil)),
+				WithFileSystem(fs),
+			),
+			args: []string{},
+			conf: &configuration.Configuration{
+				BuildersPath: "/builders.yaml",
+				ImagesPath:   "/invalid",
+			},
+			options: &Options{
+				Output: "json",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(validate::Handler)", "",
+					errors.New("(application::validate::Run)", "Validation failed, errors found: 1"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			output.Reset()
+
+			err := test.entrypoint.Execute(context.TODO(), test.args, test.conf, test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				test.assertFunc(t, test.entrypoint)
+			}
+		})
+	}
+}
+
+func TestCreateDefinitionsSources(t *testing.T) {
+	errContext := "(validate::entrypoint::createDefinitionsSources)"
+
+	tests := []struct {
+		desc       string
+		entrypoint *Entrypoint
+		conf       *configuration.Configuration
+		res        *definitionssources.DefinitionsSources
+		err        error
+	}{
+		{
+			desc:       "Testing error creating definitions sources in validate entrypoint when configuration is not defined",
+			entrypoint: NewEntrypoint(),
+			err:        errors.New(errContext, "To create the definitions sources in validate entrypoint, configuration is required"),
+		},
+		{
+			desc:       "Testing create definitions sources in validate entrypoint",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesPath:          "images",
+			},
+			res: definitionssources.NewDefinitionsSources(
+				definitionssources.WithCachePath("/cache/git"),
+			),
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing error creating definitions sources in validate entrypoint when git sources are defined and credentials configuration is not defined",
+			entrypoint: NewEntrypoint(),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Git: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git", CredentialsID: "github"}},
+				},
+			},
+			err: errors.New(errContext, "",
+				errors.New("(validate::entrypoint::createAuthFactory)", "To create the auth factory in validate entrypoint, credentials configuration is required")),
+		},
+		{
+			desc: "Testing create definitions sources in validate entrypoint authenticating the git sources through the credentials store",
+			entrypoint: NewEntrypoint(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.Configuration{
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*configuration.DefinitionsSource{
+					{Git: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git", CredentialsID: "github"}},
+				},
+				Credentials: &configuration.CredentialsConfiguration{
+					StorageType: credentials.EnvvarsStore,
+					Format:      credentials.JSONFormat,
+				},
+			},
+			res: &definitionssources.DefinitionsSources{},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			sources, err := test.entrypoint.createDefinitionsSources(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else if test.conf.HasGitDefinitionsSources() {
+				assert.IsType(t, test.res, sources)
+			} else {
+				assert.Equal(t, test.res, sources)
+			}
+		})
+	}
+}
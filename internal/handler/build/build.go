@@ -98,6 +98,7 @@ func (h *Handler) Handler(ctx context.Context, imageName string, options *Option
 		buildServiceOptions.PersistentVars[kPVar] = vPVar
 	}
 
+	buildServiceOptions.Force = options.Force
 	buildServiceOptions.PullParentImage = options.PullParentImage
 	buildServiceOptions.PushImageAfterBuild = options.PushImagesAfterBuild
 	buildServiceOptions.RemoveImagesAfterPush = options.RemoveImagesAfterPush
@@ -16,6 +16,8 @@ type Options struct {
 	CascadeDepth int
 	// EnableSemanticVersionTags if is true semantic version tags are generated
 	EnableSemanticVersionTags bool
+	// Force if is true the image tags are pushed even when they are immutable
+	Force bool
 	// ImageFromName is the name of the image to use as source
 	ImageFromName string
 	// ImageFromRegistryHost is the host of the registry to use as source
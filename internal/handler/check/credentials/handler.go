@@ -0,0 +1,77 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/check/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for check credentials commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for check credentials commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles check credentials commands
+func (h *Handler) Handler(ctx context.Context, ids []string, options *Options) error {
+	var err error
+
+	errContext := "(check::credentials::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Handler options must be provided")
+	}
+
+	appOptions := &application.Options{
+		Repository: options.Repository,
+	}
+
+	if options.Repository != "" {
+		appOptions.Scope = options.Scope
+		if appOptions.Scope == "" {
+			appOptions.Scope = credentials.CheckScopePull
+		}
+	}
+
+	if appOptions.Scope != "" && appOptions.Scope != credentials.CheckScopePull && appOptions.Scope != credentials.CheckScopePush {
+		return errors.New(errContext, fmt.Sprintf("Invalid scope '%s'. Supported scopes are: %s, %s", appOptions.Scope, credentials.CheckScopePull, credentials.CheckScopePush))
+	}
+
+	err = h.app.Run(ctx, ids, appOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/check/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(check::credentials::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		ids               []string
+		options           *Options
+		prepareAssertFunc func(*Handler)
+		err               error
+	}{
+		{
+			desc:    "Testing error running check credentials handler without application",
+			handler: NewHandler(),
+			ids:     []string{"id"},
+			options: &Options{},
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing error running check credentials handler with an invalid scope",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			ids: []string{"id"},
+			options: &Options{
+				Repository: "team/app",
+				Scope:      "delete",
+			},
+			err: errors.New(errContext, "Invalid scope 'delete'. Supported scopes are: pull, push"),
+		},
+		{
+			desc: "Testing run check credentials handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			ids:     []string{"id"},
+			options: &Options{},
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), []string{"id"}, &application.Options{}, mock.Anything).Return(nil)
+			},
+		},
+		{
+			desc: "Testing run check credentials handler checking the pull scope by default",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			ids: []string{"id"},
+			options: &Options{
+				Repository: "team/app",
+			},
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), []string{"id"}, &application.Options{
+					Repository: "team/app",
+					Scope:      credentials.CheckScopePull,
+				}, mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.ids, test.options)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, test.err)
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
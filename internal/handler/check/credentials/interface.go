@@ -0,0 +1,12 @@
+package credentials
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/check/credentials"
+)
+
+// Applicationer is the service for check credentials commands
+type Applicationer interface {
+	Run(ctx context.Context, ids []string, options *application.Options, optionsFunc ...application.OptionsFunc) error
+}
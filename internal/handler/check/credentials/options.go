@@ -0,0 +1,9 @@
+package credentials
+
+// Options are the options for the check credentials handler
+type Options struct {
+	// Repository is the repository, on each registry, whose access is checked
+	Repository string
+	// Scope is the access checked on the repository, either pull or push
+	Scope string
+}
@@ -72,6 +72,18 @@ func (h *CreateConfigurationHandler) Handler(ctx context.Context, options *Optio
 		config.Credentials.EncryptionKey = options.CredentialsEncryptionKey
 	}
 
+	if len(options.CredentialsEncryptionKeyFile) > 0 {
+		config.Credentials.EncryptionKeyFile = options.CredentialsEncryptionKeyFile
+	}
+
+	if len(options.CredentialsEncryptionKeyEnv) > 0 {
+		config.Credentials.EncryptionKeyEnv = options.CredentialsEncryptionKeyEnv
+	}
+
+	if len(options.CredentialsEncryptionKeyCommand) > 0 {
+		config.Credentials.EncryptionKeyCommand = options.CredentialsEncryptionKeyCommand
+	}
+
 	config.EnableSemanticVersionTags = options.EnableSemanticVersionTags
 
 	if len(options.ImagesPath) > 0 {
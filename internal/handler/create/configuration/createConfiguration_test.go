@@ -59,8 +59,62 @@ func TestHandler(t *testing.T) {
 							LocalStoragePath: "credentialslocalstoragepath",
 							StorageType:      "credentialsstoragetype",
 						},
-						EnableSemanticVersionTags:    true,
-						ImagesPath:                   "imagespath",
+						EnableSemanticVersionTags: true,
+						ImagesPath:                "imagespath",
+						ImmutableTags: &configuration.ImmutableTagsConfiguration{
+							Images:       []string{},
+							FloatingTags: []string{`^latest$`, `^\d+$`, `^\d+\.\d+$`},
+						},
+						LogPathFile:                  "logpathfile",
+						LogWriter:                    io.Discard,
+						PushImages:                   true,
+						SemanticVersionTagsTemplates: []string{"tmpl1"},
+					},
+					// application OptionsFunc
+					mock.AnythingOfType("[]configuration.OptionsFunc"),
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create configuration handler with encryption key file",
+			handler: NewCreateConfigurationHandler(
+				WithApplication(application.NewMockCreateConfigurationApplication()),
+			),
+			options: &Options{
+				BuildersPath:                 "builderspath",
+				Concurrency:                  10,
+				CredentialsEncryptionKey:     "credentialsencryptionkey",
+				CredentialsEncryptionKeyFile: "credentialsencryptionkeyfile",
+				CredentialsFormat:            "credentialsformat",
+				CredentialsLocalStoragePath:  "credentialslocalstoragepath",
+				CredentialsStorageType:       "credentialsstoragetype",
+				EnableSemanticVersionTags:    true,
+				ImagesPath:                   "imagespath",
+				LogPathFile:                  "logpathfile",
+				PushImages:                   true,
+				SemanticVersionTagsTemplates: []string{"tmpl1"},
+			},
+			prepareMockFunc: func(a Applicationer) {
+				a.(*application.MockCreateConfigurationApplication).On(
+					"Run",
+					context.TODO(),
+					&configuration.Configuration{
+						BuildersPath: "builderspath",
+						Concurrency:  10,
+						Credentials: &configuration.CredentialsConfiguration{
+							EncryptionKey:     "credentialsencryptionkey",
+							EncryptionKeyFile: "credentialsencryptionkeyfile",
+							Format:            "credentialsformat",
+							LocalStoragePath:  "credentialslocalstoragepath",
+							StorageType:       "credentialsstoragetype",
+						},
+						EnableSemanticVersionTags: true,
+						ImagesPath:                "imagespath",
+						ImmutableTags: &configuration.ImmutableTagsConfiguration{
+							Images:       []string{},
+							FloatingTags: []string{`^latest$`, `^\d+$`, `^\d+\.\d+$`},
+						},
 						LogPathFile:                  "logpathfile",
 						LogWriter:                    io.Discard,
 						PushImages:                   true,
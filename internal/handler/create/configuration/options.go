@@ -2,15 +2,18 @@ package configuration
 
 // Options for create configuration handler
 type Options struct {
-	BuildersPath                 string
-	Concurrency                  int
-	CredentialsFormat            string
-	CredentialsLocalStoragePath  string
-	CredentialsStorageType       string
-	CredentialsEncryptionKey     string
-	EnableSemanticVersionTags    bool
-	ImagesPath                   string
-	LogPathFile                  string
-	PushImages                   bool
-	SemanticVersionTagsTemplates []string
+	BuildersPath                    string
+	Concurrency                     int
+	CredentialsFormat               string
+	CredentialsLocalStoragePath     string
+	CredentialsStorageType          string
+	CredentialsEncryptionKey        string
+	CredentialsEncryptionKeyFile    string
+	CredentialsEncryptionKeyEnv     string
+	CredentialsEncryptionKeyCommand string
+	EnableSemanticVersionTags       bool
+	ImagesPath                      string
+	LogPathFile                     string
+	PushImages                      bool
+	SemanticVersionTagsTemplates    []string
 }
@@ -64,10 +64,15 @@ func createCredentialFromOptions(options *Options) *credentials.Credential {
 	credential.AWSSharedConfigFiles = append([]string{}, options.AWSSharedConfigFiles...)
 	credential.AWSSharedCredentialsFiles = append([]string{}, options.AWSSharedCredentialsFiles...)
 	credential.AWSUseDefaultCredentialsChain = options.AWSUseDefaultCredentialsChain
+	credential.CredentialProcess = options.CredentialProcess
+	credential.Description = options.Description
+	credential.ExpiresAt = options.ExpiresAt
 	credential.GitSSHUser = options.GitSSHUser
 	credential.Password = options.Password
 	credential.PrivateKeyFile = options.PrivateKeyFile
 	credential.PrivateKeyPassword = options.PrivateKeyPassword
+	credential.RefreshToken = options.RefreshToken
+	credential.Token = options.Token
 	credential.Username = options.Username
 
 	return credential
@@ -83,6 +83,7 @@ func TestCreateCredentialFromOptions(t *testing.T) {
 				AWSSharedConfigFiles:          []string{"AWSSharedConfigFiles"},
 				AWSSharedCredentialsFiles:     []string{"AWSSharedCredentialsFiles"},
 				AWSUseDefaultCredentialsChain: true,
+				CredentialProcess:             "CredentialProcess",
 				GitSSHUser:                    "GitSSHUser",
 				Password:                      "Password",
 				PrivateKeyFile:                "PrivateKeyFile",
@@ -104,6 +105,7 @@ func TestCreateCredentialFromOptions(t *testing.T) {
 				PrivateKeyPassword:            "PrivateKeyPassword",
 				GitSSHUser:                    "GitSSHUser",
 				AllowUseSSHAgent:              true,
+				CredentialProcess:             "CredentialProcess",
 			},
 		},
 	}
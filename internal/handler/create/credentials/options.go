@@ -11,9 +11,14 @@ type Options struct {
 	AWSSharedConfigFiles          []string
 	AWSSharedCredentialsFiles     []string
 	AWSUseDefaultCredentialsChain bool
+	CredentialProcess             string
+	Description                   string
+	ExpiresAt                     string
 	GitSSHUser                    string
 	Password                      string
 	PrivateKeyFile                string
 	PrivateKeyPassword            string
+	RefreshToken                  string
+	Token                         string
 	Username                      string
 }
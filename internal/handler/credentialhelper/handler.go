@@ -0,0 +1,72 @@
+package credentialhelper
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for credential helper commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for credential helper commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application for the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the service
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles credential helper commands
+func (h *Handler) Handler(ctx context.Context, options *Options) error {
+	var err error
+
+	errContext := "(credentialhelper::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Handler options are not defined")
+	}
+
+	switch options.Action {
+	case GetAction:
+		err = h.app.Get(ctx, options.ServerURL)
+	case StoreAction:
+		err = h.app.Store(ctx, options.ServerURL, options.Username, options.Secret)
+	case EraseAction:
+		err = h.app.Erase(ctx, options.ServerURL)
+	case ListAction:
+		err = h.app.List(ctx)
+	default:
+		return errors.New(errContext, fmt.Sprintf("Unknown credentials helper action '%s'", options.Action))
+	}
+
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package credentialhelper
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/credentialhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(credentialhelper::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		options           *Options
+		prepareAssertFunc func(handler *Handler)
+		err               error
+	}{
+		{
+			desc:    "Testing error handling credential helper without an application defined",
+			handler: NewHandler(),
+			options: &Options{},
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing error handling an unknown credential helper action",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{
+				Action: "unknown",
+			},
+			err: errors.New(errContext, "Unknown credentials helper action 'unknown'"),
+		},
+		{
+			desc: "Testing handle get action",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{
+				Action:    GetAction,
+				ServerURL: "registry.example.com",
+			},
+			prepareAssertFunc: func(handler *Handler) {
+				handler.app.(*application.MockApplication).On("Get", context.TODO(), "registry.example.com").Return(nil)
+			},
+		},
+		{
+			desc: "Testing handle store action",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{
+				Action:    StoreAction,
+				ServerURL: "registry.example.com",
+				Username:  "username",
+				Secret:    "secret",
+			},
+			prepareAssertFunc: func(handler *Handler) {
+				handler.app.(*application.MockApplication).On("Store", context.TODO(), "registry.example.com", "username", "secret").Return(nil)
+			},
+		},
+		{
+			desc: "Testing handle erase action",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{
+				Action:    EraseAction,
+				ServerURL: "registry.example.com",
+			},
+			prepareAssertFunc: func(handler *Handler) {
+				handler.app.(*application.MockApplication).On("Erase", context.TODO(), "registry.example.com").Return(nil)
+			},
+		},
+		{
+			desc: "Testing handle list action",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{
+				Action: ListAction,
+			},
+			prepareAssertFunc: func(handler *Handler) {
+				handler.app.(*application.MockApplication).On("List", context.TODO()).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
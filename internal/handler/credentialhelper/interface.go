@@ -0,0 +1,11 @@
+package credentialhelper
+
+import "context"
+
+// Applicationer is the service for credential helper commands
+type Applicationer interface {
+	Get(ctx context.Context, serverURL string) error
+	Store(ctx context.Context, serverURL, username, secret string) error
+	Erase(ctx context.Context, serverURL string) error
+	List(ctx context.Context) error
+}
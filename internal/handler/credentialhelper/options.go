@@ -0,0 +1,24 @@
+package credentialhelper
+
+const (
+	// GetAction is the credentials helper action to get the credentials for a server
+	GetAction = "get"
+	// StoreAction is the credentials helper action to store the credentials for a server
+	StoreAction = "store"
+	// EraseAction is the credentials helper action to erase the credentials for a server
+	EraseAction = "erase"
+	// ListAction is the credentials helper action to list the servers and usernames of the credentials
+	ListAction = "list"
+)
+
+// Options is the options for the credential helper handler
+type Options struct {
+	// Action is the credentials helper action to carry out
+	Action string
+	// ServerURL is the registry server address
+	ServerURL string
+	// Username is the username to store
+	Username string
+	// Secret is the secret to store
+	Secret string
+}
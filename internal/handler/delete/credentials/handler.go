@@ -0,0 +1,55 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for delete credentials commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for delete credentials commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles delete credentials commands
+func (h *Handler) Handler(ctx context.Context, id string) error {
+	var err error
+
+	errContext := "(delete::credentials::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	err = h.app.Run(ctx, id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
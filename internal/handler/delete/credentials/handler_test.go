@@ -0,0 +1,58 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/delete/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(delete::credentials::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		id                string
+		prepareAssertFunc func(*Handler)
+		err               error
+	}{
+		{
+			desc:    "Testing error running delete credentials handler without application",
+			handler: NewHandler(),
+			id:      "id",
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing run delete credentials handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			id: "id",
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), "id", mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.id)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
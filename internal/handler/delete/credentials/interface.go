@@ -0,0 +1,12 @@
+package credentials
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/delete/credentials"
+)
+
+// Applicationer is the service for delete credentials commands
+type Applicationer interface {
+	Run(ctx context.Context, id string, optionsFunc ...application.OptionsFunc) error
+}
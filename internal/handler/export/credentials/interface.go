@@ -0,0 +1,12 @@
+package credentials
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/export/credentials"
+)
+
+// Applicationer is the service for export credentials commands
+type Applicationer interface {
+	Run(ctx context.Context, optionsFunc ...application.OptionsFunc) (int, error)
+}
@@ -0,0 +1,54 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for import credentials commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for import credentials commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles import credentials commands. It returns the number of imported credentials
+func (h *Handler) Handler(ctx context.Context) (int, error) {
+
+	errContext := "(import::credentials::Handler)"
+
+	if h.app == nil {
+		return 0, errors.New(errContext, "Handler application is not configured")
+	}
+
+	count, err := h.app.Run(ctx)
+	if err != nil {
+		return 0, errors.New(errContext, "", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,68 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/import/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(import::credentials::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		prepareAssertFunc func(*Handler)
+		res               int
+		err               error
+	}{
+		{
+			desc:    "Testing error running import credentials handler without application",
+			handler: NewHandler(),
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing error running import credentials handler when application fails",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), mock.Anything).Return(0, errors.New("", "error"))
+			},
+			err: errors.New(errContext, "", errors.New("", "error")),
+		},
+		{
+			desc: "Testing run import credentials handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), mock.Anything).Return(2, nil)
+			},
+			res: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			res, err := test.handler.Handler(context.TODO())
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -5,51 +5,126 @@ import (
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/application/promote"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
 )
 
 type Handler struct {
-	app PromoteApplication
+	planFactory PlanFactorier
+	app         PromoteApplication
 }
 
-func NewHandler(a PromoteApplication) *Handler {
+func NewHandler(p PlanFactorier, a PromoteApplication) *Handler {
 	return &Handler{
-		app: a,
+		planFactory: p,
+		app:         a,
 	}
 }
 
 func (h *Handler) Handler(ctx context.Context, options *Options) error {
 
+	var err error
 	errContext := "(handler::promote::Handler)"
 
+	if options.ImageDefinitionName != "" {
+		err = h.handleDefinition(ctx, options)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		return nil
+	}
+
 	if options.SourceImageName == "" {
 		return errors.New(errContext, "Source images name must be provided")
 	}
 
-	applicationOptions := &promote.Options{
-		SourceImageName: options.SourceImageName,
+	applicationOptions := applicationOptions(options)
+	applicationOptions.SourceImageName = options.SourceImageName
+	applicationOptions.SourceImageDigest = options.SourceImageDigest
+	applicationOptions.PromoteSourceImageTag = options.PromoteSourceImageTag
+
+	if len(options.TargetImageTags) > 0 {
+		applicationOptions.TargetImageTags = append([]string{}, options.TargetImageTags...)
+	}
+
+	err = h.app.Promote(ctx, applicationOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// handleDefinition promotes the images resolved from an images definition
+func (h *Handler) handleDefinition(ctx context.Context, options *Options) error {
+
+	var err error
+	var promotePlan promote.Planner
+	errContext := "(handler::promote::handleDefinition)"
+
+	if h.planFactory == nil {
+		return errors.New(errContext, "Promote handler requires a plan factory to promote images definition")
+	}
+
+	if options.SourceImageName != "" {
+		return errors.New(errContext, "Source image name and images definition can not be provided at the same time")
+	}
+
+	if options.TargetImageName != "" && options.TargetImageName != image.UndefinedStringValue {
+		return errors.New(errContext, "Target image name is not supported when promoting an images definition, it could cause an unpredictable result")
+	}
+
+	if options.SourceImageDigest != "" {
+		return errors.New(errContext, "Source image digest is not supported when promoting an images definition, it identifies a single image")
+	}
+
+	if len(options.TargetImageTags) > 0 {
+		return errors.New(errContext, "Target image tags are not supported when promoting an images definition, tags are taken from the images definition")
 	}
 
+	planParameters := map[string]interface{}{}
+	planType := "single"
+	if options.PromoteOnCascade {
+		planType = "cascade"
+		planParameters["depth"] = options.CascadeDepth
+	}
+
+	promotePlan, err = h.planFactory.NewPlan(planType, planParameters)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	applicationOptions := applicationOptions(options)
+	if len(options.Filter) > 0 {
+		applicationOptions.Filter = append([]string{}, options.Filter...)
+	}
+
+	err = h.app.PromoteDefinition(ctx, promotePlan, options.ImageDefinitionName, options.ImageDefinitionVersions, applicationOptions)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// applicationOptions returns the application options shared by both promote modes
+func applicationOptions(options *Options) *promote.Options {
+	applicationOptions := &promote.Options{}
+
 	applicationOptions.DryRun = options.DryRun
 	applicationOptions.EnableSemanticVersionTags = options.EnableSemanticVersionTags
-	applicationOptions.PromoteSourceImageTag = options.PromoteSourceImageTag
+	applicationOptions.Force = options.Force
+	applicationOptions.Promoter = options.Promoter
 	applicationOptions.RemoteSourceImage = options.RemoteSourceImage
 	applicationOptions.RemoveTargetImageTags = options.RemoveTargetImageTags
 	applicationOptions.TargetImageName = options.TargetImageName
 	applicationOptions.TargetImageRegistryHost = options.TargetImageRegistryHost
 	applicationOptions.TargetImageRegistryNamespace = options.TargetImageRegistryNamespace
+	applicationOptions.VerifyDigest = options.VerifyDigest
 
 	if len(options.SemanticVersionTagsTemplates) > 0 {
 		applicationOptions.SemanticVersionTagsTemplates = append([]string{}, options.SemanticVersionTagsTemplates...)
 	}
 
-	if len(options.TargetImageTags) > 0 {
-		applicationOptions.TargetImageTags = append([]string{}, options.TargetImageTags...)
-	}
-
-	err := h.app.Promote(ctx, applicationOptions)
-	if err != nil {
-		return errors.New(errContext, "", err)
-	}
-
-	return nil
+	return applicationOptions
 }
@@ -6,6 +6,8 @@ import (
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/application/promote"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,7 +25,7 @@ func TestHandler(t *testing.T) {
 	}{
 		{
 			desc:    "Testing promote handler error when no source image is provided",
-			handler: NewHandler(promote.NewMockApplication()),
+			handler: NewHandler(plan.NewMockPlanFactory(), promote.NewMockApplication()),
 			err:     errors.New(errContext, "Source images name must be provided"),
 			options: &Options{
 
@@ -35,16 +37,19 @@ func TestHandler(t *testing.T) {
 		},
 		{
 			desc:    "Testing promote handler passing all options",
-			handler: NewHandler(promote.NewMockApplication()),
+			handler: NewHandler(plan.NewMockPlanFactory(), promote.NewMockApplication()),
 			err:     &errors.Error{},
 			options: &Options{
 				DryRun:                       true,
 				EnableSemanticVersionTags:    true,
 				PromoteSourceImageTag:        true,
+				Promoter:                     "registry",
 				RemoteSourceImage:            true,
 				RemoveTargetImageTags:        true,
 				SemanticVersionTagsTemplates: []string{"{{ .Major }}"},
+				SourceImageDigest:            "sha256:digest",
 				SourceImageName:              "source_name",
+				VerifyDigest:                 true,
 				TargetImageName:              "target_name",
 				TargetImageRegistryHost:      "target_registry_host",
 				TargetImageRegistryNamespace: "target_registry_namespace",
@@ -56,10 +61,13 @@ func TestHandler(t *testing.T) {
 					DryRun:                       true,
 					EnableSemanticVersionTags:    true,
 					PromoteSourceImageTag:        true,
+					Promoter:                     "registry",
 					RemoteSourceImage:            true,
 					RemoveTargetImageTags:        true,
 					SemanticVersionTagsTemplates: []string{"{{ .Major }}"},
+					SourceImageDigest:            "sha256:digest",
 					SourceImageName:              "source_name",
+					VerifyDigest:                 true,
 					TargetImageName:              "target_name",
 					TargetImageRegistryHost:      "target_registry_host",
 					TargetImageRegistryNamespace: "target_registry_namespace",
@@ -69,6 +77,81 @@ func TestHandler(t *testing.T) {
 				h.app.(*promote.MockApplication).On("Promote", context.TODO(), options).Return(nil)
 			},
 		},
+		{
+			desc:    "Testing promote handler error promoting an images definition with target image tags",
+			handler: NewHandler(plan.NewMockPlanFactory(), promote.NewMockApplication()),
+			err:     errors.New(errContext, "Target image tags are not supported when promoting an images definition, tags are taken from the images definition"),
+			options: &Options{
+				ImageDefinitionName: "image",
+				TargetImageName:     image.UndefinedStringValue,
+				TargetImageTags:     []string{"tag"},
+			},
+		},
+		{
+			desc:    "Testing promote handler error promoting an images definition with source image digest",
+			handler: NewHandler(plan.NewMockPlanFactory(), promote.NewMockApplication()),
+			err:     errors.New(errContext, "Source image digest is not supported when promoting an images definition, it identifies a single image"),
+			options: &Options{
+				ImageDefinitionName: "image",
+				SourceImageDigest:   "sha256:digest",
+			},
+		},
+		{
+			desc:    "Testing promote handler error promoting an images definition with target image name",
+			handler: NewHandler(plan.NewMockPlanFactory(), promote.NewMockApplication()),
+			err:     errors.New(errContext, "Target image name is not supported when promoting an images definition, it could cause an unpredictable result"),
+			options: &Options{
+				ImageDefinitionName: "image",
+				TargetImageName:     "target_name",
+			},
+		},
+		{
+			desc:    "Testing promote handler error promoting an images definition without plan factory",
+			handler: NewHandler(nil, promote.NewMockApplication()),
+			err:     errors.New(errContext, "Promote handler requires a plan factory to promote images definition"),
+			options: &Options{
+				ImageDefinitionName: "image",
+			},
+		},
+		{
+			desc:    "Testing promote handler promoting an images definition on cascade",
+			handler: NewHandler(plan.NewMockPlanFactory(), promote.NewMockApplication()),
+			err:     &errors.Error{},
+			options: &Options{
+				CascadeDepth:                 2,
+				DryRun:                       true,
+				EnableSemanticVersionTags:    true,
+				Filter:                       []string{"namespace=stable"},
+				ImageDefinitionName:          "image",
+				ImageDefinitionVersions:      []string{"1.2.3"},
+				PromoteOnCascade:             true,
+				RemoteSourceImage:            true,
+				SemanticVersionTagsTemplates: []string{"{{ .Major }}"},
+				TargetImageName:              image.UndefinedStringValue,
+				TargetImageRegistryHost:      "target_registry_host",
+				TargetImageRegistryNamespace: "target_registry_namespace",
+			},
+			prepareAssertFunc: func(h *Handler) {
+				promotePlan := plan.NewMockPlan()
+
+				h.planFactory.(*plan.MockPlanFactory).On("NewPlan", "cascade", map[string]interface{}{
+					"depth": 2,
+				}).Return(promotePlan, nil)
+
+				options := &promote.Options{
+					DryRun:                       true,
+					EnableSemanticVersionTags:    true,
+					Filter:                       []string{"namespace=stable"},
+					RemoteSourceImage:            true,
+					SemanticVersionTagsTemplates: []string{"{{ .Major }}"},
+					TargetImageName:              image.UndefinedStringValue,
+					TargetImageRegistryHost:      "target_registry_host",
+					TargetImageRegistryNamespace: "target_registry_namespace",
+				}
+
+				h.app.(*promote.MockApplication).On("PromoteDefinition", context.TODO(), promotePlan, "image", []string{"1.2.3"}, options).Return(nil)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -4,9 +4,16 @@ import (
 	"context"
 
 	"github.com/gostevedore/stevedore/internal/application/promote"
+	"github.com/gostevedore/stevedore/internal/infrastructure/plan"
 )
 
+// PlanFactorier interface defines the execution plan
+type PlanFactorier interface {
+	NewPlan(id string, parameters map[string]interface{}) (plan.Planner, error)
+}
+
 // PromoteApplication
 type PromoteApplication interface {
 	Promote(ctx context.Context, options *promote.Options) error
+	PromoteDefinition(ctx context.Context, promotePlan promote.Planner, name string, versions []string, options *promote.Options) error
 }
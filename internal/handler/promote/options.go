@@ -1,12 +1,28 @@
 package promote
 
 type Options struct {
+	// CascadeDepth is the number of levels to promote when promote on cascade is executed
+	CascadeDepth int
 	// DryRun is a flag to indicate if the promote should be a dry run
 	DryRun bool
+	// Force is a flag to indicate whether to overwrite the target image tags even when they are immutable
+	Force bool
+	// Filter is the list of filters to select which images from the definition are promoted
+	Filter []string
+	// ImageDefinitionName is the name of the images definition to promote
+	ImageDefinitionName string
+	// ImageDefinitionVersions is the list of versions from the images definition to promote
+	ImageDefinitionVersions []string
+	// PromoteOnCascade if is true the images definition children are also promoted
+	PromoteOnCascade bool
 	// EnableSemanticVersionTags is a flag to indicate whether to generate semantic version tags
 	EnableSemanticVersionTags bool
+	// SourceImageDigest is the digest that the source image must have to be promoted
+	SourceImageDigest string
 	// SourceImageName is the name of the image to promote
 	SourceImageName string
+	// VerifyDigest is a flag to indicate whether to verify that the promoted images digest matches the source image digest
+	VerifyDigest bool
 	// TargetImageName is the name of the image to promote to
 	TargetImageName string
 	// TargetImageRegistryNamespace is the namespace of the registry to use as target
@@ -21,6 +37,8 @@ type Options struct {
 	DEPRECATEDRemoveTargetImageTags bool
 	// SemanticVersionTagsTemplates is the list of semantic version tags templates
 	SemanticVersionTagsTemplates []string
+	// Promoter is the name of the promoter used to promote the images
+	Promoter string
 	// PromoteSourceImageTag is the tag to promote
 	PromoteSourceImageTag bool
 	// RemoteSourceImage is the flag to indicate whether to promote from remote source image
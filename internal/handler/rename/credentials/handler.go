@@ -0,0 +1,55 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for rename credentials commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for rename credentials commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles rename credentials commands
+func (h *Handler) Handler(ctx context.Context, id, newID string) error {
+	var err error
+
+	errContext := "(rename::credentials::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	err = h.app.Run(ctx, id, newID)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/rename/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(rename::credentials::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		id                string
+		newID             string
+		prepareAssertFunc func(*Handler)
+		err               error
+	}{
+		{
+			desc:    "Testing error running rename credentials handler without application",
+			handler: NewHandler(),
+			id:      "id",
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing run rename credentials handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			id:    "id",
+			newID: "new-id",
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), "id", "new-id", mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.id, test.newID)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
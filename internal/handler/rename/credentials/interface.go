@@ -0,0 +1,12 @@
+package credentials
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/rename/credentials"
+)
+
+// Applicationer is the service for rename credentials commands
+type Applicationer interface {
+	Run(ctx context.Context, id, newID string, optionsFunc ...application.OptionsFunc) error
+}
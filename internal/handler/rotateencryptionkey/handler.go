@@ -0,0 +1,59 @@
+package rotateencryptionkey
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for rotate encryption key commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for rotate encryption key commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles rotate encryption key commands
+func (h *Handler) Handler(ctx context.Context, options *Options) error {
+	var err error
+
+	errContext := "(rotateencryptionkey::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Handler options must be provided")
+	}
+
+	err = h.app.Run(ctx, options.EncryptionKey)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
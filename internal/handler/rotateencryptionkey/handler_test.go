@@ -0,0 +1,65 @@
+package rotateencryptionkey
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/rotateencryptionkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(rotateencryptionkey::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		options           *Options
+		prepareAssertFunc func(*Handler)
+		err               error
+	}{
+		{
+			desc:    "Testing error running rotate encryption key handler without application",
+			handler: NewHandler(),
+			options: &Options{EncryptionKey: "new-key"},
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing error running rotate encryption key handler without options",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			err: errors.New(errContext, "Handler options must be provided"),
+		},
+		{
+			desc: "Testing run rotate encryption key handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{EncryptionKey: "new-key"},
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), "new-key", mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.options)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
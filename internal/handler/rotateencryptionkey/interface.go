@@ -0,0 +1,12 @@
+package rotateencryptionkey
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/rotateencryptionkey"
+)
+
+// Applicationer is the service for rotate encryption key commands
+type Applicationer interface {
+	Run(ctx context.Context, key string, optionsFunc ...application.OptionsFunc) error
+}
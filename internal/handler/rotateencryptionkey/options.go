@@ -0,0 +1,7 @@
+package rotateencryptionkey
+
+// Options is the options for the rotate encryption key handler
+type Options struct {
+	// EncryptionKey is the new encryption key
+	EncryptionKey string
+}
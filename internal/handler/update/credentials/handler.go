@@ -0,0 +1,90 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for update credentials commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for update credentials commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles update credentials commands
+func (h *Handler) Handler(ctx context.Context, id string, options *Options) error {
+	var err error
+
+	errContext := "(update::credentials::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Handler options must be provided")
+	}
+
+	err = h.app.Run(ctx, id, updateCredentialFromOptions(options))
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+func updateCredentialFromOptions(options *Options) *credentials.Credential {
+	update := &credentials.Credential{}
+
+	update.AllowUseSSHAgent = options.AllowUseSSHAgent
+	update.AWSAccessKeyID = options.AWSAccessKeyID
+	update.AWSProfile = options.AWSProfile
+	update.AWSRegion = options.AWSRegion
+	update.AWSRoleARN = options.AWSRoleARN
+	update.AWSSecretAccessKey = options.AWSSecretAccessKey
+	if len(options.AWSSharedConfigFiles) > 0 {
+		update.AWSSharedConfigFiles = append([]string{}, options.AWSSharedConfigFiles...)
+	}
+	if len(options.AWSSharedCredentialsFiles) > 0 {
+		update.AWSSharedCredentialsFiles = append([]string{}, options.AWSSharedCredentialsFiles...)
+	}
+	update.AWSUseDefaultCredentialsChain = options.AWSUseDefaultCredentialsChain
+	update.CredentialProcess = options.CredentialProcess
+	update.Description = options.Description
+	update.ExpiresAt = options.ExpiresAt
+	update.GitSSHUser = options.GitSSHUser
+	update.Password = options.Password
+	update.PrivateKeyFile = options.PrivateKeyFile
+	update.PrivateKeyPassword = options.PrivateKeyPassword
+	update.RefreshToken = options.RefreshToken
+	update.Token = options.Token
+	update.Username = options.Username
+
+	return update
+}
@@ -0,0 +1,67 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/update/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(update::credentials::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		id                string
+		options           *Options
+		prepareAssertFunc func(*Handler)
+		err               error
+	}{
+		{
+			desc: "Testing error running update credentials handler without options",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			id:  "id",
+			err: errors.New(errContext, "Handler options must be provided"),
+		},
+		{
+			desc: "Testing run update credentials handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			id: "id",
+			options: &Options{
+				Username: "username",
+			},
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), "id", &credentials.Credential{
+					Username: "username",
+				}, mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.id, test.options)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package credentials
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/update/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+// Applicationer is the service for update credentials commands
+type Applicationer interface {
+	Run(ctx context.Context, id string, update *credentials.Credential, optionsFunc ...application.OptionsFunc) error
+}
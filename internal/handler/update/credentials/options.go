@@ -0,0 +1,24 @@
+package credentials
+
+// Options is the options for the update credentials handler. Only the attributes that are set are updated
+type Options struct {
+	AllowUseSSHAgent              bool
+	AWSAccessKeyID                string
+	AWSProfile                    string
+	AWSRegion                     string
+	AWSRoleARN                    string
+	AWSSecretAccessKey            string
+	AWSSharedConfigFiles          []string
+	AWSSharedCredentialsFiles     []string
+	AWSUseDefaultCredentialsChain bool
+	CredentialProcess             string
+	Description                   string
+	ExpiresAt                     string
+	GitSSHUser                    string
+	Password                      string
+	PrivateKeyFile                string
+	PrivateKeyPassword            string
+	RefreshToken                  string
+	Token                         string
+	Username                      string
+}
@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/validate"
+)
+
+// OptionsFunc is a function used to configure the handler
+type OptionsFunc func(*Handler)
+
+// Handler is a handler for validate commands
+type Handler struct {
+	app Applicationer
+}
+
+// NewHandler creates a new handler for validate commands
+func NewHandler(options ...OptionsFunc) *Handler {
+	handler := &Handler{}
+	handler.Options(options...)
+
+	return handler
+}
+
+// WithApplication sets the application to the handler
+func WithApplication(app Applicationer) OptionsFunc {
+	return func(h *Handler) {
+		h.app = app
+	}
+}
+
+// Options configure the handler
+func (h *Handler) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// Handler handles validate commands
+func (h *Handler) Handler(ctx context.Context, options *Options) error {
+	var err error
+
+	errContext := "(validate::Handler)"
+
+	if h.app == nil {
+		return errors.New(errContext, "Handler application is not configured")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Handler options must be provided")
+	}
+
+	if len(options.ConfigurationFiles) == 0 && len(options.BuildersPaths) == 0 && len(options.ImagesPaths) == 0 {
+		return errors.New(errContext, "There are no files to validate. Configuration file, builders path or images path must be provided")
+	}
+
+	err = h.app.Run(ctx, &application.Options{
+		ConfigurationFiles: options.ConfigurationFiles,
+		BuildersPaths:      options.BuildersPaths,
+		ImagesPaths:        options.ImagesPaths,
+	})
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
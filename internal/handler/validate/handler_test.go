@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	application "github.com/gostevedore/stevedore/internal/application/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler(t *testing.T) {
+
+	errContext := "(validate::Handler)"
+
+	tests := []struct {
+		desc              string
+		handler           *Handler
+		options           *Options
+		prepareAssertFunc func(*Handler)
+		err               error
+	}{
+		{
+			desc:    "Testing error running validate handler without application",
+			handler: NewHandler(),
+			options: &Options{},
+			err:     errors.New(errContext, "Handler application is not configured"),
+		},
+		{
+			desc: "Testing error running validate handler without options",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: nil,
+			err:     errors.New(errContext, "Handler options must be provided"),
+		},
+		{
+			desc: "Testing error running validate handler without files to validate",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{},
+			err:     errors.New(errContext, "There are no files to validate. Configuration file, builders path or images path must be provided"),
+		},
+		{
+			desc: "Testing run validate handler",
+			handler: NewHandler(
+				WithApplication(application.NewMockApplication()),
+			),
+			options: &Options{
+				ConfigurationFiles: []string{"stevedore.yaml"},
+				BuildersPaths:      []string{"builders"},
+				ImagesPaths:        []string{"images"},
+			},
+			prepareAssertFunc: func(h *Handler) {
+				h.app.(*application.MockApplication).On("Run", context.TODO(), &application.Options{
+					ConfigurationFiles: []string{"stevedore.yaml"},
+					BuildersPaths:      []string{"builders"},
+					ImagesPaths:        []string{"images"},
+				}, mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.handler)
+			}
+
+			err := test.handler.Handler(context.TODO(), test.options)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				test.handler.app.(*application.MockApplication).AssertExpectations(t)
+			}
+		})
+	}
+}
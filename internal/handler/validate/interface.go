@@ -0,0 +1,12 @@
+package validate
+
+import (
+	"context"
+
+	application "github.com/gostevedore/stevedore/internal/application/validate"
+)
+
+// Applicationer is the service for validate commands
+type Applicationer interface {
+	Run(ctx context.Context, options *application.Options, optionsFunc ...application.OptionsFunc) error
+}
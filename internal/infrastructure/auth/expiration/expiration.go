@@ -0,0 +1,107 @@
+package expiration
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+// OptionsFunc defines the signature for an option function to set the expiration warner attributes
+type OptionsFunc func(*ExpirationWarner)
+
+// ExpirationWarner warns about the credentials that expire within a window. Each credential is warned once
+type ExpirationWarner struct {
+	credentials CredentialsResolver
+	output      Warner
+	window      time.Duration
+	now         func() time.Time
+
+	mutex  sync.Mutex
+	warned map[string]struct{}
+}
+
+// NewExpirationWarner returns a new expiration warner. The default window is credentials.DefaultExpirationWarningWindow
+func NewExpirationWarner(resolver CredentialsResolver, output Warner, opts ...OptionsFunc) *ExpirationWarner {
+	warner := &ExpirationWarner{
+		credentials: resolver,
+		output:      output,
+		window:      credentials.DefaultExpirationWarningWindow,
+		now:         time.Now,
+		warned:      map[string]struct{}{},
+	}
+
+	warner.Options(opts...)
+
+	return warner
+}
+
+// WithWindow sets the window used to warn about the credentials that are about to expire. A zero window disables the warnings
+func WithWindow(window time.Duration) OptionsFunc {
+	return func(w *ExpirationWarner) {
+		w.window = window
+	}
+}
+
+// WithNow sets the function used to get the current time
+func WithNow(now func() time.Time) OptionsFunc {
+	return func(w *ExpirationWarner) {
+		w.now = now
+	}
+}
+
+// Options configures the expiration warner
+func (w *ExpirationWarner) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(w)
+	}
+}
+
+// WarnExpiring warns about the credentials used by the ids that are expired or expire within the window. Warnings must not stop the caller, then the credentials that can not be resolved are skipped
+func (w *ExpirationWarner) WarnExpiring(ids ...string) {
+
+	if w.credentials == nil || w.output == nil || w.window <= 0 {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	now := w.now()
+
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		credential, err := w.credentials.Credential(id)
+		if err != nil || credential == nil {
+			continue
+		}
+
+		if _, warned := w.warned[credential.ID]; warned {
+			continue
+		}
+
+		expiresAt, defined, err := credential.Expiration()
+		if err != nil {
+			w.warned[credential.ID] = struct{}{}
+			w.output.Warn(fmt.Sprintf("Credentials '%s' have an invalid expiration date '%s'", credential.ID, credential.ExpiresAt))
+			continue
+		}
+
+		if !defined || !expiresAt.Before(now.Add(w.window)) {
+			continue
+		}
+
+		w.warned[credential.ID] = struct{}{}
+
+		if !expiresAt.After(now) {
+			w.output.Warn(fmt.Sprintf("Credentials '%s' expired at %s", credential.ID, credential.ExpiresAt))
+			continue
+		}
+
+		w.output.Warn(fmt.Sprintf("Credentials '%s' expire at %s", credential.ID, credential.ExpiresAt))
+	}
+}
@@ -0,0 +1,137 @@
+package expiration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/console"
+)
+
+func TestWarnExpiring(t *testing.T) {
+
+	now := func() time.Time {
+		return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		desc              string
+		warner            *ExpirationWarner
+		ids               []string
+		prepareAssertFunc func(*ExpirationWarner)
+	}{
+		{
+			desc: "Testing warn about credentials that expire within the window",
+			warner: NewExpirationWarner(
+				factory.NewMockAuthFactory(),
+				console.NewMockConsole(),
+				WithNow(now),
+			),
+			ids: []string{"registry.example.com"},
+			prepareAssertFunc: func(w *ExpirationWarner) {
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "registry.example.com").Return(&credentials.Credential{
+					ID:        "registry.example.com",
+					ExpiresAt: "2024-06-03T00:00:00Z",
+				}, nil)
+				w.output.(*console.MockConsole).On("Warn", []interface{}{"Credentials 'registry.example.com' expire at 2024-06-03T00:00:00Z"})
+			},
+		},
+		{
+			desc: "Testing warn about expired credentials",
+			warner: NewExpirationWarner(
+				factory.NewMockAuthFactory(),
+				console.NewMockConsole(),
+				WithNow(now),
+			),
+			ids: []string{"registry.example.com"},
+			prepareAssertFunc: func(w *ExpirationWarner) {
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "registry.example.com").Return(&credentials.Credential{
+					ID:        "registry.example.com",
+					ExpiresAt: "2024-05-01T00:00:00Z",
+				}, nil)
+				w.output.(*console.MockConsole).On("Warn", []interface{}{"Credentials 'registry.example.com' expired at 2024-05-01T00:00:00Z"})
+			},
+		},
+		{
+			desc: "Testing warn about credentials with an invalid expiration date",
+			warner: NewExpirationWarner(
+				factory.NewMockAuthFactory(),
+				console.NewMockConsole(),
+				WithNow(now),
+			),
+			ids: []string{"registry.example.com"},
+			prepareAssertFunc: func(w *ExpirationWarner) {
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "registry.example.com").Return(&credentials.Credential{
+					ID:        "registry.example.com",
+					ExpiresAt: "tomorrow",
+				}, nil)
+				w.output.(*console.MockConsole).On("Warn", []interface{}{"Credentials 'registry.example.com' have an invalid expiration date 'tomorrow'"})
+			},
+		},
+		{
+			desc: "Testing warn once about credentials shared by several ids",
+			warner: NewExpirationWarner(
+				factory.NewMockAuthFactory(),
+				console.NewMockConsole(),
+				WithNow(now),
+			),
+			ids: []string{"registry.example.com/team-a/image", "registry.example.com/team-b/image"},
+			prepareAssertFunc: func(w *ExpirationWarner) {
+				credential := &credentials.Credential{
+					ID:        "registry.example.com",
+					ExpiresAt: "2024-06-03T00:00:00Z",
+				}
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "registry.example.com/team-a/image").Return(credential, nil)
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "registry.example.com/team-b/image").Return(credential, nil)
+				w.output.(*console.MockConsole).On("Warn", []interface{}{"Credentials 'registry.example.com' expire at 2024-06-03T00:00:00Z"}).Once()
+			},
+		},
+		{
+			desc: "Testing skip credentials that expire after the window, have no expiration or can not be resolved",
+			warner: NewExpirationWarner(
+				factory.NewMockAuthFactory(),
+				console.NewMockConsole(),
+				WithNow(now),
+			),
+			ids: []string{"not-expiring", "no-expiration", "unknown", "error", ""},
+			prepareAssertFunc: func(w *ExpirationWarner) {
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "not-expiring").Return(&credentials.Credential{
+					ID:        "not-expiring",
+					ExpiresAt: "2025-06-01T00:00:00Z",
+				}, nil)
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "no-expiration").Return(&credentials.Credential{
+					ID: "no-expiration",
+				}, nil)
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "unknown").Return(nil, nil)
+				w.credentials.(*factory.MockAuthFactory).On("Credential", "error").Return(nil, fmt.Errorf("error"))
+			},
+		},
+		{
+			desc: "Testing no warnings when the window is disabled",
+			warner: NewExpirationWarner(
+				factory.NewMockAuthFactory(),
+				console.NewMockConsole(),
+				WithNow(now),
+				WithWindow(0),
+			),
+			ids: []string{"registry.example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.warner)
+			}
+
+			test.warner.WarnExpiring(test.ids...)
+
+			test.warner.credentials.(*factory.MockAuthFactory).AssertExpectations(t)
+			test.warner.output.(*console.MockConsole).AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package expiration
+
+import "github.com/gostevedore/stevedore/internal/core/domain/credentials"
+
+// CredentialsResolver interface defines the component which resolves the credentials used for an id, such as a registry host or a registry path
+type CredentialsResolver interface {
+	Credential(id string) (*credentials.Credential, error)
+}
+
+// Warner interface defines the output used to print the warnings
+type Warner interface {
+	Warn(msg ...interface{})
+}
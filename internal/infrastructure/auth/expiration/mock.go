@@ -0,0 +1,18 @@
+package expiration
+
+import "github.com/stretchr/testify/mock"
+
+// MockExpirationWarner is a mock of ExpirationWarner
+type MockExpirationWarner struct {
+	mock.Mock
+}
+
+// NewMockExpirationWarner returns a new mock of ExpirationWarner
+func NewMockExpirationWarner() *MockExpirationWarner {
+	return &MockExpirationWarner{}
+}
+
+// WarnExpiring provides a mock function with given fields: ids
+func (w *MockExpirationWarner) WarnExpiring(ids ...string) {
+	w.Called(ids)
+}
@@ -1,15 +1,29 @@
 package factory
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 )
 
+// CredentialsLister is the interface of the credentials stores that can list all their credentials. It is required to resolve credentials whose id is a pattern
+type CredentialsLister interface {
+	All() ([]*credentials.Credential, error)
+}
+
 // AuthFactory is a factory for auth providers
 type AuthFactory struct {
 	store                repository.CredentialsStorer
 	credentialsProviders []repository.AuthProviderer
+
+	listMutex   sync.Mutex
+	listed      bool
+	credentials []*credentials.Credential
+	listErr     error
 }
 
 // NewAuthFactory creates a new auth provider factory
@@ -24,7 +38,7 @@ func NewAuthFactory(store repository.CredentialsStorer, auth ...repository.AuthP
 	return factory
 }
 
-// Get returns a new auth provider
+// Get returns a new auth provider, or nil when there are no credentials for the id. The id could be a registry host or a registry path, such as 'registry.example.com/team-a/image', and it is resolved to the credentials whose id is the longest match, either an exact id or a pattern
 func (f *AuthFactory) Get(id string) (repository.AuthMethodReader, error) {
 
 	var err error
@@ -36,11 +50,15 @@ func (f *AuthFactory) Get(id string) (repository.AuthMethodReader, error) {
 		return nil, errors.New(errContext, "To get credentials, you must provide an id")
 	}
 
-	badge, err = f.store.Get(id)
+	badge, err = f.resolve(id)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
 
+	if badge == nil {
+		return nil, nil
+	}
+
 	for _, provider := range f.credentialsProviders {
 
 		method, err = provider.Get(badge)
@@ -55,3 +73,104 @@ func (f *AuthFactory) Get(id string) (repository.AuthMethodReader, error) {
 
 	return nil, nil
 }
+
+// Credential returns the credentials that the id is resolved to, or nil when there are no credentials for the id
+func (f *AuthFactory) Credential(id string) (*credentials.Credential, error) {
+
+	errContext := "(credentials::factory::AuthFactory::Credential)"
+
+	if id == "" {
+		return nil, errors.New(errContext, "To get credentials, you must provide an id")
+	}
+
+	badge, err := f.resolve(id)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return badge, nil
+}
+
+// resolve returns the credentials that best match the id. Exact ids are looked up for the id and its parent paths, and the ids defined as patterns are matched when the store can list its credentials. It returns nil when no credentials match the id, and an error when the store fails for any other reason than not having the credentials
+func (f *AuthFactory) resolve(id string) (*credentials.Credential, error) {
+
+	var best *credentials.Credential
+
+	errContext := "(credentials::factory::AuthFactory::resolve)"
+
+	bestSpecificity := -1
+
+	for scope := id; scope != ""; scope = parentScope(scope) {
+		badge, err := f.store.Get(scope)
+		if err != nil {
+			if credentials.IsNotFoundError(err) {
+				continue
+			}
+
+			return nil, errors.New(errContext, fmt.Sprintf("Credentials '%s' could not be achieved", scope), err)
+		}
+
+		if badge == nil {
+			continue
+		}
+
+		// there is no match more specific than the id itself
+		if scope == id {
+			return badge, nil
+		}
+
+		best = badge
+		bestSpecificity, _ = credentials.MatchID(scope, id)
+		break
+	}
+
+	all, err := f.list()
+	if err != nil {
+		return nil, errors.New(errContext, "Credentials could not be listed", err)
+	}
+
+	for _, badge := range all {
+		if badge == nil {
+			continue
+		}
+
+		specificity, match := credentials.MatchID(badge.ID, id)
+		if match && specificity > bestSpecificity {
+			best = badge
+			bestSpecificity = specificity
+		}
+	}
+
+	return best, nil
+}
+
+// list returns all the credentials from the store. Credentials are listed once and kept for the next lookups
+func (f *AuthFactory) list() ([]*credentials.Credential, error) {
+
+	f.listMutex.Lock()
+	defer f.listMutex.Unlock()
+
+	if f.listed {
+		return f.credentials, f.listErr
+	}
+	f.listed = true
+
+	lister, isLister := f.store.(CredentialsLister)
+	if !isLister {
+		return nil, nil
+	}
+
+	f.credentials, f.listErr = lister.All()
+
+	return f.credentials, f.listErr
+}
+
+// parentScope returns the id without its last path segment, or an empty string when the id has no path
+func parentScope(id string) string {
+	idx := strings.LastIndex(id, "/")
+	if idx < 0 {
+		return ""
+	}
+
+	return id[:idx]
+}
@@ -131,3 +131,228 @@ func TestGet(t *testing.T) {
 	}
 
 }
+
+func TestGetByPattern(t *testing.T) {
+
+	errContext := "(credentials::factory::AuthFactory::Get)"
+	notFoundErr := credentials.NewNotFoundError("id")
+
+	storedCredentials := []*credentials.Credential{
+		{
+			ID:       "*.dkr.ecr.eu-west-1.amazonaws.com",
+			Username: "ecr-username",
+			Password: "ecr-password",
+		},
+		{
+			ID:       "*.example.com",
+			Username: "example-username",
+			Password: "example-password",
+		},
+		{
+			ID:       "registry.example.com/team-a",
+			Username: "team-a-username",
+			Password: "team-a-password",
+		},
+	}
+
+	tests := []struct {
+		desc              string
+		id                string
+		prepareAssertFunc func(*AuthFactory)
+		res               repository.AuthMethodReader
+		err               error
+	}{
+		{
+			desc: "Testing get credentials matching a registry host pattern",
+			id:   "123456789012.dkr.ecr.eu-west-1.amazonaws.com/team-a/image",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "123456789012.dkr.ecr.eu-west-1.amazonaws.com/team-a/image").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "123456789012.dkr.ecr.eu-west-1.amazonaws.com/team-a").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "123456789012.dkr.ecr.eu-west-1.amazonaws.com").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("All").Return(storedCredentials, nil)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "ecr-username",
+				Password: "ecr-password",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get credentials matching the longest path-scoped id",
+			id:   "registry.example.com/team-a/image",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com/team-a/image").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com/team-a").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com").Return(
+					&credentials.Credential{
+						ID:       "registry.example.com",
+						Username: "registry-username",
+						Password: "registry-password",
+					}, nil)
+				f.store.(*mockstore.MockStore).On("All").Return(storedCredentials, nil)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "team-a-username",
+				Password: "team-a-password",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get credentials preferring an exact registry host over a host pattern",
+			id:   "registry.example.com/team-b/image",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com/team-b/image").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com/team-b").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com").Return(
+					&credentials.Credential{
+						ID:       "registry.example.com",
+						Username: "registry-username",
+						Password: "registry-password",
+					}, nil)
+				f.store.(*mockstore.MockStore).On("All").Return(storedCredentials, nil)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "registry-username",
+				Password: "registry-password",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get no credentials when no id matches",
+			id:   "registry.test",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.test").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("All").Return(storedCredentials, nil)
+			},
+			res: nil,
+		},
+		{
+			desc: "Testing error getting credentials when the store fails",
+			id:   "registry.test",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.test").Return(nil, errors.New("(store::credentials::mock::Get)", "Error decrypting credentials"))
+			},
+			err: errors.New(errContext, "",
+				errors.New("(credentials::factory::AuthFactory::resolve)", "Credentials 'registry.test' could not be achieved",
+					errors.New("(store::credentials::mock::Get)", "Error decrypting credentials"))),
+		},
+		{
+			desc: "Testing error getting credentials when the store credentials can not be listed",
+			id:   "registry.test",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.test").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("All").Return([]*credentials.Credential{}, errors.New("(store::credentials::mock::All)", "Vault is unreachable"))
+			},
+			err: errors.New(errContext, "",
+				errors.New("(credentials::factory::AuthFactory::resolve)", "Credentials could not be listed",
+					errors.New("(store::credentials::mock::All)", "Vault is unreachable"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			factory := NewAuthFactory(
+				mockstore.NewMockStore(),
+				credential.NewStoreAuthProvider(
+					keyfile.NewKeyFileAuthMethod(),
+					basic.NewBasicAuthMethod(),
+					sshagent.NewSSHAgentAuthMethod(),
+				),
+			)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(factory)
+			}
+
+			res, err := factory.Get(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestCredential(t *testing.T) {
+
+	errContext := "(credentials::factory::AuthFactory::Credential)"
+	notFoundErr := credentials.NewNotFoundError("id")
+
+	tests := []struct {
+		desc              string
+		id                string
+		prepareAssertFunc func(*AuthFactory)
+		res               *credentials.Credential
+		err               error
+	}{
+		{
+			desc: "Testing error on credentials factory getting a credential with empty id",
+			id:   "",
+			err:  errors.New(errContext, "To get credentials, you must provide an id"),
+		},
+		{
+			desc: "Testing get the credential resolved for a registry path",
+			id:   "registry.example.com/team-a/image",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com/team-a/image").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com/team-a").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("Get", "registry.example.com").Return(
+					&credentials.Credential{
+						ID:        "registry.example.com",
+						Username:  "registry-username",
+						Password:  "registry-password",
+						ExpiresAt: "2024-12-31T00:00:00Z",
+					}, nil)
+				f.store.(*mockstore.MockStore).On("All").Return([]*credentials.Credential{}, nil)
+			},
+			res: &credentials.Credential{
+				ID:        "registry.example.com",
+				Username:  "registry-username",
+				Password:  "registry-password",
+				ExpiresAt: "2024-12-31T00:00:00Z",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing get no credential when no id matches",
+			id:   "registry.test",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.test").Return(nil, notFoundErr)
+				f.store.(*mockstore.MockStore).On("All").Return([]*credentials.Credential{}, nil)
+			},
+			res: nil,
+		},
+		{
+			desc: "Testing error getting a credential when the store fails",
+			id:   "registry.test",
+			prepareAssertFunc: func(f *AuthFactory) {
+				f.store.(*mockstore.MockStore).On("Get", "registry.test").Return(nil, errors.New("(store::credentials::mock::Get)", "Error decrypting credentials"))
+			},
+			err: errors.New(errContext, "",
+				errors.New("(credentials::factory::AuthFactory::resolve)", "Credentials 'registry.test' could not be achieved",
+					errors.New("(store::credentials::mock::Get)", "Error decrypting credentials"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			factory := NewAuthFactory(mockstore.NewMockStore())
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(factory)
+			}
+
+			res, err := factory.Credential(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package factory
 
 import (
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	"github.com/stretchr/testify/mock"
 )
@@ -25,3 +26,14 @@ func (f *MockAuthFactory) Get(id string) (repository.AuthMethodReader, error) {
 
 	return args.Get(0).(repository.AuthMethodReader), args.Error(1)
 }
+
+// Credential provides a mock function with given fields: id
+func (f *MockAuthFactory) Credential(id string) (*credentials.Credential, error) {
+	args := f.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*credentials.Credential), args.Error(1)
+}
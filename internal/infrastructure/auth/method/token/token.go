@@ -0,0 +1,45 @@
+package token
+
+import (
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// TokenAuthMethod is an authentication method that uses a bearer token or an OAuth2 refresh token
+type TokenAuthMethod struct {
+	Username string
+	// Token is a bearer token sent to the registry. It is passed to Docker as the registry token
+	Token string
+	// RefreshToken is an OAuth2 refresh token exchanged for an access token. It is passed to Docker as the identity token
+	RefreshToken string
+}
+
+// NewTokenAuthMethod creates a new TokenAuthMethod
+func NewTokenAuthMethod() *TokenAuthMethod {
+	return &TokenAuthMethod{}
+}
+
+// AuthMethodConstructor return TokenAuthMethod from the given credential
+func (auth *TokenAuthMethod) AuthMethodConstructor(credential *credentials.Credential) (repository.AuthMethodReader, error) {
+
+	if credential == nil {
+		return nil, nil
+	}
+
+	if credential.Token != "" || credential.RefreshToken != "" {
+		auth = &TokenAuthMethod{
+			Username:     credential.Username,
+			Token:        credential.Token,
+			RefreshToken: credential.RefreshToken,
+		}
+
+		return auth, nil
+	} else {
+		return nil, nil
+	}
+}
+
+// Name returns the name of the authentication method
+func (a *TokenAuthMethod) Name() string {
+	return credentials.TokenAuthMethod
+}
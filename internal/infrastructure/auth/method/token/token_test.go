@@ -0,0 +1,63 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMethod(t *testing.T) {
+	tests := []struct {
+		desc       string
+		method     *TokenAuthMethod
+		credential *credentials.Credential
+		res        repository.AuthMethodReader
+		err        error
+	}{
+		{
+			desc:       "Testing get auth method with nil credential",
+			method:     NewTokenAuthMethod(),
+			credential: nil,
+			res:        nil,
+		},
+		{
+			desc:       "Testing get auth method token defined on the credential",
+			method:     NewTokenAuthMethod(),
+			credential: &credentials.Credential{Username: "robot$project", Token: "token"},
+			res:        &TokenAuthMethod{Username: "robot$project", Token: "token"},
+		},
+		{
+			desc:       "Testing get auth method refresh token defined on the credential",
+			method:     NewTokenAuthMethod(),
+			credential: &credentials.Credential{RefreshToken: "refresh-token"},
+			res:        &TokenAuthMethod{RefreshToken: "refresh-token"},
+		},
+		{
+			desc:       "Testing get auth method tokens not defined on the credential",
+			method:     NewTokenAuthMethod(),
+			credential: &credentials.Credential{Username: "username", Password: "password"},
+			res:        nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			auth, err := test.method.AuthMethodConstructor(test.credential)
+			if err != nil {
+				assert.Equal(t, test.res, err)
+			} else {
+				assert.Equal(t, test.res, auth)
+			}
+
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	method := NewTokenAuthMethod()
+	assert.Equal(t, credentials.TokenAuthMethod, method.Name())
+}
@@ -2,8 +2,14 @@ package ecr
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,54 +17,166 @@ import (
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/cache"
 )
 
 const (
 	AWSECRUserName = "AWS"
 )
 
+// OptionsFunc defines the signature for an option function to set the AWS ECR auth provider
+type OptionsFunc func(*AWSECRAuthProvider)
+
 // AWSECRAuthProvider return auth method from credential
 type AWSECRAuthProvider struct {
 	tokenProvider AWSECRTokenProvider
+	caches        []TokenCacher
+	now           func() time.Time
+
+	mutex sync.Mutex
 }
 
 // NewAWSECRAuthProvider return new instance of AWSECRAuthProvider
-func NewAWSECRAuthProvider(provider AWSECRTokenProvider) *AWSECRAuthProvider {
-	return &AWSECRAuthProvider{
+func NewAWSECRAuthProvider(provider AWSECRTokenProvider, opts ...OptionsFunc) *AWSECRAuthProvider {
+	p := &AWSECRAuthProvider{
 		tokenProvider: provider,
+		now:           time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithTokenCaches sets the caches where the authorization tokens are kept until they expire. Caches are looked up in the given order
+func WithTokenCaches(caches ...TokenCacher) OptionsFunc {
+	return func(p *AWSECRAuthProvider) {
+		p.caches = append([]TokenCacher{}, caches...)
 	}
 }
 
-// Get returns the most appropiate AuthMethodReader for the credential received
+// Get returns the most appropiate AuthMethodReader for the credential received. The authorization token is reused from the caches until it expires, so AWS is only requested once per AWS account credentials, region and role. The tokens achieved through the default credentials chain are not kept on the persistent caches
 func (p *AWSECRAuthProvider) Get(credential *credentials.Credential) (repository.AuthMethodReader, error) {
 
-	// The error is not being controled because is prefered to return nil and let the caller to decide what to do. AWS would be required for very specific cases and controlling the error would cause anoying errors to the user
-	// In worst case scenario, docker API will return an error when trying to pull or push the image because the authorization token is not found
-	// TODO: log the error
-	token, _ := p.tokenProvider.Get(context.TODO(),
+	errContext := "(credentials::provider::AWSECRAuthProvider::Get)"
+
+	if credential == nil {
+		return nil, nil
+	}
+
+	// the mutex prevents concurrent builds from requesting the same token at once
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	key := tokenCacheKey(credential)
+	caches := p.tokenCaches(credential)
+
+	token := cachedToken(caches, key, p.now())
+	if token == nil {
+		var err error
+
+		token, err = p.requestToken(credential)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("AWS ECR authorization token for '%s' could not be achieved", credential.ID), err)
+		}
+
+		if token == nil {
+			return nil, nil
+		}
+
+		cacheToken(caches, key, token)
+	}
+
+	auth, err := p.AuthMethod(token.AuthorizationToken)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return auth, nil
+}
+
+// requestToken requests a new authorization token to AWS. It returns nil when the credential does not define how to authenticate to AWS
+func (p *AWSECRAuthProvider) requestToken(credential *credentials.Credential) (*cache.Token, error) {
+
+	errContext := "(credentials::provider::AWSECRAuthProvider::requestToken)"
+
+	if p.tokenProvider == nil {
+		return nil, errors.New(errContext, "To request an AWS ECR authorization token, a token provider is required")
+	}
+
+	output, err := p.tokenProvider.Get(context.TODO(),
 		// That funcion is used to load the AWS Configuration that will be used to create the ECR client to get the authorization token
 		func(ctx context.Context, loadOptionsFuncs ...func(*config.LoadOptions) error) (aws.Config, error) {
-			// The error is not being controled because is prefered to return nil and let the caller to decide what to do. AWS would be required for very specific cases and controlling the error would cause anoying errors to the user
-			// In worst case scenario, docker API will return an error when trying to pull or push the image because the authorization token is not found
-			// TODO: log the error
-			cfg, _ := config.LoadDefaultConfig(ctx, loadOptionsFuncs...)
-
-			return cfg, nil
+			return config.LoadDefaultConfig(ctx, loadOptionsFuncs...)
 		}, credential)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	for _, data := range output.AuthorizationData {
+		authorizationToken := aws.ToString(data.AuthorizationToken)
+		if authorizationToken == "" {
+			continue
+		}
+
+		return &cache.Token{
+			AuthorizationToken: authorizationToken,
+			ExpiresAt:          aws.ToTime(data.ExpiresAt),
+		}, nil
+	}
+
+	return nil, errors.New(errContext, "AWS ECR did not return any authorization token")
+}
+
+// tokenCaches returns the caches where the credential token can be kept. The principal resolved by the default credentials chain depends on the environment, such as the environment variables or the instance role, and the credential attributes do not identify it, so its tokens are only kept on the caches that do not persist across invocations
+func (p *AWSECRAuthProvider) tokenCaches(credential *credentials.Credential) []TokenCacher {
+
+	if !usesDefaultCredentialsChain(credential) {
+		return p.caches
+	}
 
-	if token != nil {
-		for _, a := range token.AuthorizationData {
-			// if error is returned the process continues with out returning an error. It is prefered to return nil and continues exploring the other credentials.
-			// TODO: log the error
-			auth, _ := p.AuthMethod(aws.ToString(a.AuthorizationToken))
+	caches := []TokenCacher{}
+	for _, c := range p.caches {
+		persistent, isPersistent := c.(PersistentTokenCacher)
+		if isPersistent && persistent.Persistent() {
+			continue
+		}
+		caches = append(caches, c)
+	}
+
+	return caches
+}
 
-			if auth != nil {
-				return auth, nil
-			}
+// cachedToken returns the first unexpired token found on the caches, and it is also kept on the caches looked up before. A cache that fails is skipped, because caching is a best effort and the token can be requested again
+func cachedToken(caches []TokenCacher, key string, now time.Time) *cache.Token {
+
+	for i, c := range caches {
+		token, err := c.Get(key)
+		if err != nil || token.IsExpired(now) {
+			continue
+		}
+
+		for _, previous := range caches[:i] {
+			_ = previous.Set(key, token)
 		}
+
+		return token
 	}
 
-	return nil, nil
+	return nil
+}
+
+// cacheToken keeps the token on all the caches. A cache that fails is skipped, because caching is a best effort and the token can be requested again
+func cacheToken(caches []TokenCacher, key string, token *cache.Token) {
+	for _, c := range caches {
+		_ = c.Set(key, token)
+	}
 }
 
 // AuthMethod returns a BasicAuthMethod having the username and password from authorization token
@@ -83,3 +201,27 @@ func (p *AWSECRAuthProvider) AuthMethod(authorizationToken string) (repository.A
 
 	return auth, nil
 }
+
+// tokenCacheKey returns the key to cache the authorization token of a credential. The key is a hash of the attributes that identify the AWS account credentials, the region and the role, so the cached token is shared by all the credentials using them
+func tokenCacheKey(credential *credentials.Credential) string {
+	identity := strings.Join([]string{
+		credential.AWSAccessKeyID,
+		credential.AWSProfile,
+		credential.AWSRegion,
+		credential.AWSRoleARN,
+		strconv.FormatBool(credential.AWSUseDefaultCredentialsChain),
+		strings.Join(credential.AWSSharedCredentialsFiles, ","),
+		strings.Join(credential.AWSSharedConfigFiles, ","),
+	}, "|")
+
+	hash := sha256.Sum256([]byte(identity))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// usesDefaultCredentialsChain returns whether the AWS credentials are resolved by the default credentials chain because the credential neither defines a role nor static credentials
+func usesDefaultCredentialsChain(credential *credentials.Credential) bool {
+	return credential.AWSUseDefaultCredentialsChain &&
+		credential.AWSRoleARN == "" &&
+		(credential.AWSAccessKeyID == "" || credential.AWSSecretAccessKey == "")
+}
@@ -3,6 +3,7 @@ package ecr
 import (
 	"context"
 	"testing"
+	"time"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/cache"
 	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -66,6 +68,255 @@ NTM2Ngp9`,
 	}
 }
 
+func TestGetWithTokenCaches(t *testing.T) {
+	errContext := "(credentials::provider::AWSECRAuthProvider::Get)"
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	credential := &credentials.Credential{
+		ID:                 "registry.test",
+		AWSAccessKeyID:     "accessKey",
+		AWSSecretAccessKey: "secretKey",
+		AWSRegion:          "eu-west-1",
+	}
+	key := tokenCacheKey(credential)
+	defaultChainCredential := &credentials.Credential{
+		ID:                            "registry.test",
+		AWSRegion:                     "eu-west-1",
+		AWSUseDefaultCredentialsChain: true,
+	}
+	defaultChainKey := tokenCacheKey(defaultChainCredential)
+	roleCredential := &credentials.Credential{
+		ID:                            "registry.test",
+		AWSRegion:                     "eu-west-1",
+		AWSRoleARN:                    "arn:aws:iam::123456789012:role/ecr",
+		AWSUseDefaultCredentialsChain: true,
+	}
+	roleKey := tokenCacheKey(roleCredential)
+
+	tests := []struct {
+		desc              string
+		provider          *AWSECRAuthProvider
+		credential        *credentials.Credential
+		prepareAssertFunc func(*AWSECRAuthProvider)
+		assertFunc        func(*testing.T, *AWSECRAuthProvider)
+		res               *basic.BasicAuthMethod
+		err               error
+	}{
+		{
+			desc:       "Testing get nil auth method from a nil credential",
+			provider:   NewAWSECRAuthProvider(token.NewMockAWSECRToken()),
+			credential: nil,
+			res:        nil,
+		},
+		{
+			desc: "Testing get auth method from a cached token",
+			provider: NewAWSECRAuthProvider(
+				token.NewMockAWSECRToken(),
+				WithTokenCaches(cache.NewMemoryCache()),
+			),
+			credential: credential,
+			prepareAssertFunc: func(p *AWSECRAuthProvider) {
+				_ = p.caches[0].Set(key, &cache.Token{
+					AuthorizationToken: "QVdTOnBhc3N3b3Jk",
+					ExpiresAt:          now.Add(time.Hour),
+				})
+			},
+			assertFunc: func(t *testing.T, p *AWSECRAuthProvider) {
+				p.tokenProvider.(*token.MockAWSECRToken).AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "AWS",
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing get auth method requesting a new token when the cached one is expired",
+			provider: NewAWSECRAuthProvider(
+				token.NewMockAWSECRToken(),
+				WithTokenCaches(cache.NewMemoryCache()),
+			),
+			credential: credential,
+			prepareAssertFunc: func(p *AWSECRAuthProvider) {
+				_ = p.caches[0].Set(key, &cache.Token{
+					AuthorizationToken: "expired",
+					ExpiresAt:          now.Add(time.Minute),
+				})
+				p.tokenProvider.(*token.MockAWSECRToken).On("Get", context.TODO(), mock.Anything, credential).Return(&ecr.GetAuthorizationTokenOutput{
+					AuthorizationData: []types.AuthorizationData{
+						{
+							AuthorizationToken: aws.String("QVdTOnBhc3N3b3Jk"),
+							ExpiresAt:          aws.Time(now.Add(12 * time.Hour)),
+						},
+					},
+				}, nil)
+			},
+			assertFunc: func(t *testing.T, p *AWSECRAuthProvider) {
+				p.tokenProvider.(*token.MockAWSECRToken).AssertExpectations(t)
+
+				cached, err := p.caches[0].Get(key)
+				assert.Nil(t, err)
+				assert.Equal(t, &cache.Token{
+					AuthorizationToken: "QVdTOnBhc3N3b3Jk",
+					ExpiresAt:          now.Add(12 * time.Hour),
+				}, cached)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "AWS",
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing token found on a cache is kept on the caches looked up before",
+			provider: NewAWSECRAuthProvider(
+				token.NewMockAWSECRToken(),
+				WithTokenCaches(cache.NewMemoryCache(), cache.NewMemoryCache()),
+			),
+			credential: credential,
+			prepareAssertFunc: func(p *AWSECRAuthProvider) {
+				_ = p.caches[1].Set(key, &cache.Token{
+					AuthorizationToken: "QVdTOnBhc3N3b3Jk",
+					ExpiresAt:          now.Add(time.Hour),
+				})
+			},
+			assertFunc: func(t *testing.T, p *AWSECRAuthProvider) {
+				cached, err := p.caches[0].Get(key)
+				assert.Nil(t, err)
+				assert.Equal(t, &cache.Token{
+					AuthorizationToken: "QVdTOnBhc3N3b3Jk",
+					ExpiresAt:          now.Add(time.Hour),
+				}, cached)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "AWS",
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing the token achieved through the default credentials chain is not kept on the persistent caches",
+			provider: NewAWSECRAuthProvider(
+				token.NewMockAWSECRToken(),
+				WithTokenCaches(cache.NewMemoryCache(), newPersistentMemoryCache()),
+			),
+			credential: defaultChainCredential,
+			prepareAssertFunc: func(p *AWSECRAuthProvider) {
+				_ = p.caches[1].Set(defaultChainKey, &cache.Token{
+					AuthorizationToken: "QVdTOm90aGVy",
+					ExpiresAt:          now.Add(time.Hour),
+				})
+				p.tokenProvider.(*token.MockAWSECRToken).On("Get", context.TODO(), mock.Anything, defaultChainCredential).Return(&ecr.GetAuthorizationTokenOutput{
+					AuthorizationData: []types.AuthorizationData{
+						{
+							AuthorizationToken: aws.String("QVdTOnBhc3N3b3Jk"),
+							ExpiresAt:          aws.Time(now.Add(12 * time.Hour)),
+						},
+					},
+				}, nil)
+			},
+			assertFunc: func(t *testing.T, p *AWSECRAuthProvider) {
+				p.tokenProvider.(*token.MockAWSECRToken).AssertExpectations(t)
+
+				cached, err := p.caches[0].Get(defaultChainKey)
+				assert.Nil(t, err)
+				assert.Equal(t, "QVdTOnBhc3N3b3Jk", cached.AuthorizationToken)
+
+				persisted, err := p.caches[1].Get(defaultChainKey)
+				assert.Nil(t, err)
+				assert.Equal(t, "QVdTOm90aGVy", persisted.AuthorizationToken)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "AWS",
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing the token achieved assuming a role is kept on the persistent caches",
+			provider: NewAWSECRAuthProvider(
+				token.NewMockAWSECRToken(),
+				WithTokenCaches(cache.NewMemoryCache(), newPersistentMemoryCache()),
+			),
+			credential: roleCredential,
+			prepareAssertFunc: func(p *AWSECRAuthProvider) {
+				p.tokenProvider.(*token.MockAWSECRToken).On("Get", context.TODO(), mock.Anything, roleCredential).Return(&ecr.GetAuthorizationTokenOutput{
+					AuthorizationData: []types.AuthorizationData{
+						{
+							AuthorizationToken: aws.String("QVdTOnBhc3N3b3Jk"),
+							ExpiresAt:          aws.Time(now.Add(12 * time.Hour)),
+						},
+					},
+				}, nil)
+			},
+			assertFunc: func(t *testing.T, p *AWSECRAuthProvider) {
+				persisted, err := p.caches[1].Get(roleKey)
+				assert.Nil(t, err)
+				assert.Equal(t, "QVdTOnBhc3N3b3Jk", persisted.AuthorizationToken)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "AWS",
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing error when the authorization token could not be achieved",
+			provider: NewAWSECRAuthProvider(
+				token.NewMockAWSECRToken(),
+				WithTokenCaches(cache.NewMemoryCache()),
+			),
+			credential: credential,
+			prepareAssertFunc: func(p *AWSECRAuthProvider) {
+				p.tokenProvider.(*token.MockAWSECRToken).On("Get", context.TODO(), mock.Anything, credential).Return(
+					(*ecr.GetAuthorizationTokenOutput)(nil),
+					errors.New(errContext, "token error"))
+			},
+			err: errors.New(errContext, "AWS ECR authorization token for 'registry.test' could not be achieved",
+				errors.New("(credentials::provider::AWSECRAuthProvider::requestToken)", "",
+					errors.New(errContext, "token error"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.provider.now = func() time.Time { return now }
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.provider)
+			}
+
+			res, err := test.provider.Get(test.credential)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				if test.res == nil {
+					assert.Nil(t, res)
+				} else {
+					assert.Equal(t, test.res, res)
+				}
+
+				if test.assertFunc != nil {
+					test.assertFunc(t, test.provider)
+				}
+			}
+		})
+	}
+}
+
+// persistentMemoryCache is a memory cache that reports to persist the tokens across invocations
+type persistentMemoryCache struct {
+	*cache.MemoryCache
+}
+
+func newPersistentMemoryCache() *persistentMemoryCache {
+	return &persistentMemoryCache{
+		MemoryCache: cache.NewMemoryCache(),
+	}
+}
+
+func (c *persistentMemoryCache) Persistent() bool {
+	return true
+}
+
 func TestAuthMethod(t *testing.T) {
 
 	errContext := "(credentials::provider::AWSECRAuthProvider::AuthMethod)"
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc defines the signature for an option function to set the file cache
+type OptionsFunc func(*FileCache)
+
+// FileCache caches the AWS ECR authorization tokens on disk, encrypted, to reuse them across invocations. Each token is stored on a file named after its key
+type FileCache struct {
+	fs         afero.Fs
+	path       string
+	encryption repository.CredentialsEncrypter
+}
+
+// NewFileCache creates a new FileCache
+func NewFileCache(opts ...OptionsFunc) *FileCache {
+	cache := &FileCache{}
+	cache.Options(opts...)
+
+	return cache
+}
+
+// WithFilesystem sets the filesystem to the file cache
+func WithFilesystem(fs afero.Fs) OptionsFunc {
+	return func(c *FileCache) {
+		c.fs = fs
+	}
+}
+
+// WithPath sets the folder where the tokens are cached
+func WithPath(path string) OptionsFunc {
+	return func(c *FileCache) {
+		c.path = path
+	}
+}
+
+// WithEncryption sets the encryption used to cipher the cached tokens
+func WithEncryption(encryption repository.CredentialsEncrypter) OptionsFunc {
+	return func(c *FileCache) {
+		c.encryption = encryption
+	}
+}
+
+// Options provides the options to the file cache
+func (c *FileCache) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// Get returns the token cached for the key, or nil when there is no token for it
+func (c *FileCache) Get(key string) (*Token, error) {
+
+	errContext := "(awsecr::cache::FileCache::Get)"
+
+	err := c.validate()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	file := filepath.Join(c.path, key)
+
+	data, err := afero.ReadFile(c.fs, file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading the AWS ECR token cache file '%s'", file), err)
+	}
+
+	decrypted, err := c.encryption.Decrypt(string(data))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error decrypting the AWS ECR token cache file '%s'", file), err)
+	}
+
+	token := &Token{}
+	err = json.Unmarshal([]byte(decrypted), token)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error unmarshaling the AWS ECR token cache file '%s'", file), err)
+	}
+
+	return token, nil
+}
+
+// Persistent returns true because the tokens are kept across invocations
+func (c *FileCache) Persistent() bool {
+	return true
+}
+
+// Set caches the token for the key
+func (c *FileCache) Set(key string, token *Token) error {
+
+	errContext := "(awsecr::cache::FileCache::Set)"
+
+	err := c.validate()
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if token == nil {
+		return errors.New(errContext, "To cache an AWS ECR token, a token must be provided")
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return errors.New(errContext, "Error marshaling the AWS ECR token", err)
+	}
+
+	encrypted, err := c.encryption.Encrypt(string(data))
+	if err != nil {
+		return errors.New(errContext, "Error encrypting the AWS ECR token", err)
+	}
+
+	err = c.fs.MkdirAll(c.path, 0700)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error creating the AWS ECR token cache folder '%s'", c.path), err)
+	}
+
+	file := filepath.Join(c.path, key)
+	err = afero.WriteFile(c.fs, file, []byte(encrypted), 0600)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error writing the AWS ECR token cache file '%s'", file), err)
+	}
+
+	return nil
+}
+
+func (c *FileCache) validate() error {
+
+	errContext := "(awsecr::cache::FileCache::validate)"
+
+	if c.fs == nil {
+		return errors.New(errContext, "AWS ECR token file cache requires a filesystem")
+	}
+
+	if c.path == "" {
+		return errors.New(errContext, "AWS ECR token file cache requires a path")
+	}
+
+	if c.encryption == nil {
+		return errors.New(errContext, "AWS ECR token file cache requires an encryption")
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenJSON = `{"authorization_token":"token","expires_at":"2024-01-01T12:00:00Z"}`
+
+func TestFileCacheGet(t *testing.T) {
+	errContext := "(awsecr::cache::FileCache::Get)"
+
+	tests := []struct {
+		desc              string
+		cache             *FileCache
+		key               string
+		prepareAssertFunc func(*FileCache)
+		res               *Token
+		err               error
+	}{
+		{
+			desc: "Testing error getting a token from a file cache without path",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key: "key",
+			err: errors.New(errContext, "",
+				errors.New("(awsecr::cache::FileCache::validate)", "AWS ECR token file cache requires a path")),
+		},
+		{
+			desc: "Testing get nil when there is no token cached for the key",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/cache"),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key: "key",
+			res: nil,
+		},
+		{
+			desc: "Testing get a cached token",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/cache"),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key: "key",
+			prepareAssertFunc: func(c *FileCache) {
+				_ = afero.WriteFile(c.fs, "/cache/key", []byte("encrypted"), 0600)
+				c.encryption.(*encryption.MockEncription).On("Decrypt", "encrypted").Return(tokenJSON, nil)
+			},
+			res: &Token{
+				AuthorizationToken: "token",
+				ExpiresAt:          time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			desc: "Testing error decrypting a cached token",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/cache"),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key: "key",
+			prepareAssertFunc: func(c *FileCache) {
+				_ = afero.WriteFile(c.fs, "/cache/key", []byte("encrypted"), 0600)
+				c.encryption.(*encryption.MockEncription).On("Decrypt", "encrypted").Return("", errors.New(errContext, "decryption error"))
+			},
+			err: errors.New(errContext, "Error decrypting the AWS ECR token cache file '/cache/key'",
+				errors.New(errContext, "decryption error")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.cache)
+			}
+
+			res, err := test.cache.Get(test.key)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestFileCacheSet(t *testing.T) {
+	errContext := "(awsecr::cache::FileCache::Set)"
+
+	tests := []struct {
+		desc              string
+		cache             *FileCache
+		key               string
+		token             *Token
+		prepareAssertFunc func(*FileCache)
+		assertFunc        func(*testing.T, *FileCache)
+		err               error
+	}{
+		{
+			desc: "Testing error caching a nil token",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/cache"),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key:   "key",
+			token: nil,
+			err:   errors.New(errContext, "To cache an AWS ECR token, a token must be provided"),
+		},
+		{
+			desc: "Testing cache a token",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/cache"),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key: "key",
+			token: &Token{
+				AuthorizationToken: "token",
+				ExpiresAt:          time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			prepareAssertFunc: func(c *FileCache) {
+				c.encryption.(*encryption.MockEncription).On("Encrypt", tokenJSON).Return("encrypted", nil)
+			},
+			assertFunc: func(t *testing.T, c *FileCache) {
+				content, err := afero.ReadFile(c.fs, "/cache/key")
+				assert.Nil(t, err)
+				assert.Equal(t, "encrypted", string(content))
+
+				info, err := c.fs.Stat("/cache/key")
+				assert.Nil(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			},
+		},
+		{
+			desc: "Testing error encrypting a token",
+			cache: NewFileCache(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/cache"),
+				WithEncryption(encryption.NewMockEncryption()),
+			),
+			key: "key",
+			token: &Token{
+				AuthorizationToken: "token",
+				ExpiresAt:          time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			prepareAssertFunc: func(c *FileCache) {
+				c.encryption.(*encryption.MockEncription).On("Encrypt", tokenJSON).Return("", errors.New(errContext, "encryption error"))
+			},
+			err: errors.New(errContext, "Error encrypting the AWS ECR token",
+				errors.New(errContext, "encryption error")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.cache)
+			}
+
+			err := test.cache.Set(test.key, test.token)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				test.assertFunc(t, test.cache)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"sync"
+)
+
+// MemoryCache caches the AWS ECR authorization tokens in memory for the whole process
+type MemoryCache struct {
+	mutex  sync.RWMutex
+	tokens map[string]*Token
+}
+
+// NewMemoryCache creates a new MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		tokens: map[string]*Token{},
+	}
+}
+
+// Get returns the token cached for the key, or nil when there is no token for it
+func (c *MemoryCache) Get(key string) (*Token, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.tokens[key], nil
+}
+
+// Set caches the token for the key
+func (c *MemoryCache) Set(key string, token *Token) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.tokens == nil {
+		c.tokens = map[string]*Token{}
+	}
+	c.tokens[key] = token
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	token := &Token{
+		AuthorizationToken: "token",
+		ExpiresAt:          time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		desc              string
+		cache             *MemoryCache
+		key               string
+		prepareAssertFunc func(*MemoryCache)
+		res               *Token
+	}{
+		{
+			desc:  "Testing get a cached token",
+			cache: NewMemoryCache(),
+			key:   "key",
+			prepareAssertFunc: func(c *MemoryCache) {
+				_ = c.Set("key", token)
+			},
+			res: token,
+		},
+		{
+			desc:  "Testing get nil when there is no token cached for the key",
+			cache: NewMemoryCache(),
+			key:   "key",
+			prepareAssertFunc: func(c *MemoryCache) {
+				_ = c.Set("other", token)
+			},
+			res: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.cache)
+			}
+
+			res, err := test.cache.Get(test.key)
+			assert.Nil(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
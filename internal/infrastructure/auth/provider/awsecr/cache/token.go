@@ -0,0 +1,25 @@
+package cache
+
+import "time"
+
+const (
+	// ExpirationMargin is the time before a token expires when it is no longer used, so that the operations started with it do not outlive the token
+	ExpirationMargin = 5 * time.Minute
+)
+
+// Token is a cached AWS ECR authorization token
+type Token struct {
+	// AuthorizationToken is the base64 encoded authorization token
+	AuthorizationToken string `json:"authorization_token"`
+	// ExpiresAt is the time when the authorization token expires
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IsExpired returns whether the token is expired or about to expire at the given time
+func (t *Token) IsExpired(now time.Time) bool {
+	if t == nil {
+		return true
+	}
+
+	return !now.Add(ExpirationMargin).Before(t.ExpiresAt)
+}
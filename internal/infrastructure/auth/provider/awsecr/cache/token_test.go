@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc  string
+		token *Token
+		res   bool
+	}{
+		{
+			desc:  "Testing a nil token is expired",
+			token: nil,
+			res:   true,
+		},
+		{
+			desc: "Testing a token that expires later is not expired",
+			token: &Token{
+				AuthorizationToken: "token",
+				ExpiresAt:          now.Add(time.Hour),
+			},
+			res: false,
+		},
+		{
+			desc: "Testing a token that expires within the expiration margin is expired",
+			token: &Token{
+				AuthorizationToken: "token",
+				ExpiresAt:          now.Add(ExpirationMargin),
+			},
+			res: true,
+		},
+		{
+			desc: "Testing a token that already expired is expired",
+			token: &Token{
+				AuthorizationToken: "token",
+				ExpiresAt:          now.Add(-time.Hour),
+			},
+			res: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.token.IsExpired(now))
+		})
+	}
+}
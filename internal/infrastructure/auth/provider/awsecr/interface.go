@@ -7,9 +7,22 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/cache"
 )
 
 // AWSECRTokenProvider is the interface for the ECR client that generates the authorization token
 type AWSECRTokenProvider interface {
 	Get(ctx context.Context, cfgFunc func(context.Context, ...func(*config.LoadOptions) error) (aws.Config, error), badge *credentials.Credential) (*ecr.GetAuthorizationTokenOutput, error)
 }
+
+// TokenCacher is the interface for the caches where the authorization tokens are kept
+type TokenCacher interface {
+	Get(key string) (*cache.Token, error)
+	Set(key string, token *cache.Token) error
+}
+
+// PersistentTokenCacher is the interface for the caches that keep the authorization tokens across invocations
+type PersistentTokenCacher interface {
+	TokenCacher
+	Persistent() bool
+}
@@ -53,12 +53,7 @@ func WithECRClientFactory(ecrClientFactory *ECRClientFactory) OptionsFunc {
 }
 
 func defaultAWSConfigFunc(ctx context.Context, options ...func(*config.LoadOptions) error) (aws.Config, error) {
-
-	// if LoadDefaultConfig returns an error the process continues with out returning an error. It is prefered to return nil and let the caller to decide what to do. In worst case scenario, docker API will return an error when trying to pull or push the image because the authorization token is not found
-	// TODO: log the error
-	cfg, _ := config.LoadDefaultConfig(ctx, options...)
-
-	return cfg, nil
+	return config.LoadDefaultConfig(ctx, options...)
 }
 
 // Get return the authorization token
@@ -70,6 +65,11 @@ func (token *AWSECRToken) Get(ctx context.Context, AWSConfigFunc func(context.Co
 		return nil, errors.New(errContext, "To get an ECR authorization token, you must provide a credential")
 	}
 
+	// credentials that do not define how to authenticate to AWS have no authorization token, and it is not required to load the AWS configuration
+	if !hasAWSAuth(credential) {
+		return nil, nil
+	}
+
 	if AWSConfigFunc == nil {
 		AWSConfigFunc = defaultAWSConfigFunc
 	}
@@ -84,10 +84,10 @@ func (token *AWSECRToken) Get(ctx context.Context, AWSConfigFunc func(context.Co
 		return nil, errors.New(errContext, "", err)
 	}
 
-	// The error is not being controled because is prefered to return nil and let the caller to decide what to do. AWS would be required for very specific cases and controlling the error would cause anoying errors to the user
-	// In worst case scenario, docker API will return an error when trying to pull or push the image because the authorization token is not found
-	// TODO: log the error
-	credentialsProvider, _ := token.resolveCredentialsProvider(AWSConfig, credential, options...)
+	credentialsProvider, err := token.resolveCredentialsProvider(AWSConfig, credential, options...)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
 
 	if credentialsProvider != nil {
 		// when resolve credentials provider returns an empty aws.CredentialsCache it means that no credentials provider was found
@@ -101,10 +101,10 @@ func (token *AWSECRToken) Get(ctx context.Context, AWSConfigFunc func(context.Co
 	}
 
 	client := token.ecrClientFactory.Client(AWSConfig)
-	// The error is not being controled because is prefered to return nil and let the caller to decide what to do. AWS would be required for very specific cases and controlling the error would cause anoying errors to the user
-	// In worst case scenario, docker API will return an error when trying to pull or push the image because the authorization token is not found
-	// TODO: log the error
-	auth, _ := client.GetAuthorizationToken(ctx, &ecr.GetAuthorizationTokenInput{})
+	auth, err := client.GetAuthorizationToken(ctx, &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the AWS ECR authorization token", err)
+	}
 
 	return auth, nil
 }
@@ -179,3 +179,10 @@ func (token *AWSECRToken) resolveCredentialsProvider(cfg aws.Config, credential
 
 	return &aws.CredentialsCache{}, nil
 }
+
+// hasAWSAuth returns whether the credential defines how to authenticate to AWS
+func hasAWSAuth(credential *credentials.Credential) bool {
+	return credential.AWSRoleARN != "" ||
+		(credential.AWSAccessKeyID != "" && credential.AWSSecretAccessKey != "") ||
+		credential.AWSUseDefaultCredentialsChain
+}
@@ -37,6 +37,53 @@ func TestGet(t *testing.T) {
 			credential: nil,
 			err:        errors.New(errContext, "To get an ECR authorization token, you must provide a credential"),
 		},
+		{
+			desc: "Testing get no authorization token when the credential does not authenticate to aws",
+			ecr:  NewAWSECRToken(),
+			cfgFunc: func(context.Context, ...func(*config.LoadOptions) error) (aws.Config, error) {
+				return aws.Config{}, errors.New(errContext, "unexpected call to load the aws configuration")
+			},
+			credential: &credentials.Credential{
+				Username: "username",
+				Password: "password",
+			},
+			res: nil,
+		},
+		{
+			desc: "Testing error when loading the aws configuration",
+			ecr:  NewAWSECRToken(),
+			cfgFunc: func(context.Context, ...func(*config.LoadOptions) error) (aws.Config, error) {
+				return aws.Config{}, errors.New(errContext, "error loading the aws configuration")
+			},
+			credential: &credentials.Credential{
+				AWSUseDefaultCredentialsChain: true,
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContext, "error loading the aws configuration")),
+		},
+		{
+			desc: "Testing error when getting the ecr authorization token",
+			ecr: NewAWSECRToken(
+				WithECRClientFactory(
+					NewECRClientFactory(
+						func(cfg aws.Config) ECRClienter {
+							c := client.NewMockECRClient()
+							c.On("GetAuthorizationToken", context.TODO(), &ecr.GetAuthorizationTokenInput{}, mock.Anything).Return(
+								&ecr.GetAuthorizationTokenOutput{},
+								errors.New(errContext, "error getting the authorization token"))
+
+							return c
+						})),
+			),
+			cfgFunc: func(context.Context, ...func(*config.LoadOptions) error) (aws.Config, error) {
+				return aws.Config{}, nil
+			},
+			credential: &credentials.Credential{
+				AWSUseDefaultCredentialsChain: true,
+			},
+			err: errors.New(errContext, "Error getting the AWS ECR authorization token",
+				errors.New(errContext, "error getting the authorization token")),
+		},
 		{
 			desc: "Testing get ecr authorization token",
 			ecr: NewAWSECRToken(
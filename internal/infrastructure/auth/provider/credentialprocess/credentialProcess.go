@@ -0,0 +1,157 @@
+package credentialprocess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+)
+
+const (
+	// DefaultTimeout is the time a credential process command can run before it is cancelled
+	DefaultTimeout = 1 * time.Minute
+	// ExpirationMargin is the time before the credentials expire when they are no longer used, so that the operations started with them do not outlive the credentials
+	ExpirationMargin = 1 * time.Minute
+)
+
+// OptionsFunc defines the signature for an option function to set the credential process auth provider
+type OptionsFunc func(*CredentialProcessAuthProvider)
+
+// processOutput is the output expected from a credential process command
+type processOutput struct {
+	Username  string     `json:"username"`
+	Password  string     `json:"password"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// cachedCredentials are the credentials achieved from a credential process command, kept until they expire
+type cachedCredentials struct {
+	auth      *basic.BasicAuthMethod
+	expiresAt *time.Time
+}
+
+// CredentialProcessAuthProvider return auth method executing the credential process command defined on the credential
+type CredentialProcessAuthProvider struct {
+	runner  CommandRunner
+	timeout time.Duration
+	now     func() time.Time
+
+	mutex sync.Mutex
+	cache map[string]*cachedCredentials
+}
+
+// NewCredentialProcessAuthProvider return new instance of CredentialProcessAuthProvider
+func NewCredentialProcessAuthProvider(opts ...OptionsFunc) *CredentialProcessAuthProvider {
+	p := &CredentialProcessAuthProvider{
+		timeout: DefaultTimeout,
+		now:     time.Now,
+		cache:   map[string]*cachedCredentials{},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithRunner sets the runner that executes the credential process commands
+func WithRunner(runner CommandRunner) OptionsFunc {
+	return func(p *CredentialProcessAuthProvider) {
+		p.runner = runner
+	}
+}
+
+// WithTimeout sets the time a credential process command can run before it is cancelled
+func WithTimeout(timeout time.Duration) OptionsFunc {
+	return func(p *CredentialProcessAuthProvider) {
+		p.timeout = timeout
+	}
+}
+
+// Get returns a basic auth method having the username and password printed by the credential process command. The credentials are kept in memory until they expire, so the command is executed once per command and expiration
+func (p *CredentialProcessAuthProvider) Get(credential *credentials.Credential) (repository.AuthMethodReader, error) {
+
+	errContext := "(credentials::provider::CredentialProcessAuthProvider::Get)"
+
+	if credential == nil || credential.CredentialProcess == "" {
+		return nil, nil
+	}
+
+	// the mutex prevents concurrent builds from executing the same command at once
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	cached, exists := p.cache[credential.CredentialProcess]
+	if exists && !cached.isExpired(p.now()) {
+		return cached.auth, nil
+	}
+
+	cached, err := p.run(credential.CredentialProcess)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials for '%s' could not be achieved from the credential process", credential.ID), err)
+	}
+
+	p.cache[credential.CredentialProcess] = cached
+
+	return cached.auth, nil
+}
+
+// run executes the credential process command and parses its output
+func (p *CredentialProcessAuthProvider) run(command string) (*cachedCredentials, error) {
+
+	errContext := "(credentials::provider::CredentialProcessAuthProvider::run)"
+
+	if p.runner == nil {
+		return nil, errors.New(errContext, "To execute a credential process, a command runner is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), p.timeout)
+	defer cancel()
+
+	output, err := p.runner.Run(ctx, command)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	credentialOutput := &processOutput{}
+	err = json.Unmarshal(output, credentialOutput)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the credential process output. A JSON object with 'username', 'password' and, optionally, 'expires_at' attributes is expected", err)
+	}
+
+	if credentialOutput.Username == "" {
+		return nil, errors.New(errContext, "The credential process output does not contain a username")
+	}
+
+	if credentialOutput.Password == "" {
+		return nil, errors.New(errContext, "The credential process output does not contain a password")
+	}
+
+	if credentialOutput.ExpiresAt != nil && credentialOutput.ExpiresAt.Before(p.now()) {
+		return nil, errors.New(errContext, fmt.Sprintf("The credential process returned credentials that expired at %s", credentialOutput.ExpiresAt.Format(time.RFC3339)))
+	}
+
+	return &cachedCredentials{
+		auth: &basic.BasicAuthMethod{
+			Username: credentialOutput.Username,
+			Password: credentialOutput.Password,
+		},
+		expiresAt: credentialOutput.ExpiresAt,
+	}, nil
+}
+
+// isExpired returns whether the credentials are expired or about to expire at the given time. Credentials without expiration never expire
+func (c *cachedCredentials) isExpired(now time.Time) bool {
+	if c.expiresAt == nil {
+		return false
+	}
+
+	return !now.Add(ExpirationMargin).Before(*c.expiresAt)
+}
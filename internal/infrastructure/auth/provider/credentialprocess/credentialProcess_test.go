@@ -0,0 +1,188 @@
+package credentialprocess
+
+import (
+	"testing"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGet(t *testing.T) {
+	errContext := "(credentials::provider::CredentialProcessAuthProvider::Get)"
+	errRunContext := "(credentials::provider::CredentialProcessAuthProvider::run)"
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	command := "credential-broker registry.test"
+	credential := &credentials.Credential{
+		ID:                "registry.test",
+		CredentialProcess: command,
+	}
+
+	tests := []struct {
+		desc              string
+		provider          *CredentialProcessAuthProvider
+		credential        *credentials.Credential
+		prepareAssertFunc func(*CredentialProcessAuthProvider)
+		assertFunc        func(*testing.T, *CredentialProcessAuthProvider)
+		res               *basic.BasicAuthMethod
+		err               error
+	}{
+		{
+			desc:       "Testing get nil auth method from a nil credential",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: nil,
+			res:        nil,
+		},
+		{
+			desc:     "Testing get nil auth method from a credential without credential process",
+			provider: NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: &credentials.Credential{
+				Username: "username",
+				Password: "password",
+			},
+			res: nil,
+		},
+		{
+			desc:       "Testing get auth method from the credential process output",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).On("Run", mock.Anything, command).Return([]byte(`{"username":"username","password":"password","expires_at":"2024-01-01T13:00:00Z"}`), nil)
+			},
+			assertFunc: func(t *testing.T, p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).AssertExpectations(t)
+				assert.Contains(t, p.cache, command)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "username",
+				Password: "password",
+			},
+		},
+		{
+			desc:       "Testing get auth method from cached credentials",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				expiresAt := now.Add(time.Hour)
+				p.cache[command] = &cachedCredentials{
+					auth: &basic.BasicAuthMethod{
+						Username: "cached",
+						Password: "password",
+					},
+					expiresAt: &expiresAt,
+				}
+			},
+			assertFunc: func(t *testing.T, p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).AssertNotCalled(t, "Run", mock.Anything, mock.Anything)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "cached",
+				Password: "password",
+			},
+		},
+		{
+			desc:       "Testing get auth method executing the credential process when the cached credentials are about to expire",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				expiresAt := now.Add(30 * time.Second)
+				p.cache[command] = &cachedCredentials{
+					auth: &basic.BasicAuthMethod{
+						Username: "cached",
+						Password: "password",
+					},
+					expiresAt: &expiresAt,
+				}
+				p.runner.(*MockCommandRunner).On("Run", mock.Anything, command).Return([]byte(`{"username":"username","password":"password"}`), nil)
+			},
+			assertFunc: func(t *testing.T, p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).AssertExpectations(t)
+			},
+			res: &basic.BasicAuthMethod{
+				Username: "username",
+				Password: "password",
+			},
+		},
+		{
+			desc:       "Testing error when the credential process fails",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).On("Run", mock.Anything, command).Return(nil, errors.New("", "process error"))
+			},
+			err: errors.New(errContext, "Credentials for 'registry.test' could not be achieved from the credential process",
+				errors.New(errRunContext, "",
+					errors.New("", "process error"))),
+		},
+		{
+			desc:       "Testing error when the credential process output is not a JSON object",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).On("Run", mock.Anything, command).Return([]byte(`username:password`), nil)
+			},
+			err: errors.New(errContext, "Credentials for 'registry.test' could not be achieved from the credential process",
+				errors.New(errRunContext, "Error parsing the credential process output. A JSON object with 'username', 'password' and, optionally, 'expires_at' attributes is expected",
+					errors.New("", "invalid character 'u' looking for beginning of value"))),
+		},
+		{
+			desc:       "Testing error when the credential process output does not contain a password",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).On("Run", mock.Anything, command).Return([]byte(`{"username":"username"}`), nil)
+			},
+			err: errors.New(errContext, "Credentials for 'registry.test' could not be achieved from the credential process",
+				errors.New(errRunContext, "The credential process output does not contain a password")),
+		},
+		{
+			desc:       "Testing error when the credential process returns expired credentials",
+			provider:   NewCredentialProcessAuthProvider(WithRunner(NewMockCommandRunner())),
+			credential: credential,
+			prepareAssertFunc: func(p *CredentialProcessAuthProvider) {
+				p.runner.(*MockCommandRunner).On("Run", mock.Anything, command).Return([]byte(`{"username":"username","password":"password","expires_at":"2024-01-01T11:00:00Z"}`), nil)
+			},
+			err: errors.New(errContext, "Credentials for 'registry.test' could not be achieved from the credential process",
+				errors.New(errRunContext, "The credential process returned credentials that expired at 2024-01-01T11:00:00Z")),
+		},
+		{
+			desc:       "Testing error when no command runner is set",
+			provider:   NewCredentialProcessAuthProvider(),
+			credential: credential,
+			err: errors.New(errContext, "Credentials for 'registry.test' could not be achieved from the credential process",
+				errors.New(errRunContext, "To execute a credential process, a command runner is required")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.provider.now = func() time.Time { return now }
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.provider)
+			}
+
+			res, err := test.provider.Get(test.credential)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				if test.res == nil {
+					assert.Nil(t, res)
+				} else {
+					assert.Equal(t, test.res, res)
+				}
+
+				if test.assertFunc != nil {
+					test.assertFunc(t, test.provider)
+				}
+			}
+		})
+	}
+}
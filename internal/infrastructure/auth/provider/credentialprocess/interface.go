@@ -0,0 +1,8 @@
+package credentialprocess
+
+import "context"
+
+// CommandRunner is the interface for the runners that execute the credential process commands
+type CommandRunner interface {
+	Run(ctx context.Context, command string) ([]byte, error)
+}
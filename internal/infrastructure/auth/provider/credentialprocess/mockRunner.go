@@ -0,0 +1,28 @@
+package credentialprocess
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCommandRunner is a mock of the credential process command runner
+type MockCommandRunner struct {
+	mock.Mock
+}
+
+// NewMockCommandRunner returns a new MockCommandRunner
+func NewMockCommandRunner() *MockCommandRunner {
+	return &MockCommandRunner{}
+}
+
+// Run provides a mock function
+func (r *MockCommandRunner) Run(ctx context.Context, command string) ([]byte, error) {
+	args := r.Called(ctx, command)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package credentialprocess
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DefaultShell is the shell used to execute the credential process commands
+	DefaultShell = "sh"
+)
+
+// ShellCommandRunner executes the credential process commands through a shell, so the commands could use quotes, pipes or environment variables
+type ShellCommandRunner struct {
+	shell string
+}
+
+// NewShellCommandRunner returns a new ShellCommandRunner
+func NewShellCommandRunner() *ShellCommandRunner {
+	return &ShellCommandRunner{
+		shell: DefaultShell,
+	}
+}
+
+// Run executes the command and returns its stdout. The command stderr is not captured as output, but it is included in the error message when the command fails
+func (r *ShellCommandRunner) Run(ctx context.Context, command string) ([]byte, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	errContext := "(auth::provider::credentialprocess::ShellCommandRunner::Run)"
+
+	if command == "" {
+		return nil, errors.New(errContext, "To run a credential process, a command must be provided")
+	}
+
+	cmd := exec.CommandContext(ctx, r.shell, "-c", command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error running credential process '%s'. %s", command, strings.TrimSpace(stderr.String())), err)
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+package credentialprocess
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	errContext := "(auth::provider::credentialprocess::ShellCommandRunner::Run)"
+
+	tests := []struct {
+		desc    string
+		runner  *ShellCommandRunner
+		command string
+		res     string
+		err     error
+	}{
+		{
+			desc:    "Testing run a credential process command",
+			runner:  NewShellCommandRunner(),
+			command: `echo '{"username":"username","password":"password"}'`,
+			res:     "{\"username\":\"username\",\"password\":\"password\"}\n",
+		},
+		{
+			desc:    "Testing error running a credential process command that fails",
+			runner:  NewShellCommandRunner(),
+			command: "echo 'token expired' >&2; exit 1",
+			err: errors.New(errContext, "Error running credential process 'echo 'token expired' >&2; exit 1'. token expired",
+				errors.New("", "exit status 1")),
+		},
+		{
+			desc:    "Testing error running an empty credential process command",
+			runner:  NewShellCommandRunner(),
+			command: "",
+			err:     errors.New(errContext, "To run a credential process, a command must be provided"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.runner.Run(context.TODO(), test.command)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.res, string(res))
+			}
+		})
+	}
+}
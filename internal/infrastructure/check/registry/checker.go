@@ -0,0 +1,508 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+const (
+	// DefaultScheme is the scheme used to reach the registries
+	DefaultScheme = "https"
+
+	// dockerHubRegistryHost is the host that serves the Registry HTTP API v2 for Docker Hub
+	dockerHubRegistryHost = "registry-1.docker.io"
+	// maxErrorBodySize is the maximum size read from the registry error responses
+	maxErrorBodySize = 64 * 1024
+)
+
+// OptionsFunc defines the signature for an option function to set the registry checker attributes
+type OptionsFunc func(*RegistryChecker)
+
+// RegistryChecker checks the credentials against the Docker registries through the Registry HTTP API v2. It pings the registry, negotiates the authorization it requires and, when a repository is given, verifies the access to the repository
+type RegistryChecker struct {
+	client HTTPClienter
+	scheme string
+	now    func() time.Time
+}
+
+// NewRegistryChecker returns a new RegistryChecker
+func NewRegistryChecker(client HTTPClienter, opts ...OptionsFunc) *RegistryChecker {
+	c := &RegistryChecker{
+		client: client,
+		scheme: DefaultScheme,
+		now:    time.Now,
+	}
+	c.Options(opts...)
+
+	return c
+}
+
+// WithScheme sets the scheme used to reach the registries
+func WithScheme(scheme string) OptionsFunc {
+	return func(c *RegistryChecker) {
+		c.scheme = scheme
+	}
+}
+
+// Options configures the registry checker
+func (c *RegistryChecker) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// Check returns the result of accessing the registry using the username and password. When a repository is provided, it also checks the access to the repository for the scope, either pull or push. The registry failures are reported on the result status, and the error is only returned when the check can not be performed
+func (c *RegistryChecker) Check(ctx context.Context, registry, username, password, repository, scope string) (*credentials.CheckResult, error) {
+
+	var authorization string
+	var result *credentials.CheckResult
+
+	errContext := "(registry::RegistryChecker::Check)"
+
+	if c.client == nil {
+		return nil, errors.New(errContext, "To check the credentials, an HTTP client is required")
+	}
+
+	if registry == "" {
+		return nil, errors.New(errContext, "To check the credentials, a registry is required")
+	}
+
+	if repository != "" && scope == "" {
+		scope = credentials.CheckScopePull
+	}
+
+	if scope != "" && scope != credentials.CheckScopePull && scope != credentials.CheckScopePush {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid check scope '%s'. Supported scopes are: %s, %s", scope, credentials.CheckScopePull, credentials.CheckScopePush))
+	}
+
+	host := registryHost(registry)
+	checker := &check{
+		checker:  c,
+		host:     host,
+		username: username,
+		password: password,
+	}
+
+	authorization, result = checker.authorize(ctx, repository, scope)
+	if result == nil && repository != "" {
+		result = checker.checkScope(ctx, authorization, repository, scope)
+	}
+
+	if result == nil {
+		result = &credentials.CheckResult{
+			Status:  credentials.CheckStatusOK,
+			Message: "Registry access granted",
+		}
+
+		if repository != "" {
+			result.Message = fmt.Sprintf("Registry access granted to %s '%s'", scopeDescription(scope), repository)
+		}
+	}
+
+	result.Registry = registry
+	result.Repository = repository
+	if repository != "" {
+		result.Scope = scope
+	}
+
+	return result, nil
+}
+
+// check holds the attributes of a single credentials check
+type check struct {
+	checker  *RegistryChecker
+	host     string
+	username string
+	password string
+}
+
+// authorize negotiates the authorization required by the registry. It returns the authorization header value to use on the next requests, or the check result when the authorization is not granted
+func (c *check) authorize(ctx context.Context, repository, scope string) (string, *credentials.CheckResult) {
+
+	resp, err := c.do(ctx, http.MethodGet, c.url("/v2/"), "")
+	if err != nil {
+		return "", unreachable(fmt.Sprintf("Registry '%s' could not be reached. %s", c.host, err.Error()))
+	}
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return "", nil
+	case http.StatusUnauthorized:
+	default:
+		return "", failed(fmt.Sprintf("Registry '%s' returned an unexpected status code %d", c.host, resp.StatusCode))
+	}
+
+	if c.username == "" && c.password == "" {
+		return "", c.unauthorized(fmt.Sprintf("Registry '%s' requires credentials", c.host), "")
+	}
+
+	authorization, result := c.negotiate(ctx, resp.Header.Get("WWW-Authenticate"), repository, scope)
+	if result != nil {
+		return "", result
+	}
+
+	return c.verify(ctx, authorization)
+}
+
+// negotiate returns the authorization header value required by the registry challenge, or the check result when the authorization is not granted
+func (c *check) negotiate(ctx context.Context, header, repository, scope string) (string, *credentials.CheckResult) {
+
+	challenge, params := parseChallenge(header)
+	switch strings.ToLower(challenge) {
+	case "basic":
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)), nil
+
+	case "bearer":
+		token, result := c.fetchToken(ctx, params, repository, scope)
+		if result != nil {
+			return "", result
+		}
+		return "Bearer " + token, nil
+
+	default:
+		return "", failed(fmt.Sprintf("Registry '%s' requires an unsupported authorization scheme '%s'", c.host, challenge))
+	}
+}
+
+// verify requests the registry using the authorization to confirm that it is accepted
+func (c *check) verify(ctx context.Context, authorization string) (string, *credentials.CheckResult) {
+
+	resp, err := c.do(ctx, http.MethodGet, c.url("/v2/"), authorization)
+	if err != nil {
+		return "", unreachable(fmt.Sprintf("Registry '%s' could not be reached. %s", c.host, err.Error()))
+	}
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return authorization, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", c.unauthorized(fmt.Sprintf("Registry '%s' rejected the credentials", c.host), readErrorBody(resp))
+	default:
+		return "", failed(fmt.Sprintf("Registry '%s' returned an unexpected status code %d", c.host, resp.StatusCode))
+	}
+}
+
+// fetchToken requests a bearer token to the authorization service defined on the challenge
+func (c *check) fetchToken(ctx context.Context, params map[string]string, repository, scope string) (string, *credentials.CheckResult) {
+
+	realm, exists := params["realm"]
+	if !exists {
+		return "", failed(fmt.Sprintf("Registry '%s' authorization challenge does not define a realm", c.host))
+	}
+
+	realmURL, err := url.Parse(realm)
+	if err != nil {
+		return "", failed(fmt.Sprintf("Registry '%s' authorization realm '%s' is not valid", c.host, realm))
+	}
+
+	query := realmURL.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	if repository != "" {
+		query.Set("scope", fmt.Sprintf("repository:%s:%s", repository, scopeActions(scope)))
+	}
+	realmURL.RawQuery = query.Encode()
+
+	authorization := "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password))
+	resp, err := c.do(ctx, http.MethodGet, realmURL.String(), authorization)
+	if err != nil {
+		return "", unreachable(fmt.Sprintf("Authorization service '%s' could not be reached. %s", realm, err.Error()))
+	}
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", c.unauthorized(fmt.Sprintf("Authorization service '%s' rejected the credentials", realm), readErrorBody(resp))
+	default:
+		return "", failed(fmt.Sprintf("Authorization service '%s' returned an unexpected status code %d", realm, resp.StatusCode))
+	}
+
+	tokenResponse := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+
+	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+	if err != nil {
+		return "", failed(fmt.Sprintf("Token response from '%s' could not be decoded. %s", realm, err.Error()))
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+
+	return "", failed(fmt.Sprintf("Token response from '%s' does not contain any token", realm))
+}
+
+// checkScope checks the access to the repository for the scope. The pull access is checked listing the repository tags, and the push access is checked starting a blob upload that is cancelled right away
+func (c *check) checkScope(ctx context.Context, authorization, repository, scope string) *credentials.CheckResult {
+
+	resp, err := c.do(ctx, http.MethodGet, c.url(fmt.Sprintf("/v2/%s/tags/list", repository)), authorization)
+	if err != nil {
+		return unreachable(fmt.Sprintf("Registry '%s' could not be reached. %s", c.host, err.Error()))
+	}
+	drainAndClose(resp)
+
+	// registries that allow anonymous access to the API could still require authorization for the repository
+	if resp.StatusCode == http.StatusUnauthorized && authorization == "" && (c.username != "" || c.password != "") {
+		var result *credentials.CheckResult
+
+		authorization, result = c.negotiate(ctx, resp.Header.Get("WWW-Authenticate"), repository, scope)
+		if result != nil {
+			return result
+		}
+
+		resp, err = c.do(ctx, http.MethodGet, c.url(fmt.Sprintf("/v2/%s/tags/list", repository)), authorization)
+		if err != nil {
+			return unreachable(fmt.Sprintf("Registry '%s' could not be reached. %s", c.host, err.Error()))
+		}
+		drainAndClose(resp)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// pushing creates the repository, then it could not exist yet
+		if scope != credentials.CheckScopePush {
+			return failed(fmt.Sprintf("Repository '%s' does not exist on registry '%s'", repository, c.host))
+		}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return c.unauthorized(fmt.Sprintf("Pull access to repository '%s' is denied", repository), "")
+	default:
+		return failed(fmt.Sprintf("Registry '%s' returned an unexpected status code %d listing the tags of '%s'", c.host, resp.StatusCode, repository))
+	}
+
+	if scope != credentials.CheckScopePush {
+		return nil
+	}
+
+	resp, err = c.do(ctx, http.MethodPost, c.url(fmt.Sprintf("/v2/%s/blobs/uploads/", repository)), authorization)
+	if err != nil {
+		return unreachable(fmt.Sprintf("Registry '%s' could not be reached. %s", c.host, err.Error()))
+	}
+	drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		c.cancelUpload(ctx, authorization, resp)
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return c.unauthorized(fmt.Sprintf("Push access to repository '%s' is denied", repository), "")
+	default:
+		return failed(fmt.Sprintf("Registry '%s' returned an unexpected status code %d starting an upload to '%s'", c.host, resp.StatusCode, repository))
+	}
+}
+
+// cancelUpload cancels the blob upload started to check the push access. The upload is abandoned when it could not be cancelled, and the registry discards it eventually
+func (c *check) cancelUpload(ctx context.Context, authorization string, resp *http.Response) {
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return
+	}
+
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return
+	}
+
+	cancelResp, err := c.do(ctx, http.MethodDelete, resp.Request.URL.ResolveReference(locationURL).String(), authorization)
+	if err != nil {
+		return
+	}
+	drainAndClose(cancelResp)
+}
+
+// unauthorized returns the result for the credentials rejected by the registry. The credentials are reported as expired when the registry says so, or when the password is a token whose expiration time has passed
+func (c *check) unauthorized(message, body string) *credentials.CheckResult {
+
+	if strings.Contains(strings.ToLower(body), "expired") {
+		return &credentials.CheckResult{
+			Status:  credentials.CheckStatusExpired,
+			Message: fmt.Sprintf("%s. The credentials are expired", message),
+		}
+	}
+
+	expiresAt, isToken := tokenExpiration(c.password)
+	if isToken && !expiresAt.After(c.checker.now()) {
+		return &credentials.CheckResult{
+			Status:  credentials.CheckStatusExpired,
+			Message: fmt.Sprintf("%s. The credentials expired at %s", message, expiresAt.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	return &credentials.CheckResult{
+		Status:  credentials.CheckStatusUnauthorized,
+		Message: message,
+	}
+}
+
+// do executes a request to the registry
+func (c *check) do(ctx context.Context, method, url, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return c.checker.client.Do(req)
+}
+
+// url returns the absolute url for the path on the registry
+func (c *check) url(path string) string {
+	return fmt.Sprintf("%s://%s%s", c.checker.scheme, c.host, path)
+}
+
+// registryHost returns the host that serves the Registry HTTP API v2 for the registry
+func registryHost(registry string) string {
+	host := credentials.ServerHost(registry)
+	if host == credentials.DockerHubHost {
+		return dockerHubRegistryHost
+	}
+
+	return host
+}
+
+// scopeActions returns the actions requested to the authorization service for the scope
+func scopeActions(scope string) string {
+	if scope == credentials.CheckScopePush {
+		return "pull,push"
+	}
+
+	return "pull"
+}
+
+// scopeDescription returns a readable description of the scope
+func scopeDescription(scope string) string {
+	if scope == credentials.CheckScopePush {
+		return "pull and push to"
+	}
+
+	return "pull from"
+}
+
+// tokenExpiration returns the expiration time of a password that is a JSON Web Token, and whether the password is such a token
+func tokenExpiration(password string) (time.Time, bool) {
+
+	parts := strings.Split(password, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	claims := struct {
+		ExpiresAt int64 `json:"exp"`
+	}{}
+
+	err = json.Unmarshal(payload, &claims)
+	if err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.ExpiresAt, 0), true
+}
+
+// unreachable returns the result for a registry that could not be reached
+func unreachable(message string) *credentials.CheckResult {
+	return &credentials.CheckResult{
+		Status:  credentials.CheckStatusUnreachable,
+		Message: message,
+	}
+}
+
+// failed returns the result for a check that could not be completed
+func failed(message string) *credentials.CheckResult {
+	return &credentials.CheckResult{
+		Status:  credentials.CheckStatusError,
+		Message: message,
+	}
+}
+
+// readErrorBody returns the content of an error response
+func readErrorBody(resp *http.Response) string {
+	if resp == nil || resp.Body == nil {
+		return ""
+	}
+
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	return string(content)
+}
+
+// parseChallenge returns the authorization scheme and its parameters from a WWW-Authenticate header value
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	challenge = strings.TrimSpace(challenge)
+	idx := strings.IndexRune(challenge, ' ')
+	if idx < 0 {
+		return challenge, params
+	}
+
+	scheme := challenge[:idx]
+	for _, param := range splitChallengeParams(challenge[idx+1:]) {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), "\"")
+	}
+
+	return scheme, params
+}
+
+// splitChallengeParams splits the challenge parameters by comma, ignoring the commas within quoted values
+func splitChallengeParams(params string) []string {
+	list := []string{}
+	quoted := false
+	start := 0
+
+	for i, r := range params {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				list = append(list, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+	list = append(list, params[start:])
+
+	return list
+}
+
+// drainAndClose consumes and closes the response body to reuse the connection
+func drainAndClose(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,374 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+// registryStandIn is an in-process Docker registry that implements the subset of the Registry HTTP API v2 used by the registry checker
+type registryStandIn struct {
+	server *httptest.Server
+
+	// scheme is the authorization scheme required by the registry, either basic or bearer
+	scheme   string
+	username string
+	password string
+	// pushers are the users allowed to push
+	pushers map[string]bool
+	// repositories are the existing repositories
+	repositories map[string]bool
+	// expired makes the registry report that the credentials are expired
+	expired bool
+
+	cancelledUploads int
+}
+
+func newRegistryStandIn(scheme, username, password string) *registryStandIn {
+	r := &registryStandIn{
+		scheme:       scheme,
+		username:     username,
+		password:     password,
+		pushers:      map[string]bool{},
+		repositories: map[string]bool{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+
+	return r
+}
+
+func (r *registryStandIn) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func (r *registryStandIn) serveHTTP(w http.ResponseWriter, req *http.Request) {
+
+	if req.URL.Path == "/token" {
+		r.serveToken(w, req)
+		return
+	}
+
+	user, authorized := r.authorized(req)
+	if !authorized {
+		if r.scheme == "bearer" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="stand-in"`, r.server.URL))
+		} else {
+			w.Header().Set("WWW-Authenticate", `Basic realm="stand-in"`)
+		}
+
+		w.WriteHeader(http.StatusUnauthorized)
+		if r.expired {
+			fmt.Fprint(w, `{"errors":[{"code":"UNAUTHORIZED","message":"authentication token has expired"}]}`)
+		}
+		return
+	}
+
+	switch {
+	case req.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+
+	case strings.HasSuffix(req.URL.Path, "/tags/list"):
+		repository := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v2/"), "/tags/list")
+		if !r.repositories[repository] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+
+	case strings.HasSuffix(req.URL.Path, "/blobs/uploads/") && req.Method == http.MethodPost:
+		if !r.pushers[user] {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Location", req.URL.Path+"upload-id")
+		w.WriteHeader(http.StatusAccepted)
+
+	case strings.HasSuffix(req.URL.Path, "/upload-id") && req.Method == http.MethodDelete:
+		r.cancelledUploads++
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *registryStandIn) serveToken(w http.ResponseWriter, req *http.Request) {
+	username, password, _ := req.BasicAuth()
+	if r.expired || username != r.username || password != r.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"token": "token-" + username})
+}
+
+func (r *registryStandIn) authorized(req *http.Request) (string, bool) {
+	if r.expired {
+		return "", false
+	}
+
+	authorization := req.Header.Get("Authorization")
+
+	if r.scheme == "bearer" {
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if token != "token-"+r.username {
+			return "", false
+		}
+		return r.username, true
+	}
+
+	username, password, ok := req.BasicAuth()
+	if !ok || username != r.username || password != r.password {
+		return "", false
+	}
+
+	return username, true
+}
+
+func TestCheck(t *testing.T) {
+	errContext := "(registry::RegistryChecker::Check)"
+
+	expiredToken := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1704067200}`)) + ".signature"
+
+	tests := []struct {
+		desc              string
+		registry          *registryStandIn
+		checker           *RegistryChecker
+		username          string
+		password          string
+		repository        string
+		scope             string
+		prepareAssertFunc func(*registryStandIn)
+		assertFunc        func(*testing.T, *registryStandIn)
+		res               *credentials.CheckResult
+		err               error
+	}{
+		{
+			desc:     "Testing error checking credentials without http client",
+			registry: newRegistryStandIn("basic", "user", "pass"),
+			checker:  NewRegistryChecker(nil),
+			err:      errors.New(errContext, "To check the credentials, an HTTP client is required"),
+		},
+		{
+			desc:     "Testing error checking credentials with an invalid scope",
+			registry: newRegistryStandIn("basic", "user", "pass"),
+			checker:  NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			scope:    "delete",
+			err:      errors.New(errContext, "Invalid check scope 'delete'. Supported scopes are: pull, push"),
+		},
+		{
+			desc:     "Testing check credentials on a registry using basic authorization",
+			registry: newRegistryStandIn("basic", "user", "pass"),
+			checker:  NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username: "user",
+			password: "pass",
+			res: &credentials.CheckResult{
+				Status:  credentials.CheckStatusOK,
+				Message: "Registry access granted",
+			},
+		},
+		{
+			desc:     "Testing check unauthorized credentials on a registry using basic authorization",
+			registry: newRegistryStandIn("basic", "user", "pass"),
+			checker:  NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username: "user",
+			password: "wrong",
+			res: &credentials.CheckResult{
+				Status:  credentials.CheckStatusUnauthorized,
+				Message: "Registry '%s' rejected the credentials",
+			},
+		},
+		{
+			desc:       "Testing check credentials with pull access on a registry using token authorization",
+			registry:   newRegistryStandIn("bearer", "user", "pass"),
+			checker:    NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username:   "user",
+			password:   "pass",
+			repository: "team/app",
+			prepareAssertFunc: func(r *registryStandIn) {
+				r.repositories["team/app"] = true
+			},
+			res: &credentials.CheckResult{
+				Repository: "team/app",
+				Scope:      credentials.CheckScopePull,
+				Status:     credentials.CheckStatusOK,
+				Message:    "Registry access granted to pull from 'team/app'",
+			},
+		},
+		{
+			desc:       "Testing check credentials with push access on a registry using token authorization",
+			registry:   newRegistryStandIn("bearer", "user", "pass"),
+			checker:    NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username:   "user",
+			password:   "pass",
+			repository: "team/new-app",
+			scope:      credentials.CheckScopePush,
+			prepareAssertFunc: func(r *registryStandIn) {
+				r.pushers["user"] = true
+			},
+			assertFunc: func(t *testing.T, r *registryStandIn) {
+				assert.Equal(t, 1, r.cancelledUploads)
+			},
+			res: &credentials.CheckResult{
+				Repository: "team/new-app",
+				Scope:      credentials.CheckScopePush,
+				Status:     credentials.CheckStatusOK,
+				Message:    "Registry access granted to pull and push to 'team/new-app'",
+			},
+		},
+		{
+			desc:       "Testing check credentials without push access",
+			registry:   newRegistryStandIn("bearer", "user", "pass"),
+			checker:    NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username:   "user",
+			password:   "pass",
+			repository: "team/app",
+			scope:      credentials.CheckScopePush,
+			prepareAssertFunc: func(r *registryStandIn) {
+				r.repositories["team/app"] = true
+			},
+			res: &credentials.CheckResult{
+				Repository: "team/app",
+				Scope:      credentials.CheckScopePush,
+				Status:     credentials.CheckStatusUnauthorized,
+				Message:    "Push access to repository 'team/app' is denied",
+			},
+		},
+		{
+			desc:       "Testing check credentials to pull from a repository that does not exist",
+			registry:   newRegistryStandIn("bearer", "user", "pass"),
+			checker:    NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username:   "user",
+			password:   "pass",
+			repository: "team/app",
+			res: &credentials.CheckResult{
+				Repository: "team/app",
+				Scope:      credentials.CheckScopePull,
+				Status:     credentials.CheckStatusError,
+				Message:    "Repository 'team/app' does not exist on registry '%s'",
+			},
+		},
+		{
+			desc:     "Testing check credentials reported as expired by the registry",
+			registry: newRegistryStandIn("basic", "user", "pass"),
+			checker:  NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username: "user",
+			password: "pass",
+			prepareAssertFunc: func(r *registryStandIn) {
+				r.expired = true
+			},
+			res: &credentials.CheckResult{
+				Status:  credentials.CheckStatusExpired,
+				Message: "Registry '%s' rejected the credentials. The credentials are expired",
+			},
+		},
+		{
+			desc:     "Testing check credentials whose password is an expired token",
+			registry: newRegistryStandIn("bearer", "user", "pass"),
+			checker:  NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username: "user",
+			password: expiredToken,
+			res: &credentials.CheckResult{
+				Status:  credentials.CheckStatusExpired,
+				Message: "Authorization service '%s/token' rejected the credentials. The credentials expired at 2024-01-01T00:00:00Z",
+			},
+		},
+		{
+			desc:     "Testing check credentials on an unreachable registry",
+			registry: newRegistryStandIn("basic", "user", "pass"),
+			checker:  NewRegistryChecker(&http.Client{}, WithScheme("http")),
+			username: "user",
+			password: "pass",
+			prepareAssertFunc: func(r *registryStandIn) {
+				r.server.Close()
+			},
+			res: &credentials.CheckResult{
+				Status: credentials.CheckStatusUnreachable,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			defer test.registry.server.Close()
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.registry)
+			}
+
+			test.checker.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+			res, err := test.checker.Check(context.TODO(), test.registry.host(), test.username, test.password, test.repository, test.scope)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+
+			assert.Nil(t, test.err)
+			assert.Equal(t, test.registry.host(), res.Registry)
+			assert.Equal(t, test.res.Repository, res.Repository)
+			assert.Equal(t, test.res.Scope, res.Scope)
+			assert.Equal(t, test.res.Status, res.Status)
+
+			// the unreachable message depends on the network error
+			if test.res.Status != credentials.CheckStatusUnreachable {
+				message := test.res.Message
+				if strings.Contains(message, "%s") {
+					message = fmt.Sprintf(message, test.registry.server.URL)
+					if !strings.Contains(test.res.Message, "/token") {
+						message = fmt.Sprintf(test.res.Message, test.registry.host())
+					}
+				}
+				assert.Equal(t, message, res.Message)
+			}
+
+			if test.assertFunc != nil {
+				test.assertFunc(t, test.registry)
+			}
+		})
+	}
+}
+
+func TestRegistryHost(t *testing.T) {
+	tests := []struct {
+		desc     string
+		registry string
+		res      string
+	}{
+		{
+			desc:     "Testing registry host of a registry",
+			registry: "registry.example.com",
+			res:      "registry.example.com",
+		},
+		{
+			desc:     "Testing registry host of a path-scoped registry",
+			registry: "registry.example.com/team-a",
+			res:      "registry.example.com",
+		},
+		{
+			desc:     "Testing registry host of Docker Hub",
+			registry: "https://index.docker.io/v1/",
+			res:      dockerHubRegistryHost,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, registryHost(test.registry))
+		})
+	}
+}
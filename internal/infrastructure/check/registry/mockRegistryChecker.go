@@ -0,0 +1,29 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRegistryChecker is a mock of the registry checker
+type MockRegistryChecker struct {
+	mock.Mock
+}
+
+// NewMockRegistryChecker returns a new MockRegistryChecker
+func NewMockRegistryChecker() *MockRegistryChecker {
+	return &MockRegistryChecker{}
+}
+
+// Check provides a mock function with given fields: ctx, registry, username, password, repository, scope
+func (m *MockRegistryChecker) Check(ctx context.Context, registry, username, password, repository, scope string) (*credentials.CheckResult, error) {
+	args := m.Called(ctx, registry, username, password, repository, scope)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*credentials.CheckResult), args.Error(1)
+}
@@ -117,6 +117,7 @@ func NewCommand(ctx context.Context, compatibility Compatibilitier, conf *config
 			handlerOptions.BuildOnCascade = buildFlagOptions.BuildOnCascade
 			handlerOptions.CascadeDepth = buildFlagOptions.CascadeDepth
 			handlerOptions.EnableSemanticVersionTags = buildFlagOptions.EnableSemanticVersionTags
+			handlerOptions.Force = buildFlagOptions.Force
 			handlerOptions.ImageFromName = buildFlagOptions.ImageFromName
 			handlerOptions.ImageFromRegistryHost = buildFlagOptions.ImageFromRegistryHost
 			handlerOptions.ImageFromRegistryNamespace = buildFlagOptions.ImageFromRegistryNamespace
@@ -188,6 +189,7 @@ func NewCommand(ctx context.Context, compatibility Compatibilitier, conf *config
 	buildCmd.Flags().BoolVar(&buildFlagOptions.DEPRECATEDPushImages, "no-push", false, DeprecatedFlagMessagePushImages)
 	buildCmd.Flags().BoolVar(&buildFlagOptions.DryRun, "dry-run", false, "When this flag is enabled, the built is executed in dry-run mode")
 	buildCmd.Flags().BoolVar(&buildFlagOptions.EnableSemanticVersionTags, "enable-semver-tags", false, "When this flag is enabled, and main version is semver 2.0.0 compliance extra tag are created based on the semantic version tree")
+	buildCmd.Flags().BoolVar(&buildFlagOptions.Force, "force", false, "When this flag is enabled, the image tags are pushed even when they are immutable and they already exist on the registry")
 	buildCmd.Flags().BoolVar(&buildFlagOptions.PullParentImage, "pull-parent-image", false, "When this flag is enabled, parent image is pulled from docker registry")
 	buildCmd.Flags().BoolVar(&buildFlagOptions.PushImagesAfterBuild, "push-after-build", false, "When this flag is enabled, the image is pushed to docker registry after the build")
 	buildCmd.Flags().BoolVar(&buildFlagOptions.RemoveImagesAfterPush, "remove-local-images-after-push", false, "When this flag is enabled, images are removed from local after push")
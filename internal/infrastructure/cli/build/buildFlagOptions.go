@@ -22,6 +22,8 @@ type buildFlagOptions struct {
 	DryRun bool
 	// EnableSemanticVersionTags if is true semantic version tags are generated
 	EnableSemanticVersionTags bool
+	// Force if is true the image tags are pushed even when they are immutable
+	Force bool
 	// ImageFromName is the name of the image to use as source
 	ImageFromName string
 	// ImageFromRegistryHost is the host of the registry to use as source
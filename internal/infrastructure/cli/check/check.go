@@ -0,0 +1,31 @@
+package check
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to check stevedore elements
+func NewCommand(ctx context.Context, subcommands ...*command.StevedoreCommand) *command.StevedoreCommand {
+
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Stevedore command to check items",
+		Long:  "Stevedore command to check items",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command := &command.StevedoreCommand{
+		Command: checkCmd,
+	}
+
+	for _, subcommand := range subcommands {
+		command.AddCommand(subcommand)
+	}
+
+	return command
+}
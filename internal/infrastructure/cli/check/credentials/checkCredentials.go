@@ -0,0 +1,69 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/check/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	outputcredentialscheck "github.com/gostevedore/stevedore/internal/infrastructure/output/credentialscheck"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to check credentials
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	checkCredentialsFlagOptions := &checkCredentialsFlagOptions{}
+
+	checkCredentialsCmd := &cobra.Command{
+		Use: "credentials [id...]",
+		Aliases: []string{
+			"auth",
+			"badge",
+		},
+		Short: "Stevedore subcommand to check that credentials grant access to their registries",
+		Long: `
+Stevedore subcommand to check that credentials grant access to their registries.
+When no credentials id is provided, all the credentials from the credentials store are checked.
+The command fails when any of the checks fails.
+`,
+		Example: `
+Check all the credentials from the credentials store:
+  stevedore check credentials
+
+Check that the credentials for a private registry can push to a repository:
+  stevedore check credentials myregistry.example.com --repository myproject/myimage --scope push
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::check::credentials::RunE)"
+
+			entrypointOptions := &entrypoint.Options{
+				LocalStoragePath: checkCredentialsFlagOptions.LocalStoragePath,
+				Repository:       checkCredentialsFlagOptions.Repository,
+				Scope:            checkCredentialsFlagOptions.Scope,
+				Output:           checkCredentialsFlagOptions.Output,
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	checkCredentialsCmd.Flags().StringVar(&checkCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+	checkCredentialsCmd.Flags().StringVarP(&checkCredentialsFlagOptions.Repository, "repository", "r", "", "Repository, on each registry, whose access is checked")
+	checkCredentialsCmd.Flags().StringVar(&checkCredentialsFlagOptions.Scope, "scope", "", "Access checked on the repository. Supported scopes are: pull and push. When a repository is set, it defaults to pull")
+	checkCredentialsCmd.Flags().StringVarP(&checkCredentialsFlagOptions.Output, "output", "o", outputcredentialscheck.TableFormat, "Output format. Supported formats are: table, json and yaml")
+
+	command := &command.StevedoreCommand{
+		Command: checkCredentialsCmd,
+	}
+
+	return command
+}
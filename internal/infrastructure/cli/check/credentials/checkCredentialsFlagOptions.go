@@ -0,0 +1,13 @@
+package credentials
+
+// checkCredentialsFlagOptions is the options for the check credentials command
+type checkCredentialsFlagOptions struct {
+	// LocalStoragePath
+	LocalStoragePath string
+	// Repository
+	Repository string
+	// Scope
+	Scope string
+	// Output
+	Output string
+}
@@ -0,0 +1,91 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/check/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		desc            string
+		config          *configuration.Configuration
+		entrypoint      Entrypointer
+		prepareMockFunc func(Entrypointer, *configuration.Configuration)
+		args            []string
+		err             error
+	}{
+		{
+			desc:       "Testing run check credentials command",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args:       []string{},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{},
+					conf,
+					&entrypoint.Options{
+						Output: "table",
+					},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing run check credentials command with flags",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"credential-id",
+				"--local-storage-path",
+				"local-storage-path",
+				"--repository",
+				"project/image",
+				"--scope",
+				"push",
+				"--output",
+				"json",
+			},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{"credential-id"},
+					conf,
+					&entrypoint.Options{
+						LocalStoragePath: "local-storage-path",
+						Repository:       "project/image",
+						Scope:            "push",
+						Output:           "json",
+					},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareMockFunc != nil {
+				test.prepareMockFunc(test.entrypoint, test.config)
+			}
+
+			cmd := NewCommand(context.TODO(), test.config, test.entrypoint)
+			cmd.Command.ParseFlags(test.args)
+			err := cmd.Command.RunE(cmd.Command, test.args)
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.entrypoint.(*entrypoint.MockEntrypoint).AssertExpectations(t)
+			}
+		})
+	}
+}
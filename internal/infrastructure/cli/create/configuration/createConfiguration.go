@@ -32,6 +32,9 @@ Stevedore subcommand to create and initialize the configuration
 		Example: `
 Example setting all configuration parameters:
   stevedore create configuration --builders-path /builders --concurrency 4 --config /stevedore-config.yaml --credentials-format json --credentials-local-storage-path /credentials --credentials-storage-type local --enable-semver-tags --force --images-path /images --log-path-file /logs --push-images --semver-tags-template "{{ .Major }}" --semver-tags-template "{{ .Major }}_{{ .Minor }}"
+
+Example generating the credentials encryption key on a file, keeping it out of the configuration file:
+  stevedore create configuration --generate-credentials-encryption-key --credentials-encryption-key-file ~/.config/stevedore/encryption.key
 `,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			return nil
@@ -45,6 +48,9 @@ Example setting all configuration parameters:
 			entrypointOptions.Concurrency = createConfigurationFlagOptions.Concurrency
 			entrypointOptions.ConfigurationFilePath = createConfigurationFlagOptions.ConfigurationFilePath
 			entrypointOptions.CredentialsEncryptionKey = createConfigurationFlagOptions.CredentialsEncryptionKey
+			entrypointOptions.CredentialsEncryptionKeyCommand = createConfigurationFlagOptions.CredentialsEncryptionKeyCommand
+			entrypointOptions.CredentialsEncryptionKeyEnv = createConfigurationFlagOptions.CredentialsEncryptionKeyEnv
+			entrypointOptions.CredentialsEncryptionKeyFile = createConfigurationFlagOptions.CredentialsEncryptionKeyFile
 			entrypointOptions.CredentialsFormat = createConfigurationFlagOptions.CredentialsFormat
 			entrypointOptions.CredentialsLocalStoragePath = createConfigurationFlagOptions.CredentialsLocalStoragePath
 			entrypointOptions.CredentialsStorageType = createConfigurationFlagOptions.CredentialsStorageType
@@ -71,6 +77,9 @@ Example setting all configuration parameters:
 	createConfigurationCmd.PersistentFlags().StringVarP(&createConfigurationFlagOptions.ConfigurationFilePath, "config", "C", "", "Configuration file location path")
 	createConfigurationCmd.Flags().IntVarP(&createConfigurationFlagOptions.Concurrency, "concurrency", "c", defaultConfiguration.Concurrency, fmt.Sprintf("It defines the number of concurrent workers created to build images. Its default value is '%d'", defaultConfiguration.Concurrency))
 	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsEncryptionKey, "credentials-encryption-key", "", "Is the encryption key used on the credentials store")
+	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsEncryptionKeyCommand, "credentials-encryption-key-command", "", "Command that prints the encryption key used on the credentials store. It is not compatible with providing or generating an encryption key")
+	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsEncryptionKeyEnv, "credentials-encryption-key-env", "", "Environment variable that contains the encryption key used on the credentials store. It is not compatible with providing or generating an encryption key")
+	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsEncryptionKeyFile, "credentials-encryption-key-file", "", "File that contains the encryption key used on the credentials store. When the encryption key is provided or generated, it is written on that file instead of the configuration file")
 	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsFormat, "credentials-format", defaultConfiguration.Credentials.Format, fmt.Sprintf("Format used to store credentials. The accepted formats are: %s and %s", credentials.JSONFormat, credentials.YAMLFormat))
 	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsLocalStoragePath, "credentials-local-storage-path", defaultConfiguration.Credentials.LocalStoragePath, fmt.Sprintf("When is used the '%s' storage, it defines the path to store the credentials. Its default value is '%s'", credentials.LocalStore, defaultConfiguration.Credentials.LocalStoragePath))
 	createConfigurationCmd.Flags().StringVar(&createConfigurationFlagOptions.CredentialsStorageType, "credentials-storage-type", defaultConfiguration.Credentials.StorageType, fmt.Sprintf("It defines the storage type. Its default value is '%s'", defaultConfiguration.Credentials.StorageType))
@@ -6,6 +6,9 @@ type createConfigurationFlagOptions struct {
 	Concurrency                      int
 	ConfigurationFilePath            string
 	CredentialsEncryptionKey         string
+	CredentialsEncryptionKeyCommand  string
+	CredentialsEncryptionKeyEnv      string
+	CredentialsEncryptionKeyFile     string
 	CredentialsFormat                string
 	CredentialsLocalStoragePath      string
 	CredentialsStorageType           string
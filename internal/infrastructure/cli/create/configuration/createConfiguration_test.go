@@ -46,6 +46,8 @@ func TestNewCommand(t *testing.T) {
 				"--credentials-encryption-key",
 				"credentials-encryption-key",
 				"--generate-credentials-encryption-key",
+				"--credentials-encryption-key-file",
+				"/secrets/encryption.key",
 			},
 			prepareMockFunc: func(e Entrypointer) {
 				e.(*entrypoint.MockCreateConfigurationEntrypoint).On(
@@ -56,6 +58,7 @@ func TestNewCommand(t *testing.T) {
 						Concurrency:                      4,
 						ConfigurationFilePath:            "/stevedore-config.yaml",
 						CredentialsEncryptionKey:         "credentials-encryption-key",
+						CredentialsEncryptionKeyFile:     "/secrets/encryption.key",
 						CredentialsFormat:                "json",
 						CredentialsLocalStoragePath:      "/credentials",
 						CredentialsStorageType:           "local",
@@ -37,6 +37,21 @@ Create credentials to authenticate through basic auth into a private registry:
 
 Create credentials to achieve credentials from AWS ECR using the default credentials chain:
   stevedore create credentials ecr-host --aws-region eu-west-1 --aws-use-default-credentials-chain
+
+Create credentials for any AWS ECR registry on a region, using a registry host pattern as id:
+  stevedore create credentials '*.dkr.ecr.eu-west-1.amazonaws.com' --aws-region eu-west-1 --aws-use-default-credentials-chain
+
+Create credentials to authenticate using a bearer token:
+  stevedore create credentials myregistry --username robot --token token
+
+Create credentials achieved by executing a command that prints the username and password as JSON:
+  stevedore create credentials myregistry --credential-process 'credential-broker myregistry'
+
+Create credentials that expire in 90 days, with a description to remember their purpose:
+  stevedore create credentials myregistry --username robot --expires 90d --description "CI robot account"
+
+Create credentials scoped to a registry namespace. They take precedence over the registry host credentials for the images on that namespace:
+  stevedore create credentials registry.example.com/team-a --username username
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
@@ -80,12 +95,27 @@ Create credentials to achieve credentials from AWS ECR using the default credent
 			if createCredentialsFlagOptions.AWSUseDefaultCredentialsChain {
 				handlerOptions.AWSUseDefaultCredentialsChain = createCredentialsFlagOptions.AWSUseDefaultCredentialsChain
 			}
+			if createCredentialsFlagOptions.CredentialProcess != "" {
+				handlerOptions.CredentialProcess = createCredentialsFlagOptions.CredentialProcess
+			}
+			if createCredentialsFlagOptions.Description != "" {
+				handlerOptions.Description = createCredentialsFlagOptions.Description
+			}
+			if createCredentialsFlagOptions.Expires != "" {
+				handlerOptions.ExpiresAt = createCredentialsFlagOptions.Expires
+			}
 			if createCredentialsFlagOptions.GitSSHUser != "" {
 				handlerOptions.GitSSHUser = createCredentialsFlagOptions.GitSSHUser
 			}
 			if createCredentialsFlagOptions.PrivateKeyFile != "" {
 				handlerOptions.PrivateKeyFile = createCredentialsFlagOptions.PrivateKeyFile
 			}
+			if createCredentialsFlagOptions.RefreshToken != "" {
+				handlerOptions.RefreshToken = createCredentialsFlagOptions.RefreshToken
+			}
+			if createCredentialsFlagOptions.Token != "" {
+				handlerOptions.Token = createCredentialsFlagOptions.Token
+			}
 			if createCredentialsFlagOptions.Username != "" {
 				handlerOptions.Username = createCredentialsFlagOptions.Username
 			}
@@ -119,9 +149,14 @@ Create credentials to achieve credentials from AWS ECR using the default credent
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.AWSProfile, "aws-profile", "", "AWS Profile to achieve credentials from AWS")
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.AWSRegion, "aws-region", "", "AWS Region to achieve credentials from AWS")
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.AWSRoleARN, "aws-role-arn", "", "AWS Role ARN to achieve credentials from AWS")
+	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.CredentialProcess, "credential-process", "", "Command executed to achieve the username and password. It must print a JSON object with the 'username', 'password' and, optionally, 'expires_at' attributes")
+	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.Description, "description", "", "Description of the credentials")
+	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.Expires, "expires", "", "Credentials expiration. It could be a date, such as '2024-12-31' or '2024-12-31T00:00:00Z', or a duration from now, such as '90d'")
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.GitSSHUser, "git-ssh-user", "", "Git SSH User")
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.PrivateKeyFile, "private-key-file", "", "Private Key File")
+	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.RefreshToken, "refresh-token", "", "OAuth2 refresh token, also known as identity token, exchanged by an access token on the registry authorization service")
+	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.Token, "token", "", "Bearer token sent to the registry. It could be used along with the username flag")
 	createCredentialsCmd.Flags().StringVar(&createCredentialsFlagOptions.Username, "username", "", "Username for basic auth method. Password is going to be requested")
 
 	createCredentialsCmd.Flags().StringVarP(&createCredentialsFlagOptions.DEPRECATEDDockerRegistryCredentialsDir, "credentials-dir", "d", "", DeprecatedFlagMessageDockerRegistryCredentialsDir)
@@ -20,12 +20,22 @@ type createCredentialsFlagOptions struct {
 	AWSSharedCredentialsFiles []string
 	// AWSUseDefaultCredentialsChain
 	AWSUseDefaultCredentialsChain bool
+	// CredentialProcess
+	CredentialProcess string
+	// Description
+	Description string
+	// Expires
+	Expires string
 	// GitSSHUser
 	GitSSHUser string
 	// LocalStoragePath
 	LocalStoragePath string
 	// PrivateKeyFile
 	PrivateKeyFile string
+	// RefreshToken
+	RefreshToken string
+	// Token
+	Token string
 	// Username
 	Username string
 	// Force
@@ -47,6 +47,10 @@ func TestNewCommand(t *testing.T) {
 				"aws-region",
 				"--aws-role-arn",
 				"aws-role-arn",
+				"--description",
+				"description",
+				"--expires",
+				"90d",
 				"--git-ssh-user",
 				"git-ssh-user",
 				"--local-storage-path",
@@ -80,6 +84,8 @@ func TestNewCommand(t *testing.T) {
 						AWSProfile:     "aws-profile",
 						AWSRegion:      "aws-region",
 						AWSRoleARN:     "aws-role-arn",
+						Description:    "description",
+						ExpiresAt:      "90d",
 						GitSSHUser:     "git-ssh-user",
 						PrivateKeyFile: "private-key-file",
 						Username:       "username",
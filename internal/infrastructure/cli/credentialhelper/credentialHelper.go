@@ -0,0 +1,73 @@
+package credentialhelper
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	credentialhelperentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/credentialhelper"
+	handler "github.com/gostevedore/stevedore/internal/handler/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// CommandName is the name of the credential helper command
+	CommandName = "credential-helper"
+	// HelperBinaryPrefix is the prefix of the Docker credentials helpers binaries. When stevedore is invoked through a 'docker-credential-stevedore' link, it runs the credential helper command
+	HelperBinaryPrefix = "docker-credential-"
+)
+
+// NewCommand return an stevedore command object that implements the Docker credentials helpers protocol. The protocol errors are written to the writer, as the Docker credentials helpers clients expect
+func NewCommand(ctx context.Context, config *configuration.Configuration, entrypoint Entrypointer, writer Writer) *command.StevedoreCommand {
+
+	credentialHelperCmd := &cobra.Command{
+		Use:   CommandName + " <get|store|erase|list>",
+		Short: "Stevedore command that serves the stevedore credentials as a Docker credentials helper",
+		Long: `Stevedore command that serves the stevedore credentials as a Docker credentials helper
+
+  It implements the Docker credentials helpers protocol on top of the configured credentials store, so Docker CLI, BuildKit and other tools can use the credentials managed by stevedore. The AWS ECR credentials are exchanged for a registry token.
+
+  Example:
+    # Docker configuration, ~/.docker/config.json, to use stevedore as the credentials helper for a registry. It requires the 'docker-credential-stevedore' link to the stevedore binary in the PATH
+    {
+      "credHelpers": {
+        "registry.example.com": "stevedore"
+      }
+    }
+
+    ln -s $(which stevedore) /usr/local/bin/docker-credential-stevedore
+
+    echo registry.example.com | stevedore credential-helper get
+`,
+		Args:          cobra.ExactArgs(1),
+		ValidArgs:     []string{handler.GetAction, handler.StoreAction, handler.EraseAction, handler.ListAction},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::credentialhelper::RunE)"
+
+			entrypointOptions := &credentialhelperentrypoint.Options{
+				Action: args[0],
+			}
+
+			err = entrypoint.Execute(ctx, args, config, entrypointOptions)
+			if err != nil {
+				if writer != nil {
+					writer.Write([]byte(err.Error() + "\n"))
+				}
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	command := &command.StevedoreCommand{
+		Command: credentialHelperCmd,
+	}
+
+	return command
+}
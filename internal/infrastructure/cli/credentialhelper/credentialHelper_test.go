@@ -0,0 +1,68 @@
+package credentialhelper
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		desc            string
+		config          *configuration.Configuration
+		entrypoint      Entrypointer
+		prepareMockFunc func(Entrypointer, *configuration.Configuration)
+		args            []string
+		res             string
+		err             error
+	}{
+		{
+			desc:       "Testing run credential helper command",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args:       []string{"get"},
+			prepareMockFunc: func(ep Entrypointer, config *configuration.Configuration) {
+				ep.(*entrypoint.MockEntrypoint).On("Execute", context.TODO(), []string{"get"}, config, &entrypoint.Options{Action: "get"}).Return(nil)
+			},
+		},
+		{
+			desc:       "Testing run credential helper command writes the error message",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args:       []string{"get"},
+			prepareMockFunc: func(ep Entrypointer, config *configuration.Configuration) {
+				ep.(*entrypoint.MockEntrypoint).On("Execute", context.TODO(), []string{"get"}, config, &entrypoint.Options{Action: "get"}).Return(
+					errors.New("", "credentials not found in native keychain"),
+				)
+			},
+			res: "credentials not found in native keychain\n",
+			err: errors.New("", "credentials not found in native keychain"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareMockFunc != nil {
+				test.prepareMockFunc(test.entrypoint, test.config)
+			}
+
+			writer := &bytes.Buffer{}
+			cmd := NewCommand(context.TODO(), test.config, test.entrypoint, writer)
+			cmd.Command.ParseFlags(test.args)
+			err := cmd.Command.RunE(cmd.Command, test.args)
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err.Error(), err.Error())
+			}
+
+			assert.Equal(t, test.res, writer.String())
+			test.entrypoint.(*entrypoint.MockEntrypoint).AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package credentialhelper
+
+import (
+	"context"
+
+	credentialhelperentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+)
+
+// Entrypointer is the interface that wraps the main function
+type Entrypointer interface {
+	Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *credentialhelperentrypoint.Options) error
+}
+
+// Writer is the interface to write the credentials helper errors
+type Writer interface {
+	Write(data []byte) (int, error)
+}
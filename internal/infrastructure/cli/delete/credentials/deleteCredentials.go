@@ -0,0 +1,59 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/delete/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to delete credentials
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	deleteCredentialsFlagOptions := &deleteCredentialsFlagOptions{}
+
+	deleteCredentialsCmd := &cobra.Command{
+		Use: "credentials <id>",
+		Aliases: []string{
+			"auth",
+			"badge",
+		},
+		Short: "Stevedore subcommand to delete a credential from the credentials store",
+		Long: `
+Stevedore subcommand to delete a credential from the credentials store
+`,
+		Example: `
+Delete the credentials to authenticate into a private registry:
+  stevedore delete credentials myregistry
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::delete::credentials::RunE)"
+
+			entrypointOptions := &entrypoint.Options{}
+
+			if deleteCredentialsFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = deleteCredentialsFlagOptions.LocalStoragePath
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	deleteCredentialsCmd.Flags().StringVar(&deleteCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+
+	command := &command.StevedoreCommand{
+		Command: deleteCredentialsCmd,
+	}
+
+	return command
+}
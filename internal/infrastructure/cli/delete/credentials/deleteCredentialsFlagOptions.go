@@ -0,0 +1,7 @@
+package credentials
+
+// deleteCredentialsFlagOptions is the options for the delete credentials command
+type deleteCredentialsFlagOptions struct {
+	// LocalStoragePath
+	LocalStoragePath string
+}
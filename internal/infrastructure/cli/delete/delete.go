@@ -0,0 +1,32 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to delete stevedore elements
+func NewCommand(ctx context.Context, subcommands ...*command.StevedoreCommand) *command.StevedoreCommand {
+
+	deleteCmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"remove", "rm"},
+		Short:   "Stevedore command to delete items",
+		Long:    "Stevedore command to delete items",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command := &command.StevedoreCommand{
+		Command: deleteCmd,
+	}
+
+	for _, subcommand := range subcommands {
+		command.AddCommand(subcommand)
+	}
+
+	return command
+}
@@ -0,0 +1,75 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/export/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to export credentials
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	exportCredentialsFlagOptions := &exportCredentialsFlagOptions{}
+
+	exportCredentialsCmd := &cobra.Command{
+		Use: "credentials",
+		Aliases: []string{
+			"auth",
+			"badge",
+		},
+		Short: "Stevedore subcommand to export the credentials to an encrypted credentials bundle",
+		Long: `
+Stevedore subcommand to export all the credentials from the credentials store to an encrypted credentials bundle file, which can be imported on another credentials store or machine.
+The credentials bundle is encrypted with a passphrase. When it is not provided, a one-time passphrase is generated and printed
+`,
+		Example: `
+Export the credentials using a one-time passphrase:
+  stevedore export credentials --to credentials.bundle
+
+Export the credentials asking for the passphrase:
+  stevedore export credentials --to credentials.bundle --ask-passphrase
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::export::credentials::RunE)"
+
+			entrypointOptions := &entrypoint.Options{}
+
+			if exportCredentialsFlagOptions.AskPassphrase {
+				entrypointOptions.AskPassphrase = exportCredentialsFlagOptions.AskPassphrase
+			}
+			if exportCredentialsFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = exportCredentialsFlagOptions.LocalStoragePath
+			}
+			if exportCredentialsFlagOptions.Passphrase != "" {
+				entrypointOptions.Passphrase = exportCredentialsFlagOptions.Passphrase
+			}
+			if exportCredentialsFlagOptions.To != "" {
+				entrypointOptions.To = exportCredentialsFlagOptions.To
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	exportCredentialsCmd.Flags().BoolVar(&exportCredentialsFlagOptions.AskPassphrase, "ask-passphrase", false, "When this flag is enabled, you will be asked for the passphrase to encrypt the credentials bundle")
+	exportCredentialsCmd.Flags().StringVar(&exportCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+	exportCredentialsCmd.Flags().StringVar(&exportCredentialsFlagOptions.Passphrase, "passphrase", "", "Passphrase to encrypt the credentials bundle. When it is not provided, a one-time passphrase is generated")
+	exportCredentialsCmd.Flags().StringVar(&exportCredentialsFlagOptions.To, "to", "", "Path of the credentials bundle file to export the credentials to")
+
+	command := &command.StevedoreCommand{
+		Command: exportCredentialsCmd,
+	}
+
+	return command
+}
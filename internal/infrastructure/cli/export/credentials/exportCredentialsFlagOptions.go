@@ -0,0 +1,13 @@
+package credentials
+
+// exportCredentialsFlagOptions is the options for the export credentials command
+type exportCredentialsFlagOptions struct {
+	// AskPassphrase
+	AskPassphrase bool
+	// LocalStoragePath
+	LocalStoragePath string
+	// Passphrase
+	Passphrase string
+	// To
+	To string
+}
@@ -0,0 +1,71 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/export/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		desc            string
+		config          *configuration.Configuration
+		entrypoint      Entrypointer
+		prepareMockFunc func(Entrypointer, *configuration.Configuration)
+		args            []string
+		err             error
+	}{
+		{
+			desc:       "Testing run export credentials command",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"--ask-passphrase",
+				"--local-storage-path",
+				"local-storage-path",
+				"--passphrase",
+				"passphrase",
+				"--to",
+				"credentials.bundle",
+			},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{},
+					conf,
+					&entrypoint.Options{
+						AskPassphrase:    true,
+						LocalStoragePath: "local-storage-path",
+						Passphrase:       "passphrase",
+						To:               "credentials.bundle",
+					},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareMockFunc != nil {
+				test.prepareMockFunc(test.entrypoint, test.config)
+			}
+
+			cmd := NewCommand(context.TODO(), test.config, test.entrypoint)
+			cmd.Command.ParseFlags(test.args)
+			err := cmd.Command.RunE(cmd.Command, test.args)
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.entrypoint.(*entrypoint.MockEntrypoint).AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to export stevedore elements
+func NewCommand(ctx context.Context, subcommands ...*command.StevedoreCommand) *command.StevedoreCommand {
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Stevedore command to export items",
+		Long:  "Stevedore command to export items",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command := &command.StevedoreCommand{
+		Command: exportCmd,
+	}
+
+	for _, subcommand := range subcommands {
+		command.AddCommand(subcommand)
+	}
+
+	return command
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 
 	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/configuration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	"github.com/spf13/cobra"
 )
 
 // NewCommand return an stevedore command object for get builders
-func NewCommand(ctx context.Context, config *configuration.Configuration, entrypoint Entrypointer) *command.StevedoreCommand {
+func NewCommand(ctx context.Context, config *configuration.Configuration, getConfigurationEntrypoint Entrypointer) *command.StevedoreCommand {
+
+	getConfigurationFlagOptions := &getConfigurationFlagOptions{}
 
 	getConfigurationCmd := &cobra.Command{
 		Use: "configuration",
@@ -25,13 +28,18 @@ Stevedore subcommand to get configuration information
 `,
 		Example: `
   stevedore get configuration
+  stevedore get configuration --show-origin
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
 			errContext := "(cli::get::configuration::RunE)"
 
-			err = entrypoint.Execute(ctx, cmd.Flags().Args(), config)
+			entrypointOptions := &entrypoint.Options{
+				ShowOrigin: getConfigurationFlagOptions.ShowOrigin,
+			}
+
+			err = getConfigurationEntrypoint.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
 			if err != nil {
 				return errors.New(errContext, "", err)
 			}
@@ -40,6 +48,8 @@ Stevedore subcommand to get configuration information
 		},
 	}
 
+	getConfigurationCmd.Flags().BoolVar(&getConfigurationFlagOptions.ShowOrigin, "show-origin", false, "Show where each configuration value comes from, which is either a profile, an environment variable, a configuration file or the default value")
+
 	command := &command.StevedoreCommand{
 		Command: getConfigurationCmd,
 	}
@@ -0,0 +1,6 @@
+package configuration
+
+// getConfigurationFlagOptions is the options for the get configuration command
+type getConfigurationFlagOptions struct {
+	ShowOrigin bool
+}
@@ -30,6 +30,25 @@ func TestNewCommand(t *testing.T) {
 					context.TODO(),
 					[]string{},
 					c,
+					&entrypoint.Options{},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing run get configuration command showing the origin of the values",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockGetConfigurationEntrypoint(),
+			args:       []string{"--show-origin"},
+			prepareMockFunc: func(e Entrypointer, c *configuration.Configuration) {
+				e.(*entrypoint.MockGetConfigurationEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{},
+					c,
+					&entrypoint.Options{
+						ShowOrigin: true,
+					},
 				).Return(nil)
 			},
 			err: &errors.Error{},
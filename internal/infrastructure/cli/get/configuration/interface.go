@@ -3,12 +3,13 @@ package configuration
 import (
 	"context"
 
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/configuration"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 )
 
 // Entrypointer is the interface that wraps the main function
 type Entrypointer interface {
-	Execute(ctx context.Context, args []string, conf *configuration.Configuration) error
+	Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *entrypoint.Options) error
 }
 
 // Compatibilitier is the interface for the compatibility checker
@@ -7,6 +7,7 @@ import (
 	getcredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	outputcredentials "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +30,10 @@ func NewCommand(ctx context.Context, config *configuration.Configuration, entryp
 
   Example:
     stevedore get credentials
+
+    stevedore get credentials --output json --type username-password
+
+    stevedore get credentials --expiring 7d
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
@@ -37,6 +42,11 @@ func NewCommand(ctx context.Context, config *configuration.Configuration, entryp
 
 			entrypointOptions := &getcredentialsentrypoint.Options{}
 			entrypointOptions.ShowSecrets = getCredentialsFlagOptions.ShowSecrets
+			entrypointOptions.Output = getCredentialsFlagOptions.Output
+			entrypointOptions.Expiring = getCredentialsFlagOptions.Expiring
+			if len(getCredentialsFlagOptions.Types) > 0 {
+				entrypointOptions.Types = append([]string{}, getCredentialsFlagOptions.Types...)
+			}
 
 			err = entrypoint.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
 			if err != nil {
@@ -48,6 +58,10 @@ func NewCommand(ctx context.Context, config *configuration.Configuration, entryp
 	}
 
 	getCredentialsCmd.Flags().BoolVar(&getCredentialsFlagOptions.ShowSecrets, "show-secrets", false, "When this flag is enabled, the output provide secrets")
+	getCredentialsCmd.Flags().StringVarP(&getCredentialsFlagOptions.Output, "output", "o", outputcredentials.TableFormat, "Output format. Supported formats are: table, json and yaml")
+	getCredentialsCmd.Flags().StringSliceVar(&getCredentialsFlagOptions.Types, "type", []string{}, "Credentials type to show. It could be set multiple times. Types are case insensitive and spaces could be written as dashes, i.e. 'aws-role-arn'")
+
+	getCredentialsCmd.Flags().StringVar(&getCredentialsFlagOptions.Expiring, "expiring", "", "Show only the credentials that expire within the given duration, i.e. '7d', '2w' or '36h'. Expired credentials are also shown")
 
 	command := &command.StevedoreCommand{
 		Command: getCredentialsCmd,
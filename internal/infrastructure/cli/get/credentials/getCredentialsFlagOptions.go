@@ -3,4 +3,7 @@ package credentials
 // getCredentialsFlagOptions is the options for the get credentials command
 type getCredentialsFlagOptions struct {
 	ShowSecrets bool
+	Output      string
+	Types       []string
+	Expiring    string
 }
@@ -24,7 +24,31 @@ func TestNewCommand(t *testing.T) {
 			entrypoint: entrypoint.NewMockEntrypoint(),
 			args:       []string{},
 			prepareMockFunc: func(ep Entrypointer, config *configuration.Configuration) {
-				ep.(*entrypoint.MockEntrypoint).On("Execute", context.TODO(), []string{}, config).Return(nil)
+				ep.(*entrypoint.MockEntrypoint).On("Execute", context.TODO(), []string{}, config, &entrypoint.Options{Output: "table"}).Return(nil)
+			},
+		},
+		{
+			desc:       "Testing run get credentials command with output format and types",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"--show-secrets",
+				"--output",
+				"json",
+				"--type",
+				"username-password",
+				"--type",
+				"aws-role-arn",
+				"--expiring",
+				"7d",
+			},
+			prepareMockFunc: func(ep Entrypointer, config *configuration.Configuration) {
+				ep.(*entrypoint.MockEntrypoint).On("Execute", context.TODO(), []string{}, config, &entrypoint.Options{
+					ShowSecrets: true,
+					Output:      "json",
+					Types:       []string{"username-password", "aws-role-arn"},
+					Expiring:    "7d",
+				}).Return(nil)
 			},
 		},
 	}
@@ -42,6 +66,8 @@ func TestNewCommand(t *testing.T) {
 			err := cmd.Command.RunE(cmd.Command, test.args)
 			if err != nil && assert.Error(t, err) {
 				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.entrypoint.(*entrypoint.MockEntrypoint).AssertExpectations(t)
 			}
 
 		})
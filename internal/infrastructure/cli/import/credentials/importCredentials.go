@@ -0,0 +1,83 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/import/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to import credentials
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	importCredentialsFlagOptions := &importCredentialsFlagOptions{}
+
+	importCredentialsCmd := &cobra.Command{
+		Use: "credentials",
+		Aliases: []string{
+			"auth",
+			"badge",
+		},
+		Short: "Stevedore subcommand to import credentials into the credentials store",
+		Long: `
+Stevedore subcommand to import credentials into the credentials store, either from an encrypted credentials bundle created by 'stevedore export credentials' or from the Docker configuration file.
+The existing credentials are not overwritten on the local storage type, unless the import is forced
+`,
+		Example: `
+Import the credentials from a credentials bundle:
+  stevedore import credentials --from credentials.bundle
+
+Import the credentials from the Docker configuration file:
+  stevedore import credentials --from-docker-config
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::import::credentials::RunE)"
+
+			entrypointOptions := &entrypoint.Options{}
+
+			if importCredentialsFlagOptions.AskPassphrase {
+				entrypointOptions.AskPassphrase = importCredentialsFlagOptions.AskPassphrase
+			}
+			if importCredentialsFlagOptions.Force {
+				entrypointOptions.ForceImport = importCredentialsFlagOptions.Force
+			}
+			if importCredentialsFlagOptions.From != "" {
+				entrypointOptions.From = importCredentialsFlagOptions.From
+			}
+			if importCredentialsFlagOptions.FromDockerConfig {
+				entrypointOptions.FromDockerConfig = importCredentialsFlagOptions.FromDockerConfig
+			}
+			if importCredentialsFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = importCredentialsFlagOptions.LocalStoragePath
+			}
+			if importCredentialsFlagOptions.Passphrase != "" {
+				entrypointOptions.Passphrase = importCredentialsFlagOptions.Passphrase
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	importCredentialsCmd.Flags().BoolVar(&importCredentialsFlagOptions.AskPassphrase, "ask-passphrase", false, "When this flag is enabled, you will be asked for the passphrase to decrypt the credentials bundle. It is asked anyway when it is not provided")
+	importCredentialsCmd.Flags().BoolVar(&importCredentialsFlagOptions.Force, "force", false, "When this flag is enabled, the existing credentials are overwritten")
+	importCredentialsCmd.Flags().StringVar(&importCredentialsFlagOptions.From, "from", "", "Path of the credentials bundle file to import the credentials from")
+	importCredentialsCmd.Flags().BoolVar(&importCredentialsFlagOptions.FromDockerConfig, "from-docker-config", false, "When this flag is enabled, the credentials are imported from the Docker configuration file")
+	importCredentialsCmd.Flags().StringVar(&importCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+	importCredentialsCmd.Flags().StringVar(&importCredentialsFlagOptions.Passphrase, "passphrase", "", "Passphrase to decrypt the credentials bundle")
+
+	command := &command.StevedoreCommand{
+		Command: importCredentialsCmd,
+	}
+
+	return command
+}
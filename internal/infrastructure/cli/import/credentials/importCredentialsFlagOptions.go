@@ -0,0 +1,17 @@
+package credentials
+
+// importCredentialsFlagOptions is the options for the import credentials command
+type importCredentialsFlagOptions struct {
+	// AskPassphrase
+	AskPassphrase bool
+	// Force
+	Force bool
+	// From
+	From string
+	// FromDockerConfig
+	FromDockerConfig bool
+	// LocalStoragePath
+	LocalStoragePath string
+	// Passphrase
+	Passphrase string
+}
@@ -0,0 +1,93 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/import/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		desc            string
+		config          *configuration.Configuration
+		entrypoint      Entrypointer
+		prepareMockFunc func(Entrypointer, *configuration.Configuration)
+		args            []string
+		err             error
+	}{
+		{
+			desc:       "Testing run import credentials command",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"--ask-passphrase",
+				"--force",
+				"--from",
+				"credentials.bundle",
+				"--local-storage-path",
+				"local-storage-path",
+				"--passphrase",
+				"passphrase",
+			},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{},
+					conf,
+					&entrypoint.Options{
+						AskPassphrase:    true,
+						ForceImport:      true,
+						From:             "credentials.bundle",
+						LocalStoragePath: "local-storage-path",
+						Passphrase:       "passphrase",
+					},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing run import credentials command from the Docker configuration file",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"--from-docker-config",
+			},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{},
+					conf,
+					&entrypoint.Options{
+						FromDockerConfig: true,
+					},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareMockFunc != nil {
+				test.prepareMockFunc(test.entrypoint, test.config)
+			}
+
+			cmd := NewCommand(context.TODO(), test.config, test.entrypoint)
+			cmd.Command.ParseFlags(test.args)
+			err := cmd.Command.RunE(cmd.Command, test.args)
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.entrypoint.(*entrypoint.MockEntrypoint).AssertExpectations(t)
+			}
+		})
+	}
+}
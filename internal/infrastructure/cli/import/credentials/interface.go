@@ -0,0 +1,14 @@
+package credentials
+
+import (
+	"context"
+
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/import/credentials"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+)
+
+// Entrypointer is the interface that wraps the main function
+type Entrypointer interface {
+	Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *entrypoint.Options) error
+}
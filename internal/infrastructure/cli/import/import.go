@@ -0,0 +1,31 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to import stevedore elements
+func NewCommand(ctx context.Context, subcommands ...*command.StevedoreCommand) *command.StevedoreCommand {
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Stevedore command to import items",
+		Long:  "Stevedore command to import items",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command := &command.StevedoreCommand{
+		Command: importCmd,
+	}
+
+	for _, subcommand := range subcommands {
+		command.AddCommand(subcommand)
+	}
+
+	return command
+}
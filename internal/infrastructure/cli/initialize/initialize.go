@@ -20,6 +20,9 @@ Initialize command is an create configuration subcommand alias.
 	init.Command.Example = `
 Example setting all configuration parameters:
   stevedore initialize --builders-path /builders --concurrency 4 --config /stevedore-config.yaml --credentials-format json --credentials-local-storage-path /credentials --credentials-storage-type local --enable-semver-tags --force --images-path /images --log-path-file /logs --push-images --semver-tags-template "{{ .Major }}" --semver-tags-template "{{ .Major }}_{{ .Minor }}"
+
+Example generating the credentials encryption key on a file, keeping it out of the configuration file:
+  stevedore initialize --generate-credentials-encryption-key --credentials-encryption-key-file ~/.config/stevedore/encryption.key
 	`
 
 	return init
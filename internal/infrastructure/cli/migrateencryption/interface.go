@@ -0,0 +1,13 @@
+package migrateencryption
+
+import (
+	"context"
+
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/rotateencryptionkey"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+)
+
+// Entrypointer is the interface that wraps the main function
+type Entrypointer interface {
+	Execute(ctx context.Context, args []string, conf *configuration.Configuration, options *entrypoint.Options) error
+}
@@ -0,0 +1,65 @@
+package migrateencryption
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/rotateencryptionkey"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to migrate the credentials to the current encryption format
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	migrateEncryptionFlagOptions := &migrateEncryptionFlagOptions{}
+
+	migrateEncryptionCmd := &cobra.Command{
+		Use:   "migrate-encryption",
+		Short: "Stevedore command to migrate the credentials to the current encryption format",
+		Long: `
+Stevedore command to migrate the credentials to the current encryption format. It decrypts the credentials with the current encryption key, either stored using the legacy format or the versioned one, and re-encrypts them using the versioned format, which derives the encryption key using scrypt or argon2id.
+The encryption key is not modified. The 'envvars' storage type prints the environment variables that must be set with the re-encrypted credentials
+`,
+		Example: `
+Migrate the credentials to the current encryption format:
+  stevedore migrate-encryption
+
+Migrate the credentials to the current encryption format using argon2id:
+  stevedore migrate-encryption --kdf argon2id
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::migrateencryption::RunE)"
+
+			entrypointOptions := &entrypoint.Options{
+				KeepEncryptionKey: true,
+			}
+
+			if migrateEncryptionFlagOptions.KDF != "" {
+				entrypointOptions.KDF = migrateEncryptionFlagOptions.KDF
+			}
+			if migrateEncryptionFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = migrateEncryptionFlagOptions.LocalStoragePath
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	migrateEncryptionCmd.Flags().StringVar(&migrateEncryptionFlagOptions.KDF, "kdf", "", "Key derivation function used to encrypt the credentials. Supported values are 'scrypt' and 'argon2id'. Default is 'scrypt'")
+	migrateEncryptionCmd.Flags().StringVar(&migrateEncryptionFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+
+	command := &command.StevedoreCommand{
+		Command: migrateEncryptionCmd,
+	}
+
+	return command
+}
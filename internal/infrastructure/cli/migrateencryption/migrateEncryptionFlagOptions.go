@@ -0,0 +1,9 @@
+package migrateencryption
+
+// migrateEncryptionFlagOptions is the options for the migrate encryption command
+type migrateEncryptionFlagOptions struct {
+	// KDF
+	KDF string
+	// LocalStoragePath
+	LocalStoragePath string
+}
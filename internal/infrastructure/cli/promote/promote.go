@@ -2,6 +2,7 @@ package promote
 
 import (
 	"context"
+	"fmt"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/image"
@@ -28,7 +29,16 @@ func NewCommand(ctx context.Context, compatibility Compatibilitier, conf *config
 		Aliases: []string{"publish", "copy"},
 		Short:   "Stevedore command to promote, publish or copy images to a docker registry or namespace",
 		Long:    "Stevedore command to promote, publish or copy images to a docker registry or namespace",
-		Example: "stevedore promote ubuntu:impish --promote-image-registry myregistry.example.com --promote-image-namespace mynamespace",
+		Example: `
+Promote an image reference:
+  stevedore promote ubuntu:impish --promote-image-registry-host myregistry.example.com --promote-image-registry-namespace mynamespace
+
+Promote all the versions of an images definition and its children to the stable namespace:
+  stevedore promote --definition ubuntu --cascade --promote-image-registry-namespace stable
+
+Promote the images from an images definition filtered by namespace:
+  stevedore promote --definition ubuntu --image-version impish --filter namespace=staging --promote-image-registry-namespace stable
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
@@ -58,10 +68,24 @@ func NewCommand(ctx context.Context, compatibility Compatibilitier, conf *config
 				}
 			}
 
+			entrypointOptions.Concurrency = promoteFlagOptions.Concurrency
+			entrypointOptions.DryRun = promoteFlagOptions.DryRun
+			entrypointOptions.PromotionPolicyPath = promoteFlagOptions.PromotionPolicyPath
 			entrypointOptions.UseDockerNormalizedName = promoteFlagOptions.UseDockerNormalizedName
 
+			handlerOptions.CascadeDepth = promoteFlagOptions.CascadeDepth
+			handlerOptions.ImageDefinitionName = promoteFlagOptions.ImageDefinitionName
+			handlerOptions.PromoteOnCascade = promoteFlagOptions.PromoteOnCascade
+			if len(promoteFlagOptions.ImageDefinitionVersions) > 0 {
+				handlerOptions.ImageDefinitionVersions = append([]string{}, promoteFlagOptions.ImageDefinitionVersions...)
+			}
+			if len(promoteFlagOptions.Filter) > 0 {
+				handlerOptions.Filter = append([]string{}, promoteFlagOptions.Filter...)
+			}
+
 			handlerOptions.DryRun = promoteFlagOptions.DryRun
 			handlerOptions.EnableSemanticVersionTags = promoteFlagOptions.EnableSemanticVersionTags
+			handlerOptions.Force = promoteFlagOptions.Force
 			handlerOptions.TargetImageName = promoteFlagOptions.TargetImageName
 			handlerOptions.TargetImageRegistryNamespace = promoteFlagOptions.TargetImageRegistryNamespace
 			handlerOptions.TargetImageRegistryHost = promoteFlagOptions.TargetImageRegistryHost
@@ -69,6 +93,9 @@ func NewCommand(ctx context.Context, compatibility Compatibilitier, conf *config
 			handlerOptions.RemoveTargetImageTags = promoteFlagOptions.RemoveTargetImageTags
 			handlerOptions.SemanticVersionTagsTemplates = append([]string{}, promoteFlagOptions.SemanticVersionTagsTemplates...)
 			handlerOptions.PromoteSourceImageTag = promoteFlagOptions.PromoteSourceImageTag
+			handlerOptions.Promoter = promoteFlagOptions.Promoter
+			handlerOptions.SourceImageDigest = promoteFlagOptions.SourceImageDigest
+			handlerOptions.VerifyDigest = promoteFlagOptions.VerifyDigest
 			handlerOptions.RemoteSourceImage = promoteFlagOptions.RemoteSourceImage
 
 			err = promote.Execute(ctx, cmd.Flags().Args(), conf, entrypointOptions, handlerOptions)
@@ -98,6 +125,17 @@ func NewCommand(ctx context.Context, compatibility Compatibilitier, conf *config
 	promoteCmd.Flags().BoolVarP(&promoteFlagOptions.PromoteSourceImageTag, "force-promote-source-image", "s", false, "When this flag is enabled, the source image is also promoted, along with any other target image")
 	promoteCmd.Flags().BoolVarP(&promoteFlagOptions.RemoteSourceImage, "use-source-image-from-remote", "R", false, "When this flag is enabled, source images is downloaded from remote Docker registry")
 	promoteCmd.Flags().BoolVar(&promoteFlagOptions.UseDockerNormalizedName, "use-docker-normalized-name", false, "Use Docker normalized name references")
+	promoteCmd.Flags().StringVar(&promoteFlagOptions.ImageDefinitionName, "definition", "", "Images definition name to promote. When it is set, the images to promote are resolved from the images definition instead of the source image argument")
+	promoteCmd.Flags().StringSliceVarP(&promoteFlagOptions.ImageDefinitionVersions, "image-version", "v", []string{}, "List of images definition versions to promote")
+	promoteCmd.Flags().StringSliceVarP(&promoteFlagOptions.Filter, "filter", "f", []string{}, "List of filters to select the images definition to promote. Filters must be defined on the following format: <attribute>=<value>")
+	promoteCmd.Flags().BoolVar(&promoteFlagOptions.PromoteOnCascade, "cascade", false, "When this flag is enabled, images definition children are also promoted")
+	promoteCmd.Flags().IntVar(&promoteFlagOptions.CascadeDepth, "cascade-depth", -1, "Number children levels to promote when promote on cascade is executed")
+	promoteCmd.Flags().StringVar(&promoteFlagOptions.Promoter, "promoter", "", fmt.Sprintf("Promoter used to promote the images. Valid values are '%s', which uses the Docker daemon, and '%s', which copies the images between registries without a Docker daemon. By default, it is used '%s'", image.DockerPromoterName, image.RegistryPromoterName, image.DockerPromoterName))
+	promoteCmd.Flags().StringVar(&promoteFlagOptions.SourceImageDigest, "source-digest", "", "Digest that the source image must have to be promoted, i.e. sha256:<hash>. The image is not promoted when the source image tag has moved to another digest. The source image is promoted by its digest and the promoted images digests are verified")
+	promoteCmd.Flags().BoolVar(&promoteFlagOptions.VerifyDigest, "verify-digest", false, "When this flag is enabled, the digest of each promoted image is compared with the source image digest, and a source to target digest table is printed. It is always enabled when the source digest is set")
+	promoteCmd.Flags().BoolVar(&promoteFlagOptions.Force, "force", false, "When this flag is enabled, the target image tags are overwritten even when they are immutable and they already exist with another digest")
+	promoteCmd.Flags().StringVar(&promoteFlagOptions.PromotionPolicyPath, "policy", "", "Promotion policy file path. It overrides the 'promotion_policy_path' configuration, and images can only be promoted through the routes allowed by the policy rules")
+	promoteCmd.Flags().IntVar(&promoteFlagOptions.Concurrency, "concurrency", 0, "Number of images promotions that can be excuted at the same time")
 
 	command := &command.StevedoreCommand{
 		Command: promoteCmd,
@@ -49,13 +49,20 @@ func TestNewCommand(t *testing.T) {
 				"--force-promote-source-image",
 				"--use-source-image-from-remote",
 				"--use-docker-normalized-name",
+				"--promoter",
+				"registry",
+				"--source-digest",
+				"sha256:digest",
+				"--verify-digest",
 			},
 			prepareMockFunc: func(compatibility Compatibilitier, promote Entrypointer, config *configuration.Configuration) {
 
 				entrypointOptions := &entrypoint.Options{
+					DryRun:                  true,
 					UseDockerNormalizedName: true,
 				}
 				handlerOptions := &handler.Options{
+					CascadeDepth:                 -1,
 					DryRun:                       true,
 					EnableSemanticVersionTags:    true,
 					TargetImageName:              "promote-image-name",
@@ -65,7 +72,10 @@ func TestNewCommand(t *testing.T) {
 					RemoveTargetImageTags:        true,
 					SemanticVersionTagsTemplates: []string{"{{ .Major }}"},
 					PromoteSourceImageTag:        true,
+					Promoter:                     "registry",
 					RemoteSourceImage:            true,
+					SourceImageDigest:            "sha256:digest",
+					VerifyDigest:                 true,
 				}
 
 				promote.(*entrypoint.MockEntrypoint).On(
@@ -108,9 +118,11 @@ func TestNewCommand(t *testing.T) {
 			prepareMockFunc: func(comp Compatibilitier, promote Entrypointer, config *configuration.Configuration) {
 
 				entrypointOptions := &entrypoint.Options{
+					DryRun:                  true,
 					UseDockerNormalizedName: false,
 				}
 				handlerOptions := &handler.Options{
+					CascadeDepth:                 -1,
 					DryRun:                       true,
 					EnableSemanticVersionTags:    true,
 					TargetImageName:              "promote-image-name",
@@ -173,9 +185,11 @@ func TestNewCommand(t *testing.T) {
 			prepareMockFunc: func(comp Compatibilitier, promote Entrypointer, config *configuration.Configuration) {
 
 				entrypointOptions := &entrypoint.Options{
+					DryRun:                  true,
 					UseDockerNormalizedName: false,
 				}
 				handlerOptions := &handler.Options{
+					CascadeDepth:                 -1,
 					DryRun:                       true,
 					EnableSemanticVersionTags:    true,
 					TargetImageName:              "promote-image-name",
@@ -227,8 +241,11 @@ func TestNewCommand(t *testing.T) {
 				"--remove-local-images-after-push",
 			},
 			prepareMockFunc: func(compatibility Compatibilitier, promote Entrypointer, config *configuration.Configuration) {
-				entrypointOptions := &entrypoint.Options{}
+				entrypointOptions := &entrypoint.Options{
+					DryRun: true,
+				}
 				handlerOptions := &handler.Options{
+					CascadeDepth:                 -1,
 					DryRun:                       true,
 					EnableSemanticVersionTags:    false,
 					TargetImageName:              "myubuntu",
@@ -271,8 +288,11 @@ func TestNewCommand(t *testing.T) {
 			},
 			prepareMockFunc: func(compatibility Compatibilitier, promote Entrypointer, config *configuration.Configuration) {
 
-				entrypointOptions := &entrypoint.Options{}
+				entrypointOptions := &entrypoint.Options{
+					DryRun: true,
+				}
 				handlerOptions := &handler.Options{
+					CascadeDepth:                 -1,
 					DryRun:                       true,
 					EnableSemanticVersionTags:    true,
 					TargetImageName:              image.UndefinedStringValue,
@@ -298,6 +318,54 @@ func TestNewCommand(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc:       "Testing to promote an images definition on cascade",
+			handler:    handler.NewHandlerMock(),
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"--definition",
+				"ubuntu",
+				"--image-version",
+				"impish",
+				"--filter",
+				"namespace=staging",
+				"--cascade",
+				"--cascade-depth",
+				"2",
+				"--concurrency",
+				"4",
+				"--promote-image-registry-namespace",
+				"stable",
+			},
+			prepareMockFunc: func(compatibility Compatibilitier, promote Entrypointer, config *configuration.Configuration) {
+
+				entrypointOptions := &entrypoint.Options{
+					Concurrency: 4,
+				}
+				handlerOptions := &handler.Options{
+					CascadeDepth:                 2,
+					Filter:                       []string{"namespace=staging"},
+					ImageDefinitionName:          "ubuntu",
+					ImageDefinitionVersions:      []string{"impish"},
+					PromoteOnCascade:             true,
+					TargetImageName:              image.UndefinedStringValue,
+					TargetImageRegistryNamespace: "stable",
+					TargetImageRegistryHost:      image.UndefinedStringValue,
+					TargetImageTags:              []string{},
+					SemanticVersionTagsTemplates: []string{},
+				}
+
+				promote.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{},
+					config,
+					entrypointOptions,
+					handlerOptions,
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,59 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/rename/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to rename credentials
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	renameCredentialsFlagOptions := &renameCredentialsFlagOptions{}
+
+	renameCredentialsCmd := &cobra.Command{
+		Use: "credentials <id> <new-id>",
+		Aliases: []string{
+			"auth",
+			"badge",
+		},
+		Short: "Stevedore subcommand to rename a credential on the credentials store",
+		Long: `
+Stevedore subcommand to rename a credential on the credentials store
+`,
+		Example: `
+Rename the credentials of a private registry:
+  stevedore rename credentials myregistry registry.example.com
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::rename::credentials::RunE)"
+
+			entrypointOptions := &entrypoint.Options{}
+
+			if renameCredentialsFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = renameCredentialsFlagOptions.LocalStoragePath
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	renameCredentialsCmd.Flags().StringVar(&renameCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+
+	command := &command.StevedoreCommand{
+		Command: renameCredentialsCmd,
+	}
+
+	return command
+}
@@ -0,0 +1,7 @@
+package credentials
+
+// renameCredentialsFlagOptions is the options for the rename credentials command
+type renameCredentialsFlagOptions struct {
+	// LocalStoragePath
+	LocalStoragePath string
+}
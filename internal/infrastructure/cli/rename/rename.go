@@ -0,0 +1,32 @@
+package rename
+
+import (
+	"context"
+
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to rename stevedore elements
+func NewCommand(ctx context.Context, subcommands ...*command.StevedoreCommand) *command.StevedoreCommand {
+
+	renameCmd := &cobra.Command{
+		Use:     "rename",
+		Aliases: []string{"mv"},
+		Short:   "Stevedore command to rename items",
+		Long:    "Stevedore command to rename items",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command := &command.StevedoreCommand{
+		Command: renameCmd,
+	}
+
+	for _, subcommand := range subcommands {
+		command.AddCommand(subcommand)
+	}
+
+	return command
+}
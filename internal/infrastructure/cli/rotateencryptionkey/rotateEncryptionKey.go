@@ -0,0 +1,74 @@
+package rotateencryptionkey
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/rotateencryptionkey"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to rotate the credentials encryption key
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	rotateEncryptionKeyFlagOptions := &rotateEncryptionKeyFlagOptions{}
+
+	rotateEncryptionKeyCmd := &cobra.Command{
+		Use: "rotate-encryption-key",
+		Aliases: []string{
+			"rekey",
+		},
+		Short: "Stevedore command to rotate the credentials encryption key",
+		Long: `
+Stevedore command to rotate the credentials encryption key. It decrypts the credentials with the current encryption key and re-encrypts them with the new one, which is generated when it is not provided.
+The new encryption key is written on the 'encryption_key_file', when it is configured, or on the configuration file, unless the encryption key is achieved from 'encryption_key_env' or 'encryption_key_command'. The 'envvars' storage type prints the environment variables that must be set with the re-encrypted credentials
+`,
+		Example: `
+Rotate the encryption key using a generated key:
+  stevedore rotate-encryption-key
+
+Rotate the encryption key asking for the new key:
+  stevedore rotate-encryption-key --ask-encryption-key
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::rotateencryptionkey::RunE)"
+
+			entrypointOptions := &entrypoint.Options{}
+
+			if rotateEncryptionKeyFlagOptions.AskEncryptionKey {
+				entrypointOptions.AskEncryptionKey = rotateEncryptionKeyFlagOptions.AskEncryptionKey
+			}
+			if rotateEncryptionKeyFlagOptions.EncryptionKey != "" {
+				entrypointOptions.EncryptionKey = rotateEncryptionKeyFlagOptions.EncryptionKey
+			}
+			if rotateEncryptionKeyFlagOptions.KDF != "" {
+				entrypointOptions.KDF = rotateEncryptionKeyFlagOptions.KDF
+			}
+			if rotateEncryptionKeyFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = rotateEncryptionKeyFlagOptions.LocalStoragePath
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	rotateEncryptionKeyCmd.Flags().BoolVar(&rotateEncryptionKeyFlagOptions.AskEncryptionKey, "ask-encryption-key", false, "When this flag is enabled, you will be asked for the new encryption key")
+	rotateEncryptionKeyCmd.Flags().StringVar(&rotateEncryptionKeyFlagOptions.EncryptionKey, "encryption-key", "", "New encryption key. When it is not provided, a new encryption key is generated")
+	rotateEncryptionKeyCmd.Flags().StringVar(&rotateEncryptionKeyFlagOptions.KDF, "kdf", "", "Key derivation function used to encrypt the credentials. Supported values are 'scrypt' and 'argon2id'. Default is 'scrypt'")
+	rotateEncryptionKeyCmd.Flags().StringVar(&rotateEncryptionKeyFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+
+	command := &command.StevedoreCommand{
+		Command: rotateEncryptionKeyCmd,
+	}
+
+	return command
+}
@@ -0,0 +1,13 @@
+package rotateencryptionkey
+
+// rotateEncryptionKeyFlagOptions is the options for the rotate encryption key command
+type rotateEncryptionKeyFlagOptions struct {
+	// AskEncryptionKey
+	AskEncryptionKey bool
+	// EncryptionKey
+	EncryptionKey string
+	// KDF
+	KDF string
+	// LocalStoragePath
+	LocalStoragePath string
+}
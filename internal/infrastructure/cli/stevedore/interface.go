@@ -38,6 +38,7 @@ type ConsoleWriter interface {
 	Warn(msg ...interface{})
 	Error(msg ...interface{})
 	Debug(msg ...interface{})
+	PrintTable(content [][]string) error
 }
 
 type ConsoleReader interface {
@@ -3,30 +3,56 @@ package stevedore
 import (
 	"context"
 	"fmt"
+	"os"
 
 	errors "github.com/apenella/go-common-utils/error"
 	buildentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/build"
+	checkcredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/check/credentials"
 	createconfigurationentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/create/configuration"
 	createcredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/create/credentials"
+	credentialhelperentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/credentialhelper"
+	deletecredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/delete/credentials"
+	exportcredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/export/credentials"
 	getbuildersentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/builders"
 	getconfigurationentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/configuration"
 	getcredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/credentials"
 	getimagesentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/get/images"
+	importcredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/import/credentials"
 	promoteentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/promote"
+	renamecredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/rename/credentials"
+	rotateencryptionkeyentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/rotateencryptionkey"
+	updatecredentialsentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/update/credentials"
+	validateentrypoint "github.com/gostevedore/stevedore/internal/entrypoint/validate"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/build"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/check"
+	checkcredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/check/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command/middleware"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/completion"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/create"
 	createconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/cli/create/configuration"
 	createcredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/create/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/credentialhelper"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/delete"
+	deletecredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/delete/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/export"
+	exportcredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/export/credentials"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/get"
 	getbuilders "github.com/gostevedore/stevedore/internal/infrastructure/cli/get/builders"
 	getconfiguration "github.com/gostevedore/stevedore/internal/infrastructure/cli/get/configuration"
 	getcredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/get/credentials"
 	getimages "github.com/gostevedore/stevedore/internal/infrastructure/cli/get/images"
+	imports "github.com/gostevedore/stevedore/internal/infrastructure/cli/import"
+	importcredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/import/credentials"
 	initizalize "github.com/gostevedore/stevedore/internal/infrastructure/cli/initialize"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/migrateencryption"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/promote"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/rename"
+	renamecredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/rename/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/rotateencryptionkey"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/update"
+	updatecredentials "github.com/gostevedore/stevedore/internal/infrastructure/cli/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/validate"
 	"github.com/gostevedore/stevedore/internal/infrastructure/cli/version"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 	"github.com/spf13/afero"
@@ -37,6 +63,7 @@ import (
 type stevedoreCmdFlags struct {
 	ConfigFile string
 	Debug      bool
+	Profile    string
 }
 
 var stevedoreCmdFlagsVars *stevedoreCmdFlags
@@ -67,6 +94,13 @@ Stevedore is a Docker images factory, a tool that helps you to manage bunches of
 					return errors.New(errContext, fmt.Sprintf("Error loading configuration from file '%s'", stevedoreCmdFlagsVars.ConfigFile), err)
 				}
 			}
+
+			if stevedoreCmdFlagsVars.Profile != "" {
+				err = config.ReloadConfigurationWithProfile(stevedoreCmdFlagsVars.Profile)
+				if err != nil {
+					return errors.New(errContext, fmt.Sprintf("Error loading configuration profile '%s'", stevedoreCmdFlagsVars.Profile), err)
+				}
+			}
 			log.ReloadWithWriter(config.LogWriter)
 
 			return nil
@@ -75,6 +109,7 @@ Stevedore is a Docker images factory, a tool that helps you to manage bunches of
 
 	stevedoreCmd.PersistentFlags().StringVarP(&stevedoreCmdFlagsVars.ConfigFile, "config", "c", "", "Configuration file location path")
 	stevedoreCmd.PersistentFlags().BoolVar(&stevedoreCmdFlagsVars.Debug, "debug", false, "Enable debug mode")
+	stevedoreCmd.PersistentFlags().StringVar(&stevedoreCmdFlagsVars.Profile, "profile", "", fmt.Sprintf("Configuration profile whose values override the configuration. It can also be selected by '%s' environment variable", configuration.ProfileEnv))
 
 	// Stevedore command
 	command := &command.StevedoreCommand{
@@ -109,6 +144,40 @@ Stevedore is a Docker images factory, a tool that helps you to manage bunches of
 		middleware.Command(ctx, build.NewCommand(ctx, compatibilityStore, config, buildEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug),
 	)
 
+	//
+	// Check command
+	//
+
+	// Check credentials
+	checkCredentialsEntrypoint := checkcredentialsentrypoint.NewEntrypoint(
+		checkcredentialsentrypoint.WithWriter(console),
+		checkcredentialsentrypoint.WithFileSystem(fs),
+		checkcredentialsentrypoint.WithCompatibility(compatibilityStore),
+	)
+	checkCredentialsCommand := middleware.Command(ctx, checkcredentials.NewCommand(ctx, config, checkCredentialsEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug)
+
+	// Check root command
+	checkCommand := check.NewCommand(
+		ctx,
+		checkCredentialsCommand,
+	)
+	command.AddCommand(checkCommand)
+
+	//
+	// Credential helper command
+	//
+
+	// the Docker credentials helpers protocol only expects the responses on the output, so the command is not wrapped by the middlewares that print reports and errors
+	credentialHelperEntrypoint := credentialhelperentrypoint.NewEntrypoint(
+		credentialhelperentrypoint.WithWriter(console),
+		credentialhelperentrypoint.WithReader(os.Stdin),
+		credentialhelperentrypoint.WithFileSystem(fs),
+		credentialhelperentrypoint.WithCompatibility(compatibilityStore),
+	)
+	command.AddCommand(
+		credentialhelper.NewCommand(ctx, config, credentialHelperEntrypoint, console),
+	)
+
 	//
 	// Create command
 	//
@@ -136,6 +205,44 @@ Stevedore is a Docker images factory, a tool that helps you to manage bunches of
 	)
 	command.AddCommand(createCommand)
 
+	//
+	// Delete command
+	//
+
+	// Delete credentials
+	deleteCredentialsEntrypoint := deletecredentialsentrypoint.NewEntrypoint(
+		deletecredentialsentrypoint.WithConsole(console),
+		deletecredentialsentrypoint.WithFileSystem(fs),
+		deletecredentialsentrypoint.WithCompatibility(compatibilityStore),
+	)
+	deleteCredentialsCommand := middleware.Command(ctx, deletecredentials.NewCommand(ctx, config, deleteCredentialsEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug)
+
+	// Delete root command
+	deleteCommand := delete.NewCommand(
+		ctx,
+		deleteCredentialsCommand,
+	)
+	command.AddCommand(deleteCommand)
+
+	//
+	// Export command
+	//
+
+	// Export credentials
+	exportCredentialsEntrypoint := exportcredentialsentrypoint.NewEntrypoint(
+		exportcredentialsentrypoint.WithConsole(console),
+		exportcredentialsentrypoint.WithFileSystem(fs),
+		exportcredentialsentrypoint.WithCompatibility(compatibilityStore),
+	)
+	exportCredentialsCommand := middleware.Command(ctx, exportcredentials.NewCommand(ctx, config, exportCredentialsEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug)
+
+	// Export root command
+	exportCommand := export.NewCommand(
+		ctx,
+		exportCredentialsCommand,
+	)
+	command.AddCommand(exportCommand)
+
 	//
 	// Get command
 	//
@@ -180,6 +287,25 @@ Stevedore is a Docker images factory, a tool that helps you to manage bunches of
 	)
 	command.AddCommand(getCommand)
 
+	//
+	// Import command
+	//
+
+	// Import credentials
+	importCredentialsEntrypoint := importcredentialsentrypoint.NewEntrypoint(
+		importcredentialsentrypoint.WithConsole(console),
+		importcredentialsentrypoint.WithFileSystem(fs),
+		importcredentialsentrypoint.WithCompatibility(compatibilityStore),
+	)
+	importCredentialsCommand := middleware.Command(ctx, importcredentials.NewCommand(ctx, config, importCredentialsEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug)
+
+	// Import root command
+	importCommand := imports.NewCommand(
+		ctx,
+		importCredentialsCommand,
+	)
+	command.AddCommand(importCommand)
+
 	//
 	// Initialize command
 	//
@@ -200,5 +326,74 @@ Stevedore is a Docker images factory, a tool that helps you to manage bunches of
 		middleware.Command(ctx, promote.NewCommand(ctx, compatibilityStore, config, promoteEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug),
 	)
 
+	//
+	// Rename command
+	//
+
+	// Rename credentials
+	renameCredentialsEntrypoint := renamecredentialsentrypoint.NewEntrypoint(
+		renamecredentialsentrypoint.WithConsole(console),
+		renamecredentialsentrypoint.WithFileSystem(fs),
+		renamecredentialsentrypoint.WithCompatibility(compatibilityStore),
+	)
+	renameCredentialsCommand := middleware.Command(ctx, renamecredentials.NewCommand(ctx, config, renameCredentialsEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug)
+
+	// Rename root command
+	renameCommand := rename.NewCommand(
+		ctx,
+		renameCredentialsCommand,
+	)
+	command.AddCommand(renameCommand)
+
+	//
+	// Rotate encryption key command
+	//
+	rotateEncryptionKeyEntrypoint := rotateencryptionkeyentrypoint.NewEntrypoint(
+		rotateencryptionkeyentrypoint.WithConsole(console),
+		rotateencryptionkeyentrypoint.WithFileSystem(fs),
+		rotateencryptionkeyentrypoint.WithCompatibility(compatibilityStore),
+	)
+	command.AddCommand(
+		middleware.Command(ctx, rotateencryptionkey.NewCommand(ctx, config, rotateEncryptionKeyEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug),
+	)
+
+	//
+	// Migrate encryption command
+	//
+	command.AddCommand(
+		middleware.Command(ctx, migrateencryption.NewCommand(ctx, config, rotateEncryptionKeyEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug),
+	)
+
+	//
+	// Update command
+	//
+
+	// Update credentials
+	updateCredentialsEntrypoint := updatecredentialsentrypoint.NewEntrypoint(
+		updatecredentialsentrypoint.WithConsole(console),
+		updatecredentialsentrypoint.WithFileSystem(fs),
+		updatecredentialsentrypoint.WithCompatibility(compatibilityStore),
+	)
+	updateCredentialsCommand := middleware.Command(ctx, updatecredentials.NewCommand(ctx, config, updateCredentialsEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug)
+
+	// Update root command
+	updateCommand := update.NewCommand(
+		ctx,
+		updateCredentialsCommand,
+	)
+	command.AddCommand(updateCommand)
+
+	//
+	// Validate command
+	//
+	validateEntrypoint := validateentrypoint.NewEntrypoint(
+		validateentrypoint.WithWriter(console),
+		validateentrypoint.WithFileSystem(fs),
+		validateentrypoint.WithCompatibility(compatibilityStore),
+	)
+	command.AddCommand(
+		middleware.Command(ctx, validate.NewCommand(ctx, config, validateEntrypoint), compatibilityReport, log, console, &stevedoreCmdFlagsVars.Debug),
+	)
+
 	return command
 }
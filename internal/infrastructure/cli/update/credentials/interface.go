@@ -0,0 +1,18 @@
+package credentials
+
+import (
+	"context"
+
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/update/credentials"
+	handler "github.com/gostevedore/stevedore/internal/handler/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+)
+
+// Entrypointer is the interface that wraps the main function
+type Entrypointer interface {
+	Execute(ctx context.Context,
+		args []string,
+		conf *configuration.Configuration,
+		inputEntrypointOptions *entrypoint.Options,
+		inputHandlerOptions *handler.Options) error
+}
@@ -0,0 +1,147 @@
+package credentials
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/update/credentials"
+	handler "github.com/gostevedore/stevedore/internal/handler/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to update credentials
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	updateCredentialsFlagOptions := &updateCredentialsFlagOptions{}
+
+	updateCredentialsCmd := &cobra.Command{
+		Use: "credentials <id>",
+		Aliases: []string{
+			"auth",
+			"badge",
+		},
+		Short: "Stevedore subcommand to update a credential on the credentials store",
+		Long: `
+Stevedore subcommand to update a credential on the credentials store. Only the attributes set through flags are updated, the remaining ones keep their value
+`,
+		Example: `
+Update the username of a basic auth credential, keeping its password:
+  stevedore update credentials myregistry --username new-username
+
+Update the password of a basic auth credential:
+  stevedore update credentials myregistry --ask-password
+
+Update the AWS region of an AWS ECR credential:
+  stevedore update credentials ecr-host --aws-region eu-west-1
+
+Update the password and the expiration of a rotated credential:
+  stevedore update credentials myregistry --ask-password --expires 90d
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::update::credentials::RunE)"
+
+			handlerOptions := &handler.Options{}
+			entrypointOptions := &entrypoint.Options{}
+
+			if updateCredentialsFlagOptions.LocalStoragePath != "" {
+				entrypointOptions.LocalStoragePath = updateCredentialsFlagOptions.LocalStoragePath
+			}
+			if updateCredentialsFlagOptions.AskAWSSecretAccessKey {
+				entrypointOptions.AskAWSSecretAccessKey = updateCredentialsFlagOptions.AskAWSSecretAccessKey
+			}
+			if updateCredentialsFlagOptions.AskPassword {
+				entrypointOptions.AskPassword = updateCredentialsFlagOptions.AskPassword
+			}
+			if updateCredentialsFlagOptions.AskPrivateKeyPassword {
+				entrypointOptions.AskPrivateKeyPassword = updateCredentialsFlagOptions.AskPrivateKeyPassword
+			}
+
+			if updateCredentialsFlagOptions.AllowUseSSHAgent {
+				handlerOptions.AllowUseSSHAgent = updateCredentialsFlagOptions.AllowUseSSHAgent
+			}
+			if updateCredentialsFlagOptions.AWSAccessKeyID != "" {
+				handlerOptions.AWSAccessKeyID = updateCredentialsFlagOptions.AWSAccessKeyID
+			}
+			if updateCredentialsFlagOptions.AWSProfile != "" {
+				handlerOptions.AWSProfile = updateCredentialsFlagOptions.AWSProfile
+			}
+			if updateCredentialsFlagOptions.AWSRegion != "" {
+				handlerOptions.AWSRegion = updateCredentialsFlagOptions.AWSRegion
+			}
+			if updateCredentialsFlagOptions.AWSRoleARN != "" {
+				handlerOptions.AWSRoleARN = updateCredentialsFlagOptions.AWSRoleARN
+			}
+			if len(updateCredentialsFlagOptions.AWSSharedConfigFiles) > 0 {
+				handlerOptions.AWSSharedConfigFiles = append([]string{}, updateCredentialsFlagOptions.AWSSharedConfigFiles...)
+			}
+			if len(updateCredentialsFlagOptions.AWSSharedCredentialsFiles) > 0 {
+				handlerOptions.AWSSharedCredentialsFiles = append([]string{}, updateCredentialsFlagOptions.AWSSharedCredentialsFiles...)
+			}
+			if updateCredentialsFlagOptions.AWSUseDefaultCredentialsChain {
+				handlerOptions.AWSUseDefaultCredentialsChain = updateCredentialsFlagOptions.AWSUseDefaultCredentialsChain
+			}
+			if updateCredentialsFlagOptions.CredentialProcess != "" {
+				handlerOptions.CredentialProcess = updateCredentialsFlagOptions.CredentialProcess
+			}
+			if updateCredentialsFlagOptions.Description != "" {
+				handlerOptions.Description = updateCredentialsFlagOptions.Description
+			}
+			if updateCredentialsFlagOptions.Expires != "" {
+				handlerOptions.ExpiresAt = updateCredentialsFlagOptions.Expires
+			}
+			if updateCredentialsFlagOptions.GitSSHUser != "" {
+				handlerOptions.GitSSHUser = updateCredentialsFlagOptions.GitSSHUser
+			}
+			if updateCredentialsFlagOptions.PrivateKeyFile != "" {
+				handlerOptions.PrivateKeyFile = updateCredentialsFlagOptions.PrivateKeyFile
+			}
+			if updateCredentialsFlagOptions.RefreshToken != "" {
+				handlerOptions.RefreshToken = updateCredentialsFlagOptions.RefreshToken
+			}
+			if updateCredentialsFlagOptions.Token != "" {
+				handlerOptions.Token = updateCredentialsFlagOptions.Token
+			}
+			if updateCredentialsFlagOptions.Username != "" {
+				handlerOptions.Username = updateCredentialsFlagOptions.Username
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions, handlerOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	updateCredentialsCmd.Flags().BoolVar(&updateCredentialsFlagOptions.AllowUseSSHAgent, "allow-use-ssh-agent", false, "When this flag is enabled, is allowed to use ssh-agent")
+	updateCredentialsCmd.Flags().BoolVar(&updateCredentialsFlagOptions.AskAWSSecretAccessKey, "ask-aws-secret-access-key", false, "When this flag is enabled, you will be asked for a new AWS secret access key")
+	updateCredentialsCmd.Flags().BoolVar(&updateCredentialsFlagOptions.AskPassword, "ask-password", false, "When this flag is enabled, you will be asked for a new password")
+	updateCredentialsCmd.Flags().BoolVar(&updateCredentialsFlagOptions.AskPrivateKeyPassword, "ask-private-key-password", false, "When this flag is enabled, you will be asked for a new private key password")
+	updateCredentialsCmd.Flags().BoolVar(&updateCredentialsFlagOptions.AWSUseDefaultCredentialsChain, "aws-use-default-credentials-chain", false, "When is used that flag, AWS default credentials chain is used to achieve credentials from AWS")
+	updateCredentialsCmd.Flags().StringSliceVar(&updateCredentialsFlagOptions.AWSSharedConfigFiles, "aws-shared-config-files", []string{}, "List of AWS shared config files to achieve credentials from AWS")
+	updateCredentialsCmd.Flags().StringSliceVar(&updateCredentialsFlagOptions.AWSSharedCredentialsFiles, "aws-shared-credentials-files", []string{}, "List AWS shared credentials files to achieve credentials from AWS")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.AWSAccessKeyID, "aws-access-key-id", "", "AWS Access Key ID to achieve credentials from AWS. AWS Secret asked key is going to be requested")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.AWSProfile, "aws-profile", "", "AWS Profile to achieve credentials from AWS")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.AWSRegion, "aws-region", "", "AWS Region to achieve credentials from AWS")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.AWSRoleARN, "aws-role-arn", "", "AWS Role ARN to achieve credentials from AWS")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.CredentialProcess, "credential-process", "", "Command executed to achieve the username and password. It must print a JSON object with the 'username', 'password' and, optionally, 'expires_at' attributes")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.Description, "description", "", "Description of the credentials")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.Expires, "expires", "", "Credentials expiration. It could be a date, such as '2024-12-31' or '2024-12-31T00:00:00Z', or a duration from now, such as '90d'")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.GitSSHUser, "git-ssh-user", "", "Git SSH User")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.LocalStoragePath, "local-storage-path", "", "Path where credentials are stored locally, using local storage type")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.PrivateKeyFile, "private-key-file", "", "Private Key File")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.RefreshToken, "refresh-token", "", "OAuth2 refresh token, also known as identity token, exchanged by an access token on the registry authorization service")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.Token, "token", "", "Bearer token sent to the registry. It could be used along with the username flag")
+	updateCredentialsCmd.Flags().StringVar(&updateCredentialsFlagOptions.Username, "username", "", "Username for basic auth method")
+
+	command := &command.StevedoreCommand{
+		Command: updateCredentialsCmd,
+	}
+
+	return command
+}
@@ -0,0 +1,45 @@
+package credentials
+
+// updateCredentialsFlagOptions is the options for the update credentials command
+type updateCredentialsFlagOptions struct {
+	// AllowUseSSHAgent
+	AllowUseSSHAgent bool
+	// AskAWSSecretAccessKey
+	AskAWSSecretAccessKey bool
+	// AskPassword
+	AskPassword bool
+	// AskPrivateKeyPassword
+	AskPrivateKeyPassword bool
+	// AWSAccessKeyID
+	AWSAccessKeyID string
+	// AWSProfile
+	AWSProfile string
+	// AWSRegion
+	AWSRegion string
+	// AWSRoleARN
+	AWSRoleARN string
+	// AWSSharedConfigFiles
+	AWSSharedConfigFiles []string
+	// AWSSharedCredentialsFiles
+	AWSSharedCredentialsFiles []string
+	// AWSUseDefaultCredentialsChain
+	AWSUseDefaultCredentialsChain bool
+	// CredentialProcess
+	CredentialProcess string
+	// Description
+	Description string
+	// Expires
+	Expires string
+	// GitSSHUser
+	GitSSHUser string
+	// LocalStoragePath
+	LocalStoragePath string
+	// PrivateKeyFile
+	PrivateKeyFile string
+	// RefreshToken
+	RefreshToken string
+	// Token
+	Token string
+	// Username
+	Username string
+}
@@ -0,0 +1,125 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/update/credentials"
+	handler "github.com/gostevedore/stevedore/internal/handler/update/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		desc            string
+		config          *configuration.Configuration
+		entrypoint      Entrypointer
+		prepareMockFunc func(Entrypointer, *configuration.Configuration)
+		args            []string
+		err             error
+	}{
+		{
+			desc:       "Testing run update credentials command",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args: []string{
+				"credential-id",
+				"--allow-use-ssh-agent",
+				"--ask-aws-secret-access-key",
+				"--ask-password",
+				"--ask-private-key-password",
+				"--aws-shared-config-files",
+				"aws-shared-config-file1",
+				"--aws-shared-credentials-files",
+				"aws-shared-credentials-file1",
+				"--aws-access-key-id",
+				"aws-access-key-id",
+				"--aws-profile",
+				"aws-profile",
+				"--aws-region",
+				"aws-region",
+				"--aws-role-arn",
+				"aws-role-arn",
+				"--description",
+				"description",
+				"--expires",
+				"2024-12-31",
+				"--git-ssh-user",
+				"git-ssh-user",
+				"--local-storage-path",
+				"local-storage-path",
+				"--private-key-file",
+				"private-key-file",
+				"--username",
+				"username",
+			},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{"credential-id"},
+					conf,
+					&entrypoint.Options{
+						AskAWSSecretAccessKey: true,
+						AskPassword:           true,
+						AskPrivateKeyPassword: true,
+						LocalStoragePath:      "local-storage-path",
+					},
+					&handler.Options{
+						AllowUseSSHAgent:          true,
+						AWSSharedConfigFiles:      []string{"aws-shared-config-file1"},
+						AWSSharedCredentialsFiles: []string{"aws-shared-credentials-file1"},
+						AWSAccessKeyID:            "aws-access-key-id",
+						AWSProfile:                "aws-profile",
+						AWSRegion:                 "aws-region",
+						AWSRoleARN:                "aws-role-arn",
+						Description:               "description",
+						ExpiresAt:                 "2024-12-31",
+						GitSSHUser:                "git-ssh-user",
+						PrivateKeyFile:            "private-key-file",
+						Username:                  "username",
+					},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:       "Testing run update credentials command without flags",
+			config:     &configuration.Configuration{},
+			entrypoint: entrypoint.NewMockEntrypoint(),
+			args:       []string{"credential-id"},
+			prepareMockFunc: func(e Entrypointer, conf *configuration.Configuration) {
+				e.(*entrypoint.MockEntrypoint).On(
+					"Execute",
+					context.TODO(),
+					[]string{"credential-id"},
+					conf,
+					&entrypoint.Options{},
+					&handler.Options{},
+				).Return(nil)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareMockFunc != nil {
+				test.prepareMockFunc(test.entrypoint, test.config)
+			}
+
+			cmd := NewCommand(context.TODO(), test.config, test.entrypoint)
+			cmd.Command.ParseFlags(test.args)
+			err := cmd.Command.RunE(cmd.Command, test.args)
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.entrypoint.(*entrypoint.MockEntrypoint).AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package validate
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	entrypoint "github.com/gostevedore/stevedore/internal/entrypoint/validate"
+	"github.com/gostevedore/stevedore/internal/infrastructure/cli/command"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	outputvalidation "github.com/gostevedore/stevedore/internal/infrastructure/output/validation"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand return an stevedore command object to validate the configuration, builders and images files
+func NewCommand(ctx context.Context, config *configuration.Configuration, e Entrypointer) *command.StevedoreCommand {
+
+	validateFlagOptions := &validateFlagOptions{}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Args:  cobra.NoArgs,
+		Short: "Stevedore command to validate the configuration, builders and images files",
+		Long: `
+Stevedore command to validate the configuration, builders and images files.
+The configuration file, the builders path and the images path are validated against the JSON Schemas generated from their definitions, which are published in the 'schemas' folder of the Stevedore repository.
+It reports the unknown keys, the values with a wrong type, the deprecated keys and their replacements, the invalid image names and versions, and the parent or children images that are not defined, along with their file and line.
+The command fails when any error is found. Deprecated keys are reported as warnings.
+`,
+		Example: `
+Validate the files defined on the configuration:
+  stevedore validate
+
+Validate the files defined on a configuration file, printing the issues as JSON:
+  stevedore validate --config stevedore.yaml --output json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			errContext := "(cli::validate::RunE)"
+
+			entrypointOptions := &entrypoint.Options{
+				Output: validateFlagOptions.Output,
+			}
+
+			err = e.Execute(ctx, cmd.Flags().Args(), config, entrypointOptions)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			return nil
+		},
+	}
+
+	validateCmd.Flags().StringVarP(&validateFlagOptions.Output, "output", "o", outputvalidation.TableFormat, "Output format. Supported formats are: table, json and yaml")
+
+	command := &command.StevedoreCommand{
+		Command: validateCmd,
+	}
+
+	return command
+}
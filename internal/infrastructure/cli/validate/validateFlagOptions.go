@@ -0,0 +1,7 @@
+package validate
+
+// validateFlagOptions is the options for the validate command
+type validateFlagOptions struct {
+	// Output
+	Output string
+}
@@ -13,54 +13,117 @@ import (
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
 	"github.com/spf13/afero"
 )
 
 type CredentialsConfiguration struct {
 	// StorageType is the backend used to store credentials
-	StorageType string
+	StorageType string `yaml:"storage_type"`
 	// LocalStoragePath is the local storage path where credentials are stored
-	LocalStoragePath string
+	LocalStoragePath string `yaml:"local_storage_path"`
 	// Format defines the format to store credentials, in case a format is required
-	Format string
-	// EncryptionKey is the key used to encrypt credentials
-	EncryptionKey string
+	Format string `yaml:"format"`
+	// EncryptionKey is the key used to encrypt credentials. It has precedence over the encryption key file, environment variable and command
+	EncryptionKey string `yaml:"encryption_key"`
+	// EncryptionKeyFile is the path of the file that contains the encryption key
+	EncryptionKeyFile string `yaml:"encryption_key_file"`
+	// EncryptionKeyEnv is the name of the environment variable that contains the encryption key
+	EncryptionKeyEnv string `yaml:"encryption_key_env"`
+	// EncryptionKeyCommand is the command that prints the encryption key
+	EncryptionKeyCommand string `yaml:"encryption_key_command"`
+	// AWSECRTokenCachePath is the folder where the AWS ECR authorization tokens are cached, encrypted, across invocations. Tokens are only cached in memory when it is not defined
+	AWSECRTokenCachePath string `yaml:"aws_ecr_token_cache_path"`
+	// ExpirationWarningWindow is the window used to warn about the credentials that are about to expire, such as '7d'. The default window is used when it is not defined and '0' disables the warnings
+	ExpirationWarningWindow string `yaml:"expiration_warning_window"`
+	// Vault is the HashiCorp Vault configuration, which is only defined when the storage type is 'vault'
+	Vault *VaultConfiguration `yaml:"vault"`
+}
+
+// VaultConfiguration defines how to reach the HashiCorp Vault KV version 2 secrets engine where the credentials are stored
+type VaultConfiguration struct {
+	// Address is the Vault address, such as 'https://vault.example.com:8200'
+	Address string `yaml:"address"`
+	// Namespace is the Vault Enterprise namespace
+	Namespace string `yaml:"namespace"`
+	// Mount is the mount of the KV version 2 secrets engine
+	Mount string `yaml:"mount"`
+	// Path is the path on the secrets engine where the credentials are stored
+	Path string `yaml:"path"`
+	// AuthMethod is the auth method used to log in to Vault, either 'token', 'approle' or 'jwt'
+	AuthMethod string `yaml:"auth_method"`
+	// AuthMount is the mount of the auth method. It defaults to the auth method name
+	AuthMount string `yaml:"auth_mount"`
+	// Token is the Vault token used by the 'token' auth method
+	Token string `yaml:"token"`
+	// RoleID is the AppRole role id used by the 'approle' auth method
+	RoleID string `yaml:"role_id"`
+	// SecretID is the AppRole secret id used by the 'approle' auth method
+	SecretID string `yaml:"secret_id"`
+	// Role is the role used by the 'jwt' auth method
+	Role string `yaml:"role"`
+	// JWT is the token used by the 'jwt' auth method
+	JWT string `yaml:"jwt"`
+	// JWTPath is the path of the file that contains the token used by the 'jwt' auth method, when JWT is not defined
+	JWTPath string `yaml:"jwt_path"`
+}
+
+// ImmutableTagsConfiguration defines the images whose tags can not be overwritten once they exist on a registry
+type ImmutableTagsConfiguration struct {
+	// Images is a list of patterns that defines the images whose tags are immutable, such as 'stable/*' or 'registry.example.com/*/*'
+	Images []string `yaml:"images"`
+	// FloatingTags is a list of regular expressions that defines the tags that remain mutable, such as 'latest'
+	FloatingTags []string `yaml:"floating_tags"`
 }
 
 type Configuration struct {
 	// BuildersPath is the path where the builders are stored
-	BuildersPath string
+	BuildersPath string `yaml:"builders_path"`
+	// BuildersSources are the local paths and git repositories where the builders are stored, when builders_path is a list
+	BuildersSources []*DefinitionsSource `yaml:"-"`
 	// Concurrency is the number of concurrent builds
-	Concurrency int
+	Concurrency int `yaml:"concurrency"`
 	// Credentials is the credentials configuration block
-	Credentials *CredentialsConfiguration
+	Credentials *CredentialsConfiguration `yaml:"credentials"`
 	// DEPRECATEDBuilderPath is the path where the builders are stored
-	DEPRECATEDBuilderPath string
+	DEPRECATEDBuilderPath string `yaml:"builder_path" jsonschema:"deprecated=builders_path"`
 	// DEPRECATEDBuildOnCascade is the flag to build on cascade
-	DEPRECATEDBuildOnCascade bool
+	DEPRECATEDBuildOnCascade bool `yaml:"build_on_cascade" jsonschema:"deprecated"`
 	// DEPRECATEDDockerCredentialsDir is the path to the docker credentials directory
-	DEPRECATEDDockerCredentialsDir string
+	DEPRECATEDDockerCredentialsDir string `yaml:"docker_registry_credentials_dir" jsonschema:"deprecated=credentials"`
 	// DEPRECATEDNumWorkers is the number of concurrent workers
-	DEPRECATEDNumWorkers int
+	DEPRECATEDNumWorkers int `yaml:"num_workers" jsonschema:"deprecated=concurrency"`
 	// DEPRECATEDTreePathFile is the path to the tree path file
-	DEPRECATEDTreePathFile string
+	DEPRECATEDTreePathFile string `yaml:"tree_path" jsonschema:"deprecated=images_path"`
 	// EnableSemanticVersionTags is the flag to enable semantic version tags
-	EnableSemanticVersionTags bool
+	EnableSemanticVersionTags bool `yaml:"semantic_version_tags_enabled"`
+	// GitSourcesCachePath is the folder where the git repositories that define images or builders are cloned. By default, it is located on the user cache folder
+	GitSourcesCachePath string `yaml:"git_sources_cache_path"`
 	// ImagesPath is the path where the images are stored
-	ImagesPath string
+	ImagesPath string `yaml:"images_path"`
+	// ImagesSources are the local paths and git repositories where the images are stored, when images_path is a list
+	ImagesSources []*DefinitionsSource `yaml:"-"`
+	// ImmutableTags is the immutable tags configuration block
+	ImmutableTags *ImmutableTagsConfiguration `yaml:"immutable_tags"`
 	// LogPathFile is the path to the log file
-	LogPathFile string
+	LogPathFile string `yaml:"log_path"`
 	// LogWriter is the writer to the log file
-	LogWriter io.Writer
+	LogWriter io.Writer `yaml:"-"`
+	// PromotionPolicyPath is the path to the promotion policy file
+	PromotionPolicyPath string `yaml:"promotion_policy_path"`
 	// PushImages is the flag to push images automatically after build
-	PushImages bool
+	PushImages bool `yaml:"push_images"`
 	// SemanticVersionTagsTemplates is the list of semantic version tags templates
-	SemanticVersionTagsTemplates []string
+	SemanticVersionTagsTemplates []string `yaml:"semantic_version_tags_templates"`
 
 	compatibility Compatibilitier
 	configFile    string
+	configFiles   []string
 	fs            afero.Fs
 	loader        ConfigurationLoader
+	origins       map[string]*ValueOrigin
+	profile       string
+	sources       map[string]string
 }
 
 const (
@@ -82,12 +145,20 @@ const (
 	DefaultCredentialsStorage = credentials.LocalStore
 	// DefaultCredentialsEncryptionKey is an empty string
 	DefaultCredentialsEncryptionKey = ""
+	// DefaultCredentialsVaultAuthMethod is the default auth method used to log in to Vault
+	DefaultCredentialsVaultAuthMethod = credentials.VaultTokenAuth
+	// DefaultCredentialsVaultMount is the default mount of the Vault KV version 2 secrets engine
+	DefaultCredentialsVaultMount = "secret"
+	// DefaultCredentialsVaultPath is the default path where the credentials are stored on Vault
+	DefaultCredentialsVaultPath = "stevedore"
 	// DefaultEnableSemanticVersionTags is the default enable semantic version tags
 	DefaultEnableSemanticVersionTags = false
 	// DefaultImagesPath is the default images path
 	DefaultImagesPath = "stevedore.yaml"
 	// DefaultLogPathFile is the default log path file
 	DefaultLogPathFile = ""
+	// DefaultPromotionPolicyPath by default promotions are not governed by any policy
+	DefaultPromotionPolicyPath = ""
 	// DefaultPushImages by default images won't be pushed
 	DefaultPushImages = false
 	// DefaultSemanticVersionTagsTemplates is the default semantic version tags templates
@@ -115,8 +186,44 @@ const (
 	CredentialsLocalStoragePathKey = "local_storage_path"
 	// CredentialsEncryptionKeyKey is the key for the credentials encryption token
 	CredentialsEncryptionKeyKey = "encryption_key"
+	// CredentialsEncryptionKeyFileKey is the key for the credentials encryption key file
+	CredentialsEncryptionKeyFileKey = "encryption_key_file"
+	// CredentialsEncryptionKeyEnvKey is the key for the credentials encryption key environment variable
+	CredentialsEncryptionKeyEnvKey = "encryption_key_env"
+	// CredentialsEncryptionKeyCommandKey is the key for the credentials encryption key command
+	CredentialsEncryptionKeyCommandKey = "encryption_key_command"
+	// CredentialsAWSECRTokenCachePathKey is the key for the AWS ECR authorization tokens cache path
+	CredentialsAWSECRTokenCachePathKey = "aws_ecr_token_cache_path"
+	// CredentialsExpirationWarningWindowKey is the key for the credentials expiration warning window
+	CredentialsExpirationWarningWindowKey = "expiration_warning_window"
 	// CredentialsStorageTypeKey is the key for the credentials storage type
 	CredentialsStorageTypeKey = "storage_type"
+	// CredentialsVaultKey is the key for the credentials Vault block
+	CredentialsVaultKey = "vault"
+	// CredentialsVaultAddressKey is the key for the Vault address
+	CredentialsVaultAddressKey = "address"
+	// CredentialsVaultNamespaceKey is the key for the Vault namespace
+	CredentialsVaultNamespaceKey = "namespace"
+	// CredentialsVaultMountKey is the key for the Vault secrets engine mount
+	CredentialsVaultMountKey = "mount"
+	// CredentialsVaultPathKey is the key for the Vault credentials path
+	CredentialsVaultPathKey = "path"
+	// CredentialsVaultAuthMethodKey is the key for the Vault auth method
+	CredentialsVaultAuthMethodKey = "auth_method"
+	// CredentialsVaultAuthMountKey is the key for the Vault auth method mount
+	CredentialsVaultAuthMountKey = "auth_mount"
+	// CredentialsVaultTokenKey is the key for the Vault token
+	CredentialsVaultTokenKey = "token"
+	// CredentialsVaultRoleIDKey is the key for the Vault AppRole role id
+	CredentialsVaultRoleIDKey = "role_id"
+	// CredentialsVaultSecretIDKey is the key for the Vault AppRole secret id
+	CredentialsVaultSecretIDKey = "secret_id"
+	// CredentialsVaultRoleKey is the key for the Vault JWT role
+	CredentialsVaultRoleKey = "role"
+	// CredentialsVaultJWTKey is the key for the Vault JWT
+	CredentialsVaultJWTKey = "jwt"
+	// CredentialsVaultJWTPathKey is the key for the Vault JWT file path
+	CredentialsVaultJWTPathKey = "jwt_path"
 	// DEPRECATEDBuilderPathKey is the key for the deprecated builder path
 	DEPRECATEDBuilderPathKey = "builder_path"
 	// DEPRECATEDBuildOnCascadeKey is the key for the deprecated build on cascade value
@@ -129,10 +236,22 @@ const (
 	DEPRECATEDTreePathFileKey = "tree_path"
 	// EnableSemanticVersionTagsKey is the key for the enable semantic version tags value
 	EnableSemanticVersionTagsKey = "semantic_version_tags_enabled"
+	// GitSourcesCachePathKey is the key for the git sources cache path
+	GitSourcesCachePathKey = "git_sources_cache_path"
 	// ImagesPathKey is the key for the images path
 	ImagesPathKey = "images_path"
+	// ImmutableTagsKey is the key for the immutable tags block
+	ImmutableTagsKey = "immutable_tags"
+	// ImmutableTagsImagesKey is the key for the immutable images patterns
+	ImmutableTagsImagesKey = "images"
+	// ImmutableTagsFloatingTagsKey is the key for the floating tags expressions
+	ImmutableTagsFloatingTagsKey = "floating_tags"
 	// LogPathFileKey is the key for the log path file
 	LogPathFileKey = "log_path"
+	// ProfilesKey is the key for the configuration profiles block
+	ProfilesKey = "profiles"
+	// PromotionPolicyPathKey is the key for the promotion policy path
+	PromotionPolicyPathKey = "promotion_policy_path"
 	// PushImagesKey is the key for the push images value
 	PushImagesKey = "push_images"
 	// SemanticVersionTagsTemplatesKey is the key for the semantic version tags templates
@@ -150,8 +269,13 @@ func DefaultConfig() *Configuration {
 	config.Concurrency = defaultConcurrency
 	config.EnableSemanticVersionTags = DefaultEnableSemanticVersionTags
 	config.ImagesPath = filepath.Join(DefaultConfigFolder, DefaultImagesPath)
+	config.ImmutableTags = &ImmutableTagsConfiguration{
+		Images:       []string{},
+		FloatingTags: defaultImmutableTagsFloatingTags(),
+	}
 	config.LogPathFile = DefaultLogPathFile
 	config.LogWriter = io.Discard
+	config.PromotionPolicyPath = DefaultPromotionPolicyPath
 	config.PushImages = DefaultPushImages
 	config.SemanticVersionTagsTemplates = []string{DefaultSemanticVersionTagsTemplates}
 
@@ -188,9 +312,12 @@ func New(fs afero.Fs, loader ConfigurationLoader, compatibility Compatibilitier)
 		return nil, errors.New(errContext, "Current user information can not be cached", err)
 	}
 
-	alternativesConfigFolders := []string{
-		filepath.Join(user.HomeDir, ".config", "stevedore"),
-		user.HomeDir,
+	// configuration files are merged from the lowest to the highest precedence, so the project configuration overrides the user configuration
+	configFileName := strings.Join([]string{DefaultConfigFile, DefaultConfigFileExtention}, ".")
+	configFiles := []string{
+		filepath.Join(user.HomeDir, configFileName),
+		filepath.Join(user.HomeDir, ".config", "stevedore", configFileName),
+		filepath.Join(DefaultConfigFolder, configFileName),
 	}
 
 	config := &Configuration{
@@ -207,7 +334,6 @@ func New(fs afero.Fs, loader ConfigurationLoader, compatibility Compatibilitier)
 	replacer := strings.NewReplacer(".", "_")
 	loader.SetEnvKeyReplacer(replacer)
 
-	loader.SetConfigName(DefaultConfigFile)
 	loader.SetConfigType(DefaultConfigFileExtention)
 
 	// dynamic default values
@@ -218,6 +344,11 @@ func New(fs afero.Fs, loader ConfigurationLoader, compatibility Compatibilitier)
 	loader.SetDefault(EnableSemanticVersionTagsKey, DefaultEnableSemanticVersionTags)
 	loader.SetDefault(ImagesPathKey, filepath.Join(DefaultConfigFolder, DefaultImagesPath))
 	loader.SetDefault(LogPathFileKey, DefaultLogPathFile)
+	loader.SetDefault(
+		strings.Join([]string{ImmutableTagsKey, ImmutableTagsImagesKey}, "."), []string{})
+	loader.SetDefault(
+		strings.Join([]string{ImmutableTagsKey, ImmutableTagsFloatingTagsKey}, "."), defaultImmutableTagsFloatingTags())
+	loader.SetDefault(PromotionPolicyPathKey, DefaultPromotionPolicyPath)
 	loader.SetDefault(PushImagesKey, DefaultPushImages)
 	loader.SetDefault(SemanticVersionTagsTemplatesKey, []string{DefaultSemanticVersionTagsTemplates})
 	loader.SetDefault(
@@ -229,16 +360,28 @@ func New(fs afero.Fs, loader ConfigurationLoader, compatibility Compatibilitier)
 	loader.SetDefault(
 		strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyKey}, "."), DefaultCredentialsEncryptionKey)
 
-	for _, alternativeConfigFolder := range alternativesConfigFolders {
-		loader.AddConfigPath(alternativeConfigFolder)
+	files := []*configurationFile{}
+	for _, configFile := range configFiles {
+		_, err = fs.Stat(configFile)
+		if err != nil {
+			continue
+		}
+
+		// The errors are not checked here to skip the configuration files that can not be loaded, as happens when no configuration file exists yet. The error is checked later on the LoadFromFile method
+		file, err := readConfigurationFile(fs, loader, configFile, len(files) > 0)
+		if err != nil {
+			continue
+		}
+		files = append(files, file)
 	}
-	// Set the default config folder as last default option
-	loader.AddConfigPath(DefaultConfigFolder)
 
-	// The errors is not checked here because it throws an error when initializing a new configuration and no configuration file exists yet. The error is checked later on the LoadFromFile method
-	_ = loader.ReadInConfig()
+	resolver := newKeyResolver(os.Getenv(ProfileEnv), files...)
+	err = resolver.validate()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
 
-	logWriter, err = createLogWriter(fs, loader.GetString(LogPathFileKey))
+	logWriter, err = createLogWriter(fs, loader.GetString(resolver.key(LogPathFileKey)))
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
 	}
@@ -263,23 +406,59 @@ func New(fs afero.Fs, loader ConfigurationLoader, compatibility Compatibilitier)
 		config.DEPRECATEDDockerCredentialsDir = loader.GetString(DEPRECATEDDockerCredentialsDirKey)
 	}
 
-	config.BuildersPath = loader.GetString(BuildersPathKey)
-	config.Concurrency = loader.GetInt(ConcurrencyKey)
-	config.EnableSemanticVersionTags = loader.GetBool(EnableSemanticVersionTagsKey)
-	config.ImagesPath = loader.GetString(ImagesPathKey)
-	config.LogPathFile = loader.GetString(LogPathFileKey)
+	config.BuildersPath, config.BuildersSources, err = loadDefinitionsSources(loader.Get(resolver.key(BuildersPathKey)))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid configuration, '%s' is not valid", BuildersPathKey), err)
+	}
+
+	config.ImagesPath, config.ImagesSources, err = loadDefinitionsSources(loader.Get(resolver.key(ImagesPathKey)))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid configuration, '%s' is not valid", ImagesPathKey), err)
+	}
+
+	config.Concurrency = loader.GetInt(resolver.key(ConcurrencyKey))
+	config.EnableSemanticVersionTags = loader.GetBool(resolver.key(EnableSemanticVersionTagsKey))
+	config.GitSourcesCachePath = loader.GetString(resolver.key(GitSourcesCachePathKey))
+	config.LogPathFile = loader.GetString(resolver.key(LogPathFileKey))
 	config.LogWriter = logWriter
-	config.PushImages = loader.GetBool(PushImagesKey)
-	config.SemanticVersionTagsTemplates = loader.GetStringSlice(SemanticVersionTagsTemplatesKey)
+	config.PromotionPolicyPath = loader.GetString(resolver.key(PromotionPolicyPathKey))
+	config.PushImages = loader.GetBool(resolver.key(PushImagesKey))
+	config.SemanticVersionTagsTemplates = loader.GetStringSlice(resolver.key(SemanticVersionTagsTemplatesKey))
 
 	config.Credentials = &CredentialsConfiguration{
-		StorageType:      loader.GetString(strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, ".")),
-		LocalStoragePath: loader.GetString(strings.Join([]string{CredentialsKey, CredentialsLocalStoragePathKey}, ".")),
-		Format:           loader.GetString(strings.Join([]string{CredentialsKey, CredentialsFormatKey}, ".")),
-		EncryptionKey:    loader.GetString(strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyKey}, ".")),
+		StorageType:      loader.GetString(resolver.key(CredentialsKey, CredentialsStorageTypeKey)),
+		LocalStoragePath: loader.GetString(resolver.key(CredentialsKey, CredentialsLocalStoragePathKey)),
+		Format:           loader.GetString(resolver.key(CredentialsKey, CredentialsFormatKey)),
+		EncryptionKey:    loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyKey)),
+
+		EncryptionKeyFile:       loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyFileKey)),
+		EncryptionKeyEnv:        loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyEnvKey)),
+		EncryptionKeyCommand:    loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyCommandKey)),
+		AWSECRTokenCachePath:    loader.GetString(resolver.key(CredentialsKey, CredentialsAWSECRTokenCachePathKey)),
+		ExpirationWarningWindow: loader.GetString(resolver.key(CredentialsKey, CredentialsExpirationWarningWindowKey)),
 	}
 
-	config.configFile = loader.ConfigFileUsed()
+	if config.Credentials.StorageType == credentials.VaultStore {
+		config.Credentials.Vault = loadVaultConfiguration(loader, resolver)
+	}
+
+	err = config.Credentials.validateEncryptionKeySources()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	config.ImmutableTags = &ImmutableTagsConfiguration{
+		Images:       loader.GetStringSlice(resolver.key(ImmutableTagsKey, ImmutableTagsImagesKey)),
+		FloatingTags: loader.GetStringSlice(resolver.key(ImmutableTagsKey, ImmutableTagsFloatingTagsKey)),
+	}
+
+	for _, file := range files {
+		config.configFiles = append(config.configFiles, file.path)
+		config.configFile = file.path
+	}
+	config.origins = resolver.origins
+	config.profile = resolver.profile
+	config.sources = resolver.sources
 
 	err = config.CheckCompatibility()
 	if err != nil {
@@ -294,8 +473,13 @@ func New(fs afero.Fs, loader ConfigurationLoader, compatibility Compatibilitier)
 	return config, nil
 }
 
-// LoadFromFile method returns a configuration object loaded from a file
+// LoadFromFile method returns a configuration object loaded from a file. The values defined on the profile selected by the STEVEDORE_PROFILE environment variable take precedence
 func LoadFromFile(fs afero.Fs, loader ConfigurationLoader, file string, compatibility Compatibilitier) (*Configuration, error) {
+	return loadFromFiles(fs, loader, []string{file}, os.Getenv(ProfileEnv), compatibility)
+}
+
+// loadFromFiles returns a configuration object loaded from the files, which are merged from the lowest to the highest precedence. Its values are overridden by the ones defined on the profile
+func loadFromFiles(fs afero.Fs, loader ConfigurationLoader, configFiles []string, profile string, compatibility Compatibilitier) (*Configuration, error) {
 
 	var err error
 	var logWriter io.Writer
@@ -316,36 +500,75 @@ func LoadFromFile(fs afero.Fs, loader ConfigurationLoader, file string, compatib
 	}
 
 	loader.SetFs(fs)
-	loader.SetConfigFile(file)
-	err = loader.ReadInConfig()
+
+	files := []*configurationFile{}
+	for _, configFile := range configFiles {
+		file, err := readConfigurationFile(fs, loader, configFile, len(files) > 0)
+		if err != nil {
+			return nil, errors.New(errContext, "Configuration file could not be loaded", err)
+		}
+		files = append(files, file)
+	}
+
+	resolver := newKeyResolver(profile, files...)
+	err = resolver.validate()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	buildersPath, buildersSources, err := loadDefinitionsSources(loader.Get(resolver.key(BuildersPathKey)))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid configuration, '%s' is not valid", BuildersPathKey), err)
+	}
+
+	imagesPath, imagesSources, err := loadDefinitionsSources(loader.Get(resolver.key(ImagesPathKey)))
 	if err != nil {
-		return nil, errors.New(errContext, "Configuration file could not be loaded", err)
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid configuration, '%s' is not valid", ImagesPathKey), err)
 	}
 
 	config = &Configuration{
-		BuildersPath: loader.GetString(BuildersPathKey),
-		Concurrency:  loader.GetInt(ConcurrencyKey),
+		BuildersPath:    buildersPath,
+		BuildersSources: buildersSources,
+		Concurrency:     loader.GetInt(resolver.key(ConcurrencyKey)),
 		Credentials: &CredentialsConfiguration{
-			StorageType:      loader.GetString(strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, ".")),
-			LocalStoragePath: loader.GetString(strings.Join([]string{CredentialsKey, CredentialsLocalStoragePathKey}, ".")),
-			Format:           loader.GetString(strings.Join([]string{CredentialsKey, CredentialsFormatKey}, ".")),
+			StorageType:      loader.GetString(resolver.key(CredentialsKey, CredentialsStorageTypeKey)),
+			LocalStoragePath: loader.GetString(resolver.key(CredentialsKey, CredentialsLocalStoragePathKey)),
+			Format:           loader.GetString(resolver.key(CredentialsKey, CredentialsFormatKey)),
+			EncryptionKey:    loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyKey)),
+
+			EncryptionKeyFile:       loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyFileKey)),
+			EncryptionKeyEnv:        loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyEnvKey)),
+			EncryptionKeyCommand:    loader.GetString(resolver.key(CredentialsKey, CredentialsEncryptionKeyCommandKey)),
+			AWSECRTokenCachePath:    loader.GetString(resolver.key(CredentialsKey, CredentialsAWSECRTokenCachePathKey)),
+			ExpirationWarningWindow: loader.GetString(resolver.key(CredentialsKey, CredentialsExpirationWarningWindowKey)),
 		},
 		DEPRECATEDBuilderPath:          loader.GetString(DEPRECATEDBuilderPathKey),
 		DEPRECATEDBuildOnCascade:       loader.GetBool(DEPRECATEDBuildOnCascadeKey),
 		DEPRECATEDNumWorkers:           loader.GetInt(DEPRECATEDNumWorkerKey),
 		DEPRECATEDTreePathFile:         loader.GetString(DEPRECATEDTreePathFileKey),
 		DEPRECATEDDockerCredentialsDir: loader.GetString(DEPRECATEDDockerCredentialsDirKey),
-		EnableSemanticVersionTags:      loader.GetBool(EnableSemanticVersionTagsKey),
-		ImagesPath:                     loader.GetString(ImagesPathKey),
-		LogPathFile:                    loader.GetString(LogPathFileKey),
-		LogWriter:                      logWriter,
-		PushImages:                     loader.GetBool(PushImagesKey),
-		SemanticVersionTagsTemplates:   loader.GetStringSlice(SemanticVersionTagsTemplatesKey),
+		EnableSemanticVersionTags:      loader.GetBool(resolver.key(EnableSemanticVersionTagsKey)),
+		GitSourcesCachePath:            loader.GetString(resolver.key(GitSourcesCachePathKey)),
+		ImagesPath:                     imagesPath,
+		ImagesSources:                  imagesSources,
+		ImmutableTags: &ImmutableTagsConfiguration{
+			Images:       loader.GetStringSlice(resolver.key(ImmutableTagsKey, ImmutableTagsImagesKey)),
+			FloatingTags: loader.GetStringSlice(resolver.key(ImmutableTagsKey, ImmutableTagsFloatingTagsKey)),
+		},
+		LogPathFile:                  loader.GetString(resolver.key(LogPathFileKey)),
+		LogWriter:                    logWriter,
+		PromotionPolicyPath:          loader.GetString(resolver.key(PromotionPolicyPathKey)),
+		PushImages:                   loader.GetBool(resolver.key(PushImagesKey)),
+		SemanticVersionTagsTemplates: loader.GetStringSlice(resolver.key(SemanticVersionTagsTemplatesKey)),
 
 		compatibility: compatibility,
-		configFile:    file,
+		configFile:    configFiles[len(configFiles)-1],
+		configFiles:   configFiles,
 		fs:            fs,
 		loader:        loader,
+		origins:       resolver.origins,
+		profile:       profile,
+		sources:       resolver.sources,
 	}
 
 	err = config.CheckCompatibility()
@@ -353,7 +576,7 @@ func LoadFromFile(fs afero.Fs, loader ConfigurationLoader, file string, compatib
 		return nil, errors.New(errContext, "", err)
 	}
 
-	if config.BuildersPath == "" {
+	if config.BuildersPath == "" && len(config.BuildersSources) == 0 {
 		config.BuildersPath = DefaultBuildersPath
 	}
 
@@ -374,14 +597,27 @@ func LoadFromFile(fs afero.Fs, loader ConfigurationLoader, file string, compatib
 		config.Credentials.Format = DefaultCredentialsFormat
 	}
 
+	if config.Credentials.StorageType == credentials.VaultStore {
+		config.Credentials.Vault = loadVaultConfiguration(loader, resolver)
+	}
+
+	err = config.Credentials.validateEncryptionKeySources()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
 	if !config.EnableSemanticVersionTags {
 		config.EnableSemanticVersionTags = DefaultEnableSemanticVersionTags
 	}
 
-	if config.ImagesPath == "" {
+	if config.ImagesPath == "" && len(config.ImagesSources) == 0 {
 		config.ImagesPath = DefaultImagesPath
 	}
 
+	if len(config.ImmutableTags.FloatingTags) == 0 {
+		config.ImmutableTags.FloatingTags = defaultImmutableTagsFloatingTags()
+	}
+
 	if config.LogPathFile == "" {
 		config.LogPathFile = DefaultLogPathFile
 	}
@@ -408,11 +644,45 @@ func LoadFromFile(fs afero.Fs, loader ConfigurationLoader, file string, compatib
 	return config, nil
 }
 
-// ConfigFileUsed return which is the config file used to load the configuration
+// ConfigFileUsed return which is the config file used to load the configuration. When several configuration files are merged, it is the one with the highest precedence
 func (c *Configuration) ConfigFileUsed() string {
 	return c.configFile
 }
 
+// ConfigFilesUsed returns the configuration files merged to load the configuration, sorted from the lowest to the highest precedence
+func (c *Configuration) ConfigFilesUsed() []string {
+	return c.configFiles
+}
+
+// ImagesDefinitionsSources returns the local paths and git repositories where the images are defined
+func (c *Configuration) ImagesDefinitionsSources() []*DefinitionsSource {
+	if len(c.ImagesSources) > 0 {
+		return c.ImagesSources
+	}
+
+	return []*DefinitionsSource{{Path: c.ImagesPath}}
+}
+
+// BuildersDefinitionsSources returns the local paths and git repositories where the builders are defined
+func (c *Configuration) BuildersDefinitionsSources() []*DefinitionsSource {
+	if len(c.BuildersSources) > 0 {
+		return c.BuildersSources
+	}
+
+	return []*DefinitionsSource{{Path: c.BuildersPath}}
+}
+
+// HasGitDefinitionsSources returns whether any of the images or builders definitions sources is a git repository
+func (c *Configuration) HasGitDefinitionsSources() bool {
+	for _, source := range append(c.ImagesDefinitionsSources(), c.BuildersDefinitionsSources()...) {
+		if source != nil && source.Git != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ReloadConfigurationFromFile
 func (c *Configuration) ReloadConfigurationFromFile(file string) error {
 	errContext := "(Configuration::ReloadConfigurationFromFile)"
@@ -421,7 +691,7 @@ func (c *Configuration) ReloadConfigurationFromFile(file string) error {
 		return errors.New(errContext, "Configuration file must be provided to reload configuration from file")
 	}
 
-	newConfig, err := LoadFromFile(c.fs, c.loader, file, c.compatibility)
+	newConfig, err := loadFromFiles(c.fs, c.loader, []string{file}, c.profile, c.compatibility)
 	if err != nil {
 		return errors.New(errContext, "", err)
 	}
@@ -437,6 +707,42 @@ func (c *Configuration) ReloadConfigurationFromFile(file string) error {
 	return nil
 }
 
+// ReloadConfigurationWithProfile reloads the configuration from the configuration file in use, overriding its values by the ones defined on the profile
+func (c *Configuration) ReloadConfigurationWithProfile(profile string) error {
+	errContext := "(Configuration::ReloadConfigurationWithProfile)"
+
+	if profile == "" {
+		return errors.New(errContext, "Profile must be provided to reload configuration with a profile")
+	}
+
+	if len(c.configFiles) == 0 {
+		return errors.New(errContext, fmt.Sprintf("Profile '%s' can not be selected because there is no configuration file", profile))
+	}
+
+	newConfig, err := loadFromFiles(c.fs, c.loader, c.configFiles, profile, c.compatibility)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	*c = *newConfig
+	return nil
+}
+
+// Profile returns the name of the profile whose values override the configuration
+func (c *Configuration) Profile() string {
+	return c.profile
+}
+
+// ValueSource returns where the value of a configuration key comes from, which is either a profile, an environment variable, a configuration file or the default value. It returns an empty string when the source is unknown
+func (c *Configuration) ValueSource(key ...string) string {
+	return c.sources[strings.Join(key, ".")]
+}
+
+// ValueOrigin returns where the value of a configuration key is defined. It returns nil when the origin is unknown
+func (c *Configuration) ValueOrigin(key ...string) *ValueOrigin {
+	return c.origins[strings.Join(key, ".")]
+}
+
 // ValidateConfiguration method validates the configuration
 func (c *Configuration) ValidateConfiguration() error {
 
@@ -447,14 +753,28 @@ func (c *Configuration) ValidateConfiguration() error {
 	// 	return errors.New(errContext, "File system must be provided to create a new configuration")
 	// }
 
-	if c.BuildersPath == "" {
+	if c.BuildersPath == "" && len(c.BuildersSources) == 0 {
 		return errors.New(errContext, "Invalid configuration, builders path must be provided")
 	}
 
-	if c.ImagesPath == "" {
+	for _, source := range c.BuildersSources {
+		err := source.validate()
+		if err != nil {
+			return errors.New(errContext, "Invalid configuration, builders source is not valid", err)
+		}
+	}
+
+	if c.ImagesPath == "" && len(c.ImagesSources) == 0 {
 		return errors.New(errContext, "Invalid configuration, images path must be provided")
 	}
 
+	for _, source := range c.ImagesSources {
+		err := source.validate()
+		if err != nil {
+			return errors.New(errContext, "Invalid configuration, images source is not valid", err)
+		}
+	}
+
 	if c.Concurrency < 1 {
 		return errors.New(errContext, "Invalid configuration, concurrency must be greater than 0")
 	}
@@ -473,6 +793,25 @@ func (c *Configuration) ValidateConfiguration() error {
 				return errors.New(errContext, "Invalid configuration, credentials local storage path must be provided")
 			}
 		}
+
+		err := c.Credentials.validateEncryptionKeySources()
+		if err != nil {
+			return errors.New(errContext, "Invalid configuration, credentials encryption key is not valid", err)
+		}
+
+		if c.Credentials.StorageType == credentials.VaultStore {
+			err := c.Credentials.Vault.validate()
+			if err != nil {
+				return errors.New(errContext, "Invalid configuration, credentials Vault configuration is not valid", err)
+			}
+		}
+	}
+
+	if c.ImmutableTags != nil {
+		_, err := image.NewImmutableTags(c.ImmutableTags.Images, c.ImmutableTags.FloatingTags)
+		if err != nil {
+			return errors.New(errContext, "Invalid configuration, immutable tags are not valid", err)
+		}
 	}
 
 	return nil
@@ -490,21 +829,23 @@ func (c *Configuration) CheckCompatibility() error {
 	if c.DEPRECATEDTreePathFile != "" {
 		c.compatibility.AddDeprecated(fmt.Sprintf("'%s' is deprecated and will be removed on v0.12.0, please use '%s' instead", DEPRECATEDTreePathFileKey, ImagesPathKey))
 
-		if c.ImagesPath != "" && c.ImagesPath != DefaultImagesPath {
+		if (c.ImagesPath != "" && c.ImagesPath != DefaultImagesPath) || len(c.ImagesSources) > 0 {
 			c.compatibility.AddDeprecated(fmt.Sprintf("'%s' and '%s' are both defined, '%s' will be used", DEPRECATEDTreePathFileKey, ImagesPathKey, DEPRECATEDTreePathFileKey))
 		}
 
 		c.ImagesPath = c.DEPRECATEDTreePathFile
+		c.ImagesSources = nil
 	}
 
 	if c.DEPRECATEDBuilderPath != "" {
 		c.compatibility.AddDeprecated(fmt.Sprintf("'%s' is deprecated and will be removed on v0.12.0, please use '%s' instead", DEPRECATEDBuilderPathKey, BuildersPathKey))
 
-		if c.BuildersPath != "" && c.BuildersPath != DefaultBuildersPath {
+		if (c.BuildersPath != "" && c.BuildersPath != DefaultBuildersPath) || len(c.BuildersSources) > 0 {
 			c.compatibility.AddDeprecated(fmt.Sprintf("'%s' and '%s' are both defined, '%s' will be used", DEPRECATEDBuilderPathKey, BuildersPathKey, DEPRECATEDBuilderPathKey))
 		}
 
 		c.BuildersPath = c.DEPRECATEDBuilderPath
+		c.BuildersSources = nil
 	}
 
 	if c.DEPRECATEDNumWorkers > 0 {
@@ -538,6 +879,84 @@ func (c *Configuration) CheckCompatibility() error {
 	return nil
 }
 
+// loadVaultConfiguration returns the Vault configuration from the credentials block, setting the default values to those attributes that are not defined
+func loadVaultConfiguration(loader ConfigurationLoader, resolver *keyResolver) *VaultConfiguration {
+
+	vaultKey := func(key string) string {
+		return resolver.key(CredentialsKey, CredentialsVaultKey, key)
+	}
+
+	vault := &VaultConfiguration{
+		Address:    loader.GetString(vaultKey(CredentialsVaultAddressKey)),
+		Namespace:  loader.GetString(vaultKey(CredentialsVaultNamespaceKey)),
+		Mount:      loader.GetString(vaultKey(CredentialsVaultMountKey)),
+		Path:       loader.GetString(vaultKey(CredentialsVaultPathKey)),
+		AuthMethod: loader.GetString(vaultKey(CredentialsVaultAuthMethodKey)),
+		AuthMount:  loader.GetString(vaultKey(CredentialsVaultAuthMountKey)),
+		Token:      loader.GetString(vaultKey(CredentialsVaultTokenKey)),
+		RoleID:     loader.GetString(vaultKey(CredentialsVaultRoleIDKey)),
+		SecretID:   loader.GetString(vaultKey(CredentialsVaultSecretIDKey)),
+		Role:       loader.GetString(vaultKey(CredentialsVaultRoleKey)),
+		JWT:        loader.GetString(vaultKey(CredentialsVaultJWTKey)),
+		JWTPath:    loader.GetString(vaultKey(CredentialsVaultJWTPathKey)),
+	}
+
+	if vault.Mount == "" {
+		vault.Mount = DefaultCredentialsVaultMount
+	}
+
+	if vault.Path == "" {
+		vault.Path = DefaultCredentialsVaultPath
+	}
+
+	if vault.AuthMethod == "" {
+		vault.AuthMethod = DefaultCredentialsVaultAuthMethod
+	}
+
+	if vault.AuthMount == "" {
+		vault.AuthMount = vault.AuthMethod
+	}
+
+	return vault
+}
+
+// validate returns an error when the Vault configuration is not valid
+func (v *VaultConfiguration) validate() error {
+
+	errContext := "(VaultConfiguration::validate)"
+
+	if v == nil {
+		return errors.New(errContext, "Vault configuration must be provided")
+	}
+
+	if v.Address == "" {
+		return errors.New(errContext, "Vault address must be provided")
+	}
+
+	switch v.AuthMethod {
+	case credentials.VaultTokenAuth:
+		if v.Token == "" {
+			return errors.New(errContext, "Vault token must be provided to use the 'token' auth method")
+		}
+	case credentials.VaultAppRoleAuth:
+		if v.RoleID == "" {
+			return errors.New(errContext, "Vault role id must be provided to use the 'approle' auth method")
+		}
+	case credentials.VaultJWTAuth:
+		if v.Role == "" {
+			return errors.New(errContext, "Vault role must be provided to use the 'jwt' auth method")
+		}
+
+		if v.JWT == "" && v.JWTPath == "" {
+			return errors.New(errContext, "Either Vault JWT or JWT path must be provided to use the 'jwt' auth method")
+		}
+	default:
+		return errors.New(errContext, fmt.Sprintf("Vault auth method '%s' is not supported", v.AuthMethod))
+	}
+
+	return nil
+}
+
 // createLogWriter return a io.Writer associated to the log file
 func createLogWriter(fs afero.Fs, path string) (io.Writer, error) {
 
@@ -555,6 +974,11 @@ func createLogWriter(fs afero.Fs, path string) (io.Writer, error) {
 	return writer, nil
 }
 
+// defaultImmutableTagsFloatingTags returns the default floating tags expressions, which are 'latest' and the major and minor semantic version tags
+func defaultImmutableTagsFloatingTags() []string {
+	return []string{`^latest$`, `^\d+$`, `^\d+\.\d+$`}
+}
+
 // concurrencyValue returns the concurrency value from the configuration, in case of panic concurrency is set to 1
 func concurrencyValue() (concurrency int) {
 
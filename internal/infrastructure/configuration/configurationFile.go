@@ -0,0 +1,83 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// configurationFile is a configuration file that contributes its values to the merged configuration
+type configurationFile struct {
+	path   string
+	values map[string]interface{}
+}
+
+// loadConfigurationFile returns the configuration file located on the path
+func loadConfigurationFile(fs afero.Fs, path string) (*configurationFile, error) {
+
+	errContext := "(Configuration::loadConfigurationFile)"
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be read", path), err)
+	}
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(content, &values)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be parsed", path), err)
+	}
+
+	return &configurationFile{
+		path:   path,
+		values: values,
+	}, nil
+}
+
+// defines returns true when the configuration file defines the key, whose levels are separated by dots
+func (f *configurationFile) defines(key string) bool {
+
+	var value interface{} = f.values
+
+	for _, level := range strings.Split(key, ".") {
+		values, isMap := value.(map[string]interface{})
+		if !isMap {
+			return false
+		}
+
+		value = nil
+		for k, v := range values {
+			if strings.EqualFold(k, level) {
+				value = v
+				break
+			}
+		}
+
+		if value == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readConfigurationFile reads the configuration file into the loader. When merge is true, the file values are merged over the ones already read
+func readConfigurationFile(fs afero.Fs, loader ConfigurationLoader, path string, merge bool) (*configurationFile, error) {
+
+	var err error
+
+	loader.SetConfigFile(path)
+	if merge {
+		err = loader.MergeInConfig()
+	} else {
+		err = loader.ReadInConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return loadConfigurationFile(fs, path)
+}
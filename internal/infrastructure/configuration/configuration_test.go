@@ -105,10 +105,14 @@ func TestDefaultConfig(t *testing.T) {
 	defaultConcurrency := concurrencyValue()
 
 	expected := &Configuration{
-		BuildersPath:                 filepath.Join(DefaultConfigFolder, DefaultBuildersPath),
-		Concurrency:                  defaultConcurrency,
-		EnableSemanticVersionTags:    DefaultEnableSemanticVersionTags,
-		ImagesPath:                   filepath.Join(DefaultConfigFolder, DefaultImagesPath),
+		BuildersPath:              filepath.Join(DefaultConfigFolder, DefaultBuildersPath),
+		Concurrency:               defaultConcurrency,
+		EnableSemanticVersionTags: DefaultEnableSemanticVersionTags,
+		ImagesPath:                filepath.Join(DefaultConfigFolder, DefaultImagesPath),
+		ImmutableTags: &ImmutableTagsConfiguration{
+			Images:       []string{},
+			FloatingTags: []string{`^latest$`, `^\d+$`, `^\d+\.\d+$`},
+		},
 		LogPathFile:                  DefaultLogPathFile,
 		LogWriter:                    io.Discard,
 		PushImages:                   DefaultPushImages,
@@ -135,6 +139,24 @@ func TestNew(t *testing.T) {
 		log.Fatal(err.Error())
 	}
 
+	mergeFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(mergeFs, filepath.Join(user.HomeDir, "stevedore.yaml"), []byte(`
+concurrency: 2
+push_images: true
+`), 0644)
+	_ = afero.WriteFile(mergeFs, filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml"), []byte(`
+concurrency: 3
+credentials:
+  storage_type: local
+  local_storage_path: /home/credentials
+  format: yaml
+`), 0644)
+	_ = afero.WriteFile(mergeFs, "stevedore.yaml", []byte(`
+images_path: images
+credentials:
+  local_storage_path: credentials
+`), 0644)
+
 	tests := []struct {
 		desc              string
 		res               *Configuration
@@ -142,6 +164,8 @@ func TestNew(t *testing.T) {
 		loader            ConfigurationLoader
 		fs                afero.Fs
 		prepareAssertFunc func(l ConfigurationLoader, c Compatibilitier)
+		sources           map[string]string
+		origins           map[string]*ValueOrigin
 		err               error
 	}{
 		{
@@ -170,7 +194,6 @@ func TestNew(t *testing.T) {
 				l.(*loader.MockConfigurationLoader).On("AutomaticEnv").Return()
 				l.(*loader.MockConfigurationLoader).On("SetEnvPrefix", "stevedore").Return()
 				l.(*loader.MockConfigurationLoader).On("SetEnvKeyReplacer", mock.Anything).Return()
-				l.(*loader.MockConfigurationLoader).On("SetConfigName", DefaultConfigFile).Return()
 				l.(*loader.MockConfigurationLoader).On("SetConfigType", DefaultConfigFileExtention).Return()
 
 				l.(*loader.MockConfigurationLoader).On("SetDefault", BuildersPathKey, filepath.Join(DefaultConfigFolder, DefaultBuildersPath)).Return()
@@ -179,6 +202,9 @@ func TestNew(t *testing.T) {
 
 				l.(*loader.MockConfigurationLoader).On("SetDefault", ImagesPathKey, filepath.Join(DefaultConfigFolder, DefaultImagesPath)).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", LogPathFileKey, DefaultLogPathFile).Return()
+				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{ImmutableTagsKey, ImmutableTagsImagesKey}, "."), []string{}).Return()
+				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{ImmutableTagsKey, ImmutableTagsFloatingTagsKey}, "."), defaultImmutableTagsFloatingTags()).Return()
+				l.(*loader.MockConfigurationLoader).On("SetDefault", PromotionPolicyPathKey, DefaultPromotionPolicyPath).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", PushImagesKey, DefaultPushImages).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", SemanticVersionTagsTemplatesKey, []string{DefaultSemanticVersionTagsTemplates}).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, "."), DefaultCredentialsStorage).Return()
@@ -186,25 +212,27 @@ func TestNew(t *testing.T) {
 				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{CredentialsKey, CredentialsFormatKey}, "."), DefaultCredentialsFormat).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyKey}, "."), DefaultCredentialsEncryptionKey).Return()
 
-				l.(*loader.MockConfigurationLoader).On("AddConfigPath", filepath.Join(user.HomeDir, ".config", "stevedore")).Return()
-				l.(*loader.MockConfigurationLoader).On("AddConfigPath", user.HomeDir).Return()
-				l.(*loader.MockConfigurationLoader).On("AddConfigPath", DefaultConfigFolder).Return()
-
-				l.(*loader.MockConfigurationLoader).On("ReadInConfig").Return(nil)
-
 				l.(*loader.MockConfigurationLoader).On("GetString", LogPathFileKey).Return(DefaultLogPathFile)
 
-				l.(*loader.MockConfigurationLoader).On("GetString", BuildersPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultBuildersPath))
+				l.(*loader.MockConfigurationLoader).On("Get", BuildersPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultBuildersPath))
 				l.(*loader.MockConfigurationLoader).On("GetInt", ConcurrencyKey).Return(concurrencyValue())
 				l.(*loader.MockConfigurationLoader).On("GetBool", EnableSemanticVersionTagsKey).Return(DefaultEnableSemanticVersionTags)
-				l.(*loader.MockConfigurationLoader).On("GetString", ImagesPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultImagesPath))
+				l.(*loader.MockConfigurationLoader).On("GetString", GitSourcesCachePathKey).Return("")
+				l.(*loader.MockConfigurationLoader).On("Get", ImagesPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultImagesPath))
+				l.(*loader.MockConfigurationLoader).On("GetString", PromotionPolicyPathKey).Return(DefaultPromotionPolicyPath)
 				l.(*loader.MockConfigurationLoader).On("GetBool", PushImagesKey).Return(DefaultPushImages)
 				l.(*loader.MockConfigurationLoader).On("GetStringSlice", SemanticVersionTagsTemplatesKey).Return([]string{DefaultSemanticVersionTagsTemplates})
+				l.(*loader.MockConfigurationLoader).On("GetStringSlice", strings.Join([]string{ImmutableTagsKey, ImmutableTagsImagesKey}, ".")).Return([]string{})
+				l.(*loader.MockConfigurationLoader).On("GetStringSlice", strings.Join([]string{ImmutableTagsKey, ImmutableTagsFloatingTagsKey}, ".")).Return(defaultImmutableTagsFloatingTags())
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, ".")).Return(DefaultCredentialsStorage)
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsLocalStoragePathKey}, ".")).Return(DefaultCredentialsLocalStoragePath)
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsFormatKey}, ".")).Return(DefaultCredentialsFormat)
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyKey}, ".")).Return(DefaultCredentialsEncryptionKey)
-				l.(*loader.MockConfigurationLoader).On("ConfigFileUsed").Return("stevedore.yaml")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsAWSECRTokenCachePathKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsExpirationWarningWindowKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyFileKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyEnvKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyCommandKey}, ".")).Return("")
 
 				// DEPRECIATED
 				l.(*loader.MockConfigurationLoader).On("GetInt", DEPRECATEDNumWorkerKey).Return(0)
@@ -223,8 +251,12 @@ func TestNew(t *testing.T) {
 					LocalStoragePath: "credentials",
 					StorageType:      "local",
 				},
-				EnableSemanticVersionTags:    false,
-				ImagesPath:                   filepath.Join(".", "stevedore.yaml"),
+				EnableSemanticVersionTags: false,
+				ImagesPath:                filepath.Join(".", "stevedore.yaml"),
+				ImmutableTags: &ImmutableTagsConfiguration{
+					Images:       []string{},
+					FloatingTags: defaultImmutableTagsFloatingTags(),
+				},
 				LogPathFile:                  "",
 				LogWriter:                    io.Discard,
 				PushImages:                   false,
@@ -244,7 +276,6 @@ func TestNew(t *testing.T) {
 				l.(*loader.MockConfigurationLoader).On("AutomaticEnv").Return()
 				l.(*loader.MockConfigurationLoader).On("SetEnvPrefix", "stevedore").Return()
 				l.(*loader.MockConfigurationLoader).On("SetEnvKeyReplacer", mock.Anything).Return()
-				l.(*loader.MockConfigurationLoader).On("SetConfigName", DefaultConfigFile).Return()
 				l.(*loader.MockConfigurationLoader).On("SetConfigType", DefaultConfigFileExtention).Return()
 
 				l.(*loader.MockConfigurationLoader).On("SetDefault", BuildersPathKey, filepath.Join(DefaultConfigFolder, DefaultBuildersPath)).Return()
@@ -253,6 +284,9 @@ func TestNew(t *testing.T) {
 
 				l.(*loader.MockConfigurationLoader).On("SetDefault", ImagesPathKey, filepath.Join(DefaultConfigFolder, DefaultImagesPath)).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", LogPathFileKey, DefaultLogPathFile).Return()
+				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{ImmutableTagsKey, ImmutableTagsImagesKey}, "."), []string{}).Return()
+				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{ImmutableTagsKey, ImmutableTagsFloatingTagsKey}, "."), defaultImmutableTagsFloatingTags()).Return()
+				l.(*loader.MockConfigurationLoader).On("SetDefault", PromotionPolicyPathKey, DefaultPromotionPolicyPath).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", PushImagesKey, DefaultPushImages).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", SemanticVersionTagsTemplatesKey, []string{DefaultSemanticVersionTagsTemplates}).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, "."), DefaultCredentialsStorage).Return()
@@ -260,25 +294,27 @@ func TestNew(t *testing.T) {
 				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{CredentialsKey, CredentialsFormatKey}, "."), DefaultCredentialsFormat).Return()
 				l.(*loader.MockConfigurationLoader).On("SetDefault", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyKey}, "."), DefaultCredentialsEncryptionKey).Return()
 
-				l.(*loader.MockConfigurationLoader).On("AddConfigPath", filepath.Join(user.HomeDir, ".config", "stevedore")).Return()
-				l.(*loader.MockConfigurationLoader).On("AddConfigPath", user.HomeDir).Return()
-				l.(*loader.MockConfigurationLoader).On("AddConfigPath", DefaultConfigFolder).Return()
-
-				l.(*loader.MockConfigurationLoader).On("ReadInConfig").Return(nil)
-
 				l.(*loader.MockConfigurationLoader).On("GetString", LogPathFileKey).Return(DefaultLogPathFile)
 
-				l.(*loader.MockConfigurationLoader).On("GetString", BuildersPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultBuildersPath))
+				l.(*loader.MockConfigurationLoader).On("Get", BuildersPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultBuildersPath))
 				l.(*loader.MockConfigurationLoader).On("GetInt", ConcurrencyKey).Return(concurrencyValue())
 				l.(*loader.MockConfigurationLoader).On("GetBool", EnableSemanticVersionTagsKey).Return(DefaultEnableSemanticVersionTags)
-				l.(*loader.MockConfigurationLoader).On("GetString", ImagesPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultImagesPath))
+				l.(*loader.MockConfigurationLoader).On("GetString", GitSourcesCachePathKey).Return("")
+				l.(*loader.MockConfigurationLoader).On("Get", ImagesPathKey).Return(filepath.Join(DefaultConfigFolder, DefaultImagesPath))
+				l.(*loader.MockConfigurationLoader).On("GetString", PromotionPolicyPathKey).Return(DefaultPromotionPolicyPath)
 				l.(*loader.MockConfigurationLoader).On("GetBool", PushImagesKey).Return(DefaultPushImages)
 				l.(*loader.MockConfigurationLoader).On("GetStringSlice", SemanticVersionTagsTemplatesKey).Return([]string{DefaultSemanticVersionTagsTemplates})
+				l.(*loader.MockConfigurationLoader).On("GetStringSlice", strings.Join([]string{ImmutableTagsKey, ImmutableTagsImagesKey}, ".")).Return([]string{})
+				l.(*loader.MockConfigurationLoader).On("GetStringSlice", strings.Join([]string{ImmutableTagsKey, ImmutableTagsFloatingTagsKey}, ".")).Return(defaultImmutableTagsFloatingTags())
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, ".")).Return(DefaultCredentialsStorage)
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsLocalStoragePathKey}, ".")).Return(DefaultCredentialsLocalStoragePath)
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsFormatKey}, ".")).Return(DefaultCredentialsFormat)
 				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyKey}, ".")).Return(DefaultCredentialsEncryptionKey)
-				l.(*loader.MockConfigurationLoader).On("ConfigFileUsed").Return("stevedore.yaml")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsAWSECRTokenCachePathKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsExpirationWarningWindowKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyFileKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyEnvKey}, ".")).Return("")
+				l.(*loader.MockConfigurationLoader).On("GetString", strings.Join([]string{CredentialsKey, CredentialsEncryptionKeyCommandKey}, ".")).Return("")
 
 				// DEPRECIATED
 				l.(*loader.MockConfigurationLoader).On("GetInt", DEPRECATEDNumWorkerKey).Return(8)
@@ -294,7 +330,11 @@ func TestNew(t *testing.T) {
 				c.(*compatibility.MockCompatibility).On("AddDeprecated", []string{"'docker_registry_credentials_dir' is deprecated and will be removed on v0.12.0, please use 'credentials' block to configure credentials. Credentials local storage located in '/credentials' has precedence over 'credentials' block and is going to be used as default credentials store"})
 			},
 			res: &Configuration{
-				ImagesPath:                   filepath.Join("images.yaml"),
+				ImagesPath: filepath.Join("images.yaml"),
+				ImmutableTags: &ImmutableTagsConfiguration{
+					Images:       []string{},
+					FloatingTags: defaultImmutableTagsFloatingTags(),
+				},
 				BuildersPath:                 filepath.Join("builders.yaml"),
 				LogPathFile:                  "",
 				Concurrency:                  8,
@@ -311,6 +351,44 @@ func TestNew(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc:          "Testing create new configuration merging the user and project configuration files",
+			fs:            mergeFs,
+			loader:        loader.NewConfigurationLoader(viper.New()),
+			compatibility: compatibility.NewMockCompatibility(),
+			res: &Configuration{
+				BuildersPath: filepath.Join(".", "stevedore.yaml"),
+				Concurrency:  3,
+				Credentials: &CredentialsConfiguration{
+					Format:           "yaml",
+					LocalStoragePath: "credentials",
+					StorageType:      "local",
+				},
+				ImagesPath:                   "images",
+				LogWriter:                    io.Discard,
+				PushImages:                   true,
+				SemanticVersionTagsTemplates: []string{"{{ .Major }}.{{ .Minor }}.{{ .Patch }}"},
+				configFiles: []string{
+					filepath.Join(user.HomeDir, "stevedore.yaml"),
+					filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml"),
+					"stevedore.yaml",
+				},
+			},
+			sources: map[string]string{
+				ConcurrencyKey: filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml"),
+				ImagesPathKey:  "stevedore.yaml",
+				PushImagesKey:  filepath.Join(user.HomeDir, "stevedore.yaml"),
+				strings.Join([]string{CredentialsKey, CredentialsFormatKey}, "."):           filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml"),
+				strings.Join([]string{CredentialsKey, CredentialsLocalStoragePathKey}, "."): "stevedore.yaml",
+				BuildersPathKey: DefaultValueSource,
+			},
+			origins: map[string]*ValueOrigin{
+				ConcurrencyKey: {File: filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml")},
+				ImagesPathKey:  {File: "stevedore.yaml"},
+				strings.Join([]string{CredentialsKey, CredentialsFormatKey}, "."): {File: filepath.Join(user.HomeDir, ".config", "stevedore", "stevedore.yaml")},
+				BuildersPathKey: {},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -336,8 +414,18 @@ func TestNew(t *testing.T) {
 				assert.Equal(t, test.res.LogWriter, c.LogWriter, "assert LogWriter")
 				assert.Equal(t, test.res.PushImages, c.PushImages, "assert PushImages")
 				assert.Equal(t, test.res.SemanticVersionTagsTemplates, c.SemanticVersionTagsTemplates, "assert SemanticVersionTagsTemplates")
-
-				c.loader.(*loader.MockConfigurationLoader).AssertExpectations(t)
+				assert.Equal(t, test.res.configFiles, c.ConfigFilesUsed(), "assert ConfigFilesUsed")
+				for key, source := range test.sources {
+					assert.Equal(t, source, c.ValueSource(key), "assert source of "+key)
+				}
+				for key, origin := range test.origins {
+					assert.Equal(t, origin, c.ValueOrigin(key), "assert origin of "+key)
+				}
+
+				mockLoader, isMock := c.loader.(*loader.MockConfigurationLoader)
+				if isMock {
+					mockLoader.AssertExpectations(t)
+				}
 				c.compatibility.(*compatibility.MockCompatibility).AssertExpectations(t)
 			}
 		})
@@ -360,6 +448,8 @@ credentials:
   storage_type: local
   local_storage_path: mycredentials
   format: yaml
+  aws_ecr_token_cache_path: /config/ecr
+  expiration_warning_window: 14d
 semantic_version_tags_enabled: true
 images_path: /config/stevedore.yaml
 log_path: mystevedore.log
@@ -379,6 +469,72 @@ semantic_version_tags_templates:
 		t.Log(err)
 	}
 
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore_vault.yaml"), []byte(`
+credentials:
+  storage_type: vault
+  vault:
+    address: https://vault.example.com:8200
+    auth_method: approle
+    role_id: role-id
+    secret_id: secret-id
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore_encryption_key_file.yaml"), []byte(`
+credentials:
+  storage_type: local
+  local_storage_path: mycredentials
+  encryption_key_file: /config/encryption.key
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "encryption.key"), []byte("0123456789abcdef\n"), 0600)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore_encryption_key_command.yaml"), []byte(`
+credentials:
+  storage_type: local
+  local_storage_path: mycredentials
+  encryption_key_command: exit 1
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore_sources.yaml"), []byte(`
+builders_path:
+  - /config/builders
+  - git:
+      repository: https://github.com/example/definitions.git
+      reference: v1.0.0
+      path: builders
+git_sources_cache_path: /cache/git
+images_path:
+  - /config/images
+  - git:
+      repository: git@github.com:example/definitions.git
+      path: images
+      credentials_id: github
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore_invalid_sources.yaml"), []byte(`
+images_path:
+  - git:
+      path: images
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
 	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore_deprecated.yaml"), []byte(`
 builder_path: /config/stevedore.yaml
 num_workers: 10
@@ -444,9 +600,11 @@ semantic_version_tags_templates:
 				BuildersPath: "/config/stevedore.yaml",
 				Concurrency:  10,
 				Credentials: &CredentialsConfiguration{
-					StorageType:      "local",
-					LocalStoragePath: "mycredentials",
-					Format:           "yaml",
+					StorageType:             "local",
+					LocalStoragePath:        "mycredentials",
+					Format:                  "yaml",
+					AWSECRTokenCachePath:    "/config/ecr",
+					ExpirationWarningWindow: "14d",
 				},
 				EnableSemanticVersionTags: true,
 				ImagesPath:                "/config/stevedore.yaml",
@@ -483,6 +641,138 @@ semantic_version_tags_templates:
 			},
 			compatibility: compatibility.NewMockCompatibility(),
 		},
+		{
+			desc:   "Testing create new configuration from file with images and builders sources",
+			fs:     testFs,
+			loader: loader.NewConfigurationLoader(viper.New()),
+			file:   filepath.Join(baseDir, "stevedore_sources.yaml"),
+			err:    &errors.Error{},
+			res: &Configuration{
+				BuildersSources: []*DefinitionsSource{
+					{Path: "/config/builders"},
+					{
+						Git: &GitDefinitionsSource{
+							Repository: "https://github.com/example/definitions.git",
+							Reference:  "v1.0.0",
+							Path:       "builders",
+						},
+					},
+				},
+				Concurrency: concurrencyValue(),
+				Credentials: &CredentialsConfiguration{
+					StorageType:      "local",
+					LocalStoragePath: "credentials",
+					Format:           "json",
+				},
+				GitSourcesCachePath: "/cache/git",
+				ImagesSources: []*DefinitionsSource{
+					{Path: "/config/images"},
+					{
+						Git: &GitDefinitionsSource{
+							Repository:    "git@github.com:example/definitions.git",
+							Path:          "images",
+							CredentialsID: "github",
+						},
+					},
+				},
+				SemanticVersionTagsTemplates: []string{
+					"{{ .Major }}.{{ .Minor }}.{{ .Patch }}",
+				},
+			},
+			compatibility: compatibility.NewMockCompatibility(),
+		},
+		{
+			desc:          "Testing error loading configuration from file with an invalid images source",
+			fs:            testFs,
+			loader:        loader.NewConfigurationLoader(viper.New()),
+			file:          filepath.Join(baseDir, "stevedore_invalid_sources.yaml"),
+			compatibility: compatibility.NewMockCompatibility(),
+			err: errors.New(errContext, "",
+				errors.New("(Configuration::ValidateConfiguration)", "Invalid configuration, images source is not valid",
+					errors.New("(Configuration::DefinitionsSource::validate)", "Git definitions source must define a repository"))),
+		},
+		{
+			desc:   "Testing create new configuration from file with Vault credentials store",
+			fs:     testFs,
+			loader: loader.NewConfigurationLoader(viper.New()),
+			file:   filepath.Join(baseDir, "stevedore_vault.yaml"),
+			err:    &errors.Error{},
+			res: &Configuration{
+				BuildersPath: "stevedore.yaml",
+				Concurrency:  concurrencyValue(),
+				Credentials: &CredentialsConfiguration{
+					StorageType: "vault",
+					Format:      "json",
+					Vault: &VaultConfiguration{
+						Address:    "https://vault.example.com:8200",
+						Mount:      DefaultCredentialsVaultMount,
+						Path:       DefaultCredentialsVaultPath,
+						AuthMethod: "approle",
+						AuthMount:  "approle",
+						RoleID:     "role-id",
+						SecretID:   "secret-id",
+					},
+				},
+				EnableSemanticVersionTags: false,
+				ImagesPath:                "stevedore.yaml",
+				LogPathFile:               "",
+				PushImages:                false,
+				SemanticVersionTagsTemplates: []string{
+					"{{ .Major }}.{{ .Minor }}.{{ .Patch }}",
+				},
+			},
+			compatibility: compatibility.NewMockCompatibility(),
+		},
+		{
+			desc:   "Testing create new configuration from file with the encryption key defined on a file, which is not read until the key is required",
+			fs:     testFs,
+			loader: loader.NewConfigurationLoader(viper.New()),
+			file:   filepath.Join(baseDir, "stevedore_encryption_key_file.yaml"),
+			err:    &errors.Error{},
+			res: &Configuration{
+				BuildersPath: "stevedore.yaml",
+				Concurrency:  concurrencyValue(),
+				Credentials: &CredentialsConfiguration{
+					StorageType:       "local",
+					LocalStoragePath:  "mycredentials",
+					Format:            "json",
+					EncryptionKeyFile: "/config/encryption.key",
+				},
+				EnableSemanticVersionTags: false,
+				ImagesPath:                "stevedore.yaml",
+				LogPathFile:               "",
+				PushImages:                false,
+				SemanticVersionTagsTemplates: []string{
+					"{{ .Major }}.{{ .Minor }}.{{ .Patch }}",
+				},
+			},
+			compatibility: compatibility.NewMockCompatibility(),
+		},
+		{
+			desc:   "Testing create new configuration from file with a failing encryption key command, which is not executed until the key is required",
+			fs:     testFs,
+			loader: loader.NewConfigurationLoader(viper.New()),
+			file:   filepath.Join(baseDir, "stevedore_encryption_key_command.yaml"),
+			err:    &errors.Error{},
+			res: &Configuration{
+				BuildersPath: "stevedore.yaml",
+				Concurrency:  concurrencyValue(),
+				Credentials: &CredentialsConfiguration{
+					StorageType:          "local",
+					LocalStoragePath:     "mycredentials",
+					Format:               "json",
+					EncryptionKeyCommand: "exit 1",
+				},
+				EnableSemanticVersionTags: false,
+				ImagesPath:                "stevedore.yaml",
+				LogPathFile:               "",
+				PushImages:                false,
+				SemanticVersionTagsTemplates: []string{
+					"{{ .Major }}.{{ .Minor }}.{{ .Patch }}",
+				},
+			},
+			compatibility: compatibility.NewMockCompatibility(),
+		},
 		{
 			desc:   "Testing create new configuration from file with deprecated configuration",
 			fs:     testFs,
@@ -534,10 +824,13 @@ semantic_version_tags_templates:
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
 				assert.Equal(t, test.res.BuildersPath, config.BuildersPath, "assert BuildersPath")
+				assert.Equal(t, test.res.BuildersSources, config.BuildersSources, "assert BuildersSources")
 				assert.Equal(t, test.res.Concurrency, config.Concurrency, "assert Concurrency")
 				assert.Equal(t, test.res.Credentials, config.Credentials, "assert Credentials")
 				assert.Equal(t, test.res.EnableSemanticVersionTags, config.EnableSemanticVersionTags, "assert EnableSemanticVersionTags")
+				assert.Equal(t, test.res.GitSourcesCachePath, config.GitSourcesCachePath, "assert GitSourcesCachePath")
 				assert.Equal(t, test.res.ImagesPath, config.ImagesPath, "assert ImagesPath")
+				assert.Equal(t, test.res.ImagesSources, config.ImagesSources, "assert ImagesSources")
 				assert.Equal(t, test.res.LogPathFile, config.LogPathFile, "assert LogPathFile")
 				assert.Equal(t, test.res.PushImages, config.PushImages, "assert PushImages")
 				assert.Equal(t, test.res.SemanticVersionTagsTemplates, config.SemanticVersionTagsTemplates, "assert SemanticVersionTagsTemplates")
@@ -641,6 +934,129 @@ semantic_version_tags_templates:
 	}
 }
 
+func TestReloadConfigurationWithProfile(t *testing.T) {
+	var err error
+
+	errContext := "(Configuration::ReloadConfigurationWithProfile)"
+
+	baseDir := "/config"
+	testFs := afero.NewMemMapFs()
+	testFs.MkdirAll(baseDir, 0755)
+	err = afero.WriteFile(testFs, filepath.Join(baseDir, "stevedore.yaml"), []byte(`
+builders_path: /config/stevedore.yaml
+concurrency: 10
+credentials:
+  storage_type: local
+  local_storage_path: mycredentials
+images_path: /config/stevedore.yaml
+push_images: false
+profiles:
+  ci:
+    concurrency: 2
+    push_images: true
+    images_path: /config/ci/images.yaml
+    credentials:
+      storage_type: envvars
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	tests := []struct {
+		desc              string
+		config            *Configuration
+		profile           string
+		res               *Configuration
+		sources           map[string]string
+		prepareAssertFunc func(c *Configuration)
+		err               error
+	}{
+		{
+			desc:   "Testing error when reloading configuration with profile without profile",
+			config: &Configuration{},
+			err:    errors.New(errContext, "Profile must be provided to reload configuration with a profile"),
+		},
+		{
+			desc:    "Testing error when reloading configuration with profile without configuration file",
+			config:  &Configuration{},
+			profile: "ci",
+			err:     errors.New(errContext, "Profile 'ci' can not be selected because there is no configuration file"),
+		},
+		{
+			desc: "Testing error when reloading configuration with an undefined profile",
+			config: &Configuration{
+				configFile:    filepath.Join(baseDir, "stevedore.yaml"),
+				configFiles:   []string{filepath.Join(baseDir, "stevedore.yaml")},
+				fs:            testFs,
+				loader:        loader.NewConfigurationLoader(viper.New()),
+				compatibility: compatibility.NewMockCompatibility(),
+			},
+			profile: "prod",
+			err: errors.New(errContext, "",
+				errors.New("(configuration::LoadFromFile)", "",
+					errors.New("(Configuration::keyResolver::validate)", "Profile 'prod' is not defined on the configuration files: /config/stevedore.yaml"))),
+		},
+		{
+			desc: "Testing reloading configuration with profile",
+			config: &Configuration{
+				configFile:    filepath.Join(baseDir, "stevedore.yaml"),
+				configFiles:   []string{filepath.Join(baseDir, "stevedore.yaml")},
+				fs:            testFs,
+				loader:        loader.NewConfigurationLoader(viper.New()),
+				compatibility: compatibility.NewMockCompatibility(),
+			},
+			profile: "ci",
+			res: &Configuration{
+				BuildersPath: "/config/stevedore.yaml",
+				Concurrency:  2,
+				ImagesPath:   "/config/ci/images.yaml",
+				Credentials: &CredentialsConfiguration{
+					StorageType:      "envvars",
+					LocalStoragePath: "mycredentials",
+					Format:           "json",
+				},
+				PushImages: true,
+			},
+			sources: map[string]string{
+				BuildersPathKey: "/config/stevedore.yaml",
+				ConcurrencyKey:  "profile 'ci' on /config/stevedore.yaml",
+				ImagesPathKey:   "profile 'ci' on /config/stevedore.yaml",
+				PushImagesKey:   "profile 'ci' on /config/stevedore.yaml",
+				strings.Join([]string{CredentialsKey, CredentialsStorageTypeKey}, "."):      "profile 'ci' on /config/stevedore.yaml",
+				strings.Join([]string{CredentialsKey, CredentialsLocalStoragePathKey}, "."): "/config/stevedore.yaml",
+				strings.Join([]string{CredentialsKey, CredentialsFormatKey}, "."):           DefaultValueSource,
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.config)
+			}
+
+			err := test.config.ReloadConfigurationWithProfile(test.profile)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.profile, test.config.Profile(), "assert Profile")
+				assert.Equal(t, test.res.BuildersPath, test.config.BuildersPath, "assert BuildersPath")
+				assert.Equal(t, test.res.Concurrency, test.config.Concurrency, "assert Concurrency")
+				assert.Equal(t, test.res.Credentials, test.config.Credentials, "assert Credentials")
+				assert.Equal(t, test.res.ImagesPath, test.config.ImagesPath, "assert ImagesPath")
+				assert.Equal(t, test.res.PushImages, test.config.PushImages, "assert PushImages")
+
+				for key, source := range test.sources {
+					assert.Equal(t, source, test.config.ValueSource(key), "assert source of "+key)
+				}
+			}
+		})
+	}
+}
+
 func TestCheckCompatibility(t *testing.T) {
 
 	errContext := "(Configuration::CheckCompatibility)"
@@ -766,6 +1182,44 @@ func TestValidateConfiguration(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing error when Vault credentials store has no address",
+			config: &Configuration{
+				BuildersPath: filepath.Join(baseDir, "mystevedore.yaml"),
+				Concurrency:  10,
+				ImagesPath:   filepath.Join(baseDir, "mystevedore.yaml"),
+				Credentials: &CredentialsConfiguration{
+					StorageType: "vault",
+					Format:      "json",
+					Vault: &VaultConfiguration{
+						AuthMethod: "token",
+						Token:      "token",
+					},
+				},
+				fs: testFs,
+			},
+			err: errors.New(errContext, "Invalid configuration, credentials Vault configuration is not valid",
+				errors.New("(VaultConfiguration::validate)", "Vault address must be provided")),
+		},
+		{
+			desc: "Testing error when Vault credentials store uses an unsupported auth method",
+			config: &Configuration{
+				BuildersPath: filepath.Join(baseDir, "mystevedore.yaml"),
+				Concurrency:  10,
+				ImagesPath:   filepath.Join(baseDir, "mystevedore.yaml"),
+				Credentials: &CredentialsConfiguration{
+					StorageType: "vault",
+					Format:      "json",
+					Vault: &VaultConfiguration{
+						Address:    "https://vault.example.com:8200",
+						AuthMethod: "userpass",
+					},
+				},
+				fs: testFs,
+			},
+			err: errors.New(errContext, "Invalid configuration, credentials Vault configuration is not valid",
+				errors.New("(VaultConfiguration::validate)", "Vault auth method 'userpass' is not supported")),
+		},
 		// Note: It is not validated if fs is defined
 		// {
 		// 	desc:   "Testing error when file system is not defined on configuration",
@@ -835,6 +1289,40 @@ func TestValidateConfiguration(t *testing.T) {
 			},
 			err: errors.New(errContext, "Invalid configuration, credentials local storage path must be provided"),
 		},
+		{
+			desc: "Testing error when more than one credentials encryption key source is defined",
+			config: &Configuration{
+				BuildersPath: filepath.Join(baseDir, "mystevedore.yaml"),
+				ImagesPath:   filepath.Join(baseDir, "mystevedore.yaml"),
+				Concurrency:  1,
+				Credentials: &CredentialsConfiguration{
+					StorageType:       "local",
+					LocalStoragePath:  "mycredentials",
+					Format:            "json",
+					EncryptionKeyFile: "/config/encryption.key",
+					EncryptionKeyEnv:  "ENCRYPTION_KEY",
+				},
+				fs: testFs,
+			},
+			err: errors.New(errContext, "Invalid configuration, credentials encryption key is not valid",
+				errors.New("(CredentialsConfiguration::validateEncryptionKeySources)", "Only one encryption key source can be defined, but 'encryption_key_file', 'encryption_key_env' are defined")),
+		},
+		{
+			desc: "Testing error when immutable tags floating tags are not valid",
+			config: &Configuration{
+				BuildersPath: filepath.Join(baseDir, "mystevedore.yaml"),
+				ImagesPath:   filepath.Join(baseDir, "mystevedore.yaml"),
+				Concurrency:  1,
+				ImmutableTags: &ImmutableTagsConfiguration{
+					Images:       []string{"stable/*"},
+					FloatingTags: []string{"^(latest$"},
+				},
+				fs: testFs,
+			},
+			err: errors.New(errContext, "Invalid configuration, immutable tags are not valid",
+				errors.New("(core::domain::image::NewImmutableTags)", "Floating tag expression '^(latest$' is not valid",
+					errors.New("", "error parsing regexp: missing closing ): `^(latest$`"))),
+		},
 	}
 
 	for _, test := range tests {
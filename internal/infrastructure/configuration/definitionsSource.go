@@ -0,0 +1,100 @@
+package configuration
+
+import (
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+// DefinitionsSource is a location where images or builders are defined. It is either a local path or a git repository
+type DefinitionsSource struct {
+	// Path is the file or folder where the definitions are located
+	Path string `yaml:"path"`
+	// Git is the git repository where the definitions are located
+	Git *GitDefinitionsSource `yaml:"git"`
+}
+
+// GitDefinitionsSource is a git repository where images or builders are defined. The repository is cloned into the git sources cache
+type GitDefinitionsSource struct {
+	// Repository is the url of the git repository
+	Repository string `yaml:"repository"`
+	// Reference is the branch, tag or commit to checkout. By default, it is used the repository default branch
+	Reference string `yaml:"reference"`
+	// Path is the file or folder inside the repository where the definitions are located. By default, it is used the repository root
+	Path string `yaml:"path"`
+	// CredentialsID is the id of the credentials on credentials store to use to authenticate to the git repository. When it is not defined, the credentials stored for the repository host are used
+	CredentialsID string `yaml:"credentials_id"`
+}
+
+// validate returns an error when the source does not define either a path or a git repository
+func (s *DefinitionsSource) validate() error {
+
+	errContext := "(Configuration::DefinitionsSource::validate)"
+
+	if s == nil {
+		return errors.New(errContext, "Definitions source must be defined")
+	}
+
+	if s.Path != "" && s.Git != nil {
+		return errors.New(errContext, fmt.Sprintf("Definitions source can not define both a path and a git repository, found path '%s' and git repository '%s'", s.Path, s.Git.Repository))
+	}
+
+	if s.Path == "" && s.Git == nil {
+		return errors.New(errContext, "Definitions source must define either a path or a git repository")
+	}
+
+	if s.Git != nil && s.Git.Repository == "" {
+		return errors.New(errContext, "Git definitions source must define a repository")
+	}
+
+	return nil
+}
+
+// loadDefinitionsSources returns the definitions path or sources achieved from a configuration value, which is either a path or a list whose items are paths or sources
+func loadDefinitionsSources(value interface{}) (string, []*DefinitionsSource, error) {
+
+	errContext := "(Configuration::loadDefinitionsSources)"
+
+	switch v := value.(type) {
+	case nil:
+		return "", nil, nil
+	case string:
+		return v, nil, nil
+	case []string:
+		sources := []*DefinitionsSource{}
+		for _, path := range v {
+			sources = append(sources, &DefinitionsSource{Path: path})
+		}
+
+		return "", sources, nil
+	case []interface{}:
+		sources := []*DefinitionsSource{}
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				sources = append(sources, &DefinitionsSource{Path: i})
+			case map[string]interface{}:
+				source := &DefinitionsSource{}
+
+				sourceDefinitionBytes, err := yaml.Marshal(i)
+				if err != nil {
+					return "", nil, errors.New(errContext, "There is an error marshaling the definitions source", err)
+				}
+
+				err = yaml.Unmarshal(sourceDefinitionBytes, source)
+				if err != nil {
+					return "", nil, errors.New(errContext, fmt.Sprintf("Definitions source could not be created.\nfound:\n'%s'\n", string(sourceDefinitionBytes)), err)
+				}
+
+				sources = append(sources, source)
+			default:
+				return "", nil, errors.New(errContext, fmt.Sprintf("Definitions source '%v' is not valid, it must be either a path or a git repository", item))
+			}
+		}
+
+		return "", sources, nil
+	default:
+		return "", nil, errors.New(errContext, fmt.Sprintf("Definitions sources '%v' are not valid, they must be either a path or a list of sources", value))
+	}
+}
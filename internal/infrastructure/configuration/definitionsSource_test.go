@@ -0,0 +1,193 @@
+package configuration
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDefinitionsSources(t *testing.T) {
+
+	errContext := "(Configuration::loadDefinitionsSources)"
+
+	tests := []struct {
+		desc    string
+		value   interface{}
+		path    string
+		sources []*DefinitionsSource
+		err     error
+	}{
+		{
+			desc:  "Testing load definitions sources from a path",
+			value: "stevedore.yaml",
+			path:  "stevedore.yaml",
+		},
+		{
+			desc:  "Testing load definitions sources from a list of paths",
+			value: []string{"images", "base-images"},
+			sources: []*DefinitionsSource{
+				{Path: "images"},
+				{Path: "base-images"},
+			},
+		},
+		{
+			desc: "Testing load definitions sources from a list of paths and git repositories",
+			value: []interface{}{
+				"images",
+				map[string]interface{}{
+					"git": map[string]interface{}{
+						"repository":     "https://github.com/example/definitions.git",
+						"reference":      "main",
+						"path":           "images",
+						"credentials_id": "github",
+					},
+				},
+			},
+			sources: []*DefinitionsSource{
+				{Path: "images"},
+				{
+					Git: &GitDefinitionsSource{
+						Repository:    "https://github.com/example/definitions.git",
+						Reference:     "main",
+						Path:          "images",
+						CredentialsID: "github",
+					},
+				},
+			},
+		},
+		{
+			desc:  "Testing error loading definitions sources from a list with an invalid item",
+			value: []interface{}{"images", 10},
+			err:   errors.New(errContext, "Definitions source '10' is not valid, it must be either a path or a git repository"),
+		},
+		{
+			desc:  "Testing error loading definitions sources from an invalid value",
+			value: 10,
+			err:   errors.New(errContext, "Definitions sources '10' are not valid, they must be either a path or a list of sources"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			path, sources, err := loadDefinitionsSources(test.value)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, test.err)
+
+			assert.Equal(t, test.path, path)
+			assert.Equal(t, test.sources, sources)
+		})
+	}
+}
+
+func TestDefinitionsSourceValidate(t *testing.T) {
+
+	errContext := "(Configuration::DefinitionsSource::validate)"
+
+	tests := []struct {
+		desc   string
+		source *DefinitionsSource
+		err    error
+	}{
+		{
+			desc:   "Testing validate a local definitions source",
+			source: &DefinitionsSource{Path: "images"},
+		},
+		{
+			desc: "Testing validate a git definitions source",
+			source: &DefinitionsSource{
+				Git: &GitDefinitionsSource{
+					Repository: "https://github.com/example/definitions.git",
+				},
+			},
+		},
+		{
+			desc:   "Testing error validating an empty definitions source",
+			source: &DefinitionsSource{},
+			err:    errors.New(errContext, "Definitions source must define either a path or a git repository"),
+		},
+		{
+			desc: "Testing error validating a definitions source with a path and a git repository",
+			source: &DefinitionsSource{
+				Path: "images",
+				Git: &GitDefinitionsSource{
+					Repository: "https://github.com/example/definitions.git",
+				},
+			},
+			err: errors.New(errContext, "Definitions source can not define both a path and a git repository, found path 'images' and git repository 'https://github.com/example/definitions.git'"),
+		},
+		{
+			desc: "Testing error validating a git definitions source without repository",
+			source: &DefinitionsSource{
+				Git: &GitDefinitionsSource{
+					Path: "images",
+				},
+			},
+			err: errors.New(errContext, "Git definitions source must define a repository"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.source.validate()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, test.err)
+		})
+	}
+}
+
+func TestHasGitDefinitionsSources(t *testing.T) {
+	tests := []struct {
+		desc   string
+		config *Configuration
+		res    bool
+	}{
+		{
+			desc: "Testing has git definitions sources when images and builders are defined on local paths",
+			config: &Configuration{
+				BuildersPath: "builders",
+				ImagesPath:   "images",
+			},
+			res: false,
+		},
+		{
+			desc: "Testing has git definitions sources when images are defined on a git repository",
+			config: &Configuration{
+				BuildersPath: "builders",
+				ImagesSources: []*DefinitionsSource{
+					{Path: "images"},
+					{Git: &GitDefinitionsSource{Repository: "https://github.com/example/definitions.git"}},
+				},
+			},
+			res: true,
+		},
+		{
+			desc: "Testing has git definitions sources when builders are defined on a git repository",
+			config: &Configuration{
+				ImagesPath: "images",
+				BuildersSources: []*DefinitionsSource{
+					{Git: &GitDefinitionsSource{Repository: "https://github.com/example/definitions.git"}},
+				},
+			},
+			res: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.config.HasGitDefinitionsSources())
+		})
+	}
+}
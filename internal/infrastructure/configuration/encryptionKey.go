@@ -0,0 +1,139 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+)
+
+const (
+	// EncryptionKeyCommandShell is the shell used to execute the encryption key command
+	EncryptionKeyCommandShell = "sh"
+)
+
+// validateEncryptionKeySources returns an error when more than one of the encryption key file, environment variable or command is defined
+func (c *CredentialsConfiguration) validateEncryptionKeySources() error {
+
+	errContext := "(CredentialsConfiguration::validateEncryptionKeySources)"
+
+	sources := []string{}
+
+	if c.EncryptionKeyFile != "" {
+		sources = append(sources, fmt.Sprintf("'%s'", CredentialsEncryptionKeyFileKey))
+	}
+
+	if c.EncryptionKeyEnv != "" {
+		sources = append(sources, fmt.Sprintf("'%s'", CredentialsEncryptionKeyEnvKey))
+	}
+
+	if c.EncryptionKeyCommand != "" {
+		sources = append(sources, fmt.Sprintf("'%s'", CredentialsEncryptionKeyCommandKey))
+	}
+
+	if len(sources) > 1 {
+		return errors.New(errContext, fmt.Sprintf("Only one encryption key source can be defined, but %s are defined", strings.Join(sources, ", ")))
+	}
+
+	return nil
+}
+
+// ResolveEncryptionKey returns the encryption key, achieving it from the encryption key file, environment variable or command when it is not already defined. The achieved key is kept on the configuration, so its source is used only once. The encryption key has precedence over those sources, so they are not used when it is already defined
+func (c *CredentialsConfiguration) ResolveEncryptionKey(fs afero.Fs) (string, error) {
+
+	var err error
+	var key string
+
+	errContext := "(CredentialsConfiguration::ResolveEncryptionKey)"
+
+	err = c.validateEncryptionKeySources()
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	if c.EncryptionKey != "" {
+		return c.EncryptionKey, nil
+	}
+
+	switch {
+	case c.EncryptionKeyFile != "":
+		key, err = encryptionKeyFromFile(fs, c.EncryptionKeyFile)
+	case c.EncryptionKeyEnv != "":
+		key, err = encryptionKeyFromEnv(c.EncryptionKeyEnv)
+	case c.EncryptionKeyCommand != "":
+		key, err = encryptionKeyFromCommand(c.EncryptionKeyCommand)
+	default:
+		return "", nil
+	}
+
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	c.EncryptionKey = key
+
+	return key, nil
+}
+
+// encryptionKeyFromFile returns the encryption key stored on the file, ignoring its leading and trailing white spaces
+func encryptionKeyFromFile(fs afero.Fs, path string) (string, error) {
+
+	errContext := "(configuration::encryptionKeyFromFile)"
+
+	if fs == nil {
+		return "", errors.New(errContext, "File system must be provided to read the encryption key file")
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Encryption key file '%s' could not be read", path), err)
+	}
+
+	key := strings.TrimSpace(string(content))
+	if key == "" {
+		return "", errors.New(errContext, fmt.Sprintf("Encryption key file '%s' is empty", path))
+	}
+
+	return key, nil
+}
+
+// encryptionKeyFromEnv returns the encryption key defined on the environment variable
+func encryptionKeyFromEnv(name string) (string, error) {
+
+	errContext := "(configuration::encryptionKeyFromEnv)"
+
+	key := strings.TrimSpace(os.Getenv(name))
+	if key == "" {
+		return "", errors.New(errContext, fmt.Sprintf("Encryption key environment variable '%s' is not defined", name))
+	}
+
+	return key, nil
+}
+
+// encryptionKeyFromCommand returns the encryption key printed by the command, which is executed through a shell
+func encryptionKeyFromCommand(command string) (string, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	errContext := "(configuration::encryptionKeyFromCommand)"
+
+	cmd := exec.Command(EncryptionKeyCommandShell, "-c", command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Encryption key command '%s' failed. %s", command, strings.TrimSpace(stderr.String())), err)
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		return "", errors.New(errContext, fmt.Sprintf("Encryption key command '%s' did not print any key", command))
+	}
+
+	return key, nil
+}
@@ -0,0 +1,140 @@
+package configuration
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveEncryptionKey(t *testing.T) {
+	var err error
+	errContext := "(CredentialsConfiguration::ResolveEncryptionKey)"
+
+	testFs := afero.NewMemMapFs()
+	err = afero.WriteFile(testFs, "/config/encryption.key", []byte(" 0123456789abcdef\n"), 0600)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, "/config/empty.key", []byte("\n"), 0600)
+	if err != nil {
+		t.Log(err)
+	}
+
+	t.Setenv("STEVEDORE_TEST_ENCRYPTION_KEY", "env-encryption-key")
+	t.Setenv("STEVEDORE_TEST_EMPTY_ENCRYPTION_KEY", "")
+
+	tests := []struct {
+		desc   string
+		config *CredentialsConfiguration
+		res    string
+		err    error
+	}{
+		{
+			desc:   "Testing resolve encryption key when no encryption key source is defined",
+			config: &CredentialsConfiguration{},
+			res:    "",
+			err:    &errors.Error{},
+		},
+		{
+			desc: "Testing resolve encryption key when the encryption key has precedence over the encryption key sources",
+			config: &CredentialsConfiguration{
+				EncryptionKey:     "encryption-key",
+				EncryptionKeyFile: "/config/encryption.key",
+			},
+			res: "encryption-key",
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing resolve encryption key from file",
+			config: &CredentialsConfiguration{
+				EncryptionKeyFile: "/config/encryption.key",
+			},
+			res: "0123456789abcdef",
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing resolve encryption key from environment variable",
+			config: &CredentialsConfiguration{
+				EncryptionKeyEnv: "STEVEDORE_TEST_ENCRYPTION_KEY",
+			},
+			res: "env-encryption-key",
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing resolve encryption key from command",
+			config: &CredentialsConfiguration{
+				EncryptionKeyCommand: "printf 'command-encryption-key\\n'",
+			},
+			res: "command-encryption-key",
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error resolving encryption key when more than one encryption key source is defined",
+			config: &CredentialsConfiguration{
+				EncryptionKeyEnv:     "STEVEDORE_TEST_ENCRYPTION_KEY",
+				EncryptionKeyCommand: "echo key",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(CredentialsConfiguration::validateEncryptionKeySources)", "Only one encryption key source can be defined, but 'encryption_key_env', 'encryption_key_command' are defined")),
+		},
+		{
+			desc: "Testing error resolving encryption key from an unexisting file",
+			config: &CredentialsConfiguration{
+				EncryptionKeyFile: "/config/unknown.key",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(configuration::encryptionKeyFromFile)", "Encryption key file '/config/unknown.key' could not be read",
+					errors.New("", "open /config/unknown.key: file does not exist"))),
+		},
+		{
+			desc: "Testing error resolving encryption key from an empty file",
+			config: &CredentialsConfiguration{
+				EncryptionKeyFile: "/config/empty.key",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(configuration::encryptionKeyFromFile)", "Encryption key file '/config/empty.key' is empty")),
+		},
+		{
+			desc: "Testing error resolving encryption key from an undefined environment variable",
+			config: &CredentialsConfiguration{
+				EncryptionKeyEnv: "STEVEDORE_TEST_EMPTY_ENCRYPTION_KEY",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(configuration::encryptionKeyFromEnv)", "Encryption key environment variable 'STEVEDORE_TEST_EMPTY_ENCRYPTION_KEY' is not defined")),
+		},
+		{
+			desc: "Testing error resolving encryption key from a failing command",
+			config: &CredentialsConfiguration{
+				EncryptionKeyCommand: "echo failure >&2; exit 1",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(configuration::encryptionKeyFromCommand)", "Encryption key command 'echo failure >&2; exit 1' failed. failure",
+					errors.New("", "exit status 1"))),
+		},
+		{
+			desc: "Testing error resolving encryption key from a command that does not print any key",
+			config: &CredentialsConfiguration{
+				EncryptionKeyCommand: "true",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(configuration::encryptionKeyFromCommand)", "Encryption key command 'true' did not print any key")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			key, err := test.config.ResolveEncryptionKey(testFs)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, key)
+				assert.Equal(t, test.res, test.config.EncryptionKey)
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ type ImagesConfiguration struct {
 	render        repository.Renderer
 
 	// DEPRECATEDImagesTree is replaced by Images
-	DEPRECATEDImagesTree map[string]map[string]*image.Image `yaml:"images_tree"`
+	DEPRECATEDImagesTree map[string]map[string]*image.Image `yaml:"images_tree" jsonschema:"deprecated=images"`
 	Images               map[string]map[string]*image.Image `yaml:"images"`
 }
 
@@ -66,15 +66,17 @@ func (c *ImagesConfiguration) CheckCompatibility() error {
 	return nil
 }
 
-// LoadImagesToStore method loads images defined on configuration to images store
-func (c *ImagesConfiguration) LoadImagesToStore(path string) error {
+// LoadImagesToStore method loads images defined on configuration to images store. When several paths are provided, the images defined on all of them are loaded before being stored, so the images can extend the ones defined on another path
+func (c *ImagesConfiguration) LoadImagesToStore(paths ...string) error {
 
 	var err error
 	errContext := "(images::LoadImagesToStore)"
 
-	err = c.LoadImagesConfiguration(path)
-	if err != nil {
-		return errors.New(errContext, "", err)
+	for _, path := range paths {
+		err = c.LoadImagesConfiguration(path)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
 	}
 
 	storedNodes := map[string]struct{}{}
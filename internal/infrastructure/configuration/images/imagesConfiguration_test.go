@@ -67,6 +67,8 @@ func TestLoadImagesToStore(t *testing.T) {
 
 	baseDir := "/imagestree"
 	baseErrorDir := "/imagestree_error"
+	parentsDir := "/imagestree_parents"
+	childrenDir := "/imagestree_children"
 	testFs := afero.NewMemMapFs()
 	testFs.MkdirAll(baseDir, 0755)
 
@@ -97,6 +99,40 @@ images:
 		t.Log(err)
 	}
 
+	err = afero.WriteFile(testFs, filepath.Join(parentsDir, "images.yaml"), []byte(`
+images:
+  parent1:
+    parent1_version:
+      registry: registry.test
+      namespace: namespace
+      version: "v{{ .Version }}"
+      builder: builder
+      persistent_labels:
+        plabel: plabelvalue
+      persistent_vars:
+        pvar: pvarvalue
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	err = afero.WriteFile(testFs, filepath.Join(childrenDir, "images.yaml"), []byte(`
+images:
+  child:
+    version:
+      registry: registry.test
+      namespace: namespace
+      name: child
+      version: "{{ .Parent.Version }}"
+      builder: builder
+      parents:
+        parent1:
+        - parent1_version
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
 	err = afero.WriteFile(testFs, filepath.Join(baseErrorDir, "tab_error_file.yaml"), []byte(`
 images:
 image:
@@ -108,17 +144,180 @@ image:
 		t.Log(err)
 	}
 
+	// prepareLoadImages sets the expectations to store the parent1:parent1_version and child:version images
+	prepareLoadImages := func(i *ImagesConfiguration) {
+
+		// Create parent1:parent1_version
+		i.render.(*render.MockImageRender).On("Render", "parent1", "parent1_version",
+			&domainimage.Image{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "parent1",
+				Version:           "v{{ .Version }}",
+				Builder:           "builder",
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+			},
+		).Return(
+			&domainimage.Image{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "parent1",
+				Version:           "vparent1_version",
+				Builder:           "builder",
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+			}, nil)
+
+		i.store.(*images.MockStore).On("Store", "parent1", "parent1_version",
+			&domainimage.Image{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "parent1",
+				Version:           "vparent1_version",
+				Builder:           "builder",
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+			},
+		).Return(nil)
+
+		// Create child:version
+		i.store.(*images.MockStore).On("Find", "parent1", "parent1_version").Return([]*domainimage.Image{
+			{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "parent1",
+				Version:           "vparent1_version",
+				Builder:           "builder",
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+			},
+		}, nil)
+
+		i.render.(*render.MockImageRender).On("Render", "child", "version",
+			&domainimage.Image{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "child",
+				Version:           "{{ .Parent.Version }}",
+				Builder:           "builder",
+				Children:          []*domainimage.Image{},
+				Labels:            map[string]string{},
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+				Tags: []string{},
+				Vars: map[string]interface{}{},
+				Parent: &domainimage.Image{
+					RegistryHost:      "registry.test",
+					RegistryNamespace: "namespace",
+					Name:              "parent1",
+					Version:           "vparent1_version",
+					Builder:           "builder",
+					PersistentLabels: map[string]string{
+						"plabel": "plabelvalue",
+					},
+					PersistentVars: map[string]interface{}{
+						"pvar": "pvarvalue",
+					},
+				},
+			},
+		).Return(
+			&domainimage.Image{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "child",
+				Version:           "vparent_version",
+				Builder:           "builder",
+				Children:          []*domainimage.Image{},
+				Labels:            map[string]string{},
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+				Tags: []string{},
+				Vars: map[string]interface{}{},
+				Parent: &domainimage.Image{
+					RegistryHost:      "registry.test",
+					RegistryNamespace: "namespace",
+					Name:              "parent1",
+					Version:           "v{{ .Version }}",
+					Builder:           "builder",
+					PersistentLabels: map[string]string{
+						"plabel": "plabelvalue",
+					},
+					PersistentVars: map[string]interface{}{
+						"pvar": "pvarvalue",
+					},
+				},
+			}, nil)
+
+		i.store.(*images.MockStore).On("Store", "child", "version",
+			&domainimage.Image{
+				RegistryHost:      "registry.test",
+				RegistryNamespace: "namespace",
+				Name:              "child",
+				Version:           "vparent_version",
+				Builder:           "builder",
+				Children:          []*domainimage.Image{},
+				Labels:            map[string]string{},
+				PersistentLabels: map[string]string{
+					"plabel": "plabelvalue",
+				},
+				PersistentVars: map[string]interface{}{
+					"pvar": "pvarvalue",
+				},
+				Tags: []string{},
+				Vars: map[string]interface{}{},
+				Parent: &domainimage.Image{
+					RegistryHost:      "registry.test",
+					RegistryNamespace: "namespace",
+					Name:              "parent1",
+					Version:           "v{{ .Version }}",
+					Builder:           "builder",
+					PersistentLabels: map[string]string{
+						"plabel": "plabelvalue",
+					},
+					PersistentVars: map[string]interface{}{
+						"pvar": "pvarvalue",
+					},
+				},
+			},
+		).Return(nil)
+	}
+
 	tests := []struct {
 		desc              string
-		path              string
+		paths             []string
 		err               error
 		images            *ImagesConfiguration
 		prepareAssertFunc func(*ImagesConfiguration)
 		assertFunc        func(*testing.T, *ImagesConfiguration)
 	}{
 		{
-			desc: "Testing load images to store",
-			path: baseDir,
+			desc:  "Testing load images to store",
+			paths: []string{baseDir},
 			images: NewImagesConfiguration(
 				testFs,
 				graph.NewImagesGraphTemplate(
@@ -128,167 +327,26 @@ image:
 				render.NewMockImageRender(),
 				compatibility.NewMockCompatibility(),
 			),
-			prepareAssertFunc: func(i *ImagesConfiguration) {
-
-				// Create parent1:parent1_version
-				i.render.(*render.MockImageRender).On("Render", "parent1", "parent1_version",
-					&domainimage.Image{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "parent1",
-						Version:           "v{{ .Version }}",
-						Builder:           "builder",
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-					},
-				).Return(
-					&domainimage.Image{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "parent1",
-						Version:           "vparent1_version",
-						Builder:           "builder",
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-					}, nil)
-
-				i.store.(*images.MockStore).On("Store", "parent1", "parent1_version",
-					&domainimage.Image{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "parent1",
-						Version:           "vparent1_version",
-						Builder:           "builder",
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-					},
-				).Return(nil)
-
-				// Create child:version
-				i.store.(*images.MockStore).On("Find", "parent1", "parent1_version").Return([]*domainimage.Image{
-					{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "parent1",
-						Version:           "vparent1_version",
-						Builder:           "builder",
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-					},
-				}, nil)
-
-				i.render.(*render.MockImageRender).On("Render", "child", "version",
-					&domainimage.Image{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "child",
-						Version:           "{{ .Parent.Version }}",
-						Builder:           "builder",
-						Children:          []*domainimage.Image{},
-						Labels:            map[string]string{},
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-						Tags: []string{},
-						Vars: map[string]interface{}{},
-						Parent: &domainimage.Image{
-							RegistryHost:      "registry.test",
-							RegistryNamespace: "namespace",
-							Name:              "parent1",
-							Version:           "vparent1_version",
-							Builder:           "builder",
-							PersistentLabels: map[string]string{
-								"plabel": "plabelvalue",
-							},
-							PersistentVars: map[string]interface{}{
-								"pvar": "pvarvalue",
-							},
-						},
-					},
-				).Return(
-					&domainimage.Image{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "child",
-						Version:           "vparent_version",
-						Builder:           "builder",
-						Children:          []*domainimage.Image{},
-						Labels:            map[string]string{},
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-						Tags: []string{},
-						Vars: map[string]interface{}{},
-						Parent: &domainimage.Image{
-							RegistryHost:      "registry.test",
-							RegistryNamespace: "namespace",
-							Name:              "parent1",
-							Version:           "v{{ .Version }}",
-							Builder:           "builder",
-							PersistentLabels: map[string]string{
-								"plabel": "plabelvalue",
-							},
-							PersistentVars: map[string]interface{}{
-								"pvar": "pvarvalue",
-							},
-						},
-					}, nil)
-
-				i.store.(*images.MockStore).On("Store", "child", "version",
-					&domainimage.Image{
-						RegistryHost:      "registry.test",
-						RegistryNamespace: "namespace",
-						Name:              "child",
-						Version:           "vparent_version",
-						Builder:           "builder",
-						Children:          []*domainimage.Image{},
-						Labels:            map[string]string{},
-						PersistentLabels: map[string]string{
-							"plabel": "plabelvalue",
-						},
-						PersistentVars: map[string]interface{}{
-							"pvar": "pvarvalue",
-						},
-						Tags: []string{},
-						Vars: map[string]interface{}{},
-						Parent: &domainimage.Image{
-							RegistryHost:      "registry.test",
-							RegistryNamespace: "namespace",
-							Name:              "parent1",
-							Version:           "v{{ .Version }}",
-							Builder:           "builder",
-							PersistentLabels: map[string]string{
-								"plabel": "plabelvalue",
-							},
-							PersistentVars: map[string]interface{}{
-								"pvar": "pvarvalue",
-							},
-						},
-					},
-				).Return(nil)
+			prepareAssertFunc: prepareLoadImages,
+			assertFunc: func(t *testing.T, i *ImagesConfiguration) {
+				i.store.(*images.MockStore).AssertExpectations(t)
+				i.render.(*render.MockImageRender).AssertExpectations(t)
 			},
+			err: &errors.Error{},
+		},
+		{
+			desc:  "Testing load images to store from several paths",
+			paths: []string{parentsDir, childrenDir},
+			images: NewImagesConfiguration(
+				testFs,
+				graph.NewImagesGraphTemplate(
+					imagesgraph.NewGraphTemplateFactory(false),
+				),
+				images.NewMockStore(),
+				render.NewMockImageRender(),
+				compatibility.NewMockCompatibility(),
+			),
+			prepareAssertFunc: prepareLoadImages,
 			assertFunc: func(t *testing.T, i *ImagesConfiguration) {
 				i.store.(*images.MockStore).AssertExpectations(t)
 				i.render.(*render.MockImageRender).AssertExpectations(t)
@@ -305,7 +363,7 @@ image:
 				test.prepareAssertFunc(test.images)
 			}
 
-			err := test.images.LoadImagesToStore(test.path)
+			err := test.images.LoadImagesToStore(test.paths...)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
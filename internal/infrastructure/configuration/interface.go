@@ -17,10 +17,12 @@ type Compatibilitier interface {
 type ConfigurationLoader interface {
 	AddConfigPath(in string)
 	AutomaticEnv()
+	Get(key string) interface{}
 	GetBool(key string) bool
 	GetInt(key string) int
 	GetString(key string) string
 	GetStringSlice(key string) []string
+	MergeInConfig() error
 	ReadInConfig() error
 	SetConfigFile(in string)
 	SetConfigName(in string)
@@ -25,6 +25,11 @@ func (c *ConfigurationLoader) AutomaticEnv() {
 	c.viper.AutomaticEnv()
 }
 
+// Get returns the value associated with the key
+func (c *ConfigurationLoader) Get(key string) interface{} {
+	return c.viper.Get(key)
+}
+
 // GetBool returns the value associated with the key as a boolean
 func (c *ConfigurationLoader) GetBool(key string) bool {
 	return c.viper.GetBool(key)
@@ -45,6 +50,11 @@ func (c *ConfigurationLoader) GetStringSlice(key string) []string {
 	return c.viper.GetStringSlice(key)
 }
 
+// MergeInConfig merges a new configuration with an existing config
+func (c *ConfigurationLoader) MergeInConfig() error {
+	return c.viper.MergeInConfig()
+}
+
 // ReadInConfig will discover and load the configuration file from disk and key/value stores, searching in one of the defined paths
 func (c *ConfigurationLoader) ReadInConfig() error {
 	return c.viper.ReadInConfig()
@@ -25,6 +25,12 @@ func (c *MockConfigurationLoader) AutomaticEnv() {
 	c.Called()
 }
 
+// Get returns the value associated with the key
+func (c *MockConfigurationLoader) Get(key string) interface{} {
+	args := c.Called(key)
+	return args.Get(0)
+}
+
 // GetBool returns the value associated with the key as a boolean
 func (c *MockConfigurationLoader) GetBool(key string) bool {
 	args := c.Called(key)
@@ -49,6 +55,12 @@ func (c *MockConfigurationLoader) GetStringSlice(key string) []string {
 	return args.Get(0).([]string)
 }
 
+// MergeInConfig merges a new configuration with an existing config
+func (c *MockConfigurationLoader) MergeInConfig() error {
+	args := c.Called()
+	return args.Error(0)
+}
+
 // ReadInConfig will discover and load the configuration file from disk and key/value stores, searching in one of the defined paths
 func (c *MockConfigurationLoader) ReadInConfig() error {
 	args := c.Called()
@@ -8,45 +8,161 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
 )
 
+const (
+	// RedactedValue is shown instead of the secret configuration values
+	RedactedValue = "<redacted>"
+	// ProfileKey is the key used to show the selected configuration profile
+	ProfileKey = "profile"
+)
+
+// OptionsFunc is a function used to configure the console output
+type OptionsFunc func(*ConfigurationConsoleOutput)
+
 type ConfigurationConsoleOutput struct {
-	writer io.Writer
+	writer     io.Writer
+	showOrigin bool
 }
 
-func NewConfigurationConsoleOutput(w io.Writer) *ConfigurationConsoleOutput {
-	return &ConfigurationConsoleOutput{
+func NewConfigurationConsoleOutput(w io.Writer, opts ...OptionsFunc) *ConfigurationConsoleOutput {
+	o := &ConfigurationConsoleOutput{
 		writer: w,
 	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithShowOrigin sets whether the output shows where each configuration value comes from
+func WithShowOrigin(show bool) OptionsFunc {
+	return func(o *ConfigurationConsoleOutput) {
+		o.showOrigin = show
+	}
 }
 
 func (o *ConfigurationConsoleOutput) Write(conf *configuration.Configuration) error {
 
 	fmt.Println()
-	fmt.Fprintf(o.writer, " %s: %s\n", configuration.BuildersPathKey, conf.BuildersPath)
-	fmt.Fprintf(o.writer, " %s: %d\n", configuration.ConcurrencyKey, conf.Concurrency)
-	fmt.Fprintf(o.writer, " %s: %t\n", configuration.EnableSemanticVersionTagsKey, conf.EnableSemanticVersionTags)
-	fmt.Fprintf(o.writer, " %s: %s\n", configuration.ImagesPathKey, conf.ImagesPath)
+	if conf.Profile() != "" {
+		fmt.Fprintf(o.writer, " %s: %s\n", ProfileKey, conf.Profile())
+	}
+	o.writeDefinitionsSources(conf, configuration.BuildersPathKey, conf.BuildersPath, conf.BuildersSources)
+	fmt.Fprintf(o.writer, " %s: %d%s\n", configuration.ConcurrencyKey, conf.Concurrency, o.origin(conf, configuration.ConcurrencyKey))
+	fmt.Fprintf(o.writer, " %s: %t%s\n", configuration.EnableSemanticVersionTagsKey, conf.EnableSemanticVersionTags, o.origin(conf, configuration.EnableSemanticVersionTagsKey))
+	if conf.GitSourcesCachePath != "" {
+		fmt.Fprintf(o.writer, " %s: %s%s\n", configuration.GitSourcesCachePathKey, conf.GitSourcesCachePath, o.origin(conf, configuration.GitSourcesCachePathKey))
+	}
+	o.writeDefinitionsSources(conf, configuration.ImagesPathKey, conf.ImagesPath, conf.ImagesSources)
+	if conf.ImmutableTags != nil && len(conf.ImmutableTags.Images) > 0 {
+		fmt.Fprintf(o.writer, " %s:\n", configuration.ImmutableTagsKey)
+		fmt.Fprintf(o.writer, "   %s:%s\n", configuration.ImmutableTagsImagesKey, o.origin(conf, configuration.ImmutableTagsKey, configuration.ImmutableTagsImagesKey))
+		for _, pattern := range conf.ImmutableTags.Images {
+			fmt.Fprintf(o.writer, "     - %s\n", pattern)
+		}
+		fmt.Fprintf(o.writer, "   %s:%s\n", configuration.ImmutableTagsFloatingTagsKey, o.origin(conf, configuration.ImmutableTagsKey, configuration.ImmutableTagsFloatingTagsKey))
+		for _, expr := range conf.ImmutableTags.FloatingTags {
+			fmt.Fprintf(o.writer, "     - %s\n", expr)
+		}
+	}
 	if conf.LogPathFile != "" {
-		fmt.Fprintf(o.writer, " %s: %s\n", configuration.LogPathFileKey, conf.LogPathFile)
+		fmt.Fprintf(o.writer, " %s: %s%s\n", configuration.LogPathFileKey, conf.LogPathFile, o.origin(conf, configuration.LogPathFileKey))
+	}
+	if conf.PromotionPolicyPath != "" {
+		fmt.Fprintf(o.writer, " %s: %s%s\n", configuration.PromotionPolicyPathKey, conf.PromotionPolicyPath, o.origin(conf, configuration.PromotionPolicyPathKey))
 	}
-	fmt.Fprintf(o.writer, " %s: %t\n", configuration.PushImagesKey, conf.PushImages)
+	fmt.Fprintf(o.writer, " %s: %t%s\n", configuration.PushImagesKey, conf.PushImages, o.origin(conf, configuration.PushImagesKey))
 	if len(conf.SemanticVersionTagsTemplates) > 0 {
-		fmt.Fprintf(o.writer, " %s:\n", configuration.SemanticVersionTagsTemplatesKey)
+		fmt.Fprintf(o.writer, " %s:%s\n", configuration.SemanticVersionTagsTemplatesKey, o.origin(conf, configuration.SemanticVersionTagsTemplatesKey))
 		for _, tmpl := range conf.SemanticVersionTagsTemplates {
 			fmt.Fprintf(o.writer, "   - %s\n", tmpl)
 		}
 	}
 	if conf.Credentials != nil {
 		fmt.Fprintf(o.writer, " %s:\n", configuration.CredentialsKey)
-		fmt.Fprintf(o.writer, "   %s: %s\n", configuration.CredentialsStorageTypeKey, conf.Credentials.StorageType)
-		fmt.Fprintf(o.writer, "   %s: %s\n", configuration.CredentialsFormatKey, conf.Credentials.Format)
+		fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsStorageTypeKey, conf.Credentials.StorageType, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsStorageTypeKey))
+		fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsFormatKey, conf.Credentials.Format, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsFormatKey))
 		if conf.Credentials.StorageType == credentials.LocalStore {
-			fmt.Fprintf(o.writer, "   %s: %s\n", configuration.CredentialsLocalStoragePathKey, conf.Credentials.LocalStoragePath)
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsLocalStoragePathKey, conf.Credentials.LocalStoragePath, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsLocalStoragePathKey))
 		}
 		if conf.Credentials.EncryptionKey != "" {
-			fmt.Fprintf(o.writer, "   %s: %s\n", configuration.CredentialsEncryptionKeyKey, conf.Credentials.EncryptionKey)
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsEncryptionKeyKey, RedactedValue, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsEncryptionKeyKey))
+		}
+		if conf.Credentials.EncryptionKeyFile != "" {
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsEncryptionKeyFileKey, conf.Credentials.EncryptionKeyFile, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsEncryptionKeyFileKey))
+		}
+		if conf.Credentials.EncryptionKeyEnv != "" {
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsEncryptionKeyEnvKey, conf.Credentials.EncryptionKeyEnv, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsEncryptionKeyEnvKey))
+		}
+		if conf.Credentials.EncryptionKeyCommand != "" {
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsEncryptionKeyCommandKey, conf.Credentials.EncryptionKeyCommand, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsEncryptionKeyCommandKey))
+		}
+		if conf.Credentials.AWSECRTokenCachePath != "" {
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsAWSECRTokenCachePathKey, conf.Credentials.AWSECRTokenCachePath, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsAWSECRTokenCachePathKey))
+		}
+		if conf.Credentials.ExpirationWarningWindow != "" {
+			fmt.Fprintf(o.writer, "   %s: %s%s\n", configuration.CredentialsExpirationWarningWindowKey, conf.Credentials.ExpirationWarningWindow, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsExpirationWarningWindowKey))
+		}
+		if conf.Credentials.Vault != nil {
+			fmt.Fprintf(o.writer, "   %s:\n", configuration.CredentialsVaultKey)
+			fmt.Fprintf(o.writer, "     %s: %s%s\n", configuration.CredentialsVaultAddressKey, conf.Credentials.Vault.Address, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsVaultKey, configuration.CredentialsVaultAddressKey))
+			if conf.Credentials.Vault.Namespace != "" {
+				fmt.Fprintf(o.writer, "     %s: %s%s\n", configuration.CredentialsVaultNamespaceKey, conf.Credentials.Vault.Namespace, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsVaultKey, configuration.CredentialsVaultNamespaceKey))
+			}
+			fmt.Fprintf(o.writer, "     %s: %s%s\n", configuration.CredentialsVaultMountKey, conf.Credentials.Vault.Mount, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsVaultKey, configuration.CredentialsVaultMountKey))
+			fmt.Fprintf(o.writer, "     %s: %s%s\n", configuration.CredentialsVaultPathKey, conf.Credentials.Vault.Path, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsVaultKey, configuration.CredentialsVaultPathKey))
+			fmt.Fprintf(o.writer, "     %s: %s%s\n", configuration.CredentialsVaultAuthMethodKey, conf.Credentials.Vault.AuthMethod, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsVaultKey, configuration.CredentialsVaultAuthMethodKey))
+			fmt.Fprintf(o.writer, "     %s: %s%s\n", configuration.CredentialsVaultAuthMountKey, conf.Credentials.Vault.AuthMount, o.origin(conf, configuration.CredentialsKey, configuration.CredentialsVaultKey, configuration.CredentialsVaultAuthMountKey))
 		}
 	}
 	fmt.Println()
 
 	return nil
 }
+
+// writeDefinitionsSources writes the path where the definitions are located or, when they are located on several sources, the list of sources
+func (o *ConfigurationConsoleOutput) writeDefinitionsSources(conf *configuration.Configuration, key, path string, sources []*configuration.DefinitionsSource) {
+	if len(sources) == 0 {
+		fmt.Fprintf(o.writer, " %s: %s%s\n", key, path, o.origin(conf, key))
+		return
+	}
+
+	fmt.Fprintf(o.writer, " %s:%s\n", key, o.origin(conf, key))
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+
+		if source.Git == nil {
+			fmt.Fprintf(o.writer, "   - %s\n", source.Path)
+			continue
+		}
+
+		fmt.Fprintf(o.writer, "   - git:\n")
+		fmt.Fprintf(o.writer, "       repository: %s\n", source.Git.Repository)
+		if source.Git.Reference != "" {
+			fmt.Fprintf(o.writer, "       reference: %s\n", source.Git.Reference)
+		}
+		if source.Git.Path != "" {
+			fmt.Fprintf(o.writer, "       path: %s\n", source.Git.Path)
+		}
+		if source.Git.CredentialsID != "" {
+			fmt.Fprintf(o.writer, "       credentials_id: %s\n", source.Git.CredentialsID)
+		}
+	}
+}
+
+// origin returns the comment that tells where a configuration value comes from. It returns an empty string when the origin is not shown or it is unknown
+func (o *ConfigurationConsoleOutput) origin(conf *configuration.Configuration, key ...string) string {
+	if !o.showOrigin {
+		return ""
+	}
+
+	source := conf.ValueSource(key...)
+	if source == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" # %s", source)
+}
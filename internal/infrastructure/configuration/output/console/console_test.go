@@ -2,9 +2,14 @@ package console
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration/loader"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,3 +49,135 @@ func TestWrite(t *testing.T) {
 	console.Write(config)
 	assert.Equal(t, expected, buff.String())
 }
+
+func TestWriteDefinitionsSources(t *testing.T) {
+	var buff bytes.Buffer
+
+	config := &configuration.Configuration{
+		BuildersPath:        "mystevedore.yaml",
+		Concurrency:         10,
+		GitSourcesCachePath: "/cache/git",
+		ImagesSources: []*configuration.DefinitionsSource{
+			{Path: "images"},
+			{
+				Git: &configuration.GitDefinitionsSource{
+					Repository:    "https://github.com/example/definitions.git",
+					Reference:     "v1.0.0",
+					Path:          "images",
+					CredentialsID: "github",
+				},
+			},
+		},
+		PushImages: true,
+	}
+
+	expected := ` builders_path: mystevedore.yaml
+ concurrency: 10
+ semantic_version_tags_enabled: false
+ git_sources_cache_path: /cache/git
+ images_path:
+   - images
+   - git:
+       repository: https://github.com/example/definitions.git
+       reference: v1.0.0
+       path: images
+       credentials_id: github
+ push_images: true
+`
+
+	console := NewConfigurationConsoleOutput(&buff)
+	console.Write(config)
+	assert.Equal(t, expected, buff.String())
+}
+
+func TestWriteRedactsEncryptionKey(t *testing.T) {
+	var buff bytes.Buffer
+
+	config := &configuration.Configuration{
+		BuildersPath: "mystevedore.yaml",
+		Concurrency:  10,
+		ImagesPath:   "mystevedore.yaml",
+		Credentials: &configuration.CredentialsConfiguration{
+			StorageType:       "local",
+			LocalStoragePath:  "mycredentials",
+			Format:            "json",
+			EncryptionKey:     "0123456789abcdef",
+			EncryptionKeyFile: "/secrets/encryption.key",
+		},
+		PushImages: true,
+	}
+
+	expected := ` builders_path: mystevedore.yaml
+ concurrency: 10
+ semantic_version_tags_enabled: false
+ images_path: mystevedore.yaml
+ push_images: true
+ credentials:
+   storage_type: local
+   format: json
+   local_storage_path: mycredentials
+   encryption_key: <redacted>
+   encryption_key_file: /secrets/encryption.key
+`
+
+	console := NewConfigurationConsoleOutput(&buff)
+	console.Write(config)
+	assert.Equal(t, expected, buff.String())
+	assert.NotContains(t, buff.String(), "0123456789abcdef")
+}
+
+func TestWriteShowOrigin(t *testing.T) {
+	var buff bytes.Buffer
+
+	fs := afero.NewMemMapFs()
+	err := afero.WriteFile(fs, "/config/stevedore.yaml", []byte(`
+builders_path: /config/stevedore.yaml
+concurrency: 10
+images_path: /config/stevedore.yaml
+profiles:
+  ci:
+    concurrency: 2
+    push_images: true
+    credentials:
+      storage_type: envvars
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	t.Setenv(configuration.ProfileEnv, "ci")
+	t.Setenv("STEVEDORE_CREDENTIALS_FORMAT", "yaml")
+
+	// the loader reads the environment variables as it does when the configuration is created
+	v := viper.New()
+	v.AutomaticEnv()
+	v.SetEnvPrefix("stevedore")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	config, err := configuration.LoadFromFile(fs, loader.NewConfigurationLoader(v), "/config/stevedore.yaml", compatibility.NewMockCompatibility())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ` profile: ci
+ builders_path: /config/stevedore.yaml # /config/stevedore.yaml
+ concurrency: 2 # profile 'ci' on /config/stevedore.yaml
+ semantic_version_tags_enabled: false # default
+ images_path: /config/stevedore.yaml # /config/stevedore.yaml
+ push_images: true # profile 'ci' on /config/stevedore.yaml
+ semantic_version_tags_templates: # default
+   - {{ .Major }}.{{ .Minor }}.{{ .Patch }}
+ credentials:
+   storage_type: envvars # profile 'ci' on /config/stevedore.yaml
+   format: yaml # environment variable STEVEDORE_CREDENTIALS_FORMAT
+`
+
+	console := NewConfigurationConsoleOutput(&buff, WithShowOrigin(true))
+	console.Write(config)
+	assert.Equal(t, expected, buff.String())
+
+	buff.Reset()
+	console = NewConfigurationConsoleOutput(&buff)
+	console.Write(config)
+	assert.NotContains(t, buff.String(), "#")
+}
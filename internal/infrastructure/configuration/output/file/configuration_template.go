@@ -56,6 +56,11 @@ push_images: {{ .PushImages }}
 {{ end }}
 #
 # Credentials storage
+# Storage types are 'local', which stores the credentials on the 'local_storage_path' folder, 'envvars', which achieves them from environment variables, 'docker-config', which achieves them read-only from the Docker configuration file and its credentials helpers, the same ones created by 'docker login', and 'vault', which stores them on a HashiCorp Vault KV version 2 secrets engine set on the 'vault' block
+# Vault secrets, such as 'token', 'secret_id' or 'jwt', should be provided through environment variables, such as 'STEVEDORE_CREDENTIALS_VAULT_TOKEN'
+# AWS ECR authorization tokens are cached in memory until they expire. When 'aws_ecr_token_cache_path' is set, they are also cached on that folder, encrypted using the 'encryption_key', to be reused across invocations
+# The 'encryption_key' can be kept out of this file by reading it from the 'encryption_key_file' file, the 'encryption_key_env' environment variable or the output of the 'encryption_key_command' command. Only one of them can be set, and 'encryption_key', which can also be set by the 'STEVEDORE_CREDENTIALS_ENCRYPTION_KEY' environment variable, has precedence over them
+# Build and promote warn about the credentials they use that expire within 'expiration_warning_window', such as '7d', '2w' or '36h'. It is 7 days when it is not set, and '0' disables the warnings
 #   default value:
 #     credentials:
 #       storage_type: local
@@ -69,9 +74,41 @@ credentials:
   {{ if eq .StorageType "local" -}}
   local_storage_path: {{ .LocalStoragePath }}
   {{ end -}}
-  {{ if ne .EncryptionKey "" -}}
+  {{ if ne .EncryptionKeyFile "" -}}
+  encryption_key_file: {{ .EncryptionKeyFile }}
+  {{ else if ne .EncryptionKeyEnv "" -}}
+  encryption_key_env: {{ .EncryptionKeyEnv }}
+  {{ else if ne .EncryptionKeyCommand "" -}}
+  encryption_key_command: {{ printf "%q" .EncryptionKeyCommand }}
+  {{ else if ne .EncryptionKey "" -}}
   encryption_key: {{ .EncryptionKey }}
   {{ end -}}
+  {{ if ne .AWSECRTokenCachePath "" -}}
+  aws_ecr_token_cache_path: {{ .AWSECRTokenCachePath }}
+  {{ end -}}
+  {{ if ne .ExpirationWarningWindow "" -}}
+  expiration_warning_window: {{ .ExpirationWarningWindow }}
+  {{ end -}}
+  {{ with .Vault -}}
+  vault:
+    address: {{ .Address }}
+    {{ if ne .Namespace "" -}}
+    namespace: {{ .Namespace }}
+    {{ end -}}
+    mount: {{ .Mount }}
+    path: {{ .Path }}
+    auth_method: {{ .AuthMethod }}
+    auth_mount: {{ .AuthMount }}
+    {{ if ne .RoleID "" -}}
+    role_id: {{ .RoleID }}
+    {{ end -}}
+    {{ if ne .Role "" -}}
+    role: {{ .Role }}
+    {{ end -}}
+    {{ if ne .JWTPath "" -}}
+    jwt_path: {{ .JWTPath }}
+    {{ end -}}
+  {{ end -}}
 {{ else }}
 # credentials:
 #   storage_type: local
@@ -113,6 +150,42 @@ semantic_version_tags_templates:
 {{ end -}}
 {{ end }}
 #
+# Tags of the images that match any of the 'images' patterns are immutable, and they can not be overwritten on the registry by a build or a promote unless they are forced
+# Images patterns could be defined per namespace, such as 'stable/*', or per registry, such as 'registry.example.com/*/*'. Tags that match any of the 'floating_tags' regular expressions remain mutable
+#  default value:
+#    immutable_tags:
+#      images: []
+#      floating_tags:
+#        - ^latest$
+#        - ^\d+$
+#        - ^\d+\.\d+$
+{{ if and .ImmutableTags .ImmutableTags.Images -}}
+immutable_tags:
+  images:
+{{- range .ImmutableTags.Images }}
+    - "{{ . }}"
+{{- end }}
+  floating_tags:
+{{- range .ImmutableTags.FloatingTags }}
+    - '{{ . }}'
+{{- end }}
+{{ else -}}
+#
+# immutable_tags:
+#   images:
+#     - stable/*
+{{ end }}
+#
+# Promotion policy file location path. When it is defined, images can only be promoted through the routes allowed by the policy rules
+#  default value:
+#    promotion_policy_path: ""
+{{ with .PromotionPolicyPath -}}
+promotion_policy_path: {{ . }}
+{{ else -}}
+#
+# promotion_policy_path: promotion-policy.yaml
+{{ end }}
+#
 # Define builder types
 # You could define builders on its own file. Stevedore will look up for builders on the file set at 'builders_path'
 # 
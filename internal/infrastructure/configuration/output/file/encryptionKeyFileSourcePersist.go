@@ -0,0 +1,67 @@
+package file
+
+import (
+	"fmt"
+	"os"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+)
+
+const (
+	// encryptionKeyFileMode is the mode of the encryption key files created by stevedore, which are only readable by their owner
+	encryptionKeyFileMode os.FileMode = 0600
+)
+
+// EncryptionKeyFileSourcePersist writes the credentials encryption key on the file referenced by the 'encryption_key_file' configuration
+type EncryptionKeyFileSourcePersist struct {
+	ConfigurationFilePersist
+}
+
+// NewEncryptionKeyFileSourcePersist creates a new EncryptionKeyFileSourcePersist
+func NewEncryptionKeyFileSourcePersist(options ...OptionsFunc) *EncryptionKeyFileSourcePersist {
+	output := &EncryptionKeyFileSourcePersist{}
+	output.Options(options...)
+
+	return output
+}
+
+// WriteEncryptionKey replaces atomically the content of the encryption key file by the key. The file mode is kept when the file already exists
+func (o *EncryptionKeyFileSourcePersist) WriteEncryptionKey(key string) error {
+
+	var err error
+
+	errContext := "(configuration::output::EncryptionKeyFileSourcePersist::WriteEncryptionKey)"
+
+	if o.fs == nil {
+		return errors.New(errContext, "To write the encryption key, a file system must be provided")
+	}
+
+	if o.filePath == "" {
+		return errors.New(errContext, "To write the encryption key, an encryption key file must be provided")
+	}
+
+	if key == "" {
+		return errors.New(errContext, "To write the encryption key, the key must be provided")
+	}
+
+	mode := encryptionKeyFileMode
+	fileInfo, err := o.fs.Stat(o.filePath)
+	if err == nil {
+		mode = fileInfo.Mode().Perm()
+	}
+
+	temporaryFile := o.filePath + encryptionKeyTemporaryFileSuffix
+	err = afero.WriteFile(o.fs, temporaryFile, []byte(key+"\n"), mode)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("File '%s' could not be written", temporaryFile), err)
+	}
+
+	err = o.fs.Rename(temporaryFile, o.filePath)
+	if err != nil {
+		_ = o.fs.Remove(temporaryFile)
+		return errors.New(errContext, fmt.Sprintf("Encryption key file '%s' could not be replaced", o.filePath), err)
+	}
+
+	return nil
+}
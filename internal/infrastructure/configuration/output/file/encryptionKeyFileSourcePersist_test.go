@@ -0,0 +1,83 @@
+package file
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptionKeyFileSourcePersistWriteEncryptionKey(t *testing.T) {
+	errContext := "(configuration::output::EncryptionKeyFileSourcePersist::WriteEncryptionKey)"
+
+	tests := []struct {
+		desc              string
+		file              string
+		key               string
+		prepareAssertFunc func(afero.Fs)
+		res               string
+		mode              string
+		err               error
+	}{
+		{
+			desc: "Testing error writing encryption key when file is not provided",
+			key:  "key",
+			err:  errors.New(errContext, "To write the encryption key, an encryption key file must be provided"),
+		},
+		{
+			desc: "Testing error writing encryption key when key is not provided",
+			file: "/secrets/encryption.key",
+			err:  errors.New(errContext, "To write the encryption key, the key must be provided"),
+		},
+		{
+			desc: "Testing write encryption key on a new file",
+			file: "/secrets/encryption.key",
+			key:  "newkey",
+			res:  "newkey\n",
+			mode: "-rw-------",
+			err:  &errors.Error{},
+		},
+		{
+			desc: "Testing write encryption key on an existing file keeping its mode",
+			file: "/secrets/encryption.key",
+			key:  "newkey",
+			prepareAssertFunc: func(fs afero.Fs) {
+				_ = afero.WriteFile(fs, "/secrets/encryption.key", []byte("oldkey\n"), 0640)
+			},
+			res:  "newkey\n",
+			mode: "-rw-r-----",
+			err:  &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			testFs := afero.NewMemMapFs()
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(testFs)
+			}
+
+			output := NewEncryptionKeyFileSourcePersist(
+				WithFileSystem(testFs),
+				WithFilePath(test.file),
+			)
+
+			err := output.WriteEncryptionKey(test.key)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			content, err := afero.ReadFile(testFs, test.file)
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, string(content))
+
+			info, err := testFs.Stat(test.file)
+			assert.NoError(t, err)
+			assert.Equal(t, test.mode, info.Mode().Perm().String())
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// encryptionKeyTemporaryFileSuffix is the suffix of the temporary file written before replacing the configuration file
+	encryptionKeyTemporaryFileSuffix = ".tmp"
+)
+
+// EncryptionKeyFilePersist updates the credentials encryption key on a configuration file, keeping the rest of its content. The encryption key is written on the credentials block of the profile, when it is set, or on the top level credentials block
+type EncryptionKeyFilePersist struct {
+	ConfigurationFilePersist
+}
+
+// NewEncryptionKeyFilePersist creates a new EncryptionKeyFilePersist
+func NewEncryptionKeyFilePersist(options ...OptionsFunc) *EncryptionKeyFilePersist {
+	output := &EncryptionKeyFilePersist{}
+	output.Options(options...)
+
+	return output
+}
+
+// Check validates that the encryption key can be written on the configuration file, without writing it
+func (o *EncryptionKeyFilePersist) Check() error {
+
+	errContext := "(configuration::output::EncryptionKeyFilePersist::Check)"
+
+	document, _, err := o.load()
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	_, err = o.credentialsBlock(document)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	file, err := o.fs.OpenFile(o.filePath, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Configuration file '%s' is not writable", o.filePath), err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Configuration file '%s' is not writable", o.filePath), err)
+	}
+
+	return nil
+}
+
+// WriteEncryptionKey sets the encryption key on the credentials block of the configuration file. The configuration file is replaced atomically
+func (o *EncryptionKeyFilePersist) WriteEncryptionKey(key string) error {
+
+	var err error
+	var fileInfo os.FileInfo
+	var document *yaml.Node
+	var credentials *yaml.Node
+	var buff bytes.Buffer
+
+	errContext := "(configuration::output::EncryptionKeyFilePersist::WriteEncryptionKey)"
+
+	if key == "" {
+		return errors.New(errContext, "To write the encryption key, the key must be provided")
+	}
+
+	document, fileInfo, err = o.load()
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	credentials, err = o.credentialsBlock(document)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	encryptionKey := mappingValue(credentials, configuration.CredentialsEncryptionKeyKey)
+	if encryptionKey == nil {
+		encryptionKey = &yaml.Node{}
+		credentials.Content = append(credentials.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: configuration.CredentialsEncryptionKeyKey},
+			encryptionKey,
+		)
+	}
+	encryptionKey.Kind = yaml.ScalarNode
+	encryptionKey.Tag = "!!str"
+	encryptionKey.Style = 0
+	encryptionKey.Value = key
+
+	encoder := yaml.NewEncoder(&buff)
+	encoder.SetIndent(2)
+	err = encoder.Encode(document)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be encoded", o.filePath), err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be encoded", o.filePath), err)
+	}
+
+	temporaryFile := o.filePath + encryptionKeyTemporaryFileSuffix
+	err = afero.WriteFile(o.fs, temporaryFile, buff.Bytes(), fileInfo.Mode().Perm())
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("File '%s' could not be written", temporaryFile), err)
+	}
+
+	err = o.fs.Rename(temporaryFile, o.filePath)
+	if err != nil {
+		_ = o.fs.Remove(temporaryFile)
+		return errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be replaced", o.filePath), err)
+	}
+
+	return nil
+}
+
+// load reads and parses the configuration file. An empty configuration file is considered an empty map
+func (o *EncryptionKeyFilePersist) load() (*yaml.Node, os.FileInfo, error) {
+
+	var err error
+	var data []byte
+	var fileInfo os.FileInfo
+	var document yaml.Node
+
+	errContext := "(configuration::output::EncryptionKeyFilePersist::load)"
+
+	if o.fs == nil {
+		return nil, nil, errors.New(errContext, "To write the encryption key, a file system must be provided")
+	}
+
+	if o.filePath == "" {
+		return nil, nil, errors.New(errContext, "To write the encryption key, a configuration file must be provided")
+	}
+
+	fileInfo, err = o.fs.Stat(o.filePath)
+	if err != nil {
+		return nil, nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be found", o.filePath), err)
+	}
+
+	data, err = afero.ReadFile(o.fs, o.filePath)
+	if err != nil {
+		return nil, nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be read", o.filePath), err)
+	}
+
+	err = yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' could not be parsed", o.filePath), err)
+	}
+
+	if document.Kind == 0 {
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' must be a map", o.filePath))
+	}
+
+	return &document, fileInfo, nil
+}
+
+// credentialsBlock returns the credentials block where the encryption key is written, either the profile one or the top level one. The blocks that do not exist are created
+func (o *EncryptionKeyFilePersist) credentialsBlock(document *yaml.Node) (*yaml.Node, error) {
+
+	errContext := "(configuration::output::EncryptionKeyFilePersist::credentialsBlock)"
+
+	path := []string{configuration.CredentialsKey}
+	if o.profile != "" {
+		path = []string{configuration.ProfilesKey, o.profile, configuration.CredentialsKey}
+	}
+
+	block := document.Content[0]
+	for i, key := range path {
+		child := mappingValue(block, key)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			// an empty flow mapping, such as '{}', is written as a block mapping once it gets content
+			block.Style = 0
+			block.Content = append(block.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				child,
+			)
+		}
+
+		if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			child.Kind = yaml.MappingNode
+			child.Tag = "!!map"
+			child.Value = ""
+		}
+
+		if child.Kind != yaml.MappingNode {
+			return nil, errors.New(errContext, fmt.Sprintf("Configuration file '%s' has an invalid '%s' block", o.filePath, strings.Join(path[:i+1], ".")))
+		}
+
+		block = child
+	}
+
+	return block, nil
+}
+
+// mappingValue returns the value node for the key on a mapping node, or nil when the key is not defined
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
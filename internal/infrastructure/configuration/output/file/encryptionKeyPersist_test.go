@@ -0,0 +1,256 @@
+package file
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteEncryptionKey(t *testing.T) {
+
+	errContext := "(configuration::output::EncryptionKeyFilePersist::WriteEncryptionKey)"
+
+	tests := []struct {
+		desc    string
+		persist *EncryptionKeyFilePersist
+		content string
+		key     string
+		res     string
+		err     error
+	}{
+		{
+			desc: "Testing error writing encryption key when configuration file does not exist",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("unknown.yaml"),
+			),
+			key: "new-key",
+			err: errors.New(errContext, "Configuration file 'unknown.yaml' could not be found", errors.New("", "open unknown.yaml: file does not exist")),
+		},
+		{
+			desc: "Testing error writing an empty encryption key",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+			),
+			err: errors.New(errContext, "To write the encryption key, the key must be provided"),
+		},
+		{
+			desc: "Testing replace the encryption key on the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+			),
+			content: `# stevedore configuration
+images_path: images
+credentials:
+  storage_type: local
+  local_storage_path: credentials
+  # credentials are encrypted
+  encryption_key: current-key
+`,
+			key: "new-key",
+			res: `# stevedore configuration
+images_path: images
+credentials:
+  storage_type: local
+  local_storage_path: credentials
+  # credentials are encrypted
+  encryption_key: new-key
+`,
+		},
+		{
+			desc: "Testing add the encryption key to the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+			),
+			content: `images_path: images
+credentials:
+  storage_type: local
+  local_storage_path: credentials
+`,
+			key: "new-key",
+			res: `images_path: images
+credentials:
+  storage_type: local
+  local_storage_path: credentials
+  encryption_key: new-key
+`,
+		},
+		{
+			desc: "Testing add the credentials block to the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+			),
+			content: `images_path: images
+`,
+			key: "new-key",
+			res: `images_path: images
+credentials:
+  encryption_key: new-key
+`,
+		},
+		{
+			desc: "Testing replace the encryption key on the profile of the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+				WithProfile("ci"),
+			),
+			content: `credentials:
+  storage_type: local
+  encryption_key: top-level-key
+profiles:
+  ci:
+    credentials:
+      encryption_key: current-key
+`,
+			key: "new-key",
+			res: `credentials:
+  storage_type: local
+  encryption_key: top-level-key
+profiles:
+  ci:
+    credentials:
+      encryption_key: new-key
+`,
+		},
+		{
+			desc: "Testing add the encryption key to an empty profile of the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+				WithProfile("ci"),
+			),
+			content: `profiles:
+  ci: {}
+`,
+			key: "new-key",
+			res: `profiles:
+  ci:
+    credentials:
+      encryption_key: new-key
+`,
+		},
+		{
+			desc: "Testing error writing the encryption key on an invalid profile of the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+				WithProfile("ci"),
+			),
+			content: `profiles:
+  ci: invalid
+`,
+			key: "new-key",
+			err: errors.New(errContext, "",
+				errors.New("(configuration::output::EncryptionKeyFilePersist::credentialsBlock)", "Configuration file 'stevedore.yaml' has an invalid 'profiles.ci' block")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.content != "" {
+				_ = afero.WriteFile(test.persist.fs, test.persist.filePath, []byte(test.content), 0600)
+			}
+
+			err := test.persist.WriteEncryptionKey(test.key)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				content, _ := afero.ReadFile(test.persist.fs, test.persist.filePath)
+				assert.Equal(t, test.res, string(content))
+
+				exists, _ := afero.Exists(test.persist.fs, test.persist.filePath+encryptionKeyTemporaryFileSuffix)
+				assert.False(t, exists)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+
+	errContext := "(configuration::output::EncryptionKeyFilePersist::Check)"
+
+	readOnlyFs := func(path, content string) afero.Fs {
+		fs := afero.NewMemMapFs()
+		_ = afero.WriteFile(fs, path, []byte(content), 0600)
+		return afero.NewReadOnlyFs(fs)
+	}
+
+	tests := []struct {
+		desc    string
+		persist *EncryptionKeyFilePersist
+		content string
+		err     error
+	}{
+		{
+			desc: "Testing error checking the encryption key can be written when configuration file does not exist",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("unknown.yaml"),
+			),
+			err: errors.New(errContext, "",
+				errors.New("(configuration::output::EncryptionKeyFilePersist::load)", "Configuration file 'unknown.yaml' could not be found", errors.New("", "open unknown.yaml: file does not exist"))),
+		},
+		{
+			desc: "Testing error checking the encryption key can be written on an invalid credentials block",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+			),
+			content: `credentials: invalid
+`,
+			err: errors.New(errContext, "",
+				errors.New("(configuration::output::EncryptionKeyFilePersist::credentialsBlock)", "Configuration file 'stevedore.yaml' has an invalid 'credentials' block")),
+		},
+		{
+			desc: "Testing error checking the encryption key can be written on a read-only file system",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(readOnlyFs("stevedore.yaml", "images_path: images\n")),
+				WithFilePath("stevedore.yaml"),
+			),
+			err: errors.New(errContext, "Configuration file 'stevedore.yaml' is not writable", errors.New("", "operation not permitted")),
+		},
+		{
+			desc: "Testing check the encryption key can be written on the profile of the configuration file",
+			persist: NewEncryptionKeyFilePersist(
+				WithFileSystem(afero.NewMemMapFs()),
+				WithFilePath("stevedore.yaml"),
+				WithProfile("ci"),
+			),
+			content: `profiles:
+  ci:
+    credentials:
+      encryption_key: current-key
+`,
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.content != "" {
+				_ = afero.WriteFile(test.persist.fs, test.persist.filePath, []byte(test.content), 0600)
+			}
+
+			err := test.persist.Check()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.err, &errors.Error{})
+
+				content, _ := afero.ReadFile(test.persist.fs, test.persist.filePath)
+				assert.Equal(t, test.content, string(content))
+			}
+		})
+	}
+}
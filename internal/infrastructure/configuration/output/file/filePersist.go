@@ -3,10 +3,10 @@ package file
 import (
 	"bytes"
 	"fmt"
-	"html/template"
 	"io"
 	"os"
 	"strings"
+	"text/template"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
@@ -20,6 +20,7 @@ type OptionsFunc func(*ConfigurationFilePersist)
 type ConfigurationFilePersist struct {
 	filePath string
 	fs       afero.Fs
+	profile  string
 }
 
 // NewConfigurationFilePersist creates a new ConfigurationFilePersist
@@ -42,6 +43,13 @@ func WithFilePath(file string) OptionsFunc {
 	}
 }
 
+// WithProfile sets the profile whose credentials block receives the encryption key. When it is not set, the encryption key is written on the top level credentials block
+func WithProfile(profile string) OptionsFunc {
+	return func(o *ConfigurationFilePersist) {
+		o.profile = profile
+	}
+}
+
 // Options configure the service
 func (h *ConfigurationFilePersist) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
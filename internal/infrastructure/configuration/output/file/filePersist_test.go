@@ -65,3 +65,42 @@ func TestWrite(t *testing.T) {
 
 	assert.Equal(t, expected, content, "Unexpected response")
 }
+
+func TestWriteEncryptionKeySource(t *testing.T) {
+
+	var err error
+	var content []byte
+
+	testFs := afero.NewMemMapFs()
+
+	output := NewConfigurationFilePersist(
+		WithFileSystem(testFs),
+		WithFilePath("test.yaml"),
+	)
+
+	config := &configuration.Configuration{
+		BuildersPath: "mystevedore.yaml",
+		Concurrency:  10,
+		ImagesPath:   "mystevedore.yaml",
+		Credentials: &configuration.CredentialsConfiguration{
+			StorageType:       "local",
+			LocalStoragePath:  "mycredentials",
+			Format:            "json",
+			EncryptionKey:     "encryptionkey",
+			EncryptionKeyFile: "/secrets/encryption.key",
+		},
+	}
+
+	err = output.Write(config)
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+
+	content, err = afero.ReadFile(testFs, "test.yaml")
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+
+	assert.Contains(t, string(content), "  encryption_key_file: /secrets/encryption.key\n")
+	assert.NotContains(t, string(content), "encryptionkey")
+}
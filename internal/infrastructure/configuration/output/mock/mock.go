@@ -18,3 +18,9 @@ func (o *ConfigurationMockOutput) Write(conf *configuration.Configuration) error
 	args := o.Called(conf)
 	return args.Error(0)
 }
+
+func (o *ConfigurationMockOutput) WriteEncryptionKey(key string) error {
+
+	args := o.Called(key)
+	return args.Error(0)
+}
@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadPromotionPolicy loads the promotion policy defined on the file
+func LoadPromotionPolicy(fs afero.Fs, path string) (*image.PromotionPolicy, error) {
+
+	var err error
+	var fileData []byte
+
+	errContext := "(policy::LoadPromotionPolicy)"
+
+	if fs == nil {
+		return nil, errors.New(errContext, "File system must be provided to load the promotion policy")
+	}
+
+	if path == "" {
+		return nil, errors.New(errContext, "Promotion policy file must be provided to load the promotion policy")
+	}
+
+	fileData, err = afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Promotion policy file '%s' could not be read", path), err)
+	}
+
+	policy := &image.PromotionPolicy{}
+	err = yaml.Unmarshal(fileData, policy)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error loading promotion policy from file '%s'", path), err)
+	}
+
+	err = policy.Validate()
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Promotion policy from file '%s' is not valid", path), err)
+	}
+
+	return policy, nil
+}
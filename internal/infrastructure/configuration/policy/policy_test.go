@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPromotionPolicy(t *testing.T) {
+
+	errContext := "(policy::LoadPromotionPolicy)"
+
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "policy.yaml", []byte(`
+rules:
+  - name: staging-to-prod
+    sources:
+      - registry.staging.test/*/*
+    targets:
+      - registry.prod.test/stable/*
+    required_labels:
+      qa.approved: "true"
+    version_pattern: ^\d+\.\d+\.\d+$
+`), 0644)
+	_ = afero.WriteFile(testFs, "invalid.yaml", []byte(`
+rules:
+  - name: staging-to-prod
+    targets:
+      - registry.prod.test/stable/*
+`), 0644)
+
+	tests := []struct {
+		desc string
+		fs   afero.Fs
+		path string
+		res  *image.PromotionPolicy
+		err  error
+	}{
+		{
+			desc: "Testing error loading a promotion policy without file system",
+			err:  errors.New(errContext, "File system must be provided to load the promotion policy"),
+		},
+		{
+			desc: "Testing error loading a promotion policy without file",
+			fs:   testFs,
+			err:  errors.New(errContext, "Promotion policy file must be provided to load the promotion policy"),
+		},
+		{
+			desc: "Testing load a promotion policy",
+			fs:   testFs,
+			path: "policy.yaml",
+			res: &image.PromotionPolicy{
+				Rules: []*image.PromotionRule{
+					{
+						Name:           "staging-to-prod",
+						Sources:        []string{"registry.staging.test/*/*"},
+						Targets:        []string{"registry.prod.test/stable/*"},
+						RequiredLabels: map[string]string{"qa.approved": "true"},
+						VersionPattern: `^\d+\.\d+\.\d+$`,
+					},
+				},
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error loading an invalid promotion policy",
+			fs:   testFs,
+			path: "invalid.yaml",
+			err: errors.New(errContext, "Promotion policy from file 'invalid.yaml' is not valid",
+				errors.New("", "Promotion policy rule 'staging-to-prod' requires at least one source")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := LoadPromotionPolicy(test.fs, test.path)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
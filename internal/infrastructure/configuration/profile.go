@@ -0,0 +1,118 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// ProfileEnv is the environment variable that selects the configuration profile
+	ProfileEnv = "STEVEDORE_PROFILE"
+	// DefaultValueSource is the source of the values that are not defined on any configuration file, environment variable or profile
+	DefaultValueSource = "default"
+)
+
+// ValueOrigin describes where a configuration value is defined. All its attributes are empty when the value is the default one
+type ValueOrigin struct {
+	// File is the configuration file that defines the value
+	File string
+	// Profile is the profile of the configuration file that defines the value
+	Profile string
+	// Env is the environment variable that defines the value
+	Env string
+}
+
+// keyResolver resolves the loader key that provides each configuration value and records where the value comes from. The values defined on the selected profile take precedence over the environment variables, the configuration files and the defaults
+type keyResolver struct {
+	profile string
+	// files are the merged configuration files, sorted from the lowest to the highest precedence
+	files   []*configurationFile
+	sources map[string]string
+	origins map[string]*ValueOrigin
+}
+
+func newKeyResolver(profile string, files ...*configurationFile) *keyResolver {
+	return &keyResolver{
+		profile: profile,
+		files:   files,
+		sources: map[string]string{},
+		origins: map[string]*ValueOrigin{},
+	}
+}
+
+// validate returns an error when the selected profile is not defined on any configuration file
+func (r *keyResolver) validate() error {
+
+	errContext := "(Configuration::keyResolver::validate)"
+
+	if r.profile == "" {
+		return nil
+	}
+
+	if len(r.files) == 0 {
+		return errors.New(errContext, fmt.Sprintf("Profile '%s' can not be selected because there is no configuration file", r.profile))
+	}
+
+	if r.definedOn(strings.Join([]string{ProfilesKey, r.profile}, ".")) == nil {
+		paths := []string{}
+		for _, file := range r.files {
+			paths = append(paths, file.path)
+		}
+
+		return errors.New(errContext, fmt.Sprintf("Profile '%s' is not defined on the configuration files: %s", r.profile, strings.Join(paths, ", ")))
+	}
+
+	return nil
+}
+
+// definedOn returns the configuration file with the highest precedence that defines the key, or nil when no file defines it
+func (r *keyResolver) definedOn(key string) *configurationFile {
+	for i := len(r.files) - 1; i >= 0; i-- {
+		if r.files[i].defines(key) {
+			return r.files[i]
+		}
+	}
+
+	return nil
+}
+
+// key returns the loader key to get the value of a configuration key
+func (r *keyResolver) key(key ...string) string {
+
+	configKey := strings.Join(key, ".")
+
+	if r.profile != "" {
+		profileKey := strings.Join([]string{ProfilesKey, r.profile, configKey}, ".")
+		if file := r.definedOn(profileKey); file != nil {
+			r.sources[configKey] = fmt.Sprintf("profile '%s' on %s", r.profile, file.path)
+			r.origins[configKey] = &ValueOrigin{File: file.path, Profile: r.profile}
+			return profileKey
+		}
+	}
+
+	env := envVariableName(configKey)
+	if _, exists := os.LookupEnv(env); exists {
+		r.sources[configKey] = fmt.Sprintf("environment variable %s", env)
+		r.origins[configKey] = &ValueOrigin{Env: env}
+		return configKey
+	}
+
+	if file := r.definedOn(configKey); file != nil {
+		r.sources[configKey] = file.path
+		r.origins[configKey] = &ValueOrigin{File: file.path}
+		return configKey
+	}
+
+	r.sources[configKey] = DefaultValueSource
+	r.origins[configKey] = &ValueOrigin{}
+
+	return configKey
+}
+
+// envVariableName returns the environment variable that overrides a configuration key
+func envVariableName(key string) string {
+	return strings.ToUpper(strings.Join([]string{"stevedore", strings.ReplaceAll(key, ".", "_")}, "_"))
+}
@@ -0,0 +1,187 @@
+package configuration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyResolver(t *testing.T) {
+
+	errContext := "(Configuration::keyResolver::validate)"
+
+	userFile := filepath.Join("/home", "stevedore.yaml")
+	file := filepath.Join("/config", "stevedore.yaml")
+	testFs := afero.NewMemMapFs()
+	err := afero.WriteFile(testFs, userFile, []byte(`
+concurrency: 6
+credentials:
+  storage_type: local
+  format: yaml
+profiles:
+  ci:
+    concurrency: 1
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+	err = afero.WriteFile(testFs, file, []byte(`
+concurrency: 4
+push_images: false
+credentials:
+  storage_type: local
+profiles:
+  ci:
+    push_images: true
+    credentials:
+      storage_type: envvars
+  local: {}
+`), 0644)
+	if err != nil {
+		t.Log(err)
+	}
+
+	files := []*configurationFile{}
+	for _, path := range []string{userFile, file} {
+		configFile, err := loadConfigurationFile(testFs, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, configFile)
+	}
+
+	tests := []struct {
+		desc    string
+		profile string
+		files   []*configurationFile
+		env     map[string]string
+		keys    [][]string
+		res     map[string]string
+		sources map[string]string
+		origins map[string]*ValueOrigin
+		err     error
+	}{
+		{
+			desc:    "Testing error resolving keys with a profile that is not defined",
+			profile: "prod",
+			files:   files,
+			err:     errors.New(errContext, "Profile 'prod' is not defined on the configuration files: /home/stevedore.yaml, /config/stevedore.yaml"),
+		},
+		{
+			desc:    "Testing error resolving keys with a profile and no configuration file",
+			profile: "ci",
+			err:     errors.New(errContext, "Profile 'ci' can not be selected because there is no configuration file"),
+		},
+		{
+			desc:  "Testing resolve keys without profile",
+			files: files,
+			env: map[string]string{
+				"STEVEDORE_CONCURRENCY": "8",
+			},
+			keys: [][]string{
+				{ConcurrencyKey},
+				{PushImagesKey},
+				{ImagesPathKey},
+				{CredentialsKey, CredentialsStorageTypeKey},
+				{CredentialsKey, CredentialsFormatKey},
+			},
+			res: map[string]string{
+				"concurrency":              "concurrency",
+				"push_images":              "push_images",
+				"images_path":              "images_path",
+				"credentials.storage_type": "credentials.storage_type",
+				"credentials.format":       "credentials.format",
+			},
+			sources: map[string]string{
+				"concurrency":              "environment variable STEVEDORE_CONCURRENCY",
+				"push_images":              file,
+				"images_path":              DefaultValueSource,
+				"credentials.storage_type": file,
+				"credentials.format":       userFile,
+			},
+			origins: map[string]*ValueOrigin{
+				"concurrency":              {Env: "STEVEDORE_CONCURRENCY"},
+				"push_images":              {File: file},
+				"images_path":              {},
+				"credentials.storage_type": {File: file},
+				"credentials.format":       {File: userFile},
+			},
+		},
+		{
+			desc:    "Testing resolve keys with a profile",
+			profile: "ci",
+			files:   files,
+			env: map[string]string{
+				"STEVEDORE_PUSH_IMAGES": "false",
+			},
+			keys: [][]string{
+				{ConcurrencyKey},
+				{PushImagesKey},
+				{CredentialsKey, CredentialsStorageTypeKey},
+			},
+			res: map[string]string{
+				"concurrency":              "profiles.ci.concurrency",
+				"push_images":              "profiles.ci.push_images",
+				"credentials.storage_type": "profiles.ci.credentials.storage_type",
+			},
+			sources: map[string]string{
+				"concurrency":              "profile 'ci' on /home/stevedore.yaml",
+				"push_images":              "profile 'ci' on /config/stevedore.yaml",
+				"credentials.storage_type": "profile 'ci' on /config/stevedore.yaml",
+			},
+			origins: map[string]*ValueOrigin{
+				"concurrency":              {File: userFile, Profile: "ci"},
+				"push_images":              {File: file, Profile: "ci"},
+				"credentials.storage_type": {File: file, Profile: "ci"},
+			},
+		},
+		{
+			desc:    "Testing resolve keys with an empty profile",
+			profile: "local",
+			files:   files,
+			keys: [][]string{
+				{PushImagesKey},
+			},
+			res: map[string]string{
+				"push_images": "push_images",
+			},
+			sources: map[string]string{
+				"push_images": file,
+			},
+			origins: map[string]*ValueOrigin{
+				"push_images": {File: file},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			resolver := newKeyResolver(test.profile, test.files...)
+			err := resolver.validate()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, test.err)
+
+			res := map[string]string{}
+			for _, key := range test.keys {
+				res[strings.Join(key, ".")] = resolver.key(key...)
+			}
+
+			assert.Equal(t, test.res, res)
+			assert.Equal(t, test.sources, resolver.sources)
+			assert.Equal(t, test.origins, resolver.origins)
+		})
+	}
+}
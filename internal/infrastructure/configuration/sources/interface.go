@@ -0,0 +1,11 @@
+package sources
+
+import (
+	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
+)
+
+// GitAuthFactorier is the interface to generate the auth method to access to a git repository
+type GitAuthFactorier interface {
+	GenerateAuthMethod(repository string, options *builder.DockerDriverGitContextAuthOptions) (gitauth.GitAuther, error)
+}
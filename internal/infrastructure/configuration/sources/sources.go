@@ -0,0 +1,263 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+)
+
+const (
+	// remoteName is the name of the remote of the cloned repositories
+	remoteName = "origin"
+)
+
+// OptionsFunc defines the signature for an option function to set definitions sources attributes
+type OptionsFunc func(s *DefinitionsSources)
+
+// DefinitionsSources resolves the local paths where the images and builders are defined. The git repositories are cloned into the cache folder, or fetched when they are already cloned, and checked out to the source reference
+type DefinitionsSources struct {
+	auth      GitAuthFactorier
+	cachePath string
+}
+
+// NewDefinitionsSources returns a new definitions sources resolver
+func NewDefinitionsSources(opts ...OptionsFunc) *DefinitionsSources {
+	s := &DefinitionsSources{}
+	s.Options(opts...)
+
+	return s
+}
+
+// WithAuthFactory sets the factory to generate the auth method to access to the git repositories
+func WithAuthFactory(auth GitAuthFactorier) OptionsFunc {
+	return func(s *DefinitionsSources) {
+		s.auth = auth
+	}
+}
+
+// WithCachePath sets the folder where the git repositories are cloned
+func WithCachePath(path string) OptionsFunc {
+	return func(s *DefinitionsSources) {
+		s.cachePath = path
+	}
+}
+
+// Options provides the options for the definitions sources resolver
+func (s *DefinitionsSources) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// DefaultCachePath returns the default folder where the git repositories are cloned, which is located on the user cache folder
+func DefaultCachePath() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+
+	return filepath.Join(cacheDir, "stevedore", "git")
+}
+
+// Paths returns the local path of each definitions source. Git sources are fetched into the cache folder before returning their path
+func (s *DefinitionsSources) Paths(ctx context.Context, sources []*configuration.DefinitionsSource) ([]string, error) {
+
+	errContext := "(sources::DefinitionsSources::Paths)"
+
+	paths := []string{}
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+
+		if source.Git == nil {
+			paths = append(paths, source.Path)
+			continue
+		}
+
+		dir, err := s.fetch(ctx, source.Git)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Definitions could not be achieved from git repository '%s'", source.Git.Repository), err)
+		}
+
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(source.Git.Path)))
+	}
+
+	return paths, nil
+}
+
+// fetch clones the git repository into the cache folder, or fetches it when it is already cloned, and checks out the source reference. It returns the folder where the repository is cloned
+func (s *DefinitionsSources) fetch(ctx context.Context, source *configuration.GitDefinitionsSource) (string, error) {
+
+	var err error
+	var repo *git.Repository
+	var auth transport.AuthMethod
+
+	errContext := "(sources::DefinitionsSources::fetch)"
+
+	if source.Repository == "" {
+		return "", errors.New(errContext, "Git definitions source must define a repository")
+	}
+
+	auth, err = s.authMethod(source)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	cachePath := s.cachePath
+	if cachePath == "" {
+		cachePath = DefaultCachePath()
+	}
+	dir := filepath.Join(cachePath, cacheDirName(source))
+
+	repo, err = git.PlainOpen(dir)
+	switch {
+	case err == git.ErrRepositoryNotExists:
+		repo, err = git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+			URL:        source.Repository,
+			Auth:       auth,
+			RemoteName: remoteName,
+			NoCheckout: true,
+			Tags:       git.AllTags,
+		})
+		if err != nil {
+			// a partial clone is removed to clone the repository from scratch the next time
+			_ = os.RemoveAll(dir)
+			return "", errors.New(errContext, fmt.Sprintf("Git repository '%s' could not be cloned into '%s'", source.Repository, dir), err)
+		}
+	case err != nil:
+		return "", errors.New(errContext, fmt.Sprintf("Git repository cached on '%s' could not be opened", dir), err)
+	default:
+		err = repo.FetchContext(ctx, &git.FetchOptions{
+			RemoteName: remoteName,
+			Auth:       auth,
+			Tags:       git.AllTags,
+			Force:      true,
+		})
+		if err != nil && err != git.NoErrAlreadyUpToDate {
+			return "", errors.New(errContext, fmt.Sprintf("Git repository '%s' could not be fetched into '%s'", source.Repository, dir), err)
+		}
+	}
+
+	hash, err := s.resolveReference(ctx, repo, source.Reference, auth)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Reference '%s' could not be resolved on git repository '%s'", source.Reference, source.Repository), err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Hash:  *hash,
+		Force: true,
+	})
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Reference '%s' could not be checked out from git repository '%s'", source.Reference, source.Repository), err)
+	}
+
+	return dir, nil
+}
+
+// resolveReference returns the commit referenced by a branch, tag or commit hash. The branches are resolved from the remote, to checkout their latest fetched commit. When the reference is not defined, it is resolved the remote default branch
+func (s *DefinitionsSources) resolveReference(ctx context.Context, repo *git.Repository, reference string, auth transport.AuthMethod) (*plumbing.Hash, error) {
+
+	errContext := "(sources::DefinitionsSources::resolveReference)"
+
+	if reference == "" {
+		remote, err := repo.Remote(remoteName)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+		if err != nil {
+			return nil, errors.New(errContext, "Remote references could not be listed", err)
+		}
+
+		for _, ref := range refs {
+			if ref.Name() == plumbing.HEAD && ref.Type() == plumbing.SymbolicReference {
+				reference = ref.Target().Short()
+				break
+			}
+		}
+
+		if reference == "" {
+			return nil, errors.New(errContext, "Remote default branch could not be achieved")
+		}
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(plumbing.NewRemoteReferenceName(remoteName, reference)))
+	if err == nil {
+		return hash, nil
+	}
+
+	hash, err = repo.ResolveRevision(plumbing.Revision(reference))
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return hash, nil
+}
+
+// authMethod returns the auth method to access to the git repository, or nil when the repository is accessed anonymously
+func (s *DefinitionsSources) authMethod(source *configuration.GitDefinitionsSource) (transport.AuthMethod, error) {
+
+	var options *builder.DockerDriverGitContextAuthOptions
+
+	errContext := "(sources::DefinitionsSources::authMethod)"
+
+	if s.auth == nil {
+		if source.CredentialsID != "" {
+			return nil, errors.New(errContext, fmt.Sprintf("Credentials '%s' can not be used to access to git repository '%s' because there is no credentials store", source.CredentialsID, source.Repository))
+		}
+
+		return nil, nil
+	}
+
+	if source.CredentialsID != "" {
+		options = &builder.DockerDriverGitContextAuthOptions{
+			CredentialsID: source.CredentialsID,
+		}
+	}
+
+	gitAuth, err := s.auth.GenerateAuthMethod(source.Repository, options)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if gitAuth == nil {
+		return nil, nil
+	}
+
+	auth, err := gitAuth.Auth()
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Auth method to access to git repository '%s' could not be created", source.Repository), err)
+	}
+
+	return auth, nil
+}
+
+// cacheDirName returns the name of the folder where the git source is cloned. Each repository and reference is cloned on its own folder, so sources with distinct references can be loaded together
+func cacheDirName(source *configuration.GitDefinitionsSource) string {
+	name := strings.TrimSuffix(path.Base(strings.TrimSuffix(source.Repository, "/")), ".git")
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s@%s", source.Repository, source.Reference)))
+
+	return fmt.Sprintf("%s-%x", name, sum[:8])
+}
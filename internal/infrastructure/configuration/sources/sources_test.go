@@ -0,0 +1,257 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+// commitFile writes the file into the repository worktree and commits it
+func commitFile(t *testing.T, repo *git.Repository, dir, file, content string) plumbing.Hash {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, file), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = worktree.Add(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := worktree.Commit("add "+file, &git.CommitOptions{
+		Author: &object.Signature{Name: "stevedore", Email: "stevedore@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
+
+func TestPaths(t *testing.T) {
+
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary is required to clone local repositories")
+	}
+
+	errContext := "(sources::DefinitionsSources::Paths)"
+
+	repoDir := t.TempDir()
+	cacheDir := t.TempDir()
+
+	repo, err := git.PlainInitWithOptions(repoDir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitFile(t, repo, repoDir, "images/images.yaml", "version: v1\n")
+	_, err = repo.CreateTag("v1.0.0", mustHead(t, repo), &git.CreateTagOptions{
+		Message: "v1.0.0",
+		Tagger:  &object.Signature{Name: "stevedore", Email: "stevedore@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, repo, repoDir, "images/images.yaml", "version: v2\n")
+
+	err = repo.CreateBranch(&config.Branch{Name: "feature"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), mustHead(t, repo)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc        string
+		sources     []*configuration.DefinitionsSource
+		prepareFunc func(t *testing.T)
+		content     map[string]string
+		res         []string
+		err         error
+	}{
+		{
+			desc: "Testing paths of local definitions sources",
+			sources: []*configuration.DefinitionsSource{
+				{Path: "images"},
+				{Path: "/definitions/images"},
+			},
+			res: []string{"images", "/definitions/images"},
+		},
+		{
+			desc: "Testing paths of git definitions sources",
+			sources: []*configuration.DefinitionsSource{
+				{Path: "images"},
+				{
+					Git: &configuration.GitDefinitionsSource{
+						Repository: repoDir,
+						Path:       "images",
+					},
+				},
+				{
+					Git: &configuration.GitDefinitionsSource{
+						Repository: repoDir,
+						Reference:  "v1.0.0",
+						Path:       "images",
+					},
+				},
+				{
+					Git: &configuration.GitDefinitionsSource{
+						Repository: repoDir,
+						Reference:  "feature",
+					},
+				},
+			},
+			res: []string{
+				"images",
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir}), "images"),
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir, Reference: "v1.0.0"}), "images"),
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir, Reference: "feature"})),
+			},
+			content: map[string]string{
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir}), "images", "images.yaml"):                       "version: v2\n",
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir, Reference: "v1.0.0"}), "images", "images.yaml"):  "version: v1\n",
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir, Reference: "feature"}), "images", "images.yaml"): "version: v2\n",
+			},
+		},
+		{
+			desc: "Testing paths of a git definitions source already cloned into the cache folder",
+			sources: []*configuration.DefinitionsSource{
+				{
+					Git: &configuration.GitDefinitionsSource{
+						Repository: repoDir,
+						Path:       "images",
+					},
+				},
+			},
+			prepareFunc: func(t *testing.T) {
+				commitFile(t, repo, repoDir, "images/images.yaml", "version: v3\n")
+			},
+			res: []string{
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir}), "images"),
+			},
+			content: map[string]string{
+				filepath.Join(cacheDir, cacheDirName(&configuration.GitDefinitionsSource{Repository: repoDir}), "images", "images.yaml"): "version: v3\n",
+			},
+		},
+		{
+			desc: "Testing error achieving paths of a git definitions source with an unknown reference",
+			sources: []*configuration.DefinitionsSource{
+				{
+					Git: &configuration.GitDefinitionsSource{
+						Repository: repoDir,
+						Reference:  "unknown",
+					},
+				},
+			},
+			err: errors.New(errContext, "Definitions could not be achieved from git repository '"+repoDir+"'",
+				errors.New("(sources::DefinitionsSources::fetch)", "Reference 'unknown' could not be resolved on git repository '"+repoDir+"'",
+					errors.New("(sources::DefinitionsSources::resolveReference)", "", plumbing.ErrReferenceNotFound))),
+		},
+		{
+			desc: "Testing error achieving paths of a git definitions source with credentials and no credentials store",
+			sources: []*configuration.DefinitionsSource{
+				{
+					Git: &configuration.GitDefinitionsSource{
+						Repository:    repoDir,
+						CredentialsID: "github",
+					},
+				},
+			},
+			err: errors.New(errContext, "Definitions could not be achieved from git repository '"+repoDir+"'",
+				errors.New("(sources::DefinitionsSources::fetch)", "",
+					errors.New("(sources::DefinitionsSources::authMethod)", "Credentials 'github' can not be used to access to git repository '"+repoDir+"' because there is no credentials store"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareFunc != nil {
+				test.prepareFunc(t)
+			}
+
+			sources := NewDefinitionsSources(
+				WithCachePath(cacheDir),
+			)
+
+			res, err := sources.Paths(context.TODO(), test.sources)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, test.err)
+
+			assert.Equal(t, test.res, res)
+			for file, content := range test.content {
+				data, err := os.ReadFile(file)
+				assert.Nil(t, err)
+				assert.Equal(t, content, string(data))
+			}
+		})
+	}
+}
+
+func TestCacheDirName(t *testing.T) {
+	tests := []struct {
+		desc   string
+		source *configuration.GitDefinitionsSource
+		prefix string
+	}{
+		{
+			desc:   "Testing cache folder name of an https repository",
+			source: &configuration.GitDefinitionsSource{Repository: "https://github.com/example/definitions.git"},
+			prefix: "definitions-",
+		},
+		{
+			desc:   "Testing cache folder name of an scp-like repository",
+			source: &configuration.GitDefinitionsSource{Repository: "git@github.com:definitions.git", Reference: "main"},
+			prefix: "definitions-",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			name := cacheDirName(test.source)
+			assert.Regexp(t, "^"+test.prefix+"[0-9a-f]{16}$", name)
+			assert.NotEqual(t, name, cacheDirName(&configuration.GitDefinitionsSource{Repository: test.source.Repository, Reference: "other"}))
+		})
+	}
+}
+
+func mustHead(t *testing.T, repo *git.Repository) plumbing.Hash {
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return head.Hash()
+}
@@ -169,13 +169,23 @@ func (d *DockerDriver) Build(ctx context.Context, i *image.Image, options *image
 		// AddBuildArgs returns an error when the value exists, however we preferred to deal the situation by ignoring the error and continue with the execution without overwriting the value
 		_ = d.driver.AddBuildArgs(options.BuilderVarMappings[varsmap.VarMappingImageFromRegistryHostKey], i.Parent.RegistryHost)
 
-		err = d.driver.AddAuth(options.PullAuthUsername, options.PullAuthPassword, i.Parent.RegistryHost)
+		if options.PullAuthIdentityToken != "" || options.PullAuthRegistryToken != "" {
+			err = d.driver.AddTokenAuth(options.PullAuthUsername, options.PullAuthIdentityToken, options.PullAuthRegistryToken, i.Parent.RegistryHost)
+		} else {
+			err = d.driver.AddAuth(options.PullAuthUsername, options.PullAuthPassword, i.Parent.RegistryHost)
+		}
 		if err != nil {
 			return errors.New(errContext, fmt.Sprintf("error adding the auth configuration for registry '%s'", i.Parent.RegistryHost), err)
 		}
 	}
 
-	err = d.driver.AddAuth(options.PushAuthUsername, options.PushAuthPassword, i.RegistryHost)
+	pushTokenAuth := options.PushAuthIdentityToken != "" || options.PushAuthRegistryToken != ""
+
+	if pushTokenAuth {
+		err = d.driver.AddTokenAuth(options.PushAuthUsername, options.PushAuthIdentityToken, options.PushAuthRegistryToken, i.RegistryHost)
+	} else {
+		err = d.driver.AddAuth(options.PushAuthUsername, options.PushAuthPassword, i.RegistryHost)
+	}
 	if err != nil {
 		return errors.New(errContext, fmt.Sprintf("error adding the auth configuration for registry '%s'", i.RegistryHost), err)
 	}
@@ -183,7 +193,11 @@ func (d *DockerDriver) Build(ctx context.Context, i *image.Image, options *image
 	if options.PushImageAfterBuild {
 		d.driver.WithPushAfterBuild()
 
-		err = d.driver.AddPushAuth(options.PushAuthUsername, options.PushAuthPassword)
+		if pushTokenAuth {
+			err = d.driver.AddPushTokenAuth(options.PushAuthUsername, options.PushAuthIdentityToken, options.PushAuthRegistryToken)
+		} else {
+			err = d.driver.AddPushAuth(options.PushAuthUsername, options.PushAuthPassword)
+		}
 		if err != nil {
 			return errors.New(errContext, "error adding the auth configuration to push the image to the registry", err)
 		}
@@ -211,6 +225,13 @@ func (d *DockerDriver) Build(ctx context.Context, i *image.Image, options *image
 		return errors.New(errContext, "", err)
 	}
 
+	if len(options.BuilderOptions.SSH) > 0 {
+		err = d.driver.AddSSH(options.BuilderOptions.SSH...)
+		if err != nil {
+			return errors.New(errContext, "error adding the SSH forwards to the build", err)
+		}
+	}
+
 	responseOutputPrefix := options.OutputPrefix
 	if responseOutputPrefix == "" {
 		responseOutputPrefix = imageName
@@ -260,6 +260,102 @@ func TestBuild(t *testing.T) {
 			},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing building a docker image using token auth",
+			driver: &DockerDriver{
+				driver:        godockerbuilder.NewMockGoDockerBuildDriver(),
+				writer:        os.Stdout,
+				referenceName: reference.NewDefaultReferenceName(),
+			},
+			ctx: context.TODO(),
+			image: &image.Image{
+				Name:              "image",
+				Version:           "version",
+				RegistryNamespace: "namespace",
+				RegistryHost:      "myregistry.test",
+				Parent: &image.Image{
+					Name:              "parent",
+					Version:           "version",
+					RegistryNamespace: "namespace",
+					RegistryHost:      "parent-registry.test",
+				},
+			},
+			options: &image.BuildDriverOptions{
+				PushImageAfterBuild:   true,
+				PullAuthIdentityToken: "pull-identity-token",
+				PushAuthUsername:      "push-user",
+				PushAuthRegistryToken: "push-registry-token",
+				BuilderOptions: &builder.BuilderOptions{
+					Context: []*builder.DockerDriverContextOptions{
+						{Path: "/path/to/file"},
+					},
+				},
+			},
+			prepareAssertFunc: func(driver DockerDriverer) {
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithImageName", "myregistry.test/namespace/image:version")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildArgs", "", "parent-registry.test/namespace/parent:version").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildArgs", "", "namespace").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildArgs", "", "parent").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildArgs", "", "version").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildArgs", "", "parent-registry.test").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddTokenAuth", "", "pull-identity-token", "", "parent-registry.test").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddTokenAuth", "push-user", "", "push-registry-token", "myregistry.test").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddPushTokenAuth", "push-user", "", "push-registry-token").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithPushAfterBuild")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildContext", []*builder.DockerDriverContextOptions{
+					{Path: "/path/to/file"},
+				}).Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithResponse", os.Stdout, "myregistry.test/namespace/image:version")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithUseNormalizedNamed")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("Run", context.TODO()).Return(nil)
+			},
+			assertFunc: func(t *testing.T, driver DockerDriverer) {
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).AssertExpectations(t)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing building a docker image forwarding SSH",
+			driver: &DockerDriver{
+				driver:        godockerbuilder.NewMockGoDockerBuildDriver(),
+				writer:        os.Stdout,
+				referenceName: reference.NewDefaultReferenceName(),
+			},
+			ctx: context.TODO(),
+			image: &image.Image{
+				Name:              "image",
+				Version:           "version",
+				RegistryNamespace: "namespace",
+				RegistryHost:      "myregistry.test",
+			},
+			options: &image.BuildDriverOptions{
+				BuilderOptions: &builder.BuilderOptions{
+					Context: []*builder.DockerDriverContextOptions{
+						{Path: "/path/to/file"},
+					},
+					SSH: []*builder.DockerDriverSSHOptions{
+						{ID: "default", CredentialsID: "git-credentials"},
+					},
+				},
+			},
+			prepareAssertFunc: func(driver DockerDriverer) {
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithImageName", "myregistry.test/namespace/image:version")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddAuth", "", "", "myregistry.test").Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddBuildContext", []*builder.DockerDriverContextOptions{
+					{Path: "/path/to/file"},
+				}).Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("AddSSH", []*builder.DockerDriverSSHOptions{
+					{ID: "default", CredentialsID: "git-credentials"},
+				}).Return(nil)
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithResponse", os.Stdout, "myregistry.test/namespace/image:version")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("WithUseNormalizedNamed")
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).On("Run", context.TODO()).Return(nil)
+			},
+			assertFunc: func(t *testing.T, driver DockerDriverer) {
+				driver.(*godockerbuilder.MockGoDockerBuildDriver).AssertExpectations(t)
+			},
+			err: &errors.Error{},
+		},
 		{
 			desc: "Testing error Docker build context not defined on build options",
 			driver: &DockerDriver{
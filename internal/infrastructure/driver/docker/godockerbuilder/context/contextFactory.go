@@ -52,18 +52,20 @@ func (f *DockerBuildContextFactory) GenerateDockerBuildContext(options *builder.
 			gitBuildContext.WithPath(options.Git.Path)
 		}
 
-		if options.Git.Auth != nil {
-
-			if f.gitAuth == nil {
-				return nil, errors.New(errContext, "Git auth generator is required to generate a git build context")
-			}
+		if options.Git.Auth != nil && f.gitAuth == nil {
+			return nil, errors.New(errContext, "Git auth generator is required to generate a git build context")
+		}
 
-			auth, err := f.gitAuth.GenerateAuthMethod(options.Git.Auth)
+		// auth method is generated even when no auth is defined, to achieve the credentials stored for the repository host
+		if f.gitAuth != nil {
+			auth, err := f.gitAuth.GenerateAuthMethod(options.Git.Repository, options.Git.Auth)
 			if err != nil {
 				return nil, errors.New(errContext, "", err)
 			}
 
-			gitBuildContext.WithAuth(auth)
+			if auth != nil {
+				gitBuildContext.WithAuth(auth)
+			}
 		}
 
 		return gitBuildContext, nil
@@ -6,6 +6,8 @@ import (
 	errors "github.com/apenella/go-common-utils/error"
 	gitcontextbasicauth "github.com/apenella/go-docker-builder/pkg/auth/git/basic"
 	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
 	gitcontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git"
 	gitauth "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git/auth"
 	pathcontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/path"
@@ -27,6 +29,22 @@ func TestGenerateDockerBuildContext(t *testing.T) {
 		Password: "password",
 	})
 
+	hostCredentialsFactory := factory.NewMockAuthFactory()
+	hostCredentialsFactory.On("Get", "github.com/org/repo").Return(
+		&basic.BasicAuthMethod{
+			Username: "host-user",
+			Password: "host-password",
+		},
+		nil,
+	)
+
+	hostGitContext := gitcontext.NewGitBuildContext()
+	hostGitContext.WithRepository("https://github.com/org/repo.git")
+	hostGitContext.WithAuth(&gitcontextbasicauth.BasicAuth{
+		Username: "host-user",
+		Password: "host-password",
+	})
+
 	tests := []struct {
 		desc    string
 		options *builder.DockerDriverContextOptions
@@ -86,6 +104,19 @@ func TestGenerateDockerBuildContext(t *testing.T) {
 			context: gitContext,
 			err:     &errors.Error{},
 		},
+		{
+			desc: "Testing to generate a docker build context from git repository using the credentials stored for the repository host",
+			options: &builder.DockerDriverContextOptions{
+				Git: &builder.DockerDriverGitContextOptions{
+					Repository: "https://github.com/org/repo.git",
+				},
+			},
+			factory: &DockerBuildContextFactory{
+				gitAuth: gitauth.NewGitAuthFactory(hostCredentialsFactory),
+			},
+			context: hostGitContext,
+			err:     &errors.Error{},
+		},
 		{
 			desc: "Testing error generating docker build context from git repository without specifing a repository",
 			options: &builder.DockerDriverContextOptions{
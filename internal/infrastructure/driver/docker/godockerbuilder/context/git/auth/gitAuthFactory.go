@@ -2,6 +2,8 @@ package gitauth
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 
 	errors "github.com/apenella/go-common-utils/error"
 	gitcontextbasicauth "github.com/apenella/go-docker-builder/pkg/auth/git/basic"
@@ -27,19 +29,15 @@ func NewGitAuthFactory(credentials repository.AuthFactorier) *GitAuthFactory {
 	}
 }
 
-// GenerateAuthMethod returns a new auth method based on the given context
-func (f *GitAuthFactory) GenerateAuthMethod(options *builder.DockerDriverGitContextAuthOptions) (GitAuther, error) {
+// GenerateAuthMethod returns a new auth method to access to the given repository. When options do not define any credentials id nor inline credentials, the auth method is achieved from the credentials stored for the repository host. SSH repositories fallback to ssh-agent auth method
+func (f *GitAuthFactory) GenerateAuthMethod(repository string, options *builder.DockerDriverGitContextAuthOptions) (GitAuther, error) {
 
 	var err error
 	var auth GitAuther
 
 	errContext := "(GitAuthFactory::GenerateAuthMethod)"
 
-	if options == nil {
-		return nil, errors.New(errContext, "Git context auth options is required to generate an an auth method")
-	}
-
-	if options.CredentialsID != "" {
+	if options != nil && options.CredentialsID != "" {
 		if f.Credentials == nil {
 			return nil, errors.New(errContext, "Credentials store is expected when a credentials id is configured")
 		}
@@ -50,6 +48,17 @@ func (f *GitAuthFactory) GenerateAuthMethod(options *builder.DockerDriverGitCont
 		}
 	}
 
+	if auth == nil && !hasInlineCredentials(options) {
+		auth, err = f.generateAuthMethodFromRepository(repository)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+	}
+
+	if auth == nil && options == nil && isSSHRepository(repository) {
+		options = &builder.DockerDriverGitContextAuthOptions{}
+	}
+
 	if auth == nil {
 		auth, err = f.generateAuthMethodFromOptions(options)
 		if err != nil {
@@ -76,6 +85,60 @@ func (f *GitAuthFactory) generateAuthMethodFromCredentials(id string) (GitAuther
 		return nil, errors.New(errContext, fmt.Sprintf("Credentials with id '%s' not found", id))
 	}
 
+	auth, err = generateAuthMethodFromAuthMethod(authMethod)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials with id '%s' can not be used on a git repository", id), err)
+	}
+
+	return auth, nil
+}
+
+// generateAuthMethodFromRepository returns the auth method achieved from the credentials stored for the repository host. It returns nil when there are no credentials for the host or when they do not suit the repository transport
+func (f *GitAuthFactory) generateAuthMethodFromRepository(repo string) (GitAuther, error) {
+
+	var authMethod repository.AuthMethodReader
+	var err error
+	errContext := "(gitauth::generateAuthMethodFromRepository)"
+
+	if f.Credentials == nil {
+		return nil, nil
+	}
+
+	id, ssh := repositoryCredentialsID(repo)
+	if id == "" {
+		return nil, nil
+	}
+
+	authMethod, err = f.Credentials.Get(id)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials for repository '%s' could not be achieved", repo), err)
+	}
+
+	if authMethod == nil {
+		return nil, nil
+	}
+
+	switch authMethod.Name() {
+	case credentials.BasicAuthMethod:
+		if ssh {
+			return nil, nil
+		}
+	case credentials.KeyFileAuthMethod, credentials.SSHAgentAuthMethod:
+		if !ssh {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	return generateAuthMethodFromAuthMethod(authMethod)
+}
+
+func generateAuthMethodFromAuthMethod(authMethod repository.AuthMethodReader) (GitAuther, error) {
+	var auth GitAuther
+
+	errContext := "(gitauth::generateAuthMethodFromAuthMethod)"
+
 	switch authMethod.Name() {
 	case credentials.BasicAuthMethod:
 		username := authMethod.(*basic.BasicAuthMethod).Username
@@ -104,13 +167,15 @@ func (f *GitAuthFactory) generateAuthMethodFromCredentials(id string) (GitAuther
 				auth.(*gitcontextkeyauth.KeyAuth).GitSSHUser = user
 			}
 		}
-	default:
+	case credentials.SSHAgentAuthMethod:
 		auth = &gitcontextsshagentauth.SSHAgentAuth{}
 
 		user := authMethod.(*sshagent.SSHAgentAuthMethod).GitSSHUser
 		if user != "" {
 			auth.(*gitcontextsshagentauth.SSHAgentAuth).GitSSHUser = user
 		}
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Auth method '%s' is not supported on git repositories", authMethod.Name()))
 	}
 
 	return auth, nil
@@ -155,3 +220,67 @@ func (f *GitAuthFactory) generateAuthMethodFromOptions(options *builder.DockerDr
 
 	return auth, nil
 }
+
+// hasInlineCredentials returns true when options define either a username and password or a private key file
+func hasInlineCredentials(options *builder.DockerDriverGitContextAuthOptions) bool {
+	if options == nil {
+		return false
+	}
+
+	return (options.Username != "" && options.Password != "") || options.PrivateKeyFile != ""
+}
+
+// isSSHRepository returns true when the repository is accessed through ssh
+func isSSHRepository(repository string) bool {
+	_, ssh := repositoryCredentialsID(repository)
+	return ssh
+}
+
+// repositoryCredentialsID returns the credentials id used to look up the repository credentials, which is composed by the repository host, including the port, and its path. It also returns whether the repository is accessed through ssh. Both 'scheme://[user@]host[:port]/path' and scp-like '[user@]host:path' repositories are supported
+func repositoryCredentialsID(repository string) (string, bool) {
+	var host, path string
+	var ssh bool
+
+	repository = strings.TrimSpace(repository)
+
+	if strings.Contains(repository, "://") {
+		u, err := url.Parse(repository)
+		if err != nil {
+			return "", false
+		}
+
+		switch u.Scheme {
+		case "ssh", "git+ssh", "ssh+git":
+			ssh = true
+		case "http", "https":
+		default:
+			return "", false
+		}
+
+		host = u.Host
+		path = u.Path
+	} else {
+		idx := strings.Index(repository, ":")
+		if idx <= 0 || strings.Contains(repository[:idx], "/") {
+			return "", false
+		}
+
+		host = repository[:idx]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		path = repository[idx+1:]
+		ssh = true
+	}
+
+	if host == "" {
+		return "", false
+	}
+
+	path = strings.Trim(strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git"), "/")
+	if path == "" {
+		return host, ssh
+	}
+
+	return host + "/" + path, ssh
+}
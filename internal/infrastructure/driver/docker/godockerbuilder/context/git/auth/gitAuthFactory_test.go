@@ -12,6 +12,7 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
 	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
 	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/sshagent"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,6 +20,7 @@ func TestGenerateAuthMethod(t *testing.T) {
 	errContext := "(GitAuthFactory::GenerateAuthMethod)"
 	tests := []struct {
 		desc              string
+		repository        string
 		options           *builder.DockerDriverGitContextAuthOptions
 		factory           *GitAuthFactory
 		prepareAssertFunc func(*GitAuthFactory)
@@ -26,11 +28,110 @@ func TestGenerateAuthMethod(t *testing.T) {
 		err               error
 	}{
 		{
-			desc:    "Testing error when options is nil",
-			options: nil,
-			factory: nil,
-			res:     nil,
-			err:     errors.New(errContext, "Git context auth options is required to generate an an auth method"),
+			desc:       "Testing generate no auth method when options is nil and there are no credentials for the repository host",
+			repository: "https://github.com/org/repo.git",
+			options:    nil,
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			res: nil,
+			err: &errors.Error{},
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "github.com/org/repo").Return(nil, nil)
+			},
+		},
+		{
+			desc:       "Testing generate basic auth authorization method from the credentials stored for the repository host",
+			repository: "https://github.com/org/repo.git",
+			options:    nil,
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			res: &gitcontextbasicauth.BasicAuth{
+				Username: "user",
+				Password: "pass",
+			},
+			err: &errors.Error{},
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "github.com/org/repo").Return(
+					&basic.BasicAuthMethod{
+						Username: "user",
+						Password: "pass",
+					},
+					nil,
+				)
+			},
+		},
+		{
+			desc:       "Testing generate key auth authorization method from the credentials stored for the repository host with port",
+			repository: "ssh://git@git.internal:2222/org/repo.git",
+			options:    nil,
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			res: &gitcontextkeyauth.KeyAuth{
+				PkFile: "keyfile",
+			},
+			err: &errors.Error{},
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "git.internal:2222/org/repo").Return(
+					&keyfile.KeyFileAuthMethod{
+						PrivateKeyFile: "keyfile",
+					},
+					nil,
+				)
+			},
+		},
+		{
+			desc:       "Testing generate ssh-agent auth authorization method when repository host credentials do not suit the ssh transport",
+			repository: "git@github.com:org/repo.git",
+			options:    nil,
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			res: &gitcontextsshagentauth.SSHAgentAuth{},
+			err: &errors.Error{},
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "github.com/org/repo").Return(
+					&basic.BasicAuthMethod{
+						Username: "user",
+						Password: "pass",
+					},
+					nil,
+				)
+			},
+		},
+		{
+			desc:       "Testing generate ssh-agent auth authorization method with git ssh user when there are no credentials for the repository host",
+			repository: "git@github.com:org/repo.git",
+			options: &builder.DockerDriverGitContextAuthOptions{
+				GitSSHUser: "user",
+			},
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			res: &gitcontextsshagentauth.SSHAgentAuth{
+				GitSSHUser: "user",
+			},
+			err: &errors.Error{},
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "github.com/org/repo").Return(nil, nil)
+			},
+		},
+		{
+			desc:       "Testing error achieving the credentials stored for the repository host",
+			repository: "https://github.com/org/repo.git",
+			options:    nil,
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			res: nil,
+			err: errors.New(errContext, "",
+				errors.New("(gitauth::generateAuthMethodFromRepository)", "Credentials for repository 'https://github.com/org/repo.git' could not be achieved",
+					errors.New("", "error"))),
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "github.com/org/repo").Return(nil, errors.New("", "error"))
+			},
 		},
 		{
 			desc: "Testing generate basic auth authorization method",
@@ -124,7 +225,7 @@ func TestGenerateAuthMethod(t *testing.T) {
 				test.prepareAssertFunc(test.factory)
 			}
 
-			auth, err := test.factory.GenerateAuthMethod(test.options)
+			auth, err := test.factory.GenerateAuthMethod(test.repository, test.options)
 			if err != nil && assert.Error(t, err) {
 				assert.Equal(t, test.err, err)
 			} else {
@@ -209,7 +310,7 @@ func TestGenerateAuthMethodFromCredentials(t *testing.T) {
 			},
 		},
 		{
-			desc: "Testing generate ssh-agent (default) auth authorization method",
+			desc: "Testing generate ssh-agent auth authorization method",
 			factory: NewGitAuthFactory(
 				factory.NewMockAuthFactory(),
 			),
@@ -223,6 +324,22 @@ func TestGenerateAuthMethodFromCredentials(t *testing.T) {
 				)
 			},
 		},
+		{
+			desc: "Testing error generating authorization method from an unsupported auth method",
+			factory: NewGitAuthFactory(
+				factory.NewMockAuthFactory(),
+			),
+			credentialsID: "registry.test",
+			res:           nil,
+			err: errors.New("(gitauth::generateAuthMethodFromCredentials)", "Credentials with id 'registry.test' can not be used on a git repository",
+				errors.New("(gitauth::generateAuthMethodFromAuthMethod)", "Auth method 'token' is not supported on git repositories")),
+			prepareAssertFunc: func(f *GitAuthFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "registry.test").Return(
+					&token.TokenAuthMethod{},
+					nil,
+				)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -244,3 +361,65 @@ func TestGenerateAuthMethodFromCredentials(t *testing.T) {
 }
 
 func TestGenerateAuthMethodFromOptions(t *testing.T) {}
+
+func TestRepositoryCredentialsID(t *testing.T) {
+	tests := []struct {
+		desc       string
+		repository string
+		id         string
+		ssh        bool
+	}{
+		{
+			desc:       "Testing credentials id from https repository",
+			repository: "https://github.com/org/repo.git",
+			id:         "github.com/org/repo",
+			ssh:        false,
+		},
+		{
+			desc:       "Testing credentials id from https repository with user info",
+			repository: "https://user@github.com/org/repo",
+			id:         "github.com/org/repo",
+			ssh:        false,
+		},
+		{
+			desc:       "Testing credentials id from ssh repository with port",
+			repository: "ssh://git@git.internal:2222/org/repo.git",
+			id:         "git.internal:2222/org/repo",
+			ssh:        true,
+		},
+		{
+			desc:       "Testing credentials id from scp-like repository",
+			repository: "git@github.com:org/repo.git",
+			id:         "github.com/org/repo",
+			ssh:        true,
+		},
+		{
+			desc:       "Testing credentials id from repository without path",
+			repository: "https://git.internal/",
+			id:         "git.internal",
+			ssh:        false,
+		},
+		{
+			desc:       "Testing no credentials id from an unsupported scheme",
+			repository: "file:///tmp/repo.git",
+			id:         "",
+			ssh:        false,
+		},
+		{
+			desc:       "Testing no credentials id from a local path",
+			repository: "/tmp/repo",
+			id:         "",
+			ssh:        false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			id, ssh := repositoryCredentialsID(test.repository)
+			assert.Equal(t, test.id, id)
+			assert.Equal(t, test.ssh, ssh)
+		})
+	}
+}
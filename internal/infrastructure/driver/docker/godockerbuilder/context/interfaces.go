@@ -13,5 +13,5 @@ type DockerBuildContexter interface {
 
 // GitAuthFactorier is an interface for git authentication
 type GitAuthFactorier interface {
-	GenerateAuthMethod(*builder.DockerDriverGitContextAuthOptions) (gitauth.GitAuther, error)
+	GenerateAuthMethod(string, *builder.DockerDriverGitContextAuthOptions) (gitauth.GitAuther, error)
 }
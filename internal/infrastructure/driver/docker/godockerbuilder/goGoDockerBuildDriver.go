@@ -2,7 +2,9 @@ package godockerbuilder
 
 import (
 	"context"
+	goerrors "errors"
 	"io"
+	"net"
 	"sync"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -10,14 +12,28 @@ import (
 	"github.com/apenella/go-docker-builder/pkg/build"
 	godockerbuilderbuildcontext "github.com/apenella/go-docker-builder/pkg/build/context"
 	"github.com/apenella/go-docker-builder/pkg/response"
+	dockertypes "github.com/docker/docker/api/types"
+	dockerimagetypes "github.com/docker/docker/api/types/image"
+	dockerregistrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/gostevedore/stevedore/internal/core/domain/builder"
 	buildcontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context"
+	"github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/sshforward"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+)
+
+const (
+	// sessionEndpoint is the Docker API endpoint used to attach a BuildKit session
+	sessionEndpoint = "/session"
 )
 
 // GoDockerBuildDriver is a driver for building docker images
 type GoDockerBuildDriver struct {
 	cmd            DockerBuilder
 	contextFactory *buildcontext.DockerBuildContextFactory
+	sessionDialer  SessionDialer
+	sshFactory     SSHForwardFactorier
+	sshForwards    []*sshforward.SSHForward
 
 	addBuildArgsMutex sync.Mutex
 	addLabelMutex     sync.Mutex
@@ -32,6 +48,12 @@ func NewGoDockerBuildDriver(cmd *build.DockerBuildCmd, contextFactory *buildcont
 	}
 }
 
+// WithSSHForward sets the factory to generate the SSH forwards and the dialer to attach the BuildKit session to the Docker daemon
+func (d *GoDockerBuildDriver) WithSSHForward(factory SSHForwardFactorier, dialer SessionDialer) {
+	d.sshFactory = factory
+	d.sessionDialer = dialer
+}
+
 // WithDockerfile sets dockerfile to use
 func (d *GoDockerBuildDriver) WithDockerfile(dockerfile string) {
 	d.cmd = d.cmd.WithDockerfile(dockerfile)
@@ -85,6 +107,61 @@ func (d *GoDockerBuildDriver) AddPushAuth(username string, password string) erro
 	return d.cmd.AddPushAuth(username, password)
 }
 
+// AddTokenAuth defines the authentication to use for an specific registry, using an identity token or a registry token instead of a password
+func (d *GoDockerBuildDriver) AddTokenAuth(username string, identityToken string, registryToken string, registry string) error {
+
+	errContext := "(godockerbuilder::AddTokenAuth)"
+
+	cmd, isDockerBuildCmd := d.cmd.(*build.DockerBuildCmd)
+	if !isDockerBuildCmd {
+		return errors.New(errContext, "Docker build command is required to authenticate using tokens")
+	}
+
+	if cmd.ImageBuildOptions == nil {
+		cmd.ImageBuildOptions = &dockertypes.ImageBuildOptions{}
+	}
+
+	if cmd.ImageBuildOptions.AuthConfigs == nil {
+		cmd.ImageBuildOptions.AuthConfigs = map[string]dockerregistrytypes.AuthConfig{}
+	}
+
+	cmd.ImageBuildOptions.AuthConfigs[registry] = dockerregistrytypes.AuthConfig{
+		Username:      username,
+		IdentityToken: identityToken,
+		RegistryToken: registryToken,
+	}
+
+	return nil
+}
+
+// AddPushTokenAuth defines the authentication to push the image, using an identity token or a registry token instead of a password
+func (d *GoDockerBuildDriver) AddPushTokenAuth(username string, identityToken string, registryToken string) error {
+
+	errContext := "(godockerbuilder::AddPushTokenAuth)"
+
+	cmd, isDockerBuildCmd := d.cmd.(*build.DockerBuildCmd)
+	if !isDockerBuildCmd {
+		return errors.New(errContext, "Docker build command is required to authenticate using tokens")
+	}
+
+	auth, err := dockerregistrytypes.EncodeAuthConfig(dockerregistrytypes.AuthConfig{
+		Username:      username,
+		IdentityToken: identityToken,
+		RegistryToken: registryToken,
+	})
+	if err != nil {
+		return errors.New(errContext, "Error encoding the token auth configuration", err)
+	}
+
+	if cmd.ImagePushOptions == nil {
+		cmd.ImagePushOptions = &dockerimagetypes.PushOptions{}
+	}
+
+	cmd.ImagePushOptions.RegistryAuth = auth
+
+	return nil
+}
+
 // AddBuildArgs append new build args
 func (d *GoDockerBuildDriver) AddBuildArgs(arg string, value string) error {
 	d.addBuildArgsMutex.Lock()
@@ -119,6 +196,26 @@ func (d *GoDockerBuildDriver) AddBuildContext(options ...*builder.DockerDriverCo
 	return d.cmd.AddBuildContext(buildContextList...)
 }
 
+// AddSSH sets the SSH agent sockets or keys forwarded to the build. When SSH is forwarded, the image is built using BuildKit
+func (d *GoDockerBuildDriver) AddSSH(options ...*builder.DockerDriverSSHOptions) error {
+
+	errContext := "(godockerbuilder::AddSSH)"
+
+	if d.sshFactory == nil {
+		return errors.New(errContext, "SSH forward factory is required to forward SSH to the build")
+	}
+
+	for _, o := range options {
+		forward, err := d.sshFactory.GenerateSSHForward(o)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+		d.sshForwards = append(d.sshForwards, forward)
+	}
+
+	return nil
+}
+
 // AddLabel adds a label to the image
 func (d *GoDockerBuildDriver) AddLabel(label string, value string) error {
 	d.addLabelMutex.Lock()
@@ -137,5 +234,86 @@ func (d *GoDockerBuildDriver) AddTags(tags ...string) error {
 
 // Run starts the build
 func (d *GoDockerBuildDriver) Run(ctx context.Context) error {
-	return d.cmd.Run(ctx)
+
+	errContext := "(godockerbuilder::Run)"
+
+	if len(d.sshForwards) == 0 {
+		return d.cmd.Run(ctx)
+	}
+	defer d.closeSSHForwards()
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s, sessionErr, err := d.startSession(sessionCtx)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = d.cmd.Run(ctx)
+
+	// the session runs until its context is cancelled, so it is stopped before achieving its error
+	cancel()
+	errSession := <-sessionErr
+	s.Close()
+
+	if err != nil {
+		if errSession != nil && !goerrors.Is(errSession, context.Canceled) {
+			return errors.New(errContext, "", err, errors.New(errContext, "BuildKit session failed", errSession))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// startSession starts a BuildKit session that provides the SSH forwards to the build. The session error is sent to the returned channel once the session ends
+func (d *GoDockerBuildDriver) startSession(ctx context.Context) (*session.Session, <-chan error, error) {
+
+	errContext := "(godockerbuilder::startSession)"
+
+	if d.sessionDialer == nil {
+		return nil, nil, errors.New(errContext, "Session dialer is required to forward SSH to the build")
+	}
+
+	cmd, isDockerBuildCmd := d.cmd.(*build.DockerBuildCmd)
+	if !isDockerBuildCmd || cmd.ImageBuildOptions == nil {
+		return nil, nil, errors.New(errContext, "Docker image build options are required to forward SSH to the build")
+	}
+
+	configs := make([]sshprovider.AgentConfig, 0, len(d.sshForwards))
+	for _, forward := range d.sshForwards {
+		configs = append(configs, forward.AgentConfig())
+	}
+
+	provider, err := sshprovider.NewSSHAgentProvider(configs)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "SSH agent provider could not be created", err)
+	}
+
+	s, err := session.NewSession(ctx, "")
+	if err != nil {
+		return nil, nil, errors.New(errContext, "BuildKit session could not be created", err)
+	}
+	s.Allow(provider)
+
+	cmd.ImageBuildOptions.Version = dockertypes.BuilderBuildKit
+	cmd.ImageBuildOptions.SessionID = s.ID()
+
+	sessionErr := make(chan error, 1)
+	go func() {
+		sessionErr <- s.Run(ctx, func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+			return d.sessionDialer.DialHijack(ctx, sessionEndpoint, proto, meta)
+		})
+	}()
+
+	return s, sessionErr, nil
+}
+
+func (d *GoDockerBuildDriver) closeSSHForwards() {
+	for _, forward := range d.sshForwards {
+		_ = forward.Close()
+	}
+	d.sshForwards = nil
 }
@@ -1,15 +1,22 @@
 package godockerbuilder
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/apenella/go-docker-builder/pkg/build"
 	godockerbuilderbuildcontext "github.com/apenella/go-docker-builder/pkg/build/context"
+	dockertypes "github.com/docker/docker/api/types"
+	dockerregistrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/gostevedore/stevedore/internal/core/domain/builder"
 	dockerbuildcontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context"
 	gitcontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/git"
 	pathcontext "github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/context/path"
+	"github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/sshforward"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -180,3 +187,201 @@ func TestWithRemoveAfterPush(t *testing.T) {
 
 	assert.True(t, driver.cmd.(*build.DockerBuildCmd).RemoveAfterPush)
 }
+
+func TestAddSSH(t *testing.T) {
+	errContext := "(godockerbuilder::AddSSH)"
+	tests := []struct {
+		desc    string
+		driver  *GoDockerBuildDriver
+		options []*builder.DockerDriverSSHOptions
+		res     []*sshforward.SSHForward
+		err     error
+	}{
+		{
+			desc:   "Testing error adding SSH forwards without an SSH forward factory",
+			driver: &GoDockerBuildDriver{},
+			options: []*builder.DockerDriverSSHOptions{
+				{ID: "default"},
+			},
+			err: errors.New(errContext, "SSH forward factory is required to forward SSH to the build"),
+		},
+		{
+			desc: "Testing add SSH forwards",
+			driver: &GoDockerBuildDriver{
+				sshFactory: sshforward.NewSSHForwardFactory(nil),
+			},
+			options: []*builder.DockerDriverSSHOptions{
+				{},
+				{ID: "deploy", PrivateKeyFile: "id_rsa"},
+			},
+			res: []*sshforward.SSHForward{
+				{ID: sshforward.DefaultSSHForwardID},
+				{ID: "deploy", Paths: []string{"id_rsa"}},
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.driver.AddSSH(test.options...)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.driver.sshForwards)
+			}
+		})
+	}
+}
+
+func TestAddTokenAuth(t *testing.T) {
+	errContext := "(godockerbuilder::AddTokenAuth)"
+	tests := []struct {
+		desc          string
+		driver        *GoDockerBuildDriver
+		username      string
+		identityToken string
+		registryToken string
+		registry      string
+		res           map[string]dockerregistrytypes.AuthConfig
+		err           error
+	}{
+		{
+			desc:   "Testing error adding token auth without a docker build command",
+			driver: &GoDockerBuildDriver{},
+			err:    errors.New(errContext, "Docker build command is required to authenticate using tokens"),
+		},
+		{
+			desc: "Testing add token auth",
+			driver: &GoDockerBuildDriver{
+				cmd: &build.DockerBuildCmd{
+					ImageBuildOptions: &dockertypes.ImageBuildOptions{},
+				},
+			},
+			username:      "robot",
+			identityToken: "identity-token",
+			registryToken: "registry-token",
+			registry:      "registry.test",
+			res: map[string]dockerregistrytypes.AuthConfig{
+				"registry.test": {
+					Username:      "robot",
+					IdentityToken: "identity-token",
+					RegistryToken: "registry-token",
+				},
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.driver.AddTokenAuth(test.username, test.identityToken, test.registryToken, test.registry)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.driver.cmd.(*build.DockerBuildCmd).ImageBuildOptions.AuthConfigs)
+			}
+		})
+	}
+}
+
+func TestAddPushTokenAuth(t *testing.T) {
+	errContext := "(godockerbuilder::AddPushTokenAuth)"
+	tests := []struct {
+		desc          string
+		driver        *GoDockerBuildDriver
+		username      string
+		identityToken string
+		registryToken string
+		res           dockerregistrytypes.AuthConfig
+		err           error
+	}{
+		{
+			desc:   "Testing error adding push token auth without a docker build command",
+			driver: &GoDockerBuildDriver{},
+			err:    errors.New(errContext, "Docker build command is required to authenticate using tokens"),
+		},
+		{
+			desc: "Testing add push token auth",
+			driver: &GoDockerBuildDriver{
+				cmd: &build.DockerBuildCmd{},
+			},
+			username:      "robot",
+			registryToken: "registry-token",
+			res: dockerregistrytypes.AuthConfig{
+				Username:      "robot",
+				RegistryToken: "registry-token",
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.driver.AddPushTokenAuth(test.username, test.identityToken, test.registryToken)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				auth, err := dockerregistrytypes.DecodeAuthConfig(test.driver.cmd.(*build.DockerBuildCmd).ImagePushOptions.RegistryAuth)
+				assert.Nil(t, err)
+				assert.Equal(t, test.res, *auth)
+			}
+		})
+	}
+}
+
+func TestRunForwardingSSH(t *testing.T) {
+	t.Log("Testing error running a build forwarding SSH without a session dialer")
+
+	errContext := "(godockerbuilder::Run)"
+
+	driver := &GoDockerBuildDriver{
+		cmd: &MockDockerBuildCmd{},
+		sshForwards: []*sshforward.SSHForward{
+			{ID: sshforward.DefaultSSHForwardID},
+		},
+	}
+
+	err := driver.Run(context.TODO())
+	assert.Equal(t, errors.New(errContext, "", errors.New("(godockerbuilder::startSession)", "Session dialer is required to forward SSH to the build")).Error(), err.Error())
+	assert.Nil(t, driver.sshForwards)
+}
+
+func TestRunForwardingSSHWithFailingSession(t *testing.T) {
+	t.Log("Testing error running a build forwarding SSH when the BuildKit session can not be attached")
+
+	errContext := "(godockerbuilder::Run)"
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("SSH agent socket could not be created: %s", err)
+	}
+	defer listener.Close()
+
+	dialer := NewMockSessionDialer()
+	dialer.On("DialHijack", sessionEndpoint, "h2c").Return(nil, fmt.Errorf("dial error"))
+
+	driver := &GoDockerBuildDriver{
+		cmd: &build.DockerBuildCmd{
+			ImageBuildOptions: &dockertypes.ImageBuildOptions{},
+		},
+		sshForwards: []*sshforward.SSHForward{
+			{ID: sshforward.DefaultSSHForwardID, Paths: []string{socket}},
+		},
+		sessionDialer: dialer,
+	}
+
+	err = driver.Run(context.TODO())
+	assert.Equal(t, errors.New(errContext, "",
+		errors.New("(build::Run)", "Docker build context is not defined"),
+		errors.New(errContext, "BuildKit session failed", fmt.Errorf("failed to dial gRPC: dial error"))).Error(), err.Error())
+	dialer.AssertExpectations(t)
+	assert.Nil(t, driver.sshForwards)
+}
@@ -2,10 +2,13 @@ package godockerbuilder
 
 import (
 	"context"
+	"net"
 
 	"github.com/apenella/go-docker-builder/pkg/build"
 	godockerbuilderbuildcontext "github.com/apenella/go-docker-builder/pkg/build/context"
 	"github.com/apenella/go-docker-builder/pkg/types"
+	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/infrastructure/driver/docker/godockerbuilder/sshforward"
 )
 
 // DockerBuilder defines a docker driver
@@ -25,3 +28,13 @@ type DockerBuilder interface {
 	AddTags(...string) error
 	Run(context.Context) error
 }
+
+// SSHForwardFactorier defines a factory for the SSH forwards of a build
+type SSHForwardFactorier interface {
+	GenerateSSHForward(options *builder.DockerDriverSSHOptions) (*sshforward.SSHForward, error)
+}
+
+// SessionDialer defines the dialer used to attach a BuildKit session to the Docker daemon
+type SessionDialer interface {
+	DialHijack(ctx context.Context, url, proto string, meta map[string][]string) (net.Conn, error)
+}
@@ -65,6 +65,18 @@ func (d *MockGoDockerBuildDriver) AddPushAuth(username string, password string)
 	return args.Error(0)
 }
 
+// AddTokenAuth is a mocked method
+func (d *MockGoDockerBuildDriver) AddTokenAuth(username string, identityToken string, registryToken string, registry string) error {
+	args := d.Mock.Called(username, identityToken, registryToken, registry)
+	return args.Error(0)
+}
+
+// AddPushTokenAuth is a mocked method
+func (d *MockGoDockerBuildDriver) AddPushTokenAuth(username string, identityToken string, registryToken string) error {
+	args := d.Mock.Called(username, identityToken, registryToken)
+	return args.Error(0)
+}
+
 // AddBuildArgs is a mocked method
 func (d *MockGoDockerBuildDriver) AddBuildArgs(arg string, value string) error {
 	args := d.Mock.Called(arg, value)
@@ -77,6 +89,12 @@ func (d *MockGoDockerBuildDriver) AddBuildContext(context ...*builder.DockerDriv
 	return args.Error(0)
 }
 
+// AddSSH is a mocked method
+func (d *MockGoDockerBuildDriver) AddSSH(options ...*builder.DockerDriverSSHOptions) error {
+	args := d.Mock.Called(options)
+	return args.Error(0)
+}
+
 // AddLabel is a mocked method
 func (d *MockGoDockerBuildDriver) AddLabel(label string, value string) error {
 	args := d.Mock.Called(label, value)
@@ -0,0 +1,27 @@
+package godockerbuilder
+
+import (
+	"context"
+	"net"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSessionDialer is a mock for the dialer used to attach a BuildKit session to the Docker daemon
+type MockSessionDialer struct {
+	mock.Mock
+}
+
+// NewMockSessionDialer returns a new MockSessionDialer
+func NewMockSessionDialer() *MockSessionDialer {
+	return &MockSessionDialer{}
+}
+
+// DialHijack is a mock for DialHijack
+func (d *MockSessionDialer) DialHijack(ctx context.Context, url, proto string, meta map[string][]string) (net.Conn, error) {
+	args := d.Called(url, proto)
+
+	conn, _ := args.Get(0).(net.Conn)
+
+	return conn, args.Error(1)
+}
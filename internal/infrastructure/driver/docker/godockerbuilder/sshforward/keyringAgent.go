@@ -0,0 +1,84 @@
+package sshforward
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	keyringAgentSocketName = "agent.sock"
+)
+
+// keyringAgent serves an in-memory SSH agent through a unix socket. It is used to forward passphrase protected private keys without writing the decrypted key to disk
+type keyringAgent struct {
+	dir      string
+	listener net.Listener
+}
+
+// newKeyringAgent starts an in-memory SSH agent holding the given private key
+func newKeyringAgent(key interface{}) (*keyringAgent, error) {
+
+	errContext := "(sshforward::newKeyringAgent)"
+
+	keyring := agent.NewKeyring()
+	err := keyring.Add(agent.AddedKey{PrivateKey: key})
+	if err != nil {
+		return nil, errors.New(errContext, "Private key could not be added to the SSH agent", err)
+	}
+
+	dir, err := os.MkdirTemp("", "stevedore-ssh-")
+	if err != nil {
+		return nil, errors.New(errContext, "SSH agent directory could not be created", err)
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, keyringAgentSocketName))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, errors.New(errContext, "SSH agent socket could not be created", err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				_ = agent.ServeAgent(keyring, conn)
+			}(conn)
+		}
+	}()
+
+	return &keyringAgent{
+		dir:      dir,
+		listener: listener,
+	}, nil
+}
+
+// Socket returns the path to the agent socket
+func (a *keyringAgent) Socket() string {
+	return a.listener.Addr().String()
+}
+
+// Close stops the agent and removes its socket
+func (a *keyringAgent) Close() error {
+	errContext := "(sshforward::keyringAgent::Close)"
+
+	err := a.listener.Close()
+	if err != nil {
+		return errors.New(errContext, "SSH agent could not be stopped", err)
+	}
+
+	err = os.RemoveAll(a.dir)
+	if err != nil {
+		return errors.New(errContext, "SSH agent directory could not be removed", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package sshforward
+
+import (
+	"io"
+
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+)
+
+const (
+	// DefaultSSHForwardID is the identifier used when no identifier is defined to forward SSH to a build
+	DefaultSSHForwardID = "default"
+)
+
+// SSHForward is an SSH agent socket or a set of private keys forwarded to a docker build
+type SSHForward struct {
+	// ID is the identifier used on 'RUN --mount=type=ssh,id=<id>' instructions
+	ID string
+	// Paths are the private key files or the SSH agent socket forwarded. When empty, it is used the SSH agent from SSH_AUTH_SOCK
+	Paths []string
+
+	closer io.Closer
+}
+
+// AgentConfig returns the BuildKit SSH agent configuration for the forward
+func (f *SSHForward) AgentConfig() sshprovider.AgentConfig {
+	return sshprovider.AgentConfig{
+		ID:    f.ID,
+		Paths: f.Paths,
+	}
+}
+
+// Close releases the resources used by the forward
+func (f *SSHForward) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+
+	return f.closer.Close()
+}
@@ -0,0 +1,112 @@
+package sshforward
+
+import (
+	"fmt"
+	"os"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHForwardFactory is a factory for creating the SSH forwards of a docker build
+type SSHForwardFactory struct {
+	Credentials repository.AuthFactorier
+}
+
+// NewSSHForwardFactory creates a new SSHForwardFactory
+func NewSSHForwardFactory(credentials repository.AuthFactorier) *SSHForwardFactory {
+	return &SSHForwardFactory{
+		Credentials: credentials,
+	}
+}
+
+// GenerateSSHForward returns the SSH forward defined by the given options. The forward is backed either by a private key file or by the SSH agent
+func (f *SSHForwardFactory) GenerateSSHForward(options *builder.DockerDriverSSHOptions) (*SSHForward, error) {
+
+	errContext := "(SSHForwardFactory::GenerateSSHForward)"
+
+	if options == nil {
+		return nil, errors.New(errContext, "SSH forward options are required to generate an SSH forward")
+	}
+
+	id := options.ID
+	if id == "" {
+		id = DefaultSSHForwardID
+	}
+
+	privateKeyFile := options.PrivateKeyFile
+	privateKeyPassword := options.PrivateKeyPassword
+
+	if options.CredentialsID != "" {
+		if f.Credentials == nil {
+			return nil, errors.New(errContext, "Credentials store is expected when a credentials id is configured")
+		}
+
+		authMethod, err := f.Credentials.Get(options.CredentialsID)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		if authMethod == nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Credentials with id '%s' not found", options.CredentialsID))
+		}
+
+		switch authMethod.Name() {
+		case credentials.KeyFileAuthMethod:
+			privateKeyFile = authMethod.(*keyfile.KeyFileAuthMethod).PrivateKeyFile
+			privateKeyPassword = authMethod.(*keyfile.KeyFileAuthMethod).PrivateKeyPassword
+		case credentials.SSHAgentAuthMethod:
+			return &SSHForward{ID: id}, nil
+		default:
+			return nil, errors.New(errContext, fmt.Sprintf("Credentials '%s' can not be forwarded to the build. Found '%s' when is expected keyfile or ssh-agent auth method", options.CredentialsID, authMethod.Name()))
+		}
+	}
+
+	if privateKeyFile == "" {
+		return &SSHForward{ID: id}, nil
+	}
+
+	if privateKeyPassword == "" {
+		return &SSHForward{
+			ID:    id,
+			Paths: []string{privateKeyFile},
+		}, nil
+	}
+
+	forward, err := f.generateSSHForwardFromProtectedKey(id, privateKeyFile, privateKeyPassword)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return forward, nil
+}
+
+func (f *SSHForwardFactory) generateSSHForwardFromProtectedKey(id, privateKeyFile, privateKeyPassword string) (*SSHForward, error) {
+
+	errContext := "(sshforward::generateSSHForwardFromProtectedKey)"
+
+	data, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Private key file '%s' could not be read", privateKeyFile), err)
+	}
+
+	key, err := ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(privateKeyPassword))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Private key file '%s' could not be decrypted", privateKeyFile), err)
+	}
+
+	agent, err := newKeyringAgent(key)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return &SSHForward{
+		ID:     id,
+		Paths:  []string{agent.Socket()},
+		closer: agent,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package sshforward
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/method/sshagent"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestGenerateSSHForward(t *testing.T) {
+	errContext := "(SSHForwardFactory::GenerateSSHForward)"
+
+	tests := []struct {
+		desc              string
+		options           *builder.DockerDriverSSHOptions
+		factory           *SSHForwardFactory
+		prepareAssertFunc func(*SSHForwardFactory)
+		res               *SSHForward
+		err               error
+	}{
+		{
+			desc:    "Testing error when options is nil",
+			factory: NewSSHForwardFactory(nil),
+			err:     errors.New(errContext, "SSH forward options are required to generate an SSH forward"),
+		},
+		{
+			desc:    "Testing generate an SSH agent forward when no key is defined",
+			factory: NewSSHForwardFactory(nil),
+			options: &builder.DockerDriverSSHOptions{},
+			res: &SSHForward{
+				ID: DefaultSSHForwardID,
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing generate a private key file forward",
+			factory: NewSSHForwardFactory(nil),
+			options: &builder.DockerDriverSSHOptions{
+				ID:             "deploy",
+				PrivateKeyFile: "id_rsa",
+			},
+			res: &SSHForward{
+				ID:    "deploy",
+				Paths: []string{"id_rsa"},
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing error when credentials id is defined and there is no credentials store",
+			factory: NewSSHForwardFactory(nil),
+			options: &builder.DockerDriverSSHOptions{
+				CredentialsID: "id",
+			},
+			err: errors.New(errContext, "Credentials store is expected when a credentials id is configured"),
+		},
+		{
+			desc:    "Testing error when credentials are not found",
+			factory: NewSSHForwardFactory(factory.NewMockAuthFactory()),
+			options: &builder.DockerDriverSSHOptions{
+				CredentialsID: "id",
+			},
+			prepareAssertFunc: func(f *SSHForwardFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "id").Return(nil, nil)
+			},
+			err: errors.New(errContext, "Credentials with id 'id' not found"),
+		},
+		{
+			desc:    "Testing error when credentials are basic auth",
+			factory: NewSSHForwardFactory(factory.NewMockAuthFactory()),
+			options: &builder.DockerDriverSSHOptions{
+				CredentialsID: "id",
+			},
+			prepareAssertFunc: func(f *SSHForwardFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "id").Return(&basic.BasicAuthMethod{
+					Username: "username",
+					Password: "password",
+				}, nil)
+			},
+			err: errors.New(errContext, "Credentials 'id' can not be forwarded to the build. Found 'basic' when is expected keyfile or ssh-agent auth method"),
+		},
+		{
+			desc:    "Testing generate a private key file forward from credentials",
+			factory: NewSSHForwardFactory(factory.NewMockAuthFactory()),
+			options: &builder.DockerDriverSSHOptions{
+				CredentialsID: "id",
+			},
+			prepareAssertFunc: func(f *SSHForwardFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "id").Return(&keyfile.KeyFileAuthMethod{
+					PrivateKeyFile: "id_rsa",
+				}, nil)
+			},
+			res: &SSHForward{
+				ID:    DefaultSSHForwardID,
+				Paths: []string{"id_rsa"},
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing generate an SSH agent forward from credentials",
+			factory: NewSSHForwardFactory(factory.NewMockAuthFactory()),
+			options: &builder.DockerDriverSSHOptions{
+				ID:            "agent",
+				CredentialsID: "id",
+			},
+			prepareAssertFunc: func(f *SSHForwardFactory) {
+				f.Credentials.(*factory.MockAuthFactory).On("Get", "id").Return(&sshagent.SSHAgentAuthMethod{}, nil)
+			},
+			res: &SSHForward{
+				ID: "agent",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing error when a protected private key file does not exist",
+			factory: NewSSHForwardFactory(nil),
+			options: &builder.DockerDriverSSHOptions{
+				PrivateKeyFile:     "unexisting_id_rsa",
+				PrivateKeyPassword: "password",
+			},
+			err: errors.New(errContext, "",
+				errors.New("(sshforward::generateSSHForwardFromProtectedKey)", "Private key file 'unexisting_id_rsa' could not be read",
+					&os.PathError{Op: "open", Path: "unexisting_id_rsa", Err: syscall.ENOENT})),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.factory)
+			}
+
+			res, err := test.factory.GenerateSSHForward(test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestGenerateSSHForwardFromProtectedKey(t *testing.T) {
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKeyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	err = os.WriteFile(privateKeyFile, pem.EncodeToMemory(block), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Testing error when the private key password is wrong", func(t *testing.T) {
+		_, err := NewSSHForwardFactory(nil).GenerateSSHForward(&builder.DockerDriverSSHOptions{
+			PrivateKeyFile:     privateKeyFile,
+			PrivateKeyPassword: "wrong",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Testing forward a protected private key through an in-memory SSH agent", func(t *testing.T) {
+		forward, err := NewSSHForwardFactory(nil).GenerateSSHForward(&builder.DockerDriverSSHOptions{
+			PrivateKeyFile:     privateKeyFile,
+			PrivateKeyPassword: "password",
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, DefaultSSHForwardID, forward.ID)
+		assert.Len(t, forward.Paths, 1)
+
+		conn, err := net.Dial("unix", forward.Paths[0])
+		if !assert.NoError(t, err) {
+			return
+		}
+		keys, err := agent.NewClient(conn).List()
+		conn.Close()
+		assert.NoError(t, err)
+		assert.Len(t, keys, 1)
+
+		assert.NoError(t, forward.Close())
+		_, err = os.Stat(forward.Paths[0])
+		assert.True(t, os.IsNotExist(err))
+	})
+}
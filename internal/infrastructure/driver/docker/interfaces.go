@@ -29,8 +29,11 @@ type DockerDriverer interface {
 	WithRemoveAfterPush()
 	AddAuth(string, string, string) error
 	AddPushAuth(string, string) error
+	AddTokenAuth(string, string, string, string) error
+	AddPushTokenAuth(string, string, string) error
 	AddBuildArgs(string, string) error
 	AddBuildContext(...*builder.DockerDriverContextOptions) error
+	AddSSH(...*builder.DockerDriverSSHOptions) error
 	AddLabel(string, string) error
 	AddTags(...string) error
 	Run(context.Context) error
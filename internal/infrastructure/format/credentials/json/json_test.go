@@ -35,14 +35,19 @@ func TestMarshal(t *testing.T) {
 				AWSSharedCredentialsFiles:     []string{"awssharedcredentialsfiles"},
 				AWSSharedConfigFiles:          []string{"awssharedconfigfiles"},
 				AWSUseDefaultCredentialsChain: true,
+				CredentialProcess:             "credentialprocess",
 				DEPRECATEDPassword:            "deprecatedpassword",
 				DEPRECATEDUsername:            "deprecatedusername",
 				Password:                      "password",
 				Username:                      "username",
+				Token:                         "token",
+				RefreshToken:                  "refreshtoken",
 				PrivateKeyFile:                "privatekeyfile",
 				PrivateKeyPassword:            "privatekeypassword",
 				GitSSHUser:                    "gitsshuser",
 				AllowUseSSHAgent:              true,
+				Description:                   "description",
+				ExpiresAt:                     "2024-12-31T00:00:00Z",
 			},
 			res: `{
   "ID": "",
@@ -58,14 +63,19 @@ func TestMarshal(t *testing.T) {
     "awssharedconfigfiles"
   ],
   "aws_use_default_credentials_chain": true,
+  "credential_process": "credentialprocess",
   "docker_login_password": "deprecatedpassword",
   "docker_login_username": "deprecatedusername",
   "password": "password",
   "username": "username",
+  "token": "token",
+  "refresh_token": "refreshtoken",
   "private_key_file": "privatekeyfile",
   "private_key_password": "privatekeypassword",
   "git_ssh_user": "gitsshuser",
-  "use_ssh_agent": true
+  "use_ssh_agent": true,
+  "description": "description",
+  "expires_at": "2024-12-31T00:00:00Z"
 }`,
 		},
 	}
@@ -101,14 +111,19 @@ func TestUnmashal(t *testing.T) {
 		  "awssharedconfigfiles"
 		],
 		"aws_use_default_credentials_chain": true,
+		"credential_process": "credentialprocess",
 		"docker_login_password": "deprecatedpassword",
 		"docker_login_username": "deprecatedusername",
 		"password": "password",
 		"username": "username",
+		"token": "token",
+		"refresh_token": "refreshtoken",
 		"private_key_file": "privatekeyfile",
 		"private_key_password": "privatekeypassword",
 		"git_ssh_user": "gitsshuser",
-		"use_ssh_agent": true
+		"use_ssh_agent": true,
+		"description": "description",
+		"expires_at": "2024-12-31T00:00:00Z"
 	}`
 	expected := &credentials.Credential{
 		AWSAccessKeyID:                "awsaccesskeyid",
@@ -119,14 +134,19 @@ func TestUnmashal(t *testing.T) {
 		AWSSharedCredentialsFiles:     []string{"awssharedcredentialsfiles"},
 		AWSSharedConfigFiles:          []string{"awssharedconfigfiles"},
 		AWSUseDefaultCredentialsChain: true,
+		CredentialProcess:             "credentialprocess",
 		DEPRECATEDPassword:            "deprecatedpassword",
 		DEPRECATEDUsername:            "deprecatedusername",
 		Password:                      "password",
 		Username:                      "username",
+		Token:                         "token",
+		RefreshToken:                  "refreshtoken",
 		PrivateKeyFile:                "privatekeyfile",
 		PrivateKeyPassword:            "privatekeypassword",
 		GitSSHUser:                    "gitsshuser",
 		AllowUseSSHAgent:              true,
+		Description:                   "description",
+		ExpiresAt:                     "2024-12-31T00:00:00Z",
 	}
 
 	formater := NewJSONFormater()
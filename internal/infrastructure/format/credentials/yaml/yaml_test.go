@@ -35,14 +35,19 @@ func TestMarshal(t *testing.T) {
 				AWSSharedCredentialsFiles:     []string{"awssharedcredentialsfiles"},
 				AWSSharedConfigFiles:          []string{"awssharedconfigfiles"},
 				AWSUseDefaultCredentialsChain: true,
+				CredentialProcess:             "credentialprocess",
 				DEPRECATEDPassword:            "deprecatedpassword",
 				DEPRECATEDUsername:            "deprecatedusername",
 				Password:                      "password",
 				Username:                      "username",
+				Token:                         "token",
+				RefreshToken:                  "refreshtoken",
 				PrivateKeyFile:                "privatekeyfile",
 				PrivateKeyPassword:            "privatekeypassword",
 				GitSSHUser:                    "gitsshuser",
 				AllowUseSSHAgent:              true,
+				Description:                   "description",
+				ExpiresAt:                     "2024-12-31T00:00:00Z",
 			},
 			res: `id: ""
 aws_access_key_id: awsaccesskeyid
@@ -55,14 +60,19 @@ aws_shared_credentials_files:
 aws_shared_config_files:
     - awssharedconfigfiles
 aws_use_default_credentials_chain: true
+credential_process: credentialprocess
 docker_login_password: deprecatedpassword
 docker_login_username: deprecatedusername
 password: password
 username: username
+token: token
+refresh_token: refreshtoken
 private_key_file: privatekeyfile
 private_key_password: privatekeypassword
 git_ssh_user: gitsshuser
 use_ssh_agent: true
+description: description
+expires_at: "2024-12-31T00:00:00Z"
 `,
 		},
 	}
@@ -95,14 +105,19 @@ aws_shared_credentials_files:
 aws_shared_config_files:
 - awssharedconfigfiles
 aws_use_default_credentials_chain: true
+credential_process: credentialprocess
 docker_login_password: deprecatedpassword
 docker_login_username: deprecatedusername
 password: password
 username: username
+token: token
+refresh_token: refreshtoken
 private_key_file: privatekeyfile
 private_key_password: privatekeypassword
 git_ssh_user: gitsshuser
 use_ssh_agent: true
+description: description
+expires_at: "2024-12-31T00:00:00Z"
 `
 	expected := &credentials.Credential{
 		AWSAccessKeyID:                "awsaccesskeyid",
@@ -113,14 +128,19 @@ use_ssh_agent: true
 		AWSSharedCredentialsFiles:     []string{"awssharedcredentialsfiles"},
 		AWSSharedConfigFiles:          []string{"awssharedconfigfiles"},
 		AWSUseDefaultCredentialsChain: true,
+		CredentialProcess:             "credentialprocess",
 		DEPRECATEDPassword:            "deprecatedpassword",
 		DEPRECATEDUsername:            "deprecatedusername",
 		Password:                      "password",
 		Username:                      "username",
+		Token:                         "token",
+		RefreshToken:                  "refreshtoken",
 		PrivateKeyFile:                "privatekeyfile",
 		PrivateKeyPassword:            "privatekeypassword",
 		GitSSHUser:                    "gitsshuser",
 		AllowUseSSHAgent:              true,
+		Description:                   "description",
+		ExpiresAt:                     "2024-12-31T00:00:00Z",
 	}
 
 	formater := NewYAMLFormater()
@@ -0,0 +1,184 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagName is the struct tag used to annotate the fields with JSON Schema keywords, such as 'jsonschema:"deprecated=images_path"'
+	TagName = "jsonschema"
+
+	// deprecatedTagKeyword marks a field as deprecated. Its value, when defined, is the property that replaces the field
+	deprecatedTagKeyword = "deprecated"
+	// yamlTagName is the struct tag that defines the properties names
+	yamlTagName = "yaml"
+)
+
+// OptionsFunc defines the signature for an option function to set reflector attributes
+type OptionsFunc func(r *Reflector)
+
+// Reflector generates JSON Schemas from Go types. Properties are named after the fields yaml tag, and the fields without yaml tag are not described
+type Reflector struct {
+	fields map[reflect.Type]map[string]*Schema
+}
+
+// NewReflector returns a new reflector
+func NewReflector(opts ...OptionsFunc) *Reflector {
+	r := &Reflector{
+		fields: map[reflect.Type]map[string]*Schema{},
+	}
+	r.Options(opts...)
+
+	return r
+}
+
+// Options provides the options for the reflector
+func (r *Reflector) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(r)
+	}
+}
+
+// WithFieldSchema sets the schema of a struct field. It is required for the fields whose type does not describe the values they accept, such as the interface{} fields
+func WithFieldSchema(v interface{}, field string, schema *Schema) OptionsFunc {
+	return func(r *Reflector) {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if r.fields == nil {
+			r.fields = map[reflect.Type]map[string]*Schema{}
+		}
+
+		if _, exists := r.fields[t]; !exists {
+			r.fields[t] = map[string]*Schema{}
+		}
+
+		r.fields[t][field] = schema
+	}
+}
+
+// Reflect returns the JSON Schema that describes the type of v
+func (r *Reflector) Reflect(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+
+	return r.reflectType(reflect.TypeOf(v))
+}
+
+func (r *Reflector) reflectType(t reflect.Type) *Schema {
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.reflectType(t.Elem())
+	case reflect.Struct:
+		return r.reflectStruct(t)
+	case reflect.Map:
+		return &Schema{
+			Type:                 TypeObject,
+			AdditionalProperties: r.reflectType(t.Elem()),
+		}
+	case reflect.Slice, reflect.Array:
+		return &Schema{
+			Type:  TypeArray,
+			Items: r.reflectType(t.Elem()),
+		}
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	default:
+		// interface{} fields accept any value
+		return &Schema{}
+	}
+}
+
+func (r *Reflector) reflectStruct(t reflect.Type) *Schema {
+
+	schema := &Schema{
+		Type:                 TypeObject,
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: False(),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// unexported fields are not described
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := propertyName(field)
+		if name == "" {
+			continue
+		}
+
+		property, overridden := r.fields[t][field.Name]
+		if !overridden {
+			property = r.reflectType(field.Type)
+		}
+
+		deprecated, replacedBy := deprecation(field)
+		if deprecated {
+			// the property is copied to not annotate a schema shared by several fields
+			annotated := *property
+			annotated.Deprecated = true
+			annotated.ReplacedBy = replacedBy
+			annotated.Description = "Deprecated"
+			if replacedBy != "" {
+				annotated.Description = fmt.Sprintf("Deprecated, use '%s' instead", replacedBy)
+			}
+			property = &annotated
+		}
+
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// propertyName returns the property name defined on the field yaml tag. It returns an empty string when the field is not serialized
+func propertyName(field reflect.StructField) string {
+	tag, defined := field.Tag.Lookup(yamlTagName)
+	if !defined {
+		return ""
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name
+}
+
+// deprecation returns whether the field is deprecated and, when it is defined, the property that replaces it
+func deprecation(field reflect.StructField) (bool, string) {
+	tag, defined := field.Tag.Lookup(TagName)
+	if !defined {
+		return false, ""
+	}
+
+	for _, keyword := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(keyword), "=")
+		if name == deprecatedTagKeyword {
+			return true, value
+		}
+	}
+
+	return false, ""
+}
@@ -0,0 +1,111 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testReflectChild struct {
+	Name string `yaml:"name"`
+}
+
+type testReflectStruct struct {
+	Anything   interface{}            `yaml:"anything"`
+	Children   []*testReflectChild    `yaml:"children"`
+	Enabled    bool                   `yaml:"enabled"`
+	Ignored    string                 `yaml:"-"`
+	Labels     map[string]string      `yaml:"labels"`
+	NotTagged  string                 ``
+	Number     int                    `yaml:"number,omitempty"`
+	OldNumber  int                    `yaml:"old_number" jsonschema:"deprecated=number"`
+	Removed    bool                   `yaml:"removed" jsonschema:"deprecated"`
+	Ratio      float64                `yaml:"ratio"`
+	Vars       map[string]interface{} `yaml:"vars"`
+	Overridden interface{}            `yaml:"overridden"`
+	unexported string
+}
+
+func TestReflect(t *testing.T) {
+
+	overridden := &Schema{
+		AnyOf: []*Schema{
+			{Type: TypeString},
+			{Type: TypeArray, Items: &Schema{Type: TypeString}},
+		},
+	}
+
+	tests := []struct {
+		desc      string
+		reflector *Reflector
+		value     interface{}
+		res       *Schema
+	}{
+		{
+			desc:      "Testing reflect a nil value",
+			reflector: NewReflector(),
+			value:     nil,
+			res:       &Schema{},
+		},
+		{
+			desc:      "Testing reflect a string",
+			reflector: NewReflector(),
+			value:     "",
+			res:       &Schema{Type: TypeString},
+		},
+		{
+			desc:      "Testing reflect a struct",
+			reflector: NewReflector(WithFieldSchema(&testReflectStruct{}, "Overridden", overridden)),
+			value:     &testReflectStruct{},
+			res: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"anything": {},
+					"children": {
+						Type: TypeArray,
+						Items: &Schema{
+							Type: TypeObject,
+							Properties: map[string]*Schema{
+								"name": {Type: TypeString},
+							},
+							AdditionalProperties: False(),
+						},
+					},
+					"enabled": {Type: TypeBoolean},
+					"labels": {
+						Type:                 TypeObject,
+						AdditionalProperties: &Schema{Type: TypeString},
+					},
+					"number": {Type: TypeInteger},
+					"old_number": {
+						Type:        TypeInteger,
+						Description: "Deprecated, use 'number' instead",
+						Deprecated:  true,
+						ReplacedBy:  "number",
+					},
+					"removed": {
+						Type:        TypeBoolean,
+						Description: "Deprecated",
+						Deprecated:  true,
+					},
+					"ratio": {Type: TypeNumber},
+					"vars": {
+						Type:                 TypeObject,
+						AdditionalProperties: &Schema{},
+					},
+					"overridden": overridden,
+				},
+				AdditionalProperties: False(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := test.reflector.Reflect(test.value)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
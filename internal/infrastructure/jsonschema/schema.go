@@ -0,0 +1,127 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	// Draft is the JSON Schema dialect of the schemas
+	Draft = "https://json-schema.org/draft/2020-12/schema"
+
+	// TypeArray is the JSON Schema array type
+	TypeArray = "array"
+	// TypeBoolean is the JSON Schema boolean type
+	TypeBoolean = "boolean"
+	// TypeInteger is the JSON Schema integer type
+	TypeInteger = "integer"
+	// TypeNumber is the JSON Schema number type
+	TypeNumber = "number"
+	// TypeObject is the JSON Schema object type
+	TypeObject = "object"
+	// TypeString is the JSON Schema string type
+	TypeString = "string"
+)
+
+// Schema is the subset of the JSON Schema vocabulary used to describe the stevedore files
+type Schema struct {
+	// Schema is the JSON Schema dialect
+	Schema string `json:"$schema,omitempty"`
+	// ID is the schema identifier
+	ID string `json:"$id,omitempty"`
+	// Title is the schema title
+	Title string `json:"title,omitempty"`
+	// Description describes the schema
+	Description string `json:"description,omitempty"`
+	// Type is the type of the values that validate against the schema. Any type is valid when it is empty
+	Type string `json:"type,omitempty"`
+	// Properties are the schemas of the object properties
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is the schema of the object properties not defined on Properties
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	// PropertyNames is the schema of the object property names
+	PropertyNames *Schema `json:"propertyNames,omitempty"`
+	// Items is the schema of the array items
+	Items *Schema `json:"items,omitempty"`
+	// AnyOf are the schemas that a value could validate against
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	// Pattern is the regular expression that a string must match
+	Pattern string `json:"pattern,omitempty"`
+	// Deprecated is true when the property is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+	// ReplacedBy is the property that replaces a deprecated property
+	ReplacedBy string `json:"x-replaced-by,omitempty"`
+
+	// never is true for the schema that no value validates against
+	never bool
+}
+
+// False returns the schema that no value validates against. It is marshaled as 'false'
+func False() *Schema {
+	return &Schema{
+		never: true,
+	}
+}
+
+// IsFalse returns whether no value validates against the schema
+func (s *Schema) IsFalse() bool {
+	if s == nil {
+		return false
+	}
+
+	return s.never
+}
+
+// PropertiesNames returns the object properties names sorted alphabetically
+func (s *Schema) PropertiesNames() []string {
+	names := []string{}
+
+	if s == nil {
+		return names
+	}
+
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// MarshalJSON marshals the schema to JSON
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.never {
+		return []byte("false"), nil
+	}
+
+	// schema is an alias of Schema without the MarshalJSON method, to not get into an infinite loop
+	type schema Schema
+
+	return json.Marshal((*schema)(s))
+}
+
+// Merge returns an object schema whose properties are the properties of all the schemas. It is used to validate the files that contain several kinds of definitions, such as a stevedore.yaml that defines the configuration, builders and images
+func Merge(schemas ...*Schema) *Schema {
+
+	if len(schemas) == 1 {
+		return schemas[0]
+	}
+
+	merged := &Schema{
+		Type:                 TypeObject,
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: False(),
+	}
+
+	for _, s := range schemas {
+		if s == nil {
+			continue
+		}
+
+		for name, property := range s.Properties {
+			merged.Properties[name] = property
+		}
+	}
+
+	return merged
+}
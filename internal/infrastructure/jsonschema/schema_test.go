@@ -0,0 +1,104 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		desc   string
+		schema *Schema
+		res    string
+	}{
+		{
+			desc:   "Testing marshal the false schema",
+			schema: False(),
+			res:    `false`,
+		},
+		{
+			desc:   "Testing marshal an empty schema",
+			schema: &Schema{},
+			res:    `{}`,
+		},
+		{
+			desc: "Testing marshal an object schema",
+			schema: &Schema{
+				Schema: Draft,
+				Type:   TypeObject,
+				Properties: map[string]*Schema{
+					"name": {Type: TypeString},
+					"old_name": {
+						Type:       TypeString,
+						Deprecated: true,
+						ReplacedBy: "name",
+					},
+				},
+				AdditionalProperties: False(),
+			},
+			res: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{"name":{"type":"string"},"old_name":{"type":"string","deprecated":true,"x-replaced-by":"name"}},"additionalProperties":false}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := json.Marshal(test.schema)
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, string(res))
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	first := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"builders": {Type: TypeObject},
+		},
+		AdditionalProperties: False(),
+	}
+
+	second := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"images": {Type: TypeObject},
+		},
+		AdditionalProperties: False(),
+	}
+
+	tests := []struct {
+		desc    string
+		schemas []*Schema
+		res     *Schema
+	}{
+		{
+			desc:    "Testing merge a single schema",
+			schemas: []*Schema{first},
+			res:     first,
+		},
+		{
+			desc:    "Testing merge several schemas",
+			schemas: []*Schema{first, nil, second},
+			res: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"builders": {Type: TypeObject},
+					"images":   {Type: TypeObject},
+				},
+				AdditionalProperties: False(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, Merge(test.schemas...))
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// nullType is the type of the YAML null values, which are valid for any schema because they are decoded to the zero value
+	nullType = "null"
+	// mergeKey is the YAML key that merges a mapping into another
+	mergeKey = "<<"
+	// defaultPropertyNamesTitle describes the property names when the property names schema does not define a title
+	defaultPropertyNamesTitle = "key"
+)
+
+// Validate validates a YAML node against the schema and returns the issues found. The issues do not define the file where they are found
+func Validate(schema *Schema, node *yaml.Node) []*validation.Issue {
+	v := &nodeValidator{
+		issues: []*validation.Issue{},
+	}
+
+	v.validate(schema, node, "")
+
+	return v.issues
+}
+
+// nodeValidator walks a YAML node and collects the issues found
+type nodeValidator struct {
+	issues []*validation.Issue
+}
+
+func (v *nodeValidator) validate(schema *Schema, node *yaml.Node, path string) {
+
+	if schema == nil || node == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			v.validate(schema, content, path)
+		}
+		return
+	case yaml.AliasNode:
+		v.validate(schema, node.Alias, path)
+		return
+	}
+
+	if nodeType(node) == nullType {
+		return
+	}
+
+	if len(schema.AnyOf) > 0 {
+		for _, s := range schema.AnyOf {
+			if matchesType(s.Type, node) {
+				v.validate(s, node, path)
+				return
+			}
+		}
+
+		types := []string{}
+		for _, s := range schema.AnyOf {
+			types = append(types, s.Type)
+		}
+		v.addError(node, path, invalidTypeMessage(path, strings.Join(types, " or "), nodeType(node)))
+		return
+	}
+
+	if !matchesType(schema.Type, node) {
+		v.addError(node, path, invalidTypeMessage(path, schema.Type, nodeType(node)))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(schema, node, path)
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case yaml.ScalarNode:
+		if !matchesPattern(schema.Pattern, node.Value) {
+			v.addError(node, path, fmt.Sprintf("Invalid value '%s' for '%s', it must match the pattern '%s'", node.Value, path, schema.Pattern))
+		}
+	}
+}
+
+func (v *nodeValidator) validateMapping(schema *Schema, node *yaml.Node, path string) {
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		value := node.Content[i+1]
+
+		if key.Value == mergeKey {
+			v.validateMerge(schema, value, path)
+			continue
+		}
+
+		keyPath := joinPath(path, key.Value)
+
+		if schema.PropertyNames != nil && !matchesPattern(schema.PropertyNames.Pattern, key.Value) {
+			title := schema.PropertyNames.Title
+			if title == "" {
+				title = defaultPropertyNamesTitle
+			}
+			v.addError(key, keyPath, fmt.Sprintf("Invalid %s '%s', it must match the pattern '%s'", title, key.Value, schema.PropertyNames.Pattern))
+		}
+
+		property, defined := schema.Properties[key.Value]
+		if !defined {
+			property = schema.AdditionalProperties
+
+			if property.IsFalse() {
+				v.addError(key, keyPath, unknownKeyMessage(key.Value, schema.PropertiesNames()))
+				continue
+			}
+		}
+
+		if property != nil && property.Deprecated {
+			v.addWarning(key, keyPath, deprecatedMessage(key.Value, property.ReplacedBy))
+		}
+
+		v.validate(property, value, keyPath)
+	}
+}
+
+// validateMerge validates the mappings merged by the '<<' key, which could be a mapping or a list of mappings
+func (v *nodeValidator) validateMerge(schema *Schema, node *yaml.Node, path string) {
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			v.validate(schema, item, path)
+		}
+		return
+	}
+
+	v.validate(schema, node, path)
+}
+
+func (v *nodeValidator) addError(node *yaml.Node, path, message string) {
+	v.addIssue(node, path, validation.SeverityError, message)
+}
+
+func (v *nodeValidator) addWarning(node *yaml.Node, path, message string) {
+	v.addIssue(node, path, validation.SeverityWarning, message)
+}
+
+func (v *nodeValidator) addIssue(node *yaml.Node, path, severity, message string) {
+	v.issues = append(v.issues, &validation.Issue{
+		Line:     node.Line,
+		Column:   node.Column,
+		Path:     path,
+		Severity: severity,
+		Message:  message,
+	})
+}
+
+// nodeType returns the JSON Schema type of a YAML node
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return TypeObject
+	case yaml.SequenceNode:
+		return TypeArray
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nullType
+	case "!!bool":
+		return TypeBoolean
+	case "!!int":
+		return TypeInteger
+	case "!!float":
+		return TypeNumber
+	default:
+		return TypeString
+	}
+}
+
+// matchesType returns whether a YAML node could be decoded to a value of the type. Any scalar could be decoded to a string
+func matchesType(t string, node *yaml.Node) bool {
+	found := nodeType(node)
+
+	switch t {
+	case "":
+		return true
+	case TypeString:
+		return node.Kind == yaml.ScalarNode
+	case TypeNumber:
+		return found == TypeNumber || found == TypeInteger
+	default:
+		return found == t
+	}
+}
+
+// matchesPattern returns whether the value matches the pattern. Any value matches an empty or invalid pattern
+func matchesPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return true
+	}
+
+	return re.MatchString(value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return strings.Join([]string{path, key}, ".")
+}
+
+func invalidTypeMessage(path, expected, found string) string {
+	if path == "" {
+		return fmt.Sprintf("Invalid document type, expected %s but found %s", expected, found)
+	}
+
+	return fmt.Sprintf("Invalid type for '%s', expected %s but found %s", path, expected, found)
+}
+
+func deprecatedMessage(key, replacedBy string) string {
+	if replacedBy == "" {
+		return fmt.Sprintf("'%s' is deprecated", key)
+	}
+
+	return fmt.Sprintf("'%s' is deprecated, use '%s' instead", key, replacedBy)
+}
+
+// unknownKeyMessage returns the message for an unknown key, suggesting the closest known key when the unknown key looks like a typo
+func unknownKeyMessage(key string, known []string) string {
+	message := fmt.Sprintf("Unknown key '%s'", key)
+
+	suggestion := ""
+	maxDistance := len(key) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	for _, candidate := range known {
+		distance := levenshtein(key, candidate)
+		if distance <= maxDistance {
+			suggestion = candidate
+			maxDistance = distance - 1
+		}
+	}
+
+	if suggestion != "" {
+		message = fmt.Sprintf("%s, did you mean '%s'?", message, suggestion)
+	}
+
+	return message
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,198 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate(t *testing.T) {
+
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"concurrency": {Type: TypeInteger},
+			"num_workers": {Type: TypeInteger, Deprecated: true, ReplacedBy: "concurrency"},
+			"push_images": {Type: TypeBoolean},
+			"images": {
+				Type: TypeObject,
+				PropertyNames: &Schema{
+					Title:   "image name",
+					Pattern: "^[^:]+$",
+				},
+				AdditionalProperties: &Schema{
+					Type: TypeObject,
+					Properties: map[string]*Schema{
+						"persistent_vars": {Type: TypeObject, AdditionalProperties: &Schema{}},
+						"tags":            {Type: TypeArray, Items: &Schema{Type: TypeString}},
+						"builder": {
+							AnyOf: []*Schema{
+								{Type: TypeString},
+								{Type: TypeObject, Properties: map[string]*Schema{"driver": {Type: TypeString}}, AdditionalProperties: False()},
+							},
+						},
+					},
+					AdditionalProperties: False(),
+				},
+			},
+		},
+		AdditionalProperties: False(),
+	}
+
+	tests := []struct {
+		desc     string
+		schema   *Schema
+		document string
+		res      []*validation.Issue
+	}{
+		{
+			desc:   "Testing validate a valid document",
+			schema: schema,
+			document: `
+concurrency: 4
+push_images: true
+images:
+  ubuntu:
+    persistent_vars:
+      key: value
+    tags:
+      - 1.0
+      - latest
+    builder: infrastructure
+  alpine:
+    builder:
+      driver: docker
+`,
+			res: []*validation.Issue{},
+		},
+		{
+			desc:     "Testing validate an empty document",
+			schema:   schema,
+			document: ``,
+			res:      []*validation.Issue{},
+		},
+		{
+			desc:     "Testing validate a document that is not an object",
+			schema:   schema,
+			document: `- concurrency`,
+			res: []*validation.Issue{
+				{Line: 1, Column: 1, Severity: validation.SeverityError, Message: "Invalid document type, expected object but found array"},
+			},
+		},
+		{
+			desc:   "Testing validate unknown keys",
+			schema: schema,
+			document: `
+concurrency: 4
+images:
+  ubuntu:
+    persistant_vars:
+      key: value
+unknown: value
+`,
+			res: []*validation.Issue{
+				{Line: 5, Column: 5, Path: "images.ubuntu.persistant_vars", Severity: validation.SeverityError, Message: "Unknown key 'persistant_vars', did you mean 'persistent_vars'?"},
+				{Line: 7, Column: 1, Path: "unknown", Severity: validation.SeverityError, Message: "Unknown key 'unknown'"},
+			},
+		},
+		{
+			desc:   "Testing validate wrong types",
+			schema: schema,
+			document: `
+concurrency: four
+push_images: "true"
+images:
+  ubuntu:
+    tags: latest
+    builder:
+      - docker
+`,
+			res: []*validation.Issue{
+				{Line: 2, Column: 14, Path: "concurrency", Severity: validation.SeverityError, Message: "Invalid type for 'concurrency', expected integer but found string"},
+				{Line: 3, Column: 14, Path: "push_images", Severity: validation.SeverityError, Message: "Invalid type for 'push_images', expected boolean but found string"},
+				{Line: 6, Column: 11, Path: "images.ubuntu.tags", Severity: validation.SeverityError, Message: "Invalid type for 'images.ubuntu.tags', expected array but found string"},
+				{Line: 8, Column: 7, Path: "images.ubuntu.builder", Severity: validation.SeverityError, Message: "Invalid type for 'images.ubuntu.builder', expected string or object but found array"},
+			},
+		},
+		{
+			desc:   "Testing validate deprecated keys",
+			schema: schema,
+			document: `
+num_workers: 4
+`,
+			res: []*validation.Issue{
+				{Line: 2, Column: 1, Path: "num_workers", Severity: validation.SeverityWarning, Message: "'num_workers' is deprecated, use 'concurrency' instead"},
+			},
+		},
+		{
+			desc:   "Testing validate invalid property names",
+			schema: schema,
+			document: `
+images:
+  ubuntu:22.04:
+`,
+			res: []*validation.Issue{
+				{Line: 3, Column: 3, Path: "images.ubuntu:22.04", Severity: validation.SeverityError, Message: "Invalid image name 'ubuntu:22.04', it must match the pattern '^[^:]+$'"},
+			},
+		},
+		{
+			desc:   "Testing validate merged mappings",
+			schema: schema,
+			document: `
+images:
+  base: &base
+    tags:
+      - latest
+  ubuntu:
+    <<: *base
+    builder: docker
+`,
+			res: []*validation.Issue{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			node := &yaml.Node{}
+			err := yaml.Unmarshal([]byte(test.document), node)
+			assert.NoError(t, err)
+
+			res := Validate(test.schema, node)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestUnknownKeyMessage(t *testing.T) {
+	tests := []struct {
+		desc  string
+		key   string
+		known []string
+		res   string
+	}{
+		{
+			desc:  "Testing unknown key message suggesting the closest key",
+			key:   "persistant_vars",
+			known: []string{"persistent_labels", "persistent_vars", "vars"},
+			res:   "Unknown key 'persistant_vars', did you mean 'persistent_vars'?",
+		},
+		{
+			desc:  "Testing unknown key message without suggestion",
+			key:   "foo",
+			known: []string{"persistent_labels", "persistent_vars", "vars"},
+			res:   "Unknown key 'foo'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, unknownKeyMessage(test.key, test.known))
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package credentialhelper
+
+// OutputWriter is the interface to write the credentials helper responses
+type OutputWriter interface {
+	Write(data []byte) (int, error)
+}
@@ -0,0 +1,27 @@
+package credentialhelper
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOutput is a mock of the credentials helper output
+type MockOutput struct {
+	mock.Mock
+}
+
+// NewMockOutput creates a new MockOutput
+func NewMockOutput() *MockOutput {
+	return &MockOutput{}
+}
+
+// PrintCredentials provides a mock function with given fields: serverURL, username, secret
+func (o *MockOutput) PrintCredentials(serverURL, username, secret string) error {
+	args := o.Mock.Called(serverURL, username, secret)
+	return args.Error(0)
+}
+
+// PrintList provides a mock function with given fields: list
+func (o *MockOutput) PrintList(list map[string]string) error {
+	args := o.Mock.Called(list)
+	return args.Error(0)
+}
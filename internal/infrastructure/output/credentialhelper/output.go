@@ -0,0 +1,81 @@
+package credentialhelper
+
+import (
+	"encoding/json"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// credentialsResponse is the Docker credentials helpers protocol response for the get action
+type credentialsResponse struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// Output prints the credentials helper responses encoded as the Docker credentials helpers protocol expects
+type Output struct {
+	write OutputWriter
+}
+
+// NewOutput creates a new Output
+func NewOutput(write OutputWriter) *Output {
+	return &Output{
+		write: write,
+	}
+}
+
+// PrintCredentials prints the credentials for a server
+func (o *Output) PrintCredentials(serverURL, username, secret string) error {
+
+	errContext := "(output::credentialhelper::Output::PrintCredentials)"
+
+	err := o.print(&credentialsResponse{
+		ServerURL: serverURL,
+		Username:  username,
+		Secret:    secret,
+	})
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// PrintList prints the servers and usernames of the credentials
+func (o *Output) PrintList(list map[string]string) error {
+
+	errContext := "(output::credentialhelper::Output::PrintList)"
+
+	if list == nil {
+		list = map[string]string{}
+	}
+
+	err := o.print(list)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+func (o *Output) print(content interface{}) error {
+
+	errContext := "(output::credentialhelper::Output::print)"
+
+	if o.write == nil {
+		return errors.New(errContext, "To print credentials helper responses, you must provide a writer")
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return errors.New(errContext, "Error encoding the credentials helper response", err)
+	}
+
+	_, err = o.write.Write(append(data, '\n'))
+	if err != nil {
+		return errors.New(errContext, "Error writing the credentials helper response", err)
+	}
+
+	return nil
+}
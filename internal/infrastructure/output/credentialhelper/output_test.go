@@ -0,0 +1,88 @@
+package credentialhelper
+
+import (
+	"bytes"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintCredentials(t *testing.T) {
+	errContext := "(output::credentialhelper::Output::print)"
+
+	tests := []struct {
+		desc      string
+		output    *Output
+		serverURL string
+		username  string
+		secret    string
+		res       string
+		err       error
+	}{
+		{
+			desc:   "Testing error printing credentials without writer",
+			output: NewOutput(nil),
+			err:    errors.New(errContext, "To print credentials helper responses, you must provide a writer"),
+		},
+		{
+			desc:      "Testing print credentials",
+			output:    NewOutput(&bytes.Buffer{}),
+			serverURL: "https://registry.example.com",
+			username:  "username",
+			secret:    "secret",
+			res:       "{\"ServerURL\":\"https://registry.example.com\",\"Username\":\"username\",\"Secret\":\"secret\"}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.output.PrintCredentials(test.serverURL, test.username, test.secret)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.output.write.(*bytes.Buffer).String())
+			}
+		})
+	}
+}
+
+func TestPrintList(t *testing.T) {
+	tests := []struct {
+		desc   string
+		output *Output
+		list   map[string]string
+		res    string
+		err    error
+	}{
+		{
+			desc:   "Testing print an empty list",
+			output: NewOutput(&bytes.Buffer{}),
+			res:    "{}\n",
+		},
+		{
+			desc:   "Testing print list",
+			output: NewOutput(&bytes.Buffer{}),
+			list: map[string]string{
+				"registry.example.com": "username",
+				"docker.io":            "user",
+			},
+			res: "{\"docker.io\":\"user\",\"registry.example.com\":\"username\"}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.output.PrintList(test.list)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.output.write.(*bytes.Buffer).String())
+			}
+		})
+	}
+}
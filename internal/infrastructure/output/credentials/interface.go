@@ -8,4 +8,5 @@ type Outputter interface {
 
 type OutputWriter interface {
 	PrintTable(content [][]string) error
+	Write(p []byte) (int, error)
 }
@@ -1,14 +1,41 @@
 package credentials
 
 import (
+	"encoding/json"
+	"strings"
+
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// TableFormat prints the credentials as a table
+	TableFormat = "table"
+	// JSONFormat prints the credentials as a JSON list
+	JSONFormat = "json"
+	// YAMLFormat prints the credentials as a YAML list
+	YAMLFormat = "yaml"
 )
 
+// OptionsFunc defines the signature for an option function to set output attributes
+type OptionsFunc func(o *Output)
+
+// credentialItem is the representation of a credential on the structured formats
+type credentialItem struct {
+	ID          string `json:"id" yaml:"id"`
+	Type        string `json:"type" yaml:"type"`
+	Credentials string `json:"credentials" yaml:"credentials"`
+	ExpiresAt   string `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
 // Output is an output for the builders
 type Output struct {
 	write   OutputWriter
 	methods []Outputter
+	format  string
+	types   []string
 }
 
 // NewOutput creates a new Output
@@ -16,20 +43,64 @@ func NewOutput(write OutputWriter, output ...Outputter) *Output {
 	return &Output{
 		write:   write,
 		methods: output,
+		format:  TableFormat,
+	}
+}
+
+// WithFormat sets the format used to print the credentials
+func WithFormat(format string) OptionsFunc {
+	return func(o *Output) {
+		o.format = format
+	}
+}
+
+// WithTypes sets the credentials types to print. When no types are set, all credentials are printed
+func WithTypes(types ...string) OptionsFunc {
+	return func(o *Output) {
+		o.types = append([]string{}, types...)
+	}
+}
+
+// Options provides the options for the output
+func (o *Output) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(o)
 	}
 }
 
 // Output prints the credentials
 func (o *Output) Print(credentials []*credentials.Credential) error {
 
+	var err error
+
 	errContext := "(output::credentials::Output::PrintAll)"
 
 	if o.write == nil {
 		return errors.New(errContext, "To print credentials, you must provide a writer")
 	}
 
-	content := [][]string{}
-	content = append(content, []string{"ID", "TYPE", "CREDENTIALS"})
+	items := o.items(credentials)
+
+	switch o.format {
+	case "", TableFormat:
+		err = o.printTable(items)
+	case JSONFormat:
+		err = o.printJSON(items)
+	case YAMLFormat:
+		err = o.printYAML(items)
+	default:
+		return errors.New(errContext, "Output format '"+o.format+"' is not supported. Supported formats are: "+strings.Join([]string{TableFormat, JSONFormat, YAMLFormat}, ", "))
+	}
+	if err != nil {
+		return errors.New(errContext, "error printing credentials.", err)
+	}
+
+	return nil
+}
+
+// items returns the credentials to print, filtered by type
+func (o *Output) items(credentials []*credentials.Credential) []*credentialItem {
+	items := []*credentialItem{}
 
 	for _, credential := range credentials {
 		for _, method := range o.methods {
@@ -39,16 +110,69 @@ func (o *Output) Print(credentials []*credentials.Credential) error {
 			}
 
 			if detail != "" && credentialsType != "" {
-				content = append(content, []string{credential.ID, credentialsType, detail})
+				if o.matchType(credentialsType) {
+					items = append(items, &credentialItem{
+						ID:          credential.ID,
+						Type:        credentialsType,
+						Credentials: detail,
+						ExpiresAt:   credential.ExpiresAt,
+						Description: credential.Description,
+					})
+				}
 				break
 			}
 		}
 	}
 
-	err := o.write.PrintTable(content)
+	return items
+}
+
+// matchType returns true when the credentials type is one of the types to print. Types are compared case insensitive and spaces could be written as dashes, i.e. 'aws-role-arn' matches 'AWS role arn'
+func (o *Output) matchType(credentialsType string) bool {
+	if len(o.types) == 0 {
+		return true
+	}
+
+	for _, t := range o.types {
+		if normalizeType(t) == normalizeType(credentialsType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeType(t string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(t)), " ", "-")
+}
+
+func (o *Output) printTable(items []*credentialItem) error {
+	content := [][]string{}
+	content = append(content, []string{"ID", "TYPE", "CREDENTIALS", "EXPIRES AT", "DESCRIPTION"})
+
+	for _, item := range items {
+		content = append(content, []string{item.ID, item.Type, item.Credentials, item.ExpiresAt, item.Description})
+	}
+
+	return o.write.PrintTable(content)
+}
+
+func (o *Output) printJSON(items []*credentialItem) error {
+	content, err := json.MarshalIndent(items, "", "  ")
 	if err != nil {
-		return errors.New(errContext, "error printing credentials.", err)
+		return err
 	}
 
-	return nil
+	_, err = o.write.Write(append(content, '\n'))
+	return err
+}
+
+func (o *Output) printYAML(items []*credentialItem) error {
+	content, err := yaml.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.write.Write(content)
+	return err
 }
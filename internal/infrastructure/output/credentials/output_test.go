@@ -3,9 +3,12 @@ package credentials
 import (
 	"testing"
 
+	errors "github.com/apenella/go-common-utils/error"
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
 	write "github.com/gostevedore/stevedore/internal/infrastructure/console"
 	output "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/mock"
+	"github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/token"
+	usernamepassword "github.com/gostevedore/stevedore/internal/infrastructure/output/credentials/types/usernamePassword"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -41,11 +44,167 @@ func TestPrintAll(t *testing.T) {
 				method := o.methods[0]
 				method.(*output.MockOutput).On("Output", mock.Anything).Return("type", "details", nil)
 				o.write.(*write.MockConsole).On("PrintTable", [][]string{
-					{"ID", "TYPE", "CREDENTIALS"},
-					{"id", "type", "details"},
+					{"ID", "TYPE", "CREDENTIALS", "EXPIRES AT", "DESCRIPTION"},
+					{"id", "type", "details", "", ""},
 				}).Return(nil)
 			},
 		},
+		{
+			desc: "Testing output for credentials with expiration and description",
+			output: &Output{
+				methods: []Outputter{
+					output.NewMockOutput(),
+				},
+				write: write.NewMockConsole(),
+			},
+			credentials: []*credentials.Credential{
+				{
+					ID:          "id",
+					Username:    "username",
+					Password:    "password",
+					ExpiresAt:   "2024-12-31T00:00:00Z",
+					Description: "registry robot account",
+				},
+			},
+			prepareAssertFunc: func(o *Output) {
+				method := o.methods[0]
+				method.(*output.MockOutput).On("Output", mock.Anything).Return("type", "details", nil)
+				o.write.(*write.MockConsole).On("PrintTable", [][]string{
+					{"ID", "TYPE", "CREDENTIALS", "EXPIRES AT", "DESCRIPTION"},
+					{"id", "type", "details", "2024-12-31T00:00:00Z", "registry robot account"},
+				}).Return(nil)
+			},
+		},
+		{
+			desc: "Testing output for credentials filtered by type",
+			output: &Output{
+				methods: []Outputter{
+					output.NewMockOutput(),
+				},
+				write: write.NewMockConsole(),
+				types: []string{"aws-role-arn"},
+			},
+			credentials: []*credentials.Credential{
+				{
+					ID:       "id",
+					Username: "username",
+					Password: "password",
+				},
+				{
+					ID:         "aws",
+					AWSRoleARN: "role-arn",
+				},
+			},
+			prepareAssertFunc: func(o *Output) {
+				method := o.methods[0]
+				method.(*output.MockOutput).On("Output", &credentials.Credential{ID: "id", Username: "username", Password: "password"}).Return("Username-password", "details", nil)
+				method.(*output.MockOutput).On("Output", &credentials.Credential{ID: "aws", AWSRoleARN: "role-arn"}).Return("AWS role arn", "role details", nil)
+				o.write.(*write.MockConsole).On("PrintTable", [][]string{
+					{"ID", "TYPE", "CREDENTIALS", "EXPIRES AT", "DESCRIPTION"},
+					{"aws", "AWS role arn", "role details", "", ""},
+				}).Return(nil)
+			},
+		},
+		{
+			desc: "Testing output for credentials that only define tokens",
+			output: &Output{
+				methods: []Outputter{
+					usernamepassword.NewUsernamePasswordOutput(),
+					token.NewTokenOutput(),
+				},
+				write: write.NewMockConsole(),
+			},
+			credentials: []*credentials.Credential{
+				{
+					ID:       "basic",
+					Username: "username",
+					Password: "password",
+				},
+				{
+					ID:    "token",
+					Token: "token",
+				},
+				{
+					ID:           "refresh-token",
+					Username:     "<token>",
+					RefreshToken: "refresh-token",
+				},
+			},
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("PrintTable", [][]string{
+					{"ID", "TYPE", "CREDENTIALS", "EXPIRES AT", "DESCRIPTION"},
+					{"basic", usernamepassword.UsernamePasswordType, "username=username", "", ""},
+					{"token", token.TokenType, "token=<redacted>", "", ""},
+					{"refresh-token", token.TokenType, "username=<token>, refresh_token=<redacted>", "", ""},
+				}).Return(nil)
+			},
+		},
+		{
+			desc: "Testing output for credentials in json format",
+			output: &Output{
+				methods: []Outputter{
+					output.NewMockOutput(),
+				},
+				write:  write.NewMockConsole(),
+				format: JSONFormat,
+			},
+			credentials: []*credentials.Credential{
+				{
+					ID:       "id",
+					Username: "username",
+					Password: "password",
+				},
+			},
+			prepareAssertFunc: func(o *Output) {
+				method := o.methods[0]
+				method.(*output.MockOutput).On("Output", mock.Anything).Return("type", "details", nil)
+				o.write.(*write.MockConsole).On("Write", []byte(`[
+  {
+    "id": "id",
+    "type": "type",
+    "credentials": "details"
+  }
+]
+`)).Return(0, nil)
+			},
+		},
+		{
+			desc: "Testing output for credentials in yaml format",
+			output: &Output{
+				methods: []Outputter{
+					output.NewMockOutput(),
+				},
+				write:  write.NewMockConsole(),
+				format: YAMLFormat,
+			},
+			credentials: []*credentials.Credential{
+				{
+					ID:       "id",
+					Username: "username",
+					Password: "password",
+				},
+			},
+			prepareAssertFunc: func(o *Output) {
+				method := o.methods[0]
+				method.(*output.MockOutput).On("Output", mock.Anything).Return("type", "details", nil)
+				o.write.(*write.MockConsole).On("Write", []byte(`- id: id
+  type: type
+  credentials: details
+`)).Return(0, nil)
+			},
+		},
+		{
+			desc: "Testing error printing credentials with an unsupported format",
+			output: &Output{
+				methods: []Outputter{
+					output.NewMockOutput(),
+				},
+				write:  write.NewMockConsole(),
+				format: "unknown",
+			},
+			credentials: []*credentials.Credential{},
+			err:         errors.New("(output::credentials::Output::PrintAll)", "Output format 'unknown' is not supported. Supported formats are: table, json, yaml"),
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,34 @@
+package credentialprocess
+
+import (
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+const (
+	// CredentialProcessType is the name of the credential process authentication type
+	CredentialProcessType = "Credential process"
+)
+
+type CredentialProcessOutput struct{}
+
+func NewCredentialProcessOutput() *CredentialProcessOutput {
+	return &CredentialProcessOutput{}
+}
+
+func (o *CredentialProcessOutput) Output(credential *credentials.Credential) (string, string, error) {
+
+	errContext := "(output::credentials::types::CredentialProcessOutput::Output)"
+
+	if credential == nil {
+		return "", "", errors.New(errContext, "To show credential output, credential must be provided")
+	}
+
+	if credential.CredentialProcess != "" {
+		return CredentialProcessType, fmt.Sprintf("credential_process=%s", credential.CredentialProcess), nil
+	} else {
+		return "", "", nil
+	}
+}
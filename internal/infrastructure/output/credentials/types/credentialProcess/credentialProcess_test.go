@@ -0,0 +1,66 @@
+package credentialprocess
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+
+	errContext := "(output::credentials::types::CredentialProcessOutput::Output)"
+
+	tests := []struct {
+		desc            string
+		output          *CredentialProcessOutput
+		credential      *credentials.Credential
+		detail          string
+		credentialsType string
+		err             error
+	}{
+		{
+			desc:            "Testing error when creating the output for CredentialProcessOutput and credential is nil",
+			output:          NewCredentialProcessOutput(),
+			credential:      nil,
+			detail:          "",
+			credentialsType: "",
+			err:             errors.New(errContext, "To show credential output, credential must be provided"),
+		},
+		{
+			desc:   "Testing generate output for CredentialProcessOutput",
+			output: NewCredentialProcessOutput(),
+			credential: &credentials.Credential{
+				CredentialProcess: "credential-broker registry.example.com",
+			},
+			detail:          "credential_process=credential-broker registry.example.com",
+			credentialsType: CredentialProcessType,
+			err:             &errors.Error{},
+		},
+		{
+			desc:   "Testing generate empty output for CredentialProcessOutput when credential has no credential process",
+			output: NewCredentialProcessOutput(),
+			credential: &credentials.Credential{
+				Username: "username",
+			},
+			detail:          "",
+			credentialsType: "",
+			err:             &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			credentialsType, detail, err := test.output.Output(test.credential)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.credentialsType, credentialsType)
+				assert.Equal(t, test.detail, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+const (
+	// TokenType is the name of the token authentication type
+	TokenType = "Token"
+	// RedactedValue is shown instead of the tokens
+	RedactedValue = "<redacted>"
+)
+
+// TokenOutput shows the credentials that authenticate using a token or a refresh token, whose values are redacted
+type TokenOutput struct{}
+
+// NewTokenOutput returns a new token credentials output
+func NewTokenOutput() *TokenOutput {
+	return &TokenOutput{}
+}
+
+// Output returns the token authentication type and the credential details. It returns empty values when the credential has neither a token nor a refresh token
+func (o *TokenOutput) Output(credential *credentials.Credential) (string, string, error) {
+
+	errContext := "(output::credentials::types::TokenOutput::Output)"
+
+	if credential == nil {
+		return "", "", errors.New(errContext, "To show credential output, credential must be provided")
+	}
+
+	if credential.Token == "" && credential.RefreshToken == "" {
+		return "", "", nil
+	}
+
+	return TokenType, details(credential, RedactedValue), nil
+}
+
+// details returns the credential username, when it is defined, along with the tokens. When redacted is not empty, it is shown instead of the tokens values
+func details(credential *credentials.Credential, redacted string) string {
+
+	show := func(value string) string {
+		if redacted != "" {
+			return redacted
+		}
+		return value
+	}
+
+	items := []string{}
+	if credential.Username != "" {
+		items = append(items, fmt.Sprintf("username=%s", credential.Username))
+	}
+
+	if credential.Token != "" {
+		items = append(items, fmt.Sprintf("token=%s", show(credential.Token)))
+	}
+
+	if credential.RefreshToken != "" {
+		items = append(items, fmt.Sprintf("refresh_token=%s", show(credential.RefreshToken)))
+	}
+
+	return strings.Join(items, ", ")
+}
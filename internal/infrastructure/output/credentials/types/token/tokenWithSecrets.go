@@ -0,0 +1,38 @@
+package token
+
+import (
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+// TokenWithSecretsOutput shows the credentials that authenticate using a token or a refresh token, along with the tokens values
+type TokenWithSecretsOutput struct {
+	output *TokenOutput
+}
+
+// NewTokenWithSecretsOutput returns a new token credentials output that shows the tokens values
+func NewTokenWithSecretsOutput(o *TokenOutput) *TokenWithSecretsOutput {
+	return &TokenWithSecretsOutput{
+		output: o,
+	}
+}
+
+// Output returns the token authentication type and the credential details, including the tokens values
+func (o *TokenWithSecretsOutput) Output(credential *credentials.Credential) (string, string, error) {
+	errContext := "(output::credentials::types::TokenWithSecretsOutput::Output)"
+
+	if o.output == nil {
+		return "", "", errors.New(errContext, "Token with secret output requieres an output")
+	}
+
+	credentialType, _, err := o.output.Output(credential)
+	if err != nil {
+		return "", "", errors.New(errContext, "", err)
+	}
+
+	if credentialType == "" {
+		return "", "", nil
+	}
+
+	return credentialType, details(credential, ""), nil
+}
@@ -0,0 +1,72 @@
+package token
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputWithSecret(t *testing.T) {
+
+	errContext := "(output::credentials::types::TokenWithSecretsOutput::Output)"
+
+	tests := []struct {
+		desc            string
+		output          *TokenWithSecretsOutput
+		credential      *credentials.Credential
+		detail          string
+		credentialsType string
+		err             error
+	}{
+		{
+			desc:            "Testing error when creating the output for TokenWithSecretsOutput and output is nil",
+			output:          NewTokenWithSecretsOutput(nil),
+			credential:      nil,
+			detail:          "",
+			credentialsType: "",
+			err:             errors.New(errContext, "Token with secret output requieres an output"),
+		},
+		{
+			desc: "Testing generate output for TokenWithSecretsOutput",
+			output: NewTokenWithSecretsOutput(
+				NewTokenOutput(),
+			),
+			credential: &credentials.Credential{
+				Username:     "robot",
+				Token:        "token",
+				RefreshToken: "refresh-token",
+			},
+			detail:          "username=robot, token=token, refresh_token=refresh-token",
+			credentialsType: TokenType,
+			err:             &errors.Error{},
+		},
+		{
+			desc: "Testing generate output for TokenWithSecretsOutput without username",
+			output: NewTokenWithSecretsOutput(
+				NewTokenOutput(),
+			),
+			credential: &credentials.Credential{
+				RefreshToken: "refresh-token",
+			},
+			detail:          "refresh_token=refresh-token",
+			credentialsType: TokenType,
+			err:             &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			credentialsType, detail, err := test.output.Output(test.credential)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.credentialsType, credentialsType)
+				assert.Equal(t, test.detail, detail)
+			}
+		})
+	}
+}
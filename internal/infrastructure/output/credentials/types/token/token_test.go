@@ -0,0 +1,78 @@
+package token
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+
+	errContext := "(output::credentials::types::TokenOutput::Output)"
+
+	tests := []struct {
+		desc            string
+		output          *TokenOutput
+		credential      *credentials.Credential
+		detail          string
+		credentialsType string
+		err             error
+	}{
+		{
+			desc:            "Testing error when creating the output for TokenOutput and credential is nil",
+			output:          NewTokenOutput(),
+			credential:      nil,
+			detail:          "",
+			credentialsType: "",
+			err:             errors.New(errContext, "To show credential output, credential must be provided"),
+		},
+		{
+			desc:   "Testing generate output for TokenOutput",
+			output: NewTokenOutput(),
+			credential: &credentials.Credential{
+				Username: "robot",
+				Token:    "token",
+			},
+			detail:          "username=robot, token=<redacted>",
+			credentialsType: TokenType,
+			err:             &errors.Error{},
+		},
+		{
+			desc:   "Testing generate output for TokenOutput without username",
+			output: NewTokenOutput(),
+			credential: &credentials.Credential{
+				RefreshToken: "refresh-token",
+			},
+			detail:          "refresh_token=<redacted>",
+			credentialsType: TokenType,
+			err:             &errors.Error{},
+		},
+		{
+			desc:   "Testing generate empty output for TokenOutput when credential has no tokens",
+			output: NewTokenOutput(),
+			credential: &credentials.Credential{
+				Username: "username",
+				Password: "password",
+			},
+			detail:          "",
+			credentialsType: "",
+			err:             &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			credentialsType, detail, err := test.output.Output(test.credential)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.credentialsType, credentialsType)
+				assert.Equal(t, test.detail, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package credentialscheck
+
+// OutputWriter is the writer where the credentials check results are printed
+type OutputWriter interface {
+	PrintTable(content [][]string) error
+	Write(p []byte) (int, error)
+}
@@ -0,0 +1,22 @@
+package credentialscheck
+
+import (
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOutput is a mock of the credentials check results output
+type MockOutput struct {
+	mock.Mock
+}
+
+// NewMockOutput creates a new MockOutput
+func NewMockOutput() *MockOutput {
+	return &MockOutput{}
+}
+
+// Print prints the credentials check results
+func (o *MockOutput) Print(results []*credentials.CheckResult) error {
+	args := o.Mock.Called(results)
+	return args.Error(0)
+}
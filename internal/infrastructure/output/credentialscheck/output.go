@@ -0,0 +1,119 @@
+package credentialscheck
+
+import (
+	"encoding/json"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// TableFormat prints the credentials check results as a table
+	TableFormat = "table"
+	// JSONFormat prints the credentials check results as a JSON list
+	JSONFormat = "json"
+	// YAMLFormat prints the credentials check results as a YAML list
+	YAMLFormat = "yaml"
+)
+
+// OptionsFunc defines the signature for an option function to set output attributes
+type OptionsFunc func(o *Output)
+
+// Output is an output for the credentials check results
+type Output struct {
+	write  OutputWriter
+	format string
+}
+
+// NewOutput creates a new Output
+func NewOutput(write OutputWriter, opts ...OptionsFunc) *Output {
+	o := &Output{
+		write:  write,
+		format: TableFormat,
+	}
+	o.Options(opts...)
+
+	return o
+}
+
+// WithFormat sets the format used to print the credentials check results
+func WithFormat(format string) OptionsFunc {
+	return func(o *Output) {
+		o.format = format
+	}
+}
+
+// Options provides the options for the output
+func (o *Output) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// Print prints the credentials check results
+func (o *Output) Print(results []*credentials.CheckResult) error {
+
+	var err error
+
+	errContext := "(output::credentialscheck::Output::Print)"
+
+	if o.write == nil {
+		return errors.New(errContext, "To print the credentials check results, you must provide a writer")
+	}
+
+	if results == nil {
+		results = []*credentials.CheckResult{}
+	}
+
+	switch o.format {
+	case "", TableFormat:
+		err = o.printTable(results)
+	case JSONFormat:
+		err = o.printJSON(results)
+	case YAMLFormat:
+		err = o.printYAML(results)
+	default:
+		return errors.New(errContext, "Output format '"+o.format+"' is not supported. Supported formats are: "+strings.Join([]string{TableFormat, JSONFormat, YAMLFormat}, ", "))
+	}
+	if err != nil {
+		return errors.New(errContext, "error printing the credentials check results.", err)
+	}
+
+	return nil
+}
+
+func (o *Output) printTable(results []*credentials.CheckResult) error {
+	content := [][]string{}
+	content = append(content, []string{"ID", "REGISTRY", "REPOSITORY", "SCOPE", "STATUS", "MESSAGE"})
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		content = append(content, []string{result.ID, result.Registry, result.Repository, result.Scope, strings.ToUpper(result.Status), result.Message})
+	}
+
+	return o.write.PrintTable(content)
+}
+
+func (o *Output) printJSON(results []*credentials.CheckResult) error {
+	content, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = o.write.Write(append(content, '\n'))
+	return err
+}
+
+func (o *Output) printYAML(results []*credentials.CheckResult) error {
+	content, err := yaml.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.write.Write(content)
+	return err
+}
@@ -0,0 +1,112 @@
+package credentialscheck
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	write "github.com/gostevedore/stevedore/internal/infrastructure/console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrint(t *testing.T) {
+	errContext := "(output::credentialscheck::Output::Print)"
+
+	results := []*credentials.CheckResult{
+		{
+			ID:       "registry.test",
+			Registry: "registry.test",
+			Status:   credentials.CheckStatusOK,
+			Message:  "Registry access granted",
+		},
+		{
+			ID:         "registry.test/team",
+			Registry:   "registry.test",
+			Repository: "team/app",
+			Scope:      credentials.CheckScopePush,
+			Status:     credentials.CheckStatusUnauthorized,
+			Message:    "Push access to repository 'team/app' is denied",
+		},
+	}
+
+	tests := []struct {
+		desc              string
+		output            *Output
+		results           []*credentials.CheckResult
+		prepareAssertFunc func(*Output)
+		err               error
+	}{
+		{
+			desc:    "Testing error printing the check results without writer",
+			output:  NewOutput(nil),
+			results: results,
+			err:     errors.New(errContext, "To print the credentials check results, you must provide a writer"),
+		},
+		{
+			desc:    "Testing print the check results as a table",
+			output:  NewOutput(write.NewMockConsole()),
+			results: results,
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("PrintTable", [][]string{
+					{"ID", "REGISTRY", "REPOSITORY", "SCOPE", "STATUS", "MESSAGE"},
+					{"registry.test", "registry.test", "", "", "OK", "Registry access granted"},
+					{"registry.test/team", "registry.test", "team/app", "push", "UNAUTHORIZED", "Push access to repository 'team/app' is denied"},
+				}).Return(nil)
+			},
+		},
+		{
+			desc:    "Testing print the check results as json",
+			output:  NewOutput(write.NewMockConsole(), WithFormat(JSONFormat)),
+			results: results[:1],
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("Write", []byte(`[
+  {
+    "id": "registry.test",
+    "registry": "registry.test",
+    "status": "ok",
+    "message": "Registry access granted"
+  }
+]
+`)).Return(0, nil)
+			},
+		},
+		{
+			desc:    "Testing print the check results as yaml",
+			output:  NewOutput(write.NewMockConsole(), WithFormat(YAMLFormat)),
+			results: results[1:],
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("Write", []byte(`- id: registry.test/team
+  registry: registry.test
+  repository: team/app
+  scope: push
+  status: unauthorized
+  message: Push access to repository 'team/app' is denied
+`)).Return(0, nil)
+			},
+		},
+		{
+			desc:    "Testing error printing the check results with an unsupported format",
+			output:  NewOutput(write.NewMockConsole(), WithFormat("xml")),
+			results: results,
+			err:     errors.New(errContext, "Output format 'xml' is not supported. Supported formats are: table, json, yaml"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.output)
+			}
+
+			err := test.output.Print(test.results)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				test.output.write.(*write.MockConsole).AssertExpectations(t)
+			}
+		})
+	}
+}
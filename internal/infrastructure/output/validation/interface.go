@@ -0,0 +1,7 @@
+package validation
+
+// OutputWriter is the writer where the validation issues are printed
+type OutputWriter interface {
+	PrintTable(content [][]string) error
+	Write(p []byte) (int, error)
+}
@@ -0,0 +1,22 @@
+package validation
+
+import (
+	domainvalidation "github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOutput is a mock of the validation issues output
+type MockOutput struct {
+	mock.Mock
+}
+
+// NewMockOutput creates a new MockOutput
+func NewMockOutput() *MockOutput {
+	return &MockOutput{}
+}
+
+// Print prints the validation issues
+func (o *MockOutput) Print(issues []*domainvalidation.Issue) error {
+	args := o.Mock.Called(issues)
+	return args.Error(0)
+}
@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"encoding/json"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	domainvalidation "github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// TableFormat prints the validation issues as a table
+	TableFormat = "table"
+	// JSONFormat prints the validation issues as a JSON list
+	JSONFormat = "json"
+	// YAMLFormat prints the validation issues as a YAML list
+	YAMLFormat = "yaml"
+)
+
+// OptionsFunc defines the signature for an option function to set output attributes
+type OptionsFunc func(o *Output)
+
+// Output is an output for the validation issues
+type Output struct {
+	write  OutputWriter
+	format string
+}
+
+// NewOutput creates a new Output
+func NewOutput(write OutputWriter, opts ...OptionsFunc) *Output {
+	o := &Output{
+		write:  write,
+		format: TableFormat,
+	}
+	o.Options(opts...)
+
+	return o
+}
+
+// WithFormat sets the format used to print the validation issues
+func WithFormat(format string) OptionsFunc {
+	return func(o *Output) {
+		o.format = format
+	}
+}
+
+// Options provides the options for the output
+func (o *Output) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// Print prints the validation issues
+func (o *Output) Print(issues []*domainvalidation.Issue) error {
+
+	var err error
+
+	errContext := "(output::validation::Output::Print)"
+
+	if o.write == nil {
+		return errors.New(errContext, "To print the validation issues, you must provide a writer")
+	}
+
+	if issues == nil {
+		issues = []*domainvalidation.Issue{}
+	}
+
+	switch o.format {
+	case "", TableFormat:
+		err = o.printTable(issues)
+	case JSONFormat:
+		err = o.printJSON(issues)
+	case YAMLFormat:
+		err = o.printYAML(issues)
+	default:
+		return errors.New(errContext, "Output format '"+o.format+"' is not supported. Supported formats are: "+strings.Join([]string{TableFormat, JSONFormat, YAMLFormat}, ", "))
+	}
+	if err != nil {
+		return errors.New(errContext, "error printing the validation issues.", err)
+	}
+
+	return nil
+}
+
+func (o *Output) printTable(issues []*domainvalidation.Issue) error {
+
+	// nothing is printed when there are no issues to not print an empty table
+	if len(issues) == 0 {
+		return nil
+	}
+
+	content := [][]string{}
+	content = append(content, []string{"LOCATION", "SEVERITY", "PATH", "MESSAGE"})
+
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		content = append(content, []string{issue.Location(), strings.ToUpper(issue.Severity), issue.Path, issue.Message})
+	}
+
+	return o.write.PrintTable(content)
+}
+
+func (o *Output) printJSON(issues []*domainvalidation.Issue) error {
+	content, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = o.write.Write(append(content, '\n'))
+	return err
+}
+
+func (o *Output) printYAML(issues []*domainvalidation.Issue) error {
+	content, err := yaml.Marshal(issues)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.write.Write(content)
+	return err
+}
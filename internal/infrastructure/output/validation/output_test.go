@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	domainvalidation "github.com/gostevedore/stevedore/internal/core/domain/validation"
+	write "github.com/gostevedore/stevedore/internal/infrastructure/console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrint(t *testing.T) {
+	errContext := "(output::validation::Output::Print)"
+
+	issues := []*domainvalidation.Issue{
+		{
+			File:     "stevedore.yaml",
+			Line:     2,
+			Column:   1,
+			Path:     "tree_path",
+			Severity: domainvalidation.SeverityWarning,
+			Message:  "'tree_path' is deprecated, use 'images_path' instead",
+		},
+		{
+			File:     "images/base.yaml",
+			Line:     5,
+			Column:   7,
+			Path:     "images.ubuntu.22.04.persistant_vars",
+			Severity: domainvalidation.SeverityError,
+			Message:  "Unknown key 'persistant_vars', did you mean 'persistent_vars'?",
+		},
+	}
+
+	tests := []struct {
+		desc              string
+		output            *Output
+		issues            []*domainvalidation.Issue
+		prepareAssertFunc func(*Output)
+		err               error
+	}{
+		{
+			desc:   "Testing error printing the validation issues without writer",
+			output: NewOutput(nil),
+			issues: issues,
+			err:    errors.New(errContext, "To print the validation issues, you must provide a writer"),
+		},
+		{
+			desc:   "Testing print the validation issues as a table",
+			output: NewOutput(write.NewMockConsole()),
+			issues: issues,
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("PrintTable", [][]string{
+					{"LOCATION", "SEVERITY", "PATH", "MESSAGE"},
+					{"stevedore.yaml:2:1", "WARNING", "tree_path", "'tree_path' is deprecated, use 'images_path' instead"},
+					{"images/base.yaml:5:7", "ERROR", "images.ubuntu.22.04.persistant_vars", "Unknown key 'persistant_vars', did you mean 'persistent_vars'?"},
+				}).Return(nil)
+			},
+		},
+		{
+			desc:   "Testing print no validation issues as a table",
+			output: NewOutput(write.NewMockConsole()),
+			issues: []*domainvalidation.Issue{},
+		},
+		{
+			desc:   "Testing print the validation issues as json",
+			output: NewOutput(write.NewMockConsole(), WithFormat(JSONFormat)),
+			issues: issues[:1],
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("Write", []byte(`[
+  {
+    "file": "stevedore.yaml",
+    "line": 2,
+    "column": 1,
+    "path": "tree_path",
+    "severity": "warning",
+    "message": "'tree_path' is deprecated, use 'images_path' instead"
+  }
+]
+`)).Return(0, nil)
+			},
+		},
+		{
+			desc:   "Testing print the validation issues as yaml",
+			output: NewOutput(write.NewMockConsole(), WithFormat(YAMLFormat)),
+			issues: issues[1:],
+			prepareAssertFunc: func(o *Output) {
+				o.write.(*write.MockConsole).On("Write", []byte(`- file: images/base.yaml
+  line: 5
+  column: 7
+  path: images.ubuntu.22.04.persistant_vars
+  severity: error
+  message: Unknown key 'persistant_vars', did you mean 'persistent_vars'?
+`)).Return(0, nil)
+			},
+		},
+		{
+			desc:   "Testing error printing the validation issues with an unsupported format",
+			output: NewOutput(write.NewMockConsole(), WithFormat("xml")),
+			issues: issues,
+			err:    errors.New(errContext, "Output format 'xml' is not supported. Supported formats are: table, json, yaml"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.output)
+			}
+
+			err := test.output.Print(test.issues)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				test.output.write.(*write.MockConsole).AssertExpectations(t)
+			}
+		})
+	}
+}
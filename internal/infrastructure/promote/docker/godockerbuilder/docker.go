@@ -4,9 +4,12 @@ import (
 	"context"
 	"io"
 
+	errors "github.com/apenella/go-common-utils/error"
 	transformer "github.com/apenella/go-common-utils/transformer/string"
 	"github.com/apenella/go-docker-builder/pkg/copy"
 	"github.com/apenella/go-docker-builder/pkg/response"
+	dockerimagetypes "github.com/docker/docker/api/types/image"
+	dockerregistrytypes "github.com/docker/docker/api/types/registry"
 )
 
 // DockerCopier
@@ -76,6 +79,48 @@ func (c *DockerCopy) AddPushAuth(username string, password string) error {
 	return c.cmd.AddPushAuth(username, password)
 }
 
+// AddPullTokenAuth defines the pull authentication using an identity token or a registry token instead of a password
+func (c *DockerCopy) AddPullTokenAuth(username string, identityToken string, registryToken string) error {
+	errContext := "(godockerbuilder::AddPullTokenAuth)"
+
+	auth, err := encodeTokenAuth(username, identityToken, registryToken)
+	if err != nil {
+		return errors.New(errContext, "Error encoding the pull token auth configuration", err)
+	}
+
+	if c.cmd.ImagePullOptions == nil {
+		c.cmd.ImagePullOptions = &dockerimagetypes.PullOptions{}
+	}
+	c.cmd.ImagePullOptions.RegistryAuth = auth
+
+	return nil
+}
+
+// AddPushTokenAuth defines the push authentication using an identity token or a registry token instead of a password
+func (c *DockerCopy) AddPushTokenAuth(username string, identityToken string, registryToken string) error {
+	errContext := "(godockerbuilder::AddPushTokenAuth)"
+
+	auth, err := encodeTokenAuth(username, identityToken, registryToken)
+	if err != nil {
+		return errors.New(errContext, "Error encoding the push token auth configuration", err)
+	}
+
+	if c.cmd.ImagePushOptions == nil {
+		c.cmd.ImagePushOptions = &dockerimagetypes.PushOptions{}
+	}
+	c.cmd.ImagePushOptions.RegistryAuth = auth
+
+	return nil
+}
+
+func encodeTokenAuth(username string, identityToken string, registryToken string) (string, error) {
+	return dockerregistrytypes.EncodeAuthConfig(dockerregistrytypes.AuthConfig{
+		Username:      username,
+		IdentityToken: identityToken,
+		RegistryToken: registryToken,
+	})
+}
+
 // Run
 func (c *DockerCopy) Run(ctx context.Context) error {
 	return c.cmd.Run(ctx)
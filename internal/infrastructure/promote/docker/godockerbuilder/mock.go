@@ -44,6 +44,18 @@ func (m *PromoteMock) AddPushAuth(user string, pass string) error {
 	return args.Error(0)
 }
 
+// AddPullTokenAuth
+func (m *PromoteMock) AddPullTokenAuth(user string, identityToken string, registryToken string) error {
+	args := m.Mock.Called(user, identityToken, registryToken)
+	return args.Error(0)
+}
+
+// AddPushTokenAuth
+func (m *PromoteMock) AddPushTokenAuth(user string, identityToken string, registryToken string) error {
+	args := m.Mock.Called(user, identityToken, registryToken)
+	return args.Error(0)
+}
+
 // WithSourceImage
 func (m *PromoteMock) WithSourceImage(name string) {
 	m.Mock.Called(name)
@@ -3,8 +3,14 @@ package docker
 import (
 	"context"
 	"io"
+
+	dockerimage "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
 )
 
+// DockerCopierFactoryFunc returns a new DockerCopier. It is used to not share the copy command among promotions
+type DockerCopierFactoryFunc func() DockerCopier
+
 // DockerCopier
 type DockerCopier interface {
 	DockerCopyConfigurer
@@ -17,6 +23,8 @@ type DockerCopyAuther interface {
 	AddAuth(string, string) error
 	AddPullAuth(string, string) error
 	AddPushAuth(string, string) error
+	AddPullTokenAuth(string, string, string) error
+	AddPushTokenAuth(string, string, string) error
 }
 
 // DockerCopyConfigurer
@@ -29,3 +37,8 @@ type DockerCopyConfigurer interface {
 	WithResponse(io.Writer, string)
 	WithUseNormalizedNamed()
 }
+
+// DockerImageInspector inspects the images stored on the Docker daemon
+type DockerImageInspector interface {
+	ImageInspect(ctx context.Context, imageID string, inspectOpts ...client.ImageInspectOption) (dockerimage.InspectResponse, error)
+}
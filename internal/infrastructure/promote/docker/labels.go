@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// DockerLabelsResolver resolves the labels of the images stored on the Docker daemon
+type DockerLabelsResolver struct {
+	inspector DockerImageInspector
+}
+
+// NewDockerLabelsResolver returns a new DockerLabelsResolver
+func NewDockerLabelsResolver(inspector DockerImageInspector) *DockerLabelsResolver {
+	return &DockerLabelsResolver{
+		inspector: inspector,
+	}
+}
+
+// Labels returns the labels of the image stored on the Docker daemon. Neither credentials nor tokens are required to inspect a local image
+func (r *DockerLabelsResolver) Labels(ctx context.Context, name, username, password, identityToken, registryToken string) (map[string]string, error) {
+
+	errContext := "(docker::DockerLabelsResolver::Labels)"
+
+	if r.inspector == nil {
+		return nil, errors.New(errContext, "Docker image inspector must be initialized before resolving an image labels")
+	}
+
+	if name == "" {
+		return nil, errors.New(errContext, "Image name must be provided to resolve its labels")
+	}
+
+	inspect, err := r.inspector.ImageInspect(ctx, name)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved", name), err)
+	}
+
+	if inspect.Config == nil || inspect.Config.Labels == nil {
+		return map[string]string{}, nil
+	}
+
+	return inspect.Config.Labels, nil
+}
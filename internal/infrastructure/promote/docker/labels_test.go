@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/docker/docker/api/types/container"
+	dockerimage "github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabels(t *testing.T) {
+
+	errContext := "(docker::DockerLabelsResolver::Labels)"
+
+	tests := []struct {
+		desc              string
+		resolver          *DockerLabelsResolver
+		name              string
+		prepareAssertFunc func(*DockerLabelsResolver)
+		res               map[string]string
+		err               error
+	}{
+		{
+			desc:     "Testing error resolving labels when the inspector is not provided",
+			resolver: NewDockerLabelsResolver(nil),
+			err:      errors.New(errContext, "Docker image inspector must be initialized before resolving an image labels"),
+		},
+		{
+			desc:     "Testing error resolving labels when the image name is not provided",
+			resolver: NewDockerLabelsResolver(NewMockDockerImageInspector()),
+			err:      errors.New(errContext, "Image name must be provided to resolve its labels"),
+		},
+		{
+			desc:     "Testing resolve an image labels",
+			resolver: NewDockerLabelsResolver(NewMockDockerImageInspector()),
+			name:     "registry.test/namespace/image:1.2.3",
+			prepareAssertFunc: func(r *DockerLabelsResolver) {
+				r.inspector.(*MockDockerImageInspector).On("ImageInspect", context.TODO(), "registry.test/namespace/image:1.2.3").Return(dockerimage.InspectResponse{
+					Config: &container.Config{
+						Labels: map[string]string{"qa.approved": "true"},
+					},
+				}, nil)
+			},
+			res: map[string]string{"qa.approved": "true"},
+			err: &errors.Error{},
+		},
+		{
+			desc:     "Testing resolve the labels of an image without configuration",
+			resolver: NewDockerLabelsResolver(NewMockDockerImageInspector()),
+			name:     "registry.test/namespace/image:1.2.3",
+			prepareAssertFunc: func(r *DockerLabelsResolver) {
+				r.inspector.(*MockDockerImageInspector).On("ImageInspect", context.TODO(), "registry.test/namespace/image:1.2.3").Return(dockerimage.InspectResponse{}, nil)
+			},
+			res: map[string]string{},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.resolver)
+			}
+
+			res, err := test.resolver.Labels(context.TODO(), test.name, "", "", "", "")
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package docker
+
+import (
+	"context"
+
+	dockerimage "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDockerImageInspector is a mock of DockerImageInspector
+type MockDockerImageInspector struct {
+	mock.Mock
+}
+
+// NewMockDockerImageInspector returns a new MockDockerImageInspector
+func NewMockDockerImageInspector() *MockDockerImageInspector {
+	return &MockDockerImageInspector{}
+}
+
+// ImageInspect returns the image inspection
+func (m *MockDockerImageInspector) ImageInspect(ctx context.Context, imageID string, inspectOpts ...client.ImageInspectOption) (dockerimage.InspectResponse, error) {
+	args := m.Called(ctx, imageID)
+	return args.Get(0).(dockerimage.InspectResponse), args.Error(1)
+}
@@ -15,7 +15,8 @@ const (
 )
 
 type DockerPromete struct {
-	cmd DockerCopier
+	cmd           DockerCopier
+	copierFactory DockerCopierFactoryFunc
 	//	logger Logger
 	writer io.Writer
 }
@@ -32,13 +33,31 @@ func NewDockerPromote(cmd DockerCopier, w io.Writer) *DockerPromete {
 	}
 }
 
+// NewDockerPromoteFromFactory returns a DockerPromete that creates a new copy command for each promotion, which lets the promoter be used concurrently
+func NewDockerPromoteFromFactory(f DockerCopierFactoryFunc, w io.Writer) *DockerPromete {
+
+	if w == nil {
+		w = os.Stdout
+	}
+
+	return &DockerPromete{
+		copierFactory: f,
+		writer:        w,
+	}
+}
+
 func (p *DockerPromete) Promote(ctx context.Context, options *image.PromoteOptions) error {
 
 	var err error
 
 	contextError := "(docker::Promote)"
 
-	if p.cmd == nil {
+	cmd := p.cmd
+	if p.copierFactory != nil {
+		cmd = p.copierFactory()
+	}
+
+	if cmd == nil {
 		return errors.New(contextError, "Command to copy docker images must be initialized before promote an image to docker registry")
 	}
 
@@ -59,29 +78,37 @@ func (p *DockerPromete) Promote(ctx context.Context, options *image.PromoteOptio
 	}
 
 	if options.RemoteSourceImage {
-		err = p.cmd.AddPullAuth(options.PullAuthUsername, options.PullAuthPassword)
+		if options.PullAuthIdentityToken != "" || options.PullAuthRegistryToken != "" {
+			err = cmd.AddPullTokenAuth(options.PullAuthUsername, options.PullAuthIdentityToken, options.PullAuthRegistryToken)
+		} else {
+			err = cmd.AddPullAuth(options.PullAuthUsername, options.PullAuthPassword)
+		}
 		if err != nil {
 			return errors.New(contextError, fmt.Sprintf("Image '%s' could not be promoted because is not possible to achieve pull credentials", options.SourceImageName), err)
 		}
-		p.cmd.WithRemoteSource()
+		cmd.WithRemoteSource()
 	}
 
-	err = p.cmd.AddPushAuth(options.PushAuthUsername, options.PushAuthPassword)
+	if options.PushAuthIdentityToken != "" || options.PushAuthRegistryToken != "" {
+		err = cmd.AddPushTokenAuth(options.PushAuthUsername, options.PushAuthIdentityToken, options.PushAuthRegistryToken)
+	} else {
+		err = cmd.AddPushAuth(options.PushAuthUsername, options.PushAuthPassword)
+	}
 	if err != nil {
 		return errors.New(contextError, fmt.Sprintf("Image '%s' could not be promoted because is not possible to achieve push credentials", options.SourceImageName), err)
 	}
 
 	if options.RemoveTargetImageTags {
-		p.cmd.WithRemoveAfterPush()
+		cmd.WithRemoveAfterPush()
 	}
 
-	p.cmd.WithSourceImage(options.SourceImageName)
-	p.cmd.WithTargetImage(options.TargetImageName)
-	p.cmd.WithTags(options.TargetImageTags)
-	p.cmd.WithUseNormalizedNamed()
-	p.cmd.WithResponse(p.writer, options.TargetImageName)
+	cmd.WithSourceImage(options.SourceImageName)
+	cmd.WithTargetImage(options.TargetImageName)
+	cmd.WithTags(options.TargetImageTags)
+	cmd.WithUseNormalizedNamed()
+	cmd.WithResponse(p.writer, options.TargetImageName)
 
-	err = p.cmd.Run(ctx)
+	err = cmd.Run(ctx)
 	if err != nil {
 		return errors.New(contextError, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
 	}
@@ -18,6 +18,48 @@ func TestNewDockerPromote(t *testing.T) {
 	assert.NotNil(t, p.writer, "Failed because writer is nil")
 }
 
+func TestNewDockerPromoteFromFactory(t *testing.T) {
+	p := NewDockerPromoteFromFactory(func() DockerCopier { return godockerbuilder.NewPromoteMock() }, nil)
+
+	assert.Nil(t, p.cmd, "Failed because copier is not nil")
+	assert.NotNil(t, p.copierFactory, "Failed because copier factory is nil")
+	assert.NotNil(t, p.writer, "Failed because writer is nil")
+}
+
+func TestPromoteFromFactory(t *testing.T) {
+
+	copiers := []*godockerbuilder.PromoteMock{}
+	options := &image.PromoteOptions{
+		SourceImageName: "sourceRegistry/namespace/image",
+		TargetImageName: "targetRegistry/namespace/image",
+		TargetImageTags: []string{"tag1"},
+	}
+
+	p := NewDockerPromoteFromFactory(func() DockerCopier {
+		copier := godockerbuilder.NewPromoteMock()
+		copier.On("WithSourceImage", options.SourceImageName)
+		copier.On("WithTargetImage", options.TargetImageName)
+		copier.On("WithResponse", ioutil.Discard, options.TargetImageName)
+		copier.On("WithTags", options.TargetImageTags)
+		copier.On("WithUseNormalizedNamed")
+		copier.On("AddPushAuth", "", "").Return(nil)
+		copier.On("Run", context.TODO()).Return(nil)
+		copiers = append(copiers, copier)
+
+		return copier
+	}, ioutil.Discard)
+
+	err := p.Promote(context.TODO(), options)
+	assert.NoError(t, err)
+	err = p.Promote(context.TODO(), options)
+	assert.NoError(t, err)
+
+	assert.Len(t, copiers, 2, "Each promotion must use its own copy command")
+	for _, copier := range copiers {
+		copier.AssertNumberOfCalls(t, "Run", 1)
+	}
+}
+
 func TestPromote(t *testing.T) {
 
 	contextError := "(docker::Promote)"
@@ -154,6 +196,41 @@ func TestPromote(t *testing.T) {
 				// m.credentials.(*credentials.CredentialsStoreMock).AssertNumberOfCalls(t, "GetCredentials", 2)
 			},
 		},
+		{
+			desc: "Testing promote remote image using token auth",
+			prom: &DockerPromete{
+				cmd:    godockerbuilder.NewPromoteMock(),
+				writer: dummyWriter,
+			},
+			options: &image.PromoteOptions{
+				SourceImageName:       "sourceRegistry/namespace/image",
+				TargetImageName:       "targetRegistry/namespace/image",
+				TargetImageTags:       []string{"tag1"},
+				RemoteSourceImage:     true,
+				PullAuthIdentityToken: "pull-identity-token",
+				PushAuthUsername:      "pushname",
+				PushAuthRegistryToken: "push-registry-token",
+			},
+			err: &errors.Error{},
+			prepareAssertFunc: func(m *DockerPromete, o *image.PromoteOptions) {
+				m.cmd.(*godockerbuilder.PromoteMock).On("WithSourceImage", o.SourceImageName)
+				m.cmd.(*godockerbuilder.PromoteMock).On("WithTargetImage", o.TargetImageName)
+				m.cmd.(*godockerbuilder.PromoteMock).On("WithResponse", m.writer, o.TargetImageName)
+				m.cmd.(*godockerbuilder.PromoteMock).On("WithTags", o.TargetImageTags)
+				m.cmd.(*godockerbuilder.PromoteMock).On("WithUseNormalizedNamed")
+				m.cmd.(*godockerbuilder.PromoteMock).On("WithRemoteSource")
+				m.cmd.(*godockerbuilder.PromoteMock).On("AddPullTokenAuth", "", "pull-identity-token", "").Return(nil)
+				m.cmd.(*godockerbuilder.PromoteMock).On("AddPushTokenAuth", "pushname", "", "push-registry-token").Return(nil)
+				m.cmd.(*godockerbuilder.PromoteMock).On("Run", context.TODO()).Return(nil)
+			},
+			assertFunc: func(m *DockerPromete) bool {
+				return m.cmd.(*godockerbuilder.PromoteMock).AssertNumberOfCalls(t, "AddPullTokenAuth", 1) &&
+					m.cmd.(*godockerbuilder.PromoteMock).AssertNumberOfCalls(t, "AddPushTokenAuth", 1) &&
+					m.cmd.(*godockerbuilder.PromoteMock).AssertNotCalled(t, "AddPullAuth", "", "") &&
+					m.cmd.(*godockerbuilder.PromoteMock).AssertNotCalled(t, "AddPushAuth", "pushname", "") &&
+					m.cmd.(*godockerbuilder.PromoteMock).AssertNumberOfCalls(t, "Run", 1)
+			},
+		},
 	}
 
 	for _, test := range tests {
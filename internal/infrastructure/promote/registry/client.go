@@ -0,0 +1,581 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// dockerHubDomain is the domain used on Docker Hub image references
+	dockerHubDomain = "docker.io"
+	// dockerHubRegistryHost is the host that serves the Registry HTTP API v2 for Docker Hub
+	dockerHubRegistryHost = "registry-1.docker.io"
+	// oauth2ClientID is the client identifier sent when an identity token is exchanged by an access token
+	oauth2ClientID = "stevedore"
+)
+
+// repositoryClient communicates with a repository through the Registry HTTP API v2
+type repositoryClient struct {
+	client     HTTPClienter
+	scheme     string
+	host       string
+	repository string
+	username   string
+	password   string
+	// identityToken is an OAuth2 refresh token exchanged by an access token on the authorization service
+	identityToken string
+	// registryToken is a bearer token sent directly to the registry
+	registryToken string
+
+	// authorization is the value of the authorization header sent on each request
+	authorization string
+}
+
+// newRepositoryClient returns a client for the repository on the registry host
+func newRepositoryClient(client HTTPClienter, scheme, host, repository, username, password string) *repositoryClient {
+
+	if host == dockerHubDomain {
+		host = dockerHubRegistryHost
+	}
+
+	return &repositoryClient{
+		client:     client,
+		scheme:     scheme,
+		host:       host,
+		repository: repository,
+		username:   username,
+		password:   password,
+	}
+}
+
+// withTokens sets the tokens used to authorize the requests instead of the username and password
+func (c *repositoryClient) withTokens(identityToken, registryToken string) *repositoryClient {
+	c.identityToken = identityToken
+	c.registryToken = registryToken
+
+	return c
+}
+
+// authorize negotiates the authorization required to execute the actions over the scopes. Scopes are defined following the format 'repository:<name>:<actions>'
+func (c *repositoryClient) authorize(ctx context.Context, scopes ...string) error {
+	errContext := "(registry::repositoryClient::authorize)"
+
+	// registry token is already a bearer token, then there is nothing to negotiate
+	if c.registryToken != "" {
+		c.authorization = "Bearer " + c.registryToken
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url("/v2/"), nil)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Registry '%s' could not be reached", c.host), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+
+	scheme, params := parseChallenge(resp.Header.Get("WWW-Authenticate"))
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.identityToken != "" {
+			return errors.New(errContext, fmt.Sprintf("Registry '%s' requires basic authorization, which can not be achieved using an identity token", c.host))
+		}
+		if c.username == "" {
+			return errors.New(errContext, fmt.Sprintf("Registry '%s' requires credentials", c.host))
+		}
+		req.SetBasicAuth(c.username, c.password)
+		c.authorization = req.Header.Get("Authorization")
+	case "bearer":
+		token, err := c.fetchToken(ctx, params, scopes)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+		c.authorization = "Bearer " + token
+	default:
+		return errors.New(errContext, fmt.Sprintf("Registry '%s' requires an unsupported authorization scheme '%s'", c.host, scheme))
+	}
+
+	return nil
+}
+
+// fetchToken requests a bearer token to the authorization service defined on the challenge
+func (c *repositoryClient) fetchToken(ctx context.Context, params map[string]string, scopes []string) (string, error) {
+	errContext := "(registry::repositoryClient::fetchToken)"
+
+	realm, exists := params["realm"]
+	if !exists {
+		return "", errors.New(errContext, fmt.Sprintf("Registry '%s' authorization challenge does not define a realm", c.host))
+	}
+
+	realmURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Invalid authorization realm '%s'", realm), err)
+	}
+
+	if c.identityToken != "" {
+		return c.exchangeIdentityToken(ctx, realmURL.String(), params["service"], scopes)
+	}
+
+	query := realmURL.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	for _, scope := range scopes {
+		query.Add("scope", scope)
+	}
+	realmURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realmURL.String(), nil)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Token could not be requested to '%s'", realm), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(errContext, fmt.Sprintf("Token could not be requested to '%s'. Unexpected status code %d", realm, resp.StatusCode))
+	}
+
+	token, err := decodeTokenResponse(resp.Body)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Token response from '%s' could not be processed", realm), err)
+	}
+
+	return token, nil
+}
+
+// exchangeIdentityToken requests an access token to the authorization service using the identity token as an OAuth2 refresh token
+func (c *repositoryClient) exchangeIdentityToken(ctx context.Context, realm, service string, scopes []string) (string, error) {
+	errContext := "(registry::repositoryClient::exchangeIdentityToken)"
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", c.identityToken)
+	form.Set("client_id", oauth2ClientID)
+	if service != "" {
+		form.Set("service", service)
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, realm, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Token could not be requested to '%s'", realm), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(errContext, fmt.Sprintf("Token could not be requested to '%s' using the identity token. Unexpected status code %d", realm, resp.StatusCode))
+	}
+
+	token, err := decodeTokenResponse(resp.Body)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Token response from '%s' could not be processed", realm), err)
+	}
+
+	return token, nil
+}
+
+// decodeTokenResponse returns the token from an authorization service response
+func decodeTokenResponse(body io.Reader) (string, error) {
+	errContext := "(registry::decodeTokenResponse)"
+
+	tokenResponse := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+
+	err := json.NewDecoder(body).Decode(&tokenResponse)
+	if err != nil {
+		return "", errors.New(errContext, "Token response could not be decoded", err)
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+
+	return "", errors.New(errContext, "Token response does not contain any token")
+}
+
+// getManifest returns the manifest identified by a tag or a digest
+func (c *repositoryClient) getManifest(ctx context.Context, reference string) (*manifest, error) {
+	errContext := "(registry::repositoryClient::getManifest)"
+
+	req, err := c.newRequest(ctx, http.MethodGet, c.url(fmt.Sprintf("/v2/%s/manifests/%s", c.repository, reference)), nil)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestAcceptedMediaTypes, ", "))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be requested to '%s/%s'", reference, c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be achieved from '%s/%s'. Unexpected status code %d", reference, c.host, c.repository, resp.StatusCode))
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	mediaType := resp.Header.Get("Content-Type")
+	if idx := strings.IndexRune(mediaType, ';'); idx >= 0 {
+		mediaType = mediaType[:idx]
+	}
+
+	// some registries return a generic content type
+	if mediaType == "application/json" || mediaType == "text/plain" {
+		mediaType = ""
+	}
+
+	m, err := parseManifest(content, mediaType)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return m, nil
+}
+
+// getManifestDigest returns the digest of the manifest identified by a tag or a digest
+func (c *repositoryClient) getManifestDigest(ctx context.Context, reference string) (string, error) {
+	errContext := "(registry::repositoryClient::getManifestDigest)"
+
+	digest, found, err := c.lookupManifestDigest(ctx, reference)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	if !found {
+		return "", errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be achieved from '%s/%s'. Unexpected status code %d", reference, c.host, c.repository, http.StatusNotFound))
+	}
+
+	return digest, nil
+}
+
+// lookupManifestDigest returns the digest of the manifest identified by a tag or a digest, and whether the manifest exists on the repository
+func (c *repositoryClient) lookupManifestDigest(ctx context.Context, reference string) (string, bool, error) {
+	errContext := "(registry::repositoryClient::lookupManifestDigest)"
+
+	req, err := c.newRequest(ctx, http.MethodHead, c.url(fmt.Sprintf("/v2/%s/manifests/%s", c.repository, reference)), nil)
+	if err != nil {
+		return "", false, errors.New(errContext, "", err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestAcceptedMediaTypes, ", "))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", false, errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be requested to '%s/%s'", reference, c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", false, errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be achieved from '%s/%s'. Unexpected status code %d", reference, c.host, c.repository, resp.StatusCode))
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest != "" {
+		return digest, true, nil
+	}
+
+	// registries are not required to provide the digest header, then the digest is calculated from the manifest content
+	m, err := c.getManifest(ctx, reference)
+	if err != nil {
+		return "", false, errors.New(errContext, "", err)
+	}
+
+	return m.digest(), true, nil
+}
+
+// putManifest uploads the manifest to the repository using the reference, that could be a tag or a digest
+func (c *repositoryClient) putManifest(ctx context.Context, reference string, m *manifest) error {
+	errContext := "(registry::repositoryClient::putManifest)"
+
+	req, err := c.newRequest(ctx, http.MethodPut, c.url(fmt.Sprintf("/v2/%s/manifests/%s", c.repository, reference)), bytes.NewReader(m.content))
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	req.Header.Set("Content-Type", m.MediaType)
+	req.ContentLength = int64(len(m.content))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be uploaded to '%s/%s'", reference, c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return errors.New(errContext, fmt.Sprintf("Manifest '%s' could not be uploaded to '%s/%s'. Unexpected status code %d", reference, c.host, c.repository, resp.StatusCode))
+	}
+
+	return nil
+}
+
+// blobExists returns true when the blob already exists on the repository
+func (c *repositoryClient) blobExists(ctx context.Context, digest string) (bool, error) {
+	errContext := "(registry::repositoryClient::blobExists)"
+
+	req, err := c.newRequest(ctx, http.MethodHead, c.url(fmt.Sprintf("/v2/%s/blobs/%s", c.repository, digest)), nil)
+	if err != nil {
+		return false, errors.New(errContext, "", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, errors.New(errContext, fmt.Sprintf("Blob '%s' could not be checked on '%s/%s'", digest, c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.New(errContext, fmt.Sprintf("Blob '%s' could not be checked on '%s/%s'. Unexpected status code %d", digest, c.host, c.repository, resp.StatusCode))
+	}
+}
+
+// mountBlob tries to mount the blob from another repository on the same registry. When the registry does not mount the blob, it returns the location to upload it
+func (c *repositoryClient) mountBlob(ctx context.Context, digest, from string) (bool, string, error) {
+	errContext := "(registry::repositoryClient::mountBlob)"
+
+	query := url.Values{}
+	query.Set("mount", digest)
+	query.Set("from", from)
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.url(fmt.Sprintf("/v2/%s/blobs/uploads/?%s", c.repository, query.Encode())), nil)
+	if err != nil {
+		return false, "", errors.New(errContext, "", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, "", errors.New(errContext, fmt.Sprintf("Blob '%s' could not be mounted from '%s' on '%s/%s'", digest, from, c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, "", nil
+	case http.StatusAccepted:
+		location, err := c.location(resp)
+		if err != nil {
+			return false, "", errors.New(errContext, "", err)
+		}
+		return false, location, nil
+	default:
+		return false, "", errors.New(errContext, fmt.Sprintf("Blob '%s' could not be mounted from '%s' on '%s/%s'. Unexpected status code %d", digest, from, c.host, c.repository, resp.StatusCode))
+	}
+}
+
+// startUpload starts a blob upload and returns the location where the blob must be uploaded
+func (c *repositoryClient) startUpload(ctx context.Context) (string, error) {
+	errContext := "(registry::repositoryClient::startUpload)"
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.url(fmt.Sprintf("/v2/%s/blobs/uploads/", c.repository)), nil)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Blob upload could not be started on '%s/%s'", c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusAccepted {
+		return "", errors.New(errContext, fmt.Sprintf("Blob upload could not be started on '%s/%s'. Unexpected status code %d", c.host, c.repository, resp.StatusCode))
+	}
+
+	location, err := c.location(resp)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	return location, nil
+}
+
+// getBlob returns the blob content. The caller is in charge to close it
+func (c *repositoryClient) getBlob(ctx context.Context, digest string) (io.ReadCloser, int64, error) {
+	errContext := "(registry::repositoryClient::getBlob)"
+
+	req, err := c.newRequest(ctx, http.MethodGet, c.url(fmt.Sprintf("/v2/%s/blobs/%s", c.repository, digest)), nil)
+	if err != nil {
+		return nil, 0, errors.New(errContext, "", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, errors.New(errContext, fmt.Sprintf("Blob '%s' could not be requested to '%s/%s'", digest, c.host, c.repository), err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		drainAndClose(resp)
+		return nil, 0, errors.New(errContext, fmt.Sprintf("Blob '%s' could not be achieved from '%s/%s'. Unexpected status code %d", digest, c.host, c.repository, resp.StatusCode))
+	}
+
+	return resp.Body, resp.ContentLength, nil
+}
+
+// uploadBlob completes a blob upload on a single request
+func (c *repositoryClient) uploadBlob(ctx context.Context, location, digest string, content io.Reader, size int64) error {
+	errContext := "(registry::repositoryClient::uploadBlob)"
+
+	uploadURL, err := url.Parse(location)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Invalid upload location '%s'", location), err)
+	}
+	query := uploadURL.Query()
+	query.Set("digest", digest)
+	uploadURL.RawQuery = query.Encode()
+
+	req, err := c.newRequest(ctx, http.MethodPut, uploadURL.String(), content)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.ContentLength = size
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Blob '%s' could not be uploaded to '%s/%s'", digest, c.host, c.repository), err)
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode != http.StatusCreated {
+		return errors.New(errContext, fmt.Sprintf("Blob '%s' could not be uploaded to '%s/%s'. Unexpected status code %d", digest, c.host, c.repository, resp.StatusCode))
+	}
+
+	return nil
+}
+
+// newRequest returns a request that includes the authorization header
+func (c *repositoryClient) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+
+	return req, nil
+}
+
+// url returns the absolute url for the path on the registry
+func (c *repositoryClient) url(path string) string {
+	return fmt.Sprintf("%s://%s%s", c.scheme, c.host, path)
+}
+
+// location returns the absolute location defined on the response headers
+func (c *repositoryClient) location(resp *http.Response) (string, error) {
+	errContext := "(registry::repositoryClient::location)"
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errors.New(errContext, fmt.Sprintf("Registry '%s' does not provide the upload location", c.host))
+	}
+
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Invalid upload location '%s'", location), err)
+	}
+
+	return resp.Request.URL.ResolveReference(locationURL).String(), nil
+}
+
+// parseChallenge returns the authorization scheme and its parameters from a WWW-Authenticate header value
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	challenge = strings.TrimSpace(challenge)
+	idx := strings.IndexRune(challenge, ' ')
+	if idx < 0 {
+		return challenge, params
+	}
+
+	scheme := challenge[:idx]
+	for _, param := range splitChallengeParams(challenge[idx+1:]) {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), "\"")
+	}
+
+	return scheme, params
+}
+
+// splitChallengeParams splits the challenge parameters by comma, ignoring the commas within quoted values
+func splitChallengeParams(params string) []string {
+	list := []string{}
+	quoted := false
+	start := 0
+
+	for i, r := range params {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				list = append(list, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+	list = append(list, params[start:])
+
+	return list
+}
+
+// drainAndClose consumes and closes the response body to reuse the connection
+func drainAndClose(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChallenge(t *testing.T) {
+	tests := []struct {
+		desc      string
+		challenge string
+		scheme    string
+		params    map[string]string
+	}{
+		{
+			desc:      "Testing parse a basic challenge",
+			challenge: `Basic realm="registry"`,
+			scheme:    "Basic",
+			params:    map[string]string{"realm": "registry"},
+		},
+		{
+			desc:      "Testing parse a bearer challenge with a scope that contains commas",
+			challenge: `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/ubuntu:pull,push"`,
+			scheme:    "Bearer",
+			params: map[string]string{
+				"realm":   "https://auth.docker.io/token",
+				"service": "registry.docker.io",
+				"scope":   "repository:library/ubuntu:pull,push",
+			},
+		},
+		{
+			desc:      "Testing parse a challenge without parameters",
+			challenge: "Basic",
+			scheme:    "Basic",
+			params:    map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			scheme, params := parseChallenge(test.challenge)
+			assert.Equal(t, test.scheme, scheme)
+			assert.Equal(t, test.params, params)
+		})
+	}
+}
+
+func TestNewRepositoryClient(t *testing.T) {
+	c := newRepositoryClient(nil, DefaultScheme, dockerHubDomain, "library/ubuntu", "", "")
+
+	assert.Equal(t, dockerHubRegistryHost, c.host)
+	assert.Equal(t, "https://registry-1.docker.io/v2/", c.url("/v2/"))
+}
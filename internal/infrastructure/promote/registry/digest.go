@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/distribution/reference"
+)
+
+// DigestResolver resolves the manifest digest of the images stored on a Docker registry through the Registry HTTP API v2
+type DigestResolver struct {
+	client HTTPClienter
+	scheme string
+}
+
+// NewDigestResolver returns a new DigestResolver
+func NewDigestResolver(client HTTPClienter, scheme string) *DigestResolver {
+
+	if scheme == "" {
+		scheme = DefaultScheme
+	}
+
+	return &DigestResolver{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// Digest returns the manifest digest of the image on the registry
+func (r *DigestResolver) Digest(ctx context.Context, name, username, password, identityToken, registryToken string) (string, error) {
+
+	var err error
+	var ref reference.Named
+	var digest string
+
+	errContext := "(registry::DigestResolver::Digest)"
+
+	if r.client == nil {
+		return "", errors.New(errContext, "HTTP client must be initialized before resolving an image digest")
+	}
+
+	if name == "" {
+		return "", errors.New(errContext, "Image name must be provided to resolve its digest")
+	}
+
+	ref, err = reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Image '%s' could not be parsed", name), err)
+	}
+	ref = reference.TagNameOnly(ref)
+
+	c := newRepositoryClient(r.client, r.scheme, reference.Domain(ref), reference.Path(ref), username, password).
+		withTokens(identityToken, registryToken)
+	err = c.authorize(ctx, fmt.Sprintf("repository:%s:pull", c.repository))
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Digest of image '%s' could not be resolved", name), err)
+	}
+
+	digest, err = c.getManifestDigest(ctx, manifestReference(ref))
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Digest of image '%s' could not be resolved", name), err)
+	}
+
+	return digest, nil
+}
+
+// Lookup returns the manifest digest of the image on the registry, and whether the image exists on the registry. Unlike Digest, an image that does not exist is not considered an error
+func (r *DigestResolver) Lookup(ctx context.Context, name, username, password, identityToken, registryToken string) (string, bool, error) {
+
+	var err error
+	var ref reference.Named
+	var digest string
+	var found bool
+
+	errContext := "(registry::DigestResolver::Lookup)"
+
+	if r.client == nil {
+		return "", false, errors.New(errContext, "HTTP client must be initialized before looking up an image digest")
+	}
+
+	if name == "" {
+		return "", false, errors.New(errContext, "Image name must be provided to look up its digest")
+	}
+
+	ref, err = reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", false, errors.New(errContext, fmt.Sprintf("Image '%s' could not be parsed", name), err)
+	}
+	ref = reference.TagNameOnly(ref)
+
+	c := newRepositoryClient(r.client, r.scheme, reference.Domain(ref), reference.Path(ref), username, password).
+		withTokens(identityToken, registryToken)
+	err = c.authorize(ctx, fmt.Sprintf("repository:%s:pull", c.repository))
+	if err != nil {
+		return "", false, errors.New(errContext, fmt.Sprintf("Digest of image '%s' could not be looked up", name), err)
+	}
+
+	digest, found, err = c.lookupManifestDigest(ctx, manifestReference(ref))
+	if err != nil {
+		return "", false, errors.New(errContext, fmt.Sprintf("Digest of image '%s' could not be looked up", name), err)
+	}
+
+	return digest, found, nil
+}
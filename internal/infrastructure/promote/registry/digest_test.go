@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigest(t *testing.T) {
+
+	errContext := "(registry::DigestResolver::Digest)"
+
+	registry := newRegistryStandIn().withAuth("username", "password")
+	defer registry.close()
+
+	pushImage(registry, "namespace/image", "1.2.3")
+	m, _ := registry.manifest("namespace/image", "1.2.3")
+
+	registryWithRefreshToken := newRegistryStandIn().withRefreshToken("refresh-token")
+	defer registryWithRefreshToken.close()
+	pushImage(registryWithRefreshToken, "namespace/image", "1.2.3")
+
+	registryWithoutDigestHeader := newRegistryStandIn()
+	registryWithoutDigestHeader.omitDigestHeader = true
+	defer registryWithoutDigestHeader.close()
+	pushImage(registryWithoutDigestHeader, "namespace/image", "1.2.3")
+
+	tests := []struct {
+		desc          string
+		resolver      *DigestResolver
+		name          string
+		username      string
+		password      string
+		identityToken string
+		registryToken string
+		res           string
+		err           error
+	}{
+		{
+			desc:     "Testing error resolving a digest when the client is not provided",
+			resolver: &DigestResolver{},
+			err:      errors.New(errContext, "HTTP client must be initialized before resolving an image digest"),
+		},
+		{
+			desc:     "Testing error resolving a digest when the image name is not provided",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			err:      errors.New(errContext, "Image name must be provided to resolve its digest"),
+		},
+		{
+			desc:     "Testing resolve an image digest",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/image:1.2.3", registry.host()),
+			username: "username",
+			password: "password",
+			res:      digestOf(m.content),
+			err:      &errors.Error{},
+		},
+		{
+			desc:          "Testing resolve an image digest using an identity token",
+			resolver:      NewDigestResolver(http.DefaultClient, "http"),
+			name:          fmt.Sprintf("%s/namespace/image:1.2.3", registryWithRefreshToken.host()),
+			identityToken: "refresh-token",
+			res:           digestOf(m.content),
+			err:           &errors.Error{},
+		},
+		{
+			desc:          "Testing resolve an image digest using a registry token",
+			resolver:      NewDigestResolver(http.DefaultClient, "http"),
+			name:          fmt.Sprintf("%s/namespace/image:1.2.3", registryWithRefreshToken.host()),
+			registryToken: "stand-in-token",
+			res:           digestOf(m.content),
+			err:           &errors.Error{},
+		},
+		{
+			desc:     "Testing resolve an image digest when the registry does not provide the digest header",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/image:1.2.3", registryWithoutDigestHeader.host()),
+			res:      digestOf(m.content),
+			err:      &errors.Error{},
+		},
+		{
+			desc:     "Testing error resolving the digest of an unknown image",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/unknown:1.2.3", registryWithoutDigestHeader.host()),
+			err: errors.New(errContext, fmt.Sprintf("Digest of image '%s/namespace/unknown:1.2.3' could not be resolved", registryWithoutDigestHeader.host()),
+				errors.New("", fmt.Sprintf("Manifest '1.2.3' could not be achieved from '%s/namespace/unknown'. Unexpected status code 404", registryWithoutDigestHeader.host()))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.resolver.Digest(context.TODO(), test.name, test.username, test.password, test.identityToken, test.registryToken)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+
+	errContext := "(registry::DigestResolver::Lookup)"
+
+	registry := newRegistryStandIn().withAuth("username", "password")
+	defer registry.close()
+
+	pushImage(registry, "namespace/image", "1.2.3")
+	m, _ := registry.manifest("namespace/image", "1.2.3")
+
+	registryWithRefreshToken := newRegistryStandIn().withRefreshToken("refresh-token")
+	defer registryWithRefreshToken.close()
+	pushImage(registryWithRefreshToken, "namespace/image", "1.2.3")
+
+	tests := []struct {
+		desc          string
+		resolver      *DigestResolver
+		name          string
+		username      string
+		password      string
+		identityToken string
+		registryToken string
+		res           string
+		found         bool
+		err           error
+	}{
+		{
+			desc:     "Testing error looking up a digest when the client is not provided",
+			resolver: &DigestResolver{},
+			err:      errors.New(errContext, "HTTP client must be initialized before looking up an image digest"),
+		},
+		{
+			desc:     "Testing error looking up a digest when the image name is not provided",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			err:      errors.New(errContext, "Image name must be provided to look up its digest"),
+		},
+		{
+			desc:     "Testing look up the digest of an existing image",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/image:1.2.3", registry.host()),
+			username: "username",
+			password: "password",
+			res:      digestOf(m.content),
+			found:    true,
+			err:      &errors.Error{},
+		},
+		{
+			desc:          "Testing look up the digest of an existing image using an identity token",
+			resolver:      NewDigestResolver(http.DefaultClient, "http"),
+			name:          fmt.Sprintf("%s/namespace/image:1.2.3", registryWithRefreshToken.host()),
+			identityToken: "refresh-token",
+			res:           digestOf(m.content),
+			found:         true,
+			err:           &errors.Error{},
+		},
+		{
+			desc:     "Testing look up the digest of an unknown image",
+			resolver: NewDigestResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/image:3.2.1", registry.host()),
+			username: "username",
+			password: "password",
+			res:      "",
+			found:    false,
+			err:      &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, found, err := test.resolver.Lookup(context.TODO(), test.name, test.username, test.password, test.identityToken, test.registryToken)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+				assert.Equal(t, test.found, found)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package registry
+
+import "net/http"
+
+// HTTPClienter is the client used to communicate with the Docker registries
+type HTTPClienter interface {
+	Do(req *http.Request) (*http.Response, error)
+}
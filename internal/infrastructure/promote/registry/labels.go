@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/distribution/reference"
+)
+
+// imageConfig contains the attributes of the image configuration required to achieve the image labels
+type imageConfig struct {
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+}
+
+// LabelsResolver resolves the labels of the images stored on a Docker registry through the Registry HTTP API v2
+type LabelsResolver struct {
+	client HTTPClienter
+	scheme string
+}
+
+// NewLabelsResolver returns a new LabelsResolver
+func NewLabelsResolver(client HTTPClienter, scheme string) *LabelsResolver {
+
+	if scheme == "" {
+		scheme = DefaultScheme
+	}
+
+	return &LabelsResolver{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// Labels returns the labels of the image on the registry. When the image is an index, the labels are achieved from its first manifest
+func (r *LabelsResolver) Labels(ctx context.Context, name, username, password, identityToken, registryToken string) (map[string]string, error) {
+
+	var err error
+	var ref reference.Named
+	var m *manifest
+	var config imageConfig
+
+	errContext := "(registry::LabelsResolver::Labels)"
+
+	if r.client == nil {
+		return nil, errors.New(errContext, "HTTP client must be initialized before resolving an image labels")
+	}
+
+	if name == "" {
+		return nil, errors.New(errContext, "Image name must be provided to resolve its labels")
+	}
+
+	ref, err = reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Image '%s' could not be parsed", name), err)
+	}
+	ref = reference.TagNameOnly(ref)
+
+	c := newRepositoryClient(r.client, r.scheme, reference.Domain(ref), reference.Path(ref), username, password).
+		withTokens(identityToken, registryToken)
+	err = c.authorize(ctx, fmt.Sprintf("repository:%s:pull", c.repository))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved", name), err)
+	}
+
+	m, err = c.getManifest(ctx, manifestReference(ref))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved", name), err)
+	}
+
+	if m.isIndex() {
+		if len(m.Manifests) == 0 {
+			return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved because its index is empty", name))
+		}
+
+		m, err = c.getManifest(ctx, m.Manifests[0].Digest)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved", name), err)
+		}
+	}
+
+	if m.Config == nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved because its manifest does not reference a configuration", name))
+	}
+
+	content, _, err := c.getBlob(ctx, m.Config.Digest)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Labels of image '%s' could not be resolved", name), err)
+	}
+	defer content.Close()
+
+	err = json.NewDecoder(content).Decode(&config)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Configuration of image '%s' could not be parsed", name), err)
+	}
+
+	if config.Config.Labels == nil {
+		return map[string]string{}, nil
+	}
+
+	return config.Config.Labels, nil
+}
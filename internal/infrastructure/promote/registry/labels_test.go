@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func pushLabeledImage(r *registryStandIn, repository, tag string, config []byte) {
+	configDigest := r.addBlob(repository, config)
+	layer := r.addBlob(repository, []byte(repository+"-layer"))
+
+	content := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":1},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":1}]}`,
+		MediaTypeOCIManifest, configDigest, layer)
+	r.addManifest(repository, tag, MediaTypeOCIManifest, []byte(content))
+}
+
+func TestLabels(t *testing.T) {
+
+	errContext := "(registry::LabelsResolver::Labels)"
+
+	registry := newRegistryStandIn().withAuth("username", "password")
+	defer registry.close()
+
+	pushLabeledImage(registry, "namespace/image", "1.2.3", []byte(`{"config":{"Labels":{"qa.approved":"true"}}}`))
+	pushLabeledImage(registry, "namespace/unlabeled", "1.2.3", []byte(`{"config":{}}`))
+
+	platformManifest, _ := registry.manifest("namespace/image", "1.2.3")
+	platformDigest := digestOf(platformManifest.content)
+	registry.addManifest("namespace/image", platformDigest, MediaTypeOCIManifest, platformManifest.content)
+	registry.addManifest("namespace/image", "index", MediaTypeOCIIndex,
+		[]byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","manifests":[{"mediaType":"%s","digest":"%s","size":1}]}`, MediaTypeOCIIndex, MediaTypeOCIManifest, platformDigest)))
+
+	registryWithRefreshToken := newRegistryStandIn().withRefreshToken("refresh-token")
+	defer registryWithRefreshToken.close()
+	pushLabeledImage(registryWithRefreshToken, "namespace/image", "1.2.3", []byte(`{"config":{"Labels":{"qa.approved":"true"}}}`))
+
+	tests := []struct {
+		desc          string
+		resolver      *LabelsResolver
+		name          string
+		username      string
+		password      string
+		identityToken string
+		res           map[string]string
+		err           error
+	}{
+		{
+			desc:     "Testing error resolving labels when the client is not provided",
+			resolver: &LabelsResolver{},
+			err:      errors.New(errContext, "HTTP client must be initialized before resolving an image labels"),
+		},
+		{
+			desc:     "Testing error resolving labels when the image name is not provided",
+			resolver: NewLabelsResolver(http.DefaultClient, "http"),
+			err:      errors.New(errContext, "Image name must be provided to resolve its labels"),
+		},
+		{
+			desc:     "Testing resolve an image labels",
+			resolver: NewLabelsResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/image:1.2.3", registry.host()),
+			username: "username",
+			password: "password",
+			res:      map[string]string{"qa.approved": "true"},
+			err:      &errors.Error{},
+		},
+		{
+			desc:     "Testing resolve an image index labels",
+			resolver: NewLabelsResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/image:index", registry.host()),
+			username: "username",
+			password: "password",
+			res:      map[string]string{"qa.approved": "true"},
+			err:      &errors.Error{},
+		},
+		{
+			desc:          "Testing resolve an image labels using an identity token",
+			resolver:      NewLabelsResolver(http.DefaultClient, "http"),
+			name:          fmt.Sprintf("%s/namespace/image:1.2.3", registryWithRefreshToken.host()),
+			identityToken: "refresh-token",
+			res:           map[string]string{"qa.approved": "true"},
+			err:           &errors.Error{},
+		},
+		{
+			desc:     "Testing resolve the labels of an image without labels",
+			resolver: NewLabelsResolver(http.DefaultClient, "http"),
+			name:     fmt.Sprintf("%s/namespace/unlabeled:1.2.3", registry.host()),
+			username: "username",
+			password: "password",
+			res:      map[string]string{},
+			err:      &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.resolver.Labels(context.TODO(), test.name, test.username, test.password, test.identityToken, "")
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// MediaTypeDockerManifest is the media type of a Docker image manifest, schema 2
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	// MediaTypeDockerManifestList is the media type of a Docker manifest list
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// MediaTypeOCIManifest is the media type of an OCI image manifest
+	MediaTypeOCIManifest = "application/vnd.oci.image.manifest.v1+json"
+	// MediaTypeOCIIndex is the media type of an OCI image index
+	MediaTypeOCIIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeDockerForeignLayer is the media type of a layer that must not be pushed to the registry
+	MediaTypeDockerForeignLayer = "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip"
+)
+
+// manifestAcceptedMediaTypes are the manifest media types that the registry promoter is able to copy
+var manifestAcceptedMediaTypes = []string{
+	MediaTypeOCIIndex,
+	MediaTypeDockerManifestList,
+	MediaTypeOCIManifest,
+	MediaTypeDockerManifest,
+}
+
+// descriptor describes the content referenced by a manifest
+type descriptor struct {
+	MediaType string   `json:"mediaType"`
+	Digest    string   `json:"digest"`
+	Size      int64    `json:"size"`
+	URLs      []string `json:"urls,omitempty"`
+}
+
+// manifest contains the attributes of image manifests and indexes required to copy them
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    *descriptor  `json:"config,omitempty"`
+	Layers    []descriptor `json:"layers,omitempty"`
+	Manifests []descriptor `json:"manifests,omitempty"`
+
+	// content is the raw manifest, it is pushed as is to keep its digest
+	content []byte
+}
+
+// parseManifest returns the manifest from its raw content. When media type is empty, it is taken from the manifest content
+func parseManifest(content []byte, mediaType string) (*manifest, error) {
+	errContext := "(registry::parseManifest)"
+
+	m := &manifest{}
+	err := json.Unmarshal(content, m)
+	if err != nil {
+		return nil, errors.New(errContext, "Manifest could not be parsed", err)
+	}
+
+	if mediaType != "" {
+		m.MediaType = mediaType
+	}
+	m.content = content
+
+	return m, nil
+}
+
+// digest returns the digest of the manifest content
+func (m *manifest) digest() string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(m.content))
+}
+
+// isIndex returns true when the manifest references other manifests
+func (m *manifest) isIndex() bool {
+	return m.MediaType == MediaTypeOCIIndex || m.MediaType == MediaTypeDockerManifestList || len(m.Manifests) > 0
+}
+
+// blobs returns the blobs referenced by an image manifest that must be copied to the target repository
+func (m *manifest) blobs() []descriptor {
+	blobs := []descriptor{}
+
+	if m.Config != nil {
+		blobs = append(blobs, *m.Config)
+	}
+
+	for _, layer := range m.Layers {
+		// foreign layers are not distributed by the registries
+		if layer.MediaType == MediaTypeDockerForeignLayer || len(layer.URLs) > 0 {
+			continue
+		}
+		blobs = append(blobs, layer)
+	}
+
+	return blobs
+}
@@ -0,0 +1,29 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDigestResolver is a mock of DigestResolver
+type MockDigestResolver struct {
+	mock.Mock
+}
+
+// NewMockDigestResolver returns a new MockDigestResolver
+func NewMockDigestResolver() *MockDigestResolver {
+	return &MockDigestResolver{}
+}
+
+// Digest returns the image digest
+func (m *MockDigestResolver) Digest(ctx context.Context, name, username, password, identityToken, registryToken string) (string, error) {
+	args := m.Called(ctx, name, username, password, identityToken, registryToken)
+	return args.String(0), args.Error(1)
+}
+
+// Lookup returns the image digest and whether the image exists
+func (m *MockDigestResolver) Lookup(ctx context.Context, name, username, password, identityToken, registryToken string) (string, bool, error) {
+	args := m.Called(ctx, name, username, password, identityToken, registryToken)
+	return args.String(0), args.Bool(1), args.Error(2)
+}
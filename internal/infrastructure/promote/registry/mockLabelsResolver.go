@@ -0,0 +1,23 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLabelsResolver is a mock of LabelsResolver
+type MockLabelsResolver struct {
+	mock.Mock
+}
+
+// NewMockLabelsResolver returns a new MockLabelsResolver
+func NewMockLabelsResolver() *MockLabelsResolver {
+	return &MockLabelsResolver{}
+}
+
+// Labels returns the image labels
+func (m *MockLabelsResolver) Labels(ctx context.Context, name, username, password, identityToken, registryToken string) (map[string]string, error) {
+	args := m.Called(ctx, name, username, password, identityToken, registryToken)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
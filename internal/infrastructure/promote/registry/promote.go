@@ -0,0 +1,305 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/distribution/reference"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+)
+
+const (
+	// DefaultScheme is the scheme used to reach the registries
+	DefaultScheme = "https"
+)
+
+// OptionsFunc defines the signature for an option function to set the registry promoter attributes
+type OptionsFunc func(*RegistryPromote)
+
+// RegistryPromote promotes images copying their manifests and blobs directly between Docker registries through the Registry HTTP API v2, without any Docker daemon
+type RegistryPromote struct {
+	client HTTPClienter
+	scheme string
+	writer io.Writer
+}
+
+// NewRegistryPromote returns a new RegistryPromote
+func NewRegistryPromote(client HTTPClienter, w io.Writer, opts ...OptionsFunc) *RegistryPromote {
+
+	if w == nil {
+		w = os.Stdout
+	}
+
+	p := &RegistryPromote{
+		client: client,
+		scheme: DefaultScheme,
+		writer: w,
+	}
+	p.Options(opts...)
+
+	return p
+}
+
+// WithScheme sets the scheme used to reach the registries
+func WithScheme(scheme string) OptionsFunc {
+	return func(p *RegistryPromote) {
+		p.scheme = scheme
+	}
+}
+
+// Options configures the registry promoter
+func (p *RegistryPromote) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(p)
+	}
+}
+
+// targetRepository is a repository where the image is promoted along with the tags to be set
+type targetRepository struct {
+	host       string
+	repository string
+	tags       []string
+}
+
+// Promote copies the source image to the target image and tags
+func (p *RegistryPromote) Promote(ctx context.Context, options *image.PromoteOptions) error {
+
+	var err error
+	var sourceRef reference.Named
+	var sourceManifest *manifest
+	var targets []*targetRepository
+
+	errContext := "(registry::Promote)"
+
+	if p.client == nil {
+		return errors.New(errContext, "HTTP client must be initialized before promote an image to docker registry")
+	}
+
+	if p.writer == nil {
+		return errors.New(errContext, "Writer must be initialized before promote an image to docker registry")
+	}
+
+	if options == nil {
+		return errors.New(errContext, "Image could not be promoted because options must be defined")
+	}
+
+	if options.SourceImageName == "" {
+		return errors.New(errContext, "Image could not be promoted because source image name must be defined on promote options")
+	}
+
+	if options.TargetImageName == "" {
+		return errors.New(errContext, "Image could not be promoted because target image name must be defined on promote options")
+	}
+
+	sourceRef, err = reference.ParseNormalizedNamed(options.SourceImageName)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Image '%s' could not be parsed", options.SourceImageName), err)
+	}
+	sourceRef = reference.TagNameOnly(sourceRef)
+
+	targets, err = groupTargets(append([]string{options.TargetImageName}, options.TargetImageTags...))
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	source := newRepositoryClient(p.client, p.scheme, reference.Domain(sourceRef), reference.Path(sourceRef), options.PullAuthUsername, options.PullAuthPassword).
+		withTokens(options.PullAuthIdentityToken, options.PullAuthRegistryToken)
+	err = source.authorize(ctx, fmt.Sprintf("repository:%s:pull", source.repository))
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
+	}
+
+	sourceManifest, err = source.getManifest(ctx, manifestReference(sourceRef))
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
+	}
+
+	for _, t := range targets {
+		target := newRepositoryClient(p.client, p.scheme, t.host, t.repository, options.PushAuthUsername, options.PushAuthPassword).
+			withTokens(options.PushAuthIdentityToken, options.PushAuthRegistryToken)
+
+		scopes := []string{fmt.Sprintf("repository:%s:pull,push", target.repository)}
+		if isSameRegistry(source, target) {
+			// mounting blobs from the source repository requires to pull from it
+			scopes = append(scopes, fmt.Sprintf("repository:%s:pull", source.repository))
+		}
+
+		err = target.authorize(ctx, scopes...)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
+		}
+
+		err = p.copyManifestContent(ctx, source, target, sourceManifest)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
+		}
+
+		for _, tag := range t.tags {
+			err = target.putManifest(ctx, tag, sourceManifest)
+			if err != nil {
+				return errors.New(errContext, fmt.Sprintf("Image '%s' could not be promoted", options.SourceImageName), err)
+			}
+			fmt.Fprintf(p.writer, "%s/%s:%s: promoted from '%s'\n", target.host, target.repository, tag, options.SourceImageName)
+		}
+	}
+
+	return nil
+}
+
+// copyManifestContent copies to the target repository the content referenced by the manifest. Manifests referenced by an index are copied by digest
+func (p *RegistryPromote) copyManifestContent(ctx context.Context, source, target *repositoryClient, m *manifest) error {
+
+	var err error
+	var child *manifest
+
+	errContext := "(registry::copyManifestContent)"
+
+	if m.isIndex() {
+		for _, desc := range m.Manifests {
+			child, err = source.getManifest(ctx, desc.Digest)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			if child.MediaType == "" {
+				child.MediaType = desc.MediaType
+			}
+
+			err = p.copyManifestContent(ctx, source, target, child)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+
+			err = target.putManifest(ctx, desc.Digest, child)
+			if err != nil {
+				return errors.New(errContext, "", err)
+			}
+		}
+
+		return nil
+	}
+
+	for _, blob := range m.blobs() {
+		err = p.copyBlob(ctx, source, target, blob)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
+	return nil
+}
+
+// copyBlob copies a blob to the target repository. Blobs that already exist are skipped, and blobs on the same registry are mounted when it is possible
+func (p *RegistryPromote) copyBlob(ctx context.Context, source, target *repositoryClient, blob descriptor) error {
+
+	var err error
+	var exists, mounted bool
+	var location string
+	var content io.ReadCloser
+	var size int64
+
+	errContext := "(registry::copyBlob)"
+
+	exists, err = target.blobExists(ctx, blob.Digest)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if exists {
+		fmt.Fprintf(p.writer, "%s: blob already exists on '%s/%s'\n", blob.Digest, target.host, target.repository)
+		return nil
+	}
+
+	if isSameRegistry(source, target) && source.repository != target.repository {
+		mounted, location, err = target.mountBlob(ctx, blob.Digest, source.repository)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		if mounted {
+			fmt.Fprintf(p.writer, "%s: blob mounted from '%s'\n", blob.Digest, source.repository)
+			return nil
+		}
+	}
+
+	if location == "" {
+		location, err = target.startUpload(ctx)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
+	content, size, err = source.getBlob(ctx, blob.Digest)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	defer content.Close()
+
+	if size < 0 {
+		size = blob.Size
+	}
+
+	err = target.uploadBlob(ctx, location, blob.Digest, content, size)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+	fmt.Fprintf(p.writer, "%s: blob copied to '%s/%s'\n", blob.Digest, target.host, target.repository)
+
+	return nil
+}
+
+// groupTargets groups the target images by repository, keeping the order in which they are defined
+func groupTargets(names []string) ([]*targetRepository, error) {
+	errContext := "(registry::groupTargets)"
+
+	targets := []*targetRepository{}
+	index := map[string]*targetRepository{}
+
+	for _, name := range names {
+		ref, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Image '%s' could not be parsed", name), err)
+		}
+		ref = reference.TagNameOnly(ref)
+
+		tagged, isTagged := ref.(reference.Tagged)
+		if !isTagged {
+			return nil, errors.New(errContext, fmt.Sprintf("Target image '%s' must be tagged", name))
+		}
+
+		t, exists := index[ref.Name()]
+		if !exists {
+			t = &targetRepository{
+				host:       reference.Domain(ref),
+				repository: reference.Path(ref),
+				tags:       []string{},
+			}
+			index[ref.Name()] = t
+			targets = append(targets, t)
+		}
+		t.tags = append(t.tags, tagged.Tag())
+	}
+
+	return targets, nil
+}
+
+// manifestReference returns the digest of the reference when it is defined, otherwise it returns the tag
+func manifestReference(ref reference.Named) string {
+	if digested, isDigested := ref.(reference.Digested); isDigested {
+		return digested.Digest().String()
+	}
+
+	if tagged, isTagged := ref.(reference.Tagged); isTagged {
+		return tagged.Tag()
+	}
+
+	return "latest"
+}
+
+// isSameRegistry returns true when both repositories are on the same registry
+func isSameRegistry(source, target *repositoryClient) bool {
+	return source.scheme == target.scheme && source.host == target.host
+}
@@ -0,0 +1,249 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/stretchr/testify/assert"
+)
+
+// pushImage stores an image with a config and two layers on the registry stand-in and returns its blobs digests
+func pushImage(r *registryStandIn, repository, tag string) []string {
+	config := r.addBlob(repository, []byte(fmt.Sprintf(`{"config":"%s"}`, repository)))
+	layer1 := r.addBlob(repository, []byte(repository+"-layer-1"))
+	layer2 := r.addBlob(repository, []byte(repository+"-layer-2"))
+
+	content := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":1},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":1},{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":1}]}`,
+		MediaTypeOCIManifest, config, layer1, layer2)
+	r.addManifest(repository, tag, MediaTypeOCIManifest, []byte(content))
+
+	return []string{config, layer1, layer2}
+}
+
+func TestNewRegistryPromote(t *testing.T) {
+	p := NewRegistryPromote(http.DefaultClient, nil, WithScheme("http"))
+
+	assert.NotNil(t, p.client, "Failed because client is nil")
+	assert.NotNil(t, p.writer, "Failed because writer is nil")
+	assert.Equal(t, "http", p.scheme)
+}
+
+func TestPromote(t *testing.T) {
+
+	errContext := "(registry::Promote)"
+
+	tests := []struct {
+		desc       string
+		promote    *RegistryPromote
+		options    *image.PromoteOptions
+		assertFunc func(*testing.T, *RegistryPromote, *image.PromoteOptions)
+		err        error
+	}{
+		{
+			desc:    "Testing error promoting an image when the client is not provided",
+			promote: &RegistryPromote{},
+			err:     errors.New(errContext, "HTTP client must be initialized before promote an image to docker registry"),
+		},
+		{
+			desc: "Testing error promoting an image when options are not provided",
+			promote: &RegistryPromote{
+				client: http.DefaultClient,
+				writer: ioutil.Discard,
+			},
+			err: errors.New(errContext, "Image could not be promoted because options must be defined"),
+		},
+		{
+			desc: "Testing error promoting an image when target image name is not provided",
+			promote: &RegistryPromote{
+				client: http.DefaultClient,
+				writer: ioutil.Discard,
+			},
+			options: &image.PromoteOptions{
+				SourceImageName: "registry.test/namespace/image:tag",
+			},
+			err: errors.New(errContext, "Image could not be promoted because target image name must be defined on promote options"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.promote.Promote(context.TODO(), test.options)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.promote, test.options)
+			}
+		})
+	}
+}
+
+func TestPromoteBetweenRegistries(t *testing.T) {
+	source := newRegistryStandIn()
+	defer source.close()
+	target := newRegistryStandIn().withAuth("username", "password")
+	defer target.close()
+
+	blobs := pushImage(source, "namespace/image", "1.2.3")
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName:  fmt.Sprintf("%s/namespace/image:1.2.3", source.host()),
+		TargetImageName:  fmt.Sprintf("%s/stable/image:1.2.3", target.host()),
+		TargetImageTags:  []string{fmt.Sprintf("%s/stable/image:1", target.host())},
+		PushAuthUsername: "username",
+		PushAuthPassword: "password",
+	})
+	assert.NoError(t, err)
+
+	for _, blob := range blobs {
+		assert.True(t, target.hasBlob("stable/image", blob), "Blob '%s' has not been copied", blob)
+	}
+	assert.Equal(t, 3, target.pushes)
+	assert.Equal(t, 0, target.mounts)
+
+	sourceManifest, _ := source.manifest("namespace/image", "1.2.3")
+	for _, tag := range []string{"1.2.3", "1"} {
+		targetManifest, exists := target.manifest("stable/image", tag)
+		assert.True(t, exists, "Tag '%s' has not been promoted", tag)
+		assert.Equal(t, sourceManifest, targetManifest)
+	}
+}
+
+func TestPromoteWithinRegistryMountsBlobs(t *testing.T) {
+	registry := newRegistryStandIn()
+	defer registry.close()
+
+	blobs := pushImage(registry, "staging/image", "1.2.3")
+	// the target repository already has one of the blobs
+	registry.addBlob("stable/image", []byte("staging/image-layer-1"))
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName: fmt.Sprintf("%s/staging/image:1.2.3", registry.host()),
+		TargetImageName: fmt.Sprintf("%s/stable/image:1.2.3", registry.host()),
+	})
+	assert.NoError(t, err)
+
+	for _, blob := range blobs {
+		assert.True(t, registry.hasBlob("stable/image", blob), "Blob '%s' has not been copied", blob)
+	}
+	assert.Equal(t, 2, registry.mounts, "Existing blobs must not be mounted")
+	assert.Equal(t, 0, registry.pushes, "Blobs must be mounted rather than uploaded")
+
+	_, exists := registry.manifest("stable/image", "1.2.3")
+	assert.True(t, exists)
+}
+
+func TestPromoteImageIndex(t *testing.T) {
+	source := newRegistryStandIn()
+	defer source.close()
+	target := newRegistryStandIn()
+	defer target.close()
+
+	amd64Blobs := pushImage(source, "namespace/image", "amd64")
+	amd64Manifest, _ := source.manifest("namespace/image", "amd64")
+
+	index := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","manifests":[{"mediaType":"%s","digest":"%s","size":%d,"platform":{"architecture":"amd64","os":"linux"}}]}`,
+		MediaTypeOCIIndex, MediaTypeOCIManifest, digestOf(amd64Manifest.content), len(amd64Manifest.content))
+	source.addManifest("namespace/image", "1.2.3", MediaTypeOCIIndex, []byte(index))
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName: fmt.Sprintf("%s/namespace/image:1.2.3", source.host()),
+		TargetImageName: fmt.Sprintf("%s/namespace/image:1.2.3", target.host()),
+	})
+	assert.NoError(t, err)
+
+	for _, blob := range amd64Blobs {
+		assert.True(t, target.hasBlob("namespace/image", blob), "Blob '%s' has not been copied", blob)
+	}
+
+	_, exists := target.manifest("namespace/image", digestOf(amd64Manifest.content))
+	assert.True(t, exists, "Index child manifest has not been copied")
+
+	targetIndex, exists := target.manifest("namespace/image", "1.2.3")
+	assert.True(t, exists)
+	assert.Equal(t, MediaTypeOCIIndex, targetIndex.mediaType)
+	assert.Equal(t, index, string(targetIndex.content))
+}
+
+func TestPromoteSourceNotFound(t *testing.T) {
+	source := newRegistryStandIn()
+	defer source.close()
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName: fmt.Sprintf("%s/namespace/image:1.2.3", source.host()),
+		TargetImageName: fmt.Sprintf("%s/stable/image:1.2.3", source.host()),
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("Manifest '1.2.3' could not be achieved from '%s/namespace/image'. Unexpected status code 404", source.host()))
+}
+
+func TestPromoteUnauthorized(t *testing.T) {
+	source := newRegistryStandIn()
+	defer source.close()
+	target := newRegistryStandIn().withAuth("username", "password")
+	defer target.close()
+
+	pushImage(source, "namespace/image", "1.2.3")
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName:  fmt.Sprintf("%s/namespace/image:1.2.3", source.host()),
+		TargetImageName:  fmt.Sprintf("%s/stable/image:1.2.3", target.host()),
+		PushAuthUsername: "username",
+		PushAuthPassword: "wrong",
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unexpected status code 401")
+}
+
+func TestPromoteUsingTokens(t *testing.T) {
+	source := newRegistryStandIn().withAuth("", "")
+	defer source.close()
+	target := newRegistryStandIn().withRefreshToken("refresh-token")
+	defer target.close()
+
+	pushImage(source, "namespace/image", "1.2.3")
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName:       fmt.Sprintf("%s/namespace/image:1.2.3", source.host()),
+		TargetImageName:       fmt.Sprintf("%s/stable/image:1.2.3", target.host()),
+		PullAuthRegistryToken: "stand-in-token",
+		PushAuthIdentityToken: "refresh-token",
+	})
+
+	assert.Nil(t, err)
+	_, exists := target.manifest("stable/image", "1.2.3")
+	assert.True(t, exists)
+}
+
+func TestPromoteUsingInvalidIdentityToken(t *testing.T) {
+	source := newRegistryStandIn()
+	defer source.close()
+	target := newRegistryStandIn().withRefreshToken("refresh-token")
+	defer target.close()
+
+	pushImage(source, "namespace/image", "1.2.3")
+
+	p := NewRegistryPromote(http.DefaultClient, ioutil.Discard, WithScheme("http"))
+	err := p.Promote(context.TODO(), &image.PromoteOptions{
+		SourceImageName:       fmt.Sprintf("%s/namespace/image:1.2.3", source.host()),
+		TargetImageName:       fmt.Sprintf("%s/stable/image:1.2.3", target.host()),
+		PushAuthIdentityToken: "wrong",
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "using the identity token. Unexpected status code 401")
+}
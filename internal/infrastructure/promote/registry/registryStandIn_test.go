@@ -0,0 +1,265 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// registryStandIn is an in-process Docker registry that implements the subset of the Registry HTTP API v2 used by the registry promoter
+type registryStandIn struct {
+	server *httptest.Server
+
+	mutex     sync.Mutex
+	blobs     map[string]map[string][]byte
+	manifests map[string]map[string]storedManifest
+	uploads   map[string]string
+	mounts    int
+	pushes    int
+
+	// omitDigestHeader disables the Docker-Content-Digest header on manifest responses
+	omitDigestHeader bool
+
+	// username and password enable the token authorization when they are defined
+	username string
+	password string
+	token    string
+
+	// refreshToken enables exchanging an OAuth2 refresh token by the token
+	refreshToken string
+}
+
+type storedManifest struct {
+	mediaType string
+	content   []byte
+}
+
+func newRegistryStandIn() *registryStandIn {
+	r := &registryStandIn{
+		blobs:     map[string]map[string][]byte{},
+		manifests: map[string]map[string]storedManifest{},
+		uploads:   map[string]string{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+
+	return r
+}
+
+func (r *registryStandIn) withAuth(username, password string) *registryStandIn {
+	r.username = username
+	r.password = password
+	r.token = "stand-in-token"
+
+	return r
+}
+
+func (r *registryStandIn) withRefreshToken(refreshToken string) *registryStandIn {
+	r.refreshToken = refreshToken
+	r.token = "stand-in-token"
+
+	return r
+}
+
+func (r *registryStandIn) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func (r *registryStandIn) close() {
+	r.server.Close()
+}
+
+func digestOf(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+func (r *registryStandIn) addBlob(repository string, content []byte) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	digest := digestOf(content)
+	if r.blobs[repository] == nil {
+		r.blobs[repository] = map[string][]byte{}
+	}
+	r.blobs[repository][digest] = content
+
+	return digest
+}
+
+func (r *registryStandIn) addManifest(repository, ref, mediaType string, content []byte) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	digest := digestOf(content)
+	if r.manifests[repository] == nil {
+		r.manifests[repository] = map[string]storedManifest{}
+	}
+	r.manifests[repository][ref] = storedManifest{mediaType: mediaType, content: content}
+	r.manifests[repository][digest] = storedManifest{mediaType: mediaType, content: content}
+
+	return digest
+}
+
+func (r *registryStandIn) hasBlob(repository, digest string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, exists := r.blobs[repository][digest]
+	return exists
+}
+
+func (r *registryStandIn) manifest(repository, ref string) (storedManifest, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	m, exists := r.manifests[repository][ref]
+	return m, exists
+}
+
+func (r *registryStandIn) authorized(req *http.Request) bool {
+	if r.token == "" {
+		return true
+	}
+
+	return req.Header.Get("Authorization") == "Bearer "+r.token
+}
+
+func (r *registryStandIn) serveHTTP(w http.ResponseWriter, req *http.Request) {
+
+	if req.URL.Path == "/token" && req.Method == http.MethodPost {
+		if req.FormValue("grant_type") != "refresh_token" || r.refreshToken == "" || req.FormValue("refresh_token") != r.refreshToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"%s"}`, r.token)
+		return
+	}
+
+	if req.URL.Path == "/token" {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != r.username || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"token":"%s"}`, r.token)
+		return
+	}
+
+	if !r.authorized(req) {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="stand-in"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := req.URL.Path
+	switch {
+	case path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+
+	case strings.Contains(path, "/manifests/"):
+		idx := strings.Index(path, "/manifests/")
+		r.serveManifest(w, req, path[len("/v2/"):idx], path[idx+len("/manifests/"):])
+
+	case strings.Contains(path, "/blobs/uploads/"):
+		idx := strings.Index(path, "/blobs/uploads/")
+		r.serveUpload(w, req, path[len("/v2/"):idx], path[idx+len("/blobs/uploads/"):])
+
+	case strings.Contains(path, "/blobs/"):
+		idx := strings.Index(path, "/blobs/")
+		r.serveBlob(w, req, path[len("/v2/"):idx], path[idx+len("/blobs/"):])
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *registryStandIn) serveManifest(w http.ResponseWriter, req *http.Request, repository, ref string) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		m, exists := r.manifest(repository, ref)
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", m.mediaType)
+		if !r.omitDigestHeader {
+			w.Header().Set("Docker-Content-Digest", digestOf(m.content))
+		}
+		if req.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Write(m.content)
+
+	case http.MethodPut:
+		content, _ := io.ReadAll(req.Body)
+		r.addManifest(repository, ref, req.Header.Get("Content-Type"), content)
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *registryStandIn) serveBlob(w http.ResponseWriter, req *http.Request, repository, digest string) {
+	r.mutex.Lock()
+	content, exists := r.blobs[repository][digest]
+	r.mutex.Unlock()
+
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	if req.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.Write(content)
+}
+
+func (r *registryStandIn) serveUpload(w http.ResponseWriter, req *http.Request, repository, id string) {
+	switch req.Method {
+	case http.MethodPost:
+		mount := req.URL.Query().Get("mount")
+		from := req.URL.Query().Get("from")
+		if mount != "" && r.hasBlob(from, mount) {
+			r.mutex.Lock()
+			content := r.blobs[from][mount]
+			r.mounts++
+			r.mutex.Unlock()
+
+			r.addBlob(repository, content)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		r.mutex.Lock()
+		id = fmt.Sprintf("upload-%d", len(r.uploads))
+		r.uploads[id] = repository
+		r.mutex.Unlock()
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", repository, id))
+		w.WriteHeader(http.StatusAccepted)
+
+	case http.MethodPut:
+		content, _ := io.ReadAll(req.Body)
+		if digestOf(content) != req.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		r.addBlob(repository, content)
+		r.mutex.Lock()
+		r.pushes++
+		r.mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -6,3 +6,8 @@ import "context"
 type BuildCommander interface {
 	Execute(context.Context) error
 }
+
+// PromoteCommander interface defines the command to promote a docker image
+type PromoteCommander interface {
+	Execute(context.Context) error
+}
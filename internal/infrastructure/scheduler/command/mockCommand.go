@@ -21,3 +21,19 @@ func (c *MockBuildCommand) Execute(ctx context.Context) error {
 	args := c.Called(ctx)
 	return args.Error(0)
 }
+
+// MockPromoteCommand is a mock of PromoteCommand
+type MockPromoteCommand struct {
+	mock.Mock
+}
+
+// NewMockPromoteCommand creates a MockPromoteCommand
+func NewMockPromoteCommand() *MockPromoteCommand {
+	return &MockPromoteCommand{}
+}
+
+// Execute performs the action
+func (c *MockPromoteCommand) Execute(ctx context.Context) error {
+	args := c.Called(ctx)
+	return args.Error(0)
+}
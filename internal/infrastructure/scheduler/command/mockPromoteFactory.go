@@ -0,0 +1,23 @@
+package command
+
+import (
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPromoteCommandFactory is a factory to create a mock promote command
+type MockPromoteCommandFactory struct {
+	mock.Mock
+}
+
+// NewMockPromoteCommandFactory returns a new mock promote command factory
+func NewMockPromoteCommandFactory() *MockPromoteCommandFactory {
+	return &MockPromoteCommandFactory{}
+}
+
+// New returns a new promote command
+func (f *MockPromoteCommandFactory) New(promoter repository.Promoter, options *image.PromoteOptions) PromoteCommander {
+	args := f.Called(promoter, options)
+	return args.Get(0).(PromoteCommander)
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// PromoteCommand contains details to promote a docker image
+type PromoteCommand struct {
+	promoter repository.Promoter
+	options  *image.PromoteOptions
+}
+
+// NewPromoteCommand creates a command to promote docker images
+func NewPromoteCommand(promoter repository.Promoter, options *image.PromoteOptions) *PromoteCommand {
+	return &PromoteCommand{
+		promoter: promoter,
+		options:  options,
+	}
+}
+
+// Execute performs the action
+func (c *PromoteCommand) Execute(ctx context.Context) error {
+	errContext := "(command::PromoteCommand::Execute)"
+
+	if c.promoter == nil {
+		return errors.New(errContext, "A promoter is required to execute a promote command")
+	}
+
+	if c.options == nil {
+		return errors.New(errContext, "Options are required to execute a promote command")
+	}
+
+	return c.promoter.Promote(ctx, c.options)
+}
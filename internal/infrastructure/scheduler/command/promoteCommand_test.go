@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/gostevedore/stevedore/internal/infrastructure/promote/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteCommandExecute(t *testing.T) {
+
+	errContext := "(command::PromoteCommand::Execute)"
+
+	tests := []struct {
+		desc              string
+		command           *PromoteCommand
+		prepareAssertFunc func(command *PromoteCommand)
+		assertFunc        func(*testing.T, *PromoteCommand)
+		err               error
+	}{
+		{
+			desc:    "Testing error when promoter is nil",
+			command: &PromoteCommand{},
+			err:     errors.New(errContext, "A promoter is required to execute a promote command"),
+		},
+		{
+			desc: "Testing error when options are nil",
+			command: &PromoteCommand{
+				promoter: mock.NewMockPromote(),
+			},
+			err: errors.New(errContext, "Options are required to execute a promote command"),
+		},
+		{
+			desc: "Testing execute promote command",
+			command: NewPromoteCommand(
+				mock.NewMockPromote(),
+				&image.PromoteOptions{
+					SourceImageName: "registry.test/namespace/image:tag",
+					TargetImageName: "registry.prod/namespace/image:tag",
+				},
+			),
+			prepareAssertFunc: func(command *PromoteCommand) {
+				command.promoter.(*mock.MockPromote).On("Promote", context.TODO(), command.options).Return(nil)
+			},
+			assertFunc: func(t *testing.T, command *PromoteCommand) {
+				assert.True(t, command.promoter.(*mock.MockPromote).AssertExpectations(t))
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.command)
+			}
+
+			err := test.command.Execute(context.TODO())
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.command)
+			}
+		})
+	}
+}
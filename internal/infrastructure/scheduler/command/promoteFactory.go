@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/gostevedore/stevedore/internal/core/domain/image"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+)
+
+// PromoteCommandFactory is a factory to create a promote command
+type PromoteCommandFactory struct{}
+
+// NewPromoteCommandFactory creates a new promote command factory
+func NewPromoteCommandFactory() *PromoteCommandFactory {
+	return &PromoteCommandFactory{}
+}
+
+// New returns a new promote command
+func (f *PromoteCommandFactory) New(promoter repository.Promoter, options *image.PromoteOptions) PromoteCommander {
+	return NewPromoteCommand(promoter, options)
+}
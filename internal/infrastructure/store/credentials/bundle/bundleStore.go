@@ -0,0 +1,197 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// BundleVersion1 is the version of the credentials bundle content
+	BundleVersion1 = "v1"
+)
+
+// OptionsFunc defines the signature for an option function to set bundle credentials store
+type OptionsFunc func(opts *BundleStore)
+
+// BundleStore is a credentials store persisted on a single encrypted file. It is used to move the credentials between stores and machines
+type BundleStore struct {
+	fs         afero.Fs
+	path       string
+	encryption repository.CredentialsEncrypter
+}
+
+// bundle is the credentials bundle content, before it is encrypted
+type bundle struct {
+	Version     string              `json:"version"`
+	Credentials []*bundleCredential `json:"credentials"`
+}
+
+// bundleCredential is a credential on the credentials bundle
+type bundleCredential struct {
+	ID         string                  `json:"id"`
+	Credential *credentials.Credential `json:"credential"`
+}
+
+// NewBundleStore creates a new bundle credentials store
+func NewBundleStore(opts ...OptionsFunc) *BundleStore {
+	store := &BundleStore{}
+	store.Options(opts...)
+
+	return store
+}
+
+// WithFilesystem sets the filesystem to bundle credentials store
+func WithFilesystem(fs afero.Fs) OptionsFunc {
+	return func(s *BundleStore) {
+		s.fs = fs
+	}
+}
+
+// WithPath sets the bundle file path to bundle credentials store
+func WithPath(path string) OptionsFunc {
+	return func(s *BundleStore) {
+		s.path = path
+	}
+}
+
+// WithEncryption sets the encryption to bundle credentials store
+func WithEncryption(encryption repository.CredentialsEncrypter) OptionsFunc {
+	return func(s *BundleStore) {
+		s.encryption = encryption
+	}
+}
+
+// Options provides the options to bundle credentials store
+func (s *BundleStore) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Write persists the credentials on the bundle file, replacing its content
+func (s *BundleStore) Write(all []*credentials.Credential) error {
+	var err error
+	var data []byte
+	var ciphertext string
+
+	errContext := "(store::credentials::bundle::Write)"
+
+	err = s.validate()
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	content := &bundle{
+		Version:     BundleVersion1,
+		Credentials: []*bundleCredential{},
+	}
+
+	for _, credential := range all {
+		if credential == nil {
+			continue
+		}
+
+		if credential.ID == "" {
+			return errors.New(errContext, "Credentials bundle requires an id for each credential")
+		}
+
+		content.Credentials = append(content.Credentials, &bundleCredential{
+			ID:         credential.ID,
+			Credential: credential,
+		})
+	}
+
+	sort.Slice(content.Credentials, func(i, j int) bool {
+		return content.Credentials[i].ID < content.Credentials[j].ID
+	})
+
+	data, err = json.Marshal(content)
+	if err != nil {
+		return errors.New(errContext, "Error formating the credentials bundle", err)
+	}
+
+	ciphertext, err = s.encryption.Encrypt(string(data))
+	if err != nil {
+		return errors.New(errContext, "Error encrypting the credentials bundle", err)
+	}
+
+	err = afero.WriteFile(s.fs, s.path, []byte(ciphertext+"\n"), 0600)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error writing the credentials bundle '%s'", s.path), err)
+	}
+
+	return nil
+}
+
+// All returns all the credentials persisted on the bundle file
+func (s *BundleStore) All() ([]*credentials.Credential, error) {
+	var err error
+	var data []byte
+	var plaintext string
+
+	errContext := "(store::credentials::bundle::All)"
+
+	err = s.validate()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	data, err = afero.ReadFile(s.fs, s.path)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading the credentials bundle '%s'", s.path), err)
+	}
+
+	plaintext, err = s.encryption.Decrypt(string(bytes.TrimRight(data, "\r\n")))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error decrypting the credentials bundle '%s'. Ensure the passphrase is correct", s.path), err)
+	}
+
+	content := &bundle{}
+	err = json.Unmarshal([]byte(plaintext), content)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error parsing the credentials bundle '%s'", s.path), err)
+	}
+
+	if content.Version != BundleVersion1 {
+		return nil, errors.New(errContext, fmt.Sprintf("Credentials bundle version '%s' is not supported", content.Version))
+	}
+
+	all := []*credentials.Credential{}
+	for _, item := range content.Credentials {
+		if item == nil || item.Credential == nil {
+			continue
+		}
+
+		item.Credential.ID = item.ID
+		all = append(all, item.Credential)
+	}
+
+	return all, nil
+}
+
+// validate checks that the bundle credentials store has all its requirements
+func (s *BundleStore) validate() error {
+
+	errContext := "(store::credentials::bundle::validate)"
+
+	if s.fs == nil {
+		return errors.New(errContext, "Bundle credentials store requires a filesystem")
+	}
+
+	if s.path == "" {
+		return errors.New(errContext, "Bundle credentials store requires the credentials bundle path")
+	}
+
+	if s.encryption == nil {
+		return errors.New(errContext, "Bundle credentials store requires encryption")
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package bundle
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	errContext := "(store::credentials::bundle::Write)"
+
+	tests := []struct {
+		desc        string
+		store       *BundleStore
+		credentials []*credentials.Credential
+		assertFunc  func(*testing.T, *BundleStore)
+		err         error
+	}{
+		{
+			desc:  "Testing error writing a credentials bundle without filesystem",
+			store: NewBundleStore(),
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::bundle::validate)", "Bundle credentials store requires a filesystem")),
+		},
+		{
+			desc: "Testing error writing a credentials bundle without encryption",
+			store: NewBundleStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/credentials.bundle"),
+			),
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::bundle::validate)", "Bundle credentials store requires encryption")),
+		},
+		{
+			desc: "Testing error writing a credentials bundle with a credential without id",
+			store: NewBundleStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/credentials.bundle"),
+				WithEncryption(encryption.NewEncryption(encryption.WithKey("passphrase"))),
+			),
+			credentials: []*credentials.Credential{
+				{Username: "username", Password: "password"},
+			},
+			err: errors.New(errContext, "Credentials bundle requires an id for each credential"),
+		},
+		{
+			desc: "Testing write a credentials bundle",
+			store: NewBundleStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("/credentials.bundle"),
+				WithEncryption(encryption.NewEncryption(encryption.WithKey("passphrase"))),
+			),
+			credentials: []*credentials.Credential{
+				{ID: "registry.example.com", Username: "username", Password: "password"},
+			},
+			assertFunc: func(t *testing.T, s *BundleStore) {
+				data, err := afero.ReadFile(s.fs, s.path)
+				assert.NoError(t, err)
+				assert.NotContains(t, string(data), "password")
+				assert.False(t, encryption.IsLegacy(string(data)))
+
+				info, err := s.fs.Stat(s.path)
+				assert.NoError(t, err)
+				assert.Equal(t, "-rw-------", info.Mode().String())
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.store.Write(test.credentials)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.store)
+			}
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	errContext := "(store::credentials::bundle::All)"
+
+	fs := afero.NewMemMapFs()
+	enc := encryption.NewEncryption(encryption.WithKey("passphrase"))
+	all := []*credentials.Credential{
+		{ID: "registry.example.com", Username: "username", Password: "password"},
+		{ID: "github.com", PrivateKeyFile: "/id_rsa"},
+	}
+
+	err := NewBundleStore(
+		WithFilesystem(fs),
+		WithPath("/credentials.bundle"),
+		WithEncryption(enc),
+	).Write(all)
+	assert.NoError(t, err)
+
+	unsupported, _ := enc.Encrypt(`{"version":"v9","credentials":[]}`)
+	_ = afero.WriteFile(fs, "/unsupported.bundle", []byte(unsupported), 0600)
+
+	tests := []struct {
+		desc  string
+		store *BundleStore
+		res   []*credentials.Credential
+		err   error
+	}{
+		{
+			desc: "Testing error reading a credentials bundle that does not exist",
+			store: NewBundleStore(
+				WithFilesystem(fs),
+				WithPath("/missing.bundle"),
+				WithEncryption(enc),
+			),
+			err: errors.New(errContext, "Error reading the credentials bundle '/missing.bundle'",
+				errors.New("", "open /missing.bundle: file does not exist")),
+		},
+		{
+			desc: "Testing error reading a credentials bundle with a wrong passphrase",
+			store: NewBundleStore(
+				WithFilesystem(fs),
+				WithPath("/credentials.bundle"),
+				WithEncryption(encryption.NewEncryption(encryption.WithKey("wrong-passphrase"))),
+			),
+			err: errors.New(errContext, "Error decrypting the credentials bundle '/credentials.bundle'. Ensure the passphrase is correct",
+				errors.New("(store::credentials::encryption::Decrypt)", "",
+					errors.New("(store::credentials::encryption::decryptEnvelope)", "",
+						errors.New("", "cipher: message authentication failed")))),
+		},
+		{
+			desc: "Testing error reading a credentials bundle with an unsupported version",
+			store: NewBundleStore(
+				WithFilesystem(fs),
+				WithPath("/unsupported.bundle"),
+				WithEncryption(enc),
+			),
+			err: errors.New(errContext, "Credentials bundle version 'v9' is not supported"),
+		},
+		{
+			desc: "Testing read a credentials bundle",
+			store: NewBundleStore(
+				WithFilesystem(fs),
+				WithPath("/credentials.bundle"),
+				WithEncryption(enc),
+			),
+			res: []*credentials.Credential{
+				{ID: "github.com", PrivateKeyFile: "/id_rsa"},
+				{ID: "registry.example.com", Username: "username", Password: "password"},
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.store.All()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package dockerconfig
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/spf13/afero"
+)
+
+const (
+	// ConfigFileName is the name of the Docker configuration file
+	ConfigFileName = "config.json"
+	// DockerConfigEnvvar is the environment variable that defines the Docker configuration folder
+	DockerConfigEnvvar = "DOCKER_CONFIG"
+
+	// identityTokenUsername is the username set by the credentials helpers when the secret is an identity token, which is kept as the credential refresh token
+	identityTokenUsername = "<token>"
+)
+
+// OptionsFunc defines the signature for an option function to set Docker config credentials store
+type OptionsFunc func(opts *DockerConfigStore)
+
+// DockerConfigStore is a read-only credentials store that achieves the credentials from the Docker configuration file, the same ones created by 'docker login'
+type DockerConfigStore struct {
+	fs     afero.Fs
+	path   string
+	helper CredentialsHelperer
+}
+
+// dockerConfigFile is the Docker configuration file content related to credentials
+type dockerConfigFile struct {
+	Auths       map[string]dockerAuthConfig `json:"auths"`
+	CredsStore  string                      `json:"credsStore"`
+	CredHelpers map[string]string           `json:"credHelpers"`
+}
+
+// dockerAuthConfig is a Docker configuration file 'auths' entry
+type dockerAuthConfig struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// NewDockerConfigStore creates a new Docker config credentials store
+func NewDockerConfigStore(opts ...OptionsFunc) *DockerConfigStore {
+	store := &DockerConfigStore{}
+	store.Options(opts...)
+
+	return store
+}
+
+// WithFilesystem sets the filesystem to Docker config credentials store
+func WithFilesystem(fs afero.Fs) OptionsFunc {
+	return func(s *DockerConfigStore) {
+		s.fs = fs
+	}
+}
+
+// WithPath sets the Docker configuration file path to Docker config credentials store
+func WithPath(path string) OptionsFunc {
+	return func(s *DockerConfigStore) {
+		s.path = path
+	}
+}
+
+// WithCredentialsHelper sets the credentials helper to Docker config credentials store
+func WithCredentialsHelper(helper CredentialsHelperer) OptionsFunc {
+	return func(s *DockerConfigStore) {
+		s.helper = helper
+	}
+}
+
+// Options provides the options to Docker config credentials store
+func (s *DockerConfigStore) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// DefaultConfigPath returns the Docker configuration file path. It is placed on the folder defined by 'DOCKER_CONFIG' environment variable or, by default, on '~/.docker'
+func DefaultConfigPath() string {
+
+	dir := os.Getenv(DockerConfigEnvvar)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = ""
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	return filepath.Join(dir, ConfigFileName)
+}
+
+// Store is not supported because the credentials are managed by Docker
+func (s *DockerConfigStore) Store(id string, credential *credentials.Credential) error {
+	errContext := "(store::credentials::dockerconfig::Store)"
+
+	return errors.New(errContext, fmt.Sprintf("Credentials '%s' can not be stored. Docker config credentials store is read-only, use 'docker login' to create them", id))
+}
+
+// Get returns the credential for the registry host id. It returns a not found error when there are no credentials for that host
+func (s *DockerConfigStore) Get(id string) (*credentials.Credential, error) {
+	var err error
+	var config *dockerConfigFile
+	var credential *credentials.Credential
+
+	errContext := "(store::credentials::dockerconfig::Get)"
+
+	if id == "" {
+		return nil, errors.New(errContext, "To get a credential from the Docker config store, id must be provided")
+	}
+
+	config, err = s.load()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	credential, err = s.get(config, nil, credentials.ServerHost(id))
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if credential == nil {
+		return nil, credentials.NewNotFoundError(id)
+	}
+
+	return credential, nil
+}
+
+// All returns all the credentials defined on the Docker configuration file, either on 'auths', 'credHelpers' or the 'credsStore'. The 'credsStore' helper is listed only once
+func (s *DockerConfigStore) All() ([]*credentials.Credential, error) {
+	var err error
+	var config *dockerConfigFile
+
+	errContext := "(store::credentials::dockerconfig::All)"
+
+	config, err = s.load()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	hosts := map[string]struct{}{}
+	for server := range config.Auths {
+		hosts[credentials.ServerHost(server)] = struct{}{}
+	}
+	for server := range config.CredHelpers {
+		hosts[credentials.ServerHost(server)] = struct{}{}
+	}
+
+	servers := map[string]string{}
+	if config.CredsStore != "" {
+		servers, err = s.listHelper(config.CredsStore)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+		if servers == nil {
+			servers = map[string]string{}
+		}
+		for server := range servers {
+			hosts[credentials.ServerHost(server)] = struct{}{}
+		}
+	}
+
+	sortedHosts := []string{}
+	for host := range hosts {
+		sortedHosts = append(sortedHosts, host)
+	}
+	sort.Strings(sortedHosts)
+
+	all := []*credentials.Credential{}
+	for _, host := range sortedHosts {
+		credential, err := s.get(config, servers, host)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		if credential != nil {
+			all = append(all, credential)
+		}
+	}
+
+	return all, nil
+}
+
+// load reads the Docker configuration file. A missing file is considered an empty configuration
+func (s *DockerConfigStore) load() (*dockerConfigFile, error) {
+
+	errContext := "(store::credentials::dockerconfig::load)"
+
+	if s.fs == nil {
+		return nil, errors.New(errContext, "Docker config credentials store requires a filesystem")
+	}
+
+	if s.path == "" {
+		return nil, errors.New(errContext, "Docker config credentials store requires the Docker configuration file path")
+	}
+
+	config := &dockerConfigFile{}
+
+	data, err := afero.ReadFile(s.fs, s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading Docker configuration file '%s'", s.path), err)
+	}
+
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error parsing Docker configuration file '%s'", s.path), err)
+	}
+
+	return config, nil
+}
+
+// get returns the credential for a registry host. The host credentials helper takes precedence over the credentials store, and both over the 'auths' entries, as Docker does. The servers are the ones already listed on the credentials store, which is listed when they are not provided
+func (s *DockerConfigStore) get(config *dockerConfigFile, servers map[string]string, host string) (*credentials.Credential, error) {
+	var err error
+
+	errContext := "(store::credentials::dockerconfig::get)"
+
+	helper := ""
+	serverURL := ""
+	for server, name := range config.CredHelpers {
+		if credentials.ServerHost(server) == host {
+			helper = name
+			serverURL = server
+			break
+		}
+	}
+
+	if helper == "" && config.CredsStore != "" {
+		helper = config.CredsStore
+
+		if servers == nil {
+			servers, err = s.listHelper(helper)
+			if err != nil {
+				return nil, errors.New(errContext, "", err)
+			}
+		}
+
+		for server := range servers {
+			if credentials.ServerHost(server) == host {
+				serverURL = server
+				break
+			}
+		}
+	}
+
+	if helper != "" && serverURL != "" {
+		if s.helper == nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Docker config credentials store requires a credentials helper to get '%s' credentials", host))
+		}
+
+		username, secret, err := s.helper.Get(helper, serverURL)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error getting '%s' credentials from 'docker-credential-%s'", host, helper), err)
+		}
+
+		if username == identityTokenUsername {
+			return &credentials.Credential{
+				ID:           host,
+				RefreshToken: secret,
+			}, nil
+		}
+
+		if username != "" || secret != "" {
+			return &credentials.Credential{
+				ID:       host,
+				Username: username,
+				Password: secret,
+			}, nil
+		}
+	}
+
+	credential, err := authsCredential(config, host)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return credential, nil
+}
+
+// listHelper returns the servers stored on a credentials helper
+func (s *DockerConfigStore) listHelper(helper string) (map[string]string, error) {
+
+	errContext := "(store::credentials::dockerconfig::listHelper)"
+
+	if s.helper == nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Docker config credentials store requires a credentials helper to list 'docker-credential-%s' credentials", helper))
+	}
+
+	servers, err := s.helper.List(helper)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error listing credentials from 'docker-credential-%s'", helper), err)
+	}
+
+	return servers, nil
+}
+
+// authsCredential returns the credential defined on the 'auths' entry for the host. An identity token is kept as the credential refresh token
+func authsCredential(config *dockerConfigFile, host string) (*credentials.Credential, error) {
+
+	errContext := "(store::credentials::dockerconfig::authsCredential)"
+
+	for server, auth := range config.Auths {
+		if credentials.ServerHost(server) != host {
+			continue
+		}
+
+		username := auth.Username
+		password := auth.Password
+
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Error decoding '%s' auth", server), err)
+			}
+
+			var found bool
+			username, password, found = strings.Cut(string(decoded), ":")
+			if !found {
+				return nil, errors.New(errContext, fmt.Sprintf("Invalid '%s' auth. It must be encoded as 'username:password'", server))
+			}
+		}
+
+		if auth.IdentityToken != "" {
+			return &credentials.Credential{
+				ID:           host,
+				Username:     username,
+				RefreshToken: auth.IdentityToken,
+			}, nil
+		}
+
+		if username == "" && password == "" {
+			return nil, nil
+		}
+
+		return &credentials.Credential{
+			ID:       host,
+			Username: username,
+			Password: password,
+		}, nil
+	}
+
+	return nil, nil
+}
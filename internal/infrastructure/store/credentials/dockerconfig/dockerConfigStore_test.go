@@ -0,0 +1,204 @@
+package dockerconfig
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig/helper"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// base64 of 'username:password'
+const testAuth = "dXNlcm5hbWU6cGFzc3dvcmQ="
+
+func TestGet(t *testing.T) {
+
+	errContext := "(store::credentials::dockerconfig::Get)"
+
+	tests := []struct {
+		desc              string
+		config            string
+		id                string
+		prepareAssertFunc func(*helper.MockDockerCredentialsHelper)
+		res               *credentials.Credential
+		err               error
+	}{
+		{
+			desc: "Testing error getting credentials without an id",
+			id:   "",
+			err:  errors.New(errContext, "To get a credential from the Docker config store, id must be provided"),
+		},
+		{
+			desc:   "Testing get credentials from auths",
+			config: `{"auths":{"https://registry.test":{"auth":"` + testAuth + `"}}}`,
+			id:     "registry.test",
+			res: &credentials.Credential{
+				ID:       "registry.test",
+				Username: "username",
+				Password: "password",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing get Docker Hub credentials from auths",
+			config: `{"auths":{"https://index.docker.io/v1/":{"auth":"` + testAuth + `"}}}`,
+			id:     "docker.io",
+			res: &credentials.Credential{
+				ID:       "docker.io",
+				Username: "username",
+				Password: "password",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing get credentials from a registry host credentials helper",
+			config: `{"credsStore":"desktop","credHelpers":{"123456789012.dkr.ecr.eu-west-1.amazonaws.com":"ecr-login"}}`,
+			id:     "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+			prepareAssertFunc: func(h *helper.MockDockerCredentialsHelper) {
+				h.On("Get", "ecr-login", "123456789012.dkr.ecr.eu-west-1.amazonaws.com").Return("AWS", "token", nil)
+			},
+			res: &credentials.Credential{
+				ID:       "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+				Username: "AWS",
+				Password: "token",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing get credentials from the credentials store",
+			config: `{"auths":{"registry.test":{}},"credsStore":"desktop"}`,
+			id:     "registry.test",
+			prepareAssertFunc: func(h *helper.MockDockerCredentialsHelper) {
+				h.On("List", "desktop").Return(map[string]string{"https://registry.test": "username"}, nil)
+				h.On("Get", "desktop", "https://registry.test").Return("username", "password", nil)
+			},
+			res: &credentials.Credential{
+				ID:       "registry.test",
+				Username: "username",
+				Password: "password",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing error getting no credentials for an unknown registry",
+			config: `{"auths":{"registry.test":{"auth":"` + testAuth + `"}}}`,
+			id:     "unknown.test",
+			err:    credentials.NewNotFoundError("unknown.test"),
+		},
+		{
+			desc:   "Testing error getting no credentials when the Docker configuration file does not exist",
+			config: "",
+			id:     "registry.test",
+			err:    credentials.NewNotFoundError("registry.test"),
+		},
+		{
+			desc:   "Testing get identity token credentials from auths",
+			config: `{"auths":{"registry.test":{"auth":"dXNlcm5hbWU6","identitytoken":"token"}}}`,
+			id:     "registry.test",
+			res: &credentials.Credential{
+				ID:           "registry.test",
+				Username:     "username",
+				RefreshToken: "token",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing get identity token credentials from the credentials store",
+			config: `{"credsStore":"desktop"}`,
+			id:     "registry.test",
+			prepareAssertFunc: func(h *helper.MockDockerCredentialsHelper) {
+				h.On("List", "desktop").Return(map[string]string{"registry.test": "<token>"}, nil)
+				h.On("Get", "desktop", "registry.test").Return("<token>", "token", nil)
+			},
+			res: &credentials.Credential{
+				ID:           "registry.test",
+				RefreshToken: "token",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing error getting credentials with an invalid auth",
+			config: `{"auths":{"registry.test":{"auth":"dXNlcm5hbWU="}}}`,
+			id:     "registry.test",
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::dockerconfig::get)", "",
+					errors.New("(store::credentials::dockerconfig::authsCredential)", "Invalid 'registry.test' auth. It must be encoded as 'username:password'"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			fs := afero.NewMemMapFs()
+			if test.config != "" {
+				_ = afero.WriteFile(fs, "/docker/config.json", []byte(test.config), 0600)
+			}
+
+			credentialsHelper := helper.NewMockDockerCredentialsHelper()
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(credentialsHelper)
+			}
+
+			store := NewDockerConfigStore(
+				WithFilesystem(fs),
+				WithPath("/docker/config.json"),
+				WithCredentialsHelper(credentialsHelper),
+			)
+
+			res, err := store.Get(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+				credentialsHelper.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "/docker/config.json", []byte(`{
+		"auths": {
+			"registry.test": {"auth":"`+testAuth+`"},
+			"desktop.test": {}
+		},
+		"credsStore": "desktop",
+		"credHelpers": {
+			"ecr.test": "ecr-login"
+		}
+	}`), 0600)
+
+	credentialsHelper := helper.NewMockDockerCredentialsHelper()
+	credentialsHelper.On("List", "desktop").Return(map[string]string{"desktop.test": "desktop-username", "token.test": "<token>"}, nil)
+	credentialsHelper.On("Get", "desktop", "desktop.test").Return("desktop-username", "desktop-password", nil)
+	credentialsHelper.On("Get", "desktop", "token.test").Return("<token>", "token", nil)
+	credentialsHelper.On("Get", "ecr-login", "ecr.test").Return("AWS", "token", nil)
+
+	store := NewDockerConfigStore(
+		WithFilesystem(fs),
+		WithPath("/docker/config.json"),
+		WithCredentialsHelper(credentialsHelper),
+	)
+
+	res, err := store.All()
+	assert.Nil(t, err)
+	assert.Equal(t, []*credentials.Credential{
+		{ID: "desktop.test", Username: "desktop-username", Password: "desktop-password"},
+		{ID: "ecr.test", Username: "AWS", Password: "token"},
+		{ID: "registry.test", Username: "username", Password: "password"},
+		{ID: "token.test", RefreshToken: "token"},
+	}, res)
+	credentialsHelper.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestStore(t *testing.T) {
+	errContext := "(store::credentials::dockerconfig::Store)"
+
+	err := NewDockerConfigStore().Store("registry.test", &credentials.Credential{})
+	assert.Equal(t, errors.New(errContext, "Credentials 'registry.test' can not be stored. Docker config credentials store is read-only, use 'docker login' to create them").Error(), err.Error())
+}
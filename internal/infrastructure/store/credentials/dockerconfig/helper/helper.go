@@ -0,0 +1,101 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// HelperPrefix is the prefix of the Docker credentials helpers executables
+	HelperPrefix = "docker-credential-"
+
+	// credentialsNotFoundMessage is the message returned by the credentials helpers when there are no credentials for a server
+	credentialsNotFoundMessage = "credentials not found in native keychain"
+)
+
+// helperCredentials is the credentials helpers 'get' output
+type helperCredentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// DockerCredentialsHelper runs the Docker credentials helpers, 'docker-credential-<name>', through their stdin and stdout protocol
+type DockerCredentialsHelper struct{}
+
+// NewDockerCredentialsHelper returns a new DockerCredentialsHelper
+func NewDockerCredentialsHelper() *DockerCredentialsHelper {
+	return &DockerCredentialsHelper{}
+}
+
+// Get returns the username and secret stored on the helper for the server. It returns empty values when the helper has no credentials for the server
+func (h *DockerCredentialsHelper) Get(helper, serverURL string) (string, string, error) {
+
+	errContext := "(store::credentials::dockerconfig::helper::Get)"
+
+	output, err := h.run(helper, "get", serverURL)
+	if err != nil {
+		if strings.Contains(err.Error(), credentialsNotFoundMessage) {
+			return "", "", nil
+		}
+		return "", "", errors.New(errContext, "", err)
+	}
+
+	credentials := &helperCredentials{}
+	err = json.Unmarshal(output, credentials)
+	if err != nil {
+		return "", "", errors.New(errContext, fmt.Sprintf("Error parsing '%s%s' output", HelperPrefix, helper), err)
+	}
+
+	return credentials.Username, credentials.Secret, nil
+}
+
+// List returns the servers stored on the helper along with their usernames
+func (h *DockerCredentialsHelper) List(helper string) (map[string]string, error) {
+
+	errContext := "(store::credentials::dockerconfig::helper::List)"
+
+	output, err := h.run(helper, "list", "")
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	servers := map[string]string{}
+	err = json.Unmarshal(output, &servers)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error parsing '%s%s' output", HelperPrefix, helper), err)
+	}
+
+	return servers, nil
+}
+
+// run executes the helper action writing the input to its stdin, and returns its stdout
+func (h *DockerCredentialsHelper) run(helper, action, input string) ([]byte, error) {
+
+	var stdout, stderr bytes.Buffer
+
+	errContext := "(store::credentials::dockerconfig::helper::run)"
+
+	if helper == "" {
+		return nil, errors.New(errContext, "To run a Docker credentials helper, its name must be provided")
+	}
+
+	cmd := exec.Command(HelperPrefix+helper, action)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		// helpers write the error message to stdout
+		message := strings.TrimSpace(strings.Join([]string{stdout.String(), stderr.String()}, " "))
+		return nil, errors.New(errContext, fmt.Sprintf("Error running '%s%s %s'. %s", HelperPrefix, helper, action, message), err)
+	}
+
+	return stdout.Bytes(), nil
+}
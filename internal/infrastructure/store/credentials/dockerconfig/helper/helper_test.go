@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeHelperScript emulates a Docker credentials helper which only stores credentials for 'registry.test'
+const fakeHelperScript = `#!/bin/sh
+read server
+case "$1" in
+  get)
+    if [ "$server" = "registry.test" ]; then
+      echo '{"ServerURL":"registry.test","Username":"username","Secret":"password"}'
+      exit 0
+    fi
+    echo "credentials not found in native keychain"
+    exit 1
+    ;;
+  list)
+    echo '{"registry.test":"username"}'
+    ;;
+  *)
+    echo "unknown action" >&2
+    exit 1
+    ;;
+esac
+`
+
+func prepareFakeHelper(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, HelperPrefix+"fake"), []byte(fakeHelperScript), 0755)
+	assert.Nil(t, err)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestGet(t *testing.T) {
+
+	prepareFakeHelper(t)
+
+	tests := []struct {
+		desc      string
+		helper    string
+		serverURL string
+		username  string
+		secret    string
+		err       bool
+	}{
+		{
+			desc:      "Testing get credentials from a credentials helper",
+			helper:    "fake",
+			serverURL: "registry.test",
+			username:  "username",
+			secret:    "password",
+		},
+		{
+			desc:      "Testing get no credentials when the credentials helper has no credentials for the server",
+			helper:    "fake",
+			serverURL: "unknown.test",
+		},
+		{
+			desc:      "Testing error getting credentials from an unexisting credentials helper",
+			helper:    "unexisting",
+			serverURL: "registry.test",
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			username, secret, err := NewDockerCredentialsHelper().Get(test.helper, test.serverURL)
+			if test.err {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.username, username)
+				assert.Equal(t, test.secret, secret)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+
+	prepareFakeHelper(t)
+
+	servers, err := NewDockerCredentialsHelper().List("fake")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"registry.test": "username"}, servers)
+}
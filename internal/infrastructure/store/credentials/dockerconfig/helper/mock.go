@@ -0,0 +1,30 @@
+package helper
+
+import "github.com/stretchr/testify/mock"
+
+// MockDockerCredentialsHelper is a mocked Docker credentials helper
+type MockDockerCredentialsHelper struct {
+	mock.Mock
+}
+
+// NewMockDockerCredentialsHelper returns a new MockDockerCredentialsHelper
+func NewMockDockerCredentialsHelper() *MockDockerCredentialsHelper {
+	return &MockDockerCredentialsHelper{}
+}
+
+// Get provides a mock function
+func (m *MockDockerCredentialsHelper) Get(helper, serverURL string) (string, string, error) {
+	args := m.Called(helper, serverURL)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+// List provides a mock function
+func (m *MockDockerCredentialsHelper) List(helper string) (map[string]string, error) {
+	args := m.Called(helper)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[string]string), args.Error(1)
+}
@@ -0,0 +1,7 @@
+package dockerconfig
+
+// CredentialsHelperer is the interface to achieve credentials from the Docker credentials helpers
+type CredentialsHelperer interface {
+	Get(helper, serverURL string) (string, string, error)
+	List(helper string) (map[string]string, error)
+}
@@ -7,20 +7,51 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"io"
+	"strings"
+	"sync"
 
 	errors "github.com/apenella/go-common-utils/error"
 )
 
+const (
+	// EnvelopeHeader identifies the ciphertexts written using the versioned envelope. The ciphertexts without it are written using the legacy format, the hex encoded nonce and ciphertext encrypted with the SHA-256 hash of the key
+	EnvelopeHeader = "stevedore"
+	// EnvelopeVersion1 is the envelope format 'stevedore:v1:<kdf>:<kdf params>:<hex salt>:<hex nonce and ciphertext>'. The envelope header is authenticated as AES-GCM additional data
+	EnvelopeVersion1 = "v1"
+
+	// envelopeSeparator separates the envelope fields
+	envelopeSeparator = ":"
+	// envelopeFields is the number of fields of the version 1 envelope
+	envelopeFields = 6
+	// saltSize is the size of the key derivation function salt
+	saltSize = 16
+)
+
 // OptionsFunc defines the signature for an option function to set encryption
 type OptionsFunc func(opts *Encryption)
 
 type Encryption struct {
-	key string
+	key  string
+	kdf  string
+	keys *derivedKeys
+}
+
+// derivedKeys caches the derived keys, since the key derivation functions are expensive by design. The salt used to encrypt is generated once, so the messages encrypted by the same encryption share the derived key
+type derivedKeys struct {
+	mutex sync.Mutex
+	salt  []byte
+	keys  map[string][]byte
 }
 
 func NewEncryption(opts ...OptionsFunc) Encryption {
-	e := &Encryption{}
+	e := &Encryption{
+		kdf: ScryptKDF,
+		keys: &derivedKeys{
+			keys: map[string][]byte{},
+		},
+	}
 	e.Options(opts...)
 	return *e
 }
@@ -39,12 +70,19 @@ func WithKey(key string) OptionsFunc {
 	}
 }
 
-// Encrypt return the input text encripted
+// WithKDF sets the key derivation function used to encrypt, either 'scrypt' or 'argon2id'. Decrypt uses the key derivation function defined on the envelope
+func WithKDF(kdf string) OptionsFunc {
+	return func(e *Encryption) {
+		e.kdf = kdf
+	}
+}
+
+// Encrypt return the input text encripted using the versioned envelope
 func (e Encryption) Encrypt(text string) (string, error) {
 
 	var err error
-	var key []byte
-	var block cipher.Block
+	var key, salt []byte
+	var kdf keyDerivator
 	var gcm cipher.AEAD
 
 	errContext := "(store::credentials::encryption::Encrypt)"
@@ -52,17 +90,22 @@ func (e Encryption) Encrypt(text string) (string, error) {
 		return "", errors.New(errContext, "Encryption key must be provided to encrypt a message")
 	}
 
-	key, err = hashKey(e.key)
+	kdf, err = newDefaultKeyDerivator(e.kdf)
 	if err != nil {
 		return "", errors.New(errContext, "", err)
 	}
 
-	block, err = aes.NewCipher(key)
+	salt, err = e.salt()
 	if err != nil {
 		return "", errors.New(errContext, "", err)
 	}
 
-	gcm, err = cipher.NewGCM(block)
+	key, err = e.deriveKey(kdf, salt)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	gcm, err = newGCM(key)
 	if err != nil {
 		return "", errors.New(errContext, "", err)
 	}
@@ -72,35 +115,118 @@ func (e Encryption) Encrypt(text string) (string, error) {
 		return "", errors.New(errContext, "", err)
 	}
 
-	ciphertext := gcm.Seal(nonce, nonce, []byte(text), nil)
+	header := strings.Join([]string{EnvelopeHeader, EnvelopeVersion1, kdf.Name(), kdf.Params(), hex.EncodeToString(salt)}, envelopeSeparator)
+	ciphertext := gcm.Seal(nonce, nonce, []byte(text), []byte(header))
 
-	return hex.EncodeToString(ciphertext), nil
+	return strings.Join([]string{header, hex.EncodeToString(ciphertext)}, envelopeSeparator), nil
 }
 
-// Decrypt return the input text decrypted
+// Decrypt return the input text decrypted. It accepts both the versioned envelope and the legacy format
 func (e Encryption) Decrypt(ciphertext string) (string, error) {
 
 	var err error
-	var key, enc []byte
-	var block cipher.Block
-	var gcm cipher.AEAD
+	var plaintext string
 
 	errContext := "(store::credentials::encryption::Decrypt)"
 	if e.key == "" {
 		return "", errors.New(errContext, "Encryption key must be provided to decrypt a message")
 	}
 
-	key, err = hashKey(e.key)
+	if IsLegacy(ciphertext) {
+		plaintext, err = e.decryptLegacy(ciphertext)
+	} else {
+		plaintext, err = e.decryptEnvelope(ciphertext)
+	}
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	return plaintext, nil
+}
+
+// IsLegacy returns whether the ciphertext is written using the legacy format instead of the versioned envelope
+func IsLegacy(ciphertext string) bool {
+	return !strings.HasPrefix(ciphertext, EnvelopeHeader+envelopeSeparator)
+}
+
+// decryptEnvelope decrypts a ciphertext written using the versioned envelope
+func (e Encryption) decryptEnvelope(ciphertext string) (string, error) {
+
+	var err error
+	var key, salt, enc, plaintext []byte
+	var kdf keyDerivator
+	var gcm cipher.AEAD
+
+	errContext := "(store::credentials::encryption::decryptEnvelope)"
+
+	fields := strings.Split(ciphertext, envelopeSeparator)
+	if len(fields) < 2 || fields[1] != EnvelopeVersion1 {
+		version := ""
+		if len(fields) > 1 {
+			version = fields[1]
+		}
+		return "", errors.New(errContext, fmt.Sprintf("Encryption format version '%s' is not supported", version))
+	}
+
+	if len(fields) != envelopeFields {
+		return "", errors.New(errContext, "Invalid encryption envelope")
+	}
+
+	kdf, err = parseKeyDerivator(fields[2], fields[3])
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	salt, err = hex.DecodeString(fields[4])
+	if err != nil {
+		return "", errors.New(errContext, "Invalid encryption envelope salt", err)
+	}
+
+	enc, err = hex.DecodeString(fields[5])
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	key, err = e.deriveKey(kdf, salt)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	gcm, err = newGCM(key)
 	if err != nil {
 		return "", errors.New(errContext, "", err)
 	}
 
-	block, err = aes.NewCipher(key)
+	nonceSize := gcm.NonceSize()
+	if len(enc) < nonceSize {
+		return "", errors.New(errContext, "Invalid encryption envelope ciphertext")
+	}
+	nonce, bytedCiphertext := enc[:nonceSize], enc[nonceSize:]
+
+	header := strings.Join(fields[:envelopeFields-1], envelopeSeparator)
+	plaintext, err = gcm.Open(nil, nonce, bytedCiphertext, []byte(header))
 	if err != nil {
 		return "", errors.New(errContext, "", err)
 	}
 
-	gcm, err = cipher.NewGCM(block)
+	return string(plaintext), nil
+}
+
+// decryptLegacy decrypts a ciphertext written using the legacy format, where the AES key is the SHA-256 hash of the encryption key
+func (e Encryption) decryptLegacy(ciphertext string) (string, error) {
+
+	var err error
+	var key, enc []byte
+	var gcm cipher.AEAD
+
+	errContext := "(store::credentials::encryption::decryptLegacy)"
+
+	key, err = hashKey(e.key)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	gcm, err = newGCM(key)
 	if err != nil {
 		return "", errors.New(errContext, "", err)
 	}
@@ -111,6 +237,9 @@ func (e Encryption) Decrypt(ciphertext string) (string, error) {
 	}
 
 	nonceSize := gcm.NonceSize()
+	if len(enc) < nonceSize {
+		return "", errors.New(errContext, "Invalid ciphertext")
+	}
 	nonce, bytedCiphertext := enc[:nonceSize], enc[nonceSize:]
 
 	plaintext, err := gcm.Open(nil, nonce, bytedCiphertext, nil)
@@ -119,7 +248,71 @@ func (e Encryption) Decrypt(ciphertext string) (string, error) {
 	}
 
 	return string(plaintext), nil
+}
+
+// salt returns the salt to encrypt messages
+func (e Encryption) salt() ([]byte, error) {
+
+	errContext := "(store::credentials::encryption::salt)"
+
+	if e.keys != nil {
+		e.keys.mutex.Lock()
+		defer e.keys.mutex.Unlock()
+
+		if e.keys.salt != nil {
+			return e.keys.salt, nil
+		}
+	}
+
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, errors.New(errContext, "Error generating encryption salt", err)
+	}
+
+	if e.keys != nil {
+		e.keys.salt = salt
+	}
+
+	return salt, nil
+}
+
+// deriveKey returns the AES key derived from the encryption key, using the cached one when it is available
+func (e Encryption) deriveKey(kdf keyDerivator, salt []byte) ([]byte, error) {
+
+	errContext := "(store::credentials::encryption::deriveKey)"
+
+	id := strings.Join([]string{kdf.Name(), kdf.Params(), hex.EncodeToString(salt)}, envelopeSeparator)
+
+	if e.keys != nil {
+		e.keys.mutex.Lock()
+		defer e.keys.mutex.Unlock()
+
+		key, cached := e.keys.keys[id]
+		if cached {
+			return key, nil
+		}
+	}
+
+	key, err := kdf.Derive(e.key, salt)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if e.keys != nil {
+		e.keys.keys[id] = key
+	}
+
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
 
+	return cipher.NewGCM(block)
 }
 
 func (e Encryption) GenerateEncryptionKey() (string, error) {
@@ -1,6 +1,7 @@
 package encryption
 
 import (
+	"strings"
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -24,6 +25,46 @@ func TestEncryptDecrypt(t *testing.T) {
 	assert.Equal(t, text, decryptedText)
 }
 
+func TestEncryptDecryptKDF(t *testing.T) {
+	tests := []struct {
+		desc   string
+		kdf    string
+		prefix string
+	}{
+		{
+			desc:   "Testing encrypt and decrypt using scrypt key derivation function",
+			kdf:    ScryptKDF,
+			prefix: "stevedore:v1:scrypt:15,8,1:",
+		},
+		{
+			desc:   "Testing encrypt and decrypt using argon2id key derivation function",
+			kdf:    Argon2idKDF,
+			prefix: "stevedore:v1:argon2id:3,65536,4:",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			enc := NewEncryption(
+				WithKey("encryption-key"),
+				WithKDF(test.kdf),
+			)
+
+			encryptedText, err := enc.Encrypt("plaintext")
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encryptedText, test.prefix))
+			assert.False(t, IsLegacy(encryptedText))
+
+			// a new encryption does not share the derived keys cache
+			decryptedText, err := NewEncryption(WithKey("encryption-key")).Decrypt(encryptedText)
+			assert.NoError(t, err)
+			assert.Equal(t, "plaintext", decryptedText)
+		})
+	}
+}
+
 func TestEncrypt(t *testing.T) {
 	errContext := "(store::credentials::encryption::Encrypt)"
 
@@ -38,6 +79,15 @@ func TestEncrypt(t *testing.T) {
 			encryption: NewEncryption(),
 			err:        errors.New(errContext, "Encryption key must be provided to encrypt a message"),
 		},
+		{
+			desc: "Testing error in encryption when key derivation function is not supported",
+			encryption: NewEncryption(
+				WithKey("key"),
+				WithKDF("unknown"),
+			),
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::encryption::newDefaultKeyDerivator)", "Key derivation function 'unknown' is not supported")),
+		},
 		{
 			desc: "Testing credentials encryption",
 			encryption: NewEncryption(
@@ -85,6 +135,24 @@ func TestDecrypt(t *testing.T) {
 			input: "e50990a67be331277dc50b5dcfdd630eef07be89b070d1b5e2ee3091454ab26153811c2ad5",
 			res:   "plaintext",
 		},
+		{
+			desc: "Testing error decrypting an envelope with an unsupported version",
+			encryption: NewEncryption(
+				WithKey("key"),
+			),
+			input: "stevedore:v9:scrypt:15,8,1:00:00",
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::encryption::decryptEnvelope)", "Encryption format version 'v9' is not supported")),
+		},
+		{
+			desc: "Testing error decrypting a malformed envelope",
+			encryption: NewEncryption(
+				WithKey("key"),
+			),
+			input: "stevedore:v1:scrypt:15,8,1",
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::encryption::decryptEnvelope)", "Invalid encryption envelope")),
+		},
 	}
 
 	for _, test := range tests {
@@ -101,6 +169,28 @@ func TestDecrypt(t *testing.T) {
 	}
 }
 
+func TestDecryptTamperedEnvelope(t *testing.T) {
+	enc := NewEncryption(
+		WithKey("key"),
+	)
+
+	encryptedText, err := enc.Encrypt("plaintext")
+	assert.NoError(t, err)
+
+	// the envelope header is authenticated, so modifying the key derivation parameters must fail
+	tampered := strings.Replace(encryptedText, ":15,8,1:", ":14,8,1:", 1)
+	_, err = enc.Decrypt(tampered)
+	assert.Error(t, err)
+
+	_, err = NewEncryption(WithKey("wrong-key")).Decrypt(encryptedText)
+	assert.Error(t, err)
+}
+
+func TestIsLegacy(t *testing.T) {
+	assert.True(t, IsLegacy("e50990a67be331277dc50b5dcfdd630eef07be89b070d1b5e2ee3091454ab26153811c2ad5"))
+	assert.False(t, IsLegacy("stevedore:v1:scrypt:15,8,1:00:00"))
+}
+
 func TestHashID(t *testing.T) {
 
 	errContext := "(store::credentials::encryption::hashID)"
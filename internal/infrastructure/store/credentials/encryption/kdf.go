@@ -0,0 +1,163 @@
+package encryption
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// ScryptKDF is the identifier of the scrypt key derivation function
+	ScryptKDF = "scrypt"
+	// Argon2idKDF is the identifier of the argon2id key derivation function
+	Argon2idKDF = "argon2id"
+
+	// derivedKeySize is the size of the derived key, which corresponds to AES-256
+	derivedKeySize = 32
+	// kdfParamsSeparator separates the key derivation function parameters on the envelope
+	kdfParamsSeparator = ","
+
+	defaultScryptLogN = 15
+	defaultScryptR    = 8
+	defaultScryptP    = 1
+	// maxScryptLogN limits the cost of the scrypt parameters read from an envelope
+	maxScryptLogN = 20
+
+	defaultArgon2idTime    = 3
+	defaultArgon2idMemory  = 64 * 1024
+	defaultArgon2idThreads = 4
+	// maxArgon2idTime limits the iterations of the argon2id parameters read from an envelope
+	maxArgon2idTime = 16
+	// maxArgon2idMemory limits the memory, in KiB, of the argon2id parameters read from an envelope
+	maxArgon2idMemory = 1024 * 1024
+)
+
+// keyDerivator derives the AES key from the encryption key
+type keyDerivator interface {
+	// Name returns the key derivation function identifier
+	Name() string
+	// Params returns the key derivation function parameters as they are written on the envelope
+	Params() string
+	// Derive returns the AES key derived from the encryption key and the salt
+	Derive(key string, salt []byte) ([]byte, error)
+}
+
+// scryptKeyDerivator derives keys using scrypt, with N=2^logN
+type scryptKeyDerivator struct {
+	logN int
+	r    int
+	p    int
+}
+
+func (k *scryptKeyDerivator) Name() string {
+	return ScryptKDF
+}
+
+func (k *scryptKeyDerivator) Params() string {
+	return strings.Join([]string{strconv.Itoa(k.logN), strconv.Itoa(k.r), strconv.Itoa(k.p)}, kdfParamsSeparator)
+}
+
+func (k *scryptKeyDerivator) Derive(key string, salt []byte) ([]byte, error) {
+	errContext := "(store::credentials::encryption::scryptKeyDerivator::Derive)"
+
+	derived, err := scrypt.Key([]byte(key), salt, 1<<k.logN, k.r, k.p, derivedKeySize)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return derived, nil
+}
+
+// argon2idKeyDerivator derives keys using argon2id, with the memory expressed in KiB
+type argon2idKeyDerivator struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+func (k *argon2idKeyDerivator) Name() string {
+	return Argon2idKDF
+}
+
+func (k *argon2idKeyDerivator) Params() string {
+	return strings.Join([]string{
+		strconv.FormatUint(uint64(k.time), 10),
+		strconv.FormatUint(uint64(k.memory), 10),
+		strconv.FormatUint(uint64(k.threads), 10),
+	}, kdfParamsSeparator)
+}
+
+func (k *argon2idKeyDerivator) Derive(key string, salt []byte) ([]byte, error) {
+	return argon2.IDKey([]byte(key), salt, k.time, k.memory, k.threads, derivedKeySize), nil
+}
+
+// newDefaultKeyDerivator returns the key derivator for the key derivation function using its default parameters
+func newDefaultKeyDerivator(kdf string) (keyDerivator, error) {
+
+	errContext := "(store::credentials::encryption::newDefaultKeyDerivator)"
+
+	switch kdf {
+	case "", ScryptKDF:
+		return &scryptKeyDerivator{
+			logN: defaultScryptLogN,
+			r:    defaultScryptR,
+			p:    defaultScryptP,
+		}, nil
+	case Argon2idKDF:
+		return &argon2idKeyDerivator{
+			time:    defaultArgon2idTime,
+			memory:  defaultArgon2idMemory,
+			threads: defaultArgon2idThreads,
+		}, nil
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Key derivation function '%s' is not supported", kdf))
+	}
+}
+
+// parseKeyDerivator returns the key derivator for the key derivation function and the parameters read from an envelope
+func parseKeyDerivator(kdf string, params string) (keyDerivator, error) {
+
+	errContext := "(store::credentials::encryption::parseKeyDerivator)"
+
+	values := []uint64{}
+	for _, param := range strings.Split(params, kdfParamsSeparator) {
+		value, err := strconv.ParseUint(param, 10, 32)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid '%s' key derivation function parameters '%s'", kdf, params), err)
+		}
+		values = append(values, value)
+	}
+
+	if len(values) != 3 {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid '%s' key derivation function parameters '%s'", kdf, params))
+	}
+
+	switch kdf {
+	case ScryptKDF:
+		if values[0] < 1 || values[0] > maxScryptLogN || values[1] < 1 || values[2] < 1 || values[1]*values[2] >= 1<<30 {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid '%s' key derivation function parameters '%s'", kdf, params))
+		}
+
+		return &scryptKeyDerivator{
+			logN: int(values[0]),
+			r:    int(values[1]),
+			p:    int(values[2]),
+		}, nil
+	case Argon2idKDF:
+		if values[0] < 1 || values[0] > maxArgon2idTime || values[1] < 8 || values[1] > maxArgon2idMemory || values[2] < 1 || values[2] > 255 {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid '%s' key derivation function parameters '%s'", kdf, params))
+		}
+
+		return &argon2idKeyDerivator{
+			time:    uint32(values[0]),
+			memory:  uint32(values[1]),
+			threads: uint8(values[2]),
+		}, nil
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Key derivation function '%s' is not supported", kdf))
+	}
+}
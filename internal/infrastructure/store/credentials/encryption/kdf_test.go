@@ -0,0 +1,85 @@
+package encryption
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyDerivator(t *testing.T) {
+	errContext := "(store::credentials::encryption::parseKeyDerivator)"
+
+	tests := []struct {
+		desc   string
+		kdf    string
+		params string
+		res    keyDerivator
+		err    error
+	}{
+		{
+			desc:   "Testing parse scrypt key derivator",
+			kdf:    ScryptKDF,
+			params: "15,8,1",
+			res: &scryptKeyDerivator{
+				logN: 15,
+				r:    8,
+				p:    1,
+			},
+		},
+		{
+			desc:   "Testing parse argon2id key derivator",
+			kdf:    Argon2idKDF,
+			params: "3,65536,4",
+			res: &argon2idKeyDerivator{
+				time:    3,
+				memory:  65536,
+				threads: 4,
+			},
+		},
+		{
+			desc:   "Testing error parsing a key derivator with an unsupported key derivation function",
+			kdf:    "unknown",
+			params: "1,1,1",
+			err:    errors.New(errContext, "Key derivation function 'unknown' is not supported"),
+		},
+		{
+			desc:   "Testing error parsing a key derivator with a wrong number of parameters",
+			kdf:    ScryptKDF,
+			params: "15,8",
+			err:    errors.New(errContext, "Invalid 'scrypt' key derivation function parameters '15,8'"),
+		},
+		{
+			desc:   "Testing error parsing a scrypt key derivator exceeding the maximum cost",
+			kdf:    ScryptKDF,
+			params: "30,8,1",
+			err:    errors.New(errContext, "Invalid 'scrypt' key derivation function parameters '30,8,1'"),
+		},
+		{
+			desc:   "Testing error parsing an argon2id key derivator exceeding the maximum memory",
+			kdf:    Argon2idKDF,
+			params: "3,4194304,4",
+			err:    errors.New(errContext, "Invalid 'argon2id' key derivation function parameters '3,4194304,4'"),
+		},
+		{
+			desc:   "Testing error parsing an argon2id key derivator exceeding the maximum time",
+			kdf:    Argon2idKDF,
+			params: "4294967295,65536,4",
+			err:    errors.New(errContext, "Invalid 'argon2id' key derivation function parameters '4294967295,65536,4'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := parseKeyDerivator(test.kdf, test.params)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+				assert.Equal(t, test.params, res.Params())
+			}
+		})
+	}
+}
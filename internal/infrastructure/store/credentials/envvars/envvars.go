@@ -2,6 +2,7 @@ package envvars
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -121,7 +122,7 @@ func (s *EnvvarsStore) Store(id string, credential *credentials.Credential) erro
 	return nil
 }
 
-// Get returns a auth for the credential id
+// Get returns a auth for the credential id. It returns a not found error when the credential does not exist
 func (s *EnvvarsStore) Get(id string) (*credentials.Credential, error) {
 	errContext := "(store::credentials::envvars::Get)"
 
@@ -143,6 +144,10 @@ func (s *EnvvarsStore) Get(id string) (*credentials.Credential, error) {
 		return nil, errors.New(errContext, fmt.Sprintf("Error getting credentials credential '%s'", id), err)
 	}
 
+	if credential == nil {
+		return nil, credentials.NewNotFoundError(id)
+	}
+
 	return credential, nil
 }
 
@@ -184,6 +189,142 @@ func (s *EnvvarsStore) get(hashedID string) (*credentials.Credential, error) {
 	return credential, nil
 }
 
+// Delete deletes the credential for the id. Since the store can not modify the environment of the parent process, it shows the environment variable that must be removed
+func (s *EnvvarsStore) Delete(id string) error {
+
+	errContext := "(store::credentials::envvars::Delete)"
+
+	var err error
+	var hashedID string
+	var credential *credentials.Credential
+
+	if s.console == nil {
+		return errors.New(errContext, "Envvars credentials store requires a console writer to delete a credential")
+	}
+
+	if id == "" {
+		return errors.New(errContext, "To delete a credential, is required an ID")
+	}
+
+	hashedID, err = encryption.HashID(id)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error hashing the id '%s'", id), err)
+	}
+
+	credential, err = s.get(hashedID)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if credential == nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' does not exist", id))
+	}
+
+	key := generateEnvvarKey(envvarsCredentialsPrefix, hashedID)
+	s.console.Warn("You must remove the following environment variable to delete the credentials:")
+	s.console.Warn(fmt.Sprintf(" %s", key))
+
+	return nil
+}
+
+// Rename moves the credential for the id to the new id. It shows the environment variable to create for the new id and the one to remove for the id
+func (s *EnvvarsStore) Rename(id, newID string) error {
+
+	errContext := "(store::credentials::envvars::Rename)"
+
+	var err error
+	var credential *credentials.Credential
+
+	if id == "" || newID == "" {
+		return errors.New(errContext, "To rename a credential, are required both the ID and the new ID")
+	}
+
+	credential, err = s.Get(id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	_, err = s.Get(newID)
+	if err == nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' already exist", newID))
+	}
+
+	if !credentials.IsNotFoundError(err) {
+		return errors.New(errContext, "", err)
+	}
+
+	credential.ID = newID
+	err = s.Store(newID, credential)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = s.Delete(id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// Rekey re-encrypts all the credentials with the new encryption. Since the store can not modify the environment of the parent process, it shows the environment variables that must be set
+func (s *EnvvarsStore) Rekey(e repository.CredentialsEncrypter) error {
+
+	errContext := "(store::credentials::envvars::Rekey)"
+
+	var err error
+	var strCredential string
+
+	if s.console == nil {
+		return errors.New(errContext, "Envvars credentials store requires a console writer to rekey the credentials")
+	}
+
+	if s.backend == nil {
+		return errors.New(errContext, "Envvars credentials store requires a backend to rekey the credentials")
+	}
+
+	if s.encryption == nil {
+		return errors.New(errContext, "Envvars credentials store requires encryption to rekey the credentials")
+	}
+
+	if e == nil {
+		return errors.New(errContext, "To rekey the credentials, the new encryption must be provided")
+	}
+
+	envvars := []string{}
+	prefix := generateEnvvarKey(envvarsCredentialsPrefix)
+
+	for _, envvar := range s.backend.Environ() {
+		key, value, found := strings.Cut(envvar, "=")
+		if !found || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		strCredential, err = s.encryption.Decrypt(value)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Error decrypting the '%s' environment variable", key), err)
+		}
+
+		strCredential, err = e.Encrypt(strCredential)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+
+		envvars = append(envvars, fmt.Sprintf(" %s=%s", key, strCredential))
+	}
+
+	sort.Strings(envvars)
+
+	s.console.Warn("You must set the following environment variables to use the credentials with the new encryption key:")
+	for _, envvar := range envvars {
+		s.console.Warn(envvar)
+	}
+
+	s.encryption = e
+
+	return nil
+}
+
 // All returns all credentials
 func (s *EnvvarsStore) All() ([]*credentials.Credential, error) {
 	errContext := "(store::credentials::envvars::All)"
@@ -2,6 +2,7 @@ package envvars
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -12,6 +13,7 @@ import (
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
 	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars/backend"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestStore(t *testing.T) {
@@ -188,6 +190,21 @@ func TestGet(t *testing.T) {
 			err: errors.New(errContextGet, "Error getting credentials credential 'myregistry.test:5000'",
 				errors.New(errContextPrivGet, "Envvars credentials store requires encryption to get credentials credential")),
 		},
+		{
+			desc: "Testing error getting envvars credentials that does not exist",
+			store: NewEnvvarsStore(
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithFormater(credentialsjsonformater.NewJSONFormater()),
+				WithEncryption(encryption.NewEncryption(
+					encryption.WithKey("encryption-key"),
+				)),
+			),
+			id:  "myregistry.test:5000",
+			err: credentials.NewNotFoundError("myregistry.test:5000"),
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", "STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC").Return("")
+			},
+		},
 		{
 			desc: "Testing get envvars credentials credential",
 			store: NewEnvvarsStore(
@@ -237,6 +254,155 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestDelete(t *testing.T) {
+	errContext := "(store::credentials::envvars::Delete)"
+
+	testEncryption := encryption.NewEncryption(
+		encryption.WithKey("encryption-key"),
+	)
+	encryptedCredential, _ := testEncryption.Encrypt(`{"id":"myregistry.test:5000","username":"username","password":"password"}`)
+
+	tests := []struct {
+		desc              string
+		store             *EnvvarsStore
+		id                string
+		prepareAssertFunc func(*EnvvarsStore)
+		err               error
+	}{
+		{
+			desc:  "Testing error deleting envvars credentials when console is not provided",
+			store: NewEnvvarsStore(),
+			id:    "myregistry.test:5000",
+			err:   errors.New(errContext, "Envvars credentials store requires a console writer to delete a credential"),
+		},
+		{
+			desc: "Testing error deleting envvars credentials that does not exist",
+			store: NewEnvvarsStore(
+				WithConsole(console.NewMockConsole()),
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithFormater(credentialsjsonformater.NewJSONFormater()),
+				WithEncryption(testEncryption),
+			),
+			id: "myregistry.test:5000",
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", "STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC").Return("")
+			},
+			err: errors.New(errContext, "Credentials 'myregistry.test:5000' does not exist"),
+		},
+		{
+			desc: "Testing delete envvars credentials",
+			store: NewEnvvarsStore(
+				WithConsole(console.NewMockConsole()),
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithFormater(credentialsjsonformater.NewJSONFormater()),
+				WithEncryption(testEncryption),
+			),
+			id: "myregistry.test:5000",
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", "STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC").Return(encryptedCredential)
+				s.console.(*console.MockConsole).On("Warn", []interface{}{"You must remove the following environment variable to delete the credentials:"})
+				s.console.(*console.MockConsole).On("Warn", []interface{}{" STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC"})
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Delete(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.store.console.(*console.MockConsole).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	errContext := "(store::credentials::envvars::Rename)"
+
+	testEncryption := encryption.NewEncryption(
+		encryption.WithKey("encryption-key"),
+	)
+	encryptedCredential, _ := testEncryption.Encrypt(`{"id":"myregistry.test:5000","username":"username","password":"password"}`)
+	newHashedID, _ := encryption.HashID("myregistry.test:5001")
+
+	tests := []struct {
+		desc              string
+		store             *EnvvarsStore
+		id                string
+		newID             string
+		prepareAssertFunc func(*EnvvarsStore)
+		err               error
+	}{
+		{
+			desc:  "Testing error renaming envvars credentials when new ID is not provided",
+			store: NewEnvvarsStore(),
+			id:    "myregistry.test:5000",
+			err:   errors.New(errContext, "To rename a credential, are required both the ID and the new ID"),
+		},
+		{
+			desc: "Testing error renaming envvars credentials to an existing ID",
+			store: NewEnvvarsStore(
+				WithConsole(console.NewMockConsole()),
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithFormater(credentialsjsonformater.NewJSONFormater()),
+				WithEncryption(testEncryption),
+			),
+			id:    "myregistry.test:5000",
+			newID: "myregistry.test:5001",
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", "STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC").Return(encryptedCredential)
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", generateEnvvarKey(envvarsCredentialsPrefix, newHashedID)).Return(encryptedCredential)
+			},
+			err: errors.New(errContext, "Credentials 'myregistry.test:5001' already exist"),
+		},
+		{
+			desc: "Testing rename envvars credentials",
+			store: NewEnvvarsStore(
+				WithConsole(console.NewMockConsole()),
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithFormater(credentialsjsonformater.NewJSONFormater()),
+				WithEncryption(testEncryption),
+			),
+			id:    "myregistry.test:5000",
+			newID: "myregistry.test:5001",
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", "STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC").Return(encryptedCredential)
+				s.backend.(*backend.MockEnvvarsBackend).On("Getenv", generateEnvvarKey(envvarsCredentialsPrefix, newHashedID)).Return("")
+				s.console.(*console.MockConsole).On("Warn", []interface{}{"You must create the following environment variable to use the recently created credentials:"})
+				s.console.(*console.MockConsole).On("Warn", mock.Anything)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Rename(test.id, test.newID)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.store.backend.(*backend.MockEnvvarsBackend).AssertExpectations(t)
+				test.store.console.(*console.MockConsole).AssertExpectations(t)
+			}
+		})
+	}
+}
+
 func TestAll(t *testing.T) {
 	tests := []struct {
 		desc              string
@@ -394,3 +560,87 @@ func TestGenerateResult(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestRekey(t *testing.T) {
+	errContext := "(store::credentials::envvars::Rekey)"
+
+	credential := `{"id":"myregistry.test:5000","username":"username","password":"password"}`
+	currentEncryption := encryption.NewEncryption(
+		encryption.WithKey("encryption-key"),
+	)
+	newEncryption := encryption.NewEncryption(
+		encryption.WithKey("new-encryption-key"),
+	)
+	encryptedCredential, _ := currentEncryption.Encrypt(credential)
+
+	tests := []struct {
+		desc              string
+		store             *EnvvarsStore
+		prepareAssertFunc func(*EnvvarsStore)
+		err               error
+	}{
+		{
+			desc:  "Testing error rekeying envvars credentials when console is not provided",
+			store: NewEnvvarsStore(),
+			err:   errors.New(errContext, "Envvars credentials store requires a console writer to rekey the credentials"),
+		},
+		{
+			desc: "Testing error rekeying envvars credentials encrypted with another key",
+			store: NewEnvvarsStore(
+				WithConsole(console.NewMockConsole()),
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithEncryption(newEncryption),
+			),
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Environ").Return([]string{
+					"STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC=" + encryptedCredential,
+				})
+			},
+			err: errors.New(errContext, "Error decrypting the 'STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC' environment variable",
+				errors.New("(store::credentials::encryption::Decrypt)", "cipher: message authentication failed")),
+		},
+		{
+			desc: "Testing rekey envvars credentials",
+			store: NewEnvvarsStore(
+				WithConsole(console.NewMockConsole()),
+				WithBackend(backend.NewMockEnvvarsBackend()),
+				WithEncryption(currentEncryption),
+			),
+			prepareAssertFunc: func(s *EnvvarsStore) {
+				s.backend.(*backend.MockEnvvarsBackend).On("Environ").Return([]string{
+					"HOME=/home/stevedore",
+					"STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC=" + encryptedCredential,
+				})
+				s.console.(*console.MockConsole).On("Warn", []interface{}{"You must set the following environment variables to use the credentials with the new encryption key:"})
+				s.console.(*console.MockConsole).On("Warn", mock.MatchedBy(func(msg []interface{}) bool {
+					key, value, found := strings.Cut(msg[0].(string), "=")
+					if !found || key != " STEVEDORE_ENVVARS_CREDENTIALS_E3A70918293EEFC49419599C9D8B5ABC" {
+						return false
+					}
+
+					decrypted, err := newEncryption.Decrypt(value)
+					return err == nil && decrypted == credential
+				}))
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Rekey(newEncryption)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.store.console.(*console.MockConsole).AssertExpectations(t)
+				assert.Equal(t, newEncryption, test.store.encryption)
+			}
+		})
+	}
+}
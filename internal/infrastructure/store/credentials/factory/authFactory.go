@@ -0,0 +1,109 @@
+package factory
+
+import (
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	authmethodbasic "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/basic"
+	authmethodkeyfile "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/keyfile"
+	authmethodsshagent "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/sshagent"
+	authmethodtoken "github.com/gostevedore/stevedore/internal/infrastructure/auth/method/token"
+	authproviderawsecr "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr"
+	authproviderawsecrcache "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/cache"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token"
+	"github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/token/awscredprovider"
+	authprovidercredentialprocess "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/credentialprocess"
+	authproviderstore "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/store"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+)
+
+// CreateAuthFactory returns the auth factory that resolves the authorization methods from the credentials kept in the store
+func (f *CredentialsFactory) CreateAuthFactory(conf *configuration.CredentialsConfiguration, store repository.CredentialsStorer) (*authfactory.AuthFactory, error) {
+	errContext := "(store::credentials::factory::CreateAuthFactory)"
+
+	if store == nil {
+		return nil, errors.New(errContext, "To create the auth factory, a credentials store is required")
+	}
+
+	tokenCaches, err := f.CreateAWSECRTokenCaches(conf)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	// create auth methods
+	basic := authmethodbasic.NewBasicAuthMethod()
+	keyfile := authmethodkeyfile.NewKeyFileAuthMethod()
+	sshagent := authmethodsshagent.NewSSHAgentAuthMethod()
+	tokenauth := authmethodtoken.NewTokenAuthMethod()
+
+	// create auth providers
+	badge := authproviderstore.NewStoreAuthProvider(basic, tokenauth, keyfile, sshagent)
+
+	// create authorization aws ecr provider
+	tokenProvider := token.NewAWSECRToken(
+		token.WithAssumeRoleARNProvider(awscredprovider.NewAssumerRoleARNProvider()),
+		token.WithStaticCredentialsProvider(awscredprovider.NewStaticCredentialsProvider()),
+		token.WithECRClientFactory(
+			token.NewECRClientFactory(
+				func(cfg aws.Config) token.ECRClienter {
+					c := ecr.NewFromConfig(cfg)
+					return c
+				},
+			),
+		),
+	)
+
+	awsecr := authproviderawsecr.NewAWSECRAuthProvider(tokenProvider,
+		authproviderawsecr.WithTokenCaches(tokenCaches...),
+	)
+
+	// create authorization credential process provider
+	credentialprocess := authprovidercredentialprocess.NewCredentialProcessAuthProvider(
+		authprovidercredentialprocess.WithRunner(authprovidercredentialprocess.NewShellCommandRunner()),
+	)
+
+	return authfactory.NewAuthFactory(store, badge, credentialprocess, awsecr), nil
+}
+
+// CreateAWSECRTokenCaches returns the caches for the AWS ECR authorization tokens. Tokens are always cached in memory, and they are also cached on disk when the token cache path is configured
+func (f *CredentialsFactory) CreateAWSECRTokenCaches(conf *configuration.CredentialsConfiguration) ([]authproviderawsecr.TokenCacher, error) {
+	errContext := "(store::credentials::factory::CreateAWSECRTokenCaches)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the AWS ECR token caches, credentials configuration is required")
+	}
+
+	caches := []authproviderawsecr.TokenCacher{
+		authproviderawsecrcache.NewMemoryCache(),
+	}
+
+	if conf.AWSECRTokenCachePath != "" {
+		if f.fs == nil {
+			return nil, errors.New(errContext, "To cache the AWS ECR authorization tokens on disk, a filesystem is required")
+		}
+
+		key, err := conf.ResolveEncryptionKey(f.fs)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		if key == "" {
+			return nil, errors.New(errContext, "To cache the AWS ECR authorization tokens on disk, an encryption key is required")
+		}
+
+		encryption := credentialsstoreencryption.NewEncryption(
+			credentialsstoreencryption.WithKey(key),
+		)
+
+		caches = append(caches, authproviderawsecrcache.NewFileCache(
+			authproviderawsecrcache.WithFilesystem(f.fs),
+			authproviderawsecrcache.WithPath(conf.AWSECRTokenCachePath),
+			authproviderawsecrcache.WithEncryption(encryption),
+		))
+	}
+
+	return caches, nil
+}
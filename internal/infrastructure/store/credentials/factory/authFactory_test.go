@@ -0,0 +1,139 @@
+package factory
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	authfactory "github.com/gostevedore/stevedore/internal/infrastructure/auth/factory"
+	authproviderawsecr "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr"
+	authproviderawsecrcache "github.com/gostevedore/stevedore/internal/infrastructure/auth/provider/awsecr/cache"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/mock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAuthFactory(t *testing.T) {
+	errContext := "(store::credentials::factory::CreateAuthFactory)"
+
+	tests := []struct {
+		desc    string
+		factory *CredentialsFactory
+		conf    *configuration.CredentialsConfiguration
+		store   repository.CredentialsStorer
+		res     *authfactory.AuthFactory
+		err     error
+	}{
+		{
+			desc:    "Testing error when creating an auth factory with undefined credentials store",
+			factory: NewCredentialsFactory(),
+			conf:    &configuration.CredentialsConfiguration{},
+			err:     errors.New(errContext, "To create the auth factory, a credentials store is required"),
+		},
+		{
+			desc:    "Testing error when creating an auth factory with undefined credentials configuration",
+			factory: NewCredentialsFactory(),
+			store:   mock.NewMockStore(),
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::factory::CreateAWSECRTokenCaches)", "To create the AWS ECR token caches, credentials configuration is required")),
+		},
+		{
+			desc:    "Testing create an auth factory",
+			factory: NewCredentialsFactory(),
+			conf:    &configuration.CredentialsConfiguration{},
+			store:   mock.NewMockStore(),
+			res:     &authfactory.AuthFactory{},
+			err:     &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			factory, err := test.factory.CreateAuthFactory(test.conf, test.store)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, factory)
+			}
+		})
+	}
+}
+
+func TestCreateAWSECRTokenCaches(t *testing.T) {
+	errContext := "(store::credentials::factory::CreateAWSECRTokenCaches)"
+
+	tests := []struct {
+		desc    string
+		factory *CredentialsFactory
+		conf    *configuration.CredentialsConfiguration
+		res     []authproviderawsecr.TokenCacher
+		err     error
+	}{
+		{
+			desc:    "Testing error when creating the AWS ECR token caches with undefined credentials configuration",
+			factory: NewCredentialsFactory(),
+			err:     errors.New(errContext, "To create the AWS ECR token caches, credentials configuration is required"),
+		},
+		{
+			desc:    "Testing create the AWS ECR token caches",
+			factory: NewCredentialsFactory(),
+			conf:    &configuration.CredentialsConfiguration{},
+			res: []authproviderawsecr.TokenCacher{
+				authproviderawsecrcache.NewMemoryCache(),
+			},
+		},
+		{
+			desc: "Testing create the AWS ECR token caches with a file cache",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				AWSECRTokenCachePath: "/cache",
+				EncryptionKey:        "12345asdfg",
+			},
+			res: []authproviderawsecr.TokenCacher{
+				authproviderawsecrcache.NewMemoryCache(),
+				&authproviderawsecrcache.FileCache{},
+			},
+		},
+		{
+			desc:    "Testing error when creating the AWS ECR token caches with a file cache and undefined filesystem",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				AWSECRTokenCachePath: "/cache",
+				EncryptionKey:        "12345asdfg",
+			},
+			err: errors.New(errContext, "To cache the AWS ECR authorization tokens on disk, a filesystem is required"),
+		},
+		{
+			desc: "Testing error when creating the AWS ECR token caches with a file cache and undefined encryption key",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				AWSECRTokenCachePath: "/cache",
+			},
+			err: errors.New(errContext, "To cache the AWS ECR authorization tokens on disk, an encryption key is required"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			caches, err := test.factory.CreateAWSECRTokenCaches(test.conf)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, len(test.res), len(caches))
+				for i := range test.res {
+					assert.IsType(t, test.res[i], caches[i])
+				}
+			}
+		})
+	}
+}
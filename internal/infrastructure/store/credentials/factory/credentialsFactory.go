@@ -0,0 +1,293 @@
+package factory
+
+import (
+	"fmt"
+	"net/http"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	credentialscompatibility "github.com/gostevedore/stevedore/internal/infrastructure/compatibility/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsformatfactory "github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/factory"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
+	credentialsdockerconfighelper "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig/helper"
+	credentialsstoreencryption "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialsenvvarsstorebackend "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars/backend"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
+	credentialsvaultclient "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault/client"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc defines the signature for an option function to set the credentials factory attributes
+type OptionsFunc func(f *CredentialsFactory)
+
+// CredentialsFactory creates the credentials components defined by the credentials configuration
+type CredentialsFactory struct {
+	fs            afero.Fs
+	compatibility Compatibilitier
+	console       ConsoleWriter
+}
+
+// NewCredentialsFactory returns a new credentials factory
+func NewCredentialsFactory(opts ...OptionsFunc) *CredentialsFactory {
+	f := &CredentialsFactory{}
+	f.Options(opts...)
+
+	return f
+}
+
+// WithFilesystem sets the filesystem used by the credentials stores
+func WithFilesystem(fs afero.Fs) OptionsFunc {
+	return func(f *CredentialsFactory) {
+		f.fs = fs
+	}
+}
+
+// WithCompatibility sets the compatibility used by the local credentials store
+func WithCompatibility(compatibility Compatibilitier) OptionsFunc {
+	return func(f *CredentialsFactory) {
+		f.compatibility = compatibility
+	}
+}
+
+// WithConsole sets the console used by the envvars credentials store
+func WithConsole(console ConsoleWriter) OptionsFunc {
+	return func(f *CredentialsFactory) {
+		f.console = console
+	}
+}
+
+// Options configures the credentials factory
+func (f *CredentialsFactory) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(f)
+	}
+}
+
+// CreateStore returns the credentials store for the storage type defined on the credentials configuration
+func (f *CredentialsFactory) CreateStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
+	errContext := "(store::credentials::factory::CreateStore)"
+
+	store, err := f.createStore(conf, false)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
+
+// CreateSafeStore returns the credentials store for the storage type defined on the credentials configuration. The local and Vault stores returned refuse to overwrite existing credentials
+func (f *CredentialsFactory) CreateSafeStore(conf *configuration.CredentialsConfiguration) (repository.CredentialsStorer, error) {
+	errContext := "(store::credentials::factory::CreateSafeStore)"
+
+	store, err := f.createStore(conf, true)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return store, nil
+}
+
+func (f *CredentialsFactory) createStore(conf *configuration.CredentialsConfiguration, safe bool) (repository.CredentialsStorer, error) {
+	var store repository.CredentialsStorer
+	var format repository.Formater
+	var err error
+
+	errContext := "(store::credentials::factory::createStore)"
+
+	if conf == nil {
+		return nil, errors.New(errContext, "To create the credentials store, credentials configuration is required")
+	}
+
+	if conf.StorageType == "" {
+		return nil, errors.New(errContext, "To create the credentials store, credentials storage type must be defined")
+	}
+
+	switch conf.StorageType {
+	case credentials.LocalStore:
+		format, err = f.createFormater(conf)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		store, err = f.createLocalStore(conf, format, safe)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+	case credentials.EnvvarsStore:
+		format, err = f.createFormater(conf)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		store, err = f.createEnvvarsStore(conf, format)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+	case credentials.DockerConfigStore:
+		store, err = f.createDockerConfigStore()
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+	case credentials.VaultStore:
+		format, err = f.createFormater(conf)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		store, err = f.createVaultStore(conf, format, safe)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Unsupported credentials storage type '%s'", conf.StorageType))
+	}
+
+	return store, nil
+}
+
+func (f *CredentialsFactory) createFormater(conf *configuration.CredentialsConfiguration) (repository.Formater, error) {
+	errContext := "(store::credentials::factory::createFormater)"
+
+	if conf.Format == "" {
+		return nil, errors.New(errContext, "To create the credentials store, credentials format must be defined")
+	}
+
+	format, err := credentialsformatfactory.NewFormatFactory().Get(conf.Format)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	return format, nil
+}
+
+func (f *CredentialsFactory) createLocalStore(conf *configuration.CredentialsConfiguration, format repository.Formater, safe bool) (repository.CredentialsStorer, error) {
+	errContext := "(store::credentials::factory::createLocalStore)"
+
+	if f.fs == nil {
+		return nil, errors.New(errContext, "To create the credentials local store, a filesystem is required")
+	}
+
+	if f.compatibility == nil {
+		return nil, errors.New(errContext, "To create the credentials local store, compatibility is required")
+	}
+
+	if conf.LocalStoragePath == "" {
+		return nil, errors.New(errContext, "To create the credentials local store, local storage path is required")
+	}
+
+	key, err := conf.ResolveEncryptionKey(f.fs)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	localStoreOpts := []credentialslocalstore.OptionsFunc{
+		credentialslocalstore.WithFilesystem(f.fs),
+		credentialslocalstore.WithCompatibility(credentialscompatibility.NewCredentialsCompatibility(f.compatibility)),
+		credentialslocalstore.WithPath(conf.LocalStoragePath),
+		credentialslocalstore.WithFormater(format),
+	}
+
+	if key != "" {
+		localStoreOpts = append(localStoreOpts, credentialslocalstore.WithEncryption(
+			credentialsstoreencryption.NewEncryption(
+				credentialsstoreencryption.WithKey(key),
+			),
+		))
+	}
+
+	if safe {
+		return credentialslocalstore.NewLocalStoreWithSafeStore(localStoreOpts...), nil
+	}
+
+	return credentialslocalstore.NewLocalStore(localStoreOpts...), nil
+}
+
+func (f *CredentialsFactory) createEnvvarsStore(conf *configuration.CredentialsConfiguration, format repository.Formater) (*credentialsenvvarsstore.EnvvarsStore, error) {
+	errContext := "(store::credentials::factory::createEnvvarsStore)"
+
+	key, err := conf.ResolveEncryptionKey(f.fs)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	envvarsStoreOpts := []credentialsenvvarsstore.OptionsFunc{
+		credentialsenvvarsstore.WithBackend(credentialsenvvarsstorebackend.NewOSEnvvarsBackend()),
+		credentialsenvvarsstore.WithFormater(format),
+		credentialsenvvarsstore.WithEncryption(
+			credentialsstoreencryption.NewEncryption(
+				credentialsstoreencryption.WithKey(key),
+			),
+		),
+	}
+
+	if f.console != nil {
+		envvarsStoreOpts = append(envvarsStoreOpts, credentialsenvvarsstore.WithConsole(f.console))
+	}
+
+	return credentialsenvvarsstore.NewEnvvarsStore(envvarsStoreOpts...), nil
+}
+
+func (f *CredentialsFactory) createDockerConfigStore() (*credentialsdockerconfigstore.DockerConfigStore, error) {
+	errContext := "(store::credentials::factory::createDockerConfigStore)"
+
+	if f.fs == nil {
+		return nil, errors.New(errContext, "To create the credentials docker config store, a filesystem is required")
+	}
+
+	store := credentialsdockerconfigstore.NewDockerConfigStore(
+		credentialsdockerconfigstore.WithFilesystem(f.fs),
+		credentialsdockerconfigstore.WithPath(credentialsdockerconfigstore.DefaultConfigPath()),
+		credentialsdockerconfigstore.WithCredentialsHelper(credentialsdockerconfighelper.NewDockerCredentialsHelper()),
+	)
+
+	return store, nil
+}
+
+func (f *CredentialsFactory) createVaultStore(conf *configuration.CredentialsConfiguration, format repository.Formater, safe bool) (repository.CredentialsStorer, error) {
+	var authenticator credentialsvaultclient.Authenticator
+
+	errContext := "(store::credentials::factory::createVaultStore)"
+
+	if conf.Vault == nil {
+		return nil, errors.New(errContext, "To create the credentials Vault store, Vault configuration is required")
+	}
+
+	switch conf.Vault.AuthMethod {
+	case credentials.VaultTokenAuth:
+		authenticator = credentialsvaultclient.NewTokenAuth(conf.Vault.Token)
+	case credentials.VaultAppRoleAuth:
+		authenticator = credentialsvaultclient.NewAppRoleAuth(conf.Vault.AuthMount, conf.Vault.RoleID, conf.Vault.SecretID)
+	case credentials.VaultJWTAuth:
+		authenticator = credentialsvaultclient.NewJWTAuth(f.fs, conf.Vault.AuthMount, conf.Vault.Role, conf.Vault.JWT, conf.Vault.JWTPath)
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Unsupported Vault auth method '%s'", conf.Vault.AuthMethod))
+	}
+
+	client := credentialsvaultclient.NewClient(
+		credentialsvaultclient.WithAddress(conf.Vault.Address),
+		credentialsvaultclient.WithNamespace(conf.Vault.Namespace),
+		credentialsvaultclient.WithHTTPClient(&http.Client{}),
+		credentialsvaultclient.WithAuthenticator(authenticator),
+	)
+
+	vaultStoreOpts := []credentialsvaultstore.OptionsFunc{
+		credentialsvaultstore.WithClient(client),
+		credentialsvaultstore.WithFormater(format),
+		credentialsvaultstore.WithMount(conf.Vault.Mount),
+		credentialsvaultstore.WithPath(conf.Vault.Path),
+	}
+
+	if safe {
+		return credentialsvaultstore.NewVaultStoreWithSafeStore(vaultStoreOpts...), nil
+	}
+
+	return credentialsvaultstore.NewVaultStore(vaultStoreOpts...), nil
+}
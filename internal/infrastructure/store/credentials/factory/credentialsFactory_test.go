@@ -0,0 +1,255 @@
+package factory
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/gostevedore/stevedore/internal/infrastructure/compatibility"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	credentialsdockerconfigstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/dockerconfig"
+	credentialsenvvarsstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/envvars"
+	credentialslocalstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/local"
+	credentialsvaultstore "github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateStore(t *testing.T) {
+	errContext := "(store::credentials::factory::CreateStore)"
+	errContextCreateStore := "(store::credentials::factory::createStore)"
+
+	t.Setenv("STEVEDORE_TEST_ENCRYPTION_KEY", "12345asdfg")
+
+	tests := []struct {
+		desc    string
+		factory *CredentialsFactory
+		conf    *configuration.CredentialsConfiguration
+		safe    bool
+		res     repository.CredentialsStorer
+		err     error
+	}{
+		{
+			desc:    "Testing error when creating a credentials store with undefined configuration",
+			factory: NewCredentialsFactory(),
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "To create the credentials store, credentials configuration is required")),
+		},
+		{
+			desc:    "Testing error when creating a credentials store with undefined storage type",
+			factory: NewCredentialsFactory(),
+			conf:    &configuration.CredentialsConfiguration{},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "To create the credentials store, credentials storage type must be defined")),
+		},
+		{
+			desc:    "Testing error when creating a credentials store with unsupported storage type",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: "unsupported",
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "Unsupported credentials storage type 'unsupported'")),
+		},
+		{
+			desc:    "Testing error when creating a credentials local store with undefined format",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.LocalStore,
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "",
+					errors.New("(store::credentials::factory::createFormater)", "To create the credentials store, credentials format must be defined"))),
+		},
+		{
+			desc: "Testing error when creating a credentials local store with undefined compatibility",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				Format:           credentials.JSONFormat,
+				LocalStoragePath: "credentials",
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "",
+					errors.New("(store::credentials::factory::createLocalStore)", "To create the credentials local store, compatibility is required"))),
+		},
+		{
+			desc: "Testing error when creating a credentials local store with undefined local storage path",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.LocalStore,
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "",
+					errors.New("(store::credentials::factory::createLocalStore)", "To create the credentials local store, local storage path is required"))),
+		},
+		{
+			desc: "Testing create a credentials local store",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				Format:           credentials.JSONFormat,
+				LocalStoragePath: "credentials",
+				EncryptionKey:    "12345asdfg",
+			},
+			res: &credentialslocalstore.LocalStore{},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create a credentials local store resolving the encryption key from the environment",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				Format:           credentials.JSONFormat,
+				LocalStoragePath: "credentials",
+				EncryptionKeyEnv: "STEVEDORE_TEST_ENCRYPTION_KEY",
+			},
+			res: &credentialslocalstore.LocalStore{},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing create a credentials local store that refuses to overwrite credentials",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithCompatibility(compatibility.NewMockCompatibility()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.LocalStore,
+				Format:           credentials.JSONFormat,
+				LocalStoragePath: "credentials",
+			},
+			safe: true,
+			res:  &credentialslocalstore.LocalStoreWithSafeStore{},
+			err:  &errors.Error{},
+		},
+		{
+			desc:    "Testing create a credentials envvars store",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.EnvvarsStore,
+				Format:      credentials.JSONFormat,
+			},
+			res: &credentialsenvvarsstore.EnvvarsStore{},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing error when creating a credentials envvars store and the encryption key can not be resolved",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType:      credentials.EnvvarsStore,
+				Format:           credentials.JSONFormat,
+				EncryptionKeyEnv: "STEVEDORE_TEST_UNDEFINED_ENCRYPTION_KEY",
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "",
+					errors.New("(store::credentials::factory::createEnvvarsStore)", "",
+						errors.New("(CredentialsConfiguration::ResolveEncryptionKey)", "",
+							errors.New("(configuration::encryptionKeyFromEnv)", "Encryption key environment variable 'STEVEDORE_TEST_UNDEFINED_ENCRYPTION_KEY' is not defined"))))),
+		},
+		{
+			desc: "Testing create a credentials docker config store",
+			factory: NewCredentialsFactory(
+				WithFilesystem(afero.NewMemMapFs()),
+			),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.DockerConfigStore,
+			},
+			res: &credentialsdockerconfigstore.DockerConfigStore{},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing error when creating a credentials Vault store with undefined Vault configuration",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "",
+					errors.New("(store::credentials::factory::createVaultStore)", "To create the credentials Vault store, Vault configuration is required"))),
+		},
+		{
+			desc:    "Testing error when creating a credentials Vault store with unsupported auth method",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					AuthMethod: "unsupported",
+				},
+			},
+			err: errors.New(errContext, "",
+				errors.New(errContextCreateStore, "",
+					errors.New("(store::credentials::factory::createVaultStore)", "Unsupported Vault auth method 'unsupported'"))),
+		},
+		{
+			desc:    "Testing create a credentials Vault store",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			res: &credentialsvaultstore.VaultStore{},
+			err: &errors.Error{},
+		},
+		{
+			desc:    "Testing create a credentials Vault store that refuses to overwrite credentials",
+			factory: NewCredentialsFactory(),
+			conf: &configuration.CredentialsConfiguration{
+				StorageType: credentials.VaultStore,
+				Format:      credentials.JSONFormat,
+				Vault: &configuration.VaultConfiguration{
+					Address:    "http://127.0.0.1:8200",
+					Mount:      "secret",
+					Path:       "stevedore",
+					AuthMethod: credentials.VaultTokenAuth,
+					Token:      "token",
+				},
+			},
+			safe: true,
+			res:  &credentialsvaultstore.VaultStoreWithSafeStore{},
+			err:  &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			var store repository.CredentialsStorer
+			var err error
+
+			if test.safe {
+				store, err = test.factory.CreateSafeStore(test.conf)
+			} else {
+				store, err = test.factory.CreateStore(test.conf)
+			}
+
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.IsType(t, test.res, store)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package factory
+
+// Compatibilitier is the interface for the compatibility checker
+type Compatibilitier interface {
+	AddDeprecated(deprecated ...string)
+	AddRemoved(removed ...string)
+	AddChanged(changed ...string)
+}
+
+// ConsoleWriter is the console where the envvars credentials store writes its messages
+type ConsoleWriter interface {
+	Info(msg ...interface{})
+	Warn(msg ...interface{})
+	Error(msg ...interface{})
+	Debug(msg ...interface{})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -13,6 +14,11 @@ import (
 	"github.com/spf13/afero"
 )
 
+const (
+	// rekeyFileSuffix is the suffix of the temporary files written while the credentials are re-encrypted
+	rekeyFileSuffix = ".rekey"
+)
+
 // OptionsFunc defines the signature for an option function to set local credentials store
 type OptionsFunc func(opts *LocalStore)
 
@@ -131,7 +137,7 @@ func (s *LocalStore) Store(id string, credential *credentials.Credential) (err e
 		return errors.New(errContext, fmt.Sprintf("Error creating directory '%s'", s.path), err)
 	}
 
-	credentialFile, err = s.fs.OpenFile(filepath.Join(s.path, hashedID), os.O_RDWR|os.O_CREATE, 0600)
+	credentialFile, err = s.fs.OpenFile(filepath.Join(s.path, hashedID), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	defer func() {
 		credentialFileCloseErr := credentialFile.Close()
 		if credentialFileCloseErr != nil {
@@ -164,7 +170,7 @@ func (s *LocalStore) Store(id string, credential *credentials.Credential) (err e
 	return nil
 }
 
-// Get returns a auth for the credential id
+// Get returns a auth for the credential id. It returns a not found error when the credential does not exist
 func (s *LocalStore) Get(id string) (*credentials.Credential, error) {
 	var err error
 	var credential *credentials.Credential
@@ -180,6 +186,10 @@ func (s *LocalStore) Get(id string) (*credentials.Credential, error) {
 		return nil, errors.New(errContext, "", err)
 	}
 
+	if !s.exists(hashedID) {
+		return nil, credentials.NewNotFoundError(id)
+	}
+
 	credential, err = s.get(hashedID)
 	if err != nil {
 		return nil, errors.New(errContext, "", err)
@@ -227,6 +237,167 @@ func (s *LocalStore) get(id string) (*credentials.Credential, error) {
 	return credential, nil
 }
 
+// Delete removes the credential for the id from the local store
+func (s *LocalStore) Delete(id string) error {
+
+	errContext := "(store::credentials::local::Delete)"
+
+	if id == "" {
+		return errors.New(errContext, "To delete a credential from the local store, id must be provided")
+	}
+
+	hashedID, err := encryption.HashID(id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if !s.exists(hashedID) {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' does not exist", id))
+	}
+
+	err = s.fs.Remove(filepath.Join(s.path, hashedID))
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error removing credentials file '%s'", filepath.Join(s.path, hashedID)), err)
+	}
+
+	return nil
+}
+
+// Rename moves the credential for the id to the new id. It fails when a credential already exists for the new id
+func (s *LocalStore) Rename(id, newID string) error {
+
+	errContext := "(store::credentials::local::Rename)"
+
+	if id == "" || newID == "" {
+		return errors.New(errContext, "To rename a credential on the local store, both the id and the new id must be provided")
+	}
+
+	hashedNewID, err := encryption.HashID(newID)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if s.exists(hashedNewID) {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' already exist", newID))
+	}
+
+	credential, err := s.Get(id)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' could not be achieved", id), err)
+	}
+
+	credential.ID = newID
+	err = s.Store(newID, credential)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = s.Delete(id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// Rekey re-encrypts all the credentials on the local store with the new encryption. Every credential is re-encrypted into a temporary file before replacing any of the current ones, so the store is not modified when a credential can not be decrypted
+func (s *LocalStore) Rekey(e repository.CredentialsEncrypter) error {
+
+	var err error
+	var files []os.FileInfo
+
+	errContext := "(store::credentials::local::Rekey)"
+
+	if s.path == "" {
+		return errors.New(errContext, "To rekey the local store, local store path must be provided")
+	}
+
+	if e == nil {
+		return errors.New(errContext, "To rekey the local store, the new encryption must be provided")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err = afero.ReadDir(s.fs, s.path)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error reading credentials folder '%s'", s.path), err)
+	}
+
+	rekeyedFiles := []string{}
+	removeRekeyedFiles := func() {
+		for _, file := range rekeyedFiles {
+			_ = s.fs.Remove(file + rekeyFileSuffix)
+		}
+	}
+
+	for _, file := range files {
+		if file.IsDir() || strings.HasSuffix(file.Name(), rekeyFileSuffix) {
+			continue
+		}
+
+		credentialFile := filepath.Join(s.path, file.Name())
+		err = s.rekeyFile(credentialFile, e)
+		if err != nil {
+			removeRekeyedFiles()
+			return errors.New(errContext, fmt.Sprintf("Error rekeying credentials file '%s'. No credentials have been modified", credentialFile), err)
+		}
+		rekeyedFiles = append(rekeyedFiles, credentialFile)
+	}
+
+	for i, file := range rekeyedFiles {
+		err = s.fs.Rename(file+rekeyFileSuffix, file)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Error replacing credentials file '%s'. %d of %d credentials files have been rekeyed", file, i, len(rekeyedFiles)), err)
+		}
+	}
+
+	s.encryption = e
+
+	return nil
+}
+
+// rekeyFile writes the credentials file content, encrypted with the new encryption, into a temporary file
+func (s *LocalStore) rekeyFile(file string, e repository.CredentialsEncrypter) error {
+
+	var err error
+	var data []byte
+	var content string
+
+	errContext := "(store::credentials::local::rekeyFile)"
+
+	data, err = afero.ReadFile(s.fs, file)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error reading credentials file '%s'", file), err)
+	}
+
+	content = string(data)
+	if s.encryption != nil {
+		content, err = s.encryption.Decrypt(content)
+		if err != nil {
+			return errors.New(errContext, "", err)
+		}
+	}
+
+	content, err = e.Encrypt(content)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = afero.WriteFile(s.fs, file+rekeyFileSuffix, []byte(content), 0600)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error writing credentials file '%s'", file+rekeyFileSuffix), err)
+	}
+
+	return nil
+}
+
+// exists returns whether there is a credentials file for the hashed id
+func (s *LocalStore) exists(hashedID string) bool {
+	stat, err := s.fs.Stat(filepath.Join(s.path, hashedID))
+	return err == nil && !stat.IsDir()
+}
+
 // All returns all credentials from the store
 func (s *LocalStore) All() ([]*credentials.Credential, error) {
 
@@ -243,7 +414,10 @@ func (s *LocalStore) All() ([]*credentials.Credential, error) {
 		}
 
 		if !info.IsDir() {
-			credential, _ = s.get(info.Name())
+			credential, err = s.get(info.Name())
+			if err != nil {
+				return errors.New(errContext, fmt.Sprintf("Credentials file '%s' can not be read", path), err)
+			}
 			credentials = append(credentials, credential)
 		}
 
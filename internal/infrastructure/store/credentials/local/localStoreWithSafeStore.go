@@ -19,6 +19,7 @@ func NewLocalStoreWithSafeStore(opts ...OptionsFunc) *LocalStoreWithSafeStore {
 	return s
 }
 
+// Store persists the credential, refusing to overwrite an existing one
 func (s *LocalStoreWithSafeStore) Store(id string, credential *credentials.Credential) error {
 	errContext := "(store::credentials::local::LocalStoreWithSafeStore)"
 
@@ -29,3 +30,8 @@ func (s *LocalStoreWithSafeStore) Store(id string, credential *credentials.Crede
 
 	return nil
 }
+
+// Get returns the credential for the id from the local store
+func (s *LocalStoreWithSafeStore) Get(id string) (*credentials.Credential, error) {
+	return s.store.Get(id)
+}
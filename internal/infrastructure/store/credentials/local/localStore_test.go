@@ -334,6 +334,21 @@ func TestGet(t *testing.T) {
 			),
 			err: errors.New(errContext, "To get a credential from the store, id must be provided"),
 		},
+		{
+			desc: "Testing error when getting a credential that does not exist on local store",
+			store: NewLocalStore(
+				WithFilesystem(testFs),
+				WithPath(credentialsPath),
+				WithFormater(json.NewJSONFormater()),
+				WithCompatibility(
+					credentialscompatibility.NewCredentialsCompatibility(
+						compatibility.NewMockCompatibility(),
+					),
+				),
+			),
+			id:  "unknown",
+			err: credentials.NewNotFoundError("unknown"),
+		},
 		{
 			desc: "Testing get credentials credential from local store",
 			store: NewLocalStore(
@@ -404,6 +419,8 @@ func TestAll(t *testing.T) {
 	testFs.MkdirAll(credentialsPath, 0755)
 	emptyPath := filepath.Join("empty")
 	testFs.MkdirAll(emptyPath, 0755)
+	corruptPath := filepath.Join("corrupt")
+	_ = afero.WriteFile(testFs, filepath.Join(corruptPath, "id"), []byte("corrupt"), 0666)
 
 	err = afero.WriteFile(testFs, filepath.Join("credentials", "b80bb7740288fda1f201890375a60c8f"), []byte(`
 {
@@ -457,6 +474,23 @@ func TestAll(t *testing.T) {
 			res: []*credentials.Credential{},
 			err: &errors.Error{},
 		},
+		{
+			desc: "Testing error getting all credentials from a local store with a credentials file that can not be read",
+			store: NewLocalStore(
+				WithFilesystem(testFs),
+				WithPath(corruptPath),
+				WithFormater(json.NewJSONFormater()),
+				WithCompatibility(
+					credentialscompatibility.NewCredentialsCompatibility(
+						compatibility.NewMockCompatibility(),
+					),
+				),
+			),
+			err: errors.New("(store::credentials::local::All::walk)", "Credentials file 'corrupt/id' can not be read",
+				errors.New("(store::credentials::local::get)", "Error unmarshaling credentials from file 'corrupt/id'",
+					errors.New("(JSONFormater::Unmarshal)", "",
+						errors.New("", "invalid character 'c' looking for beginning of value")))),
+		},
 	}
 
 	for _, test := range tests {
@@ -465,10 +499,269 @@ func TestAll(t *testing.T) {
 
 			credentials, err := test.store.All()
 			if err != nil {
-				assert.Equal(t, test.err, err)
+				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
 				assert.Equal(t, test.res, credentials)
 			}
 		})
 	}
 }
+
+func TestDelete(t *testing.T) {
+
+	errContext := "(store::credentials::local::Delete)"
+
+	hashedID, _ := encryption.HashID("id")
+
+	tests := []struct {
+		desc              string
+		store             *LocalStore
+		id                string
+		prepareAssertFunc func(*LocalStore)
+		assertFunc        func(*testing.T, *LocalStore)
+		err               error
+	}{
+		{
+			desc: "Testing error when deleting a credential from local store without giving an id",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+			),
+			err: errors.New(errContext, "To delete a credential from the local store, id must be provided"),
+		},
+		{
+			desc: "Testing error when deleting a credential that does not exist on local store",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+			),
+			id:  "id",
+			err: errors.New(errContext, "Credentials 'id' does not exist"),
+		},
+		{
+			desc: "Testing delete a credential from local store",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+			),
+			id: "id",
+			prepareAssertFunc: func(s *LocalStore) {
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedID), []byte(`{"username":"username","password":"password"}`), 0600)
+			},
+			assertFunc: func(t *testing.T, s *LocalStore) {
+				exists, _ := afero.Exists(s.fs, filepath.Join("credentials", hashedID))
+				assert.False(t, exists)
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Delete(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.store)
+			}
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+
+	errContext := "(store::credentials::local::Rename)"
+
+	hashedID, _ := encryption.HashID("id")
+	hashedNewID, _ := encryption.HashID("new-id")
+
+	tests := []struct {
+		desc              string
+		store             *LocalStore
+		id                string
+		newID             string
+		prepareAssertFunc func(*LocalStore)
+		res               *credentials.Credential
+		err               error
+	}{
+		{
+			desc: "Testing error when renaming a credential on local store without giving the new id",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+			),
+			id:  "id",
+			err: errors.New(errContext, "To rename a credential on the local store, both the id and the new id must be provided"),
+		},
+		{
+			desc: "Testing error when renaming a credential to an id that already exists on local store",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+			),
+			id:    "id",
+			newID: "new-id",
+			prepareAssertFunc: func(s *LocalStore) {
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedNewID), []byte(`{"username":"username","password":"password"}`), 0600)
+			},
+			err: errors.New(errContext, "Credentials 'new-id' already exist"),
+		},
+		{
+			desc: "Testing rename a credential on local store",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+				WithFormater(json.NewJSONFormater()),
+				WithCompatibility(
+					credentialscompatibility.NewCredentialsCompatibility(
+						compatibility.NewMockCompatibility(),
+					),
+				),
+			),
+			id:    "id",
+			newID: "new-id",
+			prepareAssertFunc: func(s *LocalStore) {
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedID), []byte(`{"id":"id","username":"username","password":"password"}`), 0600)
+			},
+			res: &credentials.Credential{
+				ID:       "new-id",
+				Username: "username",
+				Password: "password",
+			},
+			err: &errors.Error{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Rename(test.id, test.newID)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				exists, _ := afero.Exists(test.store.fs, filepath.Join("credentials", hashedID))
+				assert.False(t, exists)
+
+				credential, err := test.store.Get(test.newID)
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, credential)
+			}
+		})
+	}
+}
+
+func TestRekey(t *testing.T) {
+
+	errContext := "(store::credentials::local::Rekey)"
+
+	hashedID, _ := encryption.HashID("id")
+	hashedOtherID, _ := encryption.HashID("other-id")
+	credentialContent := `{"username":"username","password":"password"}`
+
+	currentEncryption := encryption.NewEncryption(encryption.WithKey("current-key"))
+	newEncryption := encryption.NewEncryption(encryption.WithKey("new-key"))
+
+	tests := []struct {
+		desc              string
+		store             *LocalStore
+		encryption        *encryption.Encryption
+		prepareAssertFunc func(*LocalStore)
+		assertFunc        func(*testing.T, *LocalStore)
+		err               error
+	}{
+		{
+			desc:       "Testing error rekeying local store without path",
+			store:      NewLocalStore(),
+			encryption: &newEncryption,
+			err:        errors.New(errContext, "To rekey the local store, local store path must be provided"),
+		},
+		{
+			desc: "Testing rekey local store encrypted with the current key",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+				WithEncryption(currentEncryption),
+			),
+			encryption: &newEncryption,
+			prepareAssertFunc: func(s *LocalStore) {
+				content, _ := currentEncryption.Encrypt(credentialContent)
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedID), []byte(content), 0600)
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedOtherID), []byte(content), 0600)
+			},
+			assertFunc: func(t *testing.T, s *LocalStore) {
+				for _, id := range []string{hashedID, hashedOtherID} {
+					data, _ := afero.ReadFile(s.fs, filepath.Join("credentials", id))
+					content, err := newEncryption.Decrypt(string(data))
+					assert.NoError(t, err)
+					assert.Equal(t, credentialContent, content)
+
+					exists, _ := afero.Exists(s.fs, filepath.Join("credentials", id+rekeyFileSuffix))
+					assert.False(t, exists)
+				}
+				assert.Equal(t, &newEncryption, s.encryption)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing rekey local store with plain credentials",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+			),
+			encryption: &newEncryption,
+			prepareAssertFunc: func(s *LocalStore) {
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedID), []byte(credentialContent), 0600)
+			},
+			assertFunc: func(t *testing.T, s *LocalStore) {
+				data, _ := afero.ReadFile(s.fs, filepath.Join("credentials", hashedID))
+				content, err := newEncryption.Decrypt(string(data))
+				assert.NoError(t, err)
+				assert.Equal(t, credentialContent, content)
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing error rekeying local store when a credential can not be decrypted",
+			store: NewLocalStore(
+				WithFilesystem(afero.NewMemMapFs()),
+				WithPath("credentials"),
+				WithEncryption(currentEncryption),
+			),
+			encryption: &newEncryption,
+			prepareAssertFunc: func(s *LocalStore) {
+				_ = afero.WriteFile(s.fs, filepath.Join("credentials", hashedID), []byte("not-encrypted"), 0600)
+			},
+			err: errors.New(errContext, "Error rekeying credentials file 'credentials/"+hashedID+"'. No credentials have been modified",
+				errors.New("(store::credentials::local::rekeyFile)", "",
+					errors.New("(store::credentials::encryption::Decrypt)", "encoding/hex: invalid byte: U+006E 'n'"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Rekey(test.encryption)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.store)
+			}
+		})
+	}
+}
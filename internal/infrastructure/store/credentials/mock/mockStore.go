@@ -2,6 +2,7 @@ package mock
 
 import (
 	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -37,3 +38,27 @@ func (m *MockStore) All() ([]*credentials.Credential, error) {
 	args := m.Mock.Called()
 	return args.Get(0).([]*credentials.Credential), args.Error(1)
 }
+
+// Delete deletes the credential for the id
+func (m *MockStore) Delete(id string) error {
+	args := m.Mock.Called(id)
+	return args.Error(0)
+}
+
+// Rename moves the credential for the id to the new id
+func (m *MockStore) Rename(id, newID string) error {
+	args := m.Mock.Called(id, newID)
+	return args.Error(0)
+}
+
+// Rekey re-encrypts all the credentials with the new encryption
+func (m *MockStore) Rekey(encryption repository.CredentialsEncrypter) error {
+	args := m.Mock.Called(encryption)
+	return args.Error(0)
+}
+
+// Write persists a set of credentials at once
+func (m *MockStore) Write(credentials []*credentials.Credential) error {
+	args := m.Mock.Called(credentials)
+	return args.Error(0)
+}
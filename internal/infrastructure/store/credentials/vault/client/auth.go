@@ -0,0 +1,136 @@
+package client
+
+import (
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+)
+
+// TokenAuth provides a Vault token that is already known
+type TokenAuth struct {
+	token string
+}
+
+// NewTokenAuth returns a new TokenAuth
+func NewTokenAuth(token string) *TokenAuth {
+	return &TokenAuth{
+		token: token,
+	}
+}
+
+// Token returns the Vault token
+func (a *TokenAuth) Token(login Loginer) (string, error) {
+
+	errContext := "(store::credentials::vault::client::TokenAuth::Token)"
+
+	if a.token == "" {
+		return "", errors.New(errContext, "To authenticate to Vault with token auth method, a token must be provided")
+	}
+
+	return a.token, nil
+}
+
+// AppRoleAuth logs in to Vault using an AppRole role id and secret id
+type AppRoleAuth struct {
+	mount    string
+	roleID   string
+	secretID string
+}
+
+// NewAppRoleAuth returns a new AppRoleAuth
+func NewAppRoleAuth(mount, roleID, secretID string) *AppRoleAuth {
+	return &AppRoleAuth{
+		mount:    mount,
+		roleID:   roleID,
+		secretID: secretID,
+	}
+}
+
+// Token logs in to the AppRole auth method and returns the Vault token
+func (a *AppRoleAuth) Token(login Loginer) (string, error) {
+
+	errContext := "(store::credentials::vault::client::AppRoleAuth::Token)"
+
+	if login == nil {
+		return "", errors.New(errContext, "To authenticate to Vault with approle auth method, a loginer must be provided")
+	}
+
+	if a.roleID == "" {
+		return "", errors.New(errContext, "To authenticate to Vault with approle auth method, a role id must be provided")
+	}
+
+	data := map[string]interface{}{
+		"role_id": a.roleID,
+	}
+
+	if a.secretID != "" {
+		data["secret_id"] = a.secretID
+	}
+
+	token, err := login.Login(a.mount, data)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	return token, nil
+}
+
+// JWTAuth logs in to Vault using a JWT, either provided as is or read from a file
+type JWTAuth struct {
+	fs      afero.Fs
+	mount   string
+	role    string
+	jwt     string
+	jwtPath string
+}
+
+// NewJWTAuth returns a new JWTAuth. When the jwt is empty, it is read from the file on jwtPath
+func NewJWTAuth(fs afero.Fs, mount, role, jwt, jwtPath string) *JWTAuth {
+	return &JWTAuth{
+		fs:      fs,
+		mount:   mount,
+		role:    role,
+		jwt:     jwt,
+		jwtPath: jwtPath,
+	}
+}
+
+// Token logs in to the JWT auth method and returns the Vault token
+func (a *JWTAuth) Token(login Loginer) (string, error) {
+
+	errContext := "(store::credentials::vault::client::JWTAuth::Token)"
+
+	if login == nil {
+		return "", errors.New(errContext, "To authenticate to Vault with jwt auth method, a loginer must be provided")
+	}
+
+	jwt := a.jwt
+	if jwt == "" && a.jwtPath != "" {
+		if a.fs == nil {
+			return "", errors.New(errContext, "To read the JWT file, a file system must be provided")
+		}
+
+		content, err := afero.ReadFile(a.fs, a.jwtPath)
+		if err != nil {
+			return "", errors.New(errContext, "Error reading the JWT file", err)
+		}
+		jwt = strings.TrimSpace(string(content))
+	}
+
+	if jwt == "" {
+		return "", errors.New(errContext, "To authenticate to Vault with jwt auth method, a JWT must be provided")
+	}
+
+	data := map[string]interface{}{
+		"role": a.role,
+		"jwt":  jwt,
+	}
+
+	token, err := login.Login(a.mount, data)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+
+	return token, nil
+}
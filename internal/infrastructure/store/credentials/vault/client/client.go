@@ -0,0 +1,301 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// tokenHeader is the header that holds the Vault token
+	tokenHeader = "X-Vault-Token"
+	// namespaceHeader is the header that holds the Vault Enterprise namespace
+	namespaceHeader = "X-Vault-Namespace"
+	// listMethod is the HTTP method used by Vault to list keys
+	listMethod = "LIST"
+)
+
+// OptionsFunc defines the signature for an option function to set the Vault client
+type OptionsFunc func(c *Client)
+
+// Client is a HashiCorp Vault client for the KV version 2 secrets engine. It logs in through its authenticator on the first request
+type Client struct {
+	address       string
+	namespace     string
+	httpClient    HTTPDoer
+	authenticator Authenticator
+
+	tokenMutex sync.Mutex
+	token      string
+}
+
+// kvReadResponse is the KV version 2 secret read response
+type kvReadResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+// kvListResponse is the KV version 2 secret list response
+type kvListResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+// loginResponse is the auth methods login response
+type loginResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+// errorResponse is the Vault error response
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// NewClient returns a new Vault client
+func NewClient(opts ...OptionsFunc) *Client {
+	c := &Client{}
+	c.Options(opts...)
+
+	return c
+}
+
+// WithAddress sets the Vault address, such as 'https://vault.example.com:8200'
+func WithAddress(address string) OptionsFunc {
+	return func(c *Client) {
+		c.address = strings.TrimRight(address, "/")
+	}
+}
+
+// WithNamespace sets the Vault Enterprise namespace
+func WithNamespace(namespace string) OptionsFunc {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to request Vault
+func WithHTTPClient(httpClient HTTPDoer) OptionsFunc {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAuthenticator sets the authenticator that provides the Vault token
+func WithAuthenticator(authenticator Authenticator) OptionsFunc {
+	return func(c *Client) {
+		c.authenticator = authenticator
+	}
+}
+
+// Options configure the Vault client
+func (c *Client) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// ReadSecret returns the data of the secret stored at the path on the mount. It returns nil when the secret does not exist
+func (c *Client) ReadSecret(mount, path string) (map[string]interface{}, error) {
+
+	errContext := "(store::credentials::vault::client::ReadSecret)"
+
+	response := &kvReadResponse{}
+	found, err := c.request(http.MethodGet, kvPath(mount, "data", path), nil, response)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading secret '%s' on '%s'", path, mount), err)
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return response.Data.Data, nil
+}
+
+// WriteSecret stores the data as a new version of the secret at the path on the mount
+func (c *Client) WriteSecret(mount, path string, data map[string]interface{}) error {
+
+	errContext := "(store::credentials::vault::client::WriteSecret)"
+
+	body := map[string]interface{}{
+		"data": data,
+	}
+
+	_, err := c.request(http.MethodPost, kvPath(mount, "data", path), body, nil)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error writing secret '%s' on '%s'", path, mount), err)
+	}
+
+	return nil
+}
+
+// DeleteSecret deletes the secret stored at the path on the mount, along with all its versions and metadata
+func (c *Client) DeleteSecret(mount, path string) error {
+
+	errContext := "(store::credentials::vault::client::DeleteSecret)"
+
+	_, err := c.request(http.MethodDelete, kvPath(mount, "metadata", path), nil, nil)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error deleting secret '%s' on '%s'", path, mount), err)
+	}
+
+	return nil
+}
+
+// ListSecrets returns the keys stored under the path on the mount. It returns an empty list when the path does not exist
+func (c *Client) ListSecrets(mount, path string) ([]string, error) {
+
+	errContext := "(store::credentials::vault::client::ListSecrets)"
+
+	response := &kvListResponse{}
+	found, err := c.request(listMethod, kvPath(mount, "metadata", path), nil, response)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error listing secrets '%s' on '%s'", path, mount), err)
+	}
+
+	if !found {
+		return []string{}, nil
+	}
+
+	return response.Data.Keys, nil
+}
+
+// Login requests a token to the auth method mounted on the mount, sending the login data
+func (c *Client) Login(mount string, data map[string]interface{}) (string, error) {
+
+	errContext := "(store::credentials::vault::client::Login)"
+
+	response := &loginResponse{}
+	found, err := c.do(http.MethodPost, strings.Join([]string{"auth", strings.Trim(mount, "/"), "login"}, "/"), "", data, response)
+	if err != nil {
+		return "", errors.New(errContext, fmt.Sprintf("Error logging in to Vault through '%s' auth method", mount), err)
+	}
+
+	if !found || response.Auth.ClientToken == "" {
+		return "", errors.New(errContext, fmt.Sprintf("Vault login through '%s' auth method did not return a token", mount))
+	}
+
+	return response.Auth.ClientToken, nil
+}
+
+// request achieves the Vault token and sends an authenticated request
+func (c *Client) request(method, path string, body interface{}, response interface{}) (bool, error) {
+
+	errContext := "(store::credentials::vault::client::request)"
+
+	token, err := c.getToken()
+	if err != nil {
+		return false, errors.New(errContext, "", err)
+	}
+
+	return c.do(method, path, token, body, response)
+}
+
+// getToken returns the Vault token, logging in through the authenticator when there is no token yet
+func (c *Client) getToken() (string, error) {
+
+	errContext := "(store::credentials::vault::client::getToken)"
+
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	if c.authenticator == nil {
+		return "", errors.New(errContext, "To request Vault, an authenticator must be provided")
+	}
+
+	token, err := c.authenticator.Token(c)
+	if err != nil {
+		return "", errors.New(errContext, "", err)
+	}
+	c.token = token
+
+	return c.token, nil
+}
+
+// do sends a request to the Vault API and decodes its response. It returns false when the resource is not found
+func (c *Client) do(method, path, token string, body interface{}, response interface{}) (bool, error) {
+
+	var reader io.Reader
+
+	errContext := "(store::credentials::vault::client::do)"
+
+	if c.address == "" {
+		return false, errors.New(errContext, "To request Vault, an address must be provided")
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return false, errors.New(errContext, "Error encoding Vault request", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", c.address, path), reader)
+	if err != nil {
+		return false, errors.New(errContext, "Error creating Vault request", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if token != "" {
+		req.Header.Set(tokenHeader, token)
+	}
+
+	if c.namespace != "" {
+		req.Header.Set(namespaceHeader, c.namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, errors.New(errContext, fmt.Sprintf("Error requesting '%s %s' to Vault", method, path), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		vaultErr := &errorResponse{}
+		_ = json.NewDecoder(resp.Body).Decode(vaultErr)
+
+		return false, errors.New(errContext, fmt.Sprintf("Vault responded '%s' to '%s %s'. %s", resp.Status, method, path, strings.Join(vaultErr.Errors, ", ")))
+	}
+
+	if response == nil || resp.StatusCode == http.StatusNoContent {
+		return true, nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return false, errors.New(errContext, "Error decoding Vault response", err)
+	}
+
+	return true, nil
+}
+
+// kvPath returns the KV version 2 API path for the secret path on the mount, where the section is either 'data' or 'metadata'
+func kvPath(mount, section, path string) string {
+	return strings.Join([]string{strings.Trim(mount, "/"), section, strings.Trim(path, "/")}, "/")
+}
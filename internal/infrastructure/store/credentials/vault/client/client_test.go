@@ -0,0 +1,209 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testToken    = "s.token"
+	testRoleID   = "role-id"
+	testSecretID = "secret-id"
+	testJWTRole  = "ci"
+	testJWT      = "eyJhbGciOiJSUzI1NiJ9.payload.signature"
+)
+
+// testVault is an in-memory stand-in of the Vault API, which serves the approle and jwt auth methods and a KV version 2 secrets engine mounted on 'secret'
+type testVault struct {
+	mutex   sync.Mutex
+	secrets map[string]map[string]interface{}
+}
+
+func newTestVault() *testVault {
+	return &testVault{
+		secrets: map[string]map[string]interface{}{},
+	}
+}
+
+func (v *testVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+
+	switch path {
+	case "auth/approle/login":
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != testRoleID || body["secret_id"] != testSecretID {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"invalid role or secret ID"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]string{"client_token": testToken}})
+		return
+
+	case "auth/jwt/login":
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role"] != testJWTRole || body["jwt"] != testJWT {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"invalid jwt"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]string{"client_token": testToken}})
+		return
+	}
+
+	if r.Header.Get(tokenHeader) != testToken {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"permission denied"}})
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(path, "secret/data/"):
+		key := strings.TrimPrefix(path, "secret/data/")
+
+		switch r.Method {
+		case http.MethodGet:
+			data, exists := v.secrets[key]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": data}})
+
+		case http.MethodPost:
+			body := map[string]map[string]interface{}{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			v.secrets[key] = body["data"]
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": 1}})
+		}
+
+	case strings.HasPrefix(path, "secret/metadata/") && r.Method == http.MethodDelete:
+		delete(v.secrets, strings.TrimPrefix(path, "secret/metadata/"))
+		w.WriteHeader(http.StatusNoContent)
+
+	case strings.HasPrefix(path, "secret/metadata/") && r.Method == listMethod:
+		prefix := strings.TrimPrefix(path, "secret/metadata/") + "/"
+		keys := []string{}
+		for key := range v.secrets {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, strings.TrimPrefix(key, prefix))
+			}
+		}
+		if len(keys) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sort.Strings(keys)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClient(t *testing.T) {
+
+	server := httptest.NewServer(newTestVault())
+	defer server.Close()
+
+	jwtFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(jwtFs, "/var/run/secrets/jwt", []byte(testJWT+"\n"), 0600)
+
+	tests := []struct {
+		desc   string
+		client *Client
+		err    error
+	}{
+		{
+			desc: "Testing write, read, list and delete secrets authenticating with token auth method",
+			client: NewClient(
+				WithAddress(server.URL+"/"),
+				WithAuthenticator(NewTokenAuth(testToken)),
+			),
+		},
+		{
+			desc: "Testing write, read, list and delete secrets authenticating with approle auth method",
+			client: NewClient(
+				WithAddress(server.URL),
+				WithAuthenticator(NewAppRoleAuth("approle", testRoleID, testSecretID)),
+			),
+		},
+		{
+			desc: "Testing write, read, list and delete secrets authenticating with jwt auth method reading the JWT from a file",
+			client: NewClient(
+				WithAddress(server.URL),
+				WithAuthenticator(NewJWTAuth(jwtFs, "jwt", testJWTRole, "", "/var/run/secrets/jwt")),
+			),
+		},
+		{
+			desc: "Testing error writing secrets authenticating with an invalid token",
+			client: NewClient(
+				WithAddress(server.URL),
+				WithAuthenticator(NewTokenAuth("invalid")),
+			),
+			err: errors.New("(store::credentials::vault::client::WriteSecret)", "Error writing secret 'stevedore/id' on 'secret'",
+				errors.New("(store::credentials::vault::client::do)", "Vault responded '403 Forbidden' to 'POST secret/data/stevedore/id'. permission denied")),
+		},
+		{
+			desc: "Testing error writing secrets authenticating with invalid approle credentials",
+			client: NewClient(
+				WithAddress(server.URL),
+				WithAuthenticator(NewAppRoleAuth("approle", testRoleID, "invalid")),
+			),
+			err: errors.New("(store::credentials::vault::client::WriteSecret)", "Error writing secret 'stevedore/id' on 'secret'",
+				errors.New("(store::credentials::vault::client::Login)", "Error logging in to Vault through 'approle' auth method",
+					errors.New("(store::credentials::vault::client::do)", "Vault responded '400 Bad Request' to 'POST auth/approle/login'. invalid role or secret ID"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			data := map[string]interface{}{"credential": "content"}
+
+			err := test.client.WriteSecret("secret", "stevedore/id", data)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+
+			res, err := test.client.ReadSecret("secret", "stevedore/id")
+			assert.NoError(t, err)
+			assert.Equal(t, data, res)
+
+			res, err = test.client.ReadSecret("secret", "stevedore/unknown")
+			assert.NoError(t, err)
+			assert.Nil(t, res)
+
+			keys, err := test.client.ListSecrets("secret", "stevedore")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"id"}, keys)
+
+			keys, err = test.client.ListSecrets("secret", "unknown")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{}, keys)
+
+			err = test.client.DeleteSecret("secret", "stevedore/id")
+			assert.NoError(t, err)
+
+			res, err = test.client.ReadSecret("secret", "stevedore/id")
+			assert.NoError(t, err)
+			assert.Nil(t, res)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+import "net/http"
+
+// HTTPDoer is the interface of the HTTP client used to request Vault
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Authenticator is the interface of the Vault auth methods that provide the Vault token
+type Authenticator interface {
+	Token(login Loginer) (string, error)
+}
+
+// Loginer is the interface to log in to a Vault auth method
+type Loginer interface {
+	Login(mount string, data map[string]interface{}) (string, error)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClient is a mock of the Vault client
+type MockClient struct {
+	mock.Mock
+}
+
+// NewMockClient returns a new MockClient
+func NewMockClient() *MockClient {
+	return &MockClient{}
+}
+
+// ReadSecret provides a mock function with given fields: mount, path
+func (c *MockClient) ReadSecret(mount, path string) (map[string]interface{}, error) {
+	args := c.Called(mount, path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+// WriteSecret provides a mock function with given fields: mount, path, data
+func (c *MockClient) WriteSecret(mount, path string, data map[string]interface{}) error {
+	args := c.Called(mount, path, data)
+	return args.Error(0)
+}
+
+// ListSecrets provides a mock function with given fields: mount, path
+func (c *MockClient) ListSecrets(mount, path string) ([]string, error) {
+	args := c.Called(mount, path)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// DeleteSecret provides a mock function with given fields: mount, path
+func (c *MockClient) DeleteSecret(mount, path string) error {
+	args := c.Called(mount, path)
+	return args.Error(0)
+}
@@ -0,0 +1,9 @@
+package vault
+
+// VaultClienter is the interface of the Vault client for the KV version 2 secrets engine
+type VaultClienter interface {
+	ReadSecret(mount, path string) (map[string]interface{}, error)
+	WriteSecret(mount, path string, data map[string]interface{}) error
+	ListSecrets(mount, path string) ([]string, error)
+	DeleteSecret(mount, path string) error
+}
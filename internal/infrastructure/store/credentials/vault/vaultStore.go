@@ -0,0 +1,313 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/core/ports/repository"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+)
+
+const (
+	// credentialDataKey is the secret data key that holds the formatted credential
+	credentialDataKey = "credential"
+)
+
+// OptionsFunc defines the signature for an option function to set Vault credentials store
+type OptionsFunc func(opts *VaultStore)
+
+// VaultStore is a credentials store that keeps each credential as a secret on a HashiCorp Vault KV version 2 secrets engine. The secrets are stored under the path, named after the hashed credentials id, and their 'credential' key holds the credential formatted by the formater
+type VaultStore struct {
+	client   VaultClienter
+	formater repository.Formater
+	mount    string
+	path     string
+}
+
+// NewVaultStore creates a new Vault credentials store
+func NewVaultStore(opts ...OptionsFunc) *VaultStore {
+	store := &VaultStore{}
+	store.Options(opts...)
+
+	return store
+}
+
+// WithClient sets the Vault client
+func WithClient(client VaultClienter) OptionsFunc {
+	return func(s *VaultStore) {
+		s.client = client
+	}
+}
+
+// WithFormater sets the formater used to marshal the credentials
+func WithFormater(formater repository.Formater) OptionsFunc {
+	return func(s *VaultStore) {
+		s.formater = formater
+	}
+}
+
+// WithMount sets the mount of the KV version 2 secrets engine
+func WithMount(mount string) OptionsFunc {
+	return func(s *VaultStore) {
+		s.mount = strings.Trim(mount, "/")
+	}
+}
+
+// WithPath sets the path where the credentials are stored on the secrets engine
+func WithPath(path string) OptionsFunc {
+	return func(s *VaultStore) {
+		s.path = strings.Trim(path, "/")
+	}
+}
+
+// Options provides the options to Vault credentials store
+func (s *VaultStore) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Store stores a credential
+func (s *VaultStore) Store(id string, credential *credentials.Credential) error {
+
+	errContext := "(store::credentials::vault::Store)"
+
+	if s.client == nil {
+		return errors.New(errContext, "Vault credentials store requires a client to store a credential")
+	}
+
+	if s.formater == nil {
+		return errors.New(errContext, "Vault credentials store requires a formater to store a credential")
+	}
+
+	if id == "" {
+		return errors.New(errContext, "To store a credential into Vault store, id must be provided")
+	}
+
+	if credential == nil {
+		return errors.New(errContext, fmt.Sprintf("To store a credential for '%s' into Vault store, credential must be provided", id))
+	}
+
+	if credential.ID == "" {
+		credential.ID = id
+	}
+
+	hashedID, err := encryption.HashID(id)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error hashing the id '%s'", id), err)
+	}
+
+	formatedCredential, err := s.formater.Marshal(credential)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error formatting '%s' credential before to be persisted on Vault", id), err)
+	}
+
+	err = s.client.WriteSecret(s.mount, s.secretPath(hashedID), map[string]interface{}{
+		credentialDataKey: formatedCredential,
+	})
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error storing '%s' credential on Vault", id), err)
+	}
+
+	return nil
+}
+
+// SafeStore stores a credential, refusing to overwrite an existing one
+func (s *VaultStore) SafeStore(id string, credential *credentials.Credential) error {
+
+	errContext := "(store::credentials::vault::SafeStore)"
+
+	_, err := s.Get(id)
+	if err == nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' already exist", id))
+	}
+
+	if !credentials.IsNotFoundError(err) {
+		return errors.New(errContext, "", err)
+	}
+
+	err = s.Store(id, credential)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// Get returns the credential for the id. It returns a not found error when the credential does not exist
+func (s *VaultStore) Get(id string) (*credentials.Credential, error) {
+
+	errContext := "(store::credentials::vault::Get)"
+
+	if id == "" {
+		return nil, errors.New(errContext, "To get a credential from Vault store, id must be provided")
+	}
+
+	hashedID, err := encryption.HashID(id)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error hashing the id '%s'", id), err)
+	}
+
+	credential, err := s.get(hashedID)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error getting '%s' credential from Vault", id), err)
+	}
+
+	if credential == nil {
+		return nil, credentials.NewNotFoundError(id)
+	}
+
+	return credential, nil
+}
+
+// Delete deletes the credential for the id, along with all its secret versions
+func (s *VaultStore) Delete(id string) error {
+
+	errContext := "(store::credentials::vault::Delete)"
+
+	if s.client == nil {
+		return errors.New(errContext, "Vault credentials store requires a client to delete a credential")
+	}
+
+	if id == "" {
+		return errors.New(errContext, "To delete a credential from Vault store, id must be provided")
+	}
+
+	hashedID, err := encryption.HashID(id)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error hashing the id '%s'", id), err)
+	}
+
+	credential, err := s.get(hashedID)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	if credential == nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' does not exist", id))
+	}
+
+	err = s.client.DeleteSecret(s.mount, s.secretPath(hashedID))
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error deleting '%s' credential from Vault", id), err)
+	}
+
+	return nil
+}
+
+// Rename moves the credential for the id to the new id. It fails when a credential already exists for the new id
+func (s *VaultStore) Rename(id, newID string) error {
+
+	errContext := "(store::credentials::vault::Rename)"
+
+	if id == "" || newID == "" {
+		return errors.New(errContext, "To rename a credential on Vault store, both the id and the new id must be provided")
+	}
+
+	credential, err := s.Get(id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	_, err = s.Get(newID)
+	if err == nil {
+		return errors.New(errContext, fmt.Sprintf("Credentials '%s' already exist", newID))
+	}
+
+	if !credentials.IsNotFoundError(err) {
+		return errors.New(errContext, "", err)
+	}
+
+	credential.ID = newID
+	err = s.Store(newID, credential)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	err = s.Delete(id)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// All returns all the credentials stored under the path
+func (s *VaultStore) All() ([]*credentials.Credential, error) {
+
+	errContext := "(store::credentials::vault::All)"
+
+	if s.client == nil {
+		return nil, errors.New(errContext, "Vault credentials store requires a client to list credentials")
+	}
+
+	keys, err := s.client.ListSecrets(s.mount, s.path)
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	list := []*credentials.Credential{}
+	for _, key := range keys {
+		// keys ending with a slash are folders, which are not created by the store
+		if strings.HasSuffix(key, "/") {
+			continue
+		}
+
+		credential, err := s.get(key)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		if credential != nil {
+			list = append(list, credential)
+		}
+	}
+
+	return list, nil
+}
+
+// get returns the credential stored on the secret named after the hashed id
+func (s *VaultStore) get(hashedID string) (*credentials.Credential, error) {
+
+	errContext := "(store::credentials::vault::get)"
+
+	if s.client == nil {
+		return nil, errors.New(errContext, "Vault credentials store requires a client to get a credential")
+	}
+
+	if s.formater == nil {
+		return nil, errors.New(errContext, "Vault credentials store requires a formater to get a credential")
+	}
+
+	data, err := s.client.ReadSecret(s.mount, s.secretPath(hashedID))
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	formatedCredential, isString := data[credentialDataKey].(string)
+	if !isString {
+		return nil, errors.New(errContext, fmt.Sprintf("Secret '%s' does not contain a '%s' key", s.secretPath(hashedID), credentialDataKey))
+	}
+
+	credential, err := s.formater.Unmarshal([]byte(formatedCredential))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error unmarshaling secret '%s'", s.secretPath(hashedID)), err)
+	}
+
+	return credential, nil
+}
+
+// secretPath returns the path of the secret named after the key
+func (s *VaultStore) secretPath(key string) string {
+	if s.path == "" {
+		return key
+	}
+
+	return strings.Join([]string{s.path, key}, "/")
+}
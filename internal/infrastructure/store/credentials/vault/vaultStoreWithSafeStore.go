@@ -0,0 +1,37 @@
+package vault
+
+import (
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+)
+
+// VaultStoreWithSafeStore is a Vault store for credentials that refuses to overwrite the existing ones
+type VaultStoreWithSafeStore struct {
+	store *VaultStore
+}
+
+// NewVaultStoreWithSafeStore creates a new Vault store for credentials that refuses to overwrite the existing ones
+func NewVaultStoreWithSafeStore(opts ...OptionsFunc) *VaultStoreWithSafeStore {
+	s := &VaultStoreWithSafeStore{
+		NewVaultStore(opts...),
+	}
+
+	return s
+}
+
+// Store persists the credential, refusing to overwrite an existing one
+func (s *VaultStoreWithSafeStore) Store(id string, credential *credentials.Credential) error {
+	errContext := "(store::credentials::vault::VaultStoreWithSafeStore)"
+
+	err := s.store.SafeStore(id, credential)
+	if err != nil {
+		return errors.New(errContext, "", err)
+	}
+
+	return nil
+}
+
+// Get returns the credential for the id from the Vault store
+func (s *VaultStoreWithSafeStore) Get(id string) (*credentials.Credential, error) {
+	return s.store.Get(id)
+}
@@ -0,0 +1,110 @@
+package vault
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/json"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_VaultStoreWithSafeStore(t *testing.T) {
+	errContext := "(store::credentials::vault::VaultStoreWithSafeStore)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	formated, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	})
+
+	tests := []struct {
+		desc              string
+		store             *VaultStoreWithSafeStore
+		id                string
+		credential        *credentials.Credential
+		prepareAssertFunc func(*VaultStoreWithSafeStore)
+		assertFunc        func(*testing.T, *VaultStoreWithSafeStore)
+		err               error
+	}{
+		{
+			desc: "Testing error persisting a credential into Vault store that already exist with safe store",
+			store: NewVaultStoreWithSafeStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStoreWithSafeStore) {
+				s.store.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(map[string]interface{}{
+					"credential": formated,
+				}, nil)
+			},
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::vault::SafeStore)", "Credentials 'registry.example.com' already exist")),
+		},
+		{
+			desc: "Testing error persisting a credential into Vault store with safe store when the existing credential can not be read",
+			store: NewVaultStoreWithSafeStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStoreWithSafeStore) {
+				s.store.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(map[string]interface{}{
+					"username": "username",
+				}, nil)
+			},
+			err: errors.New(errContext, "",
+				errors.New("(store::credentials::vault::SafeStore)", "",
+					errors.New("(store::credentials::vault::Get)", "Error getting 'registry.example.com' credential from Vault",
+						errors.New("(store::credentials::vault::get)", "Secret 'stevedore/"+hashedID+"' does not contain a 'credential' key")))),
+		},
+		{
+			desc: "Testing persist a credential into Vault store with safe store",
+			store: NewVaultStoreWithSafeStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			credential: &credentials.Credential{
+				Username: "username",
+				Password: "password",
+			},
+			prepareAssertFunc: func(s *VaultStoreWithSafeStore) {
+				s.store.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(nil, nil)
+				s.store.client.(*client.MockClient).On("WriteSecret", "secret", "stevedore/"+hashedID, map[string]interface{}{
+					"credential": formated,
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, s *VaultStoreWithSafeStore) {
+				s.store.client.(*client.MockClient).AssertExpectations(t)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Store(test.id, test.credential)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.store)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package vault
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/credentials"
+	"github.com/gostevedore/stevedore/internal/infrastructure/format/credentials/json"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/encryption"
+	"github.com/gostevedore/stevedore/internal/infrastructure/store/credentials/vault/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	errContext := "(store::credentials::vault::Store)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+
+	tests := []struct {
+		desc              string
+		store             *VaultStore
+		id                string
+		credential        *credentials.Credential
+		prepareAssertFunc func(*VaultStore)
+		assertFunc        func(*testing.T, *VaultStore)
+		err               error
+	}{
+		{
+			desc:  "Testing error storing a credential without client",
+			store: NewVaultStore(),
+			id:    "registry.example.com",
+			err:   errors.New(errContext, "Vault credentials store requires a client to store a credential"),
+		},
+		{
+			desc: "Testing error storing a credential without id",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+			),
+			err: errors.New(errContext, "To store a credential into Vault store, id must be provided"),
+		},
+		{
+			desc: "Testing store a credential",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("/secret/"),
+				WithPath("stevedore/"),
+			),
+			id: "registry.example.com",
+			credential: &credentials.Credential{
+				Username: "username",
+				Password: "password",
+			},
+			prepareAssertFunc: func(s *VaultStore) {
+				formated, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+					ID:       "registry.example.com",
+					Username: "username",
+					Password: "password",
+				})
+				s.client.(*client.MockClient).On("WriteSecret", "secret", "stevedore/"+hashedID, map[string]interface{}{
+					"credential": formated,
+				}).Return(nil)
+			},
+			assertFunc: func(t *testing.T, s *VaultStore) {
+				s.client.(*client.MockClient).AssertExpectations(t)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Store(test.id, test.credential)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.assertFunc(t, test.store)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	errContext := "(store::credentials::vault::Get)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	formated, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	})
+
+	tests := []struct {
+		desc              string
+		store             *VaultStore
+		id                string
+		prepareAssertFunc func(*VaultStore)
+		res               *credentials.Credential
+		err               error
+	}{
+		{
+			desc:  "Testing error getting a credential without id",
+			store: NewVaultStore(),
+			err:   errors.New(errContext, "To get a credential from Vault store, id must be provided"),
+		},
+		{
+			desc: "Testing get a credential",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(map[string]interface{}{
+					"credential": formated,
+				}, nil)
+			},
+			res: &credentials.Credential{
+				ID:       "registry.example.com",
+				Username: "username",
+				Password: "password",
+			},
+		},
+		{
+			desc: "Testing error getting a credential that does not exist",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(nil, nil)
+			},
+			err: credentials.NewNotFoundError("registry.example.com"),
+		},
+		{
+			desc: "Testing error getting a credential from a secret without credential key",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", hashedID).Return(map[string]interface{}{
+					"username": "username",
+				}, nil)
+			},
+			err: errors.New(errContext, "Error getting 'registry.example.com' credential from Vault",
+				errors.New("(store::credentials::vault::get)", "Secret '"+hashedID+"' does not contain a 'credential' key")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			res, err := test.store.Get(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errContext := "(store::credentials::vault::Delete)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	formated, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	})
+
+	tests := []struct {
+		desc              string
+		store             *VaultStore
+		id                string
+		prepareAssertFunc func(*VaultStore)
+		err               error
+	}{
+		{
+			desc:  "Testing error deleting a credential without client",
+			store: NewVaultStore(),
+			id:    "registry.example.com",
+			err:   errors.New(errContext, "Vault credentials store requires a client to delete a credential"),
+		},
+		{
+			desc: "Testing delete a credential",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(map[string]interface{}{
+					"credential": formated,
+				}, nil)
+				s.client.(*client.MockClient).On("DeleteSecret", "secret", "stevedore/"+hashedID).Return(nil)
+			},
+		},
+		{
+			desc: "Testing error deleting a credential that does not exist",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id: "registry.example.com",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(nil, nil)
+			},
+			err: errors.New(errContext, "Credentials 'registry.example.com' does not exist"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Delete(test.id)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.store.client.(*client.MockClient).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	errContext := "(store::credentials::vault::Rename)"
+
+	hashedID, _ := encryption.HashID("registry.example.com")
+	newHashedID, _ := encryption.HashID("registry.example.org")
+	formated, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	})
+	renamed, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:       "registry.example.org",
+		Username: "username",
+		Password: "password",
+	})
+
+	tests := []struct {
+		desc              string
+		store             *VaultStore
+		id                string
+		newID             string
+		prepareAssertFunc func(*VaultStore)
+		err               error
+	}{
+		{
+			desc:  "Testing error renaming a credential without new id",
+			store: NewVaultStore(),
+			id:    "registry.example.com",
+			err:   errors.New(errContext, "To rename a credential on Vault store, both the id and the new id must be provided"),
+		},
+		{
+			desc: "Testing rename a credential",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id:    "registry.example.com",
+			newID: "registry.example.org",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(map[string]interface{}{
+					"credential": formated,
+				}, nil)
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+newHashedID).Return(nil, nil)
+				s.client.(*client.MockClient).On("WriteSecret", "secret", "stevedore/"+newHashedID, map[string]interface{}{
+					"credential": renamed,
+				}).Return(nil)
+				s.client.(*client.MockClient).On("DeleteSecret", "secret", "stevedore/"+hashedID).Return(nil)
+			},
+		},
+		{
+			desc: "Testing error renaming a credential to an existing id",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			id:    "registry.example.com",
+			newID: "registry.example.org",
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+hashedID).Return(map[string]interface{}{
+					"credential": formated,
+				}, nil)
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/"+newHashedID).Return(map[string]interface{}{
+					"credential": renamed,
+				}, nil)
+			},
+			err: errors.New(errContext, "Credentials 'registry.example.org' already exist"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			err := test.store.Rename(test.id, test.newID)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				test.store.client.(*client.MockClient).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	formatedRegistry, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:       "registry.example.com",
+		Username: "username",
+		Password: "password",
+	})
+	formatedECR, _ := json.NewJSONFormater().Marshal(&credentials.Credential{
+		ID:                 "*.dkr.ecr.eu-west-1.amazonaws.com",
+		AWSAccessKeyID:     "key",
+		AWSSecretAccessKey: "secret",
+	})
+
+	tests := []struct {
+		desc              string
+		store             *VaultStore
+		prepareAssertFunc func(*VaultStore)
+		res               []*credentials.Credential
+		err               error
+	}{
+		{
+			desc: "Testing list all credentials",
+			store: NewVaultStore(
+				WithClient(client.NewMockClient()),
+				WithFormater(json.NewJSONFormater()),
+				WithMount("secret"),
+				WithPath("stevedore"),
+			),
+			prepareAssertFunc: func(s *VaultStore) {
+				s.client.(*client.MockClient).On("ListSecrets", "secret", "stevedore").Return([]string{"a", "b", "folder/"}, nil)
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/a").Return(map[string]interface{}{"credential": formatedRegistry}, nil)
+				s.client.(*client.MockClient).On("ReadSecret", "secret", "stevedore/b").Return(map[string]interface{}{"credential": formatedECR}, nil)
+			},
+			res: []*credentials.Credential{
+				{
+					ID:       "registry.example.com",
+					Username: "username",
+					Password: "password",
+				},
+				{
+					ID:                 "*.dkr.ecr.eu-west-1.amazonaws.com",
+					AWSAccessKeyID:     "key",
+					AWSSecretAccessKey: "secret",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.store)
+			}
+
+			res, err := test.store.All()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// imagesKey is the key where images are defined
+	imagesKey = "images"
+	// deprecatedImagesTreeKey is the deprecated key where images are defined
+	deprecatedImagesTreeKey = "images_tree"
+	// parentsKey is the image key that refers to its parents
+	parentsKey = "parents"
+	// childrenKey is the image key that refers to its children
+	childrenKey = "children"
+)
+
+// imageReference is a reference from an image to its parent or child
+type imageReference struct {
+	file     string
+	node     *yaml.Node
+	path     string
+	relation string
+	from     string
+	to       string
+}
+
+// imagesReferences collects the images defined across all the images files, and the references between them
+type imagesReferences struct {
+	defined    map[string]struct{}
+	references []*imageReference
+}
+
+func newImagesReferences() *imagesReferences {
+	return &imagesReferences{
+		defined:    map[string]struct{}{},
+		references: []*imageReference{},
+	}
+}
+
+// collect collects the images defined on the document node and the parents and children they refer to. The nodes that do not have the expected type are skipped because they are already reported by the schema validation
+func (r *imagesReferences) collect(file string, document *yaml.Node) {
+
+	root := resolve(document)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+
+	for _, imagesEntry := range mappingEntries(root) {
+		if imagesEntry.key.Value != imagesKey && imagesEntry.key.Value != deprecatedImagesTreeKey {
+			continue
+		}
+
+		for _, nameEntry := range mappingEntries(imagesEntry.value) {
+			for _, versionEntry := range mappingEntries(nameEntry.value) {
+				name := nameEntry.key.Value
+				version := versionEntry.key.Value
+				path := strings.Join([]string{imagesEntry.key.Value, name, version}, ".")
+
+				r.defined[imageReferenceName(name, version)] = struct{}{}
+
+				for _, imageEntry := range mappingEntries(versionEntry.value) {
+					relation := imageEntry.key.Value
+					if relation != parentsKey && relation != childrenKey {
+						continue
+					}
+
+					for _, relatedEntry := range mappingEntries(imageEntry.value) {
+						related := resolve(relatedEntry.value)
+						if related == nil || related.Kind != yaml.SequenceNode {
+							continue
+						}
+
+						for i, relatedVersion := range related.Content {
+							relatedVersion = resolve(relatedVersion)
+							if relatedVersion == nil || relatedVersion.Kind != yaml.ScalarNode {
+								continue
+							}
+
+							r.references = append(r.references, &imageReference{
+								file:     file,
+								node:     relatedVersion,
+								path:     fmt.Sprintf("%s.%s.%s[%d]", path, relation, relatedEntry.key.Value, i),
+								relation: relation,
+								from:     imageReferenceName(name, version),
+								to:       imageReferenceName(relatedEntry.key.Value, relatedVersion.Value),
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// orphans returns an issue for each reference to an image that is not defined on any images file
+func (r *imagesReferences) orphans() []*validation.Issue {
+	issues := []*validation.Issue{}
+
+	for _, reference := range r.references {
+		if _, defined := r.defined[reference.to]; defined {
+			continue
+		}
+
+		relation := "Parent"
+		if reference.relation == childrenKey {
+			relation = "Child"
+		}
+
+		issues = append(issues, &validation.Issue{
+			File:     reference.file,
+			Line:     reference.node.Line,
+			Column:   reference.node.Column,
+			Path:     reference.path,
+			Severity: validation.SeverityError,
+			Message:  fmt.Sprintf("%s image '%s' of '%s' is not defined on any images file", relation, reference.to, reference.from),
+		})
+	}
+
+	return issues
+}
+
+// mappingEntry is a key and value pair of a mapping node
+type mappingEntry struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mappingEntries returns the key and value pairs of a mapping node. It returns no entries when the node is not a mapping
+func mappingEntries(node *yaml.Node) []*mappingEntry {
+	entries := []*mappingEntry{}
+
+	node = resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return entries
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries = append(entries, &mappingEntry{
+			key:   node.Content[i],
+			value: node.Content[i+1],
+		})
+	}
+
+	return entries
+}
+
+// resolve returns the node that a document or alias node refers to
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[0]
+		case yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+
+	return nil
+}
+
+func imageReferenceName(name, version string) string {
+	return strings.Join([]string{name, version}, ":")
+}
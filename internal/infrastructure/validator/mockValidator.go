@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockValidator is a mock of the files validator
+type MockValidator struct {
+	mock.Mock
+}
+
+// NewMockValidator returns a mock of the files validator
+func NewMockValidator() *MockValidator {
+	return &MockValidator{}
+}
+
+// Validate provides a mock function with given fields: sources
+func (v *MockValidator) Validate(sources []*validation.Source) ([]*validation.Issue, error) {
+	args := v.Called(sources)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*validation.Issue), args.Error(1)
+}
@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/builder"
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration/builders"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration/images"
+	"github.com/gostevedore/stevedore/internal/infrastructure/configuration/images/image"
+	"github.com/gostevedore/stevedore/internal/infrastructure/jsonschema"
+)
+
+const (
+	// SchemasBaseURL is the location where the schemas are published
+	SchemasBaseURL = "https://raw.githubusercontent.com/gostevedore/stevedore/main/schemas"
+
+	// ConfigurationSchemaFile is the published schema file for the stevedore configuration
+	ConfigurationSchemaFile = "stevedore.schema.json"
+	// BuildersSchemaFile is the published schema file for the builders files
+	BuildersSchemaFile = "builders.schema.json"
+	// ImagesSchemaFile is the published schema file for the images files
+	ImagesSchemaFile = "images.schema.json"
+
+	// imageNamePattern is the pattern that image names must match, which can not contain ':'
+	imageNamePattern = "^[^:]+$"
+	// imageVersionPattern is the pattern that image versions must match, which can not contain ':'
+	imageVersionPattern = "^[^:]+$"
+)
+
+// SchemaFiles returns the published schema file for each kind of definitions
+func SchemaFiles() map[string]string {
+	return map[string]string{
+		validation.ConfigurationKind: ConfigurationSchemaFile,
+		validation.BuildersKind:      BuildersSchemaFile,
+		validation.ImagesKind:        ImagesSchemaFile,
+	}
+}
+
+// Schema returns the schema of a kind of definitions
+func Schema(kind string) (*jsonschema.Schema, error) {
+
+	errContext := "(validator::Schema)"
+
+	switch kind {
+	case validation.ConfigurationKind:
+		return ConfigurationSchema(), nil
+	case validation.BuildersKind:
+		return BuildersSchema(), nil
+	case validation.ImagesKind:
+		return ImagesSchema(), nil
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Unknown kind of definitions '%s'", kind))
+	}
+}
+
+// ConfigurationSchema returns the schema of the stevedore configuration
+func ConfigurationSchema() *jsonschema.Schema {
+	reflector := newConfigurationReflector()
+	schema := reflector.Reflect(&configuration.Configuration{})
+	schema.Schema = jsonschema.Draft
+	schema.ID = fmt.Sprintf("%s/%s", SchemasBaseURL, ConfigurationSchemaFile)
+	schema.Title = "Stevedore configuration"
+
+	// profiles are named sets of configuration values that override the configuration
+	schema.Properties[configuration.ProfilesKey] = &jsonschema.Schema{
+		Description:          fmt.Sprintf("Named configuration profiles, selected by '--profile' flag or '%s' environment variable, whose values override the configuration", configuration.ProfileEnv),
+		Type:                 jsonschema.TypeObject,
+		AdditionalProperties: reflector.Reflect(&configuration.Configuration{}),
+	}
+
+	return schema
+}
+
+// BuildersSchema returns the schema of the builders files
+func BuildersSchema() *jsonschema.Schema {
+	schema := newDefinitionsReflector().Reflect(&builders.Builders{})
+	schema.Schema = jsonschema.Draft
+	schema.ID = fmt.Sprintf("%s/%s", SchemasBaseURL, BuildersSchemaFile)
+	schema.Title = "Stevedore builders"
+
+	return schema
+}
+
+// ImagesSchema returns the schema of the images files
+func ImagesSchema() *jsonschema.Schema {
+	schema := newDefinitionsReflector().Reflect(&images.ImagesConfiguration{})
+	schema.Schema = jsonschema.Draft
+	schema.ID = fmt.Sprintf("%s/%s", SchemasBaseURL, ImagesSchemaFile)
+	schema.Title = "Stevedore images"
+
+	// images are defined by name and version, and neither of them can contain ':'
+	for _, property := range schema.Properties {
+		property.PropertyNames = &jsonschema.Schema{
+			Title:   "image name",
+			Pattern: imageNamePattern,
+		}
+
+		if property.AdditionalProperties != nil {
+			property.AdditionalProperties.PropertyNames = &jsonschema.Schema{
+				Title:   "image version",
+				Pattern: imageVersionPattern,
+			}
+		}
+	}
+
+	return schema
+}
+
+// newConfigurationReflector returns a reflector that describes the stevedore configuration, whose images and builders paths accept several types
+func newConfigurationReflector() *jsonschema.Reflector {
+
+	reflector := jsonschema.NewReflector()
+
+	// images and builders paths are either a path or a list of paths and git repositories
+	source := reflector.Reflect(&configuration.DefinitionsSource{})
+	definitionsSources := &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			{
+				Type: jsonschema.TypeString,
+			},
+			{
+				Type: jsonschema.TypeArray,
+				Items: &jsonschema.Schema{
+					AnyOf: []*jsonschema.Schema{
+						{
+							Type: jsonschema.TypeString,
+						},
+						source,
+					},
+				},
+			},
+		},
+	}
+	reflector.Options(
+		jsonschema.WithFieldSchema(&configuration.Configuration{}, "BuildersPath", definitionsSources),
+		jsonschema.WithFieldSchema(&configuration.Configuration{}, "ImagesPath", definitionsSources),
+	)
+
+	return reflector
+}
+
+// newDefinitionsReflector returns a reflector that describes the builders and images definitions, whose interface{} fields accept several types
+func newDefinitionsReflector() *jsonschema.Reflector {
+
+	reflector := jsonschema.NewReflector()
+
+	// docker driver context is either a context or a list of contexts
+	context := reflector.Reflect(&builder.DockerDriverContextOptions{})
+	reflector.Options(
+		jsonschema.WithFieldSchema(&builder.BuilderOptions{}, "Context", &jsonschema.Schema{
+			AnyOf: []*jsonschema.Schema{
+				context,
+				{
+					Type:  jsonschema.TypeArray,
+					Items: context,
+				},
+			},
+		}),
+	)
+
+	// image builder is either the name of a builder or an in-line builder definition
+	inlineBuilder := reflector.Reflect(&builder.Builder{})
+	reflector.Options(
+		jsonschema.WithFieldSchema(&image.Image{}, "Builder", &jsonschema.Schema{
+			AnyOf: []*jsonschema.Schema{
+				{
+					Type: jsonschema.TypeString,
+				},
+				inlineBuilder,
+			},
+		}),
+	)
+
+	return reflector
+}
@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// publishedSchemasDir is the folder where the schemas are published
+const publishedSchemasDir = "../../../schemas"
+
+var updateSchemas = flag.Bool("update-schemas", false, "Update the published schemas")
+
+// TestPublishedSchemas ensures that the published schemas are generated from the current definitions. Run 'make schemas' to update them
+func TestPublishedSchemas(t *testing.T) {
+
+	for kind, file := range SchemaFiles() {
+		t.Run(kind, func(t *testing.T) {
+			t.Logf("Testing published %s schema", kind)
+
+			schema, err := Schema(kind)
+			assert.NoError(t, err)
+
+			content, err := json.MarshalIndent(schema, "", "  ")
+			assert.NoError(t, err)
+			content = append(content, '\n')
+
+			path := filepath.Join(publishedSchemasDir, file)
+
+			if *updateSchemas {
+				err = os.WriteFile(path, content, 0644)
+				assert.NoError(t, err)
+			}
+
+			published, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, string(published), string(content), "Published schema '%s' is outdated. Run 'make schemas' to update it", path)
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	_, err := Schema("unknown")
+	assert.Equal(t, errors.New("(validator::Schema)", "Unknown kind of definitions 'unknown'"), err)
+}
@@ -0,0 +1,233 @@
+package validator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/gostevedore/stevedore/internal/infrastructure/jsonschema"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlErrorLineRegexp extracts the line from the YAML parsing errors, such as 'yaml: line 3: mapping values are not allowed in this context'
+var yamlErrorLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// OptionsFunc defines the signature for an option function to set validator attributes
+type OptionsFunc func(v *Validator)
+
+// Validator validates the configuration, builders and images files against their schemas. It also validates that the images referred as parents or children are defined
+type Validator struct {
+	fs afero.Fs
+}
+
+// NewValidator returns a new validator
+func NewValidator(opts ...OptionsFunc) *Validator {
+	v := &Validator{}
+	v.Options(opts...)
+
+	return v
+}
+
+// WithFileSystem sets the file system where the files are read from
+func WithFileSystem(fs afero.Fs) OptionsFunc {
+	return func(v *Validator) {
+		v.fs = fs
+	}
+}
+
+// Options provides the options for the validator
+func (v *Validator) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(v)
+	}
+}
+
+// Validate validates the sources and returns the issues found. A file referred by several sources is validated once, against the schemas of all of its kinds of definitions
+func (v *Validator) Validate(sources []*validation.Source) ([]*validation.Issue, error) {
+
+	var err error
+
+	errContext := "(validator::Validate)"
+
+	if v.fs == nil {
+		return nil, errors.New(errContext, "To validate the files, a file system must be provided")
+	}
+
+	issues := []*validation.Issue{}
+	files := []string{}
+	kinds := map[string][]string{}
+	// locations identifies the files by its absolute path, to validate once a file referred by relative and absolute paths
+	locations := map[string]string{}
+
+	for _, source := range sources {
+		var sourceFiles []string
+		var sourceIssues []*validation.Issue
+
+		if source == nil || source.Path == "" {
+			continue
+		}
+
+		sourceFiles, sourceIssues, err = v.sourceFiles(source)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+		issues = append(issues, sourceIssues...)
+
+		for _, sourceFile := range sourceFiles {
+			location, err := filepath.Abs(sourceFile)
+			if err != nil {
+				return nil, errors.New(errContext, "", err)
+			}
+
+			file, exists := locations[location]
+			if !exists {
+				file = sourceFile
+				locations[location] = file
+				files = append(files, file)
+			}
+
+			fileKinds := kinds[file]
+
+			if !contains(fileKinds, source.Kind) {
+				kinds[file] = append(fileKinds, source.Kind)
+			}
+		}
+	}
+
+	references := newImagesReferences()
+
+	for _, file := range files {
+		fileIssues, err := v.validateFile(file, kinds[file], references)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		issues = append(issues, fileIssues...)
+	}
+
+	issues = append(issues, references.orphans()...)
+
+	return issues, nil
+}
+
+// sourceFiles returns the files to validate for a source, which are all the '*.yaml' and '*.yml' files when the source is a folder. A missing configuration file is not an issue because the default configuration is used
+func (v *Validator) sourceFiles(source *validation.Source) ([]string, []*validation.Issue, error) {
+
+	errContext := "(validator::sourceFiles)"
+
+	path := filepath.Clean(source.Path)
+
+	exists, err := afero.Exists(v.fs, path)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	if !exists {
+		if source.Kind == validation.ConfigurationKind {
+			return []string{}, []*validation.Issue{}, nil
+		}
+
+		return []string{}, []*validation.Issue{
+			{
+				File:     path,
+				Severity: validation.SeverityError,
+				Message:  fmt.Sprintf("The %s path '%s' does not exist", source.Kind, path),
+			},
+		}, nil
+	}
+
+	isDir, err := afero.IsDir(v.fs, path)
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	if !isDir {
+		return []string{path}, []*validation.Issue{}, nil
+	}
+
+	yamlFiles, err := afero.Glob(v.fs, filepath.Join(path, "*.yaml"))
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	ymlFiles, err := afero.Glob(v.fs, filepath.Join(path, "*.yml"))
+	if err != nil {
+		return nil, nil, errors.New(errContext, "", err)
+	}
+
+	return append(yamlFiles, ymlFiles...), []*validation.Issue{}, nil
+}
+
+// validateFile validates a file against the schemas of its kinds of definitions. The images defined on the file and the images they refer to are collected to validate the references once all the files are validated
+func (v *Validator) validateFile(file string, kinds []string, references *imagesReferences) ([]*validation.Issue, error) {
+
+	errContext := "(validator::validateFile)"
+
+	data, err := afero.ReadFile(v.fs, file)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("File '%s' could not be read", file), err)
+	}
+
+	node := &yaml.Node{}
+	err = yaml.Unmarshal(data, node)
+	if err != nil {
+		return []*validation.Issue{
+			{
+				File:     file,
+				Line:     yamlErrorLine(err),
+				Severity: validation.SeverityError,
+				Message:  fmt.Sprintf("Invalid YAML. %s", err.Error()),
+			},
+		}, nil
+	}
+
+	schemas := []*jsonschema.Schema{}
+	for _, kind := range kinds {
+		schema, err := Schema(kind)
+		if err != nil {
+			return nil, errors.New(errContext, "", err)
+		}
+
+		schemas = append(schemas, schema)
+
+		if kind == validation.ImagesKind {
+			references.collect(file, node)
+		}
+	}
+
+	issues := jsonschema.Validate(jsonschema.Merge(schemas...), node)
+	for _, issue := range issues {
+		issue.File = file
+	}
+
+	return issues, nil
+}
+
+// yamlErrorLine returns the line where a YAML parsing error is found, or zero when the error does not define it
+func yamlErrorLine(err error) int {
+	match := yamlErrorLineRegexp.FindStringSubmatch(err.Error())
+	if len(match) < 2 {
+		return 0
+	}
+
+	line, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+
+	return line
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
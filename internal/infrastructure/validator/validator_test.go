@@ -0,0 +1,149 @@
+package validator
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/gostevedore/stevedore/internal/core/domain/validation"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	errContext := "(validator::Validate)"
+
+	fs := afero.NewMemMapFs()
+
+	afero.WriteFile(fs, "/stevedore.yaml", []byte(`
+concurrency: 4
+push_images: true
+profiles:
+  ci:
+    concurrency: 2
+    credentials:
+      storage_type: envvars
+builders:
+  infrastructure:
+    driver: docker
+    options:
+      context:
+        path: .
+images:
+  ubuntu:
+    "22.04":
+      builder: infrastructure
+      tags:
+        - latest
+  app:
+    "1.0":
+      builder:
+        driver: docker
+        options:
+          context:
+            - path: app
+      parents:
+        ubuntu:
+          - "22.04"
+`), 0644)
+
+	afero.WriteFile(fs, "/config/stevedore.yaml", []byte(`
+tree_path: /images
+builders_path: /builders
+concurrency: four
+profiles:
+  ci:
+    concurency: 2
+`), 0644)
+
+	afero.WriteFile(fs, "/images/base.yaml", []byte(`
+images:
+  ubuntu:
+    "22.04":
+      persistant_vars:
+        key: value
+`), 0644)
+
+	afero.WriteFile(fs, "/images/apps.yml", []byte(`
+images_tree:
+  app:
+    "1.0":
+      parents:
+        ubuntu:
+          - "22.04"
+          - "20.04"
+  app:v2:
+    "2.0":
+`), 0644)
+
+	afero.WriteFile(fs, "/images/invalid.yaml", []byte(`
+images:
+  ubuntu: [
+`), 0644)
+
+	tests := []struct {
+		desc      string
+		validator *Validator
+		sources   []*validation.Source
+		res       []*validation.Issue
+		err       error
+	}{
+		{
+			desc:      "Testing error validating without file system",
+			validator: NewValidator(),
+			sources:   []*validation.Source{},
+			err:       errors.New(errContext, "To validate the files, a file system must be provided"),
+		},
+		{
+			desc:      "Testing validate a file that defines configuration, builders and images",
+			validator: NewValidator(WithFileSystem(fs)),
+			sources: []*validation.Source{
+				{Path: "/stevedore.yaml", Kind: validation.ConfigurationKind},
+				{Path: "/stevedore.yaml", Kind: validation.BuildersKind},
+				{Path: "/./stevedore.yaml", Kind: validation.ImagesKind},
+			},
+			res: []*validation.Issue{},
+		},
+		{
+			desc:      "Testing validate a missing configuration file",
+			validator: NewValidator(WithFileSystem(fs)),
+			sources: []*validation.Source{
+				{Path: "/missing.yaml", Kind: validation.ConfigurationKind},
+			},
+			res: []*validation.Issue{},
+		},
+		{
+			desc:      "Testing validate files with issues",
+			validator: NewValidator(WithFileSystem(fs)),
+			sources: []*validation.Source{
+				{Path: "/config/stevedore.yaml", Kind: validation.ConfigurationKind},
+				{Path: "/builders", Kind: validation.BuildersKind},
+				{Path: "/images", Kind: validation.ImagesKind},
+			},
+			res: []*validation.Issue{
+				{File: "/builders", Severity: validation.SeverityError, Message: "The builders path '/builders' does not exist"},
+				{File: "/config/stevedore.yaml", Line: 2, Column: 1, Path: "tree_path", Severity: validation.SeverityWarning, Message: "'tree_path' is deprecated, use 'images_path' instead"},
+				{File: "/config/stevedore.yaml", Line: 4, Column: 14, Path: "concurrency", Severity: validation.SeverityError, Message: "Invalid type for 'concurrency', expected integer but found string"},
+				{File: "/config/stevedore.yaml", Line: 7, Column: 5, Path: "profiles.ci.concurency", Severity: validation.SeverityError, Message: "Unknown key 'concurency', did you mean 'concurrency'?"},
+				{File: "/images/base.yaml", Line: 5, Column: 7, Path: "images.ubuntu.22.04.persistant_vars", Severity: validation.SeverityError, Message: "Unknown key 'persistant_vars', did you mean 'persistent_vars'?"},
+				{File: "/images/invalid.yaml", Line: 3, Severity: validation.SeverityError, Message: "Invalid YAML. yaml: line 3: did not find expected node content"},
+				{File: "/images/apps.yml", Line: 2, Column: 1, Path: "images_tree", Severity: validation.SeverityWarning, Message: "'images_tree' is deprecated, use 'images' instead"},
+				{File: "/images/apps.yml", Line: 9, Column: 3, Path: "images_tree.app:v2", Severity: validation.SeverityError, Message: "Invalid image name 'app:v2', it must match the pattern '^[^:]+$'"},
+				{File: "/images/apps.yml", Line: 8, Column: 13, Path: "images_tree.app.1.0.parents.ubuntu[1]", Severity: validation.SeverityError, Message: "Parent image 'ubuntu:20.04' of 'app:1.0' is not defined on any images file"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.validator.Validate(test.sources)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}